@@ -51,6 +51,7 @@ require (
 	golang.org/x/net v0.26.0
 	golang.org/x/oauth2 v0.12.0
 	golang.org/x/sync v0.7.0
+	google.golang.org/api v0.126.0
 	google.golang.org/grpc v1.59.0
 	gopkg.in/ini.v1 v1.62.0
 	gopkg.in/src-d/go-git.v4 v4.13.1
@@ -271,7 +272,6 @@ require (
 	golang.org/x/text v0.16.0 // indirect
 	golang.org/x/time v0.5.0 // indirect
 	golang.org/x/tools v0.21.1-0.20240508182429-e35e4ccd0d2d // indirect
-	google.golang.org/api v0.126.0 // indirect
 	google.golang.org/appengine v1.6.7 // indirect
 	google.golang.org/genproto v0.0.0-20230822172742-b8732ec3820d // indirect
 	google.golang.org/genproto/googleapis/api v0.0.0-20230822172742-b8732ec3820d // indirect
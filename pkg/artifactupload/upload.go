@@ -0,0 +1,276 @@
+package artifactupload
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// UploadURLEnv is the destination for the artifacts, s3://BUCKET/PREFIX or gs://BUCKET/PREFIX.  Credentials
+	// are read by the aws and gcloud CLIs from their usual environment variables and configuration files.
+	UploadURLEnv = "OPENSHIFT_TESTS_ARTIFACT_UPLOAD_URL"
+	// UploadRetriesEnv overrides the number of attempts made to upload the artifacts and the manifest.
+	UploadRetriesEnv = "OPENSHIFT_TESTS_ARTIFACT_UPLOAD_RETRIES"
+
+	ManifestFileName = "artifact-manifest.json"
+
+	defaultMaxTries = 4
+)
+
+// Manifest lists everything uploaded, so consumers can verify they retrieved a complete set of artifacts.  It is
+// only uploaded once every artifact has been, so a missing manifest means the upload did not finish.
+type Manifest struct {
+	Destination string         `json:"destination"`
+	Completed   time.Time      `json:"completed"`
+	Files       []ManifestFile `json:"files"`
+}
+
+// ManifestFile describes an artifact as it was uploaded.  The checksum is of the local file after the upload
+// finished, the CLIs verify their own transfers, so consumers can use it to check what they downloaded.
+type ManifestFile struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// copier copies to a remote URL.
+type copier interface {
+	// Sync copies the directory tree to the remote URL, except for the excluded file at the root of the tree.
+	Sync(ctx context.Context, localDir, remoteURL, excludeFile string) error
+	// Copy copies a single file to the remote URL.
+	Copy(ctx context.Context, localPath, remoteURL string) error
+}
+
+// cliCopier shells out to the cloud provider CLI, which handles credentials, multipart uploads, and endpoints.
+// A whole tree is copied with a single invocation, the CLIs parallelize that far better than we could.
+type cliCopier struct {
+	command  string
+	syncArgs func(localDir, remoteURL, excludeFile string) []string
+	copyArgs []string
+}
+
+func (c *cliCopier) Sync(ctx context.Context, localDir, remoteURL, excludeFile string) error {
+	return c.run(ctx, c.syncArgs(localDir, remoteURL, excludeFile))
+}
+
+func (c *cliCopier) Copy(ctx context.Context, localPath, remoteURL string) error {
+	return c.run(ctx, append(append([]string{}, c.copyArgs...), localPath, remoteURL))
+}
+
+func (c *cliCopier) run(ctx context.Context, args []string) error {
+	out, err := exec.CommandContext(ctx, c.command, args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s %v failed: %w: %s", c.command, args, err, string(out))
+	}
+	return nil
+}
+
+func awsCopier() *cliCopier {
+	return &cliCopier{
+		command: "aws",
+		syncArgs: func(localDir, remoteURL, excludeFile string) []string {
+			return []string{"s3", "sync", "--only-show-errors", "--exclude", excludeFile, localDir, remoteURL}
+		},
+		copyArgs: []string{"s3", "cp", "--only-show-errors"},
+	}
+}
+
+func gcloudCopier() *cliCopier {
+	return &cliCopier{
+		command: "gcloud",
+		syncArgs: func(localDir, remoteURL, excludeFile string) []string {
+			// gcloud excludes by a regular expression on the path relative to the source directory.
+			return []string{"storage", "rsync", "--recursive", "--exclude", "^" + regexp.QuoteMeta(excludeFile) + "$", localDir, remoteURL}
+		},
+		copyArgs: []string{"storage", "cp"},
+	}
+}
+
+// sleeper interface to enable testing without actually sleeping
+type sleeper interface {
+	Sleep(d time.Duration)
+}
+
+type realSleeper struct{}
+
+func (rs *realSleeper) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+type Uploader struct {
+	destination string
+	maxTries    int
+	copier      copier
+	sleeper     sleeper
+}
+
+// NewUploaderFromEnvironment returns nil if no upload destination is configured.
+func NewUploaderFromEnvironment() (*Uploader, error) {
+	destination := os.Getenv(UploadURLEnv)
+	if len(destination) == 0 {
+		return nil, nil
+	}
+
+	maxTries := defaultMaxTries
+	if retries := os.Getenv(UploadRetriesEnv); len(retries) > 0 {
+		var err error
+		if maxTries, err = strconv.Atoi(retries); err != nil || maxTries < 1 {
+			return nil, fmt.Errorf("%s must be a positive integer, got %q", UploadRetriesEnv, retries)
+		}
+	}
+
+	u, err := url.Parse(destination)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", UploadURLEnv, err)
+	}
+	if len(u.Host) == 0 {
+		return nil, fmt.Errorf("%s must include a bucket, got %q", UploadURLEnv, destination)
+	}
+	var c copier
+	switch u.Scheme {
+	case "s3":
+		c = awsCopier()
+	case "gs":
+		c = gcloudCopier()
+	default:
+		return nil, fmt.Errorf("%s must use the s3:// or gs:// scheme, got %q", UploadURLEnv, destination)
+	}
+
+	return &Uploader{
+		destination: destination,
+		maxTries:    maxTries,
+		copier:      c,
+		sleeper:     &realSleeper{},
+	}, nil
+}
+
+// Upload copies the tree under dir to the destination, then writes and uploads the manifest.  Syncing is
+// incremental, so a retry only copies what did not make it the first time.
+func (u *Uploader) Upload(ctx context.Context, dir string) (*Manifest, error) {
+	err := u.withRetries(ctx, u.destination, func() error {
+		return u.copier.Sync(ctx, dir, u.destination, ManifestFileName)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to upload artifacts: %w", err)
+	}
+
+	manifest := &Manifest{Destination: u.destination}
+	err = filepath.Walk(dir, func(localPath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		relativePath, err := filepath.Rel(dir, localPath)
+		if err != nil {
+			return err
+		}
+		relativePath = filepath.ToSlash(relativePath)
+		if relativePath == ManifestFileName {
+			return nil
+		}
+
+		sum, err := sha256File(localPath)
+		if err != nil {
+			return err
+		}
+		manifest.Files = append(manifest.Files, ManifestFile{Path: relativePath, Size: info.Size(), SHA256: sum})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	manifest.Completed = time.Now().UTC()
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	manifestPath := filepath.Join(dir, ManifestFileName)
+	if err := os.WriteFile(manifestPath, manifestBytes, 0644); err != nil {
+		return nil, err
+	}
+	// the manifest is uploaded last, so its presence means the upload finished.
+	manifestURL := u.remoteURL(ManifestFileName)
+	err = u.withRetries(ctx, manifestURL, func() error {
+		return u.copier.Copy(ctx, manifestPath, manifestURL)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to upload artifact manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+func (u *Uploader) remoteURL(relativePath string) string {
+	destination, _ := url.Parse(u.destination)
+	destination.Path = path.Join(destination.Path, relativePath)
+	return destination.String()
+}
+
+func (u *Uploader) withRetries(ctx context.Context, remoteURL string, upload func() error) error {
+	var err error
+	for i := 1; i <= u.maxTries; i++ {
+		if err = upload(); err == nil {
+			return nil
+		}
+		logrus.WithError(err).Warnf("Uploading to %s (attempt %d/%d) failed", remoteURL, i, u.maxTries)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if i < u.maxTries {
+			u.sleeper.Sleep(time.Duration(i) * 5 * time.Second)
+		}
+	}
+	return err
+}
+
+func sha256File(localPath string) (string, error) {
+	f, err := os.Open(localPath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// UploadFromEnvironment uploads dir if an upload destination is configured.  Errors are logged, a failed upload
+// never changes the result of the test run.
+func UploadFromEnvironment(ctx context.Context, dir string) {
+	if len(dir) == 0 {
+		return
+	}
+	uploader, err := NewUploaderFromEnvironment()
+	if err != nil {
+		logrus.WithError(err).Error("Unable to configure artifact upload")
+		return
+	}
+	if uploader == nil {
+		return
+	}
+	logrus.Infof("Uploading artifacts from %s to %s", dir, uploader.destination)
+	manifest, err := uploader.Upload(ctx, dir)
+	if err != nil {
+		logrus.WithError(err).Error("Artifact upload did not complete")
+		return
+	}
+	logrus.Infof("Uploaded %d artifacts to %s", len(manifest.Files), uploader.destination)
+}
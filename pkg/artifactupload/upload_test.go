@@ -0,0 +1,131 @@
+package artifactupload
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCopier struct {
+	// failures is the number of times each remote URL fails before succeeding
+	failures map[string]int
+	synced   []string
+	copied   []string
+}
+
+func (f *fakeCopier) fail(remoteURL string) bool {
+	if f.failures[remoteURL] > 0 {
+		f.failures[remoteURL]--
+		return true
+	}
+	return false
+}
+
+func (f *fakeCopier) Sync(ctx context.Context, localDir, remoteURL, excludeFile string) error {
+	if f.fail(remoteURL) {
+		return fmt.Errorf("transient failure")
+	}
+	f.synced = append(f.synced, remoteURL)
+	return nil
+}
+
+func (f *fakeCopier) Copy(ctx context.Context, localPath, remoteURL string) error {
+	if f.fail(remoteURL) {
+		return fmt.Errorf("transient failure")
+	}
+	f.copied = append(f.copied, remoteURL)
+	return nil
+}
+
+type fakeSleeper struct {
+	slept []time.Duration
+}
+
+func (f *fakeSleeper) Sleep(d time.Duration) {
+	f.slept = append(f.slept, d)
+}
+
+func TestUpload(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "junit"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "junit", "e2e.xml"), []byte("<xml/>"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "e2e-events.json"), []byte("{}"), 0644))
+
+	copier := &fakeCopier{failures: map[string]int{
+		"s3://bucket/run-1": 2,
+	}}
+	sleeper := &fakeSleeper{}
+	uploader := &Uploader{destination: "s3://bucket/run-1", maxTries: 3, copier: copier, sleeper: sleeper}
+
+	manifest, err := uploader.Upload(context.Background(), dir)
+	require.NoError(t, err)
+
+	paths := []string{}
+	for _, file := range manifest.Files {
+		paths = append(paths, file.Path)
+	}
+	sort.Strings(paths)
+	assert.Equal(t, []string{"e2e-events.json", "junit/e2e.xml"}, paths)
+	assert.Equal(t, []string{"s3://bucket/run-1"}, copier.synced)
+	assert.Equal(t, []string{"s3://bucket/run-1/" + ManifestFileName}, copier.copied, "the manifest is uploaded after the artifacts")
+	assert.Len(t, sleeper.slept, 2)
+
+	written := &Manifest{}
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFileName))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, written))
+	assert.Equal(t, "s3://bucket/run-1", written.Destination)
+	assert.Len(t, written.Files, 2)
+}
+
+func TestUploadFailureSkipsManifest(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "e2e-events.json"), []byte("{}"), 0644))
+
+	copier := &fakeCopier{failures: map[string]int{
+		"gs://bucket/run-1": 10,
+	}}
+	uploader := &Uploader{destination: "gs://bucket/run-1", maxTries: 3, copier: copier, sleeper: &fakeSleeper{}}
+
+	_, err := uploader.Upload(context.Background(), dir)
+	assert.Error(t, err)
+	assert.Empty(t, copier.copied, "an incomplete upload must not have a manifest")
+}
+
+func TestCLICopierArgs(t *testing.T) {
+	assert.Equal(t,
+		[]string{"s3", "sync", "--only-show-errors", "--exclude", ManifestFileName, "/artifacts", "s3://bucket/run-1"},
+		awsCopier().syncArgs("/artifacts", "s3://bucket/run-1", ManifestFileName))
+	assert.Equal(t,
+		[]string{"storage", "rsync", "--recursive", "--exclude", `^artifact-manifest\.json$`, "/artifacts", "gs://bucket/run-1"},
+		gcloudCopier().syncArgs("/artifacts", "gs://bucket/run-1", ManifestFileName))
+}
+
+func TestNewUploaderFromEnvironment(t *testing.T) {
+	t.Setenv(UploadURLEnv, "")
+	uploader, err := NewUploaderFromEnvironment()
+	assert.NoError(t, err)
+	assert.Nil(t, uploader)
+
+	t.Setenv(UploadURLEnv, "gs://bucket")
+	uploader, err = NewUploaderFromEnvironment()
+	require.NoError(t, err)
+	assert.Equal(t, "gs://bucket/junit/e2e.xml", uploader.remoteURL("junit/e2e.xml"))
+
+	t.Setenv(UploadURLEnv, "https://bucket/prefix")
+	_, err = NewUploaderFromEnvironment()
+	assert.Error(t, err)
+
+	t.Setenv(UploadURLEnv, "s3://bucket/prefix")
+	t.Setenv(UploadRetriesEnv, "0")
+	_, err = NewUploaderFromEnvironment()
+	assert.Error(t, err)
+}
@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -70,6 +71,10 @@ type ClusterConfiguration struct {
 
 	// IsNoOptionalCapabilities indicates the cluster has no optional capabilities enabled
 	HasNoOptionalCapabilities bool
+
+	// Architectures are the distinct architectures of the nodes, more than one on multi-arch clusters.  It is empty
+	// when the architectures are not known, for instance for a hard-coded provider.
+	Architectures []string `json:",omitempty"`
 }
 
 func (c *ClusterConfiguration) ToJSONString() string {
@@ -187,6 +192,15 @@ func LoadConfig(state *ClusterState) (*ClusterConfiguration, error) {
 		config.HasNoOptionalCapabilities = true
 	}
 
+	architectures := sets.NewString()
+	for _, nodes := range []*corev1.NodeList{state.Masters, state.NonMasters} {
+		for _, node := range nodes.Items {
+			architectures.Insert(node.Status.NodeInfo.Architecture)
+		}
+	}
+	architectures.Delete("")
+	config.Architectures = architectures.List()
+
 	if zones.Len() > 0 {
 		config.Zone = zones.List()[0]
 	}
@@ -272,6 +286,34 @@ func LoadConfig(state *ClusterState) (*ClusterConfiguration, error) {
 	return config, nil
 }
 
+// IsMultiArch returns true when the nodes of the cluster have more than one architecture.
+func (c *ClusterConfiguration) IsMultiArch() bool {
+	return len(c.Architectures) > 1
+}
+
+// ArchitectureSkipReason returns why a test labelled with [Architecture:ARCH] cannot run on the cluster, or empty if
+// it can.  A test may carry the label more than once when it supports several architectures, every node must have one
+// of them since the test does not control where its pods are scheduled.
+func (c *ClusterConfiguration) ArchitectureSkipReason(name string) string {
+	if len(c.Architectures) == 0 {
+		return ""
+	}
+	required := sets.NewString()
+	for _, match := range architectureRegex.FindAllStringSubmatch(name, -1) {
+		required.Insert(match[1])
+	}
+	if required.Len() == 0 {
+		return ""
+	}
+	if unsupported := sets.NewString(c.Architectures...).Difference(required); unsupported.Len() > 0 {
+		return fmt.Sprintf("test requires architecture %s, cluster has nodes of architecture %s",
+			strings.Join(required.List(), " or "), strings.Join(unsupported.List(), ", "))
+	}
+	return ""
+}
+
+var architectureRegex = regexp.MustCompile(`\[Architecture:([^]]*)\]`)
+
 // MatchFn returns a function that tests if a named function should be run based on
 // the cluster configuration
 func (c *ClusterConfiguration) MatchFn() func(string) bool {
@@ -340,3 +340,20 @@ func TestDecodeProvider(t *testing.T) {
 		})
 	}
 }
+
+func TestArchitectureSkipReason(t *testing.T) {
+	arm64 := &ClusterConfiguration{Architectures: []string{"arm64"}}
+	multiArch := &ClusterConfiguration{Architectures: []string{"amd64", "arm64"}}
+	unknown := &ClusterConfiguration{}
+
+	require.Empty(t, arm64.ArchitectureSkipReason("[sig-node] pods should run"))
+	require.Empty(t, arm64.ArchitectureSkipReason("[sig-node] pods should run [Architecture:arm64]"))
+	require.Equal(t, "test requires architecture amd64, cluster has nodes of architecture arm64",
+		arm64.ArchitectureSkipReason("[sig-node] pods should run [Architecture:amd64]"))
+	require.Empty(t, multiArch.ArchitectureSkipReason("[sig-node] pods should run [Architecture:amd64][Architecture:arm64]"))
+	require.Equal(t, "test requires architecture amd64 or s390x, cluster has nodes of architecture arm64",
+		multiArch.ArchitectureSkipReason("[sig-node] pods should run [Architecture:s390x][Architecture:amd64]"))
+	require.Empty(t, unknown.ArchitectureSkipReason("[sig-node] pods should run [Architecture:amd64]"))
+	require.True(t, multiArch.IsMultiArch())
+	require.False(t, arm64.IsMultiArch())
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/openshift/library-go/pkg/image/reference"
 	"github.com/openshift/origin/test/extended/util/image"
+	"k8s.io/apimachinery/pkg/util/sets"
 	k8simage "k8s.io/kubernetes/test/utils/image"
 )
 
@@ -57,3 +59,56 @@ func VerifyImages() error {
 
 	return nil
 }
+
+// InitializeImageMirrors reads the registry mirrors of the test images from the file named by
+// image.MirrorConfigEnvVar.  It must precede image.InitializeImages.
+func InitializeImageMirrors() error {
+	config, err := image.LoadMirrorConfig(os.Getenv(image.MirrorConfigEnvVar))
+	if err != nil {
+		return err
+	}
+	image.InitializeMirrors(config)
+	return nil
+}
+
+// MirrorUpstreamImages redirects the images of the upstream Kubernetes tests to their mirrors.  The upstream package
+// resolves its images whenever it initializes, so this must follow every k8simage.Init.
+func MirrorUpstreamImages() error {
+	configs := k8simage.GetImageConfigs()
+	for id, config := range configs {
+		mirrored := image.MirrorFor(config.GetE2EImage())
+		if mirrored == config.GetE2EImage() {
+			continue
+		}
+		ref, err := reference.Parse(mirrored)
+		if err != nil {
+			return fmt.Errorf("invalid mirror %s of test image %s: %v", mirrored, config.GetE2EImage(), err)
+		}
+		config.SetRegistry(ref.Registry)
+		config.SetName(ref.RepositoryName())
+		config.SetVersion(ref.Tag)
+		configs[id] = config
+	}
+	return nil
+}
+
+// ResolvedImages returns every test image the suites may pull, as "SOURCE LOCATION" lines where the location is
+// where the image is pulled from once fromRepository and the registry mirrors apply.
+func ResolvedImages(fromRepository string) []string {
+	lines := sets.New[string]()
+	for original, pullSpec := range image.GetMappedImages(image.OriginalImages(), fromRepository) {
+		lines.Insert(fmt.Sprintf("%s %s", original, image.MirrorFor(pullSpec)))
+	}
+
+	originals := k8simage.GetOriginalImageConfigs()
+	mapped := originals
+	if len(fromRepository) > 0 {
+		mapped = k8simage.GetMappedImageConfigs(originals, fromRepository)
+	}
+	for id, config := range mapped {
+		original := originals[id]
+		lines.Insert(fmt.Sprintf("%s %s", original.GetE2EImage(), image.MirrorFor(config.GetE2EImage())))
+	}
+
+	return sets.List(lines)
+}
@@ -0,0 +1,95 @@
+package suiteselection
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	clientconfigv1 "github.com/openshift/client-go/config/clientset/versioned"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// capabilityFilter skips tests labelled with [Capability:NAME], or with a tag that implies a capability, when NAME is a
+// capability the cluster knows of but has not enabled.  Clusters that predate a capability always have it.
+type capabilityFilter struct {
+	known   sets.String
+	enabled sets.String
+}
+
+func newCapabilityFilter(ctx context.Context, configClient clientconfigv1.Interface) (*capabilityFilter, error) {
+	clusterVersion, err := configClient.ConfigV1().ClusterVersions().Get(ctx, "version", metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &capabilityFilter{
+		known:   sets.NewString(),
+		enabled: sets.NewString(),
+	}
+	for _, capability := range clusterVersion.Status.Capabilities.KnownCapabilities {
+		ret.known.Insert(string(capability))
+	}
+	for _, capability := range clusterVersion.Status.Capabilities.EnabledCapabilities {
+		ret.enabled.Insert(string(capability))
+	}
+	return ret, nil
+}
+
+func (f *capabilityFilter) skipReason(name string) string {
+	for _, capability := range append(capabilitiesFor(name), impliedCapabilitiesFor(name)...) {
+		if f.known.Has(capability) && !f.enabled.Has(capability) {
+			return fmt.Sprintf("cluster capability %s is not enabled", capability)
+		}
+	}
+	return ""
+}
+
+// skipCapabilityTest is used when the cluster does not report capabilities, so none can be assumed.  Only the explicit
+// [Capability:NAME] labels are honored, tests tagged with a feature ran on these clusters before capabilities existed.
+func skipCapabilityTest(name string) string {
+	if capabilities := capabilitiesFor(name); len(capabilities) > 0 {
+		return fmt.Sprintf("cluster does not report capabilities, test requires %s", strings.Join(capabilities, ", "))
+	}
+	return ""
+}
+
+func capabilitiesFor(name string) []string {
+	capabilities := []string{}
+	matches := capabilityRegex.FindAllStringSubmatch(name, -1)
+	for _, match := range matches {
+		if len(match) < 2 {
+			panic(fmt.Errorf("regexp match %v is invalid: len(match) < 2 for %v", match, name))
+		}
+		capabilities = append(capabilities, match[1])
+	}
+	return capabilities
+}
+
+// impliedCapabilitiesFor returns the capabilities required by the feature tags of the test.  Mapping the existing tags
+// keeps the names, and so the history, of the tests.
+func impliedCapabilitiesFor(name string) []string {
+	capabilities := sets.NewString()
+	for _, match := range featureTagRegex.FindAllStringSubmatch(name, -1) {
+		if capability, ok := capabilityForFeatureTag[match[1]]; ok {
+			capabilities.Insert(capability)
+		}
+	}
+	return capabilities.List()
+}
+
+var (
+	capabilityRegex = regexp.MustCompile(`\[Capability:([^]]*)\]`)
+	featureTagRegex = regexp.MustCompile(`\[Feature:([^]]*)\]`)
+
+	// capabilityForFeatureTag maps the [Feature:NAME] tags of tests to the capability they require.
+	capabilityForFeatureTag = map[string]string{
+		"Builds": "Build",
+		// these push to or read from the integrated image registry
+		"ImagePrune":   "ImageRegistry",
+		"ImageLayers":  "ImageRegistry",
+		"ImageAppend":  "ImageRegistry",
+		"ImageExtract": "ImageRegistry",
+	}
+)
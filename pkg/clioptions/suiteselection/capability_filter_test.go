@@ -0,0 +1,34 @@
+package suiteselection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestCapabilityFilterSkipReason(t *testing.T) {
+	f := &capabilityFilter{
+		known:   sets.NewString("Build", "ImageRegistry", "DeploymentConfig"),
+		enabled: sets.NewString("Build", "ImageRegistry"),
+	}
+
+	assert.Empty(t, f.skipReason("[sig-builds] builds should run"))
+	assert.Empty(t, f.skipReason("[sig-builds] builds should push [Capability:Build][Capability:ImageRegistry]"))
+	assert.Empty(t, f.skipReason("[sig-builds][Feature:Builds] builds should push"))
+	assert.Equal(t, "cluster capability DeploymentConfig is not enabled",
+		f.skipReason("[sig-apps] deploymentconfigs should roll out [Capability:DeploymentConfig]"))
+	assert.Empty(t, f.skipReason("[sig-apps] should roll out [Capability:NotYetKnown]"), "clusters that predate a capability have it")
+
+	disabled := &capabilityFilter{known: sets.NewString("Build", "ImageRegistry"), enabled: sets.NewString()}
+	assert.Equal(t, "cluster capability Build is not enabled", disabled.skipReason("[sig-builds][Feature:Builds] builds should push"))
+	assert.Equal(t, "cluster capability ImageRegistry is not enabled", disabled.skipReason("[sig-imageregistry][Feature:ImagePrune] prunes images"))
+	assert.Empty(t, disabled.skipReason("[sig-imageregistry][Feature:ImageTriggers] triggers"))
+	assert.Empty(t, (&capabilityFilter{known: sets.NewString(), enabled: sets.NewString()}).skipReason("[sig-builds][Feature:Builds] builds should push"),
+		"a cluster that reports no capabilities has all of them")
+
+	assert.Empty(t, skipCapabilityTest("[sig-node] pods should run"))
+	assert.Equal(t, "cluster does not report capabilities, test requires Build",
+		skipCapabilityTest("[sig-builds] builds should run [Capability:Build]"))
+	assert.Empty(t, skipCapabilityTest("[sig-builds][Feature:Builds] builds should run"))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"strings"
 
 	clientconfigv1 "github.com/openshift/client-go/config/clientset/versioned"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -55,26 +56,38 @@ func newFeatureGateFilter(ctx context.Context, configClient clientconfigv1.Inter
 	return ret, nil
 }
 
-func (f *featureGateFilter) includeTest(name string) bool {
+// skipReason returns why a test labelled with [OCPFeatureGate:NAME] cannot run, or empty if every gate is enabled.
+func (f *featureGateFilter) skipReason(name string) string {
+	for _, featureGate := range featureGatesFor(name) {
+		if f.disabled.Has(featureGate) {
+			return fmt.Sprintf("feature gate %s is disabled on this cluster", featureGate)
+		}
+		if !f.enabled.Has(featureGate) {
+			return fmt.Sprintf("feature gate %s is not known to this cluster", featureGate)
+		}
+	}
+	return ""
+}
+
+// skipFeatureGateTest is used when we are unable to determine if there is support for feature gates.  The test
+// target doesn't comply with the preconditions of any feature gated test.
+func skipFeatureGateTest(name string) string {
+	if featureGates := featureGatesFor(name); len(featureGates) > 0 {
+		return fmt.Sprintf("cluster does not report feature gates, test requires %s", strings.Join(featureGates, ", "))
+	}
+	return ""
+}
+
+func featureGatesFor(name string) []string {
 	featureGates := []string{}
 	matches := featureGateRegex.FindAllStringSubmatch(name, -1)
 	for _, match := range matches {
 		if len(match) < 2 {
 			panic(fmt.Errorf("regexp match %v is invalid: len(match) < 2 for %v", match, name))
 		}
-		featureGate := match[1]
-		featureGates = append(featureGates, featureGate)
-	}
-
-	if f.disabled.HasAny(featureGates...) {
-		return false
+		featureGates = append(featureGates, match[1])
 	}
-
-	return f.enabled.HasAll(featureGates...)
-}
-
-func includeNonFeatureGateTest(name string) bool {
-	return featureGateRegex.FindAllStringSubmatch(name, -1) == nil
+	return featureGates
 }
 
 var (
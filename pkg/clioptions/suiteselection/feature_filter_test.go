@@ -0,0 +1,26 @@
+package suiteselection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestFeatureGateFilterSkipReason(t *testing.T) {
+	f := &featureGateFilter{
+		enabled:  sets.NewString("Enabled"),
+		disabled: sets.NewString("TechPreviewOnly"),
+	}
+
+	assert.Empty(t, f.skipReason("[sig-node] pods should run"))
+	assert.Empty(t, f.skipReason("[sig-node] pods should run [OCPFeatureGate:Enabled]"))
+	assert.Equal(t, "feature gate TechPreviewOnly is disabled on this cluster",
+		f.skipReason("[sig-node] pods should run [OCPFeatureGate:Enabled] [OCPFeatureGate:TechPreviewOnly]"))
+	assert.Equal(t, "feature gate Unknown is not known to this cluster",
+		f.skipReason("[sig-node] pods should run [OCPFeatureGate:Unknown]"))
+
+	assert.Empty(t, skipFeatureGateTest("[sig-node] pods should run"))
+	assert.Equal(t, "cluster does not report feature gates, test requires Enabled",
+		skipFeatureGateTest("[sig-node] pods should run [OCPFeatureGate:Enabled]"))
+}
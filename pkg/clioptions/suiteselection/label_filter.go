@@ -0,0 +1,42 @@
+package suiteselection
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/types"
+
+	testginkgo "github.com/openshift/origin/pkg/test/ginkgo"
+)
+
+// testLabelRegex matches the bracketed labels embedded in test names, like [sig-node] or [Serial].
+var testLabelRegex = regexp.MustCompile(`\[([^\[\]]+)\]`)
+
+// testLabels returns the bracketed labels of a test name without their brackets, sig-node, Serial, Slow,
+// Suite:openshift/conformance/parallel or Jira:"Node / Kubelet".
+func testLabels(name string) []string {
+	matches := testLabelRegex.FindAllStringSubmatch(name, -1)
+	labels := make([]string, 0, len(matches))
+	for _, match := range matches {
+		labels = append(labels, strings.TrimSpace(match[1]))
+	}
+	return labels
+}
+
+// newLabelFilterMatchFunc selects tests with a ginkgo label filter expression evaluated against the labels of their
+// names, for instance 'sig-node && !(Serial || Slow)'.  Labels are compared case insensitively.  Labels containing
+// a '/' can only be matched with a regular expression between slashes, using '.' for the '/', for instance
+// '/Suite:openshift.conformance.parallel/'.
+func newLabelFilterMatchFunc(expression string) (testginkgo.TestMatchFunc, error) {
+	if len(strings.TrimSpace(expression)) == 0 {
+		return nil, nil
+	}
+	filter, err := types.ParseLabelFilter(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label filter %q: %w", expression, err)
+	}
+	return func(name string) bool {
+		return filter(testLabels(name))
+	}, nil
+}
@@ -0,0 +1,67 @@
+package suiteselection
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelFilterMatchFunc(t *testing.T) {
+	const (
+		parallelNode = `[sig-node][Jira:"Node / Kubelet"] pods should run [Suite:openshift/conformance/parallel]`
+		serialNode   = `[sig-node] pods should survive a reboot [Serial] [Suite:openshift/conformance/serial]`
+		slowStorage  = `[sig-storage] volumes should expand [Slow] [Suite:openshift/conformance/parallel]`
+	)
+	assert.Equal(t, []string{"sig-node", `Jira:"Node / Kubelet"`, "Suite:openshift/conformance/parallel"}, testLabels(parallelNode))
+
+	tests := []struct {
+		expression string
+		expected   []string
+	}{
+		{expression: "sig-node", expected: []string{parallelNode, serialNode}},
+		{expression: "SIG-NODE && !Serial", expected: []string{parallelNode}},
+		{expression: "!(Serial || Slow)", expected: []string{parallelNode}},
+		{expression: "/conformance.parallel/ && !Slow", expected: []string{parallelNode}},
+		{expression: "/^Jira:.Node/", expected: []string{parallelNode}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			matches, err := newLabelFilterMatchFunc(tt.expression)
+			require.NoError(t, err)
+			actual := []string{}
+			for _, name := range []string{parallelNode, serialNode, slowStorage} {
+				if matches(name) {
+					actual = append(actual, name)
+				}
+			}
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+
+	matches, err := newLabelFilterMatchFunc("")
+	require.NoError(t, err)
+	assert.Nil(t, matches, "an empty filter adds no match func")
+	_, err = newLabelFilterMatchFunc("sig-node &")
+	assert.Error(t, err)
+}
+
+func TestSuiteDefinitionLabelFilter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "suite.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`name: qe/node
+selection:
+  labelFilter: "sig-node && !(Serial || Slow)"
+  excludeRegex: "Disruptive"
+`), 0644))
+	definition, err := LoadSuiteDefinition(path)
+	require.NoError(t, err)
+	suite, err := definition.TestSuite()
+	require.NoError(t, err)
+
+	assert.True(t, suite.Matches("[sig-node] pods should run"))
+	assert.False(t, suite.Matches("[sig-node] pods should run [Serial]"))
+	assert.False(t, suite.Matches("[sig-node] pods should survive [Disruptive]"))
+	assert.False(t, suite.Matches("[sig-storage] volumes should mount"))
+}
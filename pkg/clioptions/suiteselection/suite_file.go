@@ -0,0 +1,162 @@
+package suiteselection
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	testginkgo "github.com/openshift/origin/pkg/test/ginkgo"
+)
+
+// SuiteDefinition describes a test suite maintained outside of the suite registry, loaded with --suite-file.
+type SuiteDefinition struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+
+	Selection SuiteSelection `json:"selection"`
+
+	// Parallelism, Count, TestTimeout and ClusterStabilityDuringTest have the same meaning as on a registered
+	// suite.  Command line flags take precedence.
+	Parallelism                int                                   `json:"parallelism,omitempty"`
+	Count                      int                                   `json:"count,omitempty"`
+	TestTimeout                metav1.Duration                       `json:"testTimeout,omitempty"`
+	ClusterStabilityDuringTest testginkgo.ClusterStabilityDuringTest `json:"clusterStability,omitempty"`
+
+	Monitors SuiteMonitors `json:"monitors,omitempty"`
+}
+
+// SuiteSelection selects tests by the bracketed labels in their names and by regular expression.  A test is
+// included if it has every includeLabel, none of the excludeLabels, matches regex (when set), does not
+// match excludeRegex (when set) and matches labelFilter (when set), a label filter expression like --label-filter.
+type SuiteSelection struct {
+	IncludeLabels []string `json:"includeLabels,omitempty"`
+	ExcludeLabels []string `json:"excludeLabels,omitempty"`
+	Regex         string   `json:"regex,omitempty"`
+	ExcludeRegex  string   `json:"excludeRegex,omitempty"`
+	LabelFilter   string   `json:"labelFilter,omitempty"`
+}
+
+// SuiteMonitors mirrors the --monitor and --disable-monitor flags.
+type SuiteMonitors struct {
+	Enable  []string `json:"enable,omitempty"`
+	Disable []string `json:"disable,omitempty"`
+}
+
+// LoadSuiteDefinition reads and validates a yaml or json suite definition.
+func LoadSuiteDefinition(path string) (*SuiteDefinition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	definition := &SuiteDefinition{}
+	if err := yaml.UnmarshalStrict(data, definition); err != nil {
+		return nil, fmt.Errorf("unable to parse suite file %q: %w", path, err)
+	}
+	if err := definition.validate(); err != nil {
+		return nil, fmt.Errorf("invalid suite file %q: %w", path, err)
+	}
+	return definition, nil
+}
+
+func (d *SuiteDefinition) validate() error {
+	if len(d.Name) == 0 {
+		return fmt.Errorf("name is required")
+	}
+	selection := d.Selection
+	if len(selection.IncludeLabels) == 0 && len(selection.Regex) == 0 && len(selection.LabelFilter) == 0 {
+		return fmt.Errorf("selection must set includeLabels, regex or labelFilter")
+	}
+	if d.Parallelism < 0 {
+		return fmt.Errorf("parallelism must not be negative")
+	}
+	switch d.ClusterStabilityDuringTest {
+	case "", testginkgo.Stable, testginkgo.Disruptive:
+	default:
+		return fmt.Errorf("unknown clusterStability %q, expected %s or %s", d.ClusterStabilityDuringTest, testginkgo.Stable, testginkgo.Disruptive)
+	}
+	if len(d.Monitors.Enable) > 0 && len(d.Monitors.Disable) > 0 {
+		return fmt.Errorf("monitors may set only one of enable or disable")
+	}
+	if _, err := d.matchFunc(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// TestSuite converts the definition to a suite that can be passed to SelectSuite.
+func (d *SuiteDefinition) TestSuite() (*testginkgo.TestSuite, error) {
+	matches, err := d.matchFunc()
+	if err != nil {
+		return nil, err
+	}
+	return &testginkgo.TestSuite{
+		Name:                       d.Name,
+		Description:                d.Description,
+		Matches:                    matches,
+		Count:                      d.Count,
+		Parallelism:                d.Parallelism,
+		ClusterStabilityDuringTest: d.ClusterStabilityDuringTest,
+		TestTimeout:                d.TestTimeout.Duration,
+	}, nil
+}
+
+func (d *SuiteDefinition) matchFunc() (testginkgo.TestMatchFunc, error) {
+	selection := d.Selection
+	var include, exclude *regexp.Regexp
+	var err error
+	if len(selection.Regex) > 0 {
+		if include, err = regexp.Compile(selection.Regex); err != nil {
+			return nil, fmt.Errorf("invalid selection regex: %w", err)
+		}
+	}
+	if len(selection.ExcludeRegex) > 0 {
+		if exclude, err = regexp.Compile(selection.ExcludeRegex); err != nil {
+			return nil, fmt.Errorf("invalid selection excludeRegex: %w", err)
+		}
+	}
+	labelFilter, err := newLabelFilterMatchFunc(selection.LabelFilter)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selection labelFilter: %w", err)
+	}
+	includeLabels := bracketLabels(selection.IncludeLabels)
+	excludeLabels := bracketLabels(selection.ExcludeLabels)
+
+	return func(name string) bool {
+		for _, label := range includeLabels {
+			if !strings.Contains(name, label) {
+				return false
+			}
+		}
+		for _, label := range excludeLabels {
+			if strings.Contains(name, label) {
+				return false
+			}
+		}
+		if include != nil && !include.MatchString(name) {
+			return false
+		}
+		if exclude != nil && exclude.MatchString(name) {
+			return false
+		}
+		if labelFilter != nil && !labelFilter(name) {
+			return false
+		}
+		return true
+	}, nil
+}
+
+// bracketLabels allows labels to be written either as they appear in test names, [sig-node], or bare, sig-node.
+func bracketLabels(labels []string) []string {
+	ret := make([]string, 0, len(labels))
+	for _, label := range labels {
+		if !strings.HasPrefix(label, "[") {
+			label = "[" + label + "]"
+		}
+		ret = append(ret, label)
+	}
+	return ret
+}
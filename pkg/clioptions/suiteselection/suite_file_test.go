@@ -0,0 +1,65 @@
+package suiteselection
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	testginkgo "github.com/openshift/origin/pkg/test/ginkgo"
+)
+
+func TestLoadSuiteDefinition(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "suite.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`name: qe/storage
+description: storage tests maintained by QE
+selection:
+  includeLabels: ["sig-storage"]
+  excludeLabels: ["[Serial]"]
+  excludeRegex: "Disruptive"
+parallelism: 5
+testTimeout: 20m
+clusterStability: Stable
+monitors:
+  disable: ["audit-log-analyzer"]
+`), 0644))
+
+	definition, err := LoadSuiteDefinition(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"audit-log-analyzer"}, definition.Monitors.Disable)
+
+	suite, err := definition.TestSuite()
+	require.NoError(t, err)
+	assert.Equal(t, "qe/storage", suite.Name)
+	assert.Equal(t, 5, suite.Parallelism)
+	assert.Equal(t, 20*time.Minute, suite.TestTimeout)
+	assert.Equal(t, testginkgo.Stable, suite.ClusterStabilityDuringTest)
+
+	assert.True(t, suite.Matches("[sig-storage] volumes should mount"))
+	assert.False(t, suite.Matches("[sig-storage] volumes should mount [Serial]"))
+	assert.False(t, suite.Matches("[sig-storage] volumes should survive [Disruptive]"))
+	assert.False(t, suite.Matches("[sig-node] pods should run"))
+}
+
+func TestLoadSuiteDefinitionInvalid(t *testing.T) {
+	tests := map[string]string{
+		"missing name":        "selection:\n  regex: foo\n",
+		"missing selection":   "name: foo\n",
+		"invalid regex":       "name: foo\nselection:\n  regex: \"(\"\n",
+		"invalid labelFilter": "name: foo\nselection:\n  labelFilter: \"sig-node &\"\n",
+		"unknown stability":   "name: foo\nselection:\n  regex: foo\nclusterStability: Sometimes\n",
+		"unknown field":       "name: foo\nselection:\n  regex: foo\nparalelism: 3\n",
+		"enable and disable":  "name: foo\nselection:\n  regex: foo\nmonitors:\n  enable: [a]\n  disable: [b]\n",
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "suite.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+			_, err := LoadSuiteDefinition(path)
+			assert.Error(t, err)
+		})
+	}
+}
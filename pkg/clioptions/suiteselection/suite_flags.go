@@ -37,6 +37,8 @@ type TestSuiteSelectionFlags struct {
 
 	// Regex allows a selection of a subset of tests
 	Regex string
+	// LabelFilter is a ginkgo label filter expression over the bracketed labels of test names.
+	LabelFilter string
 	// MatchFn if set is also used to filter the suite contents
 	MatchFn testginkgo.TestMatchFunc
 
@@ -52,9 +54,14 @@ func NewTestSuiteSelectionFlags(streams genericclioptions.IOStreams) *TestSuiteS
 func (f *TestSuiteSelectionFlags) BindFlags(flags *pflag.FlagSet) {
 	flags.StringVarP(&f.TestFile, "file", "f", f.TestFile, "Create a suite from the newline-delimited test names in this file.")
 	flags.StringVar(&f.Regex, "run", f.Regex, "Regular expression of tests to run.")
+	flags.StringVar(&f.LabelFilter, "label-filter", f.LabelFilter, "A label filter expression over the bracketed labels of test names, for instance 'sig-node && !(Serial || Slow)'.  "+
+		"Match labels containing a '/' with a regular expression, like '/Suite:openshift.conformance.parallel/'.  Combined with the suite and --run, a test must match all of them.")
 }
 
 func (f *TestSuiteSelectionFlags) Validate() error {
+	if _, err := newLabelFilterMatchFunc(f.LabelFilter); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -110,6 +117,12 @@ func (f *TestSuiteSelectionFlags) SelectSuite(
 		suite.AddRequiredMatchFunc(re.MatchString)
 	}
 
+	labelFilterMatchFn, err := newLabelFilterMatchFunc(f.LabelFilter)
+	if err != nil {
+		return nil, err
+	}
+	suite.AddRequiredMatchFunc(labelFilterMatchFn)
+
 	suite.AddRequiredMatchFunc(f.MatchFn)
 	suite.AddRequiredMatchFunc(additionalMatchFn)
 
@@ -149,13 +162,23 @@ func (f *TestSuiteSelectionFlags) SelectSuite(
 		featureGateFilter, err := newFeatureGateFilter(context.TODO(), configClient)
 		switch {
 		case apierrors.IsNotFound(err):
-			// In case we are unable to determine if there is support for feature gates, exclude all featuregated tests
-			// as the test target doesnt comply with preconditions.
-			suite.AddRequiredMatchFunc(includeNonFeatureGateTest)
+			suite.AddEnvironmentSkipFunc(skipFeatureGateTest)
 		case err != nil:
 			return nil, fmt.Errorf("unable to build FeatureGate filter: %w", err)
 		default:
-			suite.AddRequiredMatchFunc(featureGateFilter.includeTest)
+			suite.AddEnvironmentSkipFunc(featureGateFilter.skipReason)
+		}
+
+		// tests for a disabled feature gate or capability are still reported, as skipped with the reason, so that
+		// it is clear the cluster could not run them.
+		capabilityFilter, err := newCapabilityFilter(context.TODO(), configClient)
+		switch {
+		case apierrors.IsNotFound(err):
+			suite.AddEnvironmentSkipFunc(skipCapabilityTest)
+		case err != nil:
+			return nil, fmt.Errorf("unable to build capability filter: %w", err)
+		default:
+			suite.AddEnvironmentSkipFunc(capabilityFilter.skipReason)
 		}
 	}
 
@@ -1,9 +1,6 @@
 package dev
 
 import (
-	"io/ioutil"
-	"os"
-
 	configv1 "github.com/openshift/api/config/v1"
 	"github.com/openshift/origin/pkg/alerts"
 	"github.com/openshift/origin/pkg/monitor/monitorapi"
@@ -93,7 +90,7 @@ a running cluster.
 	}
 	cmd.Flags().StringVar(&o.intervalsFile,
 		"intervals-file", "e2e-events.json",
-		"Path to an intervals file (i.e. e2e-events_20230214-203340.json). Can be obtained from a CI run in openshift-tests junit artifacts.")
+		"Path to an intervals file (i.e. e2e-events_20230214-203340.json) or partitioned intervals directory (i.e. e2e-intervals_20230214-203340). Can be obtained from a CI run in openshift-tests junit artifacts.")
 	cmd.Flags().StringVar(
 		&o.platform,
 		"platform", "gcp",
@@ -122,18 +119,7 @@ a running cluster.
 }
 
 func readIntervalsFromFile(intervalsFile string) (monitorapi.Intervals, error) {
-	jsonFile, err := os.Open(intervalsFile)
-	if err != nil {
-		return nil, err
-	}
-	defer jsonFile.Close()
-
-	jsonBytes, err := ioutil.ReadAll(jsonFile)
-	if err != nil {
-		return nil, err
-	}
-
-	return monitorserialization.IntervalsFromJSON(jsonBytes)
+	return monitorserialization.IntervalsFromPath(intervalsFile)
 }
 
 func newRunDisruptionInvariantsCommand() *cobra.Command {
@@ -177,7 +163,7 @@ a running cluster.
 	}
 	cmd.Flags().StringVar(&opts.intervalsFile,
 		"intervals-file", "e2e-events.json",
-		"Path to an intervals file (i.e. e2e-events_20230214-203340.json). Can be obtained from a CI run in openshift-tests junit artifacts.")
+		"Path to an intervals file (i.e. e2e-events_20230214-203340.json) or partitioned intervals directory (i.e. e2e-intervals_20230214-203340). Can be obtained from a CI run in openshift-tests junit artifacts.")
 	cmd.Flags().StringVar(
 		&opts.platform,
 		"platform", "gcp",
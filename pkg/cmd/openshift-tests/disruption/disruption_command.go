@@ -2,7 +2,9 @@ package disruption
 
 import (
 	poll_service "github.com/openshift/origin/pkg/cmd/openshift-tests/disruption/poll-service"
+	refresh_historical_data "github.com/openshift/origin/pkg/cmd/openshift-tests/disruption/refresh-historical-data"
 	watch_endpointslice "github.com/openshift/origin/pkg/cmd/openshift-tests/disruption/watch-endpointslice"
+	watch_kubelets "github.com/openshift/origin/pkg/cmd/openshift-tests/disruption/watch-kubelets"
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
@@ -15,7 +17,9 @@ func NewDisruptionCommand(streams genericclioptions.IOStreams) *cobra.Command {
 	}
 	cmd.AddCommand(
 		watch_endpointslice.NewWatchEndpointSlice(streams),
+		watch_kubelets.NewWatchKubelets(streams),
 		poll_service.NewPollService(streams),
+		refresh_historical_data.NewRefreshHistoricalData(streams),
 	)
 	return cmd
 }
@@ -0,0 +1,85 @@
+package refresh_historical_data
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/openshift/origin/pkg/monitortestlibrary/allowedbackenddisruption"
+)
+
+type RefreshHistoricalDataFlags struct {
+	URL                string
+	HistoricalDataFile string
+	Timeout            time.Duration
+
+	genericclioptions.IOStreams
+}
+
+func NewRefreshHistoricalDataFlags(streams genericclioptions.IOStreams) *RefreshHistoricalDataFlags {
+	return &RefreshHistoricalDataFlags{
+		URL:       allowedbackenddisruption.DefaultHistoricalDataURL,
+		Timeout:   time.Minute,
+		IOStreams: streams,
+	}
+}
+
+func NewRefreshHistoricalData(ioStreams genericclioptions.IOStreams) *cobra.Command {
+	f := NewRefreshHistoricalDataFlags(ioStreams)
+	cmd := &cobra.Command{
+		Use:   "refresh-historical-data",
+		Short: "Download the historical disruption data used to compute disruption budgets",
+		Long: `Download the historical disruption data used to compute disruption budgets into a local file.
+
+The file is validated before it replaces an existing copy.  Pass it to run or run-upgrade with
+--historical-data-file, for instance on a disconnected cluster after copying it from a host that
+can reach the published data.`,
+
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := f.Validate(); err != nil {
+				return err
+			}
+			o, err := f.ToOptions()
+			if err != nil {
+				return err
+			}
+			return o.Run(context.Background())
+		},
+	}
+
+	f.BindOptions(cmd.Flags())
+
+	return cmd
+}
+
+func (f *RefreshHistoricalDataFlags) BindOptions(flags *pflag.FlagSet) {
+	flags.StringVar(&f.URL, "url", f.URL, "The URL of the historical disruption data.")
+	flags.StringVar(&f.HistoricalDataFile, "historical-data-file", f.HistoricalDataFile, "The file to write the historical disruption data to.")
+	flags.DurationVar(&f.Timeout, "timeout", f.Timeout, "The maximum time to spend downloading the data.")
+}
+
+func (f *RefreshHistoricalDataFlags) Validate() error {
+	if len(f.URL) == 0 {
+		return fmt.Errorf("--url must be specified")
+	}
+	if len(f.HistoricalDataFile) == 0 {
+		return fmt.Errorf("--historical-data-file must be specified")
+	}
+	return nil
+}
+
+func (f *RefreshHistoricalDataFlags) ToOptions() (*RefreshHistoricalDataOptions, error) {
+	return &RefreshHistoricalDataOptions{
+		URL:                f.URL,
+		HistoricalDataFile: f.HistoricalDataFile,
+		Client:             &http.Client{Timeout: f.Timeout},
+		IOStreams:          f.IOStreams,
+	}, nil
+}
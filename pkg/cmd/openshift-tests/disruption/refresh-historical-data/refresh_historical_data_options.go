@@ -0,0 +1,74 @@
+package refresh_historical_data
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/openshift/origin/pkg/monitortestlibrary/historicaldata"
+)
+
+type RefreshHistoricalDataOptions struct {
+	URL                string
+	HistoricalDataFile string
+	Client             *http.Client
+
+	genericclioptions.IOStreams
+}
+
+func (o *RefreshHistoricalDataOptions) Run(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, o.URL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := o.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to download historical data from %s: %w", o.URL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unable to download historical data from %s: %s", o.URL, resp.Status)
+	}
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("unable to download historical data from %s: %w", o.URL, err)
+	}
+
+	// never replace a working copy with data the runs could not read.
+	matcher, err := historicaldata.NewDisruptionMatcher(content)
+	if err != nil {
+		return fmt.Errorf("%s did not return valid historical disruption data: %w", o.URL, err)
+	}
+	if err := writeFileAtomically(o.HistoricalDataFile, content); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(o.Out, "wrote %d historical disruption entries to %s\n", len(matcher.HistoricalData), o.HistoricalDataFile)
+	return nil
+}
+
+// writeFileAtomically renames a temporary file over filename so a run reading it never sees a partial copy.
+func writeFileAtomically(filename string, content []byte) error {
+	tmpFile, err := os.CreateTemp(filepath.Dir(filename), filepath.Base(filename)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.Write(content); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpFile.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile.Name(), filename)
+}
@@ -0,0 +1,97 @@
+package refresh_historical_data
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/openshift/origin/pkg/monitortestlibrary/allowedbackenddisruption"
+)
+
+const validData = `[
+  {
+    "BackendName": "kube-api-new-connections",
+    "Release": "4.16",
+    "FromRelease": "4.15",
+    "Platform": "aws",
+    "Architecture": "amd64",
+    "Network": "ovn",
+    "Topology": "ha",
+    "JobRuns": 188,
+    "P95": "1.5",
+    "P99": "3.0"
+  }
+]`
+
+func TestRefreshHistoricalData(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr string
+	}{
+		{
+			name:   "valid data replaces the file",
+			status: http.StatusOK,
+			body:   validData,
+		},
+		{
+			name:    "server error keeps the file",
+			status:  http.StatusInternalServerError,
+			body:    validData,
+			wantErr: "500 Internal Server Error",
+		},
+		{
+			name:    "invalid data keeps the file",
+			status:  http.StatusOK,
+			body:    `<html>not found</html>`,
+			wantErr: "did not return valid historical disruption data",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			filename := filepath.Join(t.TempDir(), "query_results.json")
+			require.NoError(t, os.WriteFile(filename, []byte("[]"), 0644))
+
+			out := &bytes.Buffer{}
+			o := &RefreshHistoricalDataOptions{
+				URL:                server.URL,
+				HistoricalDataFile: filename,
+				Client:             server.Client(),
+				IOStreams:          genericclioptions.IOStreams{Out: out, ErrOut: out},
+			}
+			err := o.Run(context.Background())
+
+			content, readErr := os.ReadFile(filename)
+			require.NoError(t, readErr)
+			if len(tt.wantErr) > 0 {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.Equal(t, "[]", string(content))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, validData, string(content))
+			assert.Contains(t, out.String(), "wrote 1 historical disruption entries")
+
+			// the refreshed file is accepted by the runs.
+			require.NoError(t, allowedbackenddisruption.UseHistoricalDataFile(filename))
+			entries, _ := filepath.Glob(filepath.Join(filepath.Dir(filename), "*"))
+			assert.Len(t, entries, 1, "temporary files should be removed")
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package watch_kubelets
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/backenddisruption"
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// BackendPrefix classifies the disruption of the kubelet API.
+	BackendPrefix = "kubelet"
+	// kubeletPort is the port the kubelet serves its API on.
+	kubeletPort = "10250"
+)
+
+// KubeletController polls the healthz of the kubelet of every node.  The kubelet refuses anonymous requests, so a 401
+// proves it served the request.
+type KubeletController struct {
+	namespaceName     string
+	myNodeName        string
+	stopConfigMapName string
+	recorder          monitorapi.RecorderWriter
+	outFile           io.Writer
+
+	nodeLister      corelisters.NodeLister
+	configmapLister corelisters.ConfigMapLister
+	informersToSync []cache.InformerSynced
+
+	watcherLock sync.Mutex
+	watchers    map[string]*watcher
+
+	queue workqueue.RateLimitingInterface
+}
+
+type watcher struct {
+	address                 string
+	nodeName                string
+	newConnectionSampler    *backenddisruption.BackendSampler
+	reusedConnectionSampler *backenddisruption.BackendSampler
+}
+
+func NewKubeletWatcher(
+	namespaceName string,
+	stopConfigMapName string,
+	myNodeName string,
+	recorder monitorapi.RecorderWriter,
+	outFile io.Writer,
+
+	nodeInformer coreinformers.NodeInformer,
+	configmapInformer coreinformers.ConfigMapInformer,
+) *KubeletController {
+	c := &KubeletController{
+		namespaceName:     namespaceName,
+		myNodeName:        myNodeName,
+		stopConfigMapName: stopConfigMapName,
+		recorder:          recorder,
+		outFile:           outFile,
+
+		nodeLister:      nodeInformer.Lister(),
+		configmapLister: configmapInformer.Lister(),
+		informersToSync: []cache.InformerSynced{
+			configmapInformer.Informer().HasSynced,
+			nodeInformer.Informer().HasSynced,
+		},
+
+		watchers: map[string]*watcher{},
+
+		queue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "KubeletWatcher"),
+	}
+
+	enqueue := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.queue.Add("check")
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			c.queue.Add("check")
+		},
+		DeleteFunc: func(obj interface{}) {
+			c.queue.Add("check")
+		},
+	}
+	nodeInformer.Informer().AddEventHandler(enqueue)
+	configmapInformer.Informer().AddEventHandler(enqueue)
+	return c
+}
+
+// InstanceName is the name of the disruption of the kubelet of targetNode as seen from pollerNode.
+func InstanceName(pollerNode, targetNode string) string {
+	return fmt.Sprintf("%s-from-node-%s-to-node-%s", BackendPrefix, pollerNode, targetNode)
+}
+
+// nodeAddress returns the address the kubelet of the node serves on, its first internal IP.
+func nodeAddress(node *corev1.Node) string {
+	for _, address := range node.Status.Addresses {
+		if address.Type == corev1.NodeInternalIP {
+			return address.Address
+		}
+	}
+	return ""
+}
+
+func (c *KubeletController) sync(ctx context.Context) error {
+	_, err := c.configmapLister.ConfigMaps(c.namespaceName).Get(c.stopConfigMapName)
+	switch {
+	case err == nil:
+		c.removeAllWatchers()
+		return nil
+	case apierrors.IsNotFound(err):
+	// good
+	case err != nil:
+		return err
+	}
+
+	nodes, err := c.nodeLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	// watchersForCurrNodes holds the metadata (but not the samplers) for the watchers of the nodes that exist.
+	watchersForCurrNodes := map[string]*watcher{}
+	for _, node := range nodes {
+		address := nodeAddress(node)
+		if len(address) == 0 {
+			continue
+		}
+		watchersForCurrNodes[node.Name] = &watcher{address: address, nodeName: node.Name}
+	}
+
+	c.watcherLock.Lock()
+	defer c.watcherLock.Unlock()
+
+	for nodeName, knownWatcher := range c.watchers {
+		if currWatcher, ok := watchersForCurrNodes[nodeName]; ok && currWatcher.address == knownWatcher.address {
+			continue
+		}
+		fmt.Fprintf(c.outFile, "Stopping and removing: %v for node/%v\n", knownWatcher.address, knownWatcher.nodeName)
+		knownWatcher.newConnectionSampler.Stop()
+		knownWatcher.reusedConnectionSampler.Stop()
+		delete(c.watchers, nodeName)
+	}
+
+	for nodeName, newWatcher := range watchersForCurrNodes {
+		if _, ok := c.watchers[nodeName]; ok {
+			continue
+		}
+		url := fmt.Sprintf("https://%s/healthz", net.JoinHostPort(newWatcher.address, kubeletPort))
+		fmt.Fprintf(c.outFile, "Adding and starting: %v on node/%v\n", url, newWatcher.nodeName)
+
+		newWatcher.newConnectionSampler = c.newSampler(url, newWatcher.nodeName, monitorapi.NewConnectionType)
+		newWatcher.newConnectionSampler.StartEndpointMonitoring(ctx, c.recorder, nil)
+		newWatcher.reusedConnectionSampler = c.newSampler(url, newWatcher.nodeName, monitorapi.ReusedConnectionType)
+		newWatcher.reusedConnectionSampler.StartEndpointMonitoring(ctx, c.recorder, nil)
+		c.watchers[nodeName] = newWatcher
+
+		fmt.Fprintf(c.outFile, "Successfully started: %v on node/%v\n", url, newWatcher.nodeName)
+	}
+	return nil
+}
+
+// newSampler polls the kubelet of targetNode.  The interval locator is unique for every poller and target, the backend
+// is per connection type, and the target node is kept on the locator so the intervals can be grouped per kubelet.
+func (c *KubeletController) newSampler(url, targetNode string, connectionType monitorapi.BackendConnectionType) *backenddisruption.BackendSampler {
+	locator := monitorapi.NewLocator().LocateDisruptionCheck(
+		fmt.Sprintf("%s-%v-connections", BackendPrefix, connectionType),
+		InstanceName(c.myNodeName, targetNode),
+		connectionType,
+	)
+	locator.Keys[monitorapi.LocatorNodeKey] = targetNode
+	return backenddisruption.NewSimpleBackendWithLocator(locator, url, "", connectionType).
+		WithExpectedStatusCode(401)
+}
+
+func (c *KubeletController) removeAllWatchers() {
+	c.watcherLock.Lock()
+	defer c.watcherLock.Unlock()
+
+	for _, watcherToDelete := range c.watchers {
+		fmt.Fprintf(c.outFile, "Stopping and removing: %v for node/%v\n", watcherToDelete.address, watcherToDelete.nodeName)
+
+		watcherToDelete.newConnectionSampler.Stop()
+		watcherToDelete.reusedConnectionSampler.Stop()
+	}
+
+	fmt.Fprintf(c.outFile, "Stopped all watchers\n")
+	c.watchers = map[string]*watcher{}
+}
+
+// Run starts the controller and blocks until ctx is done.
+func (c *KubeletController) Run(ctx context.Context, finishedCleanup chan struct{}) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+	defer close(finishedCleanup)
+
+	logger := klog.FromContext(ctx)
+	logger.Info("Starting KubeletWatcher controller")
+	defer logger.Info("Shutting down KubeletWatcher controller")
+
+	if !cache.WaitForNamedCacheSync("KubeletWatcher", ctx.Done(), c.informersToSync...) {
+		return
+	}
+
+	go wait.UntilWithContext(ctx, c.runWorker, time.Second)
+
+	<-ctx.Done()
+
+	c.removeAllWatchers()
+}
+
+func (c *KubeletController) runWorker(ctx context.Context) {
+	for c.processNextWorkItem(ctx) {
+	}
+}
+
+func (c *KubeletController) processNextWorkItem(ctx context.Context) bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	err := c.sync(ctx)
+	if err == nil {
+		c.queue.Forget(key)
+		return true
+	}
+
+	utilruntime.HandleError(fmt.Errorf("%v failed with : %v", key, err))
+	c.queue.AddRateLimited(key)
+
+	return true
+}
@@ -0,0 +1,136 @@
+package watch_kubelets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/openshift/origin/pkg/clioptions/iooptions"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// WatchKubeletsFlags is used to run a process polling the kubelet API of every node as a command line interaction.
+type WatchKubeletsFlags struct {
+	ConfigFlags       *genericclioptions.ConfigFlags
+	OutputFlags       *iooptions.OutputFlags
+	MyNodeName        string
+	StopConfigMapName string
+
+	genericclioptions.IOStreams
+}
+
+func NewWatchKubeletsFlags(streams genericclioptions.IOStreams) *WatchKubeletsFlags {
+	return &WatchKubeletsFlags{
+		ConfigFlags: genericclioptions.NewConfigFlags(false),
+		OutputFlags: iooptions.NewOutputOptions(),
+		IOStreams:   streams,
+	}
+}
+
+func NewWatchKubelets(ioStreams genericclioptions.IOStreams) *cobra.Command {
+	f := NewWatchKubeletsFlags(ioStreams)
+	cmd := &cobra.Command{
+		Use:   "watch-kubelets",
+		Short: "Continuously poll the kubelet API of every node to check availability.",
+
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancelFn := context.WithCancel(context.Background())
+			defer cancelFn()
+			abortCh := make(chan os.Signal, 2)
+			go func() {
+				<-abortCh
+				fmt.Fprintf(f.ErrOut, "Interrupted, terminating\n")
+				cancelFn()
+
+				sig := <-abortCh
+				fmt.Fprintf(f.ErrOut, "Interrupted twice, exiting (%s)\n", sig)
+				switch sig {
+				case syscall.SIGINT:
+					os.Exit(130)
+				default:
+					os.Exit(0)
+				}
+			}()
+			signal.Notify(abortCh, syscall.SIGINT, syscall.SIGTERM)
+
+			if err := f.Validate(); err != nil {
+				return err
+			}
+			o, err := f.ToOptions()
+			if err != nil {
+				return err
+			}
+			return o.Run(ctx)
+		},
+	}
+
+	f.BindOptions(cmd.Flags())
+
+	return cmd
+}
+
+func (f *WatchKubeletsFlags) BindOptions(flags *pflag.FlagSet) {
+	flags.StringVar(&f.MyNodeName, "my-node-name", f.MyNodeName, "the name of the node running this pod")
+	flags.StringVar(&f.StopConfigMapName, "stop-configmap", f.StopConfigMapName, "the name of the configmap that indicates that this pod should stop all watchers.")
+	f.ConfigFlags.AddFlags(flags)
+	f.OutputFlags.BindFlags(flags)
+}
+
+func (f *WatchKubeletsFlags) SetIOStreams(streams genericclioptions.IOStreams) {
+	f.IOStreams = streams
+}
+
+func (f *WatchKubeletsFlags) Validate() error {
+	if len(f.OutputFlags.OutFile) == 0 {
+		return fmt.Errorf("output-file must be specified")
+	}
+	if len(f.MyNodeName) == 0 {
+		return fmt.Errorf("my-node-name must be specified")
+	}
+
+	return nil
+}
+
+func (f *WatchKubeletsFlags) ToOptions() (*WatchKubeletsOptions, error) {
+	originalOutStream := f.IOStreams.Out
+	closeFn, err := f.OutputFlags.ConfigureIOStreams(f.IOStreams, f)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace, _, err := f.ConfigFlags.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return nil, err
+	}
+	if len(namespace) == 0 {
+		return nil, fmt.Errorf("namespace must be specified")
+	}
+
+	restConfig, err := f.ConfigFlags.ToRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WatchKubeletsOptions{
+		KubeClient:        kubeClient,
+		Namespace:         namespace,
+		OutputFile:        f.OutputFlags.OutFile,
+		StopConfigMapName: f.StopConfigMapName,
+		MyNodeName:        f.MyNodeName,
+		CloseFn:           closeFn,
+		OriginalOutFile:   originalOutStream,
+		IOStreams:         f.IOStreams,
+	}, nil
+}
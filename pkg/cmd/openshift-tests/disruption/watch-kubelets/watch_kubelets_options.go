@@ -0,0 +1,71 @@
+package watch_kubelets
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/openshift/origin/pkg/clioptions/iooptions"
+	"github.com/openshift/origin/pkg/monitor"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/informers"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type WatchKubeletsOptions struct {
+	KubeClient kubernetes.Interface
+	Namespace  string
+
+	OutputFile        string
+	MyNodeName        string
+	StopConfigMapName string
+
+	OriginalOutFile io.Writer
+	CloseFn         iooptions.CloseFunc
+	genericclioptions.IOStreams
+}
+
+func (o *WatchKubeletsOptions) Run(ctx context.Context) error {
+	fmt.Fprintf(o.OriginalOutFile, "Initializing to watch the kubelets from node/%v\n", o.MyNodeName)
+
+	startingContent, err := os.ReadFile(o.OutputFile)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if len(startingContent) > 0 {
+		// print starting content to the log so that we can simply scrape the log to find all entries at the end.
+		o.OriginalOutFile.Write(startingContent)
+	}
+
+	recorder := monitor.WrapWithJSONLRecorder(monitor.NewRecorder(), o.IOStreams.Out, nil)
+
+	kubeInformers := informers.NewSharedInformerFactory(o.KubeClient, 0)
+	namespaceScopedCoreInformers := coreinformers.New(kubeInformers, o.Namespace, nil)
+
+	cleanupFinished := make(chan struct{})
+	kubeletChecker := NewKubeletWatcher(
+		o.Namespace,
+		o.StopConfigMapName,
+		o.MyNodeName,
+		recorder,
+		o.OriginalOutFile,
+		kubeInformers.Core().V1().Nodes(),
+		namespaceScopedCoreInformers.ConfigMaps(),
+	)
+	go kubeletChecker.Run(ctx, cleanupFinished)
+
+	go kubeInformers.Start(ctx.Done())
+
+	fmt.Fprintf(o.OriginalOutFile, "Watching kubelets....\n")
+
+	<-ctx.Done()
+
+	// now wait for the watchers to shut down
+	fmt.Fprintf(o.OriginalOutFile, "Waiting for watchers to close....\n")
+	<-cleanupFinished
+	fmt.Fprintf(o.OriginalOutFile, "Exiting....\n")
+
+	return nil
+}
@@ -43,7 +43,17 @@ func NewImagesCommand() *cobra.Command {
 		By default, the test images are sourced from a public container image repository at
 		%[1]s and are provided as-is for testing purposes only. Images are mirrored by the project
 		to the public repository periodically.
-		`, imagesetup.DefaultTestImageMirrorLocation)),
+
+		Alternatively, the registries or repositories of the test images may be redirected to mirrors
+		by naming a file in the %[2]s environment variable, honored by every subcommand:
+
+				mirrors:
+				  quay.io: mirror.example.com:5000/quay.io
+				  registry.k8s.io: mirror.example.com:5000/registry.k8s.io
+
+		The '--list' flag prints every test image the suites may pull and where it will be pulled
+		from, once '--from-repository' and the mirrors apply, to verify the mirror before a run.
+		`, imagesetup.DefaultTestImageMirrorLocation, image.MirrorConfigEnvVar)),
 		PersistentPreRun: cmd.NoPrintVersion,
 		SilenceUsage:     true,
 		SilenceErrors:    true,
@@ -56,6 +66,16 @@ func NewImagesCommand() *cobra.Command {
 				return imagesetup.VerifyImages()
 			}
 
+			if o.List {
+				if err := imagesetup.InitializeImageMirrors(); err != nil {
+					return err
+				}
+				for _, line := range imagesetup.ResolvedImages(o.FromRepository) {
+					fmt.Fprintln(os.Stdout, line)
+				}
+				return nil
+			}
+
 			repository := o.Repository
 			var prefix string
 			for _, validPrefix := range []string{"file://", "s3://"} {
@@ -88,6 +108,8 @@ func NewImagesCommand() *cobra.Command {
 	}
 	cmd.Flags().BoolVar(&o.Upstream, "upstream", o.Upstream, "Retrieve images from the default upstream location")
 	cmd.Flags().StringVar(&o.Repository, "to-repository", o.Repository, "A container image repository to mirror to.")
+	cmd.Flags().BoolVar(&o.List, "list", o.List, "List every test image and the location it is pulled from, instead of a mirror list")
+	cmd.Flags().StringVar(&o.FromRepository, "from-repository", o.FromRepository, "With --list, the repository the run would source test images from")
 	// this is a private flag for debugging only
 	cmd.Flags().BoolVar(&o.Verify, "verify", o.Verify, "Verify the contents of the image mappings")
 	cmd.Flags().MarkHidden("verify")
@@ -95,9 +117,11 @@ func NewImagesCommand() *cobra.Command {
 }
 
 type imagesOptions struct {
-	Repository string
-	Upstream   bool
-	Verify     bool
+	Repository     string
+	Upstream       bool
+	Verify         bool
+	List           bool
+	FromRepository string
 }
 
 // createImageMirrorForInternalImages returns a list of 'oc image mirror' mappings from source to
@@ -0,0 +1,185 @@
+package from_must_gather
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortests/node/kubeletlogcollector"
+	"github.com/openshift/origin/pkg/monitortests/testframework/watchclusteroperators"
+	"github.com/openshift/origin/pkg/monitortests/testframework/watchevents"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	// must-gather writes into a directory named after the gather image, so paths are matched on their suffix.
+	eventsPathRegex          = regexp.MustCompile(`(^|/)namespaces/[^/]+/core/events\.yaml$`)
+	containerLogPathRegex    = regexp.MustCompile(`(^|/)namespaces/([^/]+)/pods/([^/]+)/([^/]+)/[^/]+/logs/(current|previous)\.log$`)
+	clusterOperatorPathRegex = regexp.MustCompile(`(^|/)cluster-scoped-resources/config\.openshift\.io/clusteroperators/[^/]+\.yaml$`)
+	kubeletLogPathRegex      = regexp.MustCompile(`(^|/)host_service_logs/[^/]+/kubelet_service\.log$`)
+
+	// journalLineRegex matches a journal line, optionally prefixed by the node name as written by oc adm node-logs.
+	journalLineRegex = regexp.MustCompile(`^(?:\S+\s+)??([A-Z][a-z]{2}\s+\d{1,2}\s+\d{2}:\d{2}:\d{2}(?:\.\d+)?\s+(\S+)\s.*)$`)
+)
+
+// IntervalsFromMustGather synthesizes the intervals the monitor would have recorded from the content of a
+// must-gather: events, the time span of every container log, clusteroperator conditions, and kubelet journals.
+// Files that cannot be read are logged and skipped, a partial must-gather is still useful.
+func IntervalsFromMustGather(ctx context.Context, mustGatherDir string) (monitorapi.Intervals, error) {
+	ret := monitorapi.Intervals{}
+	err := filepath.Walk(mustGatherDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		slashPath := filepath.ToSlash(path)
+
+		var intervals monitorapi.Intervals
+		switch {
+		case eventsPathRegex.MatchString(slashPath):
+			intervals, err = intervalsFromEventsFile(ctx, path)
+		case clusterOperatorPathRegex.MatchString(slashPath):
+			intervals, err = intervalsFromClusterOperatorFile(path)
+		case kubeletLogPathRegex.MatchString(slashPath):
+			intervals, err = intervalsFromKubeletLogFile(path)
+		default:
+			if matches := containerLogPathRegex.FindStringSubmatch(slashPath); matches != nil {
+				intervals, err = intervalsFromContainerLogFile(path, matches[2], matches[3], matches[4], matches[5] == "previous")
+			}
+		}
+		if err != nil {
+			logrus.WithError(err).Warnf("Skipping unreadable must-gather file %s", path)
+			return nil
+		}
+		ret = append(ret, intervals...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Sort(ret)
+	return ret, nil
+}
+
+func intervalsFromEventsFile(ctx context.Context, path string) (monitorapi.Intervals, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	list := &corev1.EventList{}
+	if err := yaml.Unmarshal(data, list); err != nil {
+		return nil, err
+	}
+	return watchevents.IntervalsFromEvents(ctx, list.Items), nil
+}
+
+// intervalsFromClusterOperatorFile records the last transition of every condition, which is all the history a
+// must-gather retains.
+func intervalsFromClusterOperatorFile(path string) (monitorapi.Intervals, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	co := &configv1.ClusterOperator{}
+	if err := yaml.Unmarshal(data, co); err != nil {
+		return nil, err
+	}
+	ret := monitorapi.Intervals{}
+	for i := range co.Status.Conditions {
+		c := &co.Status.Conditions[i]
+		if c.LastTransitionTime.IsZero() {
+			continue
+		}
+		ret = append(ret, watchclusteroperators.ClusterOperatorConditionInterval(co.Name, c, c.LastTransitionTime.UTC()))
+	}
+	return ret, nil
+}
+
+// intervalsFromContainerLogFile returns an interval from the first to the last timestamped line of a container log.
+// must-gather collects logs with --timestamps, so every line starts with an RFC3339 time.
+func intervalsFromContainerLogFile(path, namespace, podName, containerName string, previous bool) (monitorapi.Intervals, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var first, last time.Time
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		timestamp, _, _ := strings.Cut(scanner.Text(), " ")
+		t, err := time.Parse(time.RFC3339Nano, timestamp)
+		if err != nil {
+			continue
+		}
+		if first.IsZero() {
+			first = t
+		}
+		last = t
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if first.IsZero() {
+		return nil, nil
+	}
+
+	message := "current container logged"
+	if previous {
+		message = "previous container logged"
+	}
+	return monitorapi.Intervals{
+		monitorapi.NewInterval(monitorapi.SourcePodLog, monitorapi.Info).
+			Locator(monitorapi.NewLocator().ContainerFromNames(namespace, podName, "", containerName)).
+			Message(monitorapi.NewMessage().Reason(monitorapi.ContainerReasonLogged).HumanMessage(message)).
+			Build(first, last),
+	}, nil
+}
+
+// intervalsFromKubeletLogFile splits a journal gathered from several nodes by host, so each line is attributed to
+// the node that logged it.
+func intervalsFromKubeletLogFile(path string) (monitorapi.Intervals, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeLogs := map[string]*bytes.Buffer{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		matches := journalLineRegex.FindStringSubmatch(scanner.Text())
+		if matches == nil {
+			continue
+		}
+		line, nodeName := matches[1], matches[2]
+		if _, ok := nodeLogs[nodeName]; !ok {
+			nodeLogs[nodeName] = &bytes.Buffer{}
+		}
+		nodeLogs[nodeName].WriteString(line)
+		nodeLogs[nodeName].WriteString("\n")
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	ret := monitorapi.Intervals{}
+	for nodeName, nodeLog := range nodeLogs {
+		ret = append(ret, kubeletlogcollector.IntervalsFromKubeletLog(nodeName, nodeLog.Bytes())...)
+	}
+	return ret, nil
+}
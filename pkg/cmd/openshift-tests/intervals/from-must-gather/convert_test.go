@@ -0,0 +1,100 @@
+package from_must_gather
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestIntervalsFromMustGather(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "must-gather.local.1234", "quay-io-openshift-release-dev-sha256-abcd")
+
+	writeFile(t, filepath.Join(dir, "namespaces", "openshift-etcd", "core", "events.yaml"), `
+apiVersion: v1
+kind: EventList
+items:
+- metadata:
+    name: etcd-0.1
+    namespace: openshift-etcd
+  involvedObject:
+    kind: Pod
+    name: etcd-0
+    namespace: openshift-etcd
+  reason: Unhealthy
+  message: Readiness probe failed
+  type: Warning
+  count: 1
+  firstTimestamp: "2024-03-01T10:00:00Z"
+  lastTimestamp: "2024-03-01T10:00:00Z"
+`)
+	writeFile(t, filepath.Join(dir, "namespaces", "openshift-etcd", "pods", "etcd-0", "etcd", "etcd", "logs", "previous.log"),
+		"2024-03-01T09:00:00.000000000Z starting\nnot a timestamped line\n2024-03-01T09:30:00.500000000Z stopping\n")
+	writeFile(t, filepath.Join(dir, "namespaces", "openshift-etcd", "pods", "etcd-0", "etcd", "etcd", "logs", "current.log"), "")
+	writeFile(t, filepath.Join(dir, "cluster-scoped-resources", "config.openshift.io", "clusteroperators", "etcd.yaml"), `
+apiVersion: config.openshift.io/v1
+kind: ClusterOperator
+metadata:
+  name: etcd
+status:
+  conditions:
+  - type: Degraded
+    status: "True"
+    reason: MembersDown
+    message: one member is down
+    lastTransitionTime: "2024-03-01T09:45:00Z"
+`)
+	writeFile(t, filepath.Join(dir, "host_service_logs", "masters", "kubelet_service.log"),
+		`Mar 01 10:05:00.000000 master-0 kubenswrapper[1495]: I0301 10:05:00.000000    1599 prober.go:121] "Probe failed" probeType="Readiness" pod="openshift-etcd/etcd-0" podUID="1af660b3-ac3a-4182-86eb-2f74725d8415" containerName="etcd" probeResult=failure output="timeout"
+-- Boot 1234 --
+`)
+	writeFile(t, filepath.Join(dir, "namespaces", "openshift-etcd", "core", "configmaps.yaml"), "unrelated")
+
+	intervals, err := IntervalsFromMustGather(context.Background(), filepath.Dir(dir))
+	require.NoError(t, err)
+
+	bySource := map[monitorapi.IntervalSource]monitorapi.Intervals{}
+	for _, interval := range intervals {
+		bySource[interval.Source] = append(bySource[interval.Source], interval)
+	}
+
+	require.Len(t, bySource[monitorapi.SourceKubeEvent], 1)
+	assert.Equal(t, monitorapi.IntervalReason("Unhealthy"), bySource[monitorapi.SourceKubeEvent][0].Message.Reason)
+
+	require.Len(t, bySource[monitorapi.SourcePodLog], 1, "empty logs have no span")
+	podLog := bySource[monitorapi.SourcePodLog][0]
+	assert.Equal(t, "etcd", podLog.Locator.Keys[monitorapi.LocatorContainerKey])
+	assert.Equal(t, time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC), podLog.From)
+	assert.Equal(t, time.Date(2024, 3, 1, 9, 30, 0, 500000000, time.UTC), podLog.To)
+
+	require.Len(t, bySource[monitorapi.SourceClusterOperatorMonitor], 1)
+	operator := bySource[monitorapi.SourceClusterOperatorMonitor][0]
+	assert.Equal(t, monitorapi.Error, operator.Level)
+	assert.Equal(t, time.Date(2024, 3, 1, 9, 45, 0, 0, time.UTC), operator.From)
+
+	require.Len(t, bySource[monitorapi.SourceKubeletLog], 1)
+	assert.Equal(t, "master-0", bySource[monitorapi.SourceKubeletLog][0].Message.Annotations[monitorapi.AnnotationNode])
+}
+
+func TestJournalLineRegex(t *testing.T) {
+	for _, line := range []string{
+		`Mar 01 10:05:00.000000 master-0 kubenswrapper[1495]: message`,
+		`master-0.example.com Mar 01 10:05:00.000000 master-0 kubenswrapper[1495]: message`,
+	} {
+		matches := journalLineRegex.FindStringSubmatch(line)
+		require.NotNil(t, matches, line)
+		assert.Equal(t, `Mar 01 10:05:00.000000 master-0 kubenswrapper[1495]: message`, matches[1])
+		assert.Equal(t, "master-0", matches[2])
+	}
+}
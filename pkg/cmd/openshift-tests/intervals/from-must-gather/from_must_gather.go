@@ -0,0 +1,103 @@
+package from_must_gather
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/openshift/origin/pkg/cmd"
+	monitorserialization "github.com/openshift/origin/pkg/monitor/serialization"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type FromMustGatherFlags struct {
+	MustGatherDir string
+	OutputFile    string
+
+	genericclioptions.IOStreams
+}
+
+func NewFromMustGatherFlags(streams genericclioptions.IOStreams) *FromMustGatherFlags {
+	return &FromMustGatherFlags{
+		IOStreams: streams,
+	}
+}
+
+func NewFromMustGatherCommand(streams genericclioptions.IOStreams) *cobra.Command {
+	f := NewFromMustGatherFlags(streams)
+
+	cmd := &cobra.Command{
+		Use:   "from-must-gather",
+		Short: "Synthesize an intervals file from a must-gather",
+		Long: templates.LongDesc(`
+		Synthesize an intervals file from a must-gather.
+
+		Events, the time span of every container log, the last transition of every clusteroperator
+		condition, and the kubelet journals are converted to the intervals the monitor would have
+		recorded. The resulting file can be rendered with "intervals render" or passed as
+		--intervals-file to the "dev" invariant commands.
+		`),
+		PersistentPreRun: cmd.NoPrintVersion,
+		SilenceUsage:     true,
+		SilenceErrors:    true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			o, err := f.ToOptions()
+			if err != nil {
+				return err
+			}
+			return o.Run(cmd.Context())
+		},
+	}
+
+	f.BindFlags(cmd.Flags())
+
+	return cmd
+}
+
+func (f *FromMustGatherFlags) BindFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&f.MustGatherDir, "must-gather-dir", f.MustGatherDir, "The directory of an extracted must-gather.")
+	flags.StringVar(&f.OutputFile, "output-file", f.OutputFile, "The intervals file to write.  Defaults to e2e-events_must-gather.json in --must-gather-dir.")
+}
+
+func (f *FromMustGatherFlags) ToOptions() (*FromMustGatherOptions, error) {
+	if len(f.MustGatherDir) == 0 {
+		return nil, fmt.Errorf("--must-gather-dir is required")
+	}
+	outputFile := f.OutputFile
+	if len(outputFile) == 0 {
+		outputFile = filepath.Join(f.MustGatherDir, "e2e-events_must-gather.json")
+	}
+	return &FromMustGatherOptions{
+		MustGatherDir: f.MustGatherDir,
+		OutputFile:    outputFile,
+		IOStreams:     f.IOStreams,
+	}, nil
+}
+
+type FromMustGatherOptions struct {
+	MustGatherDir string
+	OutputFile    string
+
+	genericclioptions.IOStreams
+}
+
+func (o *FromMustGatherOptions) Run(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	intervals, err := IntervalsFromMustGather(ctx, o.MustGatherDir)
+	if err != nil {
+		return err
+	}
+	if len(intervals) == 0 {
+		return fmt.Errorf("no intervals could be synthesized from %s", o.MustGatherDir)
+	}
+	if err := monitorserialization.EventsToFile(o.OutputFile, intervals); err != nil {
+		return err
+	}
+	fmt.Fprintf(o.Out, "Wrote %d intervals to %s\n", len(intervals), o.OutputFile)
+	return nil
+}
@@ -0,0 +1,23 @@
+package intervals
+
+import (
+	from_must_gather "github.com/openshift/origin/pkg/cmd/openshift-tests/intervals/from-must-gather"
+	"github.com/openshift/origin/pkg/cmd/openshift-tests/intervals/render"
+	"github.com/openshift/origin/pkg/cmd/openshift-tests/intervals/schema"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func NewIntervalsCommand(streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "intervals",
+		Short:         "Work with intervals collected by a previous run",
+		SilenceErrors: true,
+	}
+	cmd.AddCommand(
+		render.NewRenderIntervalsCommand(streams),
+		from_must_gather.NewFromMustGatherCommand(streams),
+		schema.NewIntervalsSchemaCommand(streams),
+	)
+	return cmd
+}
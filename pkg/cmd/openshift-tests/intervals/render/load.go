@@ -0,0 +1,113 @@
+package render
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	monitorserialization "github.com/openshift/origin/pkg/monitor/serialization"
+	"github.com/openshift/origin/pkg/monitortests/testframework/watchevents"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	// intervalsFileRegex matches the intervals written by the monitor, e2e-timelines_*.json are subsets of them.
+	intervalsFileRegex = regexp.MustCompile(`^e2e-events.*\.json$`)
+	// partitionedIntervalsDirRegex matches the partitioned intervals written by the monitor.
+	partitionedIntervalsDirRegex = regexp.MustCompile(`^e2e-intervals`)
+	// eventListFileRegex matches gather-extra's events.json, the monitor's events_<suffix>.json, and a
+	// must-gather's namespaces/<namespace>/core/events.yaml.
+	eventListFileRegex = regexp.MustCompile(`^events.*\.(json|yaml)$`)
+)
+
+// intervalsFromDir loads every intervals file and Kubernetes event list found under dir.  Files that cannot be
+// parsed are logged and skipped, arbitrary artifact directories are full of files with similar names.
+func intervalsFromDir(ctx context.Context, dir string) (monitorapi.Intervals, error) {
+	ret := monitorapi.Intervals{}
+	seen := sets.NewString()
+	add := func(intervals monitorapi.Intervals) {
+		for _, interval := range intervals {
+			// the same event is present in the monitor intervals and in event lists collected at the end.
+			key := dedupeKey(interval)
+			if seen.Has(key) {
+				continue
+			}
+			seen.Insert(key)
+			ret = append(ret, interval)
+		}
+	}
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if !partitionedIntervalsDirRegex.MatchString(info.Name()) {
+				return nil
+			}
+			intervals, err := monitorserialization.IntervalsFromPartitionedDir(path, nil)
+			if err != nil {
+				logrus.WithError(err).Warnf("Skipping unreadable partitioned intervals %s", path)
+				return filepath.SkipDir
+			}
+			add(intervals)
+			return filepath.SkipDir
+		}
+		switch {
+		case intervalsFileRegex.MatchString(info.Name()):
+			intervals, err := monitorserialization.EventsFromFile(path)
+			if err != nil {
+				logrus.WithError(err).Warnf("Skipping unreadable intervals file %s", path)
+				return nil
+			}
+			add(intervals)
+		case eventListFileRegex.MatchString(info.Name()):
+			events, err := eventsFromFile(path)
+			if err != nil {
+				logrus.WithError(err).Warnf("Skipping unreadable events file %s", path)
+				return nil
+			}
+			add(watchevents.IntervalsFromEvents(ctx, events))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Sort(ret)
+	return ret, nil
+}
+
+// dedupeKey identifies an interval ignoring annotations that only the live monitor can add, the node roles are
+// looked up from the cluster when an event is watched but are unknown when it is loaded from a file.
+func dedupeKey(interval monitorapi.Interval) string {
+	if _, ok := interval.Message.Annotations[monitorapi.AnnotationRoles]; ok {
+		annotations := map[monitorapi.AnnotationKey]string{}
+		for k, v := range interval.Message.Annotations {
+			if k != monitorapi.AnnotationRoles {
+				annotations[k] = v
+			}
+		}
+		interval.Message.Annotations = annotations
+	}
+	return string(interval.Source) + " " + interval.String()
+}
+
+func eventsFromFile(path string) ([]corev1.Event, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	list := &corev1.EventList{}
+	if err := yaml.Unmarshal(data, list); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
@@ -0,0 +1,86 @@
+package render
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	monitorserialization "github.com/openshift/origin/pkg/monitor/serialization"
+	"github.com/openshift/origin/pkg/monitortests/testframework/watchevents"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func TestIntervalsFromDir(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	event := corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Namespace: "openshift-etcd", Name: "etcd-0.1"},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: "openshift-etcd", Name: "etcd-0"},
+		Reason:         "Unhealthy",
+		Message:        "Readiness probe failed",
+		Type:           corev1.EventTypeWarning,
+		FirstTimestamp: metav1.NewTime(start),
+		LastTimestamp:  metav1.NewTime(start),
+		Count:          1,
+	}
+	eventList, err := yaml.Marshal(&corev1.EventList{Items: []corev1.Event{event}})
+	require.NoError(t, err)
+
+	// a must-gather layout and gather-extra both contain the same event.
+	mustGatherDir := filepath.Join(dir, "must-gather", "namespaces", "openshift-etcd", "core")
+	require.NoError(t, os.MkdirAll(mustGatherDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(mustGatherDir, "events.yaml"), eventList, 0644))
+
+	monitorInterval := monitorapi.NewInterval(monitorapi.SourceDisruption, monitorapi.Error).
+		Locator(monitorapi.NewLocator().DisruptionRequiredOnly("kube-api-new-connections", "kube-api")).
+		Message(monitorapi.NewMessage().HumanMessage("disrupted")).
+		Build(start.Add(time.Minute), start.Add(2*time.Minute))
+
+	// the monitor annotates node events with the node roles, which are unknown when loading events.json.
+	nodeEvent := corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Namespace: "default", Name: "master-0.1"},
+		InvolvedObject: corev1.ObjectReference{Kind: "Node", Name: "master-0"},
+		Reason:         "NodeNotReady",
+		Message:        "Node master-0 status is now: NodeNotReady",
+		Type:           corev1.EventTypeNormal,
+		FirstTimestamp: metav1.NewTime(start.Add(3 * time.Minute)),
+		LastTimestamp:  metav1.NewTime(start.Add(3 * time.Minute)),
+		Count:          1,
+	}
+	nodeIntervals := watchevents.IntervalsFromEvents(context.Background(), []corev1.Event{nodeEvent})
+	require.Len(t, nodeIntervals, 1)
+	nodeIntervals[0].Message.Annotations[monitorapi.AnnotationRoles] = "master"
+	require.NoError(t, monitorserialization.EventsToFile(filepath.Join(dir, "e2e-events_20240301-100000.json"), monitorapi.Intervals{monitorInterval, nodeIntervals[0]}))
+	eventListJSON, err := json.Marshal(&corev1.EventList{Items: []corev1.Event{event, nodeEvent}})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "events.json"), eventListJSON, 0644))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "events-unrelated.json"), []byte("not json"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "e2e-timelines_spyglass.json"), []byte("ignored"), 0644))
+
+	// the partitioned intervals repeat the monitor interval and add one that was never written to e2e-events.
+	alertInterval := monitorapi.NewInterval(monitorapi.SourceAlert, monitorapi.Warning).
+		Locator(monitorapi.NewLocator().NodeFromName("master-1")).
+		Message(monitorapi.NewMessage().HumanMessage("firing")).
+		Build(start.Add(4*time.Minute), start.Add(5*time.Minute))
+	require.NoError(t, monitorserialization.IntervalsToPartitionedDir(filepath.Join(dir, "e2e-intervals_20240301-100000"), monitorapi.Intervals{monitorInterval, alertInterval}))
+
+	intervals, err := intervalsFromDir(context.Background(), dir)
+	require.NoError(t, err)
+	require.Len(t, intervals, 4)
+	assert.Equal(t, monitorapi.SourceKubeEvent, intervals[0].Source)
+	assert.Equal(t, monitorapi.IntervalReason("Unhealthy"), intervals[0].Message.Reason)
+	assert.Equal(t, "openshift-etcd", intervals[0].Locator.Keys[monitorapi.LocatorNamespaceKey])
+	assert.Equal(t, monitorapi.SourceDisruption, intervals[1].Source)
+	assert.Equal(t, monitorapi.IntervalReason("NodeNotReady"), intervals[2].Message.Reason)
+	assert.Equal(t, monitorapi.SourceAlert, intervals[3].Source)
+}
@@ -0,0 +1,113 @@
+package render
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/openshift/origin/pkg/cmd"
+	"github.com/openshift/origin/pkg/monitortests/testframework/timelineserializer"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type RenderIntervalsFlags struct {
+	FromDir    string
+	OutputDir  string
+	TimeSuffix string
+
+	genericclioptions.IOStreams
+}
+
+func NewRenderIntervalsFlags(streams genericclioptions.IOStreams) *RenderIntervalsFlags {
+	return &RenderIntervalsFlags{
+		IOStreams: streams,
+	}
+}
+
+func NewRenderIntervalsCommand(streams genericclioptions.IOStreams) *cobra.Command {
+	f := NewRenderIntervalsFlags(streams)
+
+	cmd := &cobra.Command{
+		Use:   "render",
+		Short: "Render the timeline html from a directory of previously collected artifacts",
+		Long: templates.LongDesc(`
+		Render the e2e-timelines json and html files from a directory of previously collected artifacts.
+
+		Every intervals file (e2e-events*.json) under the directory is loaded, along with every list of
+		Kubernetes events: gather-extra's events.json, the events*.json written by the monitor, and the
+		namespaces/*/core/events.yaml files of a must-gather. Events are converted to intervals the same
+		way the monitor records them, and duplicates are dropped, so a run's junit directory and its
+		gather-extra can be combined into one timeline.
+		`),
+		PersistentPreRun: cmd.NoPrintVersion,
+		SilenceUsage:     true,
+		SilenceErrors:    true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			o, err := f.ToOptions()
+			if err != nil {
+				return err
+			}
+			return o.Run(cmd.Context())
+		},
+	}
+
+	f.BindFlags(cmd.Flags())
+
+	return cmd
+}
+
+func (f *RenderIntervalsFlags) BindFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&f.FromDir, "from-dir", f.FromDir, "The directory of collected artifacts to read intervals and events from.")
+	flags.StringVar(&f.OutputDir, "output-dir", f.OutputDir, "The directory to write the timelines to.  Defaults to --from-dir.")
+	flags.StringVar(&f.TimeSuffix, "time-suffix", f.TimeSuffix, "Suffix added to the name of every file written.")
+}
+
+func (f *RenderIntervalsFlags) ToOptions() (*RenderIntervalsOptions, error) {
+	if len(f.FromDir) == 0 {
+		return nil, fmt.Errorf("--from-dir is required")
+	}
+	outputDir := f.OutputDir
+	if len(outputDir) == 0 {
+		outputDir = f.FromDir
+	}
+	return &RenderIntervalsOptions{
+		FromDir:    f.FromDir,
+		OutputDir:  outputDir,
+		TimeSuffix: f.TimeSuffix,
+		IOStreams:  f.IOStreams,
+	}, nil
+}
+
+type RenderIntervalsOptions struct {
+	FromDir    string
+	OutputDir  string
+	TimeSuffix string
+
+	genericclioptions.IOStreams
+}
+
+func (o *RenderIntervalsOptions) Run(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	intervals, err := intervalsFromDir(ctx, o.FromDir)
+	if err != nil {
+		return err
+	}
+	if len(intervals) == 0 {
+		return fmt.Errorf("no intervals or events found in %s", o.FromDir)
+	}
+	fmt.Fprintf(o.Out, "Loaded %d intervals from %s\n", len(intervals), o.FromDir)
+
+	if err := os.MkdirAll(o.OutputDir, 0755); err != nil {
+		return err
+	}
+	if err := timelineserializer.WriteTimelines(o.OutputDir, o.TimeSuffix, intervals); err != nil {
+		return err
+	}
+	fmt.Fprintf(o.Out, "Wrote timelines to %s\n", o.OutputDir)
+	return nil
+}
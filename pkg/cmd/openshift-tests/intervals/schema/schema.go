@@ -0,0 +1,38 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/openshift/origin/pkg/cmd"
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+func NewIntervalsSchemaCommand(streams genericclioptions.IOStreams) *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema",
+		Short: "Print the JSON schema of the intervals files",
+		Long: templates.LongDesc(`
+		Print the JSON schema of the intervals written to e2e-events*.json.
+
+		The schema is generated from the sources, levels, locator types and keys, annotation keys and
+		reasons known to this binary, and carries the version of the interval format in its $id, so
+		that consumers can validate intervals and generate code against them. Reasons are free-form
+		for the sources listed in x-externalReasonSources, they are copied from the cluster.
+		`),
+		PersistentPreRun: cmd.NoPrintVersion,
+		SilenceUsage:     true,
+		SilenceErrors:    true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := json.MarshalIndent(monitorapi.IntervalSchema(), "", "    ")
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(streams.Out, string(data))
+			return err
+		},
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"strings"
 	"syscall"
 	"time"
@@ -33,6 +34,8 @@ type RunMonitorFlags struct {
 	ExactMonitorTests   []string
 	DisableMonitorTests []string
 	FromRepository      string
+	ChaosConfigFile     string
+	CheckpointInterval  time.Duration
 
 	genericclioptions.IOStreams
 }
@@ -90,6 +93,8 @@ func (f *RunMonitorFlags) BindFlags(flags *pflag.FlagSet) {
 		fmt.Sprintf("list of exactly which monitors to enable. All others will be disabled.  Current monitors are: [%s]", strings.Join(monitorNames, ", ")))
 	flags.StringSliceVar(&f.DisableMonitorTests, "disable-monitor", f.DisableMonitorTests, "list of monitors to disable.  Defaults for others will be honored.")
 	flags.StringVar(&f.FromRepository, "from-repository", f.FromRepository, "A container image repository to retrieve test images from.")
+	flags.DurationVar(&f.CheckpointInterval, "checkpoint-interval", f.CheckpointInterval, "Evaluate the monitor tests and write their junit and intervals to checkpoints/ of --artifact-dir at this interval while the monitor keeps running, for instance 1h.  Disabled by default.")
+	flags.StringVar(&f.ChaosConfigFile, "chaos-config", f.ChaosConfigFile, "A yaml file of faults to inject into the cluster while the monitor runs: killing control plane pods, restarting kubelets and delaying the kube-apiserver.  Each fault is recorded as an interval.")
 }
 
 func (f *RunMonitorFlags) ToOptions() (*RunMonitorOptions, error) {
@@ -109,12 +114,17 @@ func (f *RunMonitorFlags) ToOptions() (*RunMonitorOptions, error) {
 		return nil, err
 	}
 
+	if f.CheckpointInterval < 0 {
+		return nil, fmt.Errorf("--checkpoint-interval must not be negative")
+	}
+
 	return &RunMonitorOptions{
-		ArtifactDir:     f.ArtifactDir,
-		DisplayFilterFn: displayFilterFn,
-		MonitorTests:    monitorTestRegistry,
-		IOStreams:       f.IOStreams,
-		FromRepository:  f.FromRepository,
+		ArtifactDir:        f.ArtifactDir,
+		DisplayFilterFn:    displayFilterFn,
+		MonitorTests:       monitorTestRegistry,
+		IOStreams:          f.IOStreams,
+		FromRepository:     f.FromRepository,
+		CheckpointInterval: f.CheckpointInterval,
 	}, nil
 }
 
@@ -123,6 +133,7 @@ func (f *RunMonitorFlags) getMonitorTestRegistry() (monitortestframework.Monitor
 		ClusterStabilityDuringTest: monitortestframework.Stable,
 		ExactMonitorTests:          f.ExactMonitorTests,
 		DisableMonitorTests:        f.DisableMonitorTests,
+		ChaosConfigFile:            f.ChaosConfigFile,
 	}
 	return defaultmonitortests.NewMonitorTestsFor(monitorTestInfo)
 }
@@ -132,6 +143,8 @@ type RunMonitorOptions struct {
 	DisplayFilterFn monitorapi.EventIntervalMatchesFunc
 	MonitorTests    monitortestframework.MonitorTestRegistry
 	FromRepository  string
+	// CheckpointInterval is how often the monitor tests are evaluated while the monitor runs, zero disables it.
+	CheckpointInterval time.Duration
 
 	genericclioptions.IOStreams
 }
@@ -141,6 +154,9 @@ type RunMonitorOptions struct {
 // condition intervals (all non-instantaneous events) are reported to Out.
 func (o *RunMonitorOptions) Run() error {
 	// set globals so that helpers will create pods with the mapped images if we create them from this process.
+	if err := imagesetup.InitializeImageMirrors(); err != nil {
+		return err
+	}
 	image.InitializeImages(o.FromRepository)
 
 	fmt.Fprintf(o.Out, "Starting the monitor.\n")
@@ -169,7 +185,18 @@ func (o *RunMonitorOptions) Run() error {
 	}()
 	signal.Notify(abortCh, syscall.SIGINT, syscall.SIGTERM)
 
-	recorder := monitor.WrapWithJSONLRecorder(monitor.NewRecorder(), o.Out, o.DisplayFilterFn)
+	// the monitor can run for days, so bound how many intervals it holds in memory.
+	boundedRecorder, err := monitor.NewBoundedRecorder(o.ArtifactDir, monitor.DefaultRecorderBatchSize, monitor.DefaultRecorderMaxInMemory)
+	if err != nil {
+		return err
+	}
+	// the spilled intervals are in the serialized artifacts by the time we return.
+	defer func() {
+		if err := boundedRecorder.Close(); err != nil {
+			fmt.Fprintf(o.ErrOut, "error removing the recorder spill file: %v\n", err)
+		}
+	}()
+	recorder := monitor.WrapWithJSONLRecorder(boundedRecorder, o.Out, o.DisplayFilterFn)
 	m := monitor.NewMonitor(
 		recorder,
 		restConfig,
@@ -181,6 +208,9 @@ func (o *RunMonitorOptions) Run() error {
 	}
 	fmt.Fprintf(o.Out, "Monitor started, waiting for ctrl+C to stop...\n")
 
+	if o.CheckpointInterval > 0 {
+		go o.runCheckpoints(ctx, m)
+	}
 	<-ctx.Done()
 
 	fmt.Fprintf(o.Out, "Monitor shutting down, this may take up to twenty minutes...\n")
@@ -191,6 +221,9 @@ func (o *RunMonitorOptions) Run() error {
 	if _, err := m.Stop(cleanupContext); err != nil {
 		fmt.Fprintf(os.Stderr, "error cleaning up, still reporting as best as possible: %v\n", err)
 	}
+	stats := boundedRecorder.Stats()
+	fmt.Fprintf(o.Out, "Recorder held %d intervals in memory and spilled %d to disk, with a maximum queue depth of %d over %d flushes.\n",
+		stats.InMemory, stats.Spilled, stats.MaxQueueDepth, stats.Flushes)
 
 	// Store events to artifact directory
 	if err := m.SerializeResults(ctx, "invariants", ""); err != nil {
@@ -199,3 +232,27 @@ func (o *RunMonitorOptions) Run() error {
 
 	return nil
 }
+
+// runCheckpoints evaluates the monitor tests every CheckpointInterval until ctx is done, so that long soak runs report
+// progressively instead of only once they stop.
+func (o *RunMonitorOptions) runCheckpoints(ctx context.Context, m monitor.Interface) {
+	ticker := time.NewTicker(o.CheckpointInterval)
+	defer ticker.Stop()
+	for checkpoint := 1; ; checkpoint++ {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		name := fmt.Sprintf("%04d", checkpoint)
+		fmt.Fprintf(o.Out, "Evaluating checkpoint %s...\n", name)
+		resultState, err := m.Checkpoint(ctx, name)
+		if err != nil {
+			if ctx.Err() == nil {
+				fmt.Fprintf(o.ErrOut, "error writing checkpoint %s: %v\n", name, err)
+			}
+			continue
+		}
+		fmt.Fprintf(o.Out, "Checkpoint %s %s, written to %s\n", name, resultState, filepath.Join(o.ArtifactDir, "checkpoints", name))
+	}
+}
@@ -1,7 +1,6 @@
 package timeline
 
 import (
-	"bytes"
 	"fmt"
 	"regexp"
 	"strings"
@@ -90,7 +89,7 @@ func NewTimelineCommand(ioStreams genericclioptions.IOStreams) *cobra.Command {
 }
 
 func (o *TimelineOptions) Bind(flagset *pflag.FlagSet) error {
-	flagset.StringVarP(&o.MonitorEventFilename, "filename", "f", o.MonitorEventFilename, "raw-monitor-events.json file")
+	flagset.StringVarP(&o.MonitorEventFilename, "filename", "f", o.MonitorEventFilename, "raw-monitor-events.json file or partitioned intervals directory")
 	flagset.StringSliceVar(&o.Namespaces, "namespace", o.Namespaces, "namespaces to filter.  No entry is no filtering.")
 	flagset.StringVarP(&o.OutputType, "output", "o", o.OutputType, fmt.Sprintf("type of output: [%s]", strings.Join(sets.StringKeySet(o.KnownRenderers).List(), ",")))
 	flagset.StringVar(&o.TimelineType, "type", o.TimelineType, "type of timeline to produce: "+strings.Join(sets.StringKeySet(o.KnownTimelines).List(), ","))
@@ -194,7 +193,7 @@ type Timeline struct {
 }
 
 func (o *Timeline) Run() error {
-	consumedEvents, err := monitorserialization.EventsFromFile(o.MonitorEventFilename)
+	consumedEvents, err := monitorserialization.IntervalsFromPath(o.MonitorEventFilename)
 	if err != nil {
 		return err
 	}
@@ -242,15 +241,5 @@ func (o *Timeline) Run() error {
 }
 
 func renderHTML(events monitorapi.Intervals) ([]byte, error) {
-	eventIntervalsJSON, err := monitorserialization.EventsIntervalsToJSON(events)
-	if err != nil {
-		return nil, err
-
-	}
-	e2eChartTemplate := testdata.MustAsset("e2echart/e2e-chart-template.html")
-	e2eChartTitle := "Timeline"
-	e2eChartHTML := bytes.ReplaceAll(e2eChartTemplate, []byte("EVENT_INTERVAL_TITLE_GOES_HERE"), []byte(e2eChartTitle))
-	e2eChartHTML = bytes.ReplaceAll(e2eChartHTML, []byte("EVENT_INTERVAL_JSON_GOES_HERE"), eventIntervalsJSON)
-
-	return e2eChartHTML, nil
+	return timelineserializer.RenderChartHTML(testdata.MustAsset("e2echart/e2e-chart-template.html"), "Timeline", events)
 }
@@ -0,0 +1,307 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+
+	monitorserialization "github.com/openshift/origin/pkg/monitor/serialization"
+	"github.com/openshift/origin/pkg/monitortests/testframework/disruptionserializer"
+	"github.com/openshift/origin/pkg/monitortests/testframework/timelineserializer"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	"github.com/openshift/origin/test/extended/testdata"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// report is what the html report shows.
+type report struct {
+	Properties []*junitapi.TestSuiteProperty
+
+	Total   int
+	Passed  int
+	Failed  int
+	Flaked  int
+	Skipped int
+
+	Failures []testResult
+	Flakes   []testResult
+
+	Disruptions []disruption
+	// Timeline is the html of the spyglass timeline, embedded in the report.
+	Timeline  string
+	Artifacts []artifact
+}
+
+type testResult struct {
+	Name    string
+	Outputs []string
+}
+
+type disruption struct {
+	Name     string
+	Duration string
+	Messages []string
+}
+
+type artifact struct {
+	Name string
+	Link string
+}
+
+// testOutcome collects the results of a test across the junits, a test that failed and passed flaked.
+type testOutcome struct {
+	failures []string
+	passed   bool
+	skipped  bool
+}
+
+// loadReport reads the junits, the backend disruption and the intervals written to a --junit-dir.  The links to the
+// artifacts are relative to the directory the report is written to.
+func loadReport(dir, outputDir string) (*report, error) {
+	junitFiles, err := filepath.Glob(filepath.Join(dir, "junit_e2e_*.xml"))
+	if err != nil {
+		return nil, err
+	}
+	if len(junitFiles) == 0 {
+		return nil, fmt.Errorf("no junit_e2e_*.xml found in %s", dir)
+	}
+
+	ret := &report{}
+	outcomes := map[string]*testOutcome{}
+	for _, junitFile := range junitFiles {
+		properties, testCases, err := readJUnit(junitFile)
+		if err != nil {
+			return nil, err
+		}
+		ret.Properties = append(ret.Properties, properties...)
+		for _, testCase := range testCases {
+			outcome, ok := outcomes[testCase.Name]
+			if !ok {
+				outcome = &testOutcome{}
+				outcomes[testCase.Name] = outcome
+			}
+			switch {
+			case testCase.FailureOutput != nil:
+				outcome.failures = append(outcome.failures, testCase.FailureOutput.Output)
+			case testCase.SkipMessage != nil:
+				outcome.skipped = true
+			default:
+				outcome.passed = true
+			}
+		}
+	}
+	for _, name := range sets.List(sets.KeySet(outcomes)) {
+		outcome := outcomes[name]
+		ret.Total++
+		switch {
+		case len(outcome.failures) > 0 && outcome.passed:
+			ret.Flaked++
+			ret.Flakes = append(ret.Flakes, testResult{Name: name, Outputs: outcome.failures})
+		case len(outcome.failures) > 0:
+			ret.Failed++
+			ret.Failures = append(ret.Failures, testResult{Name: name, Outputs: outcome.failures})
+		case outcome.skipped:
+			ret.Skipped++
+		default:
+			ret.Passed++
+		}
+	}
+
+	if ret.Disruptions, err = loadDisruptions(dir); err != nil {
+		return nil, err
+	}
+	if ret.Timeline, err = loadTimeline(dir); err != nil {
+		return nil, err
+	}
+	if ret.Artifacts, err = listArtifacts(dir, outputDir); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// readJUnit returns the properties and the test cases of a junit written by openshift-tests.
+func readJUnit(path string) ([]*junitapi.TestSuiteProperty, []*junitapi.JUnitTestCase, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	suite := &junitapi.JUnitTestSuite{}
+	if err := xml.Unmarshal(data, suite); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse junit %q: %w", path, err)
+	}
+	// the properties of the suite are written as property elements, which JUnitTestSuite does not read back.
+	properties := &struct {
+		Properties []*junitapi.TestSuiteProperty `xml:"property"`
+	}{}
+	if err := xml.Unmarshal(data, properties); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse junit %q: %w", path, err)
+	}
+	return properties.Properties, suite.TestCases, nil
+}
+
+// loadDisruptions lists the disrupted backends, the most disrupted first.
+func loadDisruptions(dir string) ([]disruption, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "backend-disruption*.json"))
+	if err != nil {
+		return nil, err
+	}
+	backends := []*disruptionserializer.BackendDisruption{}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		list := &disruptionserializer.BackendDisruptionList{}
+		if err := json.Unmarshal(data, list); err != nil {
+			return nil, fmt.Errorf("unable to parse backend disruption %q: %w", file, err)
+		}
+		for _, backend := range list.BackendDisruptions {
+			if backend.DisruptedDuration.Duration > 0 {
+				backends = append(backends, backend)
+			}
+		}
+	}
+	sort.Slice(backends, func(i, j int) bool {
+		if backends[i].DisruptedDuration.Duration != backends[j].DisruptedDuration.Duration {
+			return backends[i].DisruptedDuration.Duration > backends[j].DisruptedDuration.Duration
+		}
+		return backends[i].Name < backends[j].Name
+	})
+	ret := []disruption{}
+	for _, backend := range backends {
+		ret = append(ret, disruption{
+			Name:     backend.Name,
+			Duration: backend.DisruptedDuration.Duration.String(),
+			Messages: backend.DisruptionMessages,
+		})
+	}
+	return ret, nil
+}
+
+// loadTimeline renders the spyglass timeline of the intervals files in the directory.
+func loadTimeline(dir string) (string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "e2e-events*.json"))
+	if err != nil {
+		return "", err
+	}
+	for _, file := range files {
+		intervals, err := monitorserialization.EventsFromFile(file)
+		if err != nil {
+			logrus.WithError(err).Warnf("Skipping unreadable intervals file %s", file)
+			continue
+		}
+		timeline, err := timelineserializer.RenderChartHTML(testdata.MustAsset("e2echart/e2e-chart-template.html"), "Timeline",
+			intervals.Filter(timelineserializer.BelongsInSpyglass))
+		if err != nil {
+			return "", err
+		}
+		// a run writes a single intervals file
+		return string(timeline), nil
+	}
+	return "", nil
+}
+
+// listArtifacts links to the files and directories of the run.
+func listArtifacts(dir, outputDir string) ([]artifact, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	ret := []artifact{}
+	for _, entry := range entries {
+		link, err := filepath.Rel(outputDir, filepath.Join(dir, entry.Name()))
+		if err != nil {
+			link = filepath.Join(dir, entry.Name())
+		}
+		name := entry.Name()
+		if entry.IsDir() {
+			name += "/"
+		}
+		ret = append(ret, artifact{Name: name, Link: filepath.ToSlash(link)})
+	}
+	return ret, nil
+}
+
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>openshift-tests report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; }
+td, th { border: 1px solid #ccc; padding: 0.3em 0.6em; text-align: left; vertical-align: top; }
+pre { background: #f6f6f6; padding: 0.6em; overflow-x: auto; white-space: pre-wrap; }
+.failed { color: #c00; }
+.flaked { color: #b60; }
+.passed { color: #070; }
+iframe { width: 100%; height: 80em; border: 1px solid #ccc; }
+</style>
+</head>
+<body>
+<h1>openshift-tests report</h1>
+{{- if .Properties}}
+<table>
+{{- range .Properties}}
+<tr><th>{{.Name}}</th><td>{{.Value}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+
+<h2>Results</h2>
+<p>{{.Total}} tests: <span class="passed">{{.Passed}} passed</span>, <span class="failed">{{.Failed}} failed</span>, <span class="flaked">{{.Flaked}} flaked</span>, {{.Skipped}} skipped.</p>
+{{- if .Failures}}
+<h3 class="failed">Failed</h3>
+{{- range .Failures}}
+<details><summary>{{.Name}}</summary>{{range .Outputs}}<pre>{{.}}</pre>{{end}}</details>
+{{- end}}
+{{- end}}
+{{- if .Flakes}}
+<h3 class="flaked">Flaked</h3>
+{{- range .Flakes}}
+<details><summary>{{.Name}}</summary>{{range .Outputs}}<pre>{{.}}</pre>{{end}}</details>
+{{- end}}
+{{- end}}
+
+<h2>Disruption</h2>
+{{- if .Disruptions}}
+<table>
+<tr><th>Backend</th><th>Disrupted</th><th>Messages</th></tr>
+{{- range .Disruptions}}
+<tr><td>{{.Name}}</td><td>{{.Duration}}</td><td>{{range .Messages}}{{.}}<br>{{end}}</td></tr>
+{{- end}}
+</table>
+{{- else}}
+<p>No backend was disrupted.</p>
+{{- end}}
+
+<h2>Timeline</h2>
+{{- if .Timeline}}
+<iframe srcdoc="{{.Timeline}}"></iframe>
+{{- else}}
+<p>No intervals were recorded.</p>
+{{- end}}
+
+<h2>Artifacts</h2>
+<ul>
+{{- range .Artifacts}}
+<li><a href="{{.Link}}">{{.Name}}</a></li>
+{{- end}}
+</ul>
+</body>
+</html>
+`))
+
+func renderReport(r *report) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := reportTemplate.Execute(buf, r); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,100 @@
+package report
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/openshift/origin/pkg/cmd"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type ReportFlags struct {
+	FromDir    string
+	OutputFile string
+
+	genericclioptions.IOStreams
+}
+
+func NewReportFlags(streams genericclioptions.IOStreams) *ReportFlags {
+	return &ReportFlags{
+		IOStreams: streams,
+	}
+}
+
+func NewReportCommand(streams genericclioptions.IOStreams) *cobra.Command {
+	f := NewReportFlags(streams)
+
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Render a single html report of a run from its --junit-dir",
+		Long: templates.LongDesc(`
+		Render a single html report of a run from the directory passed to run with --junit-dir.
+
+		The report lists the failed and flaky tests with their output, summarizes the backend disruption,
+		charts the intervals of the run and links to every artifact in the directory. It is meant for runs
+		outside of CI, where no spyglass renders the artifacts. The timeline loads its charting library
+		from the internet.
+		`),
+		PersistentPreRun: cmd.NoPrintVersion,
+		SilenceUsage:     true,
+		SilenceErrors:    true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			o, err := f.ToOptions()
+			if err != nil {
+				return err
+			}
+			return o.Run()
+		},
+	}
+
+	f.BindFlags(cmd.Flags())
+
+	return cmd
+}
+
+func (f *ReportFlags) BindFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&f.FromDir, "from-dir", f.FromDir, "The --junit-dir of the run to report on.")
+	flags.StringVarP(&f.OutputFile, "output", "o", f.OutputFile, "The html file to write.  Defaults to report.html in --from-dir, so that the links to the artifacts resolve.")
+}
+
+func (f *ReportFlags) ToOptions() (*ReportOptions, error) {
+	if len(f.FromDir) == 0 {
+		return nil, fmt.Errorf("--from-dir is required")
+	}
+	outputFile := f.OutputFile
+	if len(outputFile) == 0 {
+		outputFile = filepath.Join(f.FromDir, "report.html")
+	}
+	return &ReportOptions{
+		FromDir:    f.FromDir,
+		OutputFile: outputFile,
+		IOStreams:  f.IOStreams,
+	}, nil
+}
+
+type ReportOptions struct {
+	FromDir    string
+	OutputFile string
+
+	genericclioptions.IOStreams
+}
+
+func (o *ReportOptions) Run() error {
+	report, err := loadReport(o.FromDir, filepath.Dir(o.OutputFile))
+	if err != nil {
+		return err
+	}
+	content, err := renderReport(report)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(o.OutputFile, content, 0644); err != nil {
+		return err
+	}
+	fmt.Fprintf(o.Out, "Wrote the report of %d tests to %s\n", report.Total, o.OutputFile)
+	return nil
+}
@@ -0,0 +1,74 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	monitorserialization "github.com/openshift/origin/pkg/monitor/serialization"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testJUnit = `<testsuite name="openshift-tests" tests="5" skipped="1" failures="2" time="3600">
+    <property name="ClusterPlatform" value="aws"></property>
+    <testcase name="[sig-node] passed" time="1"></testcase>
+    <testcase name="[sig-node] skipped" time="0"><skipped message="skip [node.go:1]: not supported"></skipped></testcase>
+    <testcase name="[sig-node] failed" time="1"><failure message="">fail [node.go:2]: &lt;boom&gt;</failure></testcase>
+    <testcase name="[sig-network] flaked" time="1"><failure message="">flake: dial timeout</failure></testcase>
+    <testcase name="[sig-network] flaked" time="1"></testcase>
+</testsuite>
+`
+
+const testDisruption = `{
+    "BackendDisruptions": {
+        "kube-api-new-connections": {"Name": "kube-api-new-connections", "DisruptedDuration": "3s", "DisruptionMessages": ["kube-api stopped responding"]},
+        "oauth-api-new-connections": {"Name": "oauth-api-new-connections", "DisruptedDuration": "0s"}
+    }
+}`
+
+func TestReport(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "junit_e2e__20240301-100000.xml"), []byte(testJUnit), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "backend-disruption_20240301-100000.json"), []byte(testDisruption), 0644))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "gather-extra"), 0755))
+
+	r, err := loadReport(dir, dir)
+	require.NoError(t, err)
+	assert.Equal(t, 4, r.Total)
+	assert.Equal(t, []int{1, 1, 1, 1}, []int{r.Passed, r.Failed, r.Flaked, r.Skipped})
+	require.Len(t, r.Failures, 1)
+	assert.Equal(t, []string{"fail [node.go:2]: <boom>"}, r.Failures[0].Outputs)
+	require.Len(t, r.Disruptions, 1, "backends that were not disrupted are not listed")
+	assert.Equal(t, "3s", r.Disruptions[0].Duration)
+	assert.Empty(t, r.Timeline)
+	assert.Equal(t, []artifact{
+		{Name: "backend-disruption_20240301-100000.json", Link: "backend-disruption_20240301-100000.json"},
+		{Name: "gather-extra/", Link: "gather-extra"},
+		{Name: "junit_e2e__20240301-100000.xml", Link: "junit_e2e__20240301-100000.xml"},
+	}, r.Artifacts)
+
+	content, err := renderReport(r)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "<th>ClusterPlatform</th><td>aws</td>")
+	assert.Contains(t, string(content), "<pre>fail [node.go:2]: &lt;boom&gt;</pre>", "output is escaped")
+	assert.Contains(t, string(content), "<td>kube-api-new-connections</td><td>3s</td>")
+
+	interval := monitorapi.NewInterval(monitorapi.SourceDisruption, monitorapi.Error).
+		Locator(monitorapi.NewLocator().Disruption("kube-api-new-connections", "kube-api", "external-lb", "http1", "", monitorapi.NewConnectionType)).
+		Message(monitorapi.NewMessage().Reason(monitorapi.DisruptionBeganEventReason).HumanMessage("kube-api stopped responding")).
+		Display().
+		Build(time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), time.Date(2024, 3, 1, 10, 0, 3, 0, time.UTC))
+	require.NoError(t, monitorserialization.EventsToFile(filepath.Join(dir, "e2e-events_20240301-100000.json"), monitorapi.Intervals{interval}))
+	r, err = loadReport(dir, dir)
+	require.NoError(t, err)
+	assert.Contains(t, r.Timeline, "kube-api stopped responding")
+	content, err = renderReport(r)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `<iframe srcdoc="&lt;html lang=&#34;en&#34;&gt;`, "the timeline is embedded")
+
+	_, err = loadReport(t.TempDir(), dir)
+	assert.Error(t, err, "no junit")
+}
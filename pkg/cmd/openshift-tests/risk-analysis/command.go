@@ -20,7 +20,14 @@ files after an invocation of openshift-tests. If multiple files are present
 (multiple invocations of openshift-tests) we will merge them into one.
 Results are then submitted to sippy which will return an analysis of per-test
 and overall risk level given historical pass rates on the failed tests.
-The resulting analysis is then also written to the junit artifacts directory.
+The resulting analysis is then also written to the junit artifacts directory,
+and the risk level of each failed test is added to the junit xml files as
+properties and written to risk-analysis-test-scores.json.
+
+In disconnected environments, --historical-pass-rates-file analyzes the risk
+locally from the pass rates of each test instead.  --test-renames-file adds the
+pass rates of the previous names of renamed tests to those of their current
+names.
 `),
 
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -34,5 +41,11 @@ The resulting analysis is then also written to the junit artifacts directory.
 	cmd.Flags().StringVar(&riskAnalysisOpts.SippyURL,
 		"sippy-url", sippyDefaultURL,
 		"Sippy URL API endpoint")
+	cmd.Flags().StringVar(&riskAnalysisOpts.HistoricalPassRatesFile,
+		"historical-pass-rates-file", riskAnalysisOpts.HistoricalPassRatesFile,
+		"A json list of {Name, Runs, Passes} for each test.  When set the risk is analyzed locally instead of requesting it from sippy.")
+	cmd.Flags().StringVar(&riskAnalysisOpts.TestRenamesFile,
+		"test-renames-file", riskAnalysisOpts.TestRenamesFile,
+		"A yaml file listing renamed tests by from and to name.  Used with --historical-pass-rates-file to find the history of renamed tests.")
 	return cmd
 }
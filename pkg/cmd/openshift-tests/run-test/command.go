@@ -41,7 +41,13 @@ func NewRunTestCommand(streams genericclioptions.IOStreams) *cobra.Command {
 
 			// set globals so that helpers will create pods with the mapped images if we create them from this process.
 			// we cannot eliminate the env var usage until we convert run-test, which we may be able to do in a followup.
+			if err := imagesetup.InitializeImageMirrors(); err != nil {
+				return err
+			}
 			image.InitializeImages(os.Getenv("KUBE_TEST_REPO"))
+			if err := imagesetup.MirrorUpstreamImages(); err != nil {
+				return err
+			}
 
 			if err := imagesetup.VerifyImages(); err != nil {
 				return err
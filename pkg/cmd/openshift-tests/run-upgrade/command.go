@@ -12,7 +12,7 @@ import (
 )
 
 func NewRunUpgradeCommand(streams genericclioptions.IOStreams) *cobra.Command {
-	f := NewRunUpgradeSuiteFlags(streams, imagesetup.DefaultTestImageMirrorLocation, testsuites.UpgradeTestSuites())
+	f := NewRunUpgradeSuiteFlags(streams, imagesetup.DefaultTestImageMirrorLocation, testsuites.UpgradeTestSuites(), testsuites.StandardTestSuites())
 
 	cmd := &cobra.Command{
 		Use:   "run-upgrade SUITE",
@@ -35,6 +35,14 @@ func NewRunUpgradeCommand(streams genericclioptions.IOStreams) *cobra.Command {
 		the reboot will allow the node to shut down services in an orderly fashion. If set to 'force' the
 		machine will terminate immediately without clean shutdown.
 
+		--to-image accepts a comma-separated chain of payloads to upgrade through in order, for
+		example for EUS-to-EUS upgrades.  Each upgrade is recorded as a separate UpgradeHop interval
+		and disruption is additionally evaluated per hop.
+
+		Pass --post-upgrade-suite with the name of a standard suite to run it against the upgraded
+		cluster once the upgrade completes.  The monitor covers both phases and a single set of
+		results is written.
+
 		`) + testsuites.SuitesString(testsuites.UpgradeTestSuites(), "\n\nAvailable upgrade suites:\n\n"),
 
 		SilenceUsage:  true,
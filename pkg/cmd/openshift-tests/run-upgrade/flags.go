@@ -2,6 +2,8 @@ package run_upgrade
 
 import (
 	"fmt"
+	"strings"
+
 	"github.com/openshift/origin/pkg/clioptions/clusterdiscovery"
 	"github.com/openshift/origin/pkg/clioptions/iooptions"
 	"github.com/openshift/origin/pkg/clioptions/kubeconfig"
@@ -17,6 +19,8 @@ type RunUpgradeSuiteFlags struct {
 	TestSuiteSelectionFlags *suiteselection.TestSuiteSelectionFlags
 	OutputFlags             *iooptions.OutputFlags
 	AvailableSuites         []*testginkgo.TestSuite
+	// PostUpgradeSuites are the suites that may be chained after the upgrade with --post-upgrade-suite.
+	PostUpgradeSuites []*testginkgo.TestSuite
 
 	FromRepository     string
 	ProviderTypeOrJSON string
@@ -25,6 +29,8 @@ type RunUpgradeSuiteFlags struct {
 	UpgradeSuite string
 	ToImage      string
 	TestOptions  []string
+	// PostUpgradeSuite names a suite to run against the upgraded cluster in the same invocation.
+	PostUpgradeSuite string
 
 	// Shared by initialization code
 	config *clusterdiscovery.ClusterConfiguration
@@ -32,12 +38,13 @@ type RunUpgradeSuiteFlags struct {
 	genericclioptions.IOStreams
 }
 
-func NewRunUpgradeSuiteFlags(streams genericclioptions.IOStreams, fromRepository string, availableSuites []*testginkgo.TestSuite) *RunUpgradeSuiteFlags {
+func NewRunUpgradeSuiteFlags(streams genericclioptions.IOStreams, fromRepository string, availableSuites, postUpgradeSuites []*testginkgo.TestSuite) *RunUpgradeSuiteFlags {
 	return &RunUpgradeSuiteFlags{
 		GinkgoRunSuiteOptions:   testginkgo.NewGinkgoRunSuiteOptions(streams),
 		TestSuiteSelectionFlags: suiteselection.NewTestSuiteSelectionFlags(streams),
 		OutputFlags:             iooptions.NewOutputOptions(),
 		AvailableSuites:         availableSuites,
+		PostUpgradeSuites:       postUpgradeSuites,
 
 		FromRepository: fromRepository,
 		IOStreams:      streams,
@@ -47,8 +54,9 @@ func NewRunUpgradeSuiteFlags(streams genericclioptions.IOStreams, fromRepository
 func (f *RunUpgradeSuiteFlags) BindFlags(flags *pflag.FlagSet) {
 	flags.StringVar(&f.FromRepository, "from-repository", f.FromRepository, "A container image repository to retrieve test images from.")
 	flags.StringVar(&f.ProviderTypeOrJSON, "provider", f.ProviderTypeOrJSON, "The cluster infrastructure provider. Will automatically default to the correct value.")
-	flags.StringVar(&f.ToImage, "to-image", f.ToImage, "Specify the image to test an upgrade to.")
+	flags.StringVar(&f.ToImage, "to-image", f.ToImage, "Specify the image to test an upgrade to.  A comma-separated list upgrades through each image in turn, for example for EUS-to-EUS upgrades.")
 	flags.StringSliceVar(&f.TestOptions, "options", f.TestOptions, "A set of KEY=VALUE options to control the test. See the help text.")
+	flags.StringVar(&f.PostUpgradeSuite, "post-upgrade-suite", f.PostUpgradeSuite, "The name of a suite, such as openshift/conformance, to run against the cluster after the upgrade completes.  Both phases share one monitor session and one set of results.")
 	f.GinkgoRunSuiteOptions.BindFlags(flags)
 	f.TestSuiteSelectionFlags.BindFlags(flags)
 	f.OutputFlags.BindFlags(flags)
@@ -60,6 +68,13 @@ func (f *RunUpgradeSuiteFlags) SetIOStreams(streams genericclioptions.IOStreams)
 }
 
 func (f *RunUpgradeSuiteFlags) ToOptions(args []string) (*RunUpgradeSuiteOptions, error) {
+	if err := f.GinkgoRunSuiteOptions.Validate(); err != nil {
+		return nil, err
+	}
+	if err := f.TestSuiteSelectionFlags.Validate(); err != nil {
+		return nil, err
+	}
+
 	adminRESTConfig, err := kubeconfig.GetStaticRESTConfig()
 	if err != nil {
 		return nil, err
@@ -79,6 +94,11 @@ func (f *RunUpgradeSuiteFlags) ToOptions(args []string) (*RunUpgradeSuiteOptions
 	if len(f.ToImage) == 0 {
 		return nil, fmt.Errorf("--to-image must be specified to run an upgrade test")
 	}
+	for _, toImage := range strings.Split(f.ToImage, ",") {
+		if len(strings.TrimSpace(toImage)) == 0 {
+			return nil, fmt.Errorf("--to-image must not contain empty entries: %q", f.ToImage)
+		}
+	}
 
 	suite, err := f.TestSuiteSelectionFlags.SelectSuite(
 		f.AvailableSuites,
@@ -92,6 +112,22 @@ func (f *RunUpgradeSuiteFlags) ToOptions(args []string) (*RunUpgradeSuiteOptions
 		return nil, err
 	}
 
+	if len(f.PostUpgradeSuite) > 0 {
+		// the --run and --file selection only applies to the upgrade suite
+		postUpgradeSuite, err := suiteselection.NewTestSuiteSelectionFlags(f.IOStreams).SelectSuite(
+			f.PostUpgradeSuites,
+			[]string{f.PostUpgradeSuite},
+			kubeconfig.NewDiscoveryGetter(adminRESTConfig),
+			kubeconfig.NewConfigClientGetter(adminRESTConfig),
+			f.GinkgoRunSuiteOptions.DryRun,
+			nil,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to select --post-upgrade-suite: %w", err)
+		}
+		ginkgoOptions.PostUpgradeSuite = postUpgradeSuite
+	}
+
 	o := &RunUpgradeSuiteOptions{
 		GinkgoRunSuiteOptions: ginkgoOptions,
 		Suite:                 suite,
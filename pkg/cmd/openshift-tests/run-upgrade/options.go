@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/openshift/origin/pkg/monitortestframework"
 
+	"github.com/openshift/origin/pkg/artifactupload"
 	"github.com/openshift/origin/pkg/clioptions/clusterdiscovery"
 	"github.com/openshift/origin/pkg/clioptions/imagesetup"
 	"github.com/openshift/origin/pkg/clioptions/iooptions"
@@ -82,6 +84,9 @@ func (o *RunUpgradeSuiteOptions) Run(ctx context.Context) error {
 	// set globals so that helpers will create pods with the mapped images if we create them from this process.
 	// this must be before `verifyImages` to ensure that the argument takes precedence over the env var.
 	// we cannot eliminate the env var usage until we convert run-test, which we may be able to do in a followup.
+	if err := imagesetup.InitializeImageMirrors(); err != nil {
+		return err
+	}
 	image.InitializeImages(o.FromRepository)
 
 	if err := imagesetup.VerifyTestImageRepoEnvVarUnset(); err != nil {
@@ -100,6 +105,9 @@ func (o *RunUpgradeSuiteOptions) Run(ctx context.Context) error {
 	// this will re-write the images to be used.
 	// TODO fix the upstream so that the AfterReadingAllFlags will properly check for either of the inputs having values.
 	k8simage.Init("")
+	if err := imagesetup.MirrorUpstreamImages(); err != nil {
+		return err
+	}
 
 	if err := o.UpgradeTestPreSuite(); err != nil {
 		return err
@@ -108,9 +116,15 @@ func (o *RunUpgradeSuiteOptions) Run(ctx context.Context) error {
 	// TODO the gingkoRunSuiteOptions needs to have flags then calculated options to express specified versus computed values
 	monitorTestInfo := monitortestframework.MonitorTestInitializationInfo{
 		ClusterStabilityDuringTest:        monitortestframework.Stable,
-		UpgradeTargetPayloadImagePullSpec: o.ToImage,
+		UpgradeTargetPayloadImagePullSpec: finalUpgradeTarget(o.ToImage),
 		ExactMonitorTests:                 o.GinkgoRunSuiteOptions.ExactMonitorTests,
 		DisableMonitorTests:               o.GinkgoRunSuiteOptions.DisableMonitorTests,
+		SLOConfigFile:                     o.GinkgoRunSuiteOptions.SLOConfigFile,
+		ChaosConfigFile:                   o.GinkgoRunSuiteOptions.ChaosConfigFile,
+		PromQLRulesFile:                   o.GinkgoRunSuiteOptions.PromQLRulesFile,
+		EventNamespaceInclude:             o.GinkgoRunSuiteOptions.EventNamespaceInclude,
+		EventNamespaceExclude:             o.GinkgoRunSuiteOptions.EventNamespaceExclude,
+		EventLogVerbosity:                 o.GinkgoRunSuiteOptions.EventLogVerbosity,
 	}
 
 	o.GinkgoRunSuiteOptions.CommandEnv = o.TestCommandEnvironment()
@@ -122,5 +136,15 @@ func (o *RunUpgradeSuiteOptions) Run(ctx context.Context) error {
 		fmt.Fprintf(os.Stderr, "Suite run returned error: %s\n", exitErr.Error())
 	}
 
+	// runs outside of CI configure an upload so the artifacts outlive the host.
+	if !o.GinkgoRunSuiteOptions.DryRun {
+		artifactupload.UploadFromEnvironment(ctx, o.GinkgoRunSuiteOptions.JUnitDir)
+	}
 	return exitErr
 }
+
+// finalUpgradeTarget returns the last payload of a comma-separated chain of upgrades.
+func finalUpgradeTarget(toImage string) string {
+	hops := strings.Split(toImage, ",")
+	return strings.TrimSpace(hops[len(hops)-1])
+}
@@ -28,6 +28,15 @@ func NewRunCommand(streams genericclioptions.IOStreams) *cobra.Command {
 		command with the --file argument. You may also pipe a list of test names, one per line, on
 		standard input by passing "-f -".
 
+		To reproduce the failures of a previous run, pass its junit_e2e xml with --rerun-from. Only the
+		tests that failed are run, in the suite and with the monitors recorded in the junit.
+
+		To keep the contents of --junit-dir when running outside of CI, set
+		OPENSHIFT_TESTS_ARTIFACT_UPLOAD_URL to an s3://BUCKET/PREFIX or gs://BUCKET/PREFIX destination.
+		The directory is synced with the aws or gcloud CLI after the run, retried on failure
+		(OPENSHIFT_TESTS_ARTIFACT_UPLOAD_RETRIES), and artifact-manifest.json is uploaded last
+		to mark the upload complete.
+
 		`) + testsuites.SuitesString(testsuites.StandardTestSuites(), "\n\nAvailable test suites:\n\n"),
 
 		SilenceUsage:  true,
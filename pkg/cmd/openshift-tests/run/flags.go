@@ -22,6 +22,10 @@ type RunSuiteFlags struct {
 
 	FromRepository     string
 	ProviderTypeOrJSON string
+	// SuiteFile defines a suite outside of the registry.  It replaces the suite name argument.
+	SuiteFile string
+	// RerunFrom is the junit of a previous run whose failed tests are run again, with the same suite and monitors.
+	RerunFrom string
 
 	// Passed to the test process if set
 	UpgradeSuite string
@@ -65,6 +69,8 @@ func (f *RunSuiteFlags) SuiteWithKubeTestInitializationPreSuite() (*clusterdisco
 func (f *RunSuiteFlags) BindFlags(flags *pflag.FlagSet) {
 	flags.StringVar(&f.FromRepository, "from-repository", f.FromRepository, "A container image repository to retrieve test images from.")
 	flags.StringVar(&f.ProviderTypeOrJSON, "provider", f.ProviderTypeOrJSON, "The cluster infrastructure provider. Will automatically default to the correct value.")
+	flags.StringVar(&f.SuiteFile, "suite-file", f.SuiteFile, "A yaml file defining a suite name, test selection (labels and regex), parallelism and monitors to run instead of a registered suite.")
+	flags.StringVar(&f.RerunFrom, "rerun-from", f.RerunFrom, "The junit xml of a previous run.  Only the tests that failed in it are run, with the suite, monitors and cluster stability recorded in it unless they are given on the command line.")
 	f.GinkgoRunSuiteOptions.BindFlags(flags)
 	f.TestSuiteSelectionFlags.BindFlags(flags)
 	f.OutputFlags.BindFlags(flags)
@@ -76,6 +82,13 @@ func (f *RunSuiteFlags) SetIOStreams(streams genericclioptions.IOStreams) {
 }
 
 func (f *RunSuiteFlags) ToOptions(args []string) (*RunSuiteOptions, error) {
+	if err := f.GinkgoRunSuiteOptions.Validate(); err != nil {
+		return nil, err
+	}
+	if err := f.TestSuiteSelectionFlags.Validate(); err != nil {
+		return nil, err
+	}
+
 	adminRESTConfig, err := kubeconfig.GetStaticRESTConfig()
 	switch {
 	case err != nil && f.GinkgoRunSuiteOptions.DryRun:
@@ -93,12 +106,55 @@ func (f *RunSuiteFlags) ToOptions(args []string) (*RunSuiteOptions, error) {
 	// shallow copy to mutate
 	ginkgoOptions := f.GinkgoRunSuiteOptions
 
+	availableSuites := f.AvailableSuites
+	if len(f.SuiteFile) > 0 {
+		if len(args) > 0 {
+			return nil, fmt.Errorf("--suite-file may not be combined with a suite name")
+		}
+		definition, err := suiteselection.LoadSuiteDefinition(f.SuiteFile)
+		if err != nil {
+			return nil, err
+		}
+		fileSuite, err := definition.TestSuite()
+		if err != nil {
+			return nil, err
+		}
+		availableSuites = []*testginkgo.TestSuite{fileSuite}
+		args = []string{fileSuite.Name}
+
+		// monitors passed on the command line take precedence over the suite file
+		if len(ginkgoOptions.ExactMonitorTests) == 0 && len(ginkgoOptions.DisableMonitorTests) == 0 {
+			ginkgoOptions.ExactMonitorTests = definition.Monitors.Enable
+			ginkgoOptions.DisableMonitorTests = definition.Monitors.Disable
+		}
+	}
+
+	var rerun *testginkgo.Rerun
+	if len(f.RerunFrom) > 0 {
+		rerun, err = testginkgo.LoadRerun(f.RerunFrom)
+		if err != nil {
+			return nil, err
+		}
+		if len(args) == 0 && len(rerun.Suite) > 0 {
+			args = []string{rerun.Suite}
+		}
+		// the monitors and stability passed on the command line take precedence over those of the previous run
+		if len(ginkgoOptions.ExactMonitorTests) == 0 && len(ginkgoOptions.DisableMonitorTests) == 0 {
+			ginkgoOptions.ExactMonitorTests = rerun.ExactMonitorTests
+			ginkgoOptions.DisableMonitorTests = rerun.DisableMonitorTests
+		}
+		if len(ginkgoOptions.ClusterStabilityDuringTest) == 0 {
+			ginkgoOptions.ClusterStabilityDuringTest = rerun.ClusterStability
+		}
+		fmt.Fprintf(f.ErrOut, "Rerunning %d tests that failed in %s\n", rerun.FailedTests.Len(), f.RerunFrom)
+	}
+
 	providerConfig, err := f.SuiteWithKubeTestInitializationPreSuite()
 	if err != nil {
 		return nil, err
 	}
 	suite, err := f.TestSuiteSelectionFlags.SelectSuite(
-		f.AvailableSuites,
+		availableSuites,
 		args,
 		kubeconfig.NewDiscoveryGetter(adminRESTConfig),
 		kubeconfig.NewConfigClientGetter(adminRESTConfig),
@@ -108,6 +164,11 @@ func (f *RunSuiteFlags) ToOptions(args []string) (*RunSuiteOptions, error) {
 	if err != nil {
 		return nil, err
 	}
+	if rerun != nil {
+		suite.AddRequiredMatchFunc(rerun.Matches)
+	}
+	// tests for another architecture are reported as skipped with the reason, rather than failing on the cluster.
+	suite.AddEnvironmentSkipFunc(providerConfig.ArchitectureSkipReason)
 
 	o := &RunSuiteOptions{
 		GinkgoRunSuiteOptions: ginkgoOptions,
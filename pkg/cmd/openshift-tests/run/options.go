@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/openshift/origin/pkg/artifactupload"
 	"github.com/openshift/origin/pkg/clioptions/clusterdiscovery"
 	"github.com/openshift/origin/pkg/clioptions/imagesetup"
 	"github.com/openshift/origin/pkg/clioptions/iooptions"
@@ -53,6 +54,9 @@ func (o *RunSuiteOptions) Run(ctx context.Context) error {
 	// set globals so that helpers will create pods with the mapped images if we create them from this process.
 	// this must be before `verifyImages` to ensure that the argument takes precedence over the env var.
 	// we cannot eliminate the env var usage until we convert run-test, which we may be able to do in a followup.
+	if err := imagesetup.InitializeImageMirrors(); err != nil {
+		return err
+	}
 	image.InitializeImages(o.FromRepository)
 
 	if err := imagesetup.VerifyTestImageRepoEnvVarUnset(); err != nil {
@@ -71,6 +75,9 @@ func (o *RunSuiteOptions) Run(ctx context.Context) error {
 	// this will re-write the images to be used.
 	// TODO fix the the upstream so that the AfterReadingAllFlags will properly check for either of the inputs having values.
 	k8simage.Init("")
+	if err := imagesetup.MirrorUpstreamImages(); err != nil {
+		return err
+	}
 
 	stabilitySetting := testginkgo.Stable
 	switch {
@@ -84,6 +91,17 @@ func (o *RunSuiteOptions) Run(ctx context.Context) error {
 		ClusterStabilityDuringTest: monitortestframework.ClusterStabilityDuringTest(stabilitySetting),
 		ExactMonitorTests:          o.GinkgoRunSuiteOptions.ExactMonitorTests,
 		DisableMonitorTests:        o.GinkgoRunSuiteOptions.DisableMonitorTests,
+		SLOConfigFile:              o.GinkgoRunSuiteOptions.SLOConfigFile,
+		ChaosConfigFile:            o.GinkgoRunSuiteOptions.ChaosConfigFile,
+		PromQLRulesFile:            o.GinkgoRunSuiteOptions.PromQLRulesFile,
+		EventNamespaceInclude:      o.GinkgoRunSuiteOptions.EventNamespaceInclude,
+		EventNamespaceExclude:      o.GinkgoRunSuiteOptions.EventNamespaceExclude,
+		EventLogVerbosity:          o.GinkgoRunSuiteOptions.EventLogVerbosity,
+	}
+
+	// the monitor tests are printed as json, nothing else may be written to stdout.
+	if o.GinkgoRunSuiteOptions.DryRunMonitors {
+		return o.GinkgoRunSuiteOptions.Run(o.Suite, "openshift-tests", monitorTestInfo, false)
 	}
 
 	o.GinkgoRunSuiteOptions.CommandEnv = o.TestCommandEnvironment()
@@ -98,5 +116,10 @@ func (o *RunSuiteOptions) Run(ctx context.Context) error {
 
 	// Special debugging carve-outs for teams is likely to age poorly.
 	clusterdiscovery.PrintStorageCapabilities(o.GinkgoRunSuiteOptions.Out)
+
+	// runs outside of CI configure an upload so the artifacts outlive the host.
+	if !o.GinkgoRunSuiteOptions.DryRun {
+		artifactupload.UploadFromEnvironment(ctx, o.GinkgoRunSuiteOptions.JUnitDir)
+	}
 	return exitErr
 }
@@ -6,44 +6,82 @@ import (
 	"github.com/openshift/origin/pkg/monitortestframework"
 	"github.com/openshift/origin/pkg/monitortests/authentication/legacyauthenticationmonitortests"
 	"github.com/openshift/origin/pkg/monitortests/authentication/requiredsccmonitortests"
+	"github.com/openshift/origin/pkg/monitortests/baremetal/baremetalhosts"
 	azuremetrics "github.com/openshift/origin/pkg/monitortests/cloud/azure/metrics"
+	"github.com/openshift/origin/pkg/monitortests/cloud/nodeinterruptions"
+	"github.com/openshift/origin/pkg/monitortests/clusterversionoperator/featuregatechanges"
 	"github.com/openshift/origin/pkg/monitortests/clusterversionoperator/legacycvomonitortests"
 	"github.com/openshift/origin/pkg/monitortests/clusterversionoperator/operatorstateanalyzer"
 	"github.com/openshift/origin/pkg/monitortests/clusterversionoperator/terminationmessagepolicy"
+	"github.com/openshift/origin/pkg/monitortests/clusterversionoperator/updaterisks"
+	"github.com/openshift/origin/pkg/monitortests/clusterversionoperator/upgradehops"
+	"github.com/openshift/origin/pkg/monitortests/clusterversionoperator/workloadrollouts"
 	"github.com/openshift/origin/pkg/monitortests/etcd/etcdloganalyzer"
+	"github.com/openshift/origin/pkg/monitortests/etcd/etcdobjectgrowth"
 	"github.com/openshift/origin/pkg/monitortests/etcd/legacyetcdmonitortests"
 	"github.com/openshift/origin/pkg/monitortests/imageregistry/disruptionimageregistry"
 	"github.com/openshift/origin/pkg/monitortests/kubeapiserver/apiservergracefulrestart"
+	"github.com/openshift/origin/pkg/monitortests/kubeapiserver/apiserverprofiles"
 	"github.com/openshift/origin/pkg/monitortests/kubeapiserver/auditloganalyzer"
+	"github.com/openshift/origin/pkg/monitortests/kubeapiserver/crdhealth"
 	"github.com/openshift/origin/pkg/monitortests/kubeapiserver/disruptionlegacyapiservers"
 	"github.com/openshift/origin/pkg/monitortests/kubeapiserver/disruptionnewapiserver"
+	"github.com/openshift/origin/pkg/monitortests/kubeapiserver/disruptionstreaming"
+	"github.com/openshift/origin/pkg/monitortests/kubeapiserver/eventwriterate"
+	"github.com/openshift/origin/pkg/monitortests/kubeapiserver/flowcontrol"
 	"github.com/openshift/origin/pkg/monitortests/kubeapiserver/legacykubeapiservermonitortests"
+	"github.com/openshift/origin/pkg/monitortests/kubeapiserver/newnodecerts"
+	"github.com/openshift/origin/pkg/monitortests/kubeapiserver/watchlag"
+	"github.com/openshift/origin/pkg/monitortests/kubecontrollermanager/garbagecollectororphans"
+	"github.com/openshift/origin/pkg/monitortests/kubecontrollermanager/quotaexhaustion"
 	"github.com/openshift/origin/pkg/monitortests/monitoring/disruptionmetricsapi"
 	"github.com/openshift/origin/pkg/monitortests/monitoring/statefulsetsrecreation"
 	"github.com/openshift/origin/pkg/monitortests/network/disruptioningress"
 	"github.com/openshift/origin/pkg/monitortests/network/disruptionpodnetwork"
+	"github.com/openshift/origin/pkg/monitortests/network/disruptionsecondarynetwork"
 	"github.com/openshift/origin/pkg/monitortests/network/disruptionserviceloadbalancer"
+	"github.com/openshift/origin/pkg/monitortests/network/egressip"
 	"github.com/openshift/origin/pkg/monitortests/network/legacynetworkmonitortests"
+	"github.com/openshift/origin/pkg/monitortests/network/proxyegress"
+	"github.com/openshift/origin/pkg/monitortests/network/servingcerts"
+	"github.com/openshift/origin/pkg/monitortests/node/acceleratorhealth"
+	"github.com/openshift/origin/pkg/monitortests/node/crashedcontainers"
+	"github.com/openshift/origin/pkg/monitortests/node/disruptionkubelet"
+	"github.com/openshift/origin/pkg/monitortests/node/imagepullbackoff"
 	"github.com/openshift/origin/pkg/monitortests/node/kubeletlogcollector"
 	"github.com/openshift/origin/pkg/monitortests/node/legacynodemonitortests"
+	"github.com/openshift/origin/pkg/monitortests/node/nodeinitialization"
 	"github.com/openshift/origin/pkg/monitortests/node/nodestateanalyzer"
+	"github.com/openshift/origin/pkg/monitortests/node/numaallocation"
+	"github.com/openshift/origin/pkg/monitortests/node/probefailures"
+	"github.com/openshift/origin/pkg/monitortests/node/spotnodetracker"
+	"github.com/openshift/origin/pkg/monitortests/node/unexpectedpoddeletion"
 	"github.com/openshift/origin/pkg/monitortests/node/watchnodes"
 	"github.com/openshift/origin/pkg/monitortests/node/watchpods"
 	"github.com/openshift/origin/pkg/monitortests/storage/legacystoragemonitortests"
 	"github.com/openshift/origin/pkg/monitortests/testframework/additionaleventscollector"
 	"github.com/openshift/origin/pkg/monitortests/testframework/alertanalyzer"
+	"github.com/openshift/origin/pkg/monitortests/testframework/chaosinjector"
 	"github.com/openshift/origin/pkg/monitortests/testframework/clusterinfoserializer"
+	"github.com/openshift/origin/pkg/monitortests/testframework/controlplaneresources"
+	"github.com/openshift/origin/pkg/monitortests/testframework/disruptionattribution"
 	"github.com/openshift/origin/pkg/monitortests/testframework/disruptionexternalawscloudservicemonitoring"
 	"github.com/openshift/origin/pkg/monitortests/testframework/disruptionexternalazurecloudservicemonitoring"
 	"github.com/openshift/origin/pkg/monitortests/testframework/disruptionexternalgcpcloudservicemonitoring"
 	"github.com/openshift/origin/pkg/monitortests/testframework/disruptionexternalservicemonitoring"
 	"github.com/openshift/origin/pkg/monitortests/testframework/disruptionserializer"
 	"github.com/openshift/origin/pkg/monitortests/testframework/e2etestanalyzer"
+	"github.com/openshift/origin/pkg/monitortests/testframework/healthdelta"
+	"github.com/openshift/origin/pkg/monitortests/testframework/intervalreasons"
 	"github.com/openshift/origin/pkg/monitortests/testframework/intervalserializer"
+	"github.com/openshift/origin/pkg/monitortests/testframework/intervaltriggers"
 	"github.com/openshift/origin/pkg/monitortests/testframework/knownimagechecker"
+	"github.com/openshift/origin/pkg/monitortests/testframework/leakedresources"
 	"github.com/openshift/origin/pkg/monitortests/testframework/legacytestframeworkmonitortests"
 	"github.com/openshift/origin/pkg/monitortests/testframework/metricsendpointdown"
 	"github.com/openshift/origin/pkg/monitortests/testframework/pathologicaleventanalyzer"
+	"github.com/openshift/origin/pkg/monitortests/testframework/promqlrules"
+	"github.com/openshift/origin/pkg/monitortests/testframework/sloevaluator"
 	"github.com/openshift/origin/pkg/monitortests/testframework/timelineserializer"
 	"github.com/openshift/origin/pkg/monitortests/testframework/trackedresourcesserializer"
 	"github.com/openshift/origin/pkg/monitortests/testframework/watchclusteroperators"
@@ -106,23 +144,46 @@ func newDefaultMonitorTests(info monitortestframework.MonitorTestInitializationI
 
 	monitorTestRegistry.AddMonitorTestOrDie("apiserver-availability", "kube-apiserver", disruptionlegacyapiservers.NewAvailabilityInvariant())
 	monitorTestRegistry.AddMonitorTestOrDie("apiserver-new-disruption-invariant", "kube-apiserver", disruptionnewapiserver.NewDisruptionInvariant())
+	monitorTestRegistry.AddMonitorTestOrDie("apiserver-event-write-rate", "kube-apiserver", eventwriterate.NewEventWriteRateCollector())
+	monitorTestRegistry.AddMonitorTestOrDie("apiserver-disruption-profiler", "kube-apiserver", apiserverprofiles.NewAPIServerProfiler())
+	monitorTestRegistry.AddMonitorTestOrDie("crd-health-tracker", "kube-apiserver", crdhealth.NewCRDHealthTracker())
+	monitorTestRegistry.AddMonitorTestOrDie("apiserver-flow-control-saturation", "kube-apiserver", flowcontrol.NewFlowControlSaturationMonitor())
+	monitorTestRegistry.AddMonitorTestOrDie("apiserver-watch-lag", "kube-apiserver", watchlag.NewWatchLagMonitor())
+	monitorTestRegistry.AddMonitorTestOrDie("apiserver-streaming-availability", "kube-apiserver", disruptionstreaming.NewStreamingAvailabilityInvariant())
+	monitorTestRegistry.AddMonitorTestOrDie("new-node-tls-artifact-ownership", "kube-apiserver", newnodecerts.NewNewNodeCertOwnership(info))
 
 	monitorTestRegistry.AddMonitorTestOrDie("pod-network-avalibility", "Network / ovn-kubernetes", disruptionpodnetwork.NewPodNetworkAvalibilityInvariant(info))
+	monitorTestRegistry.AddMonitorTestOrDie("secondary-network-availability", "Networking / multus", disruptionsecondarynetwork.NewSecondaryNetworkAvailability())
+	monitorTestRegistry.AddMonitorTestOrDie("egress-ip-tracker", "Networking / ovn-kubernetes", egressip.NewEgressIPTracker())
 	monitorTestRegistry.AddMonitorTestOrDie("service-type-load-balancer-availability", "Networking / router", disruptionserviceloadbalancer.NewAvailabilityInvariant())
+	monitorTestRegistry.AddMonitorTestOrDie("metallb-service-load-balancer-availability", "Networking / metal-lb", disruptionserviceloadbalancer.NewMetalLBAvailabilityInvariant())
 	monitorTestRegistry.AddMonitorTestOrDie("ingress-availability", "Networking / router", disruptioningress.NewAvailabilityInvariant())
+	monitorTestRegistry.AddMonitorTestOrDie("serving-cert-validity", "Networking / router", servingcerts.NewServingCertValidityChecker())
+	monitorTestRegistry.AddMonitorTestOrDie("proxy-egress-validation", "Networking / cluster-network-operator", proxyegress.NewProxyEgressValidator(info))
 
 	monitorTestRegistry.AddMonitorTestOrDie("alert-summary-serializer", "Test Framework", alertanalyzer.NewAlertSummarySerializer())
 	monitorTestRegistry.AddMonitorTestOrDie("metrics-endpoints-down", "Test Framework", metricsendpointdown.NewMetricsEndpointDown())
+	monitorTestRegistry.AddMonitorTestOrDie("promql-rules", "Test Framework", promqlrules.NewPromQLRules(info.PromQLRulesFile))
+	monitorTestRegistry.AddMonitorTestOrDie("control-plane-resource-usage", "Test Framework", controlplaneresources.NewControlPlaneResources())
 	monitorTestRegistry.AddMonitorTestOrDie("external-service-availability", "Test Framework", disruptionexternalservicemonitoring.NewAvailabilityInvariant())
 	monitorTestRegistry.AddMonitorTestOrDie("external-gcp-cloud-service-availability", "Test Framework", disruptionexternalgcpcloudservicemonitoring.NewCloudAvailabilityInvariant())
 	monitorTestRegistry.AddMonitorTestOrDie("external-aws-cloud-service-availability", "Test Framework", disruptionexternalawscloudservicemonitoring.NewCloudAvailabilityInvariant())
 	monitorTestRegistry.AddMonitorTestOrDie("external-azure-cloud-service-availability", "Test Framework", disruptionexternalazurecloudservicemonitoring.NewCloudAvailabilityInvariant())
 	monitorTestRegistry.AddMonitorTestOrDie("pathological-event-analyzer", "Test Framework", pathologicaleventanalyzer.NewAnalyzer())
 	monitorTestRegistry.AddMonitorTestOrDie("disruption-summary-serializer", "Test Framework", disruptionserializer.NewDisruptionSummarySerializer())
+	monitorTestRegistry.AddMonitorTestOrDie("disruption-attribution", "Test Framework", disruptionattribution.NewDisruptionAttribution())
+	monitorTestRegistry.AddMonitorTestOrDie("leaked-resource-checker", "Test Framework", leakedresources.NewLeakedResourceChecker())
+	monitorTestRegistry.AddMonitorTestOrDie("cluster-health-delta", "Test Framework", healthdelta.NewHealthDeltaReport())
 
 	monitorTestRegistry.AddMonitorTestOrDie("monitoring-statefulsets-recreation", "Monitoring", statefulsetsrecreation.NewStatefulsetsChecker())
 	monitorTestRegistry.AddMonitorTestOrDie("metrics-api-availability", "Monitoring", disruptionmetricsapi.NewAvailabilityInvariant())
 
+	monitorTestRegistry.AddMonitorTestOrDie("unexpected-pod-deletion", "Node / Kubelet", unexpectedpoddeletion.NewAnalyzer())
+	monitorTestRegistry.AddMonitorTestOrDie("image-pull-backoff-analyzer", "Node / Kubelet", imagepullbackoff.NewAnalyzer())
+	monitorTestRegistry.AddMonitorTestOrDie("probe-failure-analyzer", "Node / Kubelet", probefailures.NewAnalyzer())
+	monitorTestRegistry.AddMonitorTestOrDie("crashed-container-collector", "Node / Kubelet", crashedcontainers.NewCrashedContainerCollector())
+	monitorTestRegistry.AddMonitorTestOrDie("kubelet-api-availability", "Node / Kubelet", disruptionkubelet.NewKubeletAvailabilityInvariant(info))
+
 	return monitorTestRegistry
 }
 
@@ -149,15 +210,23 @@ func newUniversalMonitorTests(info monitortestframework.MonitorTestInitializatio
 	monitorTestRegistry.AddMonitorTestOrDie("legacy-cvo-invariants", "Cluster Version Operator", legacycvomonitortests.NewLegacyTests())
 	monitorTestRegistry.AddMonitorTestOrDie("termination-message-policy", "Cluster Version Operator", terminationmessagepolicy.NewAnalyzer())
 	monitorTestRegistry.AddMonitorTestOrDie("operator-state-analyzer", "Cluster Version Operator", operatorstateanalyzer.NewAnalyzer())
+	monitorTestRegistry.AddMonitorTestOrDie("upgrade-hop-recorder", "Cluster Version Operator", upgradehops.NewUpgradeHopRecorder())
+	monitorTestRegistry.AddMonitorTestOrDie("update-risk-recorder", "Cluster Version Operator", updaterisks.NewUpdateRiskRecorder())
+	monitorTestRegistry.AddMonitorTestOrDie("workload-rollouts", "Cluster Version Operator", workloadrollouts.NewWorkloadRolloutTracker())
+	monitorTestRegistry.AddMonitorTestOrDie("feature-gate-changes", "Cluster Version Operator", featuregatechanges.NewFeatureGateChangeDetector())
 	monitorTestRegistry.AddMonitorTestOrDie("required-scc-annotation-checker", "Cluster Version Operator", requiredsccmonitortests.NewAnalyzer())
 
 	monitorTestRegistry.AddMonitorTestOrDie("etcd-log-analyzer", "etcd", etcdloganalyzer.NewEtcdLogAnalyzer())
 	monitorTestRegistry.AddMonitorTestOrDie("legacy-etcd-invariants", "etcd", legacyetcdmonitortests.NewLegacyTests())
+	monitorTestRegistry.AddMonitorTestOrDie("etcd-object-growth", "etcd", etcdobjectgrowth.NewEtcdObjectGrowth())
 
 	monitorTestRegistry.AddMonitorTestOrDie("audit-log-analyzer", "kube-apiserver", auditloganalyzer.NewAuditLogAnalyzer())
 	monitorTestRegistry.AddMonitorTestOrDie("legacy-kube-apiserver-invariants", "kube-apiserver", legacykubeapiservermonitortests.NewLegacyTests())
 	monitorTestRegistry.AddMonitorTestOrDie("graceful-shutdown-analyzer", "kube-apiserver", apiservergracefulrestart.NewGracefulShutdownAnalyzer())
 
+	monitorTestRegistry.AddMonitorTestOrDie("garbage-collector-orphans", "kube-controller-manager", garbagecollectororphans.NewGarbageCollectorOrphans())
+	monitorTestRegistry.AddMonitorTestOrDie("quota-exhaustion", "kube-controller-manager", quotaexhaustion.NewQuotaExhaustion())
+
 	monitorTestRegistry.AddMonitorTestOrDie("legacy-networking-invariants", "Networking / cluster-network-operator", legacynetworkmonitortests.NewLegacyTests())
 
 	monitorTestRegistry.AddMonitorTestOrDie("kubelet-log-collector", "Node / Kubelet", kubeletlogcollector.NewKubeletLogCollector())
@@ -165,22 +234,32 @@ func newUniversalMonitorTests(info monitortestframework.MonitorTestInitializatio
 	monitorTestRegistry.AddMonitorTestOrDie("node-state-analyzer", "Node / Kubelet", nodestateanalyzer.NewAnalyzer())
 	monitorTestRegistry.AddMonitorTestOrDie("pod-lifecycle", "Node / Kubelet", watchpods.NewPodWatcher())
 	monitorTestRegistry.AddMonitorTestOrDie("node-lifecycle", "Node / Kubelet", watchnodes.NewNodeWatcher())
+	monitorTestRegistry.AddMonitorTestOrDie("spot-node-tracker", "Node / Kubelet", spotnodetracker.NewSpotNodeTracker())
+	monitorTestRegistry.AddMonitorTestOrDie("accelerator-health", "Node / Kubelet", acceleratorhealth.NewAcceleratorHealthMonitor())
+	monitorTestRegistry.AddMonitorTestOrDie("numa-allocation-failures", "Node / Kubelet", numaallocation.NewNUMAAllocationFailures())
+	monitorTestRegistry.AddMonitorTestOrDie("node-initialization-tracker", "Cloud Compute / Cloud Controller Manager", nodeinitialization.NewNodeInitializationTracker())
 
 	monitorTestRegistry.AddMonitorTestOrDie("legacy-storage-invariants", "Storage", legacystoragemonitortests.NewLegacyTests())
 
 	monitorTestRegistry.AddMonitorTestOrDie("legacy-test-framework-invariants", "Test Framework", legacytestframeworkmonitortests.NewLegacyTests(info))
 	monitorTestRegistry.AddMonitorTestOrDie("timeline-serializer", "Test Framework", timelineserializer.NewTimelineSerializer())
 	monitorTestRegistry.AddMonitorTestOrDie("interval-serializer", "Test Framework", intervalserializer.NewIntervalSerializer())
+	monitorTestRegistry.AddMonitorTestOrDie("interval-reason-validator", "Test Framework", intervalreasons.NewReasonValidator())
 	monitorTestRegistry.AddMonitorTestOrDie("tracked-resources-serializer", "Test Framework", trackedresourcesserializer.NewTrackedResourcesSerializer())
 	monitorTestRegistry.AddMonitorTestOrDie("cluster-info-serializer", "Test Framework", clusterinfoserializer.NewClusterInfoSerializer())
 	monitorTestRegistry.AddMonitorTestOrDie("additional-events-collector", "Test Framework", additionaleventscollector.NewIntervalSerializer())
 	monitorTestRegistry.AddMonitorTestOrDie("known-image-checker", "Test Framework", knownimagechecker.NewEnsureValidImages())
 	monitorTestRegistry.AddMonitorTestOrDie("e2e-test-analyzer", "Test Framework", e2etestanalyzer.NewAnalyzer())
-	monitorTestRegistry.AddMonitorTestOrDie("event-collector", "Test Framework", watchevents.NewEventWatcher())
+	monitorTestRegistry.AddMonitorTestOrDie("event-collector", "Test Framework", watchevents.NewEventWatcher(info.EventNamespaceInclude, info.EventNamespaceExclude, info.EventLogVerbosity))
 	monitorTestRegistry.AddMonitorTestOrDie("clusteroperator-collector", "Test Framework", watchclusteroperators.NewOperatorWatcher())
 
 	monitorTestRegistry.AddMonitorTestOrDie("azure-metrics-collector", "Test Framework", azuremetrics.NewAzureMetricsCollector())
+	monitorTestRegistry.AddMonitorTestOrDie("cloud-node-interruptions", "Test Framework", nodeinterruptions.NewNodeInterruptionCollector())
+	monitorTestRegistry.AddMonitorTestOrDie("baremetalhost-provisioning", "Bare Metal Hardware Provisioning", baremetalhosts.NewBareMetalHostMonitor())
 	monitorTestRegistry.AddMonitorTestOrDie("watch-request-counts-collector", "Test Framework", watchrequestcountscollector.NewWatchRequestCountSerializer())
+	monitorTestRegistry.AddMonitorTestOrDie("slo-evaluator", "Test Framework", sloevaluator.NewSLOEvaluator(info.SLOConfigFile))
+	monitorTestRegistry.AddMonitorTestOrDie("chaos-injector", "Test Framework", chaosinjector.NewChaosInjector(info.ChaosConfigFile, info.UpgradeTargetPayloadImagePullSpec))
+	monitorTestRegistry.AddMonitorTestOrDie("interval-triggered-collectors", "Test Framework", intervaltriggers.NewIntervalTriggeredCollectors(intervaltriggers.DefaultTriggerRules))
 
 	return monitorTestRegistry
 }
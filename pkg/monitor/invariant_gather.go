@@ -0,0 +1,135 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+)
+
+// highSeverityInvariant is a monitor test whose failure means the cluster is in a state worth capturing before
+// the monitor tests clean up and the cluster is torn down.
+type highSeverityInvariant struct {
+	testName *regexp.Regexp
+	// namespaces are always inspected when the invariant fails, in addition to any ns/NAME in the test name.
+	namespaces []string
+}
+
+var (
+	highSeverityInvariants = []highSeverityInvariant{
+		{
+			testName:   regexp.MustCompile(`^\[sig-etcd\] `),
+			namespaces: []string{"openshift-etcd", "openshift-etcd-operator"},
+		},
+		{
+			testName: regexp.MustCompile(`^\[sig-arch\] events should not repeat pathologically for ns/openshift-(etcd|kube-apiserver|kube-controller-manager|kube-scheduler|apiserver|oauth-apiserver|cluster-version)$`),
+		},
+	}
+
+	testNameNamespaceRegex = regexp.MustCompile(`ns/([a-z0-9-]+)`)
+)
+
+// implicatedNamespaces returns the namespaces to inspect for the high severity invariants that failed.  Flakes,
+// tests that also have a passing result, are ignored.
+func implicatedNamespaces(junits []*junitapi.JUnitTestCase) []string {
+	passed := sets.NewString()
+	for _, junit := range junits {
+		if junit.FailureOutput == nil && junit.SkipMessage == nil {
+			passed.Insert(junit.Name)
+		}
+	}
+
+	namespaces := sets.NewString()
+	for _, junit := range junits {
+		if junit.FailureOutput == nil || passed.Has(junit.Name) {
+			continue
+		}
+		for _, invariant := range highSeverityInvariants {
+			if !invariant.testName.MatchString(junit.Name) {
+				continue
+			}
+			namespaces.Insert(invariant.namespaces...)
+			for _, match := range testNameNamespaceRegex.FindAllStringSubmatch(junit.Name, -1) {
+				namespaces.Insert(match[1])
+			}
+		}
+	}
+	return namespaces.List()
+}
+
+// inspectNamespaces runs a scoped `oc adm inspect` of the namespaces into the storage directory.  It is much
+// cheaper than a full must-gather and captures the state while the failure is still fresh.  oc is pointed at the
+// same cluster and credentials as the monitor rather than whatever KUBECONFIG happens to be set.
+func inspectNamespaces(ctx context.Context, adminRESTConfig *rest.Config, storageDir string, namespaces []string) error {
+	ocPath, err := exec.LookPath("oc")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Skipping inspection of namespaces %v, oc is not available: %v\n", namespaces, err)
+		return nil
+	}
+
+	kubeconfigFile, err := os.CreateTemp("", "invariant-inspect-kubeconfig-")
+	if err != nil {
+		return fmt.Errorf("unable to create kubeconfig for oc adm inspect: %w", err)
+	}
+	kubeconfigFile.Close()
+	defer os.Remove(kubeconfigFile.Name())
+	if err := clientcmd.WriteToFile(kubeconfigFor(adminRESTConfig), kubeconfigFile.Name()); err != nil {
+		return fmt.Errorf("unable to write kubeconfig for oc adm inspect: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+	defer cancel()
+
+	args := []string{"adm", "inspect", "--kubeconfig", kubeconfigFile.Name(), "--dest-dir", filepath.Join(storageDir, "inspect-invariant-failures")}
+	for _, namespace := range namespaces {
+		args = append(args, "ns/"+namespace)
+	}
+	cmd := exec.CommandContext(ctx, ocPath, args...)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("oc adm inspect failed: %w", err)
+	}
+	return nil
+}
+
+// kubeconfigFor builds a kubeconfig with the server and credentials of the rest config.
+func kubeconfigFor(restConfig *rest.Config) clientcmdapi.Config {
+	cluster := clientcmdapi.NewCluster()
+	cluster.Server = restConfig.Host
+	cluster.CertificateAuthority = restConfig.CAFile
+	cluster.CertificateAuthorityData = restConfig.CAData
+	cluster.InsecureSkipTLSVerify = restConfig.Insecure
+	cluster.TLSServerName = restConfig.ServerName
+
+	authInfo := clientcmdapi.NewAuthInfo()
+	authInfo.Token = restConfig.BearerToken
+	authInfo.TokenFile = restConfig.BearerTokenFile
+	authInfo.ClientCertificate = restConfig.CertFile
+	authInfo.ClientCertificateData = restConfig.CertData
+	authInfo.ClientKey = restConfig.KeyFile
+	authInfo.ClientKeyData = restConfig.KeyData
+	authInfo.Username = restConfig.Username
+	authInfo.Password = restConfig.Password
+
+	kubeContext := clientcmdapi.NewContext()
+	kubeContext.Cluster = "cluster"
+	kubeContext.AuthInfo = "admin"
+
+	config := clientcmdapi.NewConfig()
+	config.Clusters["cluster"] = cluster
+	config.AuthInfos["admin"] = authInfo
+	config.Contexts["admin"] = kubeContext
+	config.CurrentContext = "admin"
+	return *config
+}
@@ -0,0 +1,77 @@
+package monitor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/rest"
+
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+)
+
+func TestImplicatedNamespaces(t *testing.T) {
+	failed := func(name string) *junitapi.JUnitTestCase {
+		return &junitapi.JUnitTestCase{Name: name, FailureOutput: &junitapi.FailureOutput{Output: "failed"}}
+	}
+	passed := func(name string) *junitapi.JUnitTestCase {
+		return &junitapi.JUnitTestCase{Name: name}
+	}
+
+	tests := []struct {
+		name   string
+		junits []*junitapi.JUnitTestCase
+		want   []string
+	}{
+		{
+			name:   "nothing failed",
+			junits: []*junitapi.JUnitTestCase{passed("[sig-etcd] etcd pod logs do not log slow fdatasync")},
+		},
+		{
+			name:   "etcd failure",
+			junits: []*junitapi.JUnitTestCase{failed("[sig-etcd] etcd pod logs do not log slow fdatasync")},
+			want:   []string{"openshift-etcd", "openshift-etcd-operator"},
+		},
+		{
+			name: "etcd flake",
+			junits: []*junitapi.JUnitTestCase{
+				failed("[sig-etcd] etcd pod logs do not log slow fdatasync"),
+				passed("[sig-etcd] etcd pod logs do not log slow fdatasync"),
+			},
+		},
+		{
+			name: "pathological events in control plane namespace",
+			junits: []*junitapi.JUnitTestCase{
+				failed("[sig-arch] events should not repeat pathologically for ns/openshift-kube-apiserver"),
+				failed("[sig-arch] events should not repeat pathologically for ns/openshift-monitoring"),
+			},
+			want: []string{"openshift-kube-apiserver"},
+		},
+		{
+			name:   "low severity failure",
+			junits: []*junitapi.JUnitTestCase{failed("[sig-node] pods should not be deleted")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := tt.want
+			if want == nil {
+				want = []string{}
+			}
+			assert.Equal(t, want, implicatedNamespaces(tt.junits))
+		})
+	}
+}
+
+func TestKubeconfigFor(t *testing.T) {
+	config := kubeconfigFor(&rest.Config{
+		Host:            "https://api.example.com:6443",
+		BearerToken:     "token",
+		TLSClientConfig: rest.TLSClientConfig{CAData: []byte("ca")},
+	})
+
+	assert.Equal(t, "admin", config.CurrentContext)
+	cluster := config.Clusters[config.Contexts["admin"].Cluster]
+	assert.Equal(t, "https://api.example.com:6443", cluster.Server)
+	assert.Equal(t, []byte("ca"), cluster.CertificateAuthorityData)
+	assert.Equal(t, "token", config.AuthInfos[config.Contexts["admin"].AuthInfo].Token)
+}
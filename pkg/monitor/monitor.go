@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
 	"time"
 
@@ -87,6 +88,12 @@ func (m *Monitor) Stop(ctx context.Context) (ResultState, error) {
 
 	preStopTime := time.Now()
 
+	if flusher, ok := m.recorder.(monitorapi.RecorderFlusher); ok {
+		if err := flusher.Flush(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error flushing recorded intervals, continuing. %v\n", err)
+		}
+	}
+
 	fmt.Fprintf(os.Stderr, "Collecting data.\n")
 	collectedIntervals, collectionJunits, err := m.monitorTestRegistry.CollectData(ctx, m.storageDir, m.startTime, preStopTime)
 	if err != nil {
@@ -129,6 +136,14 @@ func (m *Monitor) Stop(ctx context.Context) (ResultState, error) {
 	}
 	m.junits = append(m.junits, monitorTestJunits...)
 
+	// capture the implicated namespaces before the monitor tests clean up after themselves.
+	if namespaces := implicatedNamespaces(m.junits); len(namespaces) > 0 && len(m.storageDir) > 0 {
+		fmt.Fprintf(os.Stderr, "High severity invariants failed, inspecting namespaces %v.\n", namespaces)
+		if err := inspectNamespaces(ctx, m.adminKubeConfig, m.storageDir, namespaces); err != nil {
+			fmt.Fprintf(os.Stderr, "Error inspecting namespaces, continuing. %v\n", err)
+		}
+	}
+
 	fmt.Fprintf(os.Stderr, "Cleaning up.\n")
 	cleanupJunits, err := m.monitorTestRegistry.Cleanup(ctx)
 	if err != nil {
@@ -137,10 +152,14 @@ func (m *Monitor) Stop(ctx context.Context) (ResultState, error) {
 	}
 	m.junits = append(m.junits, cleanupJunits...)
 
+	return resultStateFor(m.junits), nil
+}
+
+// resultStateFor fails if a test only failed, tests that also passed are flakes.
+func resultStateFor(junits []*junitapi.JUnitTestCase) ResultState {
 	successfulTestNames := sets.NewString()
 	failedTestNames := sets.NewString()
-	resultState := Succeeded
-	for _, junit := range m.junits {
+	for _, junit := range junits {
 		if junit.FailureOutput != nil {
 			failedTestNames.Insert(junit.Name)
 			continue
@@ -149,10 +168,53 @@ func (m *Monitor) Stop(ctx context.Context) (ResultState, error) {
 	}
 	onlyFailingTests := failedTestNames.Difference(successfulTestNames)
 	if len(onlyFailingTests) > 0 {
-		resultState = Failed
+		return Failed
 	}
+	return Succeeded
+}
+
+// Checkpoint evaluates the monitor tests against the intervals recorded so far without stopping the monitor, so that
+// long runs report progressively.  The intervals and junit of the checkpoint are written to checkpoints/<name> of the
+// storage dir.  Data is not collected and nothing is cleaned up, so the final results can differ.
+func (m *Monitor) Checkpoint(ctx context.Context, name string) (ResultState, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.stopFn == nil {
+		return Failed, fmt.Errorf("monitor not started")
+	}
+	checkpointTime := time.Now()
 
-	return resultState, nil
+	if flusher, ok := m.recorder.(monitorapi.RecorderFlusher); ok {
+		if err := flusher.Flush(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error flushing recorded intervals, continuing. %v\n", err)
+		}
+	}
+	recordedIntervals := m.recorder.Intervals(m.startTime, checkpointTime)
+	computedIntervals, junits, err := m.monitorTestRegistry.ConstructComputedIntervals(
+		ctx, recordedIntervals, m.recorder.CurrentResourceState(), m.startTime, checkpointTime)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error computing intervals for checkpoint %s, continuing, junit will reflect this. %v\n", name, err)
+	}
+	// the computed intervals are only for this checkpoint, Stop computes them again over the whole run.
+	checkpointIntervals := append(append(monitorapi.Intervals{}, recordedIntervals...), computedIntervals...)
+	sort.Sort(checkpointIntervals)
+	monitorTestJunits, err := m.monitorTestRegistry.EvaluateTestsFromConstructedIntervals(ctx, checkpointIntervals)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error evaluating tests for checkpoint %s, continuing, junit will reflect this. %v\n", name, err)
+	}
+	junits = append(junits, monitorTestJunits...)
+
+	checkpointDir := filepath.Join(m.storageDir, "checkpoints", name)
+	if err := os.MkdirAll(checkpointDir, os.ModePerm); err != nil {
+		return Failed, err
+	}
+	if err := monitorserialization.EventsToFile(filepath.Join(checkpointDir, "e2e-events.json"), checkpointIntervals); err != nil {
+		return Failed, err
+	}
+	if _, err := writeJunitSuite(filepath.Join(checkpointDir, "e2e-monitor-tests.xml"), "checkpoint-"+name, junits); err != nil {
+		return Failed, err
+	}
+	return resultStateFor(junits), nil
 }
 
 func (m *Monitor) SerializeResults(ctx context.Context, junitSuiteName, timeSuffix string) error {
@@ -202,7 +264,7 @@ func (m *Monitor) SerializeResults(ctx context.Context, junitSuiteName, timeSuff
 		return err
 	}
 
-	if err := riskanalysis.WriteJobRunTestFailureSummary(m.storageDir, timeSuffix, junitSuite, "", "_monitor"); err != nil {
+	if err := riskanalysis.WriteJobRunTestFailureSummary(m.storageDir, timeSuffix, junitSuite, "", "_monitor", finalIntervals); err != nil {
 		fmt.Fprintf(os.Stderr, "error: Unable to write e2e job run failures summary: %v", err)
 	}
 
@@ -210,6 +272,12 @@ func (m *Monitor) SerializeResults(ctx context.Context, junitSuiteName, timeSuff
 }
 
 func (m *Monitor) serializeJunit(ctx context.Context, storageDir, junitSuiteName, fileSuffix string) (*junitapi.JUnitTestSuite, error) {
+	filePrefix := "e2e-monitor-tests"
+	path := filepath.Join(storageDir, fmt.Sprintf("%s_%s.xml", filePrefix, fileSuffix))
+	return writeJunitSuite(path, junitSuiteName, m.junits)
+}
+
+func writeJunitSuite(path, junitSuiteName string, junits []*junitapi.JUnitTestCase) (*junitapi.JUnitTestSuite, error) {
 	junitSuite := junitapi.JUnitTestSuite{
 		Name:       junitSuiteName,
 		NumTests:   0,
@@ -220,8 +288,8 @@ func (m *Monitor) serializeJunit(ctx context.Context, storageDir, junitSuiteName
 		TestCases:  nil,
 		Children:   nil,
 	}
-	for i := range m.junits {
-		currJunit := m.junits[i]
+	for i := range junits {
+		currJunit := junits[i]
 
 		junitSuite.NumTests++
 		if currJunit.FailureOutput != nil {
@@ -236,8 +304,6 @@ func (m *Monitor) serializeJunit(ctx context.Context, storageDir, junitSuiteName
 	if err != nil {
 		return nil, err
 	}
-	filePrefix := "e2e-monitor-tests"
-	path := filepath.Join(storageDir, fmt.Sprintf("%s_%s.xml", filePrefix, fileSuffix))
 	fmt.Fprintf(os.Stderr, "Writing JUnit report to %s\n", path)
 	return &junitSuite, os.WriteFile(path, test.StripANSI(out), 0640)
 }
@@ -1,11 +1,18 @@
 package monitor
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
 
 	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/util/diff"
 )
 
@@ -114,3 +121,57 @@ func TestMonitor_Events(t *testing.T) {
 		})
 	}
 }
+
+// failOnDisplayedIntervals constructs an interval for every interval it sees and fails if any are marked for display.
+type failOnDisplayedIntervals struct {
+	monitortestframework.MonitorTest
+}
+
+func (failOnDisplayedIntervals) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	return monitorapi.Intervals{
+		monitorapi.NewInterval(monitorapi.SourceTestData, monitorapi.Info).
+			Message(monitorapi.NewMessage().HumanMessagef("saw %d", len(startingIntervals))).
+			Display().
+			Build(beginning, end),
+	}, nil
+}
+
+func (failOnDisplayedIntervals) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	displayed := finalIntervals.Filter(func(interval monitorapi.Interval) bool { return interval.Display })
+	if len(displayed) > 0 {
+		return []*junitapi.JUnitTestCase{{Name: "no displayed intervals", FailureOutput: &junitapi.FailureOutput{Output: displayed[0].String()}}}, nil
+	}
+	return []*junitapi.JUnitTestCase{{Name: "no displayed intervals"}}, nil
+}
+
+func TestMonitor_Checkpoint(t *testing.T) {
+	registry := monitortestframework.NewMonitorTestRegistry()
+	registry.AddMonitorTestOrDie("fail-on-displayed-intervals", "Test Framework", failOnDisplayedIntervals{})
+	storageDir := t.TempDir()
+	recorder := NewRecorder()
+	m := &Monitor{
+		recorder:            recorder,
+		monitorTestRegistry: registry,
+		storageDir:          storageDir,
+		startTime:           time.Now().Add(-time.Minute),
+	}
+
+	_, err := m.Checkpoint(context.Background(), "0001")
+	require.Error(t, err, "checkpoints need a started monitor")
+
+	_, m.stopFn = context.WithCancel(context.Background())
+	recorder.AddIntervals(monitorapi.NewInterval(monitorapi.SourceTestData, monitorapi.Info).
+		Message(monitorapi.NewMessage().HumanMessage("recorded")).
+		Build(time.Now().Add(-30*time.Second), time.Now().Add(-20*time.Second)))
+
+	resultState, err := m.Checkpoint(context.Background(), "0001")
+	require.NoError(t, err)
+	assert.Equal(t, Failed, resultState)
+	assert.FileExists(t, filepath.Join(storageDir, "checkpoints", "0001", "e2e-events.json"))
+	junitXML, err := os.ReadFile(filepath.Join(storageDir, "checkpoints", "0001", "e2e-monitor-tests.xml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(junitXML), "saw 1")
+
+	assert.Len(t, recorder.Intervals(time.Time{}, time.Time{}), 1, "checkpoint intervals are not recorded")
+	assert.Empty(t, m.junits, "checkpoint junits are not part of the final results")
+}
@@ -0,0 +1,68 @@
+package monitorapi
+
+import (
+	"sort"
+	"strconv"
+	"time"
+)
+
+// DefaultCompactionGap is how far apart two otherwise identical intervals can be and still be merged, the same five
+// seconds used to decide that an alert or outage is still the same occurrence.
+const DefaultCompactionGap = 5 * time.Second
+
+// Compact merges intervals with the same source, level, locator and message that overlap or are within maxGap of
+// each other, which is common for flapping conditions.  Merged intervals span all the intervals they replace and
+// record how many there were in AnnotationMergedIntervals.  The intervals passed in are not modified.
+func (intervals Intervals) Compact(maxGap time.Duration) Intervals {
+	sorted := make(Intervals, len(intervals))
+	copy(sorted, intervals)
+	sort.Stable(sorted)
+
+	ret := Intervals{}
+	counts := []int{}
+	// lastMerged is the index in ret of the most recent interval for a key, intervals are only merged into it.
+	lastMerged := map[string]int{}
+	for _, interval := range sorted {
+		key := compactionKey(interval)
+		if i, ok := lastMerged[key]; ok && canMerge(ret[i], interval, maxGap) {
+			switch {
+			case ret[i].To.IsZero():
+			case interval.To.IsZero(), interval.To.After(ret[i].To):
+				ret[i].To = interval.To
+			}
+			counts[i]++
+			continue
+		}
+		lastMerged[key] = len(ret)
+		ret = append(ret, interval)
+		counts = append(counts, 1)
+	}
+
+	for i := range ret {
+		if counts[i] == 1 {
+			continue
+		}
+		annotations := make(map[AnnotationKey]string, len(ret[i].Message.Annotations)+1)
+		for k, v := range ret[i].Message.Annotations {
+			annotations[k] = v
+		}
+		annotations[AnnotationMergedIntervals] = strconv.Itoa(counts[i])
+		ret[i].Message.Annotations = annotations
+	}
+
+	sort.Sort(ret)
+	return ret
+}
+
+func compactionKey(interval Interval) string {
+	return string(interval.Source) + " " + interval.Level.String() + " " + strconv.FormatBool(interval.Display) + " " +
+		interval.Locator.OldLocator() + " " + interval.Message.OldMessage()
+}
+
+// canMerge expects next to start at or after merged, open intervals swallow everything after them.
+func canMerge(merged, next Interval, maxGap time.Duration) bool {
+	if merged.To.IsZero() {
+		return true
+	}
+	return !next.From.After(merged.To.Add(maxGap))
+}
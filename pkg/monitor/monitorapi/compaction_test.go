@@ -0,0 +1,95 @@
+package monitorapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntervalsCompact(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	interval := func(node, message string, from, to time.Duration) Interval {
+		var toTime time.Time
+		if to >= 0 {
+			toTime = start.Add(to)
+		}
+		return NewInterval(SourceNodeState, Warning).
+			Locator(NewLocator().NodeFromName(node)).
+			Message(NewMessage().HumanMessage(message)).
+			Build(start.Add(from), toTime)
+	}
+
+	tests := []struct {
+		name      string
+		intervals Intervals
+		expected  Intervals
+	}{
+		{
+			name: "flapping condition",
+			intervals: Intervals{
+				interval("a", "not ready", 0, 10*time.Second),
+				interval("a", "not ready", 12*time.Second, 20*time.Second),
+				interval("a", "not ready", 15*time.Second, 18*time.Second),
+				interval("a", "not ready", 25*time.Second, 30*time.Second),
+			},
+			expected: Intervals{
+				interval("a", "not ready", 0, 30*time.Second),
+			},
+		},
+		{
+			name: "too far apart",
+			intervals: Intervals{
+				interval("a", "not ready", 0, 10*time.Second),
+				interval("a", "not ready", 16*time.Second, 20*time.Second),
+			},
+			expected: Intervals{
+				interval("a", "not ready", 0, 10*time.Second),
+				interval("a", "not ready", 16*time.Second, 20*time.Second),
+			},
+		},
+		{
+			name: "different locators and messages",
+			intervals: Intervals{
+				interval("a", "not ready", 0, 10*time.Second),
+				interval("b", "not ready", 0, 10*time.Second),
+				interval("a", "ready", 10*time.Second, 20*time.Second),
+			},
+			expected: Intervals{
+				interval("a", "not ready", 0, 10*time.Second),
+				interval("b", "not ready", 0, 10*time.Second),
+				interval("a", "ready", 10*time.Second, 20*time.Second),
+			},
+		},
+		{
+			name: "open intervals stay open",
+			intervals: Intervals{
+				interval("a", "not ready", 0, -1),
+				interval("a", "not ready", time.Minute, 2*time.Minute),
+			},
+			expected: Intervals{
+				interval("a", "not ready", 0, -1),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := tt.intervals.Compact(DefaultCompactionGap)
+			assert.Len(t, actual, len(tt.expected))
+			for i := range actual {
+				assert.Equal(t, tt.expected[i].From, actual[i].From)
+				assert.Equal(t, tt.expected[i].To, actual[i].To)
+				assert.Equal(t, tt.expected[i].Locator, actual[i].Locator)
+				assert.Equal(t, tt.expected[i].Message.HumanMessage, actual[i].Message.HumanMessage)
+			}
+		})
+	}
+
+	merged := Intervals{
+		interval("a", "not ready", 0, 10*time.Second),
+		interval("a", "not ready", 12*time.Second, 20*time.Second),
+	}
+	compacted := merged.Compact(DefaultCompactionGap)
+	assert.Equal(t, "2", compacted[0].Message.Annotations[AnnotationMergedIntervals])
+	assert.Empty(t, merged[0].Message.Annotations[AnnotationMergedIntervals], "the input is not modified")
+}
@@ -9,6 +9,7 @@ import (
 	v1 "github.com/openshift/api/config/v1"
 	"github.com/prometheus/common/model"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/kube-openapi/pkg/util/sets"
 )
 
@@ -194,6 +195,27 @@ func (b *LocatorBuilder) PrometheusTargetDownFromPromSampleStream(sample *model.
 	return b.Build()
 }
 
+// PromQLRuleFromPromSampleStream locates a series returned by a user defined PromQL rule, keeping the labels that
+// identify where in the cluster the series came from.
+func (b *LocatorBuilder) PromQLRuleFromPromSampleStream(ruleName string, sample *model.SampleStream) Locator {
+	b.targetType = LocatorTypePromQLRule
+	b.annotations[LocatorPromQLRuleKey] = ruleName
+
+	for label, key := range map[model.LabelName]LocatorKey{
+		"node":      LocatorNodeKey,
+		"instance":  LocatorInstanceKey,
+		"namespace": LocatorNamespaceKey,
+		"pod":       LocatorPodKey,
+		"container": LocatorContainerKey,
+	} {
+		if value := string(sample.Metric[label]); len(value) > 0 {
+			b.annotations[key] = value
+		}
+	}
+
+	return b.Build()
+}
+
 func (b *LocatorBuilder) Disruption(backendDisruptionName, thisInstanceName, loadBalancer, protocol, target string, connectionType BackendConnectionType) Locator {
 	b = b.withDisruptionRequiredOnly(backendDisruptionName, thisInstanceName).withConnectionType(connectionType)
 
@@ -348,6 +370,50 @@ func (b *LocatorBuilder) KubeEvent(event *corev1.Event) Locator {
 	return b.Build()
 }
 
+// ForGVR locates any resource, including CRs, by its group, resource, namespace and name. The version is left out
+// so that the locator stays the same no matter which version of the API the resource was read from.
+// Leave namespace empty for cluster scoped resources.
+func (b *LocatorBuilder) ForGVR(gvr schema.GroupVersionResource, namespace, name string) Locator {
+	b.targetType = LocatorTypeResource
+	if len(gvr.Group) > 0 {
+		b.annotations[LocatorGroupKey] = gvr.Group
+	}
+	b.annotations[LocatorResourceKey] = gvr.Resource
+	if len(namespace) > 0 {
+		b.annotations[LocatorNamespaceKey] = namespace
+	}
+	b.annotations[LocatorNameKey] = name
+	return b.Build()
+}
+
+// APIRequests locates the requests of a user with a verb on a resource, for intervals aggregated from the audit log.
+func (b *LocatorBuilder) APIRequests(user, verb string, gr schema.GroupResource) Locator {
+	b.targetType = LocatorTypeResource
+	if len(gr.Group) > 0 {
+		b.annotations[LocatorGroupKey] = gr.Group
+	}
+	b.annotations[LocatorResourceKey] = gr.Resource
+	b.annotations[LocatorUserKey] = user
+	b.annotations[LocatorVerbKey] = verb
+	return b.Build()
+}
+
+// EventWatcher locates the event watcher itself, for intervals about how well it recorded events.
+func (b *LocatorBuilder) EventWatcher() Locator {
+	b.targetType = LocatorTypeEventWatcher
+	b.annotations[LocatorNameKey] = "event-watcher"
+	return b.Build()
+}
+
+// Workload locates the pods of a namespace that share the name of the workload owning them, for when the kind of the
+// owner is not known, as for events.
+func (b *LocatorBuilder) Workload(namespace, workload string) Locator {
+	b.targetType = LocatorTypeKind
+	b.annotations[LocatorNamespaceKey] = namespace
+	b.annotations[LocatorWorkloadKey] = workload
+	return b.Build()
+}
+
 func (b *LocatorBuilder) ContainerFromPod(pod *corev1.Pod, containerName string) Locator {
 	b.PodFromPod(pod)
 	b.targetType = LocatorTypeContainer
@@ -425,6 +491,7 @@ func (b *LocatorBuilder) Build() Locator {
 	for k, v := range b.annotations {
 		ret.Keys[k] = v
 	}
+	addHostedControlPlaneKeys(ret.Keys)
 	return ret
 }
 
@@ -0,0 +1,129 @@
+package monitorapi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// IsE2ETestInterval returns true for the intervals constructed from the start and finish of an e2e test.
+// The raw started and finished instants do not match.
+func IsE2ETestInterval(eventInterval Interval) bool {
+	if eventInterval.Source != SourceE2ETest {
+		return false
+	}
+	if _, ok := eventInterval.Message.Annotations[AnnotationStatus]; !ok {
+		return false
+	}
+	return eventInterval.To.After(eventInterval.From)
+}
+
+// effectiveEnd treats instants and intervals without an end as occurring at their From.
+func effectiveEnd(eventInterval Interval) time.Time {
+	if eventInterval.To.IsZero() || eventInterval.To.Before(eventInterval.From) {
+		return eventInterval.From
+	}
+	return eventInterval.To
+}
+
+// overlapsWindow returns true if the interval was open at any point in [from, to].
+func overlapsWindow(eventInterval Interval, from, to time.Time) bool {
+	return !eventInterval.From.After(to) && !effectiveEnd(eventInterval).Before(from)
+}
+
+const (
+	// MaxAttributedE2ETests caps the tests listed for one interval, intervals spanning the run overlap every test.
+	MaxAttributedE2ETests = 20
+	// MaxSummarizedIntervals caps the intervals listed in the output of a failed test.
+	MaxSummarizedIntervals = 50
+)
+
+// E2ETestAttribution lists the e2e tests that were executing while an interval was open.  It is written to its own
+// artifact, keyed by the source, locator, message and times of the interval, because copying the tests onto every
+// interval would multiply the size of the intervals artifact.
+type E2ETestAttribution struct {
+	Source  IntervalSource `json:"source"`
+	Locator string         `json:"locator"`
+	Message string         `json:"message"`
+	From    time.Time      `json:"from"`
+	To      time.Time      `json:"to"`
+	Tests   []string       `json:"tests"`
+	// OverlappingTests counts every overlapping test, Tests is capped at MaxAttributedE2ETests.
+	OverlappingTests int `json:"overlappingTests"`
+}
+
+// AttributeToRunningE2ETests returns, for every interval that is not itself an e2e test, the e2e tests that were
+// executing while it was open.  Intervals that did not overlap any test are omitted.
+func (intervals Intervals) AttributeToRunningE2ETests() []E2ETestAttribution {
+	tests := intervals.Filter(IsE2ETestInterval)
+	if len(tests) == 0 {
+		return nil
+	}
+	sort.Sort(tests)
+
+	ret := []E2ETestAttribution{}
+	for _, interval := range intervals {
+		if interval.Source == SourceE2ETest {
+			continue
+		}
+
+		end := effectiveEnd(interval)
+		running := sets.NewString()
+		for _, test := range tests {
+			if test.From.After(end) {
+				// tests are sorted by start, no later test can overlap
+				break
+			}
+			if !overlapsWindow(interval, test.From, test.To) {
+				continue
+			}
+			if testName, ok := E2ETestFromLocator(test.Locator); ok {
+				running.Insert(testName)
+			}
+		}
+		if len(running) == 0 {
+			continue
+		}
+
+		testNames := running.List()
+		if len(testNames) > MaxAttributedE2ETests {
+			testNames = testNames[:MaxAttributedE2ETests]
+		}
+		ret = append(ret, E2ETestAttribution{
+			Source:           interval.Source,
+			Locator:          interval.Locator.OldLocator(),
+			Message:          interval.Message.OldMessage(),
+			From:             interval.From,
+			To:               interval.To,
+			Tests:            testNames,
+			OverlappingTests: len(running),
+		})
+	}
+	return ret
+}
+
+// SummarizeOverlappingIntervals returns a human readable list of the Warning and Error intervals, other than e2e
+// tests, that were open at some point between from and to.  It is used to give context to failed tests.
+func (intervals Intervals) SummarizeOverlappingIntervals(from, to time.Time) string {
+	lines := []string{}
+	for _, interval := range intervals {
+		if interval.Source == SourceE2ETest || interval.Level < Warning {
+			continue
+		}
+		if !overlapsWindow(interval, from, to) {
+			continue
+		}
+		lines = append(lines, interval.String())
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	total := len(lines)
+	if total > MaxSummarizedIntervals {
+		lines = append(lines[:MaxSummarizedIntervals], fmt.Sprintf("... and %d more, see the timeline", total-MaxSummarizedIntervals))
+	}
+	return fmt.Sprintf("%d Warning or Error intervals overlapped this test:\n%s", total, strings.Join(lines, "\n"))
+}
@@ -0,0 +1,81 @@
+package monitorapi
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttributeToRunningE2ETests(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	e2eTest := func(name string, from, to time.Time) Interval {
+		return NewInterval(SourceE2ETest, Info).
+			Locator(NewLocator().E2ETest(name)).
+			Message(NewMessage().WithAnnotation(AnnotationStatus, "Passed")).
+			Build(from, to)
+	}
+
+	intervals := Intervals{
+		e2eTest("test-a", start, start.Add(5*time.Minute)),
+		e2eTest("test-b", start.Add(4*time.Minute), start.Add(10*time.Minute)),
+		NewInterval(SourceKubeEvent, Warning).Locator(NewLocator().NodeFromName("node-a")).
+			Message(NewMessage().Reason("Instant")).Build(start.Add(time.Minute), start.Add(time.Minute)),
+		NewInterval(SourceDisruption, Error).Locator(NewLocator().NodeFromName("node-a")).
+			Message(NewMessage().Reason("Span")).Build(start.Add(3*time.Minute), start.Add(6*time.Minute)),
+		NewInterval(SourceAlert, Warning).Locator(NewLocator().NodeFromName("node-a")).
+			Message(NewMessage().Reason("After")).Build(start.Add(20*time.Minute), start.Add(21*time.Minute)),
+	}
+
+	actual := intervals.AttributeToRunningE2ETests()
+	require.Len(t, actual, 2, "the interval after the tests is not attributed")
+
+	byMessage := map[string]E2ETestAttribution{}
+	for _, attribution := range actual {
+		byMessage[attribution.Message] = attribution
+	}
+	assert.Equal(t, []string{"test-a"}, byMessage["reason/Instant"].Tests)
+	assert.Equal(t, []string{"test-a", "test-b"}, byMessage["reason/Span"].Tests)
+	assert.Equal(t, intervals[3].From, byMessage["reason/Span"].From)
+	assert.Equal(t, intervals[3].Locator.OldLocator(), byMessage["reason/Span"].Locator)
+
+	summary := intervals.SummarizeOverlappingIntervals(start.Add(4*time.Minute), start.Add(10*time.Minute))
+	assert.Contains(t, summary, "1 Warning or Error intervals")
+	assert.Contains(t, summary, "Span")
+	assert.Empty(t, intervals.SummarizeOverlappingIntervals(start.Add(time.Hour), start.Add(2*time.Hour)))
+}
+
+func TestAttributionAndSummaryAreCapped(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	intervals := Intervals{
+		NewInterval(SourceAlert, Warning).Locator(NewLocator().NodeFromName("node-a")).
+			Message(NewMessage().Reason("WholeRun")).Build(start, start.Add(10*time.Hour)),
+	}
+	for i := 0; i < MaxSummarizedIntervals+10; i++ {
+		from := start.Add(time.Duration(i) * time.Minute)
+		intervals = append(intervals,
+			NewInterval(SourceE2ETest, Info).
+				Locator(NewLocator().E2ETest(fmt.Sprintf("test-%03d", i))).
+				Message(NewMessage().WithAnnotation(AnnotationStatus, "Passed")).
+				Build(from, from.Add(time.Minute)),
+			NewInterval(SourceKubeEvent, Warning).Locator(NewLocator().NodeFromName("node-a")).
+				Message(NewMessage().Reason("Instant")).Build(from, from),
+		)
+	}
+
+	for _, attribution := range intervals.AttributeToRunningE2ETests() {
+		if attribution.Message != "reason/WholeRun" {
+			continue
+		}
+		assert.Len(t, attribution.Tests, MaxAttributedE2ETests)
+		assert.Equal(t, MaxSummarizedIntervals+10, attribution.OverlappingTests)
+	}
+
+	summary := intervals.SummarizeOverlappingIntervals(start, start.Add(10*time.Hour))
+	assert.Contains(t, summary, fmt.Sprintf("%d Warning or Error intervals", MaxSummarizedIntervals+11))
+	assert.Contains(t, summary, "... and 11 more")
+	assert.Len(t, strings.Split(summary, "\n"), MaxSummarizedIntervals+2)
+}
@@ -0,0 +1,69 @@
+package monitorapi
+
+import (
+	"regexp"
+	"strings"
+)
+
+// HostedControlPlaneNamespacePrefix starts the namespaces HyperShift runs hosted control planes in on the
+// management cluster, clusters-<hosted cluster name> for hosted clusters created in the default clusters namespace.
+const HostedControlPlaneNamespacePrefix = "clusters-"
+
+var (
+	// deploymentPodNameRegex matches the name of a pod owned by a deployment, <deployment>-<replicaset hash>-<suffix>.
+	deploymentPodNameRegex = regexp.MustCompile(`^(.+)-[bcdfghjklmnpqrstvwxz2456789]{6,10}-[bcdfghjklmnpqrstvwxz2456789]{5}$`)
+	// statefulSetPodNameRegex matches the name of a pod owned by a statefulset, <statefulset>-<ordinal>.
+	statefulSetPodNameRegex = regexp.MustCompile(`^(.+)-[0-9]+$`)
+	// replicaSetNameRegex matches the name of a replicaset owned by a deployment, <deployment>-<hash>.
+	replicaSetNameRegex = regexp.MustCompile(`^(.+)-[bcdfghjklmnpqrstvwxz2456789]{6,10}$`)
+)
+
+// HostedClusterFromNamespace returns the name of the hosted cluster whose control plane runs in namespace.
+func HostedClusterFromNamespace(namespace string) (string, bool) {
+	if !strings.HasPrefix(namespace, HostedControlPlaneNamespacePrefix) || len(namespace) == len(HostedControlPlaneNamespacePrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(namespace, HostedControlPlaneNamespacePrefix), true
+}
+
+// HostedControlPlaneComponent returns the control plane component a locator in a hosted control plane namespace
+// points to, kube-apiserver or etcd, from the name of its deployment, statefulset, replicaset or pod.  It is empty
+// when the locator does not point to a workload.
+func HostedControlPlaneComponent(keys map[LocatorKey]string) string {
+	if deployment := keys[LocatorDeploymentKey]; len(deployment) > 0 {
+		return deployment
+	}
+	if statefulSet := keys["statefulset"]; len(statefulSet) > 0 {
+		return statefulSet
+	}
+	if match := replicaSetNameRegex.FindStringSubmatch(keys["replicaset"]); match != nil {
+		return match[1]
+	}
+	pod := keys[LocatorPodKey]
+	if match := deploymentPodNameRegex.FindStringSubmatch(pod); match != nil {
+		return match[1]
+	}
+	if match := statefulSetPodNameRegex.FindStringSubmatch(pod); match != nil {
+		return match[1]
+	}
+	return pod
+}
+
+// IsInHostedControlPlaneNamespace is true for intervals located in the namespace of a hosted control plane.
+func IsInHostedControlPlaneNamespace(eventInterval Interval) bool {
+	_, ok := HostedClusterFromNamespace(NamespaceFromLocator(eventInterval.Locator))
+	return ok
+}
+
+// addHostedControlPlaneKeys tags locators in hosted control plane namespaces with the hosted cluster and the
+// control plane component, so that they can be grouped and matched no matter which hosted cluster they belong to.
+func addHostedControlPlaneKeys(keys map[LocatorKey]string) {
+	hostedCluster, ok := HostedClusterFromNamespace(keys[LocatorNamespaceKey])
+	if !ok {
+		return
+	}
+	keys[LocatorHostedClusterKey] = hostedCluster
+	if component := HostedControlPlaneComponent(keys); len(component) > 0 {
+		keys[LocatorHostedControlPlaneComponentKey] = component
+	}
+}
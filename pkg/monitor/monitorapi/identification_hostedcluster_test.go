@@ -0,0 +1,58 @@
+package monitorapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestHostedControlPlaneLocators(t *testing.T) {
+	tests := []struct {
+		name              string
+		locator           Locator
+		expectedCluster   string
+		expectedComponent string
+	}{
+		{
+			name:              "deployment pod",
+			locator:           NewLocator().PodFromNames("clusters-foo", "kube-apiserver-6d8f7b9c5-x7k2p", ""),
+			expectedCluster:   "foo",
+			expectedComponent: "kube-apiserver",
+		},
+		{
+			name:              "statefulset pod",
+			locator:           NewLocator().ContainerFromNames("clusters-foo-bar", "etcd-0", "", "etcd"),
+			expectedCluster:   "foo-bar",
+			expectedComponent: "etcd",
+		},
+		{
+			name: "deployment event",
+			locator: NewLocator().KubeEvent(&corev1.Event{
+				InvolvedObject: corev1.ObjectReference{Kind: "Deployment", Namespace: "clusters-foo", Name: "openshift-apiserver"},
+			}),
+			expectedCluster:   "foo",
+			expectedComponent: "openshift-apiserver",
+		},
+		{
+			name:            "namespace event",
+			locator:         NewLocator().LocateNamespace("clusters-foo"),
+			expectedCluster: "foo",
+		},
+		{
+			name:    "management cluster namespace",
+			locator: NewLocator().PodFromNames("openshift-kube-apiserver", "kube-apiserver-master-0", ""),
+		},
+		{
+			name:    "namespace of hosted clusters",
+			locator: NewLocator().LocateNamespace("clusters"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedCluster, test.locator.Keys[LocatorHostedClusterKey])
+			assert.Equal(t, test.expectedComponent, test.locator.Keys[LocatorHostedControlPlaneComponentKey])
+			assert.Equal(t, len(test.expectedCluster) > 0, IsInHostedControlPlaneNamespace(Interval{Condition: Condition{Locator: test.locator}}))
+		})
+	}
+}
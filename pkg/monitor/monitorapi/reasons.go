@@ -0,0 +1,263 @@
+package monitorapi
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+var (
+	intervalReasonsLock sync.RWMutex
+
+	// intervalReasonDescriptions is the taxonomy of reasons the chart legend and downstream queries can rely on.
+	// Reasons that share a value across kinds of intervals (e.g. NotReady for nodes and containers) share an entry.
+	intervalReasonDescriptions = map[IntervalReason]string{
+		IPTablesNotPermitted: "an iptables operation was not permitted while probing apiserver availability",
+
+		DisruptionBeganEventReason:              "a disruption backend stopped answering requests",
+		DisruptionEndedEventReason:              "a disruption backend started answering requests again",
+		DisruptionSamplerOutageBeganEventReason: "the disruption sampler itself could not reach the backend",
+		GracefulAPIServerShutdown:               "an apiserver shut down gracefully",
+		IncompleteAPIServerShutdown:             "an apiserver started shutting down but never finished",
+
+		HttpClientConnectionLost: "the kubelet lost its connection to the apiserver",
+
+		PodPendingReason:               "a pod is pending",
+		PodNotPendingReason:            "a pod is no longer pending",
+		PodWasPendingReason:            "a pod was still pending when the run ended",
+		PodReasonCreated:               "a pod was created",
+		PodReasonGracefulDeleteStarted: "a pod started a graceful delete",
+		PodReasonForceDelete:           "a pod was force deleted",
+		PodReasonDeleted:               "a pod was deleted",
+		PodReasonScheduled:             "a pod was scheduled to a node",
+		PodReasonEvicted:               "a pod was evicted",
+		PodReasonPreempted:             "a pod was preempted",
+		PodReasonFailed:                "a pod failed",
+
+		ContainerReasonContainerExit:      "a container exited",
+		ContainerReasonContainerStart:     "a container started",
+		ContainerReasonContainerWait:      "a container is waiting to start",
+		ContainerReasonReadinessFailed:    "a container readiness probe failed",
+		ContainerReasonReadinessErrored:   "a container readiness probe could not be run",
+		ContainerReasonStartupProbeFailed: "a container startup probe failed",
+		ContainerReasonReady:              "a container or node became ready",
+		ContainerReasonRestarted:          "a container restarted",
+		ContainerReasonNotReady:           "a container or node is not ready",
+		TerminationStateCleared:           "a container's last termination state was cleared",
+		ContainerReasonLogged:             "a container log from its first to its last line",
+
+		PodReasonDeletedBeforeScheduling: "a pod was deleted before it was scheduled",
+		PodReasonDeletedAfterCompletion:  "a pod was deleted after it completed",
+
+		NodeUpdateReason:        "a node is being updated",
+		NodeFailedLease:         "a node failed to update its lease",
+		NodeUnderPressureReason: "a node reported memory, disk or PID pressure and may evict pods to reclaim it",
+
+		MachineConfigChangeReason:  "a node started changing its machine config",
+		MachineConfigReachedReason: "a node reached its desired machine config",
+
+		Timeout: "an e2e test timed out",
+
+		E2ETestStarted:  "an e2e test started",
+		E2ETestFinished: "an e2e test finished",
+
+		CloudMetricsExtrenuous:                "a cloud metric exceeded its expected range",
+		FailedToDeleteCGroupsPath:             "the kubelet failed to delete a cgroups path",
+		FailedToAuthenticateWithOpenShiftUser: "the kubelet failed to authenticate with the openshift user",
+		FailedContactingAPIReason:             "a watcher failed to contact the apiserver",
+
+		UpgradeStartedReason:  "a cluster upgrade started",
+		UpgradeVersionReason:  "the cluster started upgrading to a new version",
+		UpgradeRollbackReason: "the cluster started rolling back an upgrade",
+		UpgradeFailedReason:   "a cluster upgrade failed",
+		UpgradeCompleteReason: "a cluster upgrade completed",
+		UpgradeHopReason:      "one upgrade within a chain of upgrades",
+
+		EventWriteRateSpikeReason: "the apiservers saw a spike in event writes",
+
+		NodeInstallerReason: "a static pod installer ran on a node",
+
+		EventsDroppedReason: "the event watcher lost enough events that the event timeline is incomplete",
+
+		DisruptionAttributedReason:   "a disruption overlapped a known cause of disruption",
+		DisruptionUnattributedReason: "a disruption overlapped no known cause of disruption",
+
+		CloudNodePreemptedReason:       "the cloud provider preempted a node's instance",
+		CloudNodeHostMaintenanceReason: "the cloud provider stopped a node's instance for host maintenance",
+		CloudNodeLiveMigrationReason:   "the cloud provider live migrated a node's instance for host maintenance",
+		CloudNodeHostErrorReason:       "the host of a node's instance failed",
+		CloudNodeUnavailableReason:     "the cloud provider reported a node's instance unavailable for a platform initiated reason",
+
+		SpotNodeReason:            "a node runs on a spot or preemptible instance",
+		SpotNodeTerminationReason: "the cloud provider was terminating a spot or preemptible node, and the node was recovering",
+
+		ChaosPodKilledReason:        "the chaos injector killed a control plane pod",
+		ChaosKubeletRestartedReason: "the chaos injector restarted the kubelet on a node",
+		ChaosAPILatencyReason:       "the chaos injector delayed the responses of a kube-apiserver",
+
+		BareMetalHostInspectingReason:     "ironic was inspecting the hardware of a BareMetalHost",
+		BareMetalHostProvisioningReason:   "a BareMetalHost was being provisioned with an image",
+		BareMetalHostDeprovisioningReason: "a BareMetalHost was being deprovisioned",
+		BareMetalHostErrorReason:          "the baremetal-operator reported an error on a BareMetalHost",
+
+		NodeReadyAfterMachineCreatedReason:       "the time from the creation of a machine to its node becoming Ready",
+		NodeInitializedAfterMachineCreatedReason: "the time from the creation of a machine to the cloud provider initializing its node",
+
+		DeploymentRolloutReason: "a deployment was rolling out a new generation until it was fully available",
+		DaemonSetRolloutReason:  "a daemonset was rolling out a new generation until it was fully available",
+
+		CRDNotEstablishedReason:          "a CRD that was established lost its Established condition, its resources were not served",
+		CRDNamesNotAcceptedReason:        "a CRD that had its names accepted lost its NamesAccepted condition",
+		CRDConversionWebhookFailedReason: "the apiserver failed to call the conversion webhook of a CRD",
+
+		ImagePullBackOffLoopReason: "a container kept backing off pulling its image",
+
+		ProbeFailureClusterReason: "the probes of the pods of a workload failed repeatedly, each failure less than a few minutes after the previous one",
+
+		FlowControlRejectionReason:       "API priority and fairness rejected the requests of a flow schema",
+		FlowControlQueueSaturationReason: "requests of a flow schema queued in API priority and fairness",
+
+		WatchLatencySpikeReason:  "a watch delivered an update to the test process seconds after it was written",
+		WatchCacheLagReason:      "reads of an apiserver waited on its watch cache to catch up with etcd",
+		WatchersTerminatedReason: "an apiserver terminated watchers that did not keep up with their events",
+		WatchErrorReason:         "a watch of the test process failed and its informer relisted",
+
+		KubeletUnavailableReason: "the kubelet API of a node did not respond to most of the pollers probing it",
+
+		ServingCertExpiringReason:         "a serving certificate was about to expire or was outside of its validity",
+		ServingCertHostnameMismatchReason: "a serving certificate was not valid for the host it was served for",
+		ServingCertInvalidChainReason:     "a serving certificate was not signed by the next certificate of the chain it was served with",
+		ServingCertIssuerChangedReason:    "a serving certificate was issued by a different issuer than at the start of the run",
+		ServingCertRotatedReason:          "a serving certificate was replaced by one from the same issuer",
+
+		FeatureGateChangedReason: "a feature gate was enabled or disabled for a payload version that already listed its feature gates",
+		FeatureSetChangedReason:  "the feature set of the cluster was changed",
+
+		UpdateHistoryReason:                   "an entry of the update history of the ClusterVersion, from the start of the update to its completion",
+		ConditionalUpdateNotRecommendedReason: "an update available to the cluster was not recommended because of conditional update risks",
+		UpgradeableFalseReason:                "the ClusterVersion reported Upgradeable=False, minor version updates were blocked",
+
+		DirectEgressAttemptReason: "a pod or node of a proxied cluster opened a connection outside of the cluster without going through the proxy, and got no reply",
+
+		AcceleratorsUnallocatableReason: "a ready and schedulable node that advertised accelerators had none allocatable",
+		DevicePluginUnavailableReason:   "pods of an accelerator device plugin daemonset were unavailable",
+
+		SandboxErrorCorrelatedReason: "a pod sandbox error, with the state of the ovnkube-node pod of its node at the time",
+
+		RBACDenialSpikeReason: "RBAC denied many requests of a user with the same verb on the same resource",
+
+		QuotaExhaustedReason: "a ResourceQuota or ClusterResourceQuota had used all of a resource it limits",
+
+		CRIORestartReason:         "the container runtime of a node stopped or exited until it was started again",
+		CRIOImageLayerErrorReason: "the container runtime failed to store or read a layer of an image",
+
+		TopologyAffinityErrorReason:    "the kubelet rejected a pod because the topology manager could not align its resources on NUMA nodes",
+		HugepageAllocationFailedReason: "the kubelet rejected a pod because the node could not allocate the hugepages it requested",
+
+		EgressIPAssignedReason:         "an egress IP was assigned to a node",
+		EgressIPUnassignedReason:       "an egress IP was not assigned to any node, while handed over between nodes for instance",
+		EgressIPSourceMismatchReason:   "traffic selected by an EgressIP reached an external endpoint from another source address",
+		EgressFirewallNotAppliedReason: "the rules of an EgressFirewall were not applied",
+
+		EtcdLocalMemberRestartReason: "an etcd member restarted, mapping its pod to its member ID",
+		EtcdLeaderFoundReason:        "an etcd member found the current leader",
+		EtcdLeaderElectedReason:      "an etcd member became the leader",
+		EtcdLeaderLostReason:         "an etcd member lost or changed its leader",
+		EtcdLeaderMissingReason:      "an etcd member has no leader",
+	}
+
+	// externalReasonSources copy their reasons verbatim from the cluster (events, conditions, alerts), so their
+	// reasons cannot be part of the taxonomy.
+	externalReasonSources = sets.New[IntervalSource](
+		SourceAlert,
+		SourceKubeEvent,
+		SourcePathologicalEventMarker,
+		SourceClusterOperatorMonitor,
+		SourceOperatorState,
+		SourceNodeMonitor,
+	)
+)
+
+// RegisterIntervalReason adds a reason to the taxonomy so that sources outside this package can produce it without
+// being flagged as unknown. Registering a reason that is already known with the same description is a no-op.
+func RegisterIntervalReason(reason IntervalReason, description string) error {
+	if len(reason) == 0 {
+		return fmt.Errorf("interval reason must not be empty")
+	}
+	if len(description) == 0 {
+		return fmt.Errorf("interval reason %q must have a description", reason)
+	}
+
+	intervalReasonsLock.Lock()
+	defer intervalReasonsLock.Unlock()
+	if existing, ok := intervalReasonDescriptions[reason]; ok && existing != description {
+		return fmt.Errorf("interval reason %q is already registered as %q", reason, existing)
+	}
+	intervalReasonDescriptions[reason] = description
+	return nil
+}
+
+// RegisterExternalReasonSource marks a source as copying its reasons verbatim from the cluster, so its reasons are
+// not validated against the taxonomy.
+func RegisterExternalReasonSource(source IntervalSource) {
+	intervalReasonsLock.Lock()
+	defer intervalReasonsLock.Unlock()
+	externalReasonSources.Insert(source)
+}
+
+// ExternalReasonSources returns the sources whose reasons are copied from the cluster, sorted.
+func ExternalReasonSources() []IntervalSource {
+	intervalReasonsLock.RLock()
+	defer intervalReasonsLock.RUnlock()
+	return sets.List(externalReasonSources)
+}
+
+// IntervalReasonDescription returns the description of a registered reason.
+func IntervalReasonDescription(reason IntervalReason) (string, bool) {
+	intervalReasonsLock.RLock()
+	defer intervalReasonsLock.RUnlock()
+	description, ok := intervalReasonDescriptions[reason]
+	return description, ok
+}
+
+// RegisteredIntervalReasons returns every registered reason, sorted.
+func RegisteredIntervalReasons() []IntervalReason {
+	intervalReasonsLock.RLock()
+	defer intervalReasonsLock.RUnlock()
+	ret := make([]IntervalReason, 0, len(intervalReasonDescriptions))
+	for reason := range intervalReasonDescriptions {
+		ret = append(ret, reason)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i] < ret[j] })
+	return ret
+}
+
+// UnknownIntervalReasons returns the unregistered reasons used by each source, skipping intervals without a reason
+// and sources that copy their reasons from the cluster.
+func UnknownIntervalReasons(intervals Intervals) map[IntervalSource][]IntervalReason {
+	intervalReasonsLock.RLock()
+	defer intervalReasonsLock.RUnlock()
+
+	unknown := map[IntervalSource]sets.Set[IntervalReason]{}
+	for _, interval := range intervals {
+		reason := interval.Message.Reason
+		if len(reason) == 0 || externalReasonSources.Has(interval.Source) {
+			continue
+		}
+		if _, ok := intervalReasonDescriptions[reason]; ok {
+			continue
+		}
+		if _, ok := unknown[interval.Source]; !ok {
+			unknown[interval.Source] = sets.New[IntervalReason]()
+		}
+		unknown[interval.Source].Insert(reason)
+	}
+
+	ret := map[IntervalSource][]IntervalReason{}
+	for source, reasons := range unknown {
+		ret[source] = sets.List(reasons)
+	}
+	return ret
+}
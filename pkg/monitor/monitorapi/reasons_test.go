@@ -0,0 +1,51 @@
+package monitorapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterIntervalReason(t *testing.T) {
+	description, ok := IntervalReasonDescription(NodeNotReadyReason)
+	require.True(t, ok)
+	assert.NotEmpty(t, description)
+
+	require.NoError(t, RegisterIntervalReason("OutOfTreeReason", "an out of tree reason"))
+	require.NoError(t, RegisterIntervalReason("OutOfTreeReason", "an out of tree reason"))
+	assert.Error(t, RegisterIntervalReason("OutOfTreeReason", "a different description"))
+	assert.Error(t, RegisterIntervalReason(E2ETestStarted, "a different description"))
+	assert.Error(t, RegisterIntervalReason("NoDescription", ""))
+	assert.Contains(t, RegisteredIntervalReasons(), IntervalReason("OutOfTreeReason"))
+}
+
+func TestUnknownIntervalReasons(t *testing.T) {
+	from := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	interval := func(source IntervalSource, reason IntervalReason) Interval {
+		return NewInterval(source, Info).Message(NewMessage().Reason(reason).HumanMessage("test")).Build(from, from.Add(time.Minute))
+	}
+
+	unknown := UnknownIntervalReasons(Intervals{
+		interval(SourceE2ETest, E2ETestStarted),
+		interval(SourceE2ETest, ""),
+		interval(SourcePodMonitor, "Typo"),
+		interval(SourcePodMonitor, "Typo"),
+		interval(SourcePodMonitor, "AnotherTypo"),
+		interval(SourceKubeEvent, "BackOff"),
+		interval(SourceOVSVswitchdLog, "OutOfTreeSourceReason"),
+	})
+	assert.Equal(t, map[IntervalSource][]IntervalReason{
+		SourcePodMonitor:     {"AnotherTypo", "Typo"},
+		SourceOVSVswitchdLog: {"OutOfTreeSourceReason"},
+	}, unknown)
+
+	RegisterExternalReasonSource(SourceOVSVswitchdLog)
+	defer func() {
+		intervalReasonsLock.Lock()
+		defer intervalReasonsLock.Unlock()
+		externalReasonSources.Delete(SourceOVSVswitchdLog)
+	}()
+	assert.Len(t, UnknownIntervalReasons(Intervals{interval(SourceOVSVswitchdLog, "OutOfTreeSourceReason")}), 0)
+}
@@ -0,0 +1,271 @@
+package monitorapi
+
+import (
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// IntervalSchemaVersion is the version of the serialized interval format described by IntervalSchema.  Adding
+// sources, reasons or keys keeps the version, removing or renaming any of them, or changing the shape of an
+// interval, bumps it.
+const IntervalSchemaVersion = "v1"
+
+// intervalSchemaID identifies the schema so consumers can tell which version they validate against.
+const intervalSchemaID = "https://github.com/openshift/origin/pkg/monitor/monitorapi/intervals." + IntervalSchemaVersion + ".schema.json"
+
+var (
+	// knownIntervalSources, knownLocatorTypes, knownLocatorKeys and knownAnnotationKeys list the constants of
+	// types.go.  TestSchemaListsEveryConstant keeps them complete.
+	knownIntervalSources = []IntervalSource{
+		SourceAlert,
+		SourceAPIServerShutdown,
+		SourceDisruption,
+		SourceE2ETest,
+		SourceKubeEvent,
+		SourceNetworkManagerLog,
+		SourceNodeMonitor,
+		SourceKubeletLog,
+		SourcePodLog,
+		SourceEtcdLog,
+		SourceEtcdLeadership,
+		SourcePodMonitor,
+		SourceMetricsEndpointDown,
+		APIServerGracefulShutdown,
+		APIServerClusterOperatorWatcher,
+		SourceTestData,
+		SourceOVSVswitchdLog,
+		SourcePathologicalEventMarker,
+		SourceClusterOperatorMonitor,
+		SourceOperatorState,
+		SourceNodeState,
+		SourcePodState,
+		SourceCloudMetrics,
+		SourceUpgradeHop,
+		SourceAPIServerEventRate,
+		SourcePromQLRule,
+		SourceEventWatcherHealth,
+		SourceDisruptionAttribution,
+		SourceCloudNodeInterruption,
+		SourceSpotNode,
+		SourceChaos,
+		SourceBareMetalHost,
+		SourceNodeInitialization,
+		SourceWorkloadRollout,
+		SourceCRDHealth,
+		SourceImagePullBackOff,
+		SourceProbeFailures,
+		SourceAPIServerFlowControl,
+		SourceWatchLag,
+		SourceKubeletAvailability,
+		SourceServingCertificate,
+		SourceFeatureGates,
+		SourceClusterVersionUpdates,
+		SourceProxyEgress,
+		SourceAccelerators,
+		SourceEgressIP,
+		SourceResourceAllocation,
+		SourceCRIOLog,
+		SourcePodSandboxCorrelation,
+		SourceAuditLog,
+		SourceResourceQuota,
+	}
+
+	knownLocatorTypes = []LocatorType{
+		LocatorTypePod,
+		LocatorTypeContainer,
+		LocatorTypeNode,
+		LocatorTypeAlert,
+		LocatorTypeMetricsEndpoint,
+		LocatorTypeClusterOperator,
+		LocatorTypeDisruption,
+		LocatorTypeKubeEvent,
+		LocatorTypeE2ETest,
+		LocatorTypeAPIServer,
+		LocatorTypeClusterVersion,
+		LocatorTypeKind,
+		LocatorTypeCloudMetrics,
+		LocatorTypePromQLRule,
+		LocatorTypeResource,
+		LocatorTypeEventWatcher,
+	}
+
+	knownLocatorKeys = []LocatorKey{
+		LocatorClusterOperatorKey,
+		LocatorClusterVersionKey,
+		LocatorNamespaceKey,
+		LocatorDeploymentKey,
+		LocatorNodeKey,
+		LocatorEtcdMemberKey,
+		LocatorNameKey,
+		LocatorHmsgKey,
+		LocatorInstanceKey,
+		LocatorPodKey,
+		LocatorUIDKey,
+		LocatorMirrorUIDKey,
+		LocatorMetricsPathKey,
+		LocatorServiceKey,
+		LocatorContainerKey,
+		LocatorAlertKey,
+		LocatorRouteKey,
+		LocatorBackendDisruptionNameKey,
+		LocatorDisruptionKey,
+		LocatorE2ETestKey,
+		LocatorLoadBalancerKey,
+		LocatorConnectionKey,
+		LocatorProtocolKey,
+		LocatorTargetKey,
+		LocatorRowKey,
+		LocatorServerKey,
+		LocatorMetricKey,
+		LocatorPromQLRuleKey,
+		LocatorGroupKey,
+		LocatorResourceKey,
+		LocatorUserKey,
+		LocatorVerbKey,
+		LocatorWorkloadKey,
+		LocatorHostedClusterKey,
+		LocatorHostedControlPlaneComponentKey,
+	}
+
+	knownAnnotationKeys = []AnnotationKey{
+		AnnotationAlertState,
+		AnnotationState,
+		AnnotationSeverity,
+		AnnotationReason,
+		AnnotationContainerExitCode,
+		AnnotationCause,
+		AnnotationConfig,
+		AnnotationContainer,
+		AnnotationImage,
+		AnnotationInteresting,
+		AnnotationCount,
+		AnnotationNode,
+		AnnotationEtcdLocalMember,
+		AnnotationEtcdTerm,
+		AnnotationEtcdLeader,
+		AnnotationPreviousEtcdLeader,
+		AnnotationPathological,
+		AnnotationConstructed,
+		AnnotationPhase,
+		AnnotationIsStaticPod,
+		AnnotationDuration,
+		AnnotationRequestAuditID,
+		AnnotationRoles,
+		AnnotationStatus,
+		AnnotationCondition,
+		AnnotationUpgradeHop,
+		AnnotationFromVersion,
+		AnnotationToVersion,
+		AnnotationAlertSilencedBy,
+		AnnotationEventWrites,
+		AnnotationWatchedEvents,
+		AnnotationSampledEvents,
+		AnnotationMergedIntervals,
+		AnnotationDisruptionCauses,
+		AnnotationChaosFault,
+		AnnotationRegistry,
+		AnnotationProbe,
+		AnnotationCrashCapture,
+		AnnotationPriorityLevel,
+		AnnotationFeatureGate,
+		AnnotationPayloadVersion,
+		AnnotationRisks,
+		AnnotationExtendedResource,
+		AnnotationOS,
+		AnnotationEgressIP,
+	}
+)
+
+// IntervalSchema returns a JSON schema of the serialized intervals, the {"items": [...]} written to e2e-events*.json,
+// generated from the sources, levels, locator types and keys, annotation keys and registered reasons of this package.
+// Reasons of the sources in x-externalReasonSources are copied from the cluster and may be any string.
+func IntervalSchema() map[string]interface{} {
+	levels := []string{}
+	for _, level := range []IntervalLevel{Info, Warning, Error} {
+		levels = append(levels, level.String())
+	}
+
+	knownReasons := []interface{}{}
+	for _, reason := range RegisteredIntervalReasons() {
+		description, _ := IntervalReasonDescription(reason)
+		knownReasons = append(knownReasons, map[string]interface{}{
+			"const":       string(reason),
+			"description": description,
+		})
+	}
+
+	return map[string]interface{}{
+		"$schema":                 "https://json-schema.org/draft/2020-12/schema",
+		"$id":                     intervalSchemaID,
+		"title":                   "openshift-tests intervals",
+		"version":                 IntervalSchemaVersion,
+		"x-externalReasonSources": sortedStrings(ExternalReasonSources()),
+		"type":                    "object",
+		"required":                []string{"items"},
+		"properties": map[string]interface{}{
+			"items": map[string]interface{}{
+				"type":  "array",
+				"items": map[string]interface{}{"$ref": "#/$defs/interval"},
+			},
+		},
+		"$defs": map[string]interface{}{
+			"interval": map[string]interface{}{
+				"type":     "object",
+				"required": []string{"level", "locator", "message", "from", "to"},
+				"properties": map[string]interface{}{
+					"level":   map[string]interface{}{"enum": levels},
+					"source":  map[string]interface{}{"$ref": "#/$defs/source"},
+					"display": map[string]interface{}{"type": "boolean"},
+					"locator": map[string]interface{}{"$ref": "#/$defs/locator"},
+					"message": map[string]interface{}{"$ref": "#/$defs/message"},
+					"from":    map[string]interface{}{"type": []string{"string", "null"}, "format": "date-time"},
+					"to":      map[string]interface{}{"type": []string{"string", "null"}, "format": "date-time"},
+				},
+			},
+			"source": map[string]interface{}{
+				"enum": sortedStrings(knownIntervalSources),
+			},
+			"locator": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					// locators without a type are still produced from the legacy string locators.
+					"type": map[string]interface{}{"enum": append([]string{""}, sortedStrings(knownLocatorTypes)...)},
+					"keys": map[string]interface{}{
+						"type":                 []string{"object", "null"},
+						"propertyNames":        map[string]interface{}{"enum": sortedStrings(knownLocatorKeys)},
+						"additionalProperties": map[string]interface{}{"type": "string"},
+					},
+				},
+			},
+			"message": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"reason":       map[string]interface{}{"$ref": "#/$defs/reason"},
+					"cause":        map[string]interface{}{"type": "string"},
+					"humanMessage": map[string]interface{}{"type": "string"},
+					"annotations": map[string]interface{}{
+						"type":                 []string{"object", "null"},
+						"propertyNames":        map[string]interface{}{"enum": sortedStrings(knownAnnotationKeys)},
+						"additionalProperties": map[string]interface{}{"type": "string"},
+					},
+				},
+			},
+			"reason": map[string]interface{}{
+				"anyOf": []interface{}{
+					map[string]interface{}{"oneOf": knownReasons},
+					map[string]interface{}{
+						"type":        "string",
+						"description": "a reason copied from the cluster by one of the x-externalReasonSources",
+					},
+				},
+			},
+		},
+	}
+}
+
+func sortedStrings[T ~string](values []T) []string {
+	ret := sets.New[string]()
+	for _, value := range values {
+		ret.Insert(string(value))
+	}
+	return sets.List(ret)
+}
@@ -0,0 +1,91 @@
+package monitorapi
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSchemaListsEveryConstant parses types.go so that a source, locator type, locator key or annotation key added
+// there cannot be missing from the schema.  Every constant of a const block declaring one of those types counts,
+// a few sources are declared untyped.
+func TestSchemaListsEveryConstant(t *testing.T) {
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, "types.go", nil, 0)
+	require.NoError(t, err)
+
+	declared := map[string][]string{}
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.CONST {
+			continue
+		}
+		blockType := ""
+		names := []string{}
+		for _, spec := range genDecl.Specs {
+			valueSpec := spec.(*ast.ValueSpec)
+			if ident, ok := valueSpec.Type.(*ast.Ident); ok && len(blockType) == 0 {
+				blockType = ident.Name
+			}
+			for _, name := range valueSpec.Names {
+				names = append(names, name.Name)
+			}
+		}
+		declared[blockType] = append(declared[blockType], names...)
+	}
+
+	schemaFile, err := parser.ParseFile(fileSet, "schema.go", nil, 0)
+	require.NoError(t, err)
+	listed := map[string][]string{}
+	ast.Inspect(schemaFile, func(node ast.Node) bool {
+		literal, ok := node.(*ast.CompositeLit)
+		if !ok {
+			return true
+		}
+		arrayType, ok := literal.Type.(*ast.ArrayType)
+		if !ok {
+			return true
+		}
+		elementType, ok := arrayType.Elt.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		for _, element := range literal.Elts {
+			if ident, ok := element.(*ast.Ident); ok {
+				listed[elementType.Name] = append(listed[elementType.Name], ident.Name)
+			}
+		}
+		return true
+	})
+
+	for _, typeName := range []string{"IntervalSource", "LocatorType", "LocatorKey", "AnnotationKey"} {
+		require.NotEmpty(t, declared[typeName], typeName)
+		assert.ElementsMatch(t, declared[typeName], listed[typeName], "every %s constant of types.go must be listed in schema.go", typeName)
+	}
+}
+
+func TestIntervalSchema(t *testing.T) {
+	data, err := json.Marshal(IntervalSchema())
+	require.NoError(t, err)
+
+	schema := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(data, &schema))
+	assert.Equal(t, IntervalSchemaVersion, schema["version"])
+	assert.Contains(t, schema["$id"], IntervalSchemaVersion)
+
+	defs := schema["$defs"].(map[string]interface{})
+	assert.Contains(t, defs["source"].(map[string]interface{})["enum"], string(SourceChaos))
+	assert.Contains(t, defs["source"].(map[string]interface{})["enum"], SourceNodeState)
+	assert.Contains(t, schema["x-externalReasonSources"], string(SourceKubeEvent))
+
+	reasons := defs["reason"].(map[string]interface{})["anyOf"].([]interface{})[0].(map[string]interface{})["oneOf"].([]interface{})
+	assert.Contains(t, reasons, map[string]interface{}{
+		"const":       string(E2ETestStarted),
+		"description": "an e2e test started",
+	})
+}
@@ -39,6 +39,20 @@ type RecorderWriter interface {
 	EndInterval(startedInterval int, t time.Time) *Interval
 }
 
+// RecorderNotifier is implemented by recorders that tell monitor tests about intervals as they are written, so that
+// they can react while the run is in progress without reading back everything recorded so far.
+type RecorderNotifier interface {
+	// AddIntervalHandler calls handler with every interval added, started, or ended from now on.  Started intervals
+	// have no To, ended intervals always have one.  Handlers are called by the writers and must return quickly.
+	AddIntervalHandler(handler func(Interval))
+}
+
+// RecorderFlusher is implemented by recorders that buffer the intervals written to them.
+type RecorderFlusher interface {
+	// Flush writes out the buffered intervals.
+	Flush() error
+}
+
 const (
 	// ObservedUpdateCountAnnotation is an annotation added locally (in the monitor only), that tracks how many updates
 	// we've seen to this resource.  This is useful during post-processing for determining if we have a hot resource.
@@ -108,6 +122,9 @@ const (
 	LocatorTypeClusterVersion  LocatorType = "ClusterVersion"
 	LocatorTypeKind            LocatorType = "Kind"
 	LocatorTypeCloudMetrics    LocatorType = "CloudMetrics"
+	LocatorTypePromQLRule      LocatorType = "PromQLRule"
+	LocatorTypeResource        LocatorType = "Resource"
+	LocatorTypeEventWatcher    LocatorType = "EventWatcher"
 )
 
 type LocatorKey string
@@ -141,6 +158,21 @@ const (
 	LocatorRowKey                   LocatorKey = "row"
 	LocatorServerKey                LocatorKey = "server"
 	LocatorMetricKey                LocatorKey = "metric"
+	LocatorPromQLRuleKey            LocatorKey = "promql-rule"
+	// LocatorGroupKey and LocatorResourceKey identify the API resource of a generic resource locator. The group is
+	// omitted for the core group.
+	LocatorGroupKey    LocatorKey = "group"
+	LocatorResourceKey LocatorKey = "resource"
+	// LocatorUserKey and LocatorVerbKey narrow a resource locator down to the requests of a user with a verb.
+	LocatorUserKey LocatorKey = "user"
+	LocatorVerbKey LocatorKey = "verb"
+	// LocatorWorkloadKey is the name shared by the pods of a workload whose kind is not known.
+	LocatorWorkloadKey LocatorKey = "workload"
+
+	// LocatorHostedClusterKey and LocatorHostedControlPlaneComponentKey are added to locators in the namespace of a
+	// hosted control plane on a HyperShift management cluster.
+	LocatorHostedClusterKey               LocatorKey = "hosted-cluster"
+	LocatorHostedControlPlaneComponentKey LocatorKey = "hcp-component"
 )
 
 type Locator struct {
@@ -165,6 +197,7 @@ const (
 
 	PodPendingReason               IntervalReason = "PodIsPending"
 	PodNotPendingReason            IntervalReason = "PodIsNotPending"
+	PodWasPendingReason            IntervalReason = "PodWasPending"
 	PodReasonCreated               IntervalReason = "Created"
 	PodReasonGracefulDeleteStarted IntervalReason = "GracefulDelete"
 	PodReasonForceDelete           IntervalReason = "ForceDelete"
@@ -184,6 +217,8 @@ const (
 	ContainerReasonRestarted          IntervalReason = "Restarted"
 	ContainerReasonNotReady           IntervalReason = "NotReady"
 	TerminationStateCleared           IntervalReason = "TerminationStateCleared"
+	// ContainerReasonLogged spans the first to the last line of a container log.
+	ContainerReasonLogged IntervalReason = "Logged"
 
 	PodReasonDeletedBeforeScheduling IntervalReason = "DeletedBeforeScheduling"
 	PodReasonDeletedAfterCompletion  IntervalReason = "DeletedAfterCompletion"
@@ -191,6 +226,9 @@ const (
 	NodeUpdateReason   IntervalReason = "NodeUpdate"
 	NodeNotReadyReason IntervalReason = "NotReady"
 	NodeFailedLease    IntervalReason = "FailedToUpdateLease"
+	// NodeUnderPressureReason is a window a node reported memory, disk or PID pressure, the state annotation is the
+	// condition.
+	NodeUnderPressureReason IntervalReason = "NodeUnderPressure"
 
 	MachineConfigChangeReason  IntervalReason = "MachineConfigChange"
 	MachineConfigReachedReason IntervalReason = "MachineConfigReached"
@@ -210,8 +248,99 @@ const (
 	UpgradeRollbackReason IntervalReason = "UpgradeRollback"
 	UpgradeFailedReason   IntervalReason = "UpgradeFailed"
 	UpgradeCompleteReason IntervalReason = "UpgradeComplete"
+	UpgradeHopReason      IntervalReason = "UpgradeHop"
+
+	EventWriteRateSpikeReason IntervalReason = "EventWriteRateSpike"
 
 	NodeInstallerReason IntervalReason = "NodeInstaller"
+
+	EventsDroppedReason IntervalReason = "EventsDropped"
+
+	DisruptionAttributedReason   IntervalReason = "DisruptionAttributed"
+	DisruptionUnattributedReason IntervalReason = "DisruptionUnattributed"
+
+	CloudNodePreemptedReason       IntervalReason = "CloudNodePreempted"
+	CloudNodeHostMaintenanceReason IntervalReason = "CloudNodeHostMaintenance"
+	CloudNodeLiveMigrationReason   IntervalReason = "CloudNodeLiveMigration"
+	CloudNodeHostErrorReason       IntervalReason = "CloudNodeHostError"
+	CloudNodeUnavailableReason     IntervalReason = "CloudNodeUnavailable"
+
+	SpotNodeReason            IntervalReason = "SpotNode"
+	SpotNodeTerminationReason IntervalReason = "SpotNodeTermination"
+
+	ChaosPodKilledReason        IntervalReason = "ChaosPodKilled"
+	ChaosKubeletRestartedReason IntervalReason = "ChaosKubeletRestarted"
+	ChaosAPILatencyReason       IntervalReason = "ChaosAPILatency"
+
+	BareMetalHostInspectingReason     IntervalReason = "BareMetalHostInspecting"
+	BareMetalHostProvisioningReason   IntervalReason = "BareMetalHostProvisioning"
+	BareMetalHostDeprovisioningReason IntervalReason = "BareMetalHostDeprovisioning"
+	BareMetalHostErrorReason          IntervalReason = "BareMetalHostError"
+
+	NodeReadyAfterMachineCreatedReason       IntervalReason = "NodeReadyAfterMachineCreated"
+	NodeInitializedAfterMachineCreatedReason IntervalReason = "NodeInitializedAfterMachineCreated"
+
+	DeploymentRolloutReason IntervalReason = "DeploymentRollout"
+	DaemonSetRolloutReason  IntervalReason = "DaemonSetRollout"
+
+	CRDNotEstablishedReason          IntervalReason = "CRDNotEstablished"
+	CRDNamesNotAcceptedReason        IntervalReason = "CRDNamesNotAccepted"
+	CRDConversionWebhookFailedReason IntervalReason = "CRDConversionWebhookFailed"
+
+	ImagePullBackOffLoopReason IntervalReason = "ImagePullBackOffLoop"
+
+	ProbeFailureClusterReason IntervalReason = "ProbeFailureCluster"
+
+	FlowControlRejectionReason       IntervalReason = "FlowControlRejection"
+	FlowControlQueueSaturationReason IntervalReason = "FlowControlQueueSaturation"
+
+	WatchLatencySpikeReason  IntervalReason = "WatchLatencySpike"
+	WatchCacheLagReason      IntervalReason = "WatchCacheLag"
+	WatchersTerminatedReason IntervalReason = "WatchersTerminated"
+	WatchErrorReason         IntervalReason = "WatchError"
+
+	KubeletUnavailableReason IntervalReason = "KubeletUnavailable"
+
+	ServingCertExpiringReason         IntervalReason = "ServingCertExpiring"
+	ServingCertHostnameMismatchReason IntervalReason = "ServingCertHostnameMismatch"
+	ServingCertInvalidChainReason     IntervalReason = "ServingCertInvalidChain"
+	ServingCertIssuerChangedReason    IntervalReason = "ServingCertIssuerChanged"
+	ServingCertRotatedReason          IntervalReason = "ServingCertRotated"
+
+	FeatureGateChangedReason IntervalReason = "FeatureGateChanged"
+	FeatureSetChangedReason  IntervalReason = "FeatureSetChanged"
+
+	UpdateHistoryReason                   IntervalReason = "UpdateHistory"
+	ConditionalUpdateNotRecommendedReason IntervalReason = "ConditionalUpdateNotRecommended"
+	UpgradeableFalseReason                IntervalReason = "UpgradeableFalse"
+
+	DirectEgressAttemptReason IntervalReason = "DirectEgressAttempt"
+
+	AcceleratorsUnallocatableReason IntervalReason = "AcceleratorsUnallocatable"
+	DevicePluginUnavailableReason   IntervalReason = "DevicePluginUnavailable"
+
+	SandboxErrorCorrelatedReason IntervalReason = "SandboxErrorCorrelated"
+
+	RBACDenialSpikeReason IntervalReason = "RBACDenialSpike"
+
+	QuotaExhaustedReason IntervalReason = "QuotaExhausted"
+
+	CRIORestartReason         IntervalReason = "CRIORestart"
+	CRIOImageLayerErrorReason IntervalReason = "ImageLayerError"
+
+	TopologyAffinityErrorReason    IntervalReason = "TopologyAffinityError"
+	HugepageAllocationFailedReason IntervalReason = "HugepageAllocationFailed"
+
+	EgressIPAssignedReason         IntervalReason = "EgressIPAssigned"
+	EgressIPUnassignedReason       IntervalReason = "EgressIPUnassigned"
+	EgressIPSourceMismatchReason   IntervalReason = "EgressIPSourceMismatch"
+	EgressFirewallNotAppliedReason IntervalReason = "EgressFirewallNotApplied"
+
+	EtcdLocalMemberRestartReason IntervalReason = "LocalMemberRestart"
+	EtcdLeaderFoundReason        IntervalReason = "LeaderFound"
+	EtcdLeaderElectedReason      IntervalReason = "LeaderElected"
+	EtcdLeaderLostReason         IntervalReason = "LeaderLost"
+	EtcdLeaderMissingReason      IntervalReason = "LeaderMissing"
 )
 
 type AnnotationKey string
@@ -243,6 +372,43 @@ const (
 	AnnotationRoles          AnnotationKey = "roles"
 	AnnotationStatus         AnnotationKey = "status"
 	AnnotationCondition      AnnotationKey = "condition"
+	// AnnotationUpgradeHop is the 1-based position of an upgrade within a chain of upgrades.
+	AnnotationUpgradeHop  AnnotationKey = "hop"
+	AnnotationFromVersion AnnotationKey = "from-version"
+	AnnotationToVersion   AnnotationKey = "to-version"
+	// AnnotationAlertSilencedBy is the ID of the Alertmanager silence that silenced an alert.
+	AnnotationAlertSilencedBy AnnotationKey = "silenced-by"
+	// AnnotationEventWrites and AnnotationWatchedEvents compare the events written to the apiservers with the events
+	// the event watcher recorded over the same interval.
+	AnnotationEventWrites   AnnotationKey = "event-writes"
+	AnnotationWatchedEvents AnnotationKey = "watched-events"
+	// AnnotationSampledEvents is the number of events a sampled event interval stands for during an event storm.
+	AnnotationSampledEvents AnnotationKey = "sampled-events"
+	// AnnotationMergedIntervals is the number of identical intervals a compacted interval replaced.
+	AnnotationMergedIntervals AnnotationKey = "merged-intervals"
+	// AnnotationDisruptionCauses lists the most likely causes of a disruption, most likely first.
+	AnnotationDisruptionCauses AnnotationKey = "causes"
+	// AnnotationChaosFault names the configured fault the chaos injector introduced.
+	AnnotationChaosFault AnnotationKey = "fault"
+	// AnnotationRegistry is the registry host of an image.
+	AnnotationRegistry AnnotationKey = "registry"
+	// AnnotationProbe is the type of a container probe: Readiness, Liveness or Startup.
+	AnnotationProbe AnnotationKey = "probe"
+	// AnnotationCrashCapture names the file holding the termination message and last log lines of a crashed container.
+	AnnotationCrashCapture AnnotationKey = "crash-capture"
+	// AnnotationPriorityLevel is the API priority and fairness priority level of a flow schema.
+	AnnotationPriorityLevel AnnotationKey = "priority-level"
+	// AnnotationFeatureGate is the name of a feature gate, AnnotationPayloadVersion the payload version its state is for.
+	AnnotationFeatureGate    AnnotationKey = "feature-gate"
+	AnnotationPayloadVersion AnnotationKey = "payload-version"
+	// AnnotationRisks lists the names of the conditional update risks of a release, separated by ",".
+	AnnotationRisks AnnotationKey = "risks"
+	// AnnotationExtendedResource is the name of an extended resource advertised by nodes, like nvidia.com/gpu.
+	AnnotationExtendedResource AnnotationKey = "extended-resource"
+	// AnnotationOS is the operating system of a node, only set on the intervals of Windows nodes.
+	AnnotationOS AnnotationKey = "os"
+	// AnnotationEgressIP is an egress IP address of an EgressIP.
+	AnnotationEgressIP AnnotationKey = "egress-ip"
 )
 
 // ConstructionOwner was originally meant to signify that an interval was derived from other intervals.
@@ -300,6 +466,34 @@ const (
 	SourceNodeState                              = "NodeState"
 	SourcePodState                               = "PodState"
 	SourceCloudMetrics                           = "CloudMetrics"
+	SourceUpgradeHop              IntervalSource = "UpgradeHop"
+	SourceAPIServerEventRate      IntervalSource = "APIServerEventRate"
+	SourcePromQLRule              IntervalSource = "PromQLRule"
+	SourceEventWatcherHealth      IntervalSource = "EventWatcherHealth"
+	SourceDisruptionAttribution   IntervalSource = "DisruptionAttribution"
+	SourceCloudNodeInterruption   IntervalSource = "CloudNodeInterruption"
+	SourceSpotNode                IntervalSource = "SpotNode"
+	SourceChaos                   IntervalSource = "Chaos"
+	SourceBareMetalHost           IntervalSource = "BareMetalHost"
+	SourceNodeInitialization      IntervalSource = "NodeInitialization"
+	SourceWorkloadRollout         IntervalSource = "WorkloadRollout"
+	SourceCRDHealth               IntervalSource = "CRDHealth"
+	SourceImagePullBackOff        IntervalSource = "ImagePullBackOff"
+	SourceProbeFailures           IntervalSource = "ProbeFailures"
+	SourceAPIServerFlowControl    IntervalSource = "APIServerFlowControl"
+	SourceWatchLag                IntervalSource = "WatchLag"
+	SourceKubeletAvailability     IntervalSource = "KubeletAvailability"
+	SourceServingCertificate      IntervalSource = "ServingCertificate"
+	SourceFeatureGates            IntervalSource = "FeatureGates"
+	SourceClusterVersionUpdates   IntervalSource = "ClusterVersionUpdates"
+	SourceProxyEgress             IntervalSource = "ProxyEgress"
+	SourceAccelerators            IntervalSource = "Accelerators"
+	SourceEgressIP                IntervalSource = "EgressIP"
+	SourceResourceAllocation      IntervalSource = "ResourceAllocation"
+	SourceCRIOLog                 IntervalSource = "CRIOLog"
+	SourcePodSandboxCorrelation   IntervalSource = "PodSandboxCorrelation"
+	SourceAuditLog                IntervalSource = "AuditLog"
+	SourceResourceQuota           IntervalSource = "ResourceQuota"
 )
 
 type Interval struct {
@@ -308,7 +502,7 @@ type Interval struct {
 	Source IntervalSource
 
 	// Display is a very coarse hint to any UI that this event was considered important enough to *possibly* be displayed by the source that produced it.
-	// UI may apply further filtering.
+	// UI may apply further filtering.  Instants, intervals with equal From and To, are only charted when Display is set.
 	Display bool
 
 	From time.Time
@@ -319,6 +513,17 @@ func (r IntervalReason) String() string {
 	return string(r)
 }
 
+// IsInstant returns true if the interval happened at a single moment.  Open intervals, with a zero To, are not instants.
+func (i Interval) IsInstant() bool {
+	return i.From.Equal(i.To) && !i.To.IsZero()
+}
+
+// Chartable returns true if the interval should be drawn on a timeline, instants are only drawn when the source asked
+// for them to be displayed.
+func (i Interval) Chartable() bool {
+	return i.Display || !i.IsInstant()
+}
+
 func (i Interval) String() string {
 	if i.From.Equal(i.To) {
 		return fmt.Sprintf("%s.%03d %s %s %s",
@@ -404,7 +609,7 @@ func sortKeys(keys []string) []string {
 
 	// Ensure these keys appear in this order. Other keys can be mixed in and will appear at the end in alphabetical
 	// order.
-	orderedKeys := []string{"namespace", "node", "pod", "uid", "server", "container", "shutdown", "row"}
+	orderedKeys := []string{"namespace", "group", "resource", "node", "pod", "uid", "server", "container", "shutdown", "row"}
 
 	// Create a map to store the indices of keys in the orderedKeys array.
 	// This will allow us to efficiently check if a key is in orderedKeys and find its position.
@@ -670,6 +875,12 @@ func AlertPending() EventIntervalMatchesFunc {
 	}
 }
 
+func AlertSilenced() EventIntervalMatchesFunc {
+	return func(eventInterval Interval) bool {
+		return len(eventInterval.Message.Annotations[AnnotationAlertSilencedBy]) > 0
+	}
+}
+
 // Filter returns a copy of intervals with only intervals that match the provided
 // function.
 func (intervals Intervals) Filter(eventFilterMatches EventIntervalMatchesFunc) Intervals {
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func TestIntervals_Duration(t *testing.T) {
@@ -116,6 +117,21 @@ func TestLocatorOldLocator(t *testing.T) {
 			locator:  NewLocator().PodFromNames("mynamespace", "mypod", "fakeuid"),
 			expected: "namespace/mynamespace pod/mypod uid/fakeuid",
 		},
+		{
+			name:     "namespaced resource locator",
+			locator:  NewLocator().ForGVR(schema.GroupVersionResource{Group: "kubevirt.io", Version: "v1", Resource: "virtualmachineinstances"}, "mynamespace", "myvmi"),
+			expected: "namespace/mynamespace group/kubevirt.io resource/virtualmachineinstances name/myvmi",
+		},
+		{
+			name:     "cluster scoped resource locator",
+			locator:  NewLocator().ForGVR(schema.GroupVersionResource{Group: "machineconfiguration.openshift.io", Version: "v1", Resource: "machineconfigs"}, "", "00-master"),
+			expected: "group/machineconfiguration.openshift.io resource/machineconfigs name/00-master",
+		},
+		{
+			name:     "core resource locator",
+			locator:  NewLocator().ForGVR(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}, "mynamespace", "myconfigmap"),
+			expected: "namespace/mynamespace resource/configmaps name/myconfigmap",
+		},
 		{
 			name: "container locator with keys mixed in", // not sure if this can happen but make sure what we expect occurs
 			locator: Locator{Keys: map[LocatorKey]string{
@@ -13,6 +13,8 @@ import (
 )
 
 type recorder struct {
+	intervalHandlers
+
 	lock   sync.Mutex
 	events monitorapi.Intervals
 
@@ -28,6 +30,42 @@ func NewRecorder() monitorapi.Recorder {
 }
 
 var _ monitorapi.Recorder = &recorder{}
+var _ monitorapi.RecorderNotifier = &recorder{}
+
+// intervalHandlers calls the handlers registered through AddIntervalHandler.
+type intervalHandlers struct {
+	lock     sync.RWMutex
+	handlers []func(monitorapi.Interval)
+}
+
+func (h *intervalHandlers) AddIntervalHandler(handler func(monitorapi.Interval)) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.handlers = append(h.handlers, handler)
+}
+
+func (h *intervalHandlers) notify(intervals ...monitorapi.Interval) {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	for _, handler := range h.handlers {
+		for _, interval := range intervals {
+			handler(interval)
+		}
+	}
+}
+
+// notifyEnded tells the handlers about an ended interval.  Intervals ended at or before their From keep a zero To in
+// the recorder, handlers see them ended at their From.
+func (h *intervalHandlers) notifyEnded(interval *monitorapi.Interval) {
+	if interval == nil {
+		return
+	}
+	ended := *interval
+	if ended.To.IsZero() {
+		ended.To = ended.From
+	}
+	h.notify(ended)
+}
 
 func (m *recorder) CurrentResourceState() monitorapi.ResourcesMap {
 	m.recordedResourceLock.Lock()
@@ -132,22 +170,31 @@ func (m *recorder) Record(conditions ...monitorapi.Condition) {
 // AddIntervals provides a mechanism to directly inject eventIntervals
 func (m *recorder) AddIntervals(eventIntervals ...monitorapi.Interval) {
 	m.lock.Lock()
-	defer m.lock.Unlock()
 	m.events = append(m.events, eventIntervals...)
+	m.lock.Unlock()
+	m.notify(eventIntervals...)
 }
 
 // StartInterval inserts a record at time t with the provided condition and returns an opaque
 // locator to the interval. The caller may close the sample at any point by invoking EndInterval().
 func (m *recorder) StartInterval(interval monitorapi.Interval) int {
 	m.lock.Lock()
-	defer m.lock.Unlock()
 	m.events = append(m.events, interval)
-	return len(m.events) - 1
+	id := len(m.events) - 1
+	m.lock.Unlock()
+	m.notify(interval)
+	return id
 }
 
 // EndInterval updates the To of the interval started by StartInterval if it is greater than
 // the from.
 func (m *recorder) EndInterval(startedInterval int, t time.Time) *monitorapi.Interval {
+	ended := m.endInterval(startedInterval, t)
+	m.notifyEnded(ended)
+	return ended
+}
+
+func (m *recorder) endInterval(startedInterval int, t time.Time) *monitorapi.Interval {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 	if startedInterval < len(m.events) {
@@ -0,0 +1,406 @@
+package monitor
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	monitorserialization "github.com/openshift/origin/pkg/monitor/serialization"
+)
+
+const (
+	// DefaultRecorderBatchSize is how many intervals are buffered before writers pay for flushing them.
+	DefaultRecorderBatchSize = 1000
+	// DefaultRecorderMaxInMemory is how many flushed intervals are kept in memory before they are spilled to disk.
+	DefaultRecorderMaxInMemory = 500000
+
+	// maxSpilledIntervalSize bounds a single serialized interval read back from the spill file.
+	maxSpilledIntervalSize = 16 * 1024 * 1024
+)
+
+// RecorderStats describe how the intervals written to a bounded recorder are held.
+type RecorderStats struct {
+	// QueueDepth is the number of intervals buffered and not yet flushed.
+	QueueDepth int `json:"queueDepth"`
+	// MaxQueueDepth is the deepest the buffer has been.
+	MaxQueueDepth int `json:"maxQueueDepth"`
+	// InMemory is the number of flushed intervals held in memory.
+	InMemory int `json:"inMemory"`
+	// Open is the number of started intervals that have not been ended.
+	Open int `json:"open"`
+	// Spilled is the number of intervals written to the spill file.
+	Spilled int `json:"spilled"`
+	// Flushes is the number of times the buffer was flushed.
+	Flushes int `json:"flushes"`
+}
+
+// BoundedRecorder is a recorder whose memory use is bounded for long running monitors.
+type BoundedRecorder interface {
+	monitorapi.Recorder
+	monitorapi.RecorderFlusher
+	monitorapi.RecorderNotifier
+
+	Stats() RecorderStats
+	// Close removes the spill file.  The intervals that were spilled can no longer be read.
+	Close() error
+}
+
+// spillChunk is a batch of intervals written to the spill file.  The bounds of the chunk let Intervals skip reading
+// the chunks that cannot contribute to the requested window.
+type spillChunk struct {
+	offset int64
+	length int64
+	// minFrom and maxFrom bound the From of the intervals, maxEnd their To.  openEnded is set if any interval has no To.
+	minFrom   time.Time
+	maxFrom   time.Time
+	maxEnd    time.Time
+	openEnded bool
+}
+
+func (c *spillChunk) add(interval monitorapi.Interval) {
+	if c.minFrom.IsZero() || interval.From.Before(c.minFrom) {
+		c.minFrom = interval.From
+	}
+	if interval.From.After(c.maxFrom) {
+		c.maxFrom = interval.From
+	}
+	if interval.To.IsZero() {
+		c.openEnded = true
+	}
+	if interval.To.After(c.maxEnd) {
+		c.maxEnd = interval.To
+	}
+}
+
+// mayOverlap returns true if an interval of the chunk may end at or after from.
+func (c *spillChunk) mayOverlap(from time.Time) bool {
+	return c.openEnded || !c.maxEnd.Before(from)
+}
+
+type boundedRecorder struct {
+	intervalHandlers
+
+	// resources are bounded by the number of objects in the cluster, so they are left to the default recorder.
+	resources *recorder
+
+	lock        sync.Mutex
+	batchSize   int
+	maxInMemory int
+	pending     monitorapi.Intervals
+	inMemory    monitorapi.Intervals
+	// open holds started intervals until they are ended, so that they can still be updated.
+	open     map[int]monitorapi.Interval
+	nextOpen int
+
+	// the spill file is only created once intervals are spilled.
+	spillDir    string
+	spillPath   string
+	spillFile   *os.File
+	spillWriter *bufio.Writer
+	spillSize   int64
+	spillChunks []spillChunk
+
+	stats RecorderStats
+}
+
+// NewBoundedRecorder creates a recorder that buffers intervals in batches of batchSize, making writers flush a full
+// batch themselves, and spills intervals to a file in spillDir once more than maxInMemory are held in memory.
+// Intervals reads spilled intervals back from disk.  An empty spillDir spills to the default temporary directory.
+func NewBoundedRecorder(spillDir string, batchSize, maxInMemory int) (BoundedRecorder, error) {
+	if batchSize < 1 || maxInMemory < 1 {
+		return nil, fmt.Errorf("batch size and max in memory must be positive, got %d and %d", batchSize, maxInMemory)
+	}
+	return &boundedRecorder{
+		resources:   NewRecorder().(*recorder),
+		batchSize:   batchSize,
+		maxInMemory: maxInMemory,
+		open:        map[int]monitorapi.Interval{},
+		spillDir:    spillDir,
+	}, nil
+}
+
+var _ monitorapi.Recorder = &boundedRecorder{}
+
+func (m *boundedRecorder) CurrentResourceState() monitorapi.ResourcesMap {
+	return m.resources.CurrentResourceState()
+}
+
+func (m *boundedRecorder) RecordResource(resourceType string, obj runtime.Object) {
+	m.resources.RecordResource(resourceType, obj)
+}
+
+// Record captures one or more conditions at the current time. All conditions are recorded
+// in monotonic order as EventInterval objects.
+func (m *boundedRecorder) Record(conditions ...monitorapi.Condition) {
+	m.RecordAt(time.Now().UTC(), conditions...)
+}
+
+// RecordAt captures one or more conditions at the provided time. All conditions are recorded
+// as EventInterval objects.
+func (m *boundedRecorder) RecordAt(t time.Time, conditions ...monitorapi.Condition) {
+	if len(conditions) == 0 {
+		return
+	}
+	intervals := monitorapi.Intervals{}
+	for _, condition := range conditions {
+		intervals = append(intervals, monitorapi.Interval{
+			Condition: condition,
+			From:      t,
+			To:        t,
+		})
+	}
+	m.AddIntervals(intervals...)
+}
+
+// AddIntervals buffers the intervals, flushing the buffer in the caller once it is full.
+func (m *boundedRecorder) AddIntervals(eventIntervals ...monitorapi.Interval) {
+	m.lock.Lock()
+	m.addLocked(eventIntervals...)
+	m.lock.Unlock()
+	m.notify(eventIntervals...)
+}
+
+func (m *boundedRecorder) addLocked(eventIntervals ...monitorapi.Interval) {
+	m.pending = append(m.pending, eventIntervals...)
+	if len(m.pending) > m.stats.MaxQueueDepth {
+		m.stats.MaxQueueDepth = len(m.pending)
+	}
+	if len(m.pending) >= m.batchSize {
+		m.flushLocked()
+	}
+}
+
+// StartInterval holds the interval in memory until it is ended and returns an opaque locator to it.
+func (m *boundedRecorder) StartInterval(interval monitorapi.Interval) int {
+	m.lock.Lock()
+	id := m.nextOpen
+	m.nextOpen++
+	m.open[id] = interval
+	m.lock.Unlock()
+	m.notify(interval)
+	return id
+}
+
+// EndInterval updates the To of the interval started by StartInterval if it is greater than
+// the from.  The interval can no longer be updated afterwards.
+func (m *boundedRecorder) EndInterval(startedInterval int, t time.Time) *monitorapi.Interval {
+	ended := m.endInterval(startedInterval, t)
+	m.notifyEnded(ended)
+	return ended
+}
+
+func (m *boundedRecorder) endInterval(startedInterval int, t time.Time) *monitorapi.Interval {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	interval, ok := m.open[startedInterval]
+	if !ok {
+		return nil
+	}
+	delete(m.open, startedInterval)
+	if interval.From.Before(t) {
+		interval.To = t
+	}
+	m.addLocked(interval)
+	return &interval
+}
+
+// Flush moves the buffered intervals to memory, spilling to disk if that exceeds the bound, and syncs the spill
+// file so that everything recorded so far survives a crash.
+func (m *boundedRecorder) Flush() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.flushLocked()
+	if m.spillFile == nil {
+		return nil
+	}
+	if err := m.spillWriter.Flush(); err != nil {
+		return err
+	}
+	return m.spillFile.Sync()
+}
+
+func (m *boundedRecorder) flushLocked() {
+	m.stats.Flushes++
+	m.inMemory = append(m.inMemory, m.pending...)
+	m.pending = nil
+	if len(m.inMemory) <= m.maxInMemory {
+		return
+	}
+
+	if m.spillFile == nil {
+		spillFile, err := os.CreateTemp(m.spillDir, "recorder-spill-*.jsonl")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error creating the recorder spill file, keeping intervals in memory: %v\n", err)
+			return
+		}
+		m.spillPath = spillFile.Name()
+		m.spillFile = spillFile
+		m.spillWriter = bufio.NewWriter(spillFile)
+	}
+
+	chunk := spillChunk{offset: m.spillSize}
+	defer func() {
+		if chunk.length > 0 {
+			m.spillChunks = append(m.spillChunks, chunk)
+		}
+	}()
+	for i, interval := range m.inMemory {
+		intervalJSON, err := monitorserialization.IntervalToOneLineJSON(interval)
+		var n int
+		if err == nil {
+			n, err = fmt.Fprintf(m.spillWriter, "%s\n", intervalJSON)
+		}
+		if err != nil {
+			// keep what could not be spilled in memory rather than lose it.
+			fmt.Fprintf(os.Stderr, "error spilling intervals to %s: %v\n", m.spillPath, err)
+			m.inMemory = m.inMemory[i:]
+			return
+		}
+		m.spillSize += int64(n)
+		chunk.length += int64(n)
+		chunk.add(interval)
+		m.stats.Spilled++
+	}
+	if err := m.spillWriter.Flush(); err != nil {
+		fmt.Fprintf(os.Stderr, "error spilling intervals to %s: %v\n", m.spillPath, err)
+	}
+	logrus.Infof("spilled %d intervals to %s, %d spilled in total", len(m.inMemory), m.spillPath, m.stats.Spilled)
+	m.inMemory = nil
+}
+
+func (m *boundedRecorder) Stats() RecorderStats {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	ret := m.stats
+	ret.QueueDepth = len(m.pending)
+	ret.InMemory = len(m.inMemory)
+	ret.Open = len(m.open)
+	return ret
+}
+
+// Intervals returns all events that occur between from and to, reading back the intervals that were spilled to disk
+// in chunks that can contribute to the window.  The spill file is read without holding the lock, so that writers are
+// not blocked.  Intervals are returned in order of their occurrence. The returned slice is a copy of the recorder's
+// state and is safe to update.
+func (m *boundedRecorder) Intervals(from, to time.Time) monitorapi.Intervals {
+	m.lock.Lock()
+	events := make(monitorapi.Intervals, 0, len(m.inMemory)+len(m.pending)+len(m.open))
+	events = append(events, m.inMemory...)
+	events = append(events, m.pending...)
+	for _, interval := range m.open {
+		events = append(events, interval)
+	}
+	var chunks []spillChunk
+	if m.spillWriter != nil {
+		if err := m.spillWriter.Flush(); err != nil {
+			fmt.Fprintf(os.Stderr, "error flushing spilled intervals to %s: %v\n", m.spillPath, err)
+		}
+		chunks = append(chunks, m.spillChunks...)
+	}
+	spillPath := m.spillPath
+	m.lock.Unlock()
+
+	spilled, err := readSpilled(spillPath, chunksFor(chunks, events, from, to))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error reading spilled intervals from %s: %v\n", spillPath, err)
+	}
+	events = append(events, spilled...)
+
+	sort.Sort(events)
+	return events.Slice(from, to)
+}
+
+// chunksFor returns the chunks that can hold intervals Slice(from, to) selects.  Slice keeps every interval from the
+// first one to reach from up to the last to start before to, so a chunk is only skipped if all of its intervals
+// started after to, or before any interval that reaches from could have started.
+func chunksFor(chunks []spillChunk, inMemory monitorapi.Intervals, from, to time.Time) []spillChunk {
+	if from.IsZero() && to.IsZero() {
+		return chunks
+	}
+	// the earliest start of an interval that reaches from.
+	var firstStart time.Time
+	earlier := func(t time.Time) {
+		if firstStart.IsZero() || t.Before(firstStart) {
+			firstStart = t
+		}
+	}
+	if !from.IsZero() {
+		for _, chunk := range chunks {
+			if chunk.mayOverlap(from) {
+				earlier(chunk.minFrom)
+			}
+		}
+		for _, interval := range inMemory {
+			if interval.To.IsZero() || !interval.To.Before(from) {
+				earlier(interval.From)
+			}
+		}
+		if firstStart.IsZero() {
+			// nothing reaches from
+			return nil
+		}
+	}
+
+	ret := []spillChunk{}
+	for _, chunk := range chunks {
+		if !to.IsZero() && chunk.minFrom.After(to) {
+			continue
+		}
+		if chunk.maxFrom.Before(firstStart) {
+			continue
+		}
+		ret = append(ret, chunk)
+	}
+	return ret
+}
+
+func readSpilled(spillPath string, chunks []spillChunk) (monitorapi.Intervals, error) {
+	if len(chunks) == 0 {
+		return nil, nil
+	}
+	spillFile, err := os.Open(spillPath)
+	if err != nil {
+		return nil, err
+	}
+	defer spillFile.Close()
+
+	ret := monitorapi.Intervals{}
+	for _, chunk := range chunks {
+		scanner := bufio.NewScanner(io.NewSectionReader(spillFile, chunk.offset, chunk.length))
+		scanner.Buffer(make([]byte, 64*1024), maxSpilledIntervalSize)
+		for scanner.Scan() {
+			interval, err := monitorserialization.IntervalFromJSON(scanner.Bytes())
+			if err != nil {
+				return ret, err
+			}
+			ret = append(ret, *interval)
+		}
+		if err := scanner.Err(); err != nil {
+			return ret, err
+		}
+	}
+	return ret, nil
+}
+
+func (m *boundedRecorder) Close() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.spillFile == nil {
+		return nil
+	}
+	closeErr := m.spillFile.Close()
+	if err := os.Remove(m.spillPath); err != nil {
+		return err
+	}
+	m.spillFile, m.spillWriter = nil, nil
+	m.spillSize, m.spillChunks = 0, nil
+	return closeErr
+}
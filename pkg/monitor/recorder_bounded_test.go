@@ -0,0 +1,123 @@
+package monitor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+func TestBoundedRecorder(t *testing.T) {
+	spillDir := t.TempDir()
+	recorder, err := NewBoundedRecorder(spillDir, 2, 3)
+	require.NoError(t, err)
+
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	interval := func(i int) monitorapi.Interval {
+		return monitorapi.NewInterval(monitorapi.SourceTestData, monitorapi.Info).
+			Locator(monitorapi.NewLocator().NodeFromName("node")).
+			Message(monitorapi.NewMessage().HumanMessage(fmt.Sprintf("%d", i))).
+			Build(start.Add(time.Duration(i)*time.Minute), start.Add(time.Duration(i)*time.Minute+time.Second))
+	}
+
+	notified := monitorapi.Intervals{}
+	recorder.AddIntervalHandler(func(interval monitorapi.Interval) { notified = append(notified, interval) })
+
+	opened := recorder.StartInterval(interval(0))
+	recorder.AddIntervals(interval(1))
+	assert.Equal(t, RecorderStats{QueueDepth: 1, MaxQueueDepth: 1, Open: 1}, recorder.Stats())
+
+	// a full batch is flushed by the writer.
+	recorder.AddIntervals(interval(2))
+	assert.Equal(t, RecorderStats{MaxQueueDepth: 2, InMemory: 2, Open: 1, Flushes: 1}, recorder.Stats())
+	files, err := os.ReadDir(spillDir)
+	require.NoError(t, err)
+	assert.Empty(t, files, "nothing is spilled while under the bound")
+
+	// going over the bound spills everything in memory.
+	recorder.AddIntervals(interval(3), interval(4))
+	assert.Equal(t, RecorderStats{MaxQueueDepth: 2, Open: 1, Spilled: 4, Flushes: 2}, recorder.Stats())
+	files, err = os.ReadDir(spillDir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	ended := recorder.EndInterval(opened, start.Add(10*time.Minute))
+	require.NotNil(t, ended)
+	assert.Equal(t, start.Add(10*time.Minute), ended.To)
+	assert.Nil(t, recorder.EndInterval(opened, start.Add(20*time.Minute)), "ended intervals cannot be updated")
+	recorder.AddIntervals(interval(5))
+	require.NoError(t, recorder.Flush())
+
+	// handlers see the interval when it is started and again when it ended.
+	require.Len(t, notified, 7)
+	assert.Equal(t, interval(0).From, notified[0].From)
+	assert.Equal(t, start.Add(10*time.Minute), notified[5].To)
+
+	intervals := recorder.Intervals(time.Time{}, time.Time{})
+	assert.Equal(t, []string{"0", "1", "2", "3", "4", "5"}, humanMessages(intervals))
+	assert.Equal(t, intervals.Slice(start.Add(2*time.Minute), start.Add(3*time.Minute)), recorder.Intervals(start.Add(2*time.Minute), start.Add(3*time.Minute)))
+
+	spilled, err := os.ReadFile(filepath.Join(spillDir, files[0].Name()))
+	require.NoError(t, err)
+	assert.Contains(t, string(spilled), `"humanMessage":"4"`)
+
+	require.NoError(t, recorder.Close())
+	files, err = os.ReadDir(spillDir)
+	require.NoError(t, err)
+	assert.Empty(t, files, "the spill file is removed")
+}
+
+func TestBoundedRecorderReadsOnlyTheChunksOfTheWindow(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	interval := func(from, to time.Duration) monitorapi.Interval {
+		return monitorapi.NewInterval(monitorapi.SourceTestData, monitorapi.Info).
+			Locator(monitorapi.NewLocator().NodeFromName("node")).
+			Message(monitorapi.NewMessage().HumanMessage(fmt.Sprintf("%s-%s", from, to))).
+			Build(start.Add(from), start.Add(to))
+	}
+	early := spillChunk{minFrom: start, maxFrom: start.Add(time.Minute), maxEnd: start.Add(2 * time.Minute)}
+	late := spillChunk{minFrom: start.Add(time.Hour), maxFrom: start.Add(2 * time.Hour), maxEnd: start.Add(3 * time.Hour)}
+	chunks := []spillChunk{early, late}
+
+	assert.Equal(t, chunks, chunksFor(chunks, nil, time.Time{}, time.Time{}))
+	assert.Equal(t, []spillChunk{late}, chunksFor(chunks, nil, start.Add(90*time.Minute), time.Time{}), "the early chunk ended before the window")
+	assert.Equal(t, []spillChunk{early}, chunksFor(chunks, nil, time.Time{}, start.Add(10*time.Minute)), "the late chunk started after the window")
+	assert.Equal(t, chunks, chunksFor(chunks, monitorapi.Intervals{interval(0, 3*time.Hour)}, start.Add(90*time.Minute), time.Time{}),
+		"an interval that started before the early chunk and reaches the window selects everything after it")
+	assert.Empty(t, chunksFor(chunks, nil, start.Add(4*time.Hour), time.Time{}))
+
+	// the results match the default recorder.
+	spillDir := t.TempDir()
+	bounded, err := NewBoundedRecorder(spillDir, 1, 1)
+	require.NoError(t, err)
+	defer bounded.Close()
+	unbounded := NewRecorder()
+	for _, i := range []monitorapi.Interval{interval(0, 3*time.Hour), interval(time.Minute, 2*time.Minute), interval(time.Hour, 2*time.Hour),
+		interval(2*time.Hour, 3*time.Hour), interval(4*time.Hour, 5*time.Hour)} {
+		bounded.AddIntervals(i)
+		unbounded.AddIntervals(i)
+	}
+	for _, window := range [][2]time.Time{
+		{{}, {}},
+		{start.Add(90 * time.Minute), {}},
+		{start.Add(4 * time.Hour), {}},
+		{{}, start.Add(10 * time.Minute)},
+		{start.Add(150 * time.Minute), start.Add(4 * time.Hour)},
+	} {
+		assert.Equal(t, humanMessages(unbounded.Intervals(window[0], window[1])), humanMessages(bounded.Intervals(window[0], window[1])), "window %v", window)
+	}
+}
+
+func humanMessages(intervals monitorapi.Intervals) []string {
+	messages := []string{}
+	for _, interval := range intervals {
+		messages = append(messages, interval.Message.HumanMessage)
+	}
+	return messages
+}
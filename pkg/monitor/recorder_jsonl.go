@@ -100,6 +100,21 @@ func (m *jsonlRecorder) RecordAt(t time.Time, conditions ...monitorapi.Condition
 	m.AddIntervals(intervals...)
 }
 
+// Flush flushes the delegate if it buffers intervals.
+func (m *jsonlRecorder) Flush() error {
+	if flusher, ok := m.delegate.(monitorapi.RecorderFlusher); ok {
+		return flusher.Flush()
+	}
+	return nil
+}
+
+// AddIntervalHandler registers the handler with the delegate if it notifies about intervals.
+func (m *jsonlRecorder) AddIntervalHandler(handler func(monitorapi.Interval)) {
+	if notifier, ok := m.delegate.(monitorapi.RecorderNotifier); ok {
+		notifier.AddIntervalHandler(handler)
+	}
+}
+
 func (m *jsonlRecorder) Intervals(from, to time.Time) monitorapi.Intervals {
 	return m.delegate.Intervals(from, to)
 }
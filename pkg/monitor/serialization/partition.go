@@ -0,0 +1,215 @@
+package monitorserialization
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// IntervalIndexFilename is the manifest of a partitioned intervals directory.  It is written after the partitions,
+	// so a directory without one was interrupted while it was written.
+	IntervalIndexFilename = "index.json"
+
+	partitionFileTimeFormat = "20060102T15"
+)
+
+var unsafePathCharacters = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// IntervalPartition describes one file of a partitioned intervals directory, the intervals of one source that start
+// in one hour.
+type IntervalPartition struct {
+	Source string `json:"source"`
+	// File is relative to the partitioned directory.
+	File  string      `json:"file"`
+	Hour  metav1.Time `json:"hour"`
+	Count int         `json:"count"`
+
+	// From and To bound every interval in the partition, intervals can continue past the end of the hour.
+	From metav1.Time `json:"from"`
+	To   metav1.Time `json:"to"`
+}
+
+// Overlaps returns true if any interval in the partition may overlap from-to.  Zero times are unbounded.
+func (p IntervalPartition) Overlaps(from, to time.Time) bool {
+	if !to.IsZero() && p.From.Time.After(to) {
+		return false
+	}
+	if !from.IsZero() && !p.To.IsZero() && p.To.Time.Before(from) {
+		return false
+	}
+	return true
+}
+
+type IntervalIndex struct {
+	Partitions []IntervalPartition `json:"partitions"`
+}
+
+// IntervalsToPartitionedDir writes intervals to one file per source and hour below dir, followed by an index of the
+// files, so that readers can load only the sources and hours they need.
+func IntervalsToPartitionedDir(dir string, intervals monitorapi.Intervals) error {
+	type partitionKey struct {
+		source monitorapi.IntervalSource
+		hour   time.Time
+	}
+	partitions := map[partitionKey]monitorapi.Intervals{}
+	for _, interval := range intervals {
+		key := partitionKey{source: interval.Source, hour: interval.From.UTC().Truncate(time.Hour)}
+		partitions[key] = append(partitions[key], interval)
+	}
+
+	index := IntervalIndex{Partitions: []IntervalPartition{}}
+	for key, partitionIntervals := range partitions {
+		source := string(key.source)
+		if len(source) == 0 {
+			source = "unknown"
+		}
+		file := filepath.Join(unsafePathCharacters.ReplaceAllString(source, "_"), key.hour.Format(partitionFileTimeFormat)+".json")
+		if err := os.MkdirAll(filepath.Join(dir, filepath.Dir(file)), 0755); err != nil {
+			return err
+		}
+		if err := writeFileAtomically(filepath.Join(dir, file), partitionIntervals); err != nil {
+			return err
+		}
+
+		partition := partitionFor(partitionIntervals)
+		partition.Source = string(key.source)
+		partition.File = file
+		partition.Hour = metav1.Time{Time: key.hour}
+		index.Partitions = append(index.Partitions, partition)
+	}
+	sort.Slice(index.Partitions, func(i, j int) bool {
+		if !index.Partitions[i].Hour.Equal(&index.Partitions[j].Hour) {
+			return index.Partitions[i].Hour.Before(&index.Partitions[j].Hour)
+		}
+		return index.Partitions[i].Source < index.Partitions[j].Source
+	})
+
+	indexJSON, err := json.MarshalIndent(index, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, IntervalIndexFilename), indexJSON, 0644)
+}
+
+// writeFileAtomically keeps an interrupted write from leaving a truncated partition behind.
+func writeFileAtomically(filename string, intervals monitorapi.Intervals) error {
+	intervalsJSON, err := IntervalsToJSON(intervals)
+	if err != nil {
+		return err
+	}
+	tmpFilename := filename + ".tmp"
+	if err := os.WriteFile(tmpFilename, intervalsJSON, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpFilename, filename)
+}
+
+func partitionFor(intervals monitorapi.Intervals) IntervalPartition {
+	ret := IntervalPartition{Count: len(intervals)}
+	unbounded := false
+	for i, interval := range intervals {
+		if i == 0 || interval.From.Before(ret.From.Time) {
+			ret.From = metav1.Time{Time: interval.From}
+		}
+		switch {
+		case interval.To.IsZero():
+			unbounded = true
+		case interval.To.After(ret.To.Time):
+			ret.To = metav1.Time{Time: interval.To}
+		}
+	}
+	// an interval without an end runs until the end of the job, so the partition has no end either.
+	if unbounded {
+		ret.To = metav1.Time{}
+	}
+	return ret
+}
+
+// ReadIntervalIndex reads the index of a partitioned intervals directory.  If writing the directory was interrupted
+// before the index, it is rebuilt from the partitions that were written.
+func ReadIntervalIndex(dir string) (*IntervalIndex, error) {
+	indexJSON, err := os.ReadFile(filepath.Join(dir, IntervalIndexFilename))
+	switch {
+	case os.IsNotExist(err):
+		return rebuildIntervalIndex(dir)
+	case err != nil:
+		return nil, err
+	}
+
+	index := &IntervalIndex{}
+	if err := json.Unmarshal(indexJSON, index); err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %w", filepath.Join(dir, IntervalIndexFilename), err)
+	}
+	return index, nil
+}
+
+func rebuildIntervalIndex(dir string) (*IntervalIndex, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*", "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	index := &IntervalIndex{Partitions: []IntervalPartition{}}
+	for _, filename := range files {
+		hour, err := time.Parse(partitionFileTimeFormat, strings.TrimSuffix(filepath.Base(filename), ".json"))
+		if err != nil {
+			continue
+		}
+		intervals, err := EventsFromFile(filename)
+		if err != nil {
+			return nil, err
+		}
+		if len(intervals) == 0 {
+			continue
+		}
+		partition := partitionFor(intervals)
+		partition.Source = string(intervals[0].Source)
+		partition.File, _ = filepath.Rel(dir, filename)
+		partition.Hour = metav1.Time{Time: hour}
+		index.Partitions = append(index.Partitions, partition)
+	}
+	return index, nil
+}
+
+// IntervalsFromPartitionedDir reads the partitions of dir accepted by include, or every partition if include is nil.
+func IntervalsFromPartitionedDir(dir string, include func(IntervalPartition) bool) (monitorapi.Intervals, error) {
+	index, err := ReadIntervalIndex(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := monitorapi.Intervals{}
+	for _, partition := range index.Partitions {
+		if include != nil && !include(partition) {
+			continue
+		}
+		intervals, err := EventsFromFile(filepath.Join(dir, partition.File))
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, intervals...)
+	}
+	sort.Sort(ret)
+	return ret, nil
+}
+
+// IntervalsFromPath reads either a single intervals file or a partitioned intervals directory.
+func IntervalsFromPath(path string) (monitorapi.Intervals, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return IntervalsFromPartitionedDir(path, nil)
+	}
+	return EventsFromFile(path)
+}
@@ -0,0 +1,68 @@
+package monitorserialization
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartitionedIntervals(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC)
+	interval := func(source monitorapi.IntervalSource, message string, from, to time.Time) monitorapi.Interval {
+		return monitorapi.NewInterval(source, monitorapi.Info).
+			Locator(monitorapi.NewLocator().NodeFromName("node")).
+			Message(monitorapi.NewMessage().HumanMessage(message)).
+			Build(from, to)
+	}
+	intervals := monitorapi.Intervals{
+		interval(monitorapi.SourceNodeState, "first", start, start.Add(time.Minute)),
+		// continues into the next hour, but is partitioned by when it starts.
+		interval(monitorapi.SourceNodeState, "second", start.Add(20*time.Minute), start.Add(40*time.Minute)),
+		interval(monitorapi.SourceNodeState, "third", start.Add(time.Hour), start.Add(time.Hour+time.Minute)),
+		interval(monitorapi.SourceAlert, "alert", start, time.Time{}),
+	}
+	require.NoError(t, IntervalsToPartitionedDir(dir, intervals))
+
+	index, err := ReadIntervalIndex(dir)
+	require.NoError(t, err)
+	files := []string{}
+	for _, partition := range index.Partitions {
+		files = append(files, partition.File)
+	}
+	assert.Equal(t, []string{"Alert/20240301T10.json", "NodeState/20240301T10.json", "NodeState/20240301T11.json"}, files)
+	assert.Equal(t, 2, index.Partitions[1].Count)
+	assert.Equal(t, start.Add(40*time.Minute), index.Partitions[1].To.Time.UTC())
+	assert.True(t, index.Partitions[0].To.IsZero(), "the alert never ended")
+
+	all, err := IntervalsFromPath(dir)
+	require.NoError(t, err)
+	assert.Len(t, all, 4)
+
+	// only the partitions overlapping 11:05-11:10 are read, the unfinished alert and the intervals starting at 11:30.
+	selected, err := IntervalsFromPartitionedDir(dir, func(partition IntervalPartition) bool {
+		return partition.Overlaps(start.Add(35*time.Minute), start.Add(40*time.Minute))
+	})
+	require.NoError(t, err)
+	messages := []string{}
+	for _, interval := range selected {
+		messages = append(messages, interval.Message.HumanMessage)
+	}
+	assert.ElementsMatch(t, []string{"alert", "first", "second"}, messages)
+
+	// without an index, for instance after an interrupted write, the partitions that were written are still readable.
+	require.NoError(t, os.Remove(filepath.Join(dir, IntervalIndexFilename)))
+	rebuilt, err := ReadIntervalIndex(dir)
+	require.NoError(t, err)
+	indexJSON, err := json.Marshal(index)
+	require.NoError(t, err)
+	rebuiltJSON, err := json.Marshal(rebuilt)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(indexJSON), string(rebuiltJSON))
+}
@@ -135,12 +135,12 @@ func IntervalsToFile(filename string, intervals monitorapi.Intervals) error {
 	return ioutil.WriteFile(filename, json, 0644)
 }
 
-// TODO: this is very similar but subtly different to the function above, what is the purpose of skipping those
-// with from/to equal or empty to?
+// EventsIntervalsToJSON serializes the intervals that can be charted, skipping instants that were not marked for
+// display.
 func EventsIntervalsToJSON(events monitorapi.Intervals) ([]byte, error) {
 	outputEvents := []EventInterval{}
 	for _, curr := range events {
-		if curr.From == curr.To && !curr.To.IsZero() {
+		if !curr.Chartable() {
 			continue
 		}
 
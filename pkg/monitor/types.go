@@ -8,6 +8,8 @@ type Interface interface {
 	Start(ctx context.Context) error
 	Stop(ctx context.Context) (ResultState, error)
 	SerializeResults(ctx context.Context, junitSuiteName, timeSuffix string) error
+	// Checkpoint evaluates the monitor tests so far without stopping the monitor.
+	Checkpoint(ctx context.Context, name string) (ResultState, error)
 }
 
 type ResultState string
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -12,6 +13,8 @@ import (
 	"github.com/sirupsen/logrus"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
 	"github.com/openshift/origin/pkg/monitor/monitorapi"
@@ -78,7 +81,36 @@ func (r *monitorTestRegistry) ListMonitorTests() sets.String {
 	return sets.StringKeySet(r.monitorTests)
 }
 
+func (r *monitorTestRegistry) DescribeMonitorTests() []DescribedMonitorTest {
+	ret := []DescribedMonitorTest{}
+	for _, name := range r.ListMonitorTests().List() {
+		item := r.monitorTests[name]
+		described := DescribedMonitorTest{
+			Name:          item.name,
+			JiraComponent: item.jiraComponent,
+		}
+		if describer, ok := item.monitorTest.(MonitorTestWithDescription); ok {
+			described.Described = true
+			described.MonitorTestDescription = describer.Describe()
+		}
+		ret = append(ret, described)
+	}
+	return ret
+}
+
 func (r *monitorTestRegistry) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) ([]*junitapi.JUnitTestCase, error) {
+	kubeClient, err := kubernetes.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return nil, err
+	}
+	// monitor tests share informers so that each resource is only watched once.
+	kubeInformers := informers.NewSharedInformerFactory(kubeClient, 0)
+	for _, monitorTest := range r.monitorTests {
+		if withInformers, ok := monitorTest.monitorTest.(MonitorTestWithSharedInformers); ok {
+			withInformers.SetSharedInformers(kubeInformers)
+		}
+	}
+
 	wg := sync.WaitGroup{}
 	junitCh := make(chan *junitapi.JUnitTestCase, 2*len(r.monitorTests))
 	errCh := make(chan error, len(r.monitorTests))
@@ -133,6 +165,7 @@ func (r *monitorTestRegistry) StartCollection(ctx context.Context, adminRESTConf
 	wg.Wait()
 	close(junitCh)
 	close(errCh)
+	kubeInformers.Start(ctx.Done())
 
 	junits := []*junitapi.JUnitTestCase{}
 	errs := []error{}
@@ -235,12 +268,27 @@ func (r *monitorTestRegistry) ConstructComputedIntervals(ctx context.Context, st
 	junits := []*junitapi.JUnitTestCase{}
 	errs := []error{}
 
-	for _, monitorTest := range r.monitorTests {
+	orderedMonitorTests, err := r.constructionOrder()
+	if err != nil {
+		return nil, nil, err
+	}
+	constructedBy := map[string]monitorapi.Intervals{}
+	for _, monitorTest := range orderedMonitorTests {
 		testName := fmt.Sprintf("[Jira:%q] monitor test %v interval construction", monitorTest.jiraComponent, monitorTest.name)
 
+		monitorTestStartingIntervals := startingIntervals
+		if dependent, ok := monitorTest.monitorTest.(MonitorTestWithDependencies); ok {
+			monitorTestStartingIntervals = append(monitorapi.Intervals{}, startingIntervals...)
+			for _, dependency := range dependent.ComputedIntervalDependencies() {
+				monitorTestStartingIntervals = append(monitorTestStartingIntervals, constructedBy[dependency]...)
+			}
+			sort.Sort(monitorTestStartingIntervals)
+		}
+
 		start := time.Now()
-		localIntervals, err := constructComputedIntervalsWithPanicProtection(ctx, monitorTest.monitorTest, startingIntervals, recordedResources, beginning, end)
+		localIntervals, err := constructComputedIntervalsWithPanicProtection(ctx, monitorTest.monitorTest, monitorTestStartingIntervals, recordedResources, beginning, end)
 		intervals = append(intervals, localIntervals...)
+		constructedBy[monitorTest.name] = localIntervals
 		end := time.Now()
 		duration := end.Sub(start)
 		if err != nil {
@@ -280,6 +328,51 @@ func (r *monitorTestRegistry) ConstructComputedIntervals(ctx context.Context, st
 	return intervals, junits, utilerrors.NewAggregate(errs)
 }
 
+// constructionOrder returns the monitor tests sorted by name, except that every monitor test comes after the monitor
+// tests it depends on.
+func (r *monitorTestRegistry) constructionOrder() ([]*monitorTesttItem, error) {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := map[string]int{}
+	ret := []*monitorTesttItem{}
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		monitorTest, ok := r.monitorTests[name]
+		if !ok {
+			logrus.Infof("monitor test %q is not running, ignoring it as a dependency of %q", name, path[len(path)-1])
+			return nil
+		}
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("monitor tests have a dependency cycle: %s", strings.Join(append(path, name), " -> "))
+		}
+
+		state[name] = visiting
+		if dependent, ok := monitorTest.monitorTest.(MonitorTestWithDependencies); ok {
+			for _, dependency := range dependent.ComputedIntervalDependencies() {
+				if err := visit(dependency, append(path, name)); err != nil {
+					return err
+				}
+			}
+		}
+		state[name] = visited
+		ret = append(ret, monitorTest)
+		return nil
+	}
+
+	for _, name := range r.ListMonitorTests().List() {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
 func (r *monitorTestRegistry) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
 	junits := []*junitapi.JUnitTestCase{}
 	errs := []error{}
@@ -0,0 +1,96 @@
+package monitortestframework
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+)
+
+// constructingMonitorTest constructs one interval with its name as the message and remembers the messages of the
+// intervals it was started with.
+type constructingMonitorTest struct {
+	name         string
+	dependencies []string
+	sawMessages  []string
+}
+
+func (w *constructingMonitorTest) ComputedIntervalDependencies() []string {
+	return w.dependencies
+}
+
+func (*constructingMonitorTest) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	return nil
+}
+
+func (*constructingMonitorTest) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	return nil, nil, nil
+}
+
+func (w *constructingMonitorTest) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	for _, interval := range startingIntervals {
+		w.sawMessages = append(w.sawMessages, interval.Message.HumanMessage)
+	}
+	return monitorapi.Intervals{
+		monitorapi.NewInterval(monitorapi.SourceTestData, monitorapi.Info).
+			Message(monitorapi.NewMessage().HumanMessage(w.name)).
+			Build(beginning, end),
+	}, nil
+}
+
+func (*constructingMonitorTest) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	return nil, nil
+}
+
+func (*constructingMonitorTest) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	return nil
+}
+
+func (*constructingMonitorTest) Cleanup(ctx context.Context) error {
+	return nil
+}
+
+func TestConstructComputedIntervalsDependencies(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	// names sort before their dependencies, so they only see them if the dependencies are honored.
+	attribution := &constructingMonitorTest{name: "a-disruption-attribution", dependencies: []string{"z-node-state", "disabled"}}
+	summary := &constructingMonitorTest{name: "b-summary", dependencies: []string{"a-disruption-attribution"}}
+	nodeState := &constructingMonitorTest{name: "z-node-state"}
+	registry := NewMonitorTestRegistry()
+	for _, monitorTest := range []*constructingMonitorTest{attribution, summary, nodeState} {
+		require.NoError(t, registry.AddMonitorTest(monitorTest.name, "Test Framework", monitorTest))
+	}
+
+	starting := monitorapi.Intervals{
+		monitorapi.NewInterval(monitorapi.SourceTestData, monitorapi.Info).
+			Message(monitorapi.NewMessage().HumanMessage("raw")).
+			Build(start, end),
+	}
+	intervals, _, err := registry.ConstructComputedIntervals(context.TODO(), starting, nil, start, end)
+	require.NoError(t, err)
+	assert.Len(t, intervals, 3)
+
+	assert.Equal(t, []string{"raw"}, nodeState.sawMessages)
+	assert.ElementsMatch(t, []string{"raw", "z-node-state"}, attribution.sawMessages)
+	// only direct dependencies are added.
+	assert.ElementsMatch(t, []string{"raw", "a-disruption-attribution"}, summary.sawMessages)
+	assert.Len(t, starting, 1, "the starting intervals are not modified")
+}
+
+func TestConstructComputedIntervalsDependencyCycle(t *testing.T) {
+	registry := NewMonitorTestRegistry()
+	registry.AddMonitorTestOrDie("a", "Test Framework", &constructingMonitorTest{name: "a", dependencies: []string{"b"}})
+	registry.AddMonitorTestOrDie("b", "Test Framework", &constructingMonitorTest{name: "b", dependencies: []string{"a"}})
+
+	_, _, err := registry.ConstructComputedIntervals(context.TODO(), nil, nil, time.Time{}, time.Time{})
+	require.Error(t, err)
+	assert.Equal(t, "monitor tests have a dependency cycle: a -> b -> a", err.Error())
+}
@@ -6,6 +6,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/util/sets"
 
+	"k8s.io/client-go/informers"
 	"k8s.io/client-go/rest"
 
 	"github.com/openshift/origin/pkg/monitor/monitorapi"
@@ -34,6 +35,23 @@ type MonitorTestInitializationInfo struct {
 
 	// DisableMonitorTests will remove any monitor tests contained in the provided list
 	DisableMonitorTests []string
+
+	// SLOConfigFile is the path to a file of service level objectives to evaluate against the intervals of the run.
+	SLOConfigFile string
+
+	// ChaosConfigFile is the path to a file of faults to inject into the cluster while the monitor runs.
+	ChaosConfigFile string
+
+	// PromQLRulesFile is the path to a file of PromQL range queries whose threshold violations are reported.
+	PromQLRulesFile string
+
+	// EventNamespaceInclude and EventNamespaceExclude are regexes limiting the namespaces the event watcher records
+	// events for.  An empty include matches every namespace.
+	EventNamespaceInclude []string
+	EventNamespaceExclude []string
+
+	// EventLogVerbosity controls how many of the events the event watcher sees are logged: none, sampled or all.
+	EventLogVerbosity string
 }
 
 type MonitorTest interface {
@@ -48,7 +66,8 @@ type MonitorTest interface {
 	CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error)
 
 	// ConstructComputedIntervals is called after all InvariantTests have produced raw Intervals.
-	// Order of ConstructComputedIntervals across different InvariantTests is not guaranteed.
+	// Order of ConstructComputedIntervals across different InvariantTests is not guaranteed, implement
+	// MonitorTestWithDependencies to see the intervals constructed by other monitor tests.
 	// Return *only* the constructed intervals.
 	// Errors reported will be indicated as junit test failure and will cause job runs to fail.
 	ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (constructedIntervals monitorapi.Intervals, err error)
@@ -74,6 +93,66 @@ type MonitorTest interface {
 	Cleanup(ctx context.Context) error
 }
 
+// MonitorTestWithDependencies is implemented by monitor tests that need the intervals constructed by other monitor
+// tests.  ConstructComputedIntervals of the dependencies runs first and the intervals they constructed are added to
+// the startingIntervals of the dependent monitor test.
+type MonitorTestWithDependencies interface {
+	MonitorTest
+
+	// ComputedIntervalDependencies returns the registered names of the monitor tests this one depends on.  Dependencies
+	// that are not running, for instance because they were disabled, are ignored.
+	ComputedIntervalDependencies() []string
+}
+
+// MonitorTestWithSharedInformers is implemented by monitor tests that watch cluster resources through informers
+// shared with the other monitor tests, so that each resource is only watched once by the monitor.
+type MonitorTestWithSharedInformers interface {
+	MonitorTest
+
+	// SetSharedInformers is called before StartCollection.  Informers must be requested from the factory during
+	// StartCollection, the factory is started once every monitor test has started collecting.
+	SetSharedInformers(kubeInformers informers.SharedInformerFactory)
+}
+
+// MonitorTestDescription describes what a monitor test needs and produces, so coverage can be reasoned about without
+// running it.
+type MonitorTestDescription struct {
+	// Platforms and Topologies limit the clusters the monitor test runs on, named like platformidentification.JobType
+	// names them.  Empty means every platform or topology.
+	Platforms  []string `json:"platforms,omitempty"`
+	Topologies []string `json:"topologies,omitempty"`
+	// WatchedResources are the resources the monitor test watches or reads, for instance "pods" or
+	// "clusteroperators.config.openshift.io".
+	WatchedResources []string `json:"watchedResources,omitempty"`
+	// JUnits are the names of the junit results the monitor test can produce.
+	JUnits []string `json:"junits,omitempty"`
+}
+
+// MonitorTestWithDescription is implemented by monitor tests that describe themselves for --dry-run-monitors.
+type MonitorTestWithDescription interface {
+	MonitorTest
+
+	Describe() MonitorTestDescription
+}
+
+// DescribedMonitorTest is a registered monitor test and its description, if it has one.
+type DescribedMonitorTest struct {
+	Name          string `json:"name"`
+	JiraComponent string `json:"jiraComponent"`
+	// Described is false for monitor tests that do not implement MonitorTestWithDescription.
+	Described bool `json:"described"`
+	MonitorTestDescription
+}
+
+// RunsOn returns false when the description limits the monitor test to other platforms or topologies.  An unknown
+// platform or topology is assumed to match.
+func (d MonitorTestDescription) RunsOn(platform, topology string) bool {
+	matches := func(values []string, value string) bool {
+		return len(values) == 0 || len(value) == 0 || sets.NewString(values...).Has(value)
+	}
+	return matches(d.Platforms, platform) && matches(d.Topologies, topology)
+}
+
 type MonitorTestRegistry interface {
 	AddRegistryOrDie(registry MonitorTestRegistry)
 
@@ -86,6 +165,9 @@ type MonitorTestRegistry interface {
 	GetRegistryFor(names ...string) (MonitorTestRegistry, error)
 	ListMonitorTests() sets.String
 
+	// DescribeMonitorTests returns the registered monitor tests sorted by name.
+	DescribeMonitorTests() []DescribedMonitorTest
+
 	// StartCollection is responsible for setting up all resources required for collection of data on the cluster.
 	// An error will not stop execution, but will cause a junit failure that will cause the job run to fail.
 	// This allows us to know when setups fail.
@@ -17,7 +17,7 @@ func AllAlertTests(jobType *platformidentification.JobType, clusterStability *mo
 	ret = append(ret, newAlertTestPerNamespace("KubePodNotReady", jobType).firing().toTests()...)
 
 	ret = append(ret, newAlertTest("bz-etcd", "etcdMembersDown", jobType).pending().neverFail().toTests()...)
-	ret = append(ret, newAlertTest("bz-etcd", "etcdMembersDown", jobType).firing().toTests()...)
+	ret = append(ret, newAlertTest("bz-etcd", "etcdMembersDown", jobType).firing().neverFailOnTwoNodeTopologies().toTests()...)
 	ret = append(ret, newAlertTest("bz-etcd", "etcdGRPCRequestsSlow", jobType).pending().neverFail().toTests()...)
 	ret = append(ret, newAlertTest("bz-etcd", "etcdGRPCRequestsSlow", jobType).firing().toTests()...)
 	ret = append(ret, newAlertTest("bz-etcd", "etcdHighNumberOfFailedGRPCRequests", jobType).pending().neverFail().toTests()...)
@@ -31,7 +31,7 @@ func AllAlertTests(jobType *platformidentification.JobType, clusterStability *mo
 	ret = append(ret, newAlertTest("bz-etcd", "etcdHighCommitDurations", jobType).pending().neverFail().toTests()...)
 	ret = append(ret, newAlertTest("bz-etcd", "etcdHighCommitDurations", jobType).firing().toTests()...)
 	ret = append(ret, newAlertTest("bz-etcd", "etcdInsufficientMembers", jobType).pending().neverFail().toTests()...)
-	ret = append(ret, newAlertTest("bz-etcd", "etcdInsufficientMembers", jobType).firing().toTests()...)
+	ret = append(ret, newAlertTest("bz-etcd", "etcdInsufficientMembers", jobType).firing().neverFailOnTwoNodeTopologies().toTests()...)
 
 	// A rare and pretty serious failure, should always be accompanied by other failures but we want to see a specific test failure for this.
 	// It likely means a kubelet is down.
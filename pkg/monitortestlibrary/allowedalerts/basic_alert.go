@@ -121,6 +121,15 @@ func (a *alertBuilder) neverFail() *alertBuilder {
 	return a
 }
 
+// neverFailOnTwoNodeTopologies only flakes the test on the two node topologies, which lose one of the etcd members of
+// their control plane nodes whenever one of them reboots.
+func (a *alertBuilder) neverFailOnTwoNodeTopologies() *alertBuilder {
+	if a.jobType != nil && platformidentification.IsTwoNodeJobTopology(a.jobType.Topology) {
+		return a.neverFail()
+	}
+	return a
+}
+
 // alwaysFlake will flake the test if the alert enters the given state for any amount of time,
 // regardless of historical data.
 func (a *alertBuilder) alwaysFlake() *alertBuilder {
@@ -349,8 +358,9 @@ func (a *basicAlertTest) InvariantCheck(allEventIntervals monitorapi.Intervals,
 			},
 		}, nil
 	}
-	pendingIntervals := allEventIntervals.Filter(AlertPendingInNamespace(a.alertName, a.namespace))
-	firingIntervals := allEventIntervals.Filter(AlertFiringInNamespace(a.alertName, a.namespace))
+	// alerts the user expects on their cluster are never held against it.
+	pendingIntervals := allEventIntervals.Filter(AlertPendingInNamespace(a.alertName, a.namespace)).Filter(monitorapi.Not(IsUserAllowedAlert))
+	firingIntervals := allEventIntervals.Filter(AlertFiringInNamespace(a.alertName, a.namespace)).Filter(monitorapi.Not(IsUserAllowedAlert))
 
 	state, message := a.failOrFlake(firingIntervals, pendingIntervals)
 
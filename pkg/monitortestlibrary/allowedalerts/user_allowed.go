@@ -0,0 +1,76 @@
+package allowedalerts
+
+import (
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/origin/pkg/alerts"
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+// UserAllowedAlert is an alert that is expected on the cluster under test, for instance because the cluster is
+// customized in a way CI clusters are not.
+type UserAllowedAlert struct {
+	AlertName string `json:"alertName"`
+	// Namespace restricts the allowance to the alert in one namespace, all namespaces match when empty.
+	Namespace string `json:"namespace,omitempty"`
+	// Reason is reported with the allowed alert so results explain why it was tolerated.
+	Reason string `json:"reason"`
+}
+
+// UserAllowedAlerts is the file passed with --allowed-alerts-file, for instance:
+//
+//	alerts:
+//	- alertName: KubeCPUOvercommit
+//	  reason: our test clusters are intentionally small
+//	- alertName: TargetDown
+//	  namespace: partner-monitoring
+//	  reason: the partner exporter is scraped from outside the cluster
+type UserAllowedAlerts struct {
+	Alerts []UserAllowedAlert `json:"alerts"`
+}
+
+var userAllowedAlerts []UserAllowedAlert
+
+// LoadUserAllowedAlerts merges the alerts in filename into the allowances of every alert test.  It must be called
+// before the alert tests are evaluated.
+func LoadUserAllowedAlerts(filename string) error {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	allowed := &UserAllowedAlerts{}
+	if err := yaml.UnmarshalStrict(content, allowed); err != nil {
+		return fmt.Errorf("unable to parse allowed alerts from %s: %w", filename, err)
+	}
+	for i, alert := range allowed.Alerts {
+		if len(alert.AlertName) == 0 {
+			return fmt.Errorf("%s: alerts[%d] must have an alertName", filename, i)
+		}
+		if len(alert.Reason) == 0 {
+			return fmt.Errorf("%s: alert %q must have a reason", filename, alert.AlertName)
+		}
+	}
+	userAllowedAlerts = allowed.Alerts
+	return nil
+}
+
+// UserAllowedAlertConditions returns the user allowed alerts as conditions for the backstop alert test.
+func UserAllowedAlertConditions() alerts.MetricConditions {
+	ret := alerts.MetricConditions{}
+	for _, alert := range userAllowedAlerts {
+		ret = append(ret, alerts.MetricCondition{
+			AlertName:      alert.AlertName,
+			AlertNamespace: alert.Namespace,
+			Text:           fmt.Sprintf("allowed by the user: %s", alert.Reason),
+		})
+	}
+	return ret
+}
+
+// IsUserAllowedAlert returns true if the alert interval was allowed by the user.
+func IsUserAllowedAlert(interval monitorapi.Interval) bool {
+	return UserAllowedAlertConditions().MatchesInterval(interval) != nil
+}
@@ -0,0 +1,92 @@
+package allowedalerts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+func TestLoadUserAllowedAlerts(t *testing.T) {
+	t.Cleanup(func() { userAllowedAlerts = nil })
+
+	alert := func(name, namespace string) monitorapi.Interval {
+		return monitorapi.NewInterval(monitorapi.SourceAlert, monitorapi.Warning).
+			Locator(monitorapi.Locator{
+				Type: monitorapi.LocatorTypeAlert,
+				Keys: map[monitorapi.LocatorKey]string{
+					monitorapi.LocatorAlertKey:     name,
+					monitorapi.LocatorNamespaceKey: namespace,
+				},
+			}).
+			Message(monitorapi.NewMessage().WithAnnotation(monitorapi.AnnotationAlertState, "firing")).
+			Build(time.Now(), time.Now().Add(time.Minute))
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name: "valid",
+			content: `alerts:
+- alertName: KubeCPUOvercommit
+  reason: small clusters
+- alertName: TargetDown
+  namespace: partner-monitoring
+  reason: scraped from outside the cluster
+`,
+		},
+		{
+			name: "missing reason",
+			content: `alerts:
+- alertName: KubeCPUOvercommit
+`,
+			wantErr: `alert "KubeCPUOvercommit" must have a reason`,
+		},
+		{
+			name: "missing name",
+			content: `alerts:
+- reason: small clusters
+`,
+			wantErr: "alerts[0] must have an alertName",
+		},
+		{
+			name: "unknown field",
+			content: `alerts:
+- name: KubeCPUOvercommit
+  reason: small clusters
+`,
+			wantErr: `unknown field "name"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userAllowedAlerts = nil
+			filename := filepath.Join(t.TempDir(), "allowed-alerts.yaml")
+			require.NoError(t, os.WriteFile(filename, []byte(tt.content), 0644))
+
+			err := LoadUserAllowedAlerts(filename)
+			if len(tt.wantErr) > 0 {
+				assert.ErrorContains(t, err, tt.wantErr)
+				assert.Empty(t, UserAllowedAlertConditions())
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, IsUserAllowedAlert(alert("KubeCPUOvercommit", "openshift-monitoring")))
+			assert.True(t, IsUserAllowedAlert(alert("TargetDown", "partner-monitoring")))
+			assert.False(t, IsUserAllowedAlert(alert("TargetDown", "kube-system")))
+			assert.False(t, IsUserAllowedAlert(alert("KubePodNotReady", "openshift-monitoring")))
+
+			conditions := UserAllowedAlertConditions()
+			require.Len(t, conditions, 2)
+			assert.Equal(t, "allowed by the user: small clusters", conditions[0].Text)
+		})
+	}
+}
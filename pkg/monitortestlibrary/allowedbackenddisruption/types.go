@@ -2,6 +2,8 @@ package allowedbackenddisruption
 
 import (
 	_ "embed"
+	"fmt"
+	"os"
 	"sync"
 
 	"github.com/openshift/origin/pkg/monitortestlibrary/historicaldata"
@@ -49,6 +51,9 @@ order by
 `
 )
 
+// DefaultHistoricalDataURL is where the historical disruption data embedded in this binary is published.
+const DefaultHistoricalDataURL = "https://raw.githubusercontent.com/openshift/origin/main/pkg/monitortestlibrary/allowedbackenddisruption/query_results.json"
+
 //go:embed query_results.json
 var queryResults []byte
 
@@ -69,3 +74,19 @@ func GetCurrentResults() *historicaldata.DisruptionBestMatcher {
 
 	return historicalData
 }
+
+// UseHistoricalDataFile replaces the embedded historical disruption data with the content of filename, usually a copy
+// refreshed out-of-band for clusters that cannot reach the published data.  It must be called before the data is read.
+func UseHistoricalDataFile(filename string) error {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	matcher, err := historicaldata.NewDisruptionMatcher(content)
+	if err != nil {
+		return fmt.Errorf("%s is not valid historical disruption data: %w", filename, err)
+	}
+	readResults.Do(func() {})
+	historicalData = matcher
+	return nil
+}
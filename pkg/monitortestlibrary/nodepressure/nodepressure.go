@@ -0,0 +1,48 @@
+package nodepressure
+
+import (
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+// ReportingGrace is how long after a pressure window a workload restart is still attributed to it.  The pod monitor
+// only sees a container killed to reclaim memory once the kubelet synced the status of its pod.
+const ReportingGrace = time.Minute
+
+// IsWindow returns true for the constructed intervals a node was under memory, disk or PID pressure.
+func IsWindow(interval monitorapi.Interval) bool {
+	return interval.Source == monitorapi.SourceNodeState && interval.Message.Reason == monitorapi.NodeUnderPressureReason
+}
+
+// Windows returns the pressure windows of every node, keyed by node name.
+func Windows(intervals monitorapi.Intervals) map[string]monitorapi.Intervals {
+	ret := map[string]monitorapi.Intervals{}
+	for _, interval := range intervals {
+		if !IsWindow(interval) {
+			continue
+		}
+		node := interval.Locator.Keys[monitorapi.LocatorNodeKey]
+		ret[node] = append(ret[node], interval)
+	}
+	return ret
+}
+
+// During returns true if the interval started on a node while it was under pressure, or within the reporting grace
+// after.
+func During(windows map[string]monitorapi.Intervals, interval monitorapi.Interval) bool {
+	node, ok := interval.Locator.Keys[monitorapi.LocatorNodeKey]
+	if !ok {
+		return false
+	}
+	for _, window := range windows[node] {
+		if interval.From.Before(window.From) {
+			continue
+		}
+		// open intervals extend to the end of the run
+		if window.To.IsZero() || !interval.From.After(window.To.Add(ReportingGrace)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package ondiskcerts
+
+import (
+	"github.com/openshift/library-go/pkg/certs/cert-inspection/certgraphapi"
+	"github.com/openshift/library-go/pkg/certs/cert-inspection/certgraphutils"
+
+	"github.com/openshift/origin/pkg/certs"
+)
+
+// UnregisteredOnDiskArtifacts returns the on-disk certificates, keys and CA bundles of actual that are neither
+// registered in expected nor known violations.
+func UnregisteredOnDiskArtifacts(actual *certgraphapi.PKIList, expected, violations *certs.PKIRegistryInfo) *certs.PKIRegistryInfo {
+	ret := &certs.PKIRegistryInfo{}
+
+	for _, currCertKeyPair := range actual.CertKeyPairs.Items {
+		if len(currCertKeyPair.Spec.SecretLocations) != 0 || len(currCertKeyPair.Spec.OnDiskLocations) == 0 {
+			continue
+		}
+		for _, currLocation := range currCertKeyPair.Spec.OnDiskLocations {
+			if len(currLocation.Cert.Path) > 0 {
+				if _, err := certgraphutils.LocateCertKeyPairByOnDiskLocation(currLocation.Cert, violations.CertKeyPairs); err == nil {
+					continue
+				}
+
+				certInfo, err := certgraphutils.LocateCertKeyPairByOnDiskLocation(currLocation.Cert, expected.CertKeyPairs)
+				if err != nil {
+					if certInfo == nil {
+						certInfo = &certgraphapi.PKIRegistryOnDiskCertKeyPair{
+							OnDiskLocation: certgraphapi.OnDiskLocation{
+								Path: currLocation.Cert.Path,
+							},
+						}
+					}
+					ret.CertKeyPairs = append(ret.CertKeyPairs, certgraphapi.PKIRegistryCertKeyPair{OnDiskLocation: certInfo})
+				}
+			}
+
+			if len(currLocation.Key.Path) > 0 && currLocation.Key.Path != currLocation.Cert.Path {
+
+				if _, err := certgraphutils.LocateCertKeyPairByOnDiskLocation(currLocation.Key, violations.CertKeyPairs); err == nil {
+					continue
+				}
+
+				keyInfo, err := certgraphutils.LocateCertKeyPairByOnDiskLocation(currLocation.Key, expected.CertKeyPairs)
+				if err != nil {
+					if keyInfo == nil {
+						keyInfo = &certgraphapi.PKIRegistryOnDiskCertKeyPair{
+							OnDiskLocation: certgraphapi.OnDiskLocation{
+								Path: currLocation.Key.Path,
+							},
+						}
+					}
+					ret.CertKeyPairs = append(ret.CertKeyPairs, certgraphapi.PKIRegistryCertKeyPair{OnDiskLocation: keyInfo})
+				}
+			}
+		}
+	}
+
+	for _, currCABundle := range actual.CertificateAuthorityBundles.Items {
+		if len(currCABundle.Spec.ConfigMapLocations) != 0 || len(currCABundle.Spec.OnDiskLocations) == 0 {
+			continue
+		}
+		for _, currLocation := range currCABundle.Spec.OnDiskLocations {
+			if _, err := certgraphutils.LocateCABundleByOnDiskLocation(currLocation, violations.CertificateAuthorityBundles); err == nil {
+				continue
+			}
+
+			caBundleInfo, err := certgraphutils.LocateCABundleByOnDiskLocation(currLocation, expected.CertificateAuthorityBundles)
+			if err != nil {
+				if caBundleInfo == nil {
+					caBundleInfo = &certgraphapi.PKIRegistryOnDiskCABundle{
+						OnDiskLocation: certgraphapi.OnDiskLocation{
+							Path: currLocation.Path,
+						},
+					}
+				}
+				ret.CertificateAuthorityBundles = append(ret.CertificateAuthorityBundles, certgraphapi.PKIRegistryCABundle{OnDiskLocation: caBundleInfo})
+			}
+		}
+	}
+
+	return ret
+}
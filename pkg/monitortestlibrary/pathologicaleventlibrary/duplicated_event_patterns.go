@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -12,6 +13,7 @@ import (
 	configclient "github.com/openshift/client-go/config/clientset/versioned"
 	operatorv1client "github.com/openshift/client-go/operator/clientset/versioned/typed/operator/v1"
 	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestlibrary/platformidentification"
 	"github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -28,8 +30,21 @@ const (
 	DuplicateEventThreshold = 20
 	PathologicalMark        = "pathological/true"
 	InterestingMark         = "interesting/true"
+
+	// singleReplicaThresholdMultiplier scales DuplicateEventThreshold on single node clusters, where every operator,
+	// probe and controller shares the one node and legitimately repeats its events more than on an HA cluster.
+	singleReplicaThresholdMultiplier = 2
 )
 
+// DuplicateEventThresholdForTopology returns the number of times an event may repeat before it is pathological on a
+// cluster of the given topology.
+func DuplicateEventThresholdForTopology(topology v1.TopologyMode) int {
+	if topology == v1.SingleReplicaTopologyMode {
+		return DuplicateEventThreshold * singleReplicaThresholdMultiplier
+	}
+	return DuplicateEventThreshold
+}
+
 type EventMatcher interface {
 	// Name returns a unique name (enforced by registry) for this matcher.
 	Name() string
@@ -49,7 +64,9 @@ type SimplePathologicalEventMatcher struct {
 	// Name is a unique CamelCase friendly name that briefly describes the allowed dupe events. It's used in
 	// logging and unit tests to make sure we match on what we expect.
 	name string
-	// locatorKeyRegexes is a map of LocatorKey to regex that key must match.
+	// locatorKeyRegexes is a map of LocatorKey to regex that key must match.  Match
+	// monitorapi.LocatorHostedControlPlaneComponentKey to target a hosted control plane component in the namespace of
+	// any hosted cluster.
 	locatorKeyRegexes map[monitorapi.LocatorKey]*regexp.Regexp
 	// messageReasonRegex checks the Reason on a structured interval Message.
 	messageReasonRegex *regexp.Regexp
@@ -69,9 +86,9 @@ type SimplePathologicalEventMatcher struct {
 	// we don't want to allow to repeat pathologically.
 	neverAllow bool
 
-	// topology limits the exception to a specific topology. (e.g. single replica)
+	// topologies limits the exception to specific topologies. (e.g. single replica)
 	// This is only considered in the context of Allows, not Matches.
-	topology *v1.TopologyMode
+	topologies []v1.TopologyMode
 }
 
 func (ade *SimplePathologicalEventMatcher) Name() string {
@@ -121,7 +138,7 @@ func (ade *SimplePathologicalEventMatcher) Allows(i monitorapi.Interval, topolog
 		}
 	}
 
-	if ade.topology != nil && *ade.topology != topology {
+	if len(ade.topologies) > 0 && !slices.Contains(ade.topologies, topology) {
 		logrus.WithField("allower", ade.Name).Debugf("cluster did not match topology")
 		return false
 	}
@@ -471,6 +488,9 @@ func NewUniversalPathologicalEventMatchers(kubeConfig *rest.Config, finalInterva
 	registry.AddPathologicalEventMatcherOrDie(singleNodeConnectionRefusedMatcher)
 	registry.AddPathologicalEventMatcherOrDie(singleNodeKubeAPIServerProgressingMatcher)
 
+	twoNodeUnhealthyEtcdMembersMatcher := newTwoNodeUnhealthyEtcdMembersDuringNodeUpdateEventMatcher(finalIntervals)
+	registry.AddPathologicalEventMatcherOrDie(twoNodeUnhealthyEtcdMembersMatcher)
+
 	return registry
 }
 
@@ -794,13 +814,7 @@ func newFailedSchedulingDuringNodeUpdatePathologicalEventMatcher(finalIntervals
 
 	// Filter out a list of NodeUpdate events, we use these to ignore some other potential pathological events that are
 	// expected during NodeUpdate.
-	nodeUpdateIntervals := finalIntervals.Filter(func(eventInterval monitorapi.Interval) bool {
-		return eventInterval.Source == monitorapi.SourceNodeState &&
-			eventInterval.Locator.Type == monitorapi.LocatorTypeNode &&
-			eventInterval.Message.Annotations[monitorapi.AnnotationConstructed] == monitorapi.ConstructionOwnerNodeLifecycle &&
-			eventInterval.Message.Annotations[monitorapi.AnnotationPhase] == "Update" &&
-			strings.Contains(eventInterval.Message.Annotations[monitorapi.AnnotationRoles], "master")
-	})
+	nodeUpdateIntervals := masterNodeUpdateIntervals(finalIntervals)
 	logrus.Infof("found %d NodeUpdate intervals", len(nodeUpdateIntervals))
 	return &OverlapOtherIntervalsPathologicalEventMatcher{
 		delegate: &SimplePathologicalEventMatcher{
@@ -811,6 +825,17 @@ func newFailedSchedulingDuringNodeUpdatePathologicalEventMatcher(finalIntervals
 	}
 }
 
+// masterNodeUpdateIntervals returns the NodeUpdate intervals of the control plane nodes.
+func masterNodeUpdateIntervals(finalIntervals monitorapi.Intervals) monitorapi.Intervals {
+	return finalIntervals.Filter(func(eventInterval monitorapi.Interval) bool {
+		return eventInterval.Source == monitorapi.SourceNodeState &&
+			eventInterval.Locator.Type == monitorapi.LocatorTypeNode &&
+			eventInterval.Message.Annotations[monitorapi.AnnotationConstructed] == monitorapi.ConstructionOwnerNodeLifecycle &&
+			eventInterval.Message.Annotations[monitorapi.AnnotationPhase] == "Update" &&
+			strings.Contains(eventInterval.Message.Annotations[monitorapi.AnnotationRoles], "master")
+	})
+}
+
 // OverlapOtherIntervalsPathologicalEventMatcher is an implementation containing a regular
 // matcher, plus additional logic that will allow the event only if it is contained
 // within another set of intervals provided. (i.e. used to allow FailedScheduling pathological
@@ -900,8 +925,6 @@ func newSingleNodeConnectionRefusedEventMatcher(finalIntervals monitorapi.Interv
 		bufferTime     = time.Second * 45
 		bufferSourceID = "GeneratedSNOBufferInterval"
 	)
-	snoTopology := v1.SingleReplicaTopologyMode
-
 	// Intervals are collected as they come to the monitorapi and the `from` and `to` is recorded at that point,
 	// this works fine for most runs however for single node the events might be sent at irregular intervals.
 	// This makes it hard to determine if connection refused errors are false positives,
@@ -945,7 +968,7 @@ func newSingleNodeConnectionRefusedEventMatcher(finalIntervals monitorapi.Interv
 		delegate: &SimplePathologicalEventMatcher{
 			name:              "ConnectionErrorDuringSingleNodeAPIServerTargetDown",
 			messageHumanRegex: regexp.MustCompile(`dial tcp .* connect: connection refused`),
-			topology:          &snoTopology,
+			topologies:        []v1.TopologyMode{v1.SingleReplicaTopologyMode},
 		},
 		allowIfWithinIntervals: ocpAPISeverTargetDownIntervals,
 	}
@@ -955,8 +978,6 @@ func newSingleNodeConnectionRefusedEventMatcher(finalIntervals monitorapi.Interv
 // that occur during this time are the kube-apiserver-operator waiting for etcd/installer to stabilize and if we're unlucky
 // an operator might call out for leader and since the KAS is down, it'll trigger a restart since it can't get leader.
 func newSingleNodeKubeAPIProgressingEventMatcher(finalIntervals monitorapi.Intervals) EventMatcher {
-	snoTopology := v1.SingleReplicaTopologyMode
-
 	ocpKubeAPIServerProgressingInterval := finalIntervals.Filter(func(eventInterval monitorapi.Interval) bool {
 
 		isNodeInstaller := eventInterval.Message.Reason == monitorapi.NodeInstallerReason
@@ -976,8 +997,30 @@ func newSingleNodeKubeAPIProgressingEventMatcher(finalIntervals monitorapi.Inter
 		delegate: &SimplePathologicalEventMatcher{
 			name:              "KubeAPIServerProgressingDuringSingleNodeUpgrade",
 			messageHumanRegex: regexp.MustCompile(`^(clusteroperator/kube-apiserver version .* changed from |Back-off restarting failed container)`),
-			topology:          &snoTopology,
+			topologies:        []v1.TopologyMode{v1.SingleReplicaTopologyMode},
 		},
 		allowIfWithinIntervals: ocpKubeAPIServerProgressingInterval,
 	}
 }
+
+// On two node clusters, one of the etcd members of the control plane nodes is down while its node updates, and the
+// etcd operator reports it until the node is back.  Upgrades allow this on every topology, two node clusters also hit
+// it in the node updates of other jobs.
+func newTwoNodeUnhealthyEtcdMembersDuringNodeUpdateEventMatcher(finalIntervals monitorapi.Intervals) EventMatcher {
+	return &OverlapOtherIntervalsPathologicalEventMatcher{
+		delegate: &SimplePathologicalEventMatcher{
+			name: "EtcdUnhealthyMembersDuringTwoNodeUpdate",
+			locatorKeyRegexes: map[monitorapi.LocatorKey]*regexp.Regexp{
+				monitorapi.LocatorNamespaceKey:  regexp.MustCompile(`openshift-etcd-operator`),
+				monitorapi.LocatorDeploymentKey: regexp.MustCompile(`etcd-operator`),
+			},
+			messageReasonRegex: regexp.MustCompile(`^UnhealthyEtcdMember$`),
+			messageHumanRegex:  regexp.MustCompile(`unhealthy members`),
+			topologies: []v1.TopologyMode{
+				platformidentification.HighlyAvailableArbiterTopologyMode,
+				platformidentification.DualReplicaTopologyMode,
+			},
+		},
+		allowIfWithinIntervals: masterNodeUpdateIntervals(finalIntervals),
+	}
+}
@@ -102,14 +102,35 @@ func appendToFirstLine(s string, add string) string {
 	return strings.Join(splits, "\n")
 }
 
+// hostedControlPlanesResult groups the events of every hosted control plane namespace on a HyperShift management
+// cluster, their names depend on the hosted clusters so they cannot have a junit per namespace.  It cannot be the
+// name of a namespace.
+const hostedControlPlanesResult = "hosted control planes"
+
 func getJUnitName(testName string, namespace string) string {
 	jUnitName := testName
+	if namespace == hostedControlPlanesResult {
+		return jUnitName + " for " + hostedControlPlanesResult
+	}
 	if namespace != "" {
 		jUnitName = jUnitName + " for ns/" + namespace
 	}
 	return jUnitName
 }
 
+// getJUnitNamespace returns the namespace whose junit reports the interval.  We only create junit for known
+// namespaces, and one for all the hosted control planes.
+func getJUnitNamespace(interval monitorapi.Interval) string {
+	namespace := interval.Locator.Keys[monitorapi.LocatorNamespaceKey]
+	switch {
+	case monitorapi.IsInHostedControlPlaneNamespace(interval):
+		return hostedControlPlanesResult
+	case !platformidentification.KnownNamespaces.Has(namespace):
+		return ""
+	}
+	return namespace
+}
+
 func getNamespacesForJUnits() sets.String {
 	namespaces := platformidentification.KnownNamespaces.Clone()
 	namespaces.Insert("")
@@ -135,9 +156,12 @@ func generateFailureOutput(failures []string, flakes []string) string {
 	return output
 }
 
-func generateJUnitTestCasesCoreNamespaces(testName string, nsResults map[string]*eventResult) []*junitapi.JUnitTestCase {
+func generateJUnitTestCasesCoreNamespaces(testName string, nsResults map[string]*eventResult, hasHostedControlPlanes bool) []*junitapi.JUnitTestCase {
 	var tests []*junitapi.JUnitTestCase
 	namespaces := getNamespacesForJUnits()
+	if hasHostedControlPlanes {
+		namespaces.Insert(hostedControlPlanesResult)
+	}
 	for namespace := range namespaces {
 		jUnitName := getJUnitName(testName, namespace)
 		if result, ok := nsResults[namespace]; ok {
@@ -190,11 +214,12 @@ func (d *duplicateEventsEvaluator) testDuplicatedEvents(testName string, flakeOn
 
 	// displayToCount maps a static display message to the matching repeating interval we saw with the highest count
 	displayToCount := map[string]monitorapi.Interval{}
+	threshold := DuplicateEventThresholdForTopology(d.topology)
 
 	for _, event := range events {
 
 		times := GetTimesAnEventHappened(event.Message)
-		if times > DuplicateEventThreshold {
+		if times > threshold {
 
 			// Check if we have an allowance for this event. This code used to just check if it had an interesting flag,
 			// implying it matches some pattern, but that happens even for upgrade patterns occurring in non-upgrade jobs,
@@ -221,16 +246,11 @@ func (d *duplicateEventsEvaluator) testDuplicatedEvents(testName string, flakeOn
 
 	nsResults := map[string]*eventResult{}
 	for intervalDisplayMsg, interval := range displayToCount {
-		namespace := interval.Locator.Keys[monitorapi.LocatorNamespaceKey]
+		namespace := getJUnitNamespace(interval)
 		intervalMsgWithTime := intervalDisplayMsg + " (" + interval.From.Format("15:04:05Z") + ")"
 		msg := fmt.Sprintf("event happened %d times, something is wrong: %v",
 			GetTimesAnEventHappened(interval.Message), intervalMsgWithTime)
 
-		// We only create junit for known namespaces
-		if !platformidentification.KnownNamespaces.Has(namespace) {
-			namespace = ""
-		}
-
 		if _, ok := nsResults[namespace]; !ok {
 			tmp := &eventResult{}
 			nsResults[namespace] = tmp
@@ -246,7 +266,8 @@ func (d *duplicateEventsEvaluator) testDuplicatedEvents(testName string, flakeOn
 	if isE2E {
 		tests = generateJUnitTestCasesE2ENamespaces(testName, nsResults)
 	} else {
-		tests = generateJUnitTestCasesCoreNamespaces(testName, nsResults)
+		hasHostedControlPlanes := len(events.Filter(monitorapi.IsInHostedControlPlaneNamespace)) > 0
+		tests = generateJUnitTestCasesCoreNamespaces(testName, nsResults, hasHostedControlPlanes)
 	}
 	return tests
 }
@@ -264,6 +285,8 @@ func GetTimesAnEventHappened(msg monitorapi.Message) int {
 	return int(times)
 }
 
+// GetClusterInfraInfo returns the platform and control plane topology of the cluster, the topology decides which
+// topology specific matchers and thresholds apply.
 func GetClusterInfraInfo(c *rest.Config) (platform v1.PlatformType, topology v1.TopologyMode, err error) {
 	if c == nil {
 		return
@@ -284,6 +307,13 @@ func GetClusterInfraInfo(c *rest.Config) (platform v1.PlatformType, topology v1.
 	if infra.Status.ControlPlaneTopology != "" {
 		topology = infra.Status.ControlPlaneTopology
 	}
+	switch topology {
+	case "", v1.HighlyAvailableTopologyMode, v1.SingleReplicaTopologyMode, v1.ExternalTopologyMode,
+		platformidentification.HighlyAvailableArbiterTopologyMode, platformidentification.DualReplicaTopologyMode:
+	default:
+		// an unknown topology is evaluated like an HA cluster, which is likely to be too strict.
+		logrus.Warnf("unrecognized control plane topology %q", topology)
+	}
 
 	return platform, topology, nil
 }
@@ -5,7 +5,6 @@ import (
 	"strings"
 
 	"github.com/openshift/origin/pkg/monitor/monitorapi"
-	"github.com/openshift/origin/pkg/monitortestlibrary/platformidentification"
 	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
 )
 
@@ -67,12 +66,7 @@ func (s *singleEventThresholdCheck) getNamespacedFailuresAndFlakes(events monito
 	nsResults := map[string]*eventResult{}
 
 	for _, e := range events {
-		namespace := e.Locator.Keys[monitorapi.LocatorNamespaceKey]
-
-		// We only create junit for known namespaces
-		if !platformidentification.KnownNamespaces.Has(namespace) {
-			namespace = ""
-		}
+		namespace := getJUnitNamespace(e)
 
 		var failPresent, flakePresent bool
 		if s.matcher.Allows(e, "") {
@@ -108,7 +102,8 @@ func (s *singleEventThresholdCheck) getNamespacedFailuresAndFlakes(events monito
 func (s *singleEventThresholdCheck) NamespacedTest(events monitorapi.Intervals) []*junitapi.JUnitTestCase {
 
 	nsResults := s.getNamespacedFailuresAndFlakes(events)
-	return generateJUnitTestCasesCoreNamespaces(s.testName, nsResults)
+	hasHostedControlPlanes := len(events.Filter(monitorapi.IsInHostedControlPlaneNamespace)) > 0
+	return generateJUnitTestCasesCoreNamespaces(s.testName, nsResults, hasHostedControlPlanes)
 }
 
 func NewSingleEventThresholdCheck(testName string, matcher *SimplePathologicalEventMatcher, failThreshold, flakeThreshold int) *singleEventThresholdCheck {
@@ -2,11 +2,13 @@ package pathologicaleventlibrary
 
 import (
 	_ "embed"
+	"regexp"
 	"testing"
 	"time"
 
 	v1 "github.com/openshift/api/config/v1"
 	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestlibrary/platformidentification"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -233,7 +235,7 @@ func TestPathologicalEventsWithNamespaces(t *testing.T) {
 			},
 			namespace:       "openshift",
 			platform:        v1.AWSPlatformType,
-			topology:        v1.SingleReplicaTopologyMode,
+			topology:        v1.HighlyAvailableTopologyMode,
 			expectedMessage: "1 events happened too frequently\n\nevent happened 22 times, something is wrong: namespace/openshift - reason/SomeEvent1 foo (04:00:00Z) result=reject ",
 		},
 		{
@@ -249,7 +251,7 @@ func TestPathologicalEventsWithNamespaces(t *testing.T) {
 			},
 			namespace:       "",
 			platform:        v1.AWSPlatformType,
-			topology:        v1.SingleReplicaTopologyMode,
+			topology:        v1.HighlyAvailableTopologyMode,
 			expectedMessage: "1 events happened too frequently\n\nevent happened 22 times, something is wrong: namespace/random - reason/SomeEvent1 foo (04:00:00Z) result=reject ",
 		},
 		{
@@ -263,7 +265,7 @@ func TestPathologicalEventsWithNamespaces(t *testing.T) {
 			},
 			namespace:       "",
 			platform:        v1.AWSPlatformType,
-			topology:        v1.SingleReplicaTopologyMode,
+			topology:        v1.HighlyAvailableTopologyMode,
 			expectedMessage: "1 events happened too frequently\n\nevent happened 22 times, something is wrong:  - reason/SomeEvent1 foo (04:00:00Z) result=reject ",
 		},
 		{
@@ -282,6 +284,38 @@ func TestPathologicalEventsWithNamespaces(t *testing.T) {
 			topology:        v1.SingleReplicaTopologyMode,
 			expectedMessage: "",
 		},
+		{
+			name: "allows 22 on single node clusters",
+			intervals: []monitorapi.Interval{
+				monitorapi.NewInterval(monitorapi.SourceKubeEvent, monitorapi.Info).
+					Locator(monitorapi.Locator{Keys: map[monitorapi.LocatorKey]string{
+						monitorapi.LocatorNamespaceKey: "openshift",
+					}}).Message(
+					monitorapi.NewMessage().Reason("SomeEvent1").HumanMessage("foo").
+						WithAnnotation(monitorapi.AnnotationCount, "22")).
+					Build(time.Unix(872827200, 0).In(time.UTC), time.Unix(872827200, 0).In(time.UTC)),
+			},
+			namespace:       "openshift",
+			platform:        v1.AWSPlatformType,
+			topology:        v1.SingleReplicaTopologyMode,
+			expectedMessage: "",
+		},
+		{
+			name: "matches 42 on single node clusters",
+			intervals: []monitorapi.Interval{
+				monitorapi.NewInterval(monitorapi.SourceKubeEvent, monitorapi.Info).
+					Locator(monitorapi.Locator{Keys: map[monitorapi.LocatorKey]string{
+						monitorapi.LocatorNamespaceKey: "openshift",
+					}}).Message(
+					monitorapi.NewMessage().Reason("SomeEvent1").HumanMessage("foo").
+						WithAnnotation(monitorapi.AnnotationCount, "42")).
+					Build(time.Unix(872827200, 0).In(time.UTC), time.Unix(872827200, 0).In(time.UTC)),
+			},
+			namespace:       "openshift",
+			platform:        v1.AWSPlatformType,
+			topology:        v1.SingleReplicaTopologyMode,
+			expectedMessage: "1 events happened too frequently\n\nevent happened 42 times, something is wrong: namespace/openshift - reason/SomeEvent1 foo (04:00:00Z) result=reject ",
+		},
 		{
 			// This is ignored because it was during a master NodeUpdate interval
 			name: "ignore FailedScheduling in openshift-controller-manager if masters are updating",
@@ -416,6 +450,12 @@ func TestPathologicalEventsWithNamespaces(t *testing.T) {
 	}
 }
 
+func TestDuplicateEventThresholdForTopology(t *testing.T) {
+	assert.Equal(t, DuplicateEventThreshold, DuplicateEventThresholdForTopology(v1.HighlyAvailableTopologyMode))
+	assert.Equal(t, DuplicateEventThreshold, DuplicateEventThresholdForTopology(""))
+	assert.Equal(t, 2*DuplicateEventThreshold, DuplicateEventThresholdForTopology(v1.SingleReplicaTopologyMode))
+}
+
 func TestMakeProbeTestEventsGroup(t *testing.T) {
 
 	tests := []struct {
@@ -666,3 +706,82 @@ func TestPathologicalEventsTopologyAwareHintsDisabled(t *testing.T) {
 		})
 	}
 }
+
+func TestPathologicalEventsInHostedControlPlanes(t *testing.T) {
+	from := time.Unix(872827200, 0).In(time.UTC)
+	hostedEvent := func(hostedCluster, pod, reason string) monitorapi.Interval {
+		return monitorapi.NewInterval(monitorapi.SourceKubeEvent, monitorapi.Info).
+			Locator(monitorapi.NewLocator().PodFromNames("clusters-"+hostedCluster, pod, "")).
+			Message(monitorapi.NewMessage().Reason(monitorapi.IntervalReason(reason)).HumanMessage("foo").
+				WithAnnotation(monitorapi.AnnotationCount, "22")).
+			Build(from, from)
+	}
+	events := monitorapi.Intervals{
+		hostedEvent("foo", "kube-apiserver-6d8f7b9c5-x7k2p", "SomeEvent1"),
+		hostedEvent("bar", "etcd-0", "AllowedEvent"),
+	}
+
+	registry := &AllowedPathologicalEventRegistry{matchers: map[string]EventMatcher{}}
+	registry.AddPathologicalEventMatcherOrDie(&SimplePathologicalEventMatcher{
+		name: "HostedEtcdAllowedEvent",
+		locatorKeyRegexes: map[monitorapi.LocatorKey]*regexp.Regexp{
+			monitorapi.LocatorHostedControlPlaneComponentKey: regexp.MustCompile(`^etcd$`),
+		},
+		messageReasonRegex: regexp.MustCompile(`^AllowedEvent$`),
+	})
+	evaluator := duplicateEventsEvaluator{registry: registry}
+
+	testName := "events should not repeat"
+	junits := evaluator.testDuplicatedEvents(testName, false, events, nil, false)
+	assert.Equal(t, len(getNamespacesForJUnits())+1, len(junits), "expected a junit for hosted control planes")
+	for _, junit := range junits {
+		if junit.Name != testName+" for hosted control planes" {
+			assert.Nil(t, junit.FailureOutput, junit.Name)
+			continue
+		}
+		require.NotNil(t, junit.FailureOutput)
+		assert.Contains(t, junit.FailureOutput.Output, "namespace/clusters-foo")
+		assert.NotContains(t, junit.FailureOutput.Output, "AllowedEvent")
+	}
+
+	junits = evaluator.testDuplicatedEvents(testName, false, nil, nil, false)
+	assert.Equal(t, len(getNamespacesForJUnits()), len(junits), "no hosted control planes, no junit for them")
+}
+
+func TestPathologicalEventsTwoNodeUnhealthyEtcdMembers(t *testing.T) {
+	start := time.Unix(872827200, 0).In(time.UTC)
+	nodeUpdate := monitorapi.Interval{
+		Condition: monitorapi.Condition{
+			Level:   monitorapi.Info,
+			Locator: monitorapi.Locator{Type: monitorapi.LocatorTypeNode, Keys: map[monitorapi.LocatorKey]string{}},
+			Message: monitorapi.Message{
+				Reason: monitorapi.NodeUpdateReason,
+				Annotations: map[monitorapi.AnnotationKey]string{
+					monitorapi.AnnotationConstructed: monitorapi.ConstructionOwnerNodeLifecycle,
+					monitorapi.AnnotationPhase:       "Update",
+					monitorapi.AnnotationRoles:       "control-plane,master",
+				},
+			},
+		},
+		Source: monitorapi.SourceNodeState,
+		From:   start,
+		To:     start.Add(10 * time.Minute),
+	}
+	unhealthyMembers := func(at time.Time) monitorapi.Interval {
+		return monitorapi.NewInterval(monitorapi.SourceKubeEvent, monitorapi.Info).
+			Locator(monitorapi.Locator{Keys: map[monitorapi.LocatorKey]string{
+				monitorapi.LocatorNamespaceKey:  "openshift-etcd-operator",
+				monitorapi.LocatorDeploymentKey: "etcd-operator",
+			}}).Message(
+			monitorapi.NewMessage().Reason("UnhealthyEtcdMember").HumanMessage("unhealthy members: master-1").
+				WithAnnotation(monitorapi.AnnotationCount, "30")).
+			Build(at, at)
+	}
+
+	matcher := newTwoNodeUnhealthyEtcdMembersDuringNodeUpdateEventMatcher(monitorapi.Intervals{nodeUpdate})
+	during, after := unhealthyMembers(start.Add(5*time.Minute)), unhealthyMembers(start.Add(time.Hour))
+	assert.True(t, matcher.Allows(during, platformidentification.DualReplicaTopologyMode))
+	assert.True(t, matcher.Allows(during, platformidentification.HighlyAvailableArbiterTopologyMode))
+	assert.False(t, matcher.Allows(during, v1.HighlyAvailableTopologyMode), "HA clusters keep a quorum through node updates")
+	assert.False(t, matcher.Allows(after, platformidentification.DualReplicaTopologyMode), "only expected while a control plane node updates")
+}
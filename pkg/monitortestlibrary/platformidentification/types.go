@@ -8,6 +8,7 @@ import (
 	configv1 "github.com/openshift/api/config/v1"
 	configclient "github.com/openshift/client-go/config/clientset/versioned/typed/config/v1"
 	exutil "github.com/openshift/origin/test/extended/util"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -43,8 +44,37 @@ const (
 	ArchitectureAMD64   = "amd64"
 	ArchitecturePPC64le = "ppc64le"
 	ArchitectureARM64   = "arm64"
+	// ArchitectureHeterogeneous is the architecture of multi-arch clusters, whose nodes have different architectures.
+	ArchitectureHeterogeneous = "heterogeneous"
 )
 
+// The two node topologies are newer than the vendored openshift/api, so their control plane topology modes are
+// declared here.
+const (
+	// HighlyAvailableArbiterTopologyMode is two control plane nodes and an arbiter node that only runs an etcd member.
+	HighlyAvailableArbiterTopologyMode configv1.TopologyMode = "HighlyAvailableArbiter"
+	// DualReplicaTopologyMode is two control plane nodes that fence each other to keep etcd available.
+	DualReplicaTopologyMode configv1.TopologyMode = "DualReplica"
+)
+
+// The JobType topologies of the two node topologies.
+const (
+	TopologyTwoNodeArbiter = "two-node-arbiter"
+	TopologyTwoNodeFencing = "two-node-fencing"
+)
+
+// IsTwoNodeJobTopology returns true for the JobType topologies of the two node topologies.
+func IsTwoNodeJobTopology(topology string) bool {
+	return topology == TopologyTwoNodeArbiter || topology == TopologyTwoNodeFencing
+}
+
+// IsTwoNodeTopology returns true for the topologies where the control plane runs on two nodes.  These clusters lose
+// half of their apiservers and controllers whenever a node reboots, so they cannot meet the expectations of an HA
+// cluster.
+func IsTwoNodeTopology(topology configv1.TopologyMode) bool {
+	return topology == HighlyAvailableArbiterTopologyMode || topology == DualReplicaTopologyMode
+}
+
 func CloneJobType(in JobType) JobType {
 	return JobType{
 		Release:      in.Release,
@@ -214,6 +244,10 @@ func GetJobType(ctx context.Context, clientConfig *rest.Config) (*JobType, error
 		topology = "single"
 	case configv1.ExternalTopologyMode:
 		topology = "external"
+	case HighlyAvailableArbiterTopologyMode:
+		topology = TopologyTwoNodeArbiter
+	case DualReplicaTopologyMode:
+		topology = TopologyTwoNodeFencing
 	}
 
 	return &JobType{
@@ -264,6 +298,15 @@ func getArchitecture(clientConfig *rest.Config) (string, error) {
 		return "", err
 	}
 
+	// multi-arch clusters are grouped together whatever the architecture of their control plane.
+	allNodes, err := kubeClient.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return "", err
+	}
+	if isHeterogeneous(allNodes.Items) {
+		return ArchitectureHeterogeneous, nil
+	}
+
 	for _, node := range masterNodes.Items {
 		if arch := node.Status.NodeInfo.Architecture; len(arch) > 0 {
 			return arch, nil
@@ -272,3 +315,18 @@ func getArchitecture(clientConfig *rest.Config) (string, error) {
 
 	return "amd64", errors.New("could not determine architecture from master nodes")
 }
+
+// isHeterogeneous returns true when the nodes have more than one architecture.
+func isHeterogeneous(nodes []corev1.Node) bool {
+	architecture := ""
+	for _, node := range nodes {
+		switch arch := node.Status.NodeInfo.Architecture; {
+		case len(arch) == 0:
+		case len(architecture) == 0:
+			architecture = arch
+		case arch != architecture:
+			return true
+		}
+	}
+	return false
+}
@@ -1,8 +1,12 @@
 package platformidentification
 
 import (
+	"sort"
+	"strconv"
 	"time"
 
+	configv1 "github.com/openshift/api/config/v1"
+
 	"github.com/openshift/origin/pkg/monitor/monitorapi"
 )
 
@@ -20,3 +24,70 @@ func DidUpgradeHappenDuringCollection(intervals monitorapi.Intervals, beginning,
 	}
 	return false
 }
+
+// UpgradeHop is a single upgrade within the chain of upgrades performed by a run, for instance one leg of an
+// EUS-to-EUS upgrade.
+type UpgradeHop struct {
+	// Index is 1-based.
+	Index       int
+	FromVersion string
+	ToVersion   string
+	From        time.Time
+	// To is zero if the hop never completed.
+	To time.Time
+}
+
+// UpgradeHopsFromHistory returns the upgrades in the clusterversion history that started at or after since, oldest
+// first.  History is ordered newest first, so the version an entry upgraded from is the one that follows it.
+func UpgradeHopsFromHistory(history []configv1.UpdateHistory, since time.Time) []UpgradeHop {
+	var hops []UpgradeHop
+	for i := len(history) - 2; i >= 0; i-- {
+		if history[i].StartedTime.Time.Before(since) {
+			continue
+		}
+		hop := UpgradeHop{
+			FromVersion: history[i+1].Version,
+			ToVersion:   history[i].Version,
+			From:        history[i].StartedTime.Time,
+		}
+		if history[i].CompletionTime != nil {
+			hop.To = history[i].CompletionTime.Time
+		}
+		hops = append(hops, hop)
+	}
+	for i := range hops {
+		hops[i].Index = i + 1
+	}
+	return hops
+}
+
+// JobType returns the job type for this hop alone, so that historical data for single upgrades between the same
+// releases can be used to evaluate it.
+func (h UpgradeHop) JobType(jobType JobType) JobType {
+	jobType.FromRelease = VersionFromHistory(configv1.UpdateHistory{Version: h.FromVersion})
+	jobType.Release = VersionFromHistory(configv1.UpdateHistory{Version: h.ToVersion})
+	return jobType
+}
+
+// UpgradeHopsFromIntervals reads the hops recorded as UpgradeHop intervals.
+func UpgradeHopsFromIntervals(intervals monitorapi.Intervals) []UpgradeHop {
+	var hops []UpgradeHop
+	for _, interval := range intervals {
+		if interval.Source != monitorapi.SourceUpgradeHop {
+			continue
+		}
+		index, err := strconv.Atoi(interval.Message.Annotations[monitorapi.AnnotationUpgradeHop])
+		if err != nil {
+			continue
+		}
+		hops = append(hops, UpgradeHop{
+			Index:       index,
+			FromVersion: interval.Message.Annotations[monitorapi.AnnotationFromVersion],
+			ToVersion:   interval.Message.Annotations[monitorapi.AnnotationToVersion],
+			From:        interval.From,
+			To:          interval.To,
+		})
+	}
+	sort.Slice(hops, func(i, j int) bool { return hops[i].Index < hops[j].Index })
+	return hops
+}
@@ -0,0 +1,115 @@
+package spotnodes
+
+import (
+	"sort"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// InterruptibleInstanceLabel is set by the machine-api on nodes running on spot or preemptible instances.
+	InterruptibleInstanceLabel = "machine.openshift.io/interruptible-instance"
+
+	// TerminationGrace is how long a spot node may take to recover after the cloud provider interrupted it.
+	TerminationGrace = 10 * time.Minute
+)
+
+// IsSpotProviderSpec returns true if a machine's providerSpec asks for a spot or preemptible instance on AWS, GCP or
+// Azure.
+func IsSpotProviderSpec(providerSpec map[string]interface{}) bool {
+	if _, ok, _ := unstructured.NestedMap(providerSpec, "spotMarketOptions"); ok {
+		return true
+	}
+	if _, ok, _ := unstructured.NestedMap(providerSpec, "spotVMOptions"); ok {
+		return true
+	}
+	if preemptible, _, _ := unstructured.NestedBool(providerSpec, "preemptible"); preemptible {
+		return true
+	}
+	provisioningModel, _, _ := unstructured.NestedString(providerSpec, "provisioningModel")
+	return provisioningModel == "Spot"
+}
+
+func isTerminationWindow(interval monitorapi.Interval) bool {
+	return interval.Source == monitorapi.SourceSpotNode && interval.Message.Reason == monitorapi.SpotNodeTerminationReason
+}
+
+// TerminationWindows returns the termination windows of every spot node, keyed by node name.
+func TerminationWindows(intervals monitorapi.Intervals) map[string]monitorapi.Intervals {
+	ret := map[string]monitorapi.Intervals{}
+	for _, interval := range intervals.Filter(isTerminationWindow) {
+		node := interval.Locator.Keys[monitorapi.LocatorNodeKey]
+		ret[node] = append(ret[node], interval)
+	}
+	return ret
+}
+
+// DuringTermination returns true if the node was a spot node being terminated by the cloud provider at the time.
+func DuringTermination(windows map[string]monitorapi.Intervals, node string, at time.Time) bool {
+	for _, window := range windows[node] {
+		if !at.Before(window.From) && (window.To.IsZero() || !at.After(window.To)) {
+			return true
+		}
+	}
+	return false
+}
+
+// DurationOutsideTermination returns the total duration of the intervals that did not overlap the termination of any
+// spot node.  Like Intervals.Duration, intervals shorter than minDuration count as minDuration.
+func DurationOutsideTermination(intervals, allIntervals monitorapi.Intervals, minDuration time.Duration) time.Duration {
+	windows := mergeWindows(allIntervals.Filter(isTerminationWindow))
+
+	var total time.Duration
+	for _, interval := range intervals {
+		duration := interval.To.Sub(interval.From)
+		if duration <= 0 {
+			continue
+		}
+		if duration < minDuration {
+			duration = minDuration
+		}
+		for _, window := range windows {
+			from, to := window.From, window.To
+			if from.Before(interval.From) {
+				from = interval.From
+			}
+			if to.IsZero() || to.After(interval.To) {
+				to = interval.To
+			}
+			if to.After(from) {
+				duration -= to.Sub(from)
+			}
+		}
+		if duration > 0 {
+			total += duration
+		}
+	}
+	return total
+}
+
+// mergeWindows joins overlapping windows so that no time is subtracted twice.
+func mergeWindows(windows monitorapi.Intervals) monitorapi.Intervals {
+	sorted := make(monitorapi.Intervals, len(windows))
+	copy(sorted, windows)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].From.Before(sorted[j].From) })
+
+	ret := monitorapi.Intervals{}
+	for _, window := range sorted {
+		if len(ret) > 0 {
+			last := &ret[len(ret)-1]
+			if last.To.IsZero() {
+				continue
+			}
+			if !window.From.After(last.To) {
+				if window.To.IsZero() || window.To.After(last.To) {
+					last.To = window.To
+				}
+				continue
+			}
+		}
+		ret = append(ret, window)
+	}
+	return ret
+}
@@ -0,0 +1,110 @@
+package spotnodes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func terminationWindow(node string, from, to time.Time) monitorapi.Interval {
+	return monitorapi.NewInterval(monitorapi.SourceSpotNode, monitorapi.Warning).
+		Locator(monitorapi.NewLocator().NodeFromName(node)).
+		Message(monitorapi.NewMessage().Reason(monitorapi.SpotNodeTerminationReason)).
+		Build(from, to)
+}
+
+func TestIsSpotProviderSpec(t *testing.T) {
+	tests := []struct {
+		name         string
+		providerSpec map[string]interface{}
+		expected     bool
+	}{
+		{
+			name:         "aws spot",
+			providerSpec: map[string]interface{}{"spotMarketOptions": map[string]interface{}{}},
+			expected:     true,
+		},
+		{
+			name:         "azure spot",
+			providerSpec: map[string]interface{}{"spotVMOptions": map[string]interface{}{"maxPrice": "-1"}},
+			expected:     true,
+		},
+		{
+			name:         "gcp preemptible",
+			providerSpec: map[string]interface{}{"preemptible": true},
+			expected:     true,
+		},
+		{
+			name:         "gcp spot",
+			providerSpec: map[string]interface{}{"provisioningModel": "Spot"},
+			expected:     true,
+		},
+		{
+			name:         "on demand",
+			providerSpec: map[string]interface{}{"preemptible": false, "instanceType": "m6i.xlarge"},
+		},
+		{
+			name: "missing",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsSpotProviderSpec(tt.providerSpec))
+		})
+	}
+}
+
+func TestDuringTermination(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	windows := TerminationWindows(monitorapi.Intervals{terminationWindow("worker-a", start, start.Add(10*time.Minute))})
+
+	assert.True(t, DuringTermination(windows, "worker-a", start.Add(5*time.Minute)))
+	assert.False(t, DuringTermination(windows, "worker-a", start.Add(11*time.Minute)))
+	assert.False(t, DuringTermination(windows, "worker-b", start.Add(5*time.Minute)))
+}
+
+func TestDurationOutsideTermination(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	disruption := func(from, to time.Time) monitorapi.Interval {
+		return monitorapi.NewInterval(monitorapi.SourceDisruption, monitorapi.Error).
+			Locator(monitorapi.NewLocator().DisruptionRequiredOnly("ingress", "new")).
+			Message(monitorapi.NewMessage().HumanMessage("disrupted")).
+			Build(from, to)
+	}
+	// two overlapping windows on different nodes must only be subtracted once.
+	windows := monitorapi.Intervals{
+		terminationWindow("worker-a", start, start.Add(2*time.Minute)),
+		terminationWindow("worker-b", start.Add(time.Minute), start.Add(3*time.Minute)),
+	}
+
+	tests := []struct {
+		name       string
+		disruption monitorapi.Intervals
+		expected   time.Duration
+	}{
+		{
+			name:       "inside termination",
+			disruption: monitorapi.Intervals{disruption(start.Add(30*time.Second), start.Add(150*time.Second))},
+		},
+		{
+			name:       "straddles the end of termination",
+			disruption: monitorapi.Intervals{disruption(start.Add(170*time.Second), start.Add(200*time.Second))},
+			expected:   20 * time.Second,
+		},
+		{
+			name: "outside termination counts a minimum duration",
+			disruption: monitorapi.Intervals{
+				disruption(start.Add(10*time.Minute), start.Add(10*time.Minute+100*time.Millisecond)),
+				disruption(start.Add(11*time.Minute), start.Add(11*time.Minute+5*time.Second)),
+			},
+			expected: 6 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, DurationOutsideTermination(tt.disruption, append(windows, tt.disruption...), time.Second))
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package windowsnodes
+
+import (
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// Windows is the value of the kubernetes.io/os label of Windows nodes, and of the os annotation of their intervals.
+	Windows = "windows"
+
+	// BootGrace is the additional time a Windows node takes to become Ready.  The Windows Machine Config Operator only
+	// installs and configures the kubelet once the instance booted, and reboots it on the way.
+	BootGrace = 20 * time.Minute
+)
+
+// IsWindows returns true if the node runs Windows.
+func IsWindows(node *corev1.Node) bool {
+	return node.Labels[corev1.LabelOSStable] == Windows
+}
+
+// Annotations returns the annotations tagging the intervals of a node with its operating system.  Linux nodes get
+// none, so that the intervals of clusters without Windows nodes are unchanged.
+func Annotations(node *corev1.Node) map[monitorapi.AnnotationKey]string {
+	if !IsWindows(node) {
+		return map[monitorapi.AnnotationKey]string{}
+	}
+	return map[monitorapi.AnnotationKey]string{monitorapi.AnnotationOS: Windows}
+}
+
+// IsWindowsInterval returns true if the interval was tagged as being about a Windows node.
+func IsWindowsInterval(interval monitorapi.Interval) bool {
+	return interval.Message.Annotations[monitorapi.AnnotationOS] == Windows
+}
+
+// Nodes returns the names of the nodes whose intervals were tagged as Windows nodes.
+func Nodes(intervals monitorapi.Intervals) sets.Set[string] {
+	ret := sets.New[string]()
+	for _, interval := range intervals {
+		if node, ok := interval.Locator.Keys[monitorapi.LocatorNodeKey]; ok && IsWindowsInterval(interval) {
+			ret.Insert(node)
+		}
+	}
+	return ret
+}
+
+// NotOnNodes returns a filter for the intervals that are not about one of the nodes.
+func NotOnNodes(nodes sets.Set[string]) monitorapi.EventIntervalMatchesFunc {
+	return func(interval monitorapi.Interval) bool {
+		return !nodes.Has(interval.Locator.Keys[monitorapi.LocatorNodeKey])
+	}
+}
@@ -0,0 +1,42 @@
+package windowsnodes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func nodeInterval(node string, annotations map[monitorapi.AnnotationKey]string) monitorapi.Interval {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return monitorapi.NewInterval(monitorapi.SourceNodeMonitor, monitorapi.Info).
+		Locator(monitorapi.NewLocator().NodeFromName(node)).
+		Message(monitorapi.NewMessage().Reason("Ready").WithAnnotations(annotations).HumanMessage("node is ready")).
+		Build(now, now)
+}
+
+func TestAnnotations(t *testing.T) {
+	windows := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{corev1.LabelOSStable: "windows"}}}
+	linux := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{corev1.LabelOSStable: "linux"}}}
+
+	assert.True(t, IsWindows(windows))
+	assert.False(t, IsWindows(linux))
+	assert.Equal(t, map[monitorapi.AnnotationKey]string{monitorapi.AnnotationOS: "windows"}, Annotations(windows))
+	assert.Empty(t, Annotations(linux), "Linux nodes are not tagged")
+}
+
+func TestNodes(t *testing.T) {
+	intervals := monitorapi.Intervals{
+		nodeInterval("windows-a", Annotations(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{corev1.LabelOSStable: "windows"}}})),
+		nodeInterval("linux-b", nil),
+	}
+	nodes := Nodes(intervals)
+	assert.Equal(t, []string{"windows-a"}, nodes.UnsortedList())
+
+	filtered := intervals.Filter(NotOnNodes(nodes))
+	assert.Len(t, filtered, 1)
+	assert.Equal(t, "linux-b", filtered[0].Locator.Keys[monitorapi.LocatorNodeKey])
+}
@@ -1,24 +1,25 @@
 package legacyauthenticationmonitortests
 
 import (
+	configv1 "github.com/openshift/api/config/v1"
 	"github.com/openshift/origin/pkg/monitortestlibrary/pathologicaleventlibrary"
 
 	"github.com/openshift/origin/pkg/monitor/monitorapi"
 	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
 )
 
-func testOauthApiserverProbeErrorLiveness(events monitorapi.Intervals) []*junitapi.JUnitTestCase {
+func testOauthApiserverProbeErrorLiveness(events monitorapi.Intervals, topology configv1.TopologyMode) []*junitapi.JUnitTestCase {
 	const testName = "[bz-apiserver-auth] openshift-oauth-apiserver should not get probe error on liveness probe due to timeout"
-	return pathologicaleventlibrary.MakeProbeTest(testName, events, "openshift-oauth-apiserver", pathologicaleventlibrary.ProbeErrorLiveness, pathologicaleventlibrary.DuplicateEventThreshold)
+	return pathologicaleventlibrary.MakeProbeTest(testName, events, "openshift-oauth-apiserver", pathologicaleventlibrary.ProbeErrorLiveness, pathologicaleventlibrary.DuplicateEventThresholdForTopology(topology))
 }
 
-func testOauthApiserverProbeErrorReadiness(events monitorapi.Intervals) []*junitapi.JUnitTestCase {
+func testOauthApiserverProbeErrorReadiness(events monitorapi.Intervals, topology configv1.TopologyMode) []*junitapi.JUnitTestCase {
 	const testName = "[bz-apiserver-auth] openshift-oauth-apiserver should not get probe error on readiness probe due to timeout"
-	return pathologicaleventlibrary.MakeProbeTest(testName, events, "openshift-oauth-apiserver", pathologicaleventlibrary.ProbeErrorTimeoutAwaitingHeaders, pathologicaleventlibrary.DuplicateEventThreshold)
+	return pathologicaleventlibrary.MakeProbeTest(testName, events, "openshift-oauth-apiserver", pathologicaleventlibrary.ProbeErrorTimeoutAwaitingHeaders, pathologicaleventlibrary.DuplicateEventThresholdForTopology(topology))
 }
 
-func testOauthApiserverProbeErrorConnectionRefused(events monitorapi.Intervals) []*junitapi.JUnitTestCase {
+func testOauthApiserverProbeErrorConnectionRefused(events monitorapi.Intervals, topology configv1.TopologyMode) []*junitapi.JUnitTestCase {
 	const testName = "[bz-apiserver-auth] openshift-oauth-apiserver should not get probe error on readiness probe due to connection refused"
 	return pathologicaleventlibrary.MakeProbeTest(testName, events, "openshift-oauth-apiserver",
-		pathologicaleventlibrary.ProbeErrorConnectionRefused, pathologicaleventlibrary.DuplicateEventThreshold)
+		pathologicaleventlibrary.ProbeErrorConnectionRefused, pathologicaleventlibrary.DuplicateEventThresholdForTopology(topology))
 }
@@ -4,7 +4,11 @@ import (
 	"context"
 	"time"
 
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/sirupsen/logrus"
+
 	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/monitortestlibrary/pathologicaleventlibrary"
 
 	"github.com/openshift/origin/pkg/monitor/monitorapi"
 	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
@@ -13,6 +17,7 @@ import (
 
 type legacyMonitorTests struct {
 	adminRESTConfig *rest.Config
+	topology        configv1.TopologyMode
 }
 
 func NewLegacyTests() monitortestframework.MonitorTest {
@@ -21,6 +26,11 @@ func NewLegacyTests() monitortestframework.MonitorTest {
 
 func (w *legacyMonitorTests) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
 	w.adminRESTConfig = adminRESTConfig
+	_, topology, err := pathologicaleventlibrary.GetClusterInfraInfo(adminRESTConfig)
+	if err != nil {
+		logrus.WithError(err).Error("could not fetch cluster infra info")
+	}
+	w.topology = topology
 	return nil
 }
 
@@ -34,9 +44,9 @@ func (*legacyMonitorTests) ConstructComputedIntervals(ctx context.Context, start
 
 func (w *legacyMonitorTests) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
 	junits := []*junitapi.JUnitTestCase{}
-	junits = append(junits, testOauthApiserverProbeErrorReadiness(finalIntervals)...)
-	junits = append(junits, testOauthApiserverProbeErrorLiveness(finalIntervals)...)
-	junits = append(junits, testOauthApiserverProbeErrorConnectionRefused(finalIntervals)...)
+	junits = append(junits, testOauthApiserverProbeErrorReadiness(finalIntervals, w.topology)...)
+	junits = append(junits, testOauthApiserverProbeErrorLiveness(finalIntervals, w.topology)...)
+	junits = append(junits, testOauthApiserverProbeErrorConnectionRefused(finalIntervals, w.topology)...)
 
 	return junits, nil
 }
@@ -0,0 +1,189 @@
+package baremetalhosts
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	configclient "github.com/openshift/client-go/config/clientset/versioned"
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	exutil "github.com/openshift/origin/test/extended/util"
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+)
+
+var bareMetalHostsResource = schema.GroupVersionResource{Group: "metal3.io", Version: "v1alpha1", Resource: "baremetalhosts"}
+
+type bareMetalHostMonitor struct {
+	notSupportedReason error
+
+	lock sync.Mutex
+	// operations are the inspections, provisionings and deprovisionings seen in the operation history of the hosts,
+	// by host, operation and start.
+	operations map[string]*hostOperation
+	// lastErrors are the last error message of every host, so an error is only recorded when it changes.
+	lastErrors map[string]string
+}
+
+// NewBareMetalHostMonitor records the inspection, provisioning and deprovisioning of the BareMetalHosts of baremetal
+// clusters from their operation history, and the errors the baremetal-operator reports on them, so that slow or stuck
+// provisioning shows on the timeline and is caught by a time budget.
+func NewBareMetalHostMonitor() monitortestframework.MonitorTest {
+	return &bareMetalHostMonitor{
+		operations: map[string]*hostOperation{},
+		lastErrors: map[string]string{},
+	}
+}
+
+func (*bareMetalHostMonitor) Describe() monitortestframework.MonitorTestDescription {
+	return monitortestframework.MonitorTestDescription{
+		Platforms:        []string{"metal"},
+		WatchedResources: []string{"infrastructures.config.openshift.io", "baremetalhosts.metal3.io"},
+		JUnits:           bareMetalHostTestNames(),
+	}
+}
+
+func (w *bareMetalHostMonitor) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	kubeClient, err := kubernetes.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return err
+	}
+	isMicroShift, err := exutil.IsMicroShiftCluster(kubeClient)
+	if err != nil {
+		return fmt.Errorf("unable to determine if cluster is MicroShift: %v", err)
+	}
+	if isMicroShift {
+		w.notSupportedReason = &monitortestframework.NotSupportedError{
+			Reason: "platform MicroShift not supported",
+		}
+		return w.notSupportedReason
+	}
+
+	configClient, err := configclient.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return err
+	}
+	infra, err := configClient.ConfigV1().Infrastructures().Get(ctx, "cluster", metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if infra.Status.PlatformStatus == nil || infra.Status.PlatformStatus.Type != configv1.BareMetalPlatformType {
+		w.notSupportedReason = &monitortestframework.NotSupportedError{Reason: "only baremetal clusters have BareMetalHosts"}
+		return w.notSupportedReason
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return err
+	}
+	hosts := dynamicClient.Resource(bareMetalHostsResource)
+	// assisted and UPI baremetal installs may not run the baremetal-operator.
+	if _, err := hosts.List(ctx, metav1.ListOptions{Limit: 1}); apierrors.IsNotFound(err) {
+		w.notSupportedReason = &monitortestframework.NotSupportedError{Reason: "the BareMetalHost API is not installed"}
+		return w.notSupportedReason
+	} else if err != nil {
+		return err
+	}
+
+	informer := cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				return hosts.List(ctx, options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return hosts.Watch(ctx, options)
+			},
+		},
+		&unstructured.Unstructured{},
+		time.Hour,
+		nil,
+	)
+	observe := func(obj interface{}) {
+		host, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			return
+		}
+		w.observe(host, recorder, time.Now())
+	}
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: observe,
+		UpdateFunc: func(_, obj interface{}) {
+			observe(obj)
+		},
+	})
+	go informer.Run(ctx.Done())
+	return nil
+}
+
+// observe records the operations in the history of the host, and its error when it changed.
+func (w *bareMetalHostMonitor) observe(host *unstructured.Unstructured, recorder monitorapi.RecorderWriter, now time.Time) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	for _, operation := range hostOperations(host) {
+		w.operations[operation.key()] = operation
+	}
+
+	key := host.GetNamespace() + "/" + host.GetName()
+	errorType, _, _ := unstructured.NestedString(host.Object, "status", "errorType")
+	errorMessage, _, _ := unstructured.NestedString(host.Object, "status", "errorMessage")
+	if errorMessage == w.lastErrors[key] {
+		return
+	}
+	w.lastErrors[key] = errorMessage
+	if len(errorMessage) == 0 {
+		return
+	}
+	recorder.AddIntervals(monitorapi.NewInterval(monitorapi.SourceBareMetalHost, monitorapi.Error).
+		Locator(hostLocator(host.GetNamespace(), host.GetName())).
+		Message(monitorapi.NewMessage().Reason(monitorapi.BareMetalHostErrorReason).
+			HumanMessagef("%s: %s", errorType, errorMessage)).
+		Display().
+		Build(now, now))
+}
+
+func (w *bareMetalHostMonitor) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	if w.notSupportedReason != nil {
+		return nil, nil, w.notSupportedReason
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	operations := make([]*hostOperation, 0, len(w.operations))
+	for _, operation := range w.operations {
+		operations = append(operations, operation)
+	}
+	intervals := operationIntervals(operations, beginning, end)
+	logrus.Infof("found %d BareMetalHost operations", len(intervals))
+	return intervals, nil, nil
+}
+
+func (*bareMetalHostMonitor) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	return nil, nil
+}
+
+func (w *bareMetalHostMonitor) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	if w.notSupportedReason != nil {
+		return nil, w.notSupportedReason
+	}
+	return operationBudgetJUnits(finalIntervals), nil
+}
+
+func (*bareMetalHostMonitor) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	return nil
+}
+
+func (*bareMetalHostMonitor) Cleanup(ctx context.Context) error {
+	return nil
+}
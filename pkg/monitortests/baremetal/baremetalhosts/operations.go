@@ -0,0 +1,146 @@
+package baremetalhosts
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// operationBudget is how long one operation of the operation history of a BareMetalHost may take.
+type operationBudget struct {
+	// historyKey is the key of the operation in status.operationHistory.
+	historyKey string
+	// phase names the operation in intervals and tests.
+	phase  string
+	reason monitorapi.IntervalReason
+	budget time.Duration
+}
+
+// operationBudgets are generous, real hardware is slow to POST, but a host that takes longer is most likely stuck.
+var operationBudgets = []operationBudget{
+	{historyKey: "inspect", phase: "inspection", reason: monitorapi.BareMetalHostInspectingReason, budget: 30 * time.Minute},
+	{historyKey: "provision", phase: "provisioning", reason: monitorapi.BareMetalHostProvisioningReason, budget: 45 * time.Minute},
+	{historyKey: "deprovision", phase: "deprovisioning", reason: monitorapi.BareMetalHostDeprovisioningReason, budget: 30 * time.Minute},
+}
+
+type hostOperation struct {
+	namespace string
+	name      string
+	budget    operationBudget
+	start     time.Time
+	// end is zero while the operation is in progress.
+	end time.Time
+}
+
+func (o *hostOperation) key() string {
+	return fmt.Sprintf("%s/%s/%s/%s", o.namespace, o.name, o.budget.historyKey, o.start.Format(time.RFC3339))
+}
+
+func hostLocator(namespace, name string) monitorapi.Locator {
+	return monitorapi.NewLocator().ForGVR(bareMetalHostsResource, namespace, name)
+}
+
+// hostOperations returns the operations that started in the operation history of host.  The history only holds the
+// last operation of each kind, which is why hosts are watched rather than read at the end.
+func hostOperations(host *unstructured.Unstructured) []*hostOperation {
+	ret := []*hostOperation{}
+	for _, budget := range operationBudgets {
+		start := historyTime(host, budget.historyKey, "start")
+		if start.IsZero() {
+			continue
+		}
+		ret = append(ret, &hostOperation{
+			namespace: host.GetNamespace(),
+			name:      host.GetName(),
+			budget:    budget,
+			start:     start,
+			end:       historyTime(host, budget.historyKey, "end"),
+		})
+	}
+	return ret
+}
+
+func historyTime(host *unstructured.Unstructured, operation, field string) time.Time {
+	value, _, _ := unstructured.NestedString(host.Object, "status", "operationHistory", operation, field)
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// operationIntervals returns an interval for every operation that was in progress during the run.  Operations still
+// in progress end with the run.
+func operationIntervals(operations []*hostOperation, beginning, end time.Time) monitorapi.Intervals {
+	ret := monitorapi.Intervals{}
+	for _, operation := range operations {
+		if !operation.end.IsZero() && operation.end.Before(beginning) {
+			continue
+		}
+		level := monitorapi.Info
+		to := operation.end
+		message := monitorapi.NewMessage().Reason(operation.budget.reason)
+		if to.IsZero() {
+			level = monitorapi.Warning
+			to = end
+			message = message.HumanMessagef("%s did not complete by the end of the run", operation.budget.phase)
+		} else {
+			message = message.HumanMessagef("%s took %s", operation.budget.phase, to.Sub(operation.start).Round(time.Second))
+		}
+		ret = append(ret, monitorapi.NewInterval(monitorapi.SourceBareMetalHost, level).
+			Locator(hostLocator(operation.namespace, operation.name)).
+			Message(message).
+			Display().
+			Build(operation.start, to))
+	}
+	sort.Sort(ret)
+	return ret
+}
+
+func bareMetalHostTestName(budget operationBudget) string {
+	return fmt.Sprintf("[sig-metal] BareMetalHost %s should complete within its time budget", budget.phase)
+}
+
+func bareMetalHostTestNames() []string {
+	ret := []string{}
+	for _, budget := range operationBudgets {
+		ret = append(ret, bareMetalHostTestName(budget))
+	}
+	return ret
+}
+
+// operationBudgetJUnits reports the operations that took longer than their budget, including those that had not
+// completed yet.  Hardware is shared and its speed varies, so exceeding a budget only flakes until there is data to
+// set budgets that can fail.
+func operationBudgetJUnits(intervals monitorapi.Intervals) []*junitapi.JUnitTestCase {
+	ret := []*junitapi.JUnitTestCase{}
+	for _, budget := range operationBudgets {
+		testName := bareMetalHostTestName(budget)
+		overBudget := []string{}
+		for _, interval := range intervals {
+			if interval.Source != monitorapi.SourceBareMetalHost || interval.Message.Reason != budget.reason {
+				continue
+			}
+			if duration := interval.To.Sub(interval.From); duration > budget.budget {
+				overBudget = append(overBudget, fmt.Sprintf("%s %s: %s",
+					interval.From.Format(time.RFC3339), interval.Locator.OldLocator(), interval.Message.HumanMessage))
+			}
+		}
+		if len(overBudget) > 0 {
+			ret = append(ret, &junitapi.JUnitTestCase{
+				Name: testName,
+				FailureOutput: &junitapi.FailureOutput{
+					Output: fmt.Sprintf("%d BareMetalHost %s operations took longer than %s:\n\n%s",
+						len(overBudget), budget.phase, budget.budget, strings.Join(overBudget, "\n")),
+				},
+			})
+		}
+		ret = append(ret, &junitapi.JUnitTestCase{Name: testName})
+	}
+	return ret
+}
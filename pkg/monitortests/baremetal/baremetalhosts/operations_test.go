@@ -0,0 +1,51 @@
+package baremetalhosts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func host(name string, history map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"namespace": "openshift-machine-api", "name": name},
+		"status":   map[string]interface{}{"operationHistory": history},
+	}}
+}
+
+func TestOperationIntervals(t *testing.T) {
+	beginning := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	end := beginning.Add(2 * time.Hour)
+
+	operations := hostOperations(host("worker-0", map[string]interface{}{
+		"register":    map[string]interface{}{"start": "2024-03-01T09:00:00Z", "end": "2024-03-01T09:01:00Z"},
+		"inspect":     map[string]interface{}{"start": "2024-03-01T09:01:00Z", "end": "2024-03-01T09:20:00Z"},
+		"provision":   map[string]interface{}{"start": "2024-03-01T10:10:00Z", "end": "2024-03-01T10:30:00Z"},
+		"deprovision": map[string]interface{}{"start": nil, "end": nil},
+	}))
+	operations = append(operations, hostOperations(host("worker-1", map[string]interface{}{
+		"provision": map[string]interface{}{"start": "2024-03-01T10:20:00Z"},
+	}))...)
+	require.Len(t, operations, 3, "registration is not tracked, operations that never started are skipped")
+
+	intervals := operationIntervals(operations, beginning, end)
+	require.Len(t, intervals, 2, "operations that ended before the run are skipped")
+	assert.Equal(t, monitorapi.BareMetalHostProvisioningReason, intervals[0].Message.Reason)
+	assert.Equal(t, "worker-0", intervals[0].Locator.Keys[monitorapi.LocatorNameKey])
+	assert.Equal(t, "provisioning took 20m0s", intervals[0].Message.HumanMessage)
+	assert.Equal(t, monitorapi.Warning, intervals[1].Level)
+	assert.Equal(t, end, intervals[1].To, "operations in progress end with the run")
+
+	junits := operationBudgetJUnits(intervals)
+	require.Len(t, junits, 4, "one result per budget, and a flake")
+	assert.Nil(t, junits[0].FailureOutput)
+	require.NotNil(t, junits[1].FailureOutput)
+	assert.Equal(t, "[sig-metal] BareMetalHost provisioning should complete within its time budget", junits[1].Name)
+	assert.Contains(t, junits[1].FailureOutput.Output, "name/worker-1")
+	assert.Equal(t, junits[1].Name, junits[2].Name)
+	assert.Nil(t, junits[2].FailureOutput)
+}
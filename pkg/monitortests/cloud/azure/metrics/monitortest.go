@@ -47,6 +47,13 @@ func NewAzureMetricsCollector() monitortestframework.MonitorTest {
 	return &azureMetricsCollector{}
 }
 
+func (*azureMetricsCollector) Describe() monitortestframework.MonitorTestDescription {
+	return monitortestframework.MonitorTestDescription{
+		Platforms:        []string{"azure"},
+		WatchedResources: []string{"infrastructures.config.openshift.io", "machines.machine.openshift.io"},
+	}
+}
+
 func (w *azureMetricsCollector) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
 	w.adminRESTConfig = adminRESTConfig
 	kubeClient, err := kubernetes.NewForConfig(w.adminRESTConfig)
@@ -0,0 +1,116 @@
+package nodeinterruptions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/monitor/armmonitor"
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	azureutil "github.com/openshift/origin/test/extended/util/azure"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// azureVirtualMachines maps lower case virtual machine resource IDs to node names for the nodes whose providerID is
+// azure://<resource ID>.
+func azureVirtualMachines(nodes []corev1.Node) map[string]string {
+	ret := map[string]string{}
+	for _, node := range nodes {
+		if !strings.HasPrefix(node.Spec.ProviderID, "azure://") {
+			continue
+		}
+		ret[strings.ToLower(strings.TrimPrefix(node.Spec.ProviderID, "azure://"))] = node.Name
+	}
+	return ret
+}
+
+// azureResourceIDPart returns the value following key in a resource ID, for instance the subscription ID.
+func azureResourceIDPart(resourceID, key string) string {
+	parts := strings.Split(strings.Trim(resourceID, "/"), "/")
+	for i := 0; i+1 < len(parts); i++ {
+		if strings.EqualFold(parts[i], key) {
+			return parts[i+1]
+		}
+	}
+	return ""
+}
+
+func fetchAzureInterruptions(ctx context.Context, nodes []corev1.Node, beginning, end time.Time) (monitorapi.Intervals, error) {
+	virtualMachines := azureVirtualMachines(nodes)
+	if len(virtualMachines) == 0 {
+		return nil, nil
+	}
+	var subscriptionID, resourceGroup string
+	for resourceID := range virtualMachines {
+		subscriptionID = azureResourceIDPart(resourceID, "subscriptions")
+		resourceGroup = azureResourceIDPart(resourceID, "resourceGroups")
+		break
+	}
+
+	if err := azureutil.ExportAzureCredentials(); err != nil {
+		return nil, fmt.Errorf("unable to export azure credentials: %w", err)
+	}
+	cred, err := azidentity.NewDefaultAzureCredential(nil)
+	if err != nil {
+		return nil, fmt.Errorf("default azure credential does not exist: %w", err)
+	}
+	clientFactory, err := armmonitor.NewClientFactory(subscriptionID, cred, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the azure monitor client: %w", err)
+	}
+
+	filter := fmt.Sprintf("eventTimestamp ge '%s' and eventTimestamp le '%s' and resourceGroupName eq '%s'",
+		beginning.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339), resourceGroup)
+	ret := monitorapi.Intervals{}
+	pager := clientFactory.NewActivityLogsClient().NewListPager(filter, nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list the activity log: %w", err)
+		}
+		for _, event := range page.Value {
+			if interval, ok := azureEventToInterval(event, virtualMachines); ok {
+				ret = append(ret, interval)
+			}
+		}
+	}
+	return ret, nil
+}
+
+// azureEventToInterval converts the resource health events the platform raised against one of the cluster's nodes.
+// Spot evictions and host maintenance are reported as platform initiated resource health events.
+func azureEventToInterval(event *armmonitor.EventData, virtualMachines map[string]string) (monitorapi.Interval, bool) {
+	if event == nil || event.Category == nil || event.Category.Value == nil || event.ResourceID == nil || event.EventTimestamp == nil {
+		return monitorapi.Interval{}, false
+	}
+	if !strings.EqualFold(*event.Category.Value, "ResourceHealth") {
+		return monitorapi.Interval{}, false
+	}
+	if cause := event.Properties["cause"]; cause == nil || !strings.EqualFold(*cause, "PlatformInitiated") {
+		return monitorapi.Interval{}, false
+	}
+	nodeName, ok := virtualMachines[strings.ToLower(*event.ResourceID)]
+	if !ok {
+		return monitorapi.Interval{}, false
+	}
+
+	title := ""
+	if event.Properties["title"] != nil {
+		title = *event.Properties["title"]
+	}
+	reason := monitorapi.CloudNodeUnavailableReason
+	switch lowerTitle := strings.ToLower(title); {
+	case strings.Contains(lowerTitle, "spot") || strings.Contains(lowerTitle, "low-priority") || strings.Contains(lowerTitle, "preempt"):
+		reason = monitorapi.CloudNodePreemptedReason
+	case strings.Contains(lowerTitle, "maintenance"):
+		reason = monitorapi.CloudNodeHostMaintenanceReason
+	}
+
+	return monitorapi.NewInterval(monitorapi.SourceCloudNodeInterruption, monitorapi.Warning).
+		Locator(monitorapi.NewLocator().NodeFromName(nodeName)).
+		Message(monitorapi.NewMessage().Reason(reason).HumanMessage(title)).
+		Display().
+		Build(*event.EventTimestamp, *event.EventTimestamp), true
+}
@@ -0,0 +1,132 @@
+package nodeinterruptions
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"google.golang.org/api/compute/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// gcpOperationReasons maps the system operations GCE records against an instance to interval reasons.
+var gcpOperationReasons = map[string]monitorapi.IntervalReason{
+	"compute.instances.preempted":                  monitorapi.CloudNodePreemptedReason,
+	"compute.instances.terminateOnHostMaintenance": monitorapi.CloudNodeHostMaintenanceReason,
+	"compute.instances.migrateOnHostMaintenance":   monitorapi.CloudNodeLiveMigrationReason,
+	"compute.instances.hostError":                  monitorapi.CloudNodeHostErrorReason,
+}
+
+// gcpInstances maps zone/instance to node names for the nodes whose providerID is gce://<project>/<zone>/<instance>.
+func gcpInstances(nodes []corev1.Node) map[string]string {
+	ret := map[string]string{}
+	for _, node := range nodes {
+		if !strings.HasPrefix(node.Spec.ProviderID, "gce://") {
+			continue
+		}
+		parts := strings.Split(strings.TrimPrefix(node.Spec.ProviderID, "gce://"), "/")
+		if len(parts) != 3 {
+			continue
+		}
+		ret[parts[1]+"/"+parts[2]] = node.Name
+	}
+	return ret
+}
+
+func fetchGCPInterruptions(ctx context.Context, project string, nodes []corev1.Node, beginning, end time.Time) (monitorapi.Intervals, error) {
+	instances := gcpInstances(nodes)
+	zones := sets.NewString()
+	for zoneAndInstance := range instances {
+		zones.Insert(strings.Split(zoneAndInstance, "/")[0])
+	}
+
+	service, err := compute.NewService(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the compute client: %w", err)
+	}
+
+	ret := monitorapi.Intervals{}
+	for _, zone := range zones.List() {
+		filter := gcpOperationFilter(project, zone, instances, beginning, end)
+		err := service.ZoneOperations.List(project, zone).Filter(filter).Pages(ctx, func(operations *compute.OperationList) error {
+			for _, operation := range operations.Items {
+				if interval, ok := gcpOperationToInterval(operation, instances, beginning, end); ok {
+					ret = append(ret, interval)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to list operations in zone %s: %w", zone, err)
+		}
+	}
+	return ret, nil
+}
+
+// gcpOperationFilter restricts the operations of a zone to the interruptions of the cluster's instances in that zone
+// during the run, so that we do not page through every operation in the project.
+func gcpOperationFilter(project, zone string, instances map[string]string, beginning, end time.Time) string {
+	operationTypes := sets.NewString()
+	for operationType := range gcpOperationReasons {
+		operationTypes.Insert(fmt.Sprintf("(operationType = %q)", operationType))
+	}
+	targetLinks := sets.NewString()
+	for zoneAndInstance := range instances {
+		parts := strings.Split(zoneAndInstance, "/")
+		if parts[0] != zone {
+			continue
+		}
+		targetLink := fmt.Sprintf("https://www.googleapis.com/compute/v1/projects/%s/zones/%s/instances/%s", project, zone, parts[1])
+		targetLinks.Insert(fmt.Sprintf("(targetLink = %q)", targetLink))
+	}
+	return strings.Join([]string{
+		"(" + strings.Join(operationTypes.List(), " OR ") + ")",
+		"(" + strings.Join(targetLinks.List(), " OR ") + ")",
+		fmt.Sprintf("(insertTime >= %q)", beginning.UTC().Format(time.RFC3339)),
+		fmt.Sprintf("(insertTime <= %q)", end.UTC().Format(time.RFC3339)),
+	}, " AND ")
+}
+
+// gcpOperationToInterval converts an operation against one of the cluster's nodes during the run.
+func gcpOperationToInterval(operation *compute.Operation, instances map[string]string, beginning, end time.Time) (monitorapi.Interval, bool) {
+	reason, ok := gcpOperationReasons[operation.OperationType]
+	if !ok {
+		return monitorapi.Interval{}, false
+	}
+	nodeName, ok := instances[path.Base(operation.Zone)+"/"+path.Base(operation.TargetLink)]
+	if !ok {
+		return monitorapi.Interval{}, false
+	}
+	from, err := time.Parse(time.RFC3339, firstNonEmpty(operation.StartTime, operation.InsertTime))
+	if err != nil || from.Before(beginning) || from.After(end) {
+		return monitorapi.Interval{}, false
+	}
+	// an operation that is still running has no end time.
+	to := from
+	if len(operation.EndTime) > 0 {
+		if parsed, err := time.Parse(time.RFC3339, operation.EndTime); err == nil {
+			to = parsed
+		}
+	}
+
+	message := monitorapi.NewMessage().Reason(reason).
+		HumanMessage(fmt.Sprintf("%s %s", operation.OperationType, operation.StatusMessage))
+	return monitorapi.NewInterval(monitorapi.SourceCloudNodeInterruption, monitorapi.Warning).
+		Locator(monitorapi.NewLocator().NodeFromName(nodeName)).
+		Message(message).
+		Display().
+		Build(from, to), true
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if len(value) > 0 {
+			return value
+		}
+	}
+	return ""
+}
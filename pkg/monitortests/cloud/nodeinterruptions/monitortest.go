@@ -0,0 +1,132 @@
+package nodeinterruptions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	configclient "github.com/openshift/client-go/config/clientset/versioned"
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	exutil "github.com/openshift/origin/test/extended/util"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+type nodeInterruptionCollector struct {
+	kubeClient         kubernetes.Interface
+	platformStatus     *configv1.PlatformStatus
+	notSupportedReason error
+}
+
+// NewNodeInterruptionCollector records the instance lifecycle events the cloud provider raised against the cluster's
+// nodes, preemption, host maintenance, live migration, and host errors, so that they are not blamed on the cluster.
+// Only GCP and Azure are supported.  AWS is deliberately left out: its instance status and spot interruption events
+// need the EC2 API, which is not vendored, and spot terminations on AWS are already tracked from the nodes and machines
+// by the spot-node-tracker.
+func NewNodeInterruptionCollector() monitortestframework.MonitorTest {
+	return &nodeInterruptionCollector{}
+}
+
+func (*nodeInterruptionCollector) Describe() monitortestframework.MonitorTestDescription {
+	return monitortestframework.MonitorTestDescription{
+		Platforms:        []string{"gcp", "azure"},
+		WatchedResources: []string{"infrastructures.config.openshift.io", "nodes"},
+	}
+}
+
+func (w *nodeInterruptionCollector) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	kubeClient, err := kubernetes.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return err
+	}
+	isMicroShift, err := exutil.IsMicroShiftCluster(kubeClient)
+	if err != nil {
+		return fmt.Errorf("unable to determine if cluster is MicroShift: %v", err)
+	}
+	if isMicroShift {
+		w.notSupportedReason = &monitortestframework.NotSupportedError{
+			Reason: "platform MicroShift not supported",
+		}
+		return w.notSupportedReason
+	}
+
+	configClient, err := configclient.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return err
+	}
+	infra, err := configClient.ConfigV1().Infrastructures().Get(ctx, "cluster", metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if infra.Status.PlatformStatus == nil {
+		w.notSupportedReason = &monitortestframework.NotSupportedError{Reason: "platform status is not set"}
+		return w.notSupportedReason
+	}
+	switch infra.Status.PlatformStatus.Type {
+	case configv1.GCPPlatformType, configv1.AzurePlatformType:
+	case configv1.AWSPlatformType:
+		w.notSupportedReason = &monitortestframework.NotSupportedError{
+			Reason: "platform AWS not supported: EC2 instance events are not collected, spot-node-tracker covers spot terminations",
+		}
+		return w.notSupportedReason
+	default:
+		w.notSupportedReason = &monitortestframework.NotSupportedError{
+			Reason: fmt.Sprintf("platform %s not supported", infra.Status.PlatformStatus.Type),
+		}
+		return w.notSupportedReason
+	}
+
+	w.kubeClient = kubeClient
+	w.platformStatus = infra.Status.PlatformStatus
+	return nil
+}
+
+// CollectData does not fail on cloud errors, the events only help explain other failures.
+func (w *nodeInterruptionCollector) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	if w.notSupportedReason != nil {
+		return nil, nil, w.notSupportedReason
+	}
+	nodes, err := w.kubeClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var intervals monitorapi.Intervals
+	switch w.platformStatus.Type {
+	case configv1.GCPPlatformType:
+		project := ""
+		if w.platformStatus.GCP != nil {
+			project = w.platformStatus.GCP.ProjectID
+		}
+		intervals, err = fetchGCPInterruptions(ctx, project, nodes.Items, beginning, end)
+	case configv1.AzurePlatformType:
+		intervals, err = fetchAzureInterruptions(ctx, nodes.Items, beginning, end)
+	}
+	if err != nil {
+		logrus.WithError(err).Error("failed to fetch cloud node interruptions")
+		return nil, nil, &monitortestframework.FlakeError{Err: err}
+	}
+	logrus.Infof("found %d cloud node interruptions", len(intervals))
+	return intervals, nil, nil
+}
+
+func (*nodeInterruptionCollector) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	return nil, nil
+}
+
+func (*nodeInterruptionCollector) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	return nil, nil
+}
+
+func (*nodeInterruptionCollector) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	return nil
+}
+
+func (*nodeInterruptionCollector) Cleanup(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,103 @@
+package nodeinterruptions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/monitor/armmonitor"
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/compute/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func node(name, providerID string) corev1.Node {
+	return corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: corev1.NodeSpec{ProviderID: providerID}}
+}
+
+func TestGCPOperationToInterval(t *testing.T) {
+	beginning := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	end := beginning.Add(time.Hour)
+	instances := gcpInstances([]corev1.Node{
+		node("ci-worker-a", "gce://project/us-east1-b/ci-worker-a"),
+		node("kind", "kind://docker/kind/kind-control-plane"),
+	})
+	assert.Equal(t, map[string]string{"us-east1-b/ci-worker-a": "ci-worker-a"}, instances)
+
+	operation := func(operationType, instance, start string) *compute.Operation {
+		return &compute.Operation{
+			OperationType: operationType,
+			Zone:          "https://www.googleapis.com/compute/v1/projects/project/zones/us-east1-b",
+			TargetLink:    "https://www.googleapis.com/compute/v1/projects/project/zones/us-east1-b/instances/" + instance,
+			StartTime:     start,
+			EndTime:       "2024-03-01T10:05:30Z",
+			StatusMessage: "Instance was preempted.",
+		}
+	}
+
+	interval, ok := gcpOperationToInterval(operation("compute.instances.preempted", "ci-worker-a", "2024-03-01T10:05:00Z"), instances, beginning, end)
+	require.True(t, ok)
+	assert.Equal(t, monitorapi.CloudNodePreemptedReason, interval.Message.Reason)
+	assert.Equal(t, "ci-worker-a", interval.Locator.Keys[monitorapi.LocatorNodeKey])
+	assert.Equal(t, beginning.Add(5*time.Minute), interval.From)
+	assert.Equal(t, beginning.Add(5*time.Minute+30*time.Second), interval.To)
+
+	_, ok = gcpOperationToInterval(operation("compute.instances.insert", "ci-worker-a", "2024-03-01T10:05:00Z"), instances, beginning, end)
+	assert.False(t, ok, "user operations are not interruptions")
+	_, ok = gcpOperationToInterval(operation("compute.instances.preempted", "other-cluster", "2024-03-01T10:05:00Z"), instances, beginning, end)
+	assert.False(t, ok, "instances of other clusters are ignored")
+	_, ok = gcpOperationToInterval(operation("compute.instances.preempted", "ci-worker-a", "2024-03-01T09:05:00Z"), instances, beginning, end)
+	assert.False(t, ok, "operations before the run are ignored")
+}
+
+func TestGCPOperationFilter(t *testing.T) {
+	beginning := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	instances := gcpInstances([]corev1.Node{
+		node("ci-worker-a", "gce://project/us-east1-b/ci-worker-a"),
+		node("ci-worker-b", "gce://project/us-east1-b/ci-worker-b"),
+		node("ci-worker-c", "gce://project/us-east1-c/ci-worker-c"),
+	})
+
+	filter := gcpOperationFilter("project", "us-east1-b", instances, beginning, beginning.Add(time.Hour))
+	assert.Contains(t, filter, `(operationType = "compute.instances.preempted")`)
+	assert.Contains(t, filter, `((targetLink = "https://www.googleapis.com/compute/v1/projects/project/zones/us-east1-b/instances/ci-worker-a") OR (targetLink = "https://www.googleapis.com/compute/v1/projects/project/zones/us-east1-b/instances/ci-worker-b"))`)
+	assert.NotContains(t, filter, "ci-worker-c", "instances of other zones are not listed")
+	assert.Contains(t, filter, `(insertTime >= "2024-03-01T10:00:00Z") AND (insertTime <= "2024-03-01T11:00:00Z")`)
+}
+
+func TestAzureEventToInterval(t *testing.T) {
+	resourceID := "/subscriptions/sub/resourceGroups/ci-rg/providers/Microsoft.Compute/virtualMachines/ci-worker-a"
+	virtualMachines := azureVirtualMachines([]corev1.Node{node("ci-worker-a", "azure://"+resourceID)})
+	assert.Equal(t, "sub", azureResourceIDPart(resourceID, "subscriptions"))
+	assert.Equal(t, "ci-rg", azureResourceIDPart(resourceID, "resourcegroups"))
+
+	at := time.Date(2024, 3, 1, 10, 5, 0, 0, time.UTC)
+	event := func(category, cause, title string) *armmonitor.EventData {
+		// the activity log does not preserve the case of resource IDs.
+		upperResourceID := "/SUBSCRIPTIONS/SUB/RESOURCEGROUPS/CI-RG/PROVIDERS/MICROSOFT.COMPUTE/VIRTUALMACHINES/CI-WORKER-A"
+		return &armmonitor.EventData{
+			Category:       &armmonitor.LocalizableString{Value: &category},
+			ResourceID:     &upperResourceID,
+			EventTimestamp: &at,
+			Properties:     map[string]*string{"cause": &cause, "title": &title},
+		}
+	}
+
+	interval, ok := azureEventToInterval(event("ResourceHealth", "PlatformInitiated", "Virtual machine is stopping due to Spot eviction"), virtualMachines)
+	require.True(t, ok)
+	assert.Equal(t, monitorapi.CloudNodePreemptedReason, interval.Message.Reason)
+	assert.Equal(t, "ci-worker-a", interval.Locator.Keys[monitorapi.LocatorNodeKey])
+	assert.Equal(t, at, interval.From)
+	assert.True(t, interval.Display)
+
+	interval, ok = azureEventToInterval(event("ResourceHealth", "PlatformInitiated", "We're sorry, your virtual machine is unavailable"), virtualMachines)
+	require.True(t, ok)
+	assert.Equal(t, monitorapi.CloudNodeUnavailableReason, interval.Message.Reason)
+
+	_, ok = azureEventToInterval(event("ResourceHealth", "UserInitiated", "Stopped by user"), virtualMachines)
+	assert.False(t, ok, "user initiated events are not interruptions")
+	_, ok = azureEventToInterval(event("Administrative", "PlatformInitiated", "write"), virtualMachines)
+	assert.False(t, ok)
+}
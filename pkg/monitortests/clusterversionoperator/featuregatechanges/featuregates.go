@@ -0,0 +1,123 @@
+package featuregatechanges
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+const (
+	gateEnabled  = "Enabled"
+	gateDisabled = "Disabled"
+	// gateAbsent is the state of a gate a version no longer, or did not yet, list.
+	gateAbsent = "Absent"
+)
+
+var featureGateLocator = monitorapi.NewLocator().ForGVR(configv1.GroupVersion.WithResource("featuregates"), "", "cluster")
+
+// GateStates are the states of the feature gates of the cluster, by payload version and gate name.
+type GateStates map[string]map[string]string
+
+func gateStatesOf(featureGate *configv1.FeatureGate) GateStates {
+	ret := GateStates{}
+	for _, details := range featureGate.Status.FeatureGates {
+		states := map[string]string{}
+		for _, gate := range details.Enabled {
+			states[string(gate.Name)] = gateEnabled
+		}
+		for _, gate := range details.Disabled {
+			states[string(gate.Name)] = gateDisabled
+		}
+		ret[details.Version] = states
+	}
+	return ret
+}
+
+// GateChange is a feature gate changing state for a payload version.
+type GateChange struct {
+	At      time.Time
+	Version string
+	Gate    string
+	From    string
+	To      string
+}
+
+// gateChanges returns the gates that changed state between two observations.  Versions appearing are upgrades
+// adding the gates of their payload and versions disappearing are old payloads pruned after an upgrade, neither
+// changes the gates of a version.
+func gateChanges(at time.Time, previous, current GateStates) []GateChange {
+	ret := []GateChange{}
+	for version, currentStates := range current {
+		previousStates, ok := previous[version]
+		if !ok {
+			continue
+		}
+		for gate, state := range currentStates {
+			if previousState, ok := previousStates[gate]; !ok || previousState != state {
+				from := gateAbsent
+				if ok {
+					from = previousState
+				}
+				ret = append(ret, GateChange{At: at, Version: version, Gate: gate, From: from, To: state})
+			}
+		}
+		for gate, previousState := range previousStates {
+			if _, ok := currentStates[gate]; !ok {
+				ret = append(ret, GateChange{At: at, Version: version, Gate: gate, From: previousState, To: gateAbsent})
+			}
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Version != ret[j].Version {
+			return ret[i].Version < ret[j].Version
+		}
+		return ret[i].Gate < ret[j].Gate
+	})
+	return ret
+}
+
+func featureSetOf(featureGate *configv1.FeatureGate) string {
+	if len(featureGate.Spec.FeatureSet) == 0 {
+		return "Default"
+	}
+	return string(featureGate.Spec.FeatureSet)
+}
+
+func gateChangeInterval(change GateChange) monitorapi.Interval {
+	return monitorapi.NewInterval(monitorapi.SourceFeatureGates, monitorapi.Warning).
+		Locator(featureGateLocator).
+		Message(monitorapi.NewMessage().Reason(monitorapi.FeatureGateChangedReason).
+			WithAnnotation(monitorapi.AnnotationFeatureGate, change.Gate).
+			WithAnnotation(monitorapi.AnnotationPayloadVersion, change.Version).
+			HumanMessagef("feature gate %s of version %s changed from %s to %s", change.Gate, change.Version, change.From, change.To)).
+		Display().
+		Build(change.At, change.At)
+}
+
+func featureSetChangeInterval(at time.Time, from, to string) monitorapi.Interval {
+	return monitorapi.NewInterval(monitorapi.SourceFeatureGates, monitorapi.Warning).
+		Locator(featureGateLocator).
+		Message(monitorapi.NewMessage().Reason(monitorapi.FeatureSetChangedReason).
+			HumanMessagef("feature set changed from %s to %s", from, to)).
+		Display().
+		Build(at, at)
+}
+
+// unexpectedChanges describes the gate changes of the run, one per line.
+func unexpectedChanges(intervals monitorapi.Intervals) []string {
+	ret := []string{}
+	for _, interval := range intervals {
+		if interval.Source != monitorapi.SourceFeatureGates {
+			continue
+		}
+		switch interval.Message.Reason {
+		case monitorapi.FeatureGateChangedReason, monitorapi.FeatureSetChangedReason:
+			ret = append(ret, fmt.Sprintf("%s: %s", interval.From.UTC().Format(time.RFC3339), interval.Message.HumanMessage))
+		}
+	}
+	return ret
+}
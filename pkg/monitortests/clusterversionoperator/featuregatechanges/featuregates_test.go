@@ -0,0 +1,71 @@
+package featuregatechanges
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+func featureGate(featureSet configv1.FeatureSet, details ...configv1.FeatureGateDetails) *configv1.FeatureGate {
+	return &configv1.FeatureGate{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+		Spec: configv1.FeatureGateSpec{
+			FeatureGateSelection: configv1.FeatureGateSelection{FeatureSet: featureSet},
+		},
+		Status: configv1.FeatureGateStatus{FeatureGates: details},
+	}
+}
+
+func gates(version string, enabled []string, disabled []string) configv1.FeatureGateDetails {
+	ret := configv1.FeatureGateDetails{Version: version}
+	for _, name := range enabled {
+		ret.Enabled = append(ret.Enabled, configv1.FeatureGateAttributes{Name: configv1.FeatureGateName(name)})
+	}
+	for _, name := range disabled {
+		ret.Disabled = append(ret.Disabled, configv1.FeatureGateAttributes{Name: configv1.FeatureGateName(name)})
+	}
+	return ret
+}
+
+func TestObserve(t *testing.T) {
+	start := featureGate("", gates("4.17.0", []string{"GatewayAPI"}, []string{"NewOLM"}))
+	w := &featureGateChanges{featureSet: featureSetOf(start), start: gateStatesOf(start), last: gateStatesOf(start)}
+	now := time.Now()
+
+	// an upgrade adds the gates of its payload, which differ from those of the current version.
+	upgrade := featureGate("",
+		gates("4.17.0", []string{"GatewayAPI"}, []string{"NewOLM"}),
+		gates("4.18.0", []string{"GatewayAPI", "NewOLM"}, nil))
+	assert.Empty(t, w.observe(now, upgrade))
+	// the old version is pruned once the upgrade completes.
+	assert.Empty(t, w.observe(now, featureGate("", gates("4.18.0", []string{"GatewayAPI", "NewOLM"}, nil))))
+
+	flipped := featureGate(configv1.TechPreviewNoUpgrade, gates("4.18.0", []string{"NewOLM", "DynamicResourceAllocation"}, []string{"GatewayAPI"}))
+	intervals := w.observe(now.Add(time.Minute), flipped)
+	require.Len(t, intervals, 3)
+	assert.Equal(t, monitorapi.FeatureSetChangedReason, intervals[0].Message.Reason)
+	assert.Equal(t, "feature set changed from Default to TechPreviewNoUpgrade", intervals[0].Message.HumanMessage)
+	assert.Equal(t, "DynamicResourceAllocation", intervals[1].Message.Annotations[monitorapi.AnnotationFeatureGate])
+	assert.Equal(t, "feature gate DynamicResourceAllocation of version 4.18.0 changed from Absent to Enabled", intervals[1].Message.HumanMessage)
+	assert.Equal(t, "feature gate GatewayAPI of version 4.18.0 changed from Enabled to Disabled", intervals[2].Message.HumanMessage)
+	assert.Equal(t, "4.18.0", intervals[2].Message.Annotations[monitorapi.AnnotationPayloadVersion])
+	assert.Len(t, w.changes, 2)
+
+	junits, err := w.EvaluateTestsFromConstructedIntervals(context.TODO(), intervals)
+	require.NoError(t, err)
+	require.Len(t, junits, 1)
+	require.NotNil(t, junits[0].FailureOutput)
+	assert.Contains(t, junits[0].FailureOutput.Output, "GatewayAPI of version 4.18.0 changed from Enabled to Disabled")
+
+	junits, err = w.EvaluateTestsFromConstructedIntervals(context.TODO(), monitorapi.Intervals{})
+	require.NoError(t, err)
+	require.Len(t, junits, 1)
+	assert.Nil(t, junits[0].FailureOutput)
+}
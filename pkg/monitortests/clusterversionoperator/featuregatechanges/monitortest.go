@@ -0,0 +1,163 @@
+package featuregatechanges
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	configclient "github.com/openshift/client-go/config/clientset/versioned"
+	configinformers "github.com/openshift/client-go/config/informers/externalversions"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+)
+
+const testName = "[sig-arch] feature gates should not change during the run"
+
+// featureGateChanges snapshots the feature gates at the start of the run and records every gate that changes for a
+// payload version afterwards.  Gates changing under running tests silently changed their behavior before.
+type featureGateChanges struct {
+	notSupportedReason error
+	cancel             context.CancelFunc
+
+	lock            sync.Mutex
+	startFeatureSet string
+	start           GateStates
+	featureSet      string
+	last            GateStates
+	changes         []GateChange
+}
+
+// FeatureGates is written as the feature-gates artifact, the gates at the start of the run and their changes.
+type FeatureGates struct {
+	FeatureSet string
+	Start      GateStates
+	Changes    []GateChange
+}
+
+func NewFeatureGateChangeDetector() monitortestframework.MonitorTest {
+	return &featureGateChanges{}
+}
+
+func (*featureGateChanges) Describe() monitortestframework.MonitorTestDescription {
+	return monitortestframework.MonitorTestDescription{
+		WatchedResources: []string{"featuregates.config.openshift.io"},
+		JUnits:           []string{testName},
+	}
+}
+
+func (w *featureGateChanges) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	configClient, err := configclient.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return err
+	}
+	featureGate, err := configClient.ConfigV1().FeatureGates().Get(ctx, "cluster", metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		w.notSupportedReason = &monitortestframework.NotSupportedError{Reason: "featuregate/cluster not found"}
+		return w.notSupportedReason
+	}
+	if err != nil {
+		return err
+	}
+	w.startFeatureSet, w.featureSet = featureSetOf(featureGate), featureSetOf(featureGate)
+	w.start = gateStatesOf(featureGate)
+	w.last = w.start
+
+	// the gates are watched until CollectData, not for as long as the StartCollection context lives.
+	watchCtx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	configInformers := configinformers.NewSharedInformerFactory(configClient, 0)
+	if _, err := configInformers.Config().V1().FeatureGates().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(_, obj interface{}) {
+			featureGate, ok := obj.(*configv1.FeatureGate)
+			if !ok || featureGate.Name != "cluster" {
+				return
+			}
+			recorder.AddIntervals(w.observe(time.Now(), featureGate)...)
+		},
+	}); err != nil {
+		return err
+	}
+	configInformers.Start(watchCtx.Done())
+	return nil
+}
+
+// observe returns the intervals of the changes since the previous observation.
+func (w *featureGateChanges) observe(at time.Time, featureGate *configv1.FeatureGate) monitorapi.Intervals {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	ret := monitorapi.Intervals{}
+	if featureSet := featureSetOf(featureGate); featureSet != w.featureSet {
+		ret = append(ret, featureSetChangeInterval(at, w.featureSet, featureSet))
+		w.featureSet = featureSet
+	}
+	current := gateStatesOf(featureGate)
+	for _, change := range gateChanges(at, w.last, current) {
+		w.changes = append(w.changes, change)
+		ret = append(ret, gateChangeInterval(change))
+	}
+	w.last = current
+	return ret
+}
+
+func (w *featureGateChanges) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	return nil, nil, w.notSupportedReason
+}
+
+func (w *featureGateChanges) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	return nil, w.notSupportedReason
+}
+
+func (w *featureGateChanges) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	if w.notSupportedReason != nil {
+		return nil, w.notSupportedReason
+	}
+	changes := unexpectedChanges(finalIntervals)
+	if len(changes) == 0 {
+		return []*junitapi.JUnitTestCase{{Name: testName}}, nil
+	}
+	return []*junitapi.JUnitTestCase{
+		{
+			Name: testName,
+			FailureOutput: &junitapi.FailureOutput{
+				Output: fmt.Sprintf("feature gates changed while tests were running, the tests after each change ran with different gates:\n\n%s",
+					strings.Join(changes, "\n")),
+			},
+		},
+	}, nil
+}
+
+func (w *featureGateChanges) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	if w.notSupportedReason != nil {
+		return w.notSupportedReason
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	jsonContent, err := json.MarshalIndent(FeatureGates{FeatureSet: w.startFeatureSet, Start: w.start, Changes: w.changes}, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(storageDir, fmt.Sprintf("feature-gates%s.json", timeSuffix)), jsonContent, 0644)
+}
+
+func (w *featureGateChanges) Cleanup(ctx context.Context) error {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package updaterisks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	configclient "github.com/openshift/client-go/config/clientset/versioned"
+	configinformers "github.com/openshift/client-go/config/informers/externalversions"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+)
+
+// updateRiskRecorder records the update history of the ClusterVersion, the conditional update risks of the updates
+// available to the cluster and the windows where updates were blocked by Upgradeable=False, so that the upgrade
+// path a CI job took can be audited.
+type updateRiskRecorder struct {
+	configClient configclient.Interface
+	cancel       context.CancelFunc
+
+	lock    sync.Mutex
+	tracker *updateTracker
+	summary *ClusterVersionUpdates
+}
+
+// ClusterVersionUpdates is written as the cluster-version-updates artifact.
+type ClusterVersionUpdates struct {
+	History            []configv1.UpdateHistory
+	Decisions          []Decision
+	UpgradeableFalse   []UpgradeableWindow
+	NotRecommended     []RiskWindow
+	AvailableUpdates   []configv1.Release
+	ConditionalUpdates []configv1.ConditionalUpdate
+}
+
+func NewUpdateRiskRecorder() monitortestframework.MonitorTest {
+	return &updateRiskRecorder{tracker: newUpdateTracker()}
+}
+
+func (*updateRiskRecorder) Describe() monitortestframework.MonitorTestDescription {
+	return monitortestframework.MonitorTestDescription{
+		WatchedResources: []string{"clusterversions.config.openshift.io"},
+	}
+}
+
+func (w *updateRiskRecorder) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	var err error
+	w.configClient, err = configclient.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return err
+	}
+
+	// the ClusterVersion is watched until CollectData, not for as long as the StartCollection context lives.
+	watchCtx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	configInformers := configinformers.NewSharedInformerFactoryWithOptions(w.configClient, 0,
+		configinformers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = "metadata.name=version"
+		}))
+	observe := func(obj interface{}) {
+		if clusterVersion, ok := obj.(*configv1.ClusterVersion); ok {
+			w.observe(time.Now(), clusterVersion)
+		}
+	}
+	if _, err := configInformers.Config().V1().ClusterVersions().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    observe,
+		UpdateFunc: func(_, obj interface{}) { observe(obj) },
+	}); err != nil {
+		return err
+	}
+	configInformers.Start(watchCtx.Done())
+	return nil
+}
+
+func (w *updateRiskRecorder) observe(at time.Time, clusterVersion *configv1.ClusterVersion) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.tracker.observe(at, clusterVersion)
+}
+
+func (w *updateRiskRecorder) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	if w.configClient == nil {
+		return nil, nil, nil
+	}
+	clusterVersion, err := w.configClient.ConfigV1().ClusterVersions().Get(ctx, "version", metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	w.observe(end, clusterVersion)
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	upgradeableFalse, notRecommended := w.tracker.windows(end)
+	w.summary = &ClusterVersionUpdates{
+		History:            clusterVersion.Status.History,
+		Decisions:          decisions(clusterVersion.Status.History, beginning, upgradeableFalse, notRecommended),
+		UpgradeableFalse:   upgradeableFalse,
+		NotRecommended:     notRecommended,
+		AvailableUpdates:   clusterVersion.Status.AvailableUpdates,
+		ConditionalUpdates: clusterVersion.Status.ConditionalUpdates,
+	}
+	ret := historyIntervals(clusterVersion, beginning, end)
+	ret = append(ret, windowIntervals(clusterVersion, upgradeableFalse, notRecommended)...)
+	return ret, nil, nil
+}
+
+func (*updateRiskRecorder) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	return nil, nil
+}
+
+func (*updateRiskRecorder) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	return nil, nil
+}
+
+func (w *updateRiskRecorder) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	if w.summary == nil {
+		return nil
+	}
+	jsonContent, err := json.MarshalIndent(w.summary, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(storageDir, fmt.Sprintf("cluster-version-updates%s.json", timeSuffix)), jsonContent, 0644)
+}
+
+func (w *updateRiskRecorder) Cleanup(ctx context.Context) error {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+package updaterisks
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+// recommendedCondition is the condition of a conditional update saying whether it is recommended for the cluster.
+const recommendedCondition = "Recommended"
+
+// UpgradeableWindow is a period where the ClusterVersion reported Upgradeable=False.
+type UpgradeableWindow struct {
+	From    time.Time
+	To      time.Time
+	Reason  string
+	Message string
+}
+
+// RiskWindow is a period where an update available to the cluster was not recommended.
+type RiskWindow struct {
+	From    time.Time
+	To      time.Time
+	Version string
+	Risks   []string
+	Reason  string
+	Message string
+}
+
+// updateTracker follows the Upgradeable condition and the conditional updates of the ClusterVersion.
+type updateTracker struct {
+	upgradeable       *UpgradeableWindow
+	upgradeableFalse  []UpgradeableWindow
+	notRecommended    map[string]*RiskWindow
+	notRecommendedLog []RiskWindow
+}
+
+func newUpdateTracker() *updateTracker {
+	return &updateTracker{notRecommended: map[string]*RiskWindow{}}
+}
+
+func (t *updateTracker) observe(at time.Time, clusterVersion *configv1.ClusterVersion) {
+	t.observeUpgradeable(at, clusterVersion)
+	t.observeConditionalUpdates(at, clusterVersion)
+}
+
+func (t *updateTracker) observeUpgradeable(at time.Time, clusterVersion *configv1.ClusterVersion) {
+	var condition *configv1.ClusterOperatorStatusCondition
+	for i := range clusterVersion.Status.Conditions {
+		if clusterVersion.Status.Conditions[i].Type == configv1.OperatorUpgradeable {
+			condition = &clusterVersion.Status.Conditions[i]
+		}
+	}
+	blocked := condition != nil && condition.Status == configv1.ConditionFalse
+	// a new reason is a new window, the blocker changed.
+	if t.upgradeable != nil && (!blocked || condition.Reason != t.upgradeable.Reason) {
+		t.upgradeable.To = at
+		t.upgradeableFalse = append(t.upgradeableFalse, *t.upgradeable)
+		t.upgradeable = nil
+	}
+	if !blocked {
+		return
+	}
+	if t.upgradeable == nil {
+		t.upgradeable = &UpgradeableWindow{From: at, Reason: condition.Reason}
+	}
+	t.upgradeable.Message = condition.Message
+}
+
+func (t *updateTracker) observeConditionalUpdates(at time.Time, clusterVersion *configv1.ClusterVersion) {
+	current := map[string]RiskWindow{}
+	for _, update := range clusterVersion.Status.ConditionalUpdates {
+		recommended := meta.FindStatusCondition(update.Conditions, recommendedCondition)
+		if recommended == nil || recommended.Status == "True" {
+			continue
+		}
+		window := RiskWindow{Version: update.Release.Version, Reason: recommended.Reason, Message: recommended.Message}
+		for _, risk := range update.Risks {
+			window.Risks = append(window.Risks, risk.Name)
+		}
+		sort.Strings(window.Risks)
+		current[update.Release.Version] = window
+	}
+
+	for version, open := range t.notRecommended {
+		// different risks are a new window, the assessment of the update changed.
+		if window, ok := current[version]; !ok || strings.Join(window.Risks, ",") != strings.Join(open.Risks, ",") {
+			open.To = at
+			t.notRecommendedLog = append(t.notRecommendedLog, *open)
+			delete(t.notRecommended, version)
+		}
+	}
+	for version, window := range current {
+		open, ok := t.notRecommended[version]
+		if !ok {
+			window.From = at
+			t.notRecommended[version] = &window
+			continue
+		}
+		open.Reason, open.Message = window.Reason, window.Message
+	}
+}
+
+// windows returns the windows of the run, those still open end at end.
+func (t *updateTracker) windows(end time.Time) ([]UpgradeableWindow, []RiskWindow) {
+	upgradeableFalse := append([]UpgradeableWindow{}, t.upgradeableFalse...)
+	if t.upgradeable != nil {
+		open := *t.upgradeable
+		open.To = end
+		upgradeableFalse = append(upgradeableFalse, open)
+	}
+	notRecommended := append([]RiskWindow{}, t.notRecommendedLog...)
+	for _, window := range t.notRecommended {
+		open := *window
+		open.To = end
+		notRecommended = append(notRecommended, open)
+	}
+	sort.SliceStable(notRecommended, func(i, j int) bool {
+		if !notRecommended[i].From.Equal(notRecommended[j].From) {
+			return notRecommended[i].From.Before(notRecommended[j].From)
+		}
+		return notRecommended[i].Version < notRecommended[j].Version
+	})
+	return upgradeableFalse, notRecommended
+}
+
+// Decision is why an update of the run was allowed: whether it was blocked or recommended when it started and
+// which risks were accepted to start it anyway.
+type Decision struct {
+	Version       string
+	Started       time.Time
+	State         configv1.UpdateState
+	Verified      bool
+	AcceptedRisks string `json:",omitempty"`
+	// UpgradeableFalse is the reason of the Upgradeable=False condition when the update started.
+	UpgradeableFalse string `json:",omitempty"`
+	// NotRecommendedRisks are the risks of the update that were not accepted when it started.
+	NotRecommendedRisks []string `json:",omitempty"`
+}
+
+func decisions(history []configv1.UpdateHistory, beginning time.Time, upgradeableFalse []UpgradeableWindow, notRecommended []RiskWindow) []Decision {
+	ret := []Decision{}
+	// the history is most recent first.
+	for i := len(history) - 1; i >= 0; i-- {
+		entry := history[i]
+		if entry.StartedTime.Time.Before(beginning) {
+			continue
+		}
+		decision := Decision{
+			Version:       entry.Version,
+			Started:       entry.StartedTime.Time,
+			State:         entry.State,
+			Verified:      entry.Verified,
+			AcceptedRisks: entry.AcceptedRisks,
+		}
+		for _, window := range upgradeableFalse {
+			if within(entry.StartedTime.Time, window.From, window.To) {
+				decision.UpgradeableFalse = window.Reason
+			}
+		}
+		for _, window := range notRecommended {
+			if window.Version == entry.Version && within(entry.StartedTime.Time, window.From, window.To) {
+				decision.NotRecommendedRisks = window.Risks
+			}
+		}
+		ret = append(ret, decision)
+	}
+	return ret
+}
+
+func within(at, from, to time.Time) bool {
+	return !at.Before(from) && !at.After(to)
+}
+
+func historyIntervals(clusterVersion *configv1.ClusterVersion, beginning, end time.Time) monitorapi.Intervals {
+	locator := monitorapi.NewLocator().ClusterVersion(clusterVersion)
+	ret := monitorapi.Intervals{}
+	for _, entry := range clusterVersion.Status.History {
+		to := end
+		if entry.CompletionTime != nil {
+			to = entry.CompletionTime.Time
+		}
+		if to.Before(beginning) {
+			continue
+		}
+		message := fmt.Sprintf("update to %s %s, verified=%t", versionOrImage(entry), entry.State, entry.Verified)
+		if len(entry.AcceptedRisks) > 0 {
+			message = fmt.Sprintf("%s, accepted risks: %s", message, entry.AcceptedRisks)
+		}
+		ret = append(ret, monitorapi.NewInterval(monitorapi.SourceClusterVersionUpdates, monitorapi.Info).
+			Locator(locator).
+			Message(monitorapi.NewMessage().Reason(monitorapi.UpdateHistoryReason).
+				WithAnnotation(monitorapi.AnnotationToVersion, versionOrImage(entry)).
+				HumanMessage(message)).
+			Display().
+			Build(entry.StartedTime.Time, to))
+	}
+	return ret
+}
+
+func windowIntervals(clusterVersion *configv1.ClusterVersion, upgradeableFalse []UpgradeableWindow, notRecommended []RiskWindow) monitorapi.Intervals {
+	locator := monitorapi.NewLocator().ClusterVersion(clusterVersion)
+	ret := monitorapi.Intervals{}
+	for _, window := range upgradeableFalse {
+		ret = append(ret, monitorapi.NewInterval(monitorapi.SourceClusterVersionUpdates, monitorapi.Warning).
+			Locator(locator).
+			Message(monitorapi.NewMessage().Reason(monitorapi.UpgradeableFalseReason).
+				HumanMessagef("Upgradeable=False: %s: %s", window.Reason, window.Message)).
+			Display().
+			Build(window.From, window.To))
+	}
+	for _, window := range notRecommended {
+		ret = append(ret, monitorapi.NewInterval(monitorapi.SourceClusterVersionUpdates, monitorapi.Info).
+			Locator(locator).
+			Message(monitorapi.NewMessage().Reason(monitorapi.ConditionalUpdateNotRecommendedReason).
+				WithAnnotation(monitorapi.AnnotationToVersion, window.Version).
+				WithAnnotation(monitorapi.AnnotationRisks, strings.Join(window.Risks, ",")).
+				HumanMessagef("update to %s not recommended: %s", window.Version, window.Message)).
+			Build(window.From, window.To))
+	}
+	return ret
+}
+
+func versionOrImage(entry configv1.UpdateHistory) string {
+	if len(entry.Version) == 0 {
+		return entry.Image
+	}
+	return entry.Version
+}
@@ -0,0 +1,82 @@
+package updaterisks
+
+import (
+	"testing"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+func clusterVersion(upgradeable *configv1.ClusterOperatorStatusCondition, conditionalUpdates ...configv1.ConditionalUpdate) *configv1.ClusterVersion {
+	ret := &configv1.ClusterVersion{ObjectMeta: metav1.ObjectMeta{Name: "version"}}
+	if upgradeable != nil {
+		ret.Status.Conditions = append(ret.Status.Conditions, *upgradeable)
+	}
+	ret.Status.ConditionalUpdates = conditionalUpdates
+	return ret
+}
+
+func upgradeableFalse(reason string) *configv1.ClusterOperatorStatusCondition {
+	return &configv1.ClusterOperatorStatusCondition{Type: configv1.OperatorUpgradeable, Status: configv1.ConditionFalse, Reason: reason, Message: reason + " blocks minor updates"}
+}
+
+func notRecommended(version string, risks ...string) configv1.ConditionalUpdate {
+	ret := configv1.ConditionalUpdate{
+		Release:    configv1.Release{Version: version},
+		Conditions: []metav1.Condition{{Type: recommendedCondition, Status: metav1.ConditionFalse, Reason: "MultipleReasons", Message: "risky"}},
+	}
+	for _, risk := range risks {
+		ret.Risks = append(ret.Risks, configv1.ConditionalUpdateRisk{Name: risk})
+	}
+	return ret
+}
+
+func TestUpdateTracker(t *testing.T) {
+	beginning := time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return beginning.Add(time.Duration(minutes) * time.Minute) }
+
+	tracker := newUpdateTracker()
+	tracker.observe(at(0), clusterVersion(nil, notRecommended("4.18.2", "AWSOldBootImages")))
+	tracker.observe(at(5), clusterVersion(upgradeableFalse("AdminAckRequired"), notRecommended("4.18.2", "AWSOldBootImages")))
+	// new risks of the same update start a new window.
+	tracker.observe(at(10), clusterVersion(upgradeableFalse("AdminAckRequired"), notRecommended("4.18.2", "AWSOldBootImages", "SDNRemoval")))
+	tracker.observe(at(15), clusterVersion(&configv1.ClusterOperatorStatusCondition{Type: configv1.OperatorUpgradeable, Status: configv1.ConditionTrue}))
+
+	upgradeable, risks := tracker.windows(at(30))
+	require.Len(t, upgradeable, 1)
+	assert.Equal(t, UpgradeableWindow{From: at(5), To: at(15), Reason: "AdminAckRequired", Message: "AdminAckRequired blocks minor updates"}, upgradeable[0])
+	require.Len(t, risks, 2)
+	assert.Equal(t, []string{"AWSOldBootImages"}, risks[0].Risks)
+	assert.Equal(t, at(0), risks[0].From)
+	assert.Equal(t, at(10), risks[0].To)
+	assert.Equal(t, []string{"AWSOldBootImages", "SDNRemoval"}, risks[1].Risks)
+	assert.Equal(t, at(15), risks[1].To)
+
+	history := []configv1.UpdateHistory{
+		{State: configv1.PartialUpdate, Version: "4.18.2", StartedTime: metav1.NewTime(at(12)), AcceptedRisks: "AWSOldBootImages, SDNRemoval"},
+		{State: configv1.CompletedUpdate, Version: "4.17.9", StartedTime: metav1.NewTime(at(-600)), CompletionTime: &metav1.Time{Time: at(-540)}, Verified: true},
+	}
+	decided := decisions(history, beginning, upgradeable, risks)
+	require.Len(t, decided, 1, "updates started before the run are not decisions of the run")
+	assert.Equal(t, "4.18.2", decided[0].Version)
+	assert.Equal(t, "AdminAckRequired", decided[0].UpgradeableFalse)
+	assert.Equal(t, []string{"AWSOldBootImages", "SDNRemoval"}, decided[0].NotRecommendedRisks)
+
+	cv := clusterVersion(nil)
+	cv.Status.History = history
+	intervals := historyIntervals(cv, beginning, at(30))
+	require.Len(t, intervals, 1, "updates completed before the run are not shown")
+	assert.Equal(t, monitorapi.UpdateHistoryReason, intervals[0].Message.Reason)
+	assert.Equal(t, at(30), intervals[0].To)
+	assert.Equal(t, "update to 4.18.2 Partial, verified=false, accepted risks: AWSOldBootImages, SDNRemoval", intervals[0].Message.HumanMessage)
+
+	intervals = windowIntervals(cv, upgradeable, risks)
+	require.Len(t, intervals, 3)
+	assert.Equal(t, monitorapi.UpgradeableFalseReason, intervals[0].Message.Reason)
+	assert.Equal(t, "AWSOldBootImages,SDNRemoval", intervals[2].Message.Annotations[monitorapi.AnnotationRisks])
+}
@@ -0,0 +1,90 @@
+package upgradehops
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	configclient "github.com/openshift/client-go/config/clientset/versioned"
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/monitortestlibrary/platformidentification"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+// upgradeHopRecorder records an UpgradeHop interval for every upgrade performed during the run, so that chained
+// upgrades (EUS-to-EUS) can be evaluated one hop at a time.
+type upgradeHopRecorder struct {
+	configClient configclient.Interface
+}
+
+func NewUpgradeHopRecorder() monitortestframework.MonitorTest {
+	return &upgradeHopRecorder{}
+}
+
+func (w *upgradeHopRecorder) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	var err error
+	w.configClient, err = configclient.NewForConfig(adminRESTConfig)
+	return err
+}
+
+func (w *upgradeHopRecorder) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	if w.configClient == nil {
+		return nil, nil, nil
+	}
+
+	clusterVersion, err := w.configClient.ConfigV1().ClusterVersions().Get(ctx, "version", metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return hopIntervals(clusterVersion, platformidentification.UpgradeHopsFromHistory(clusterVersion.Status.History, beginning), end), nil, nil
+}
+
+func hopIntervals(clusterVersion *configv1.ClusterVersion, hops []platformidentification.UpgradeHop, end time.Time) monitorapi.Intervals {
+	ret := monitorapi.Intervals{}
+	for _, hop := range hops {
+		to := hop.To
+		level := monitorapi.Info
+		if to.IsZero() {
+			to = end
+			level = monitorapi.Warning
+		}
+		ret = append(ret,
+			monitorapi.NewInterval(monitorapi.SourceUpgradeHop, level).
+				Locator(monitorapi.NewLocator().ClusterVersion(clusterVersion)).
+				Message(monitorapi.NewMessage().Reason(monitorapi.UpgradeHopReason).
+					WithAnnotation(monitorapi.AnnotationUpgradeHop, strconv.Itoa(hop.Index)).
+					WithAnnotation(monitorapi.AnnotationFromVersion, hop.FromVersion).
+					WithAnnotation(monitorapi.AnnotationToVersion, hop.ToVersion).
+					HumanMessage(fmt.Sprintf("upgrade %d of %d from %s to %s", hop.Index, len(hops), hop.FromVersion, hop.ToVersion))).
+				Display().
+				Build(hop.From, to),
+		)
+	}
+	return ret
+}
+
+func (*upgradeHopRecorder) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	return nil, nil
+}
+
+func (*upgradeHopRecorder) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	return nil, nil
+}
+
+func (*upgradeHopRecorder) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	return nil
+}
+
+func (*upgradeHopRecorder) Cleanup(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,50 @@
+package upgradehops
+
+import (
+	"testing"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestlibrary/platformidentification"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestHopIntervals(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(5 * time.Hour)
+	completed := func(d time.Duration) *metav1.Time {
+		ret := metav1.NewTime(start.Add(d))
+		return &ret
+	}
+	clusterVersion := &configv1.ClusterVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: "version"},
+		Status: configv1.ClusterVersionStatus{
+			// newest first
+			History: []configv1.UpdateHistory{
+				{Version: "4.16.0", StartedTime: metav1.NewTime(start.Add(3 * time.Hour))},
+				{Version: "4.15.2", StartedTime: metav1.NewTime(start.Add(2 * time.Hour)), CompletionTime: completed(3 * time.Hour)},
+				{Version: "4.14.8", StartedTime: metav1.NewTime(start.Add(time.Hour)), CompletionTime: completed(2 * time.Hour)},
+				{Version: "4.14.1", StartedTime: metav1.NewTime(start.Add(-24 * time.Hour)), CompletionTime: completed(-23 * time.Hour)},
+			},
+		},
+	}
+
+	intervals := hopIntervals(clusterVersion, platformidentification.UpgradeHopsFromHistory(clusterVersion.Status.History, start), end)
+	require.Len(t, intervals, 3)
+	assert.Equal(t, monitorapi.Warning, intervals[2].Level, "an incomplete hop is a warning")
+	assert.Equal(t, end, intervals[2].To)
+
+	hops := platformidentification.UpgradeHopsFromIntervals(intervals)
+	require.Len(t, hops, 3)
+	assert.Equal(t, platformidentification.UpgradeHop{
+		Index: 1, FromVersion: "4.14.1", ToVersion: "4.14.8", From: start.Add(time.Hour), To: start.Add(2 * time.Hour),
+	}, hops[0])
+	assert.Equal(t, "4.15.2", hops[2].FromVersion)
+	assert.Equal(t, "4.16.0", hops[2].ToVersion)
+
+	jobType := hops[1].JobType(platformidentification.JobType{Release: "4.16", FromRelease: "4.15", Platform: "aws"})
+	assert.Equal(t, platformidentification.JobType{Release: "4.15", FromRelease: "4.14", Platform: "aws"}, jobType)
+}
@@ -0,0 +1,138 @@
+package workloadrollouts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/monitortestlibrary/platformidentification"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	exutil "github.com/openshift/origin/test/extended/util"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+)
+
+// platformNamespacePrefix selects the workloads of the platform.
+const platformNamespacePrefix = "openshift-"
+
+type workloadRolloutTracker struct {
+	kubeInformers      informers.SharedInformerFactory
+	budgets            rolloutBudgets
+	notSupportedReason error
+
+	lock    sync.Mutex
+	tracker *rolloutTracker
+}
+
+// NewWorkloadRolloutTracker records every rollout of the deployments and daemonsets of the openshift-* namespaces,
+// from a new generation to the workload being fully available, and fails the rollouts slower than their historical
+// P99 or stuck, so that operator rollout hangs show without digging through operator logs.
+func NewWorkloadRolloutTracker() monitortestframework.MonitorTest {
+	return &workloadRolloutTracker{tracker: newRolloutTracker()}
+}
+
+func (w *workloadRolloutTracker) SetSharedInformers(kubeInformers informers.SharedInformerFactory) {
+	w.kubeInformers = kubeInformers
+}
+
+func (*workloadRolloutTracker) Describe() monitortestframework.MonitorTestDescription {
+	return monitortestframework.MonitorTestDescription{
+		WatchedResources: []string{"deployments.apps", "daemonsets.apps"},
+		JUnits:           []string{rolloutDurationTestName, rolloutStuckTestName},
+	}
+}
+
+func (w *workloadRolloutTracker) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	kubeClient, err := kubernetes.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return err
+	}
+	isMicroShift, err := exutil.IsMicroShiftCluster(kubeClient)
+	if err != nil {
+		return fmt.Errorf("unable to determine if cluster is MicroShift: %v", err)
+	}
+	if isMicroShift {
+		w.notSupportedReason = &monitortestframework.NotSupportedError{
+			Reason: "platform MicroShift not supported",
+		}
+		return w.notSupportedReason
+	}
+	jobType, err := platformidentification.GetJobType(ctx, adminRESTConfig)
+	if err != nil {
+		return err
+	}
+	w.budgets, err = newRolloutBudgets(historicalRolloutDurations, jobType.Platform)
+	if err != nil {
+		return err
+	}
+
+	if w.kubeInformers == nil {
+		w.kubeInformers = informers.NewSharedInformerFactory(kubeClient, 0)
+		defer w.kubeInformers.Start(ctx.Done())
+	}
+	observe := func(obj interface{}) {
+		var kind, namespace, name string
+		var generation int64
+		var complete bool
+		switch workload := obj.(type) {
+		case *appsv1.Deployment:
+			kind, namespace, name, generation, complete = deploymentKind, workload.Namespace, workload.Name, workload.Generation, deploymentComplete(workload)
+		case *appsv1.DaemonSet:
+			kind, namespace, name, generation, complete = daemonSetKind, workload.Namespace, workload.Name, workload.Generation, daemonSetComplete(workload)
+		default:
+			return
+		}
+		if !strings.HasPrefix(namespace, platformNamespacePrefix) {
+			return
+		}
+		w.lock.Lock()
+		defer w.lock.Unlock()
+		w.tracker.observe(kind, namespace, name, generation, complete, time.Now())
+	}
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc: observe,
+		UpdateFunc: func(_, obj interface{}) {
+			observe(obj)
+		},
+	}
+	if _, err := w.kubeInformers.Apps().V1().Deployments().Informer().AddEventHandler(handler); err != nil {
+		return err
+	}
+	_, err = w.kubeInformers.Apps().V1().DaemonSets().Informer().AddEventHandler(handler)
+	return err
+}
+
+func (w *workloadRolloutTracker) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	if w.notSupportedReason != nil {
+		return nil, nil, w.notSupportedReason
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return rolloutIntervals(w.tracker.rollouts, end), nil, nil
+}
+
+func (*workloadRolloutTracker) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	return nil, nil
+}
+
+func (w *workloadRolloutTracker) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	if w.notSupportedReason != nil {
+		return nil, w.notSupportedReason
+	}
+	return rolloutJUnits(finalIntervals, w.budgets), nil
+}
+
+func (*workloadRolloutTracker) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	return nil
+}
+
+func (*workloadRolloutTracker) Cleanup(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,229 @@
+package workloadrollouts
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	deploymentKind = "Deployment"
+	daemonSetKind  = "DaemonSet"
+
+	rolloutDurationTestName = "[sig-apps] platform workload rollouts should not take longer than their historical P99"
+	rolloutStuckTestName    = "[sig-apps] platform workload rollouts should not get stuck"
+)
+
+// defaultRolloutBudgets are used for the workloads without historical data.  Daemonsets roll out one node at a time
+// and wait on node reboots during upgrades.
+var defaultRolloutBudgets = map[string]time.Duration{
+	deploymentKind: 20 * time.Minute,
+	daemonSetKind:  60 * time.Minute,
+}
+
+var rolloutResources = map[string]schema.GroupVersionResource{
+	deploymentKind: appsv1.SchemeGroupVersion.WithResource("deployments"),
+	daemonSetKind:  appsv1.SchemeGroupVersion.WithResource("daemonsets"),
+}
+
+var rolloutReasons = map[string]monitorapi.IntervalReason{
+	deploymentKind: monitorapi.DeploymentRolloutReason,
+	daemonSetKind:  monitorapi.DaemonSetRolloutReason,
+}
+
+// HistoricalRolloutDuration is the P99 of the rollouts of a platform workload, from CI runs of the platform.
+type HistoricalRolloutDuration struct {
+	Kind       string  `json:"Kind"`
+	Namespace  string  `json:"Namespace"`
+	Name       string  `json:"Name"`
+	Platform   string  `json:"Platform"`
+	P99Seconds float64 `json:"P99Seconds"`
+}
+
+// historicalRolloutDurations is refreshed from CI data the same way as the historical disruption.  Workloads missing
+// from it get the default budget of their kind.
+//
+//go:embed rollout_durations.json
+var historicalRolloutDurations []byte
+
+// rolloutBudgets looks up the historical P99 of the platform workloads.
+type rolloutBudgets map[string]time.Duration
+
+func newRolloutBudgets(data []byte, platform string) (rolloutBudgets, error) {
+	durations := []HistoricalRolloutDuration{}
+	if err := json.Unmarshal(data, &durations); err != nil {
+		return nil, fmt.Errorf("invalid historical rollout durations: %w", err)
+	}
+	ret := rolloutBudgets{}
+	for _, duration := range durations {
+		if duration.Platform == platform && duration.P99Seconds > 0 {
+			ret[workloadKey(duration.Kind, duration.Namespace, duration.Name)] = time.Duration(duration.P99Seconds * float64(time.Second))
+		}
+	}
+	return ret, nil
+}
+
+// budget returns the budget of a workload and whether it comes from history.
+func (b rolloutBudgets) budget(kind, namespace, name string) (time.Duration, bool) {
+	if budget, ok := b[workloadKey(kind, namespace, name)]; ok {
+		return budget, true
+	}
+	return defaultRolloutBudgets[kind], false
+}
+
+func workloadKey(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
+
+// rollout is one generation of a workload rolling out, end is zero until it is fully available.
+type rollout struct {
+	kind       string
+	namespace  string
+	name       string
+	generation int64
+	start      time.Time
+	end        time.Time
+	// superseded is set when a newer generation started before this one was available.
+	superseded bool
+}
+
+// rolloutTracker follows the generations of the platform workloads.
+type rolloutTracker struct {
+	inProgress map[string]*rollout
+	rollouts   []*rollout
+}
+
+func newRolloutTracker() *rolloutTracker {
+	return &rolloutTracker{inProgress: map[string]*rollout{}}
+}
+
+// observe starts a rollout when the generation of a workload changes, or when it is first seen rolling out, and ends
+// it once the workload is fully available at that generation.
+func (t *rolloutTracker) observe(kind, namespace, name string, generation int64, complete bool, now time.Time) {
+	key := workloadKey(kind, namespace, name)
+	current, ok := t.inProgress[key]
+	if ok && current.generation != generation {
+		current.end = now
+		current.superseded = true
+		delete(t.inProgress, key)
+		ok = false
+	}
+	switch {
+	case !ok && !complete:
+		current = &rollout{kind: kind, namespace: namespace, name: name, generation: generation, start: now}
+		t.inProgress[key] = current
+		t.rollouts = append(t.rollouts, current)
+	case ok && complete:
+		current.end = now
+		delete(t.inProgress, key)
+	}
+}
+
+func deploymentComplete(deployment *appsv1.Deployment) bool {
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	status := deployment.Status
+	return status.ObservedGeneration >= deployment.Generation &&
+		status.UpdatedReplicas == replicas &&
+		status.Replicas == replicas &&
+		status.AvailableReplicas >= replicas
+}
+
+func daemonSetComplete(daemonSet *appsv1.DaemonSet) bool {
+	status := daemonSet.Status
+	return status.ObservedGeneration >= daemonSet.Generation &&
+		status.UpdatedNumberScheduled >= status.DesiredNumberScheduled &&
+		status.NumberAvailable >= status.DesiredNumberScheduled
+}
+
+// rolloutIntervals returns the rollouts, those still in progress end with the run.
+func rolloutIntervals(rollouts []*rollout, end time.Time) monitorapi.Intervals {
+	ret := monitorapi.Intervals{}
+	for _, rollout := range rollouts {
+		level := monitorapi.Info
+		to := rollout.end
+		message := monitorapi.NewMessage().Reason(rolloutReasons[rollout.kind])
+		switch {
+		case to.IsZero():
+			level = monitorapi.Warning
+			to = end
+			message.HumanMessagef("generation %d was not fully available by the end of the run", rollout.generation)
+		case rollout.superseded:
+			message.HumanMessagef("generation %d was superseded after %s", rollout.generation, to.Sub(rollout.start).Round(time.Second))
+		default:
+			message.HumanMessagef("generation %d was fully available after %s", rollout.generation, to.Sub(rollout.start).Round(time.Second))
+		}
+		ret = append(ret, monitorapi.NewInterval(monitorapi.SourceWorkloadRollout, level).
+			Locator(monitorapi.NewLocator().ForGVR(rolloutResources[rollout.kind], rollout.namespace, rollout.name)).
+			Message(message).
+			Display().
+			Build(rollout.start, to))
+	}
+	sort.Sort(ret)
+	return ret
+}
+
+// rolloutJUnits fails rollouts that took longer than their budget, and those still in progress at the end of the run
+// for longer than their budget.
+func rolloutJUnits(intervals monitorapi.Intervals, budgets rolloutBudgets) []*junitapi.JUnitTestCase {
+	slow, stuck := []string{}, []string{}
+	for _, interval := range intervals {
+		if interval.Source != monitorapi.SourceWorkloadRollout {
+			continue
+		}
+		kind := deploymentKind
+		if interval.Message.Reason == monitorapi.DaemonSetRolloutReason {
+			kind = daemonSetKind
+		}
+		namespace := interval.Locator.Keys[monitorapi.LocatorNamespaceKey]
+		name := interval.Locator.Keys[monitorapi.LocatorNameKey]
+		budget, historical := budgets.budget(kind, namespace, name)
+		duration := interval.To.Sub(interval.From)
+		if duration <= budget {
+			continue
+		}
+		budgetSource := "default budget"
+		if historical {
+			budgetSource = "historical P99"
+		}
+		line := fmt.Sprintf("%s took %s, its %s is %s: %s", interval.Locator.OldLocator(),
+			duration.Round(time.Second), budgetSource, budget, interval.Message.HumanMessage)
+		if interval.Level == monitorapi.Warning {
+			stuck = append(stuck, line)
+		} else {
+			slow = append(slow, line)
+		}
+	}
+
+	ret := []*junitapi.JUnitTestCase{}
+	for _, test := range []struct {
+		name    string
+		reason  string
+		results []string
+	}{
+		{name: rolloutDurationTestName, reason: "rollouts took longer than their budget", results: slow},
+		{name: rolloutStuckTestName, reason: "rollouts were still in progress past their budget at the end of the run", results: stuck},
+	} {
+		if len(test.results) == 0 {
+			ret = append(ret, &junitapi.JUnitTestCase{Name: test.name})
+			continue
+		}
+		ret = append(ret, &junitapi.JUnitTestCase{
+			Name: test.name,
+			FailureOutput: &junitapi.FailureOutput{
+				Output: fmt.Sprintf("%d %s:\n\n%s", len(test.results), test.reason, strings.Join(test.results, "\n")),
+			},
+		})
+	}
+	return ret
+}
@@ -0,0 +1,73 @@
+package workloadrollouts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestRolloutTracker(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+
+	tracker := newRolloutTracker()
+	// already available when the monitor started, no rollout.
+	tracker.observe(deploymentKind, "openshift-console", "console", 3, true, start)
+	// a new generation, fully available after five minutes.
+	tracker.observe(deploymentKind, "openshift-console", "console", 4, false, start.Add(time.Minute))
+	tracker.observe(deploymentKind, "openshift-console", "console", 4, false, start.Add(2*time.Minute))
+	tracker.observe(deploymentKind, "openshift-console", "console", 4, true, start.Add(6*time.Minute))
+	// superseded by a generation that never completes.
+	tracker.observe(daemonSetKind, "openshift-dns", "dns-default", 2, false, start.Add(10*time.Minute))
+	tracker.observe(daemonSetKind, "openshift-dns", "dns-default", 3, false, start.Add(20*time.Minute))
+
+	intervals := rolloutIntervals(tracker.rollouts, end)
+	require.Len(t, intervals, 3)
+	assert.Equal(t, monitorapi.DeploymentRolloutReason, intervals[0].Message.Reason)
+	assert.Equal(t, "generation 4 was fully available after 5m0s", intervals[0].Message.HumanMessage)
+	assert.Equal(t, "console", intervals[0].Locator.Keys[monitorapi.LocatorNameKey])
+	assert.Equal(t, "generation 2 was superseded after 10m0s", intervals[1].Message.HumanMessage)
+	assert.Equal(t, monitorapi.Warning, intervals[2].Level)
+	assert.Equal(t, end, intervals[2].To)
+
+	budgets, err := newRolloutBudgets([]byte(`[
+		{"Kind": "Deployment", "Namespace": "openshift-console", "Name": "console", "Platform": "aws", "P99Seconds": 120},
+		{"Kind": "Deployment", "Namespace": "openshift-console", "Name": "console", "Platform": "gcp", "P99Seconds": 600}
+	]`), "aws")
+	require.NoError(t, err)
+	junits := rolloutJUnits(intervals, budgets)
+	require.Len(t, junits, 2)
+	require.NotNil(t, junits[0].FailureOutput)
+	assert.Contains(t, junits[0].FailureOutput.Output, "its historical P99 is 2m0s")
+	require.NotNil(t, junits[1].FailureOutput)
+	assert.Contains(t, junits[1].FailureOutput.Output, "name/dns-default")
+	assert.Contains(t, junits[1].FailureOutput.Output, "its default budget is 1h0m0s")
+
+	_, err = newRolloutBudgets(historicalRolloutDurations, "aws")
+	assert.NoError(t, err, "the embedded historical data must parse")
+}
+
+func TestRolloutComplete(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Generation: 2},
+		Spec:       appsv1.DeploymentSpec{Replicas: ptr.To[int32](2)},
+		Status:     appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 2, AvailableReplicas: 2},
+	}
+	assert.False(t, deploymentComplete(deployment), "an old replica is still running")
+	deployment.Status.Replicas = 2
+	assert.True(t, deploymentComplete(deployment))
+
+	daemonSet := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Generation: 5},
+		Status:     appsv1.DaemonSetStatus{ObservedGeneration: 4, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberAvailable: 3},
+	}
+	assert.False(t, daemonSetComplete(daemonSet), "the new generation was not observed yet")
+	daemonSet.Status.ObservedGeneration = 5
+	assert.True(t, daemonSetComplete(daemonSet))
+}
@@ -85,14 +85,19 @@ func (*etcdLogAnalyzer) ConstructComputedIntervals(ctx context.Context, starting
 	var newInterval *monitorapi.IntervalBuilder
 	startTime := time.Time{}
 
-	interestingReasons := sets.NewString("LeaderFound", "LeaderElected", "LeaderLost", "LeaderMissing")
+	interestingReasons := sets.New[monitorapi.IntervalReason](
+		monitorapi.EtcdLeaderFoundReason,
+		monitorapi.EtcdLeaderElectedReason,
+		monitorapi.EtcdLeaderLostReason,
+		monitorapi.EtcdLeaderMissingReason,
+	)
 
 	podsToNode := podaccess.NonUniquePodToNode(startingIntervals)
 	etcdMemberIDToPod := podaccess.NonUniqueEtcdMemberToPod(startingIntervals)
 
 	for _, currInterval := range startingIntervals {
 		reason := currInterval.Message.Reason
-		if !interestingReasons.Has(string(reason)) {
+		if !interestingReasons.Has(reason) {
 			continue
 		}
 
@@ -204,7 +209,7 @@ func (g etcdRecorder) HandleLogLine(logLine podaccess.LogLineContent) {
 	case strings.Contains(parsedLine.Msg, "restarting local member"):
 		messages = []*monitorapi.MessageBuilder{
 			monitorapi.NewMessage().
-				Reason("LocalMemberRestart"). // this message provides a mapping from pod ID to member ID
+				Reason(monitorapi.EtcdLocalMemberRestartReason). // this message provides a mapping from pod ID to member ID
 				WithAnnotation(monitorapi.AnnotationEtcdLocalMember, parsedLine.LocalMemberID).
 				HumanMessage(parsedLine.Msg),
 		}
@@ -213,7 +218,7 @@ func (g etcdRecorder) HandleLogLine(logLine podaccess.LogLineContent) {
 	case strings.Contains(parsedLine.Msg, "elected leader"):
 		messages = []*monitorapi.MessageBuilder{
 			monitorapi.NewMessage().
-				Reason("LeaderFound"). // this message can be produced when etcd starts up
+				Reason(monitorapi.EtcdLeaderFoundReason). // this message can be produced when etcd starts up
 				WithAnnotation(monitorapi.AnnotationEtcdLeader, currentLeaderFromMessage(parsedLine.Msg)).
 				WithAnnotation(monitorapi.AnnotationEtcdTerm, electionTermFromMessage(parsedLine.Msg)).
 				HumanMessage(parsedLine.Msg),
@@ -222,7 +227,7 @@ func (g etcdRecorder) HandleLogLine(logLine podaccess.LogLineContent) {
 	case strings.Contains(parsedLine.Msg, "became leader"):
 		messages = []*monitorapi.MessageBuilder{
 			monitorapi.NewMessage().
-				Reason("LeaderElected"). // this message is produce when a leader is chosen
+				Reason(monitorapi.EtcdLeaderElectedReason). // this message is produce when a leader is chosen
 				WithAnnotation(monitorapi.AnnotationEtcdLeader, currentLeaderFromMessage(parsedLine.Msg)).
 				WithAnnotation(monitorapi.AnnotationEtcdTerm, electionTermFromMessage(parsedLine.Msg)).
 				HumanMessage(parsedLine.Msg),
@@ -231,7 +236,7 @@ func (g etcdRecorder) HandleLogLine(logLine podaccess.LogLineContent) {
 	case strings.Contains(parsedLine.Msg, "lost leader"):
 		messages = []*monitorapi.MessageBuilder{
 			monitorapi.NewMessage().
-				Reason("LeaderLost").
+				Reason(monitorapi.EtcdLeaderLostReason).
 				WithAnnotation(monitorapi.AnnotationPreviousEtcdLeader, prevLeaderFromMessage(parsedLine.Msg)).
 				WithAnnotation(monitorapi.AnnotationEtcdLeader, "").
 				WithAnnotation(monitorapi.AnnotationEtcdTerm, electionTermFromMessage(parsedLine.Msg)).
@@ -241,7 +246,7 @@ func (g etcdRecorder) HandleLogLine(logLine podaccess.LogLineContent) {
 	case strings.Contains(parsedLine.Msg, "no leader"):
 		messages = []*monitorapi.MessageBuilder{
 			monitorapi.NewMessage().
-				Reason("LeaderMissing").
+				Reason(monitorapi.EtcdLeaderMissingReason).
 				WithAnnotation(monitorapi.AnnotationEtcdLeader, "").
 				WithAnnotation(monitorapi.AnnotationEtcdTerm, electionTermFromMessage(parsedLine.Msg)).
 				HumanMessage(parsedLine.Msg),
@@ -250,13 +255,13 @@ func (g etcdRecorder) HandleLogLine(logLine podaccess.LogLineContent) {
 	case strings.Contains(parsedLine.Msg, "changed leader"):
 		messages = []*monitorapi.MessageBuilder{
 			monitorapi.NewMessage().
-				Reason("LeaderLost").
+				Reason(monitorapi.EtcdLeaderLostReason).
 				WithAnnotation(monitorapi.AnnotationPreviousEtcdLeader, prevLeaderFromMessage(parsedLine.Msg)).
 				WithAnnotation(monitorapi.AnnotationEtcdLeader, "").
 				WithAnnotation(monitorapi.AnnotationEtcdTerm, electionTermFromMessage(parsedLine.Msg)).
 				HumanMessage(parsedLine.Msg),
 			monitorapi.NewMessage().
-				Reason("LeaderFound").
+				Reason(monitorapi.EtcdLeaderFoundReason).
 				WithAnnotation(monitorapi.AnnotationEtcdLeader, currentLeaderFromMessage(parsedLine.Msg)).
 				WithAnnotation(monitorapi.AnnotationEtcdTerm, electionTermFromMessage(parsedLine.Msg)).
 				HumanMessage(parsedLine.Msg),
@@ -0,0 +1,179 @@
+package etcdobjectgrowth
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	routeclient "github.com/openshift/client-go/route/clientset/versioned"
+	"github.com/openshift/library-go/test/library/metrics"
+	"github.com/openshift/origin/pkg/monitortestlibrary/prometheus"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	prometheusv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	prometheustypes "github.com/prometheus/common/model"
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	testName = "[sig-etcd] etcd object counts should not grow pathologically during the run"
+
+	// the apiservers each report the count of the objects they store, they agree but for the timing.
+	objectCountQuery = `max by (resource) (apiserver_storage_objects)`
+	dbSizeQuery      = `max(etcd_mvcc_db_total_size_in_bytes)`
+	sampleStep       = 5 * time.Minute
+
+	// a resource leaks when its count grew by both the factor and the number of objects over the run.  The factor
+	// ignores resources that grow along with the tests, the number ignores resources with few objects.
+	leakGrowthFactor  = 3.0
+	leakGrowthObjects = 5000
+
+	mib = 1024 * 1024
+)
+
+// ResourceGrowth is the count of the objects of a resource over the run.
+type ResourceGrowth struct {
+	Resource string
+	First    int64
+	Last     int64
+	Peak     int64
+}
+
+// Growth is written as the etcd-object-growth artifact.
+type Growth struct {
+	// DBSizeSamples are the size of the largest etcd database, in bytes, every sample step.
+	DBSizeSamples []DBSizeSample
+	// Resources are sorted by the number of objects they grew by, the most first.
+	Resources []ResourceGrowth
+}
+
+type DBSizeSample struct {
+	Time  time.Time
+	Bytes int64
+}
+
+func fetchGrowth(ctx context.Context, restConfig *rest.Config, beginning, end time.Time) (prometheustypes.Matrix, prometheustypes.Matrix, error) {
+	logger := logrus.WithField("MonitorTest", "EtcdObjectGrowth")
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	routeClient, err := routeclient.NewForConfig(restConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_, err = kubeClient.CoreV1().Namespaces().Get(ctx, "openshift-monitoring", metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil, nil
+	}
+
+	prometheusClient, err := metrics.NewPrometheusClient(ctx, kubeClient, routeClient)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := prometheus.EnsureThanosQueriersConnectedToPromSidecars(ctx, prometheusClient); err != nil {
+		return nil, nil, err
+	}
+
+	timeRange := prometheusv1.Range{
+		Start: beginning,
+		End:   end,
+		Step:  sampleStep,
+	}
+	query := func(query string) (prometheustypes.Matrix, error) {
+		value, warningsForQuery, err := prometheusClient.QueryRange(ctx, query, timeRange)
+		if err != nil {
+			return nil, err
+		}
+		for _, w := range warningsForQuery {
+			logger.Warnf("etcd object growth prom query warning: %s", w)
+		}
+		matrix, ok := value.(prometheustypes.Matrix)
+		if !ok {
+			return nil, fmt.Errorf("unexpected prometheus type %s", value.Type())
+		}
+		return matrix, nil
+	}
+	objectCounts, err := query(objectCountQuery)
+	if err != nil {
+		return nil, nil, err
+	}
+	dbSize, err := query(dbSizeQuery)
+	if err != nil {
+		return nil, nil, err
+	}
+	return objectCounts, dbSize, nil
+}
+
+// computeGrowth summarizes the object count series, which are keyed by resource, and the database size series.
+func computeGrowth(objectCounts, dbSize prometheustypes.Matrix) Growth {
+	ret := Growth{DBSizeSamples: []DBSizeSample{}, Resources: []ResourceGrowth{}}
+	for _, stream := range objectCounts {
+		if len(stream.Values) == 0 {
+			continue
+		}
+		growth := ResourceGrowth{
+			Resource: string(stream.Metric["resource"]),
+			First:    int64(stream.Values[0].Value),
+			Last:     int64(stream.Values[len(stream.Values)-1].Value),
+		}
+		for _, value := range stream.Values {
+			if int64(value.Value) > growth.Peak {
+				growth.Peak = int64(value.Value)
+			}
+		}
+		ret.Resources = append(ret.Resources, growth)
+	}
+	sort.SliceStable(ret.Resources, func(i, j int) bool {
+		lhs, rhs := ret.Resources[i], ret.Resources[j]
+		if lhs.Last-lhs.First != rhs.Last-rhs.First {
+			return lhs.Last-lhs.First > rhs.Last-rhs.First
+		}
+		return lhs.Resource < rhs.Resource
+	})
+
+	for _, stream := range dbSize {
+		for _, value := range stream.Values {
+			ret.DBSizeSamples = append(ret.DBSizeSamples, DBSizeSample{Time: value.Timestamp.Time().UTC(), Bytes: int64(value.Value)})
+		}
+	}
+	return ret
+}
+
+// isLeaking returns true when the count of the resource grew pathologically over the run.
+func (g ResourceGrowth) isLeaking() bool {
+	return g.Last-g.First > leakGrowthObjects && float64(g.Last) > float64(g.First)*leakGrowthFactor
+}
+
+func growthJunits(growth Growth) []*junitapi.JUnitTestCase {
+	leaks := []string{}
+	for _, resource := range growth.Resources {
+		if resource.isLeaking() {
+			leaks = append(leaks, fmt.Sprintf("%s grew from %d to %d objects, peaking at %d", resource.Resource, resource.First, resource.Last, resource.Peak))
+		}
+	}
+	if len(leaks) == 0 {
+		return []*junitapi.JUnitTestCase{{Name: testName}}
+	}
+
+	output := fmt.Sprintf("the object counts of %d resources grew more than %.0fx and by more than %d objects, something is likely leaking them:\n\n%s",
+		len(leaks), leakGrowthFactor, leakGrowthObjects, strings.Join(leaks, "\n"))
+	if len(growth.DBSizeSamples) > 0 {
+		first, last := growth.DBSizeSamples[0], growth.DBSizeSamples[len(growth.DBSizeSamples)-1]
+		output += fmt.Sprintf("\n\nthe etcd database grew from %dMiB to %dMiB", first.Bytes/mib, last.Bytes/mib)
+	}
+	failure := &junitapi.JUnitTestCase{
+		Name: testName,
+		FailureOutput: &junitapi.FailureOutput{
+			Output: output,
+		},
+	}
+	// TODO: marked flaky until we know which resources grow along with the tests
+	return []*junitapi.JUnitTestCase{failure, {Name: testName}}
+}
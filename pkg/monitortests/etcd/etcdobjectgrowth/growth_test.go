@@ -0,0 +1,52 @@
+package etcdobjectgrowth
+
+import (
+	"testing"
+	"time"
+
+	prometheustypes "github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeGrowth(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	series := func(metric prometheustypes.Metric, values ...float64) *prometheustypes.SampleStream {
+		stream := &prometheustypes.SampleStream{Metric: metric}
+		for i, value := range values {
+			stream.Values = append(stream.Values, prometheustypes.SamplePair{
+				Timestamp: prometheustypes.TimeFromUnixNano(start.Add(time.Duration(i) * sampleStep).UnixNano()),
+				Value:     prometheustypes.SampleValue(value),
+			})
+		}
+		return stream
+	}
+	resource := func(name string) prometheustypes.Metric {
+		return prometheustypes.Metric{"resource": prometheustypes.LabelValue(name)}
+	}
+
+	objectCounts := prometheustypes.Matrix{
+		series(resource("secrets"), 2000, 2500, 2400),
+		series(resource("leases.coordination.k8s.io"), 300, 4000, 9000),
+		// grew a lot, but not by much relative to its size
+		series(resource("events"), 20000, 40000, 35000),
+	}
+	dbSize := prometheustypes.Matrix{
+		series(prometheustypes.Metric{}, 100*mib, 180*mib, 210*mib),
+	}
+
+	growth := computeGrowth(objectCounts, dbSize)
+	require.Len(t, growth.Resources, 3)
+	assert.Equal(t, ResourceGrowth{Resource: "events", First: 20000, Last: 35000, Peak: 40000}, growth.Resources[0])
+	assert.Equal(t, "leases.coordination.k8s.io", growth.Resources[1].Resource)
+	require.Len(t, growth.DBSizeSamples, 3)
+	assert.Equal(t, start, growth.DBSizeSamples[0].Time)
+
+	junits := growthJunits(growth)
+	require.Len(t, junits, 2)
+	require.NotNil(t, junits[0].FailureOutput)
+	assert.Contains(t, junits[0].FailureOutput.Output, "leases.coordination.k8s.io grew from 300 to 9000 objects, peaking at 9000")
+	assert.NotContains(t, junits[0].FailureOutput.Output, "events grew")
+	assert.Contains(t, junits[0].FailureOutput.Output, "the etcd database grew from 100MiB to 210MiB")
+	assert.Nil(t, junits[1].FailureOutput)
+}
@@ -0,0 +1,71 @@
+package etcdobjectgrowth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitortestframework"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	"k8s.io/client-go/rest"
+)
+
+type etcdObjectGrowth struct {
+	adminRESTConfig *rest.Config
+	growth          *Growth
+}
+
+// NewEtcdObjectGrowth tracks the count of the objects of every resource stored in etcd and the size of its database
+// over the run, and fails resources whose count grew pathologically, which are likely leaked.
+func NewEtcdObjectGrowth() monitortestframework.MonitorTest {
+	return &etcdObjectGrowth{}
+}
+
+func (w *etcdObjectGrowth) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	w.adminRESTConfig = adminRESTConfig
+	return nil
+}
+
+func (w *etcdObjectGrowth) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	objectCounts, dbSize, err := fetchGrowth(ctx, w.adminRESTConfig, beginning, end)
+	if err != nil {
+		return nil, nil, err
+	}
+	if objectCounts == nil {
+		return nil, nil, nil
+	}
+	growth := computeGrowth(objectCounts, dbSize)
+	w.growth = &growth
+	return nil, nil, nil
+}
+
+func (*etcdObjectGrowth) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	return nil, nil
+}
+
+func (w *etcdObjectGrowth) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	if w.growth == nil {
+		return nil, nil
+	}
+	return growthJunits(*w.growth), nil
+}
+
+func (w *etcdObjectGrowth) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	if w.growth == nil {
+		return nil
+	}
+	jsonContent, err := json.MarshalIndent(w.growth, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(storageDir, fmt.Sprintf("etcd-object-growth%s.json", timeSuffix)), jsonContent, 0644)
+}
+
+func (*etcdObjectGrowth) Cleanup(ctx context.Context) error {
+	return nil
+}
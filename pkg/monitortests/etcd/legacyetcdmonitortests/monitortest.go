@@ -5,7 +5,11 @@ import (
 	"fmt"
 	"time"
 
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/sirupsen/logrus"
+
 	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/monitortestlibrary/pathologicaleventlibrary"
 
 	"github.com/openshift/origin/pkg/monitor/monitorapi"
 	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
@@ -16,6 +20,7 @@ import (
 
 type legacyMonitorTests struct {
 	adminRESTConfig    *rest.Config
+	topology           configv1.TopologyMode
 	notSupportedReason error
 }
 
@@ -45,6 +50,11 @@ func (w *legacyMonitorTests) StartCollection(ctx context.Context, adminRESTConfi
 		return w.notSupportedReason
 	}
 
+	_, topology, err := pathologicaleventlibrary.GetClusterInfraInfo(adminRESTConfig)
+	if err != nil {
+		logrus.WithError(err).Error("could not fetch cluster infra info")
+	}
+	w.topology = topology
 	return nil
 }
 
@@ -61,10 +71,10 @@ func (w *legacyMonitorTests) EvaluateTestsFromConstructedIntervals(ctx context.C
 		return nil, w.notSupportedReason
 	}
 	junits := []*junitapi.JUnitTestCase{}
-	junits = append(junits, testRequiredInstallerResourcesMissing(finalIntervals)...)
+	junits = append(junits, testRequiredInstallerResourcesMissing(finalIntervals, w.topology)...)
 	junits = append(junits, testEtcdShouldNotLogSlowFdataSyncs(finalIntervals)...)
 	junits = append(junits, testEtcdShouldNotLogDroppedRaftMessages(finalIntervals)...)
-	junits = append(junits, testOperatorStatusChanged(finalIntervals)...)
+	junits = append(junits, testOperatorStatusChanged(finalIntervals, w.topology)...)
 	junits = append(junits, testEtcdDoesNotLogExcessiveTookTooLongMessages(finalIntervals)...)
 
 	return junits, nil
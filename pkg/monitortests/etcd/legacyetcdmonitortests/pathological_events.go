@@ -1,6 +1,7 @@
 package legacyetcdmonitortests
 
 import (
+	configv1 "github.com/openshift/api/config/v1"
 	"github.com/openshift/origin/pkg/monitor/monitorapi"
 	"github.com/openshift/origin/pkg/monitortestlibrary/pathologicaleventlibrary"
 	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
@@ -10,17 +11,17 @@ import (
 //
 //	reason/RequiredInstallerResourcesMissing secrets: etcd-all-certs-3
 //
-// and fails if it happens more than the failure threshold count of 20 and flakes more than the
+// and fails if it happens more than the duplicate event threshold of the topology and flakes more than the
 // flake threshold.  See https://bugzilla.redhat.com/show_bug.cgi?id=2031564.
-func testRequiredInstallerResourcesMissing(events monitorapi.Intervals) []*junitapi.JUnitTestCase {
+func testRequiredInstallerResourcesMissing(events monitorapi.Intervals, topology configv1.TopologyMode) []*junitapi.JUnitTestCase {
 	testName := "[bz-etcd] pathological event should not see excessive RequiredInstallerResourcesMissing secrets"
 	return pathologicaleventlibrary.NewSingleEventThresholdCheck(testName,
-		pathologicaleventlibrary.EtcdRequiredResourcesMissing, pathologicaleventlibrary.DuplicateEventThreshold, pathologicaleventlibrary.RequiredResourceMissingFlakeThreshold).Test(events)
+		pathologicaleventlibrary.EtcdRequiredResourcesMissing, pathologicaleventlibrary.DuplicateEventThresholdForTopology(topology), pathologicaleventlibrary.RequiredResourceMissingFlakeThreshold).Test(events)
 }
 
-func testOperatorStatusChanged(events monitorapi.Intervals) []*junitapi.JUnitTestCase {
+func testOperatorStatusChanged(events monitorapi.Intervals, topology configv1.TopologyMode) []*junitapi.JUnitTestCase {
 	const testName = "[sig-node] pathological event OperatorStatusChanged condition does not occur too often"
 	return pathologicaleventlibrary.EventExprMatchThresholdTest(testName, events,
 		pathologicaleventlibrary.EtcdClusterOperatorStatusChanged,
-		pathologicaleventlibrary.DuplicateEventThreshold)
+		pathologicaleventlibrary.DuplicateEventThresholdForTopology(topology))
 }
@@ -86,7 +86,7 @@ func Test_testRequiredInstallerResourcesMissing(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			e := tt.interval
-			junit_tests := testRequiredInstallerResourcesMissing(monitorapi.Intervals{e})
+			junit_tests := testRequiredInstallerResourcesMissing(monitorapi.Intervals{e}, "")
 			switch tt.kind {
 			case "pass":
 				if len(junit_tests) != 1 {
@@ -172,7 +172,7 @@ func Test_testOperatorStatusChanged(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			e := monitorapi.Intervals{tt.interval}
-			junitTests := testOperatorStatusChanged(e)
+			junitTests := testOperatorStatusChanged(e, "")
 			switch tt.kind {
 			case "pass":
 				assert.Equal(t, 1, len(junitTests), "This should've been a single passing Test")
@@ -0,0 +1,148 @@
+package apiserverprofiles
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// apiserverProfiler captures kube-apiserver pprof profiles while the run is disrupting the kube-apiserver, so outages
+// caused by apiserver performance come with the data to debug them.
+type apiserverProfiler struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	lock     sync.Mutex
+	captures []Capture
+}
+
+func NewAPIServerProfiler() monitortestframework.MonitorTest {
+	return &apiserverProfiler{}
+}
+
+func (w *apiserverProfiler) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	// the disruption samplers write into the same recorder, it tells us about disruption while it is happening.
+	notifier, ok := recorder.(monitorapi.RecorderNotifier)
+	if !ok {
+		logrus.Warn("recorder does not notify about intervals, apiserver profiles will not be captured")
+		return nil
+	}
+	disruption := newDisruptionTracker()
+	notifier.AddIntervalHandler(disruption.observe)
+
+	kubeClient, err := kubernetes.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return err
+	}
+
+	ctx, w.cancel = context.WithCancel(ctx)
+	w.done = make(chan struct{})
+	go func() {
+		defer close(w.done)
+		w.watchDisruption(ctx, kubeClient, disruption)
+	}()
+	return nil
+}
+
+func (w *apiserverProfiler) watchDisruption(ctx context.Context, kubeClient kubernetes.Interface, disruption *disruptionTracker) {
+	trigger := &captureTrigger{}
+	since := time.Now()
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		now := time.Now()
+		from, to, disrupted := kubeAPIDisruption(disruption.since(since), since, now)
+		since = now
+		if !trigger.shouldCapture(now, disrupted) {
+			continue
+		}
+
+		logrus.Infof("kube-apiserver was disrupted for %s, capturing apiserver profiles", disrupted)
+		profiles, err := captureProfiles(ctx, kubeClient, trigger.captures)
+		if err != nil {
+			logrus.WithError(err).Warn("unable to capture apiserver profiles")
+			continue
+		}
+		w.lock.Lock()
+		w.captures = append(w.captures, Capture{
+			DisruptionFrom:    from,
+			DisruptionTo:      to,
+			DisruptionSeconds: disrupted.Seconds(),
+			CapturedAt:        now,
+			Profiles:          profiles,
+		})
+		w.lock.Unlock()
+	}
+}
+
+func (w *apiserverProfiler) stop() {
+	if w.cancel == nil {
+		return
+	}
+	w.cancel()
+	<-w.done
+}
+
+func (w *apiserverProfiler) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	w.stop()
+	return nil, nil, nil
+}
+
+func (*apiserverProfiler) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	return nil, nil
+}
+
+func (*apiserverProfiler) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	return nil, nil
+}
+
+func (w *apiserverProfiler) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if len(w.captures) == 0 {
+		return nil
+	}
+
+	profileDir := filepath.Join(storageDir, fmt.Sprintf("apiserver-pprof%s", timeSuffix))
+	if err := os.MkdirAll(profileDir, 0755); err != nil {
+		return err
+	}
+	for _, capture := range w.captures {
+		for _, profile := range capture.Profiles {
+			if len(profile.File) == 0 {
+				continue
+			}
+			if err := os.WriteFile(filepath.Join(profileDir, profile.File), profile.data, 0644); err != nil {
+				return err
+			}
+		}
+	}
+	jsonContent, err := json.MarshalIndent(w.captures, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(profileDir, "captures.json"), jsonContent, 0644)
+}
+
+func (w *apiserverProfiler) Cleanup(ctx context.Context) error {
+	w.stop()
+	return nil
+}
@@ -0,0 +1,201 @@
+package apiserverprofiles
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	pollInterval = 30 * time.Second
+	// disruptionThreshold is how much kube-apiserver disruption within a poll triggers a capture.
+	disruptionThreshold = 5 * time.Second
+	// captureCooldown and maxCaptures bound the load we add to apiservers that are already struggling.
+	captureCooldown   = 10 * time.Minute
+	maxCaptures       = 3
+	cpuProfileSeconds = 30
+)
+
+// Capture is one set of profiles taken from every kube-apiserver pod because of the disruption from DisruptionFrom to
+// DisruptionTo.
+type Capture struct {
+	DisruptionFrom    time.Time
+	DisruptionTo      time.Time
+	DisruptionSeconds float64
+	CapturedAt        time.Time
+	Profiles          []Profile
+}
+
+// Profile is a pprof profile of one pod.  File is relative to the artifact directory and empty when the profile could
+// not be taken.
+type Profile struct {
+	Pod   string
+	Type  string
+	File  string `json:",omitempty"`
+	Error string `json:",omitempty"`
+
+	data []byte
+}
+
+// isKubeAPIDisruption selects disruption of the kube-apiserver backends, not the other apiservers or the cached reads.
+func isKubeAPIDisruption(interval monitorapi.Interval) bool {
+	return interval.Source == monitorapi.SourceDisruption &&
+		interval.Level == monitorapi.Error &&
+		strings.HasPrefix(interval.Locator.Keys[monitorapi.LocatorBackendDisruptionNameKey], "kube-api-")
+}
+
+// disruptionTracker keeps the kube-apiserver disruption intervals as the recorder is told about them, including the ones
+// still open, so polling does not read back everything recorded.
+type disruptionTracker struct {
+	lock sync.Mutex
+	// intervals are keyed by locator and start, so an ended interval replaces the open interval it was started as.
+	intervals map[string]monitorapi.Interval
+}
+
+func newDisruptionTracker() *disruptionTracker {
+	return &disruptionTracker{intervals: map[string]monitorapi.Interval{}}
+}
+
+func (t *disruptionTracker) observe(interval monitorapi.Interval) {
+	if !isKubeAPIDisruption(interval) {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.intervals[fmt.Sprintf("%s %d", interval.Locator.OldLocator(), interval.From.UnixNano())] = interval
+}
+
+// since returns the intervals that are still open or ended at or after since, and forgets the ones that ended before.
+func (t *disruptionTracker) since(since time.Time) monitorapi.Intervals {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	ret := monitorapi.Intervals{}
+	for key, interval := range t.intervals {
+		if !interval.To.IsZero() && interval.To.Before(since) {
+			delete(t.intervals, key)
+			continue
+		}
+		ret = append(ret, interval)
+	}
+	return ret
+}
+
+// kubeAPIDisruption returns the window and duration of kube-apiserver disruption between since and now.  New and
+// reused connection backends are often disrupted together, overlapping time is only counted once.
+func kubeAPIDisruption(intervals monitorapi.Intervals, since, now time.Time) (time.Time, time.Time, time.Duration) {
+	type window struct{ from, to time.Time }
+	windows := []window{}
+	for _, interval := range intervals {
+		if !isKubeAPIDisruption(interval) {
+			continue
+		}
+		from, to := interval.From, interval.To
+		if to.IsZero() || to.After(now) {
+			// still disrupted.
+			to = now
+		}
+		if from.Before(since) {
+			from = since
+		}
+		if to.After(from) {
+			windows = append(windows, window{from: from, to: to})
+		}
+	}
+	if len(windows) == 0 {
+		return time.Time{}, time.Time{}, 0
+	}
+	sort.Slice(windows, func(i, j int) bool { return windows[i].from.Before(windows[j].from) })
+
+	var total time.Duration
+	first, last := windows[0].from, windows[0].to
+	current := windows[0]
+	for _, w := range windows[1:] {
+		if !w.from.After(current.to) {
+			if w.to.After(current.to) {
+				current.to = w.to
+			}
+		} else {
+			total += current.to.Sub(current.from)
+			current = w
+		}
+		if current.to.After(last) {
+			last = current.to
+		}
+	}
+	total += current.to.Sub(current.from)
+	return first, last, total
+}
+
+// captureTrigger decides when disruption is bad enough to capture profiles.
+type captureTrigger struct {
+	lastCapture time.Time
+	captures    int
+}
+
+func (t *captureTrigger) shouldCapture(now time.Time, disrupted time.Duration) bool {
+	if disrupted < disruptionThreshold || t.captures >= maxCaptures {
+		return false
+	}
+	if !t.lastCapture.IsZero() && now.Sub(t.lastCapture) < captureCooldown {
+		return false
+	}
+	t.lastCapture = now
+	t.captures++
+	return true
+}
+
+// captureProfiles takes a cpu and a heap profile from every kube-apiserver pod in parallel through the pod proxy.
+func captureProfiles(ctx context.Context, kubeClient kubernetes.Interface, index int) ([]Profile, error) {
+	pods, err := kubeClient.CoreV1().Pods("openshift-kube-apiserver").List(ctx, metav1.ListOptions{LabelSelector: "apiserver=true"})
+	if err != nil {
+		return nil, err
+	}
+
+	profileTypes := map[string]map[string]string{
+		"cpu":  {"seconds": fmt.Sprintf("%d", cpuProfileSeconds)},
+		"heap": nil,
+	}
+	paths := map[string]string{
+		"cpu":  "/debug/pprof/profile",
+		"heap": "/debug/pprof/heap",
+	}
+
+	lock := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	ret := []Profile{}
+	for _, pod := range pods.Items {
+		for profileType, params := range profileTypes {
+			wg.Add(1)
+			go func(podName, profileType, path string, params map[string]string) {
+				defer wg.Done()
+				profile := Profile{Pod: podName, Type: profileType}
+				data, err := kubeClient.CoreV1().Pods("openshift-kube-apiserver").ProxyGet("https", podName, "6443", path, params).DoRaw(ctx)
+				if err != nil {
+					profile.Error = err.Error()
+				} else {
+					profile.data = data
+					profile.File = fmt.Sprintf("capture-%d-%s-%s.pprof", index, podName, profileType)
+				}
+				lock.Lock()
+				defer lock.Unlock()
+				ret = append(ret, profile)
+			}(pod.Name, profileType, paths[profileType], params)
+		}
+	}
+	wg.Wait()
+
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Pod != ret[j].Pod {
+			return ret[i].Pod < ret[j].Pod
+		}
+		return ret[i].Type < ret[j].Type
+	})
+	return ret, nil
+}
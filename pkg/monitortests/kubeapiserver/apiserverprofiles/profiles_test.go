@@ -0,0 +1,90 @@
+package apiserverprofiles
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+func TestKubeAPIDisruption(t *testing.T) {
+	since := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	now := since.Add(pollInterval)
+	disruption := func(backend string, level monitorapi.IntervalLevel, from, to time.Time) monitorapi.Interval {
+		return monitorapi.NewInterval(monitorapi.SourceDisruption, level).
+			Locator(monitorapi.NewLocator().DisruptionRequiredOnly(backend, "kube-api")).
+			Message(monitorapi.NewMessage().HumanMessage("disrupted")).
+			Build(from, to)
+	}
+
+	intervals := monitorapi.Intervals{
+		// started before the poll, only the part after since counts.
+		disruption("kube-api-new-connections", monitorapi.Error, since.Add(-10*time.Second), since.Add(3*time.Second)),
+		// overlapping new and reused connections count once.
+		disruption("kube-api-new-connections", monitorapi.Error, since.Add(10*time.Second), since.Add(14*time.Second)),
+		disruption("kube-api-reused-connections", monitorapi.Error, since.Add(12*time.Second), since.Add(16*time.Second)),
+		// still disrupted.
+		disruption("kube-api-reused-connections", monitorapi.Error, since.Add(28*time.Second), time.Time{}),
+		// other backends and levels do not count.
+		disruption("cache-kube-api-new-connections", monitorapi.Error, since, now),
+		disruption("openshift-api-new-connections", monitorapi.Error, since, now),
+		disruption("kube-api-new-connections", monitorapi.Info, since, now),
+	}
+
+	from, to, disrupted := kubeAPIDisruption(intervals, since, now)
+	assert.Equal(t, since, from)
+	assert.Equal(t, now, to)
+	assert.Equal(t, 11*time.Second, disrupted)
+
+	_, _, disrupted = kubeAPIDisruption(intervals[4:], since, now)
+	assert.Zero(t, disrupted)
+}
+
+func TestDisruptionTracker(t *testing.T) {
+	since := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	now := since.Add(pollInterval)
+	disruption := func(backend string, from, to time.Time) monitorapi.Interval {
+		return monitorapi.NewInterval(monitorapi.SourceDisruption, monitorapi.Error).
+			Locator(monitorapi.NewLocator().DisruptionRequiredOnly(backend, "kube-api")).
+			Message(monitorapi.NewMessage().HumanMessage("disrupted")).
+			Build(from, to)
+	}
+
+	tracker := newDisruptionTracker()
+	// the outage started in the previous poll and is still going on.
+	open := disruption("kube-api-new-connections", since.Add(-2*time.Second), time.Time{})
+	tracker.observe(open)
+	tracker.observe(disruption("kube-api-reused-connections", since.Add(-time.Minute), since.Add(-time.Second)))
+	tracker.observe(disruption("openshift-api-new-connections", since, now))
+
+	intervals := tracker.since(since)
+	require.Len(t, intervals, 1, "ended and other backends are dropped")
+	from, to, disrupted := kubeAPIDisruption(intervals, since, now)
+	assert.Equal(t, since, from)
+	assert.Equal(t, now, to)
+	assert.Equal(t, pollInterval, disrupted)
+
+	// ending the interval replaces the open one.
+	ended := open
+	ended.To = since.Add(5 * time.Second)
+	tracker.observe(ended)
+	_, _, disrupted = kubeAPIDisruption(tracker.since(since), since, now)
+	assert.Equal(t, 5*time.Second, disrupted)
+	assert.Empty(t, tracker.since(now))
+}
+
+func TestCaptureTrigger(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	trigger := &captureTrigger{}
+
+	assert.False(t, trigger.shouldCapture(start, disruptionThreshold-time.Second), "below the threshold")
+	assert.True(t, trigger.shouldCapture(start, disruptionThreshold))
+	assert.False(t, trigger.shouldCapture(start.Add(captureCooldown/2), time.Minute), "cooling down")
+	assert.True(t, trigger.shouldCapture(start.Add(captureCooldown), time.Minute))
+	assert.True(t, trigger.shouldCapture(start.Add(2*captureCooldown), time.Minute))
+	assert.False(t, trigger.shouldCapture(start.Add(3*captureCooldown), time.Minute), "captured the maximum")
+	assert.Equal(t, maxCaptures, trigger.captures)
+}
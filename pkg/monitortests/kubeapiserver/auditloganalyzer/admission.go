@@ -0,0 +1,186 @@
+package auditloganalyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+)
+
+const (
+	admissionDeniedTestName   = "[sig-auth] openshift components should not be denied by SecurityContextConstraints or PodSecurity admission"
+	podSecurityAuditTestName  = "[sig-auth] openshift components should not violate PodSecurity"
+	podSecurityAuditViolation = "pod-security.kubernetes.io/audit-violations"
+)
+
+// admissionViolationKind tells the admission plugin that objected to a request, and whether it denied it.
+type admissionViolationKind string
+
+const (
+	podSecurityDenied admissionViolationKind = "PodSecurity denied"
+	podSecurityAudit  admissionViolationKind = "PodSecurity audit violation"
+	sccDenied         admissionViolationKind = "SecurityContextConstraints denied"
+)
+
+// admissionSubject is who the violations are aggregated by: the namespace of the object and the service account its
+// pods run as.  When the audit event does not carry the object, the requesting user stands in for the service account.
+type admissionSubject struct {
+	namespace      string
+	serviceAccount string
+	kind           admissionViolationKind
+}
+
+type admissionViolationCount struct {
+	count int
+	// message is the message of the first violation, they rarely differ for a subject.
+	message string
+}
+
+// AdmissionViolations counts the requests that SecurityContextConstraints or PodSecurity admission denied, and the
+// objects that violated the PodSecurity audit level, per namespace and service account.  Like the rest of the summary
+// it is not threadsafe.
+type AdmissionViolations struct {
+	perSubject map[admissionSubject]*admissionViolationCount
+}
+
+func NewAdmissionViolations() *AdmissionViolations {
+	return &AdmissionViolations{
+		perSubject: map[admissionSubject]*admissionViolationCount{},
+	}
+}
+
+// admissionViolation returns the kind of violation the audit event records and its message.
+func admissionViolation(auditEvent *auditv1.Event) (admissionViolationKind, string, bool) {
+	// every stage of a request repeats its annotations
+	if auditEvent.Stage != auditv1.StageResponseComplete {
+		return "", "", false
+	}
+	if auditEvent.ResponseStatus != nil && auditEvent.ResponseStatus.Code == 403 {
+		switch message := auditEvent.ResponseStatus.Message; {
+		case strings.Contains(message, "violates PodSecurity"):
+			return podSecurityDenied, message, true
+		case strings.Contains(message, "unable to validate against any security context constraint"):
+			return sccDenied, message, true
+		}
+	}
+	if violations := auditEvent.Annotations[podSecurityAuditViolation]; len(violations) > 0 {
+		return podSecurityAudit, violations, true
+	}
+	return "", "", false
+}
+
+// serviceAccountOf returns the service account the pods of the audited object run as, for pods and the workloads
+// templating them.
+func serviceAccountOf(auditEvent *auditv1.Event) string {
+	if auditEvent.RequestObject != nil {
+		object := struct {
+			Spec struct {
+				ServiceAccountName string `json:"serviceAccountName"`
+				Template           struct {
+					Spec struct {
+						ServiceAccountName string `json:"serviceAccountName"`
+					} `json:"spec"`
+				} `json:"template"`
+			} `json:"spec"`
+		}{}
+		if err := json.Unmarshal(auditEvent.RequestObject.Raw, &object); err == nil {
+			if len(object.Spec.ServiceAccountName) > 0 {
+				return object.Spec.ServiceAccountName
+			}
+			if len(object.Spec.Template.Spec.ServiceAccountName) > 0 {
+				return object.Spec.Template.Spec.ServiceAccountName
+			}
+		}
+	}
+	return auditEvent.User.Username
+}
+
+func (s *AdmissionViolations) Add(auditEvent *auditv1.Event) {
+	kind, message, ok := admissionViolation(auditEvent)
+	if !ok || auditEvent.ObjectRef == nil {
+		return
+	}
+	subject := admissionSubject{
+		namespace:      auditEvent.ObjectRef.Namespace,
+		serviceAccount: serviceAccountOf(auditEvent),
+		kind:           kind,
+	}
+	if _, ok := s.perSubject[subject]; !ok {
+		s.perSubject[subject] = &admissionViolationCount{message: message}
+	}
+	s.perSubject[subject].count++
+}
+
+func (s *AdmissionViolations) AddSummary(rhs *AdmissionViolations) {
+	for k, v := range rhs.perSubject {
+		if _, ok := s.perSubject[k]; !ok {
+			s.perSubject[k] = &admissionViolationCount{message: v.message}
+		}
+		s.perSubject[k].count += v.count
+	}
+}
+
+// isPayloadNamespace matches the namespaces of the components of the payload.  Tests provoke violations in their own
+// namespaces on purpose.
+func isPayloadNamespace(namespace string) bool {
+	if strings.HasPrefix(namespace, "openshift-must-gather") {
+		return false
+	}
+	return strings.HasPrefix(namespace, "openshift-") || strings.HasPrefix(namespace, "kube-")
+}
+
+// violationsOfPayload lists the violations in payload namespaces matching the kinds, one line per subject.
+func (s *AdmissionViolations) violationsOfPayload(kinds ...admissionViolationKind) []string {
+	ret := []string{}
+	for subject, violations := range s.perSubject {
+		if !isPayloadNamespace(subject.namespace) {
+			continue
+		}
+		for _, kind := range kinds {
+			if subject.kind == kind {
+				ret = append(ret, fmt.Sprintf("ns/%s serviceaccount/%s: %s %d times: %s",
+					subject.namespace, subject.serviceAccount, subject.kind, violations.count, violations.message))
+			}
+		}
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// admissionViolationsJunits reports the payload components that security admission denied, which otherwise surface
+// as pods that never started, and those that would be denied by a stricter PodSecurity level.
+func admissionViolationsJunits(violations *AdmissionViolations) []*junitapi.JUnitTestCase {
+	ret := []*junitapi.JUnitTestCase{}
+
+	denied := violations.violationsOfPayload(podSecurityDenied, sccDenied)
+	if len(denied) == 0 {
+		ret = append(ret, &junitapi.JUnitTestCase{Name: admissionDeniedTestName})
+	} else {
+		ret = append(ret, &junitapi.JUnitTestCase{
+			Name: admissionDeniedTestName,
+			FailureOutput: &junitapi.FailureOutput{
+				Output: fmt.Sprintf("security admission denied requests of %d payload service accounts:\n\n%s", len(denied), strings.Join(denied, "\n")),
+			},
+		})
+		// TODO: marked flaky until we know which components are denied in CI
+		ret = append(ret, &junitapi.JUnitTestCase{Name: admissionDeniedTestName})
+	}
+
+	audited := violations.violationsOfPayload(podSecurityAudit)
+	if len(audited) == 0 {
+		ret = append(ret, &junitapi.JUnitTestCase{Name: podSecurityAuditTestName})
+	} else {
+		ret = append(ret, &junitapi.JUnitTestCase{
+			Name: podSecurityAuditTestName,
+			FailureOutput: &junitapi.FailureOutput{
+				Output: fmt.Sprintf("%d payload service accounts violated the PodSecurity audit level of their namespace:\n\n%s", len(audited), strings.Join(audited, "\n")),
+			},
+		})
+		// TODO: marked flaky until the payload is labeled for PodSecurity
+		ret = append(ret, &junitapi.JUnitTestCase{Name: podSecurityAuditTestName})
+	}
+	return ret
+}
@@ -0,0 +1,58 @@
+package auditloganalyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authnv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+)
+
+func TestAdmissionViolations(t *testing.T) {
+	auditEvent := func(namespace, user string, code int32, message string, annotations map[string]string, requestObject string) *auditv1.Event {
+		ret := &auditv1.Event{
+			Stage:          auditv1.StageResponseComplete,
+			User:           authnv1.UserInfo{Username: user},
+			ObjectRef:      &auditv1.ObjectReference{Namespace: namespace, Resource: "pods"},
+			ResponseStatus: &metav1.Status{Code: code, Message: message},
+			Annotations:    annotations,
+		}
+		if len(requestObject) > 0 {
+			ret.RequestObject = &runtime.Unknown{Raw: []byte(requestObject)}
+		}
+		return ret
+	}
+
+	summary := NewAuditLogSummary()
+	perNode := NewAuditLogSummary()
+	denied := auditEvent("openshift-dns", "system:serviceaccount:kube-system:daemon-set-controller", 403,
+		`pods "dns-default-abcde" is forbidden: unable to validate against any security context constraint: [provider "restricted-v2": Forbidden]`,
+		nil, `{"spec":{"serviceAccountName":"dns"}}`)
+	perNode.Add(denied, auditEventInfo{})
+	perNode.Add(denied, auditEventInfo{})
+	// the first stage of the same request is not counted again
+	received := *denied
+	received.Stage = auditv1.StageRequestReceived
+	perNode.Add(&received, auditEventInfo{})
+	perNode.Add(auditEvent("openshift-monitoring", "system:serviceaccount:openshift-monitoring:prometheus-operator", 201, "",
+		map[string]string{podSecurityAuditViolation: `would violate PodSecurity "restricted:latest": allowPrivilegeEscalation != false`}, ""), auditEventInfo{})
+	// tests violate PodSecurity in their own namespaces on purpose
+	perNode.Add(auditEvent("e2e-test-psa", "e2e-user", 403, `pods "test" is forbidden: violates PodSecurity "restricted:latest": privileged`, nil, ""), auditEventInfo{})
+	summary.AddSummary(perNode)
+
+	junits := admissionViolationsJunits(summary.admissionViolations)
+	require.Len(t, junits, 4)
+
+	assert.Equal(t, admissionDeniedTestName, junits[0].Name)
+	require.NotNil(t, junits[0].FailureOutput)
+	assert.Contains(t, junits[0].FailureOutput.Output, "ns/openshift-dns serviceaccount/dns: SecurityContextConstraints denied 2 times")
+	assert.NotContains(t, junits[0].FailureOutput.Output, "e2e-test-psa")
+	assert.Nil(t, junits[1].FailureOutput)
+
+	assert.Equal(t, podSecurityAuditTestName, junits[2].Name)
+	require.NotNil(t, junits[2].FailureOutput)
+	assert.Contains(t, junits[2].FailureOutput.Output, "ns/openshift-monitoring serviceaccount/system:serviceaccount:openshift-monitoring:prometheus-operator: PodSecurity audit violation 1 times")
+}
@@ -29,6 +29,8 @@ type AuditLogSummary struct {
 	perUserRequestCount       map[string]*PerUserRequestCount
 	perResourceRequestCount   map[schema.GroupVersionResource]*PerResourceRequestCount
 	perHTTPStatusRequestCount map[int32]*PerHTTPStatusRequestCount
+	admissionViolations       *AdmissionViolations
+	rbacDenials               *RBACDenials
 }
 
 type RequestCounts struct {
@@ -111,6 +113,9 @@ func (s *AuditLogSummary) Add(auditEvent *auditv1.Event, auditEventInfo auditEve
 		}
 		s.perHTTPStatusRequestCount[httpStatus].Add(auditEvent, auditEventInfo)
 	}
+
+	s.admissionViolations.Add(auditEvent)
+	s.rbacDenials.Add(auditEvent, auditEventInfo)
 }
 
 func (s *RequestCounts) Add(auditEvent *auditv1.Event) {
@@ -220,6 +225,8 @@ func (s *AuditLogSummary) AddSummary(rhs *AuditLogSummary) {
 		}
 		s.perHTTPStatusRequestCount[k].AddSummary(v)
 	}
+	s.admissionViolations.AddSummary(rhs.admissionViolations)
+	s.rbacDenials.AddSummary(rhs.rbacDenials)
 }
 
 func (s *RequestCounts) AddSummary(rhs *RequestCounts) {
@@ -305,6 +312,8 @@ func NewAuditLogSummary() *AuditLogSummary {
 		perUserRequestCount:       map[string]*PerUserRequestCount{},
 		perResourceRequestCount:   map[schema.GroupVersionResource]*PerResourceRequestCount{},
 		perHTTPStatusRequestCount: map[int32]*PerHTTPStatusRequestCount{},
+		admissionViolations:       NewAdmissionViolations(),
+		rbacDenials:               NewRBACDenials(),
 	}
 }
 func NewRequestCounts() *RequestCounts {
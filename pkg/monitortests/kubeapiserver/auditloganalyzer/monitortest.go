@@ -36,8 +36,13 @@ func (w *auditLogAnalyzer) CollectData(ctx context.Context, storageDir string, b
 
 	auditLogSummary, auditEvents, err := intervalsFromAuditLogs(ctx, kubeClient, beginning, end)
 	w.auditLogSummary = auditLogSummary
+	if auditLogSummary == nil {
+		return auditEvents, nil, err
+	}
 
-	return auditEvents, nil, err
+	junits := admissionViolationsJunits(auditLogSummary.admissionViolations)
+	junits = append(junits, rbacDenialJunits(auditLogSummary.rbacDenials, beginning)...)
+	return auditEvents, junits, err
 }
 
 func (*auditLogAnalyzer) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
@@ -69,6 +74,7 @@ func intervalsFromAuditLogs(ctx context.Context, kubeClient kubernetes.Interface
 		// TODO report the error AND the best possible summary we have
 		return auditLogSummary, nil, err
 	}
+	ret = append(ret, rbacDenialSpikeIntervals(auditLogSummary.rbacDenials)...)
 
 	return auditLogSummary, ret, nil
 }
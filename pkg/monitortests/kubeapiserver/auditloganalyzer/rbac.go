@@ -0,0 +1,179 @@
+package auditloganalyzer
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+)
+
+const (
+	rbacDenialTestName = "[sig-auth] platform service accounts should not start being denied by RBAC during the run"
+
+	// authorizationDecision is the audit annotation the authorizer records its decision in.
+	authorizationDecision = "authorization.k8s.io/decision"
+
+	// rbacDenialSpikeThreshold is how many requests of a user with a verb on a resource have to be denied within a
+	// minute to count as a spike.  Components probing for optional APIs get denied now and then.
+	rbacDenialSpikeThreshold = 10
+	// rbacBaselineWindow is the start of the run.  Users already denied then were denied before the run, not by a
+	// change of RBAC during it.
+	rbacBaselineWindow = 5 * time.Minute
+)
+
+// rbacDenialKey is who the denials are aggregated by.
+type rbacDenialKey struct {
+	user     string
+	verb     string
+	resource schema.GroupResource
+}
+
+// RBACDenials counts the requests the authorizer denied per user, verb and resource, by minute.  Like the rest of the
+// summary it is not threadsafe.
+type RBACDenials struct {
+	perMinute map[rbacDenialKey]map[time.Time]int
+}
+
+func NewRBACDenials() *RBACDenials {
+	return &RBACDenials{
+		perMinute: map[rbacDenialKey]map[time.Time]int{},
+	}
+}
+
+func (s *RBACDenials) Add(auditEvent *auditv1.Event, auditEventInfo auditEventInfo) {
+	if auditEvent.Stage != auditv1.StageResponseComplete || auditEvent.Annotations[authorizationDecision] != "forbid" {
+		return
+	}
+	gvr := auditEventInfo.getGroupVersionResource(auditEvent)
+	if auditEvent.ObjectRef != nil && len(auditEvent.ObjectRef.Resource) > 0 {
+		gvr = schema.GroupVersionResource{Group: auditEvent.ObjectRef.APIGroup, Resource: auditEvent.ObjectRef.Resource}
+	}
+	key := rbacDenialKey{
+		user:     auditEvent.User.Username,
+		verb:     auditEvent.Verb,
+		resource: gvr.GroupResource(),
+	}
+	if _, ok := s.perMinute[key]; !ok {
+		s.perMinute[key] = map[time.Time]int{}
+	}
+	s.perMinute[key][auditEvent.RequestReceivedTimestamp.Time.UTC().Truncate(time.Minute)]++
+}
+
+func (s *RBACDenials) AddSummary(rhs *RBACDenials) {
+	for k, v := range rhs.perMinute {
+		if _, ok := s.perMinute[k]; !ok {
+			s.perMinute[k] = map[time.Time]int{}
+		}
+		for minute, count := range v {
+			s.perMinute[k][minute] += count
+		}
+	}
+}
+
+// spikes returns the minutes the key was denied at least rbacDenialSpikeThreshold times, consecutive minutes merged
+// into one window, and the first time the key was denied at all.
+func (s *RBACDenials) spikes(key rbacDenialKey) ([]rbacDenialSpike, time.Time) {
+	minutes := []time.Time{}
+	for minute := range s.perMinute[key] {
+		minutes = append(minutes, minute)
+	}
+	sort.Slice(minutes, func(i, j int) bool { return minutes[i].Before(minutes[j]) })
+
+	ret := []rbacDenialSpike{}
+	for _, minute := range minutes {
+		count := s.perMinute[key][minute]
+		if count < rbacDenialSpikeThreshold {
+			continue
+		}
+		if last := len(ret) - 1; last >= 0 && ret[last].to.Equal(minute) {
+			ret[last].to = minute.Add(time.Minute)
+			ret[last].count += count
+			continue
+		}
+		ret = append(ret, rbacDenialSpike{from: minute, to: minute.Add(time.Minute), count: count})
+	}
+	return ret, minutes[0]
+}
+
+type rbacDenialSpike struct {
+	from, to time.Time
+	count    int
+}
+
+func (s *RBACDenials) keys() []rbacDenialKey {
+	ret := []rbacDenialKey{}
+	for key := range s.perMinute {
+		ret = append(ret, key)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].user != ret[j].user {
+			return ret[i].user < ret[j].user
+		}
+		if ret[i].resource != ret[j].resource {
+			return ret[i].resource.String() < ret[j].resource.String()
+		}
+		return ret[i].verb < ret[j].verb
+	})
+	return ret
+}
+
+// rbacDenialSpikeIntervals returns an interval for every spike of denials.
+func rbacDenialSpikeIntervals(denials *RBACDenials) monitorapi.Intervals {
+	ret := monitorapi.Intervals{}
+	for _, key := range denials.keys() {
+		spikes, _ := denials.spikes(key)
+		for _, spike := range spikes {
+			ret = append(ret, monitorapi.NewInterval(monitorapi.SourceAuditLog, monitorapi.Warning).
+				Locator(monitorapi.NewLocator().APIRequests(key.user, key.verb, key.resource)).
+				Message(monitorapi.NewMessage().Reason(monitorapi.RBACDenialSpikeReason).
+					WithAnnotation(monitorapi.AnnotationCount, strconv.Itoa(spike.count)).
+					HumanMessagef("RBAC denied %d %s requests on %s", spike.count, key.verb, key.resource)).
+				Display().
+				Build(spike.from, spike.to))
+		}
+	}
+	sort.Sort(ret)
+	return ret
+}
+
+// isPlatformServiceAccount matches the service accounts of the components of the payload.
+func isPlatformServiceAccount(user string) bool {
+	return strings.HasPrefix(user, "system:serviceaccount:openshift-") || strings.HasPrefix(user, "system:serviceaccount:kube-")
+}
+
+// rbacDenialJunits fails when a platform service account that was not denied at the start of the run got denied
+// repeatedly later on, a classic symptom of an operator running ahead of, or behind, the RBAC it needs during an
+// upgrade.
+func rbacDenialJunits(denials *RBACDenials, beginning time.Time) []*junitapi.JUnitTestCase {
+	failures := []string{}
+	for _, key := range denials.keys() {
+		if !isPlatformServiceAccount(key.user) {
+			continue
+		}
+		spikes, firstDenied := denials.spikes(key)
+		if len(spikes) == 0 || firstDenied.Before(beginning.Add(rbacBaselineWindow)) {
+			continue
+		}
+		for _, spike := range spikes {
+			failures = append(failures, fmt.Sprintf("%s was denied %s on %s %d times from %s to %s",
+				key.user, key.verb, key.resource, spike.count, spike.from.Format("15:04:05"), spike.to.Format("15:04:05")))
+		}
+	}
+	if len(failures) == 0 {
+		return []*junitapi.JUnitTestCase{{Name: rbacDenialTestName}}
+	}
+	return []*junitapi.JUnitTestCase{
+		{
+			Name: rbacDenialTestName,
+			FailureOutput: &junitapi.FailureOutput{
+				Output: fmt.Sprintf("platform service accounts started being denied by RBAC during the run:\n\n%s", strings.Join(failures, "\n")),
+			},
+		},
+	}
+}
@@ -0,0 +1,57 @@
+package auditloganalyzer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authnv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+)
+
+func TestRBACDenials(t *testing.T) {
+	beginning := time.Date(2024, 4, 12, 11, 0, 0, 0, time.UTC)
+	denied := func(user, verb, group, resource string, at time.Time) *auditv1.Event {
+		return &auditv1.Event{
+			Stage:                    auditv1.StageResponseComplete,
+			User:                     authnv1.UserInfo{Username: user},
+			Verb:                     verb,
+			ObjectRef:                &auditv1.ObjectReference{APIGroup: group, Resource: resource},
+			ResponseStatus:           &metav1.Status{Code: 403},
+			Annotations:              map[string]string{authorizationDecision: "forbid"},
+			RequestReceivedTimestamp: metav1.NewMicroTime(at),
+		}
+	}
+
+	summary := NewAuditLogSummary()
+	operator := "system:serviceaccount:openshift-ingress-operator:ingress-operator"
+	prober := "system:serviceaccount:openshift-monitoring:prometheus-operator"
+	for i := 0; i < 25; i++ {
+		// denied for two consecutive minutes halfway through the run
+		summary.Add(denied(operator, "list", "route.openshift.io", "routes", beginning.Add(30*time.Minute+time.Duration(i)*4*time.Second)), auditEventInfo{})
+		// denied from the start, before the run changed anything
+		summary.Add(denied(prober, "get", "", "secrets", beginning.Add(time.Minute+time.Duration(i)*time.Second)), auditEventInfo{})
+		summary.Add(denied(prober, "get", "", "secrets", beginning.Add(40*time.Minute+time.Duration(i)*time.Second)), auditEventInfo{})
+	}
+	// allowed requests are not counted
+	allowed := denied(operator, "list", "route.openshift.io", "routes", beginning.Add(30*time.Minute))
+	allowed.Annotations[authorizationDecision] = "allow"
+	summary.Add(allowed, auditEventInfo{})
+
+	intervals := rbacDenialSpikeIntervals(summary.rbacDenials)
+	require.Len(t, intervals, 3)
+	assert.Equal(t, monitorapi.RBACDenialSpikeReason, intervals[0].Message.Reason)
+	assert.Equal(t, operator, intervals[1].Locator.Keys[monitorapi.LocatorUserKey])
+	assert.Equal(t, beginning.Add(30*time.Minute), intervals[1].From)
+	assert.Equal(t, beginning.Add(32*time.Minute), intervals[1].To)
+	assert.Equal(t, "25", intervals[1].Message.Annotations[monitorapi.AnnotationCount])
+
+	junits := rbacDenialJunits(summary.rbacDenials, beginning)
+	require.Len(t, junits, 1)
+	require.NotNil(t, junits[0].FailureOutput)
+	assert.Contains(t, junits[0].FailureOutput.Output, operator+" was denied list on routes.route.openshift.io 25 times from 11:30:00 to 11:32:00")
+	assert.NotContains(t, junits[0].FailureOutput.Output, prober)
+}
@@ -0,0 +1,205 @@
+package crdhealth
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	crdConditionsTestName        = "[sig-api-machinery] CRDs should remain established and keep their names accepted"
+	crdConversionWebhookTestName = "[sig-api-machinery] CRD conversion webhooks should not fail"
+)
+
+var crdResource = apiextensionsv1.SchemeGroupVersion.WithResource("customresourcedefinitions")
+
+// trackedConditions are the CRD conditions that must stay true once they are, the resources of a CRD that is not
+// established are not served and every controller watching them fails at once.
+var trackedConditions = map[apiextensionsv1.CustomResourceDefinitionConditionType]monitorapi.IntervalReason{
+	apiextensionsv1.Established:   monitorapi.CRDNotEstablishedReason,
+	apiextensionsv1.NamesAccepted: monitorapi.CRDNamesNotAcceptedReason,
+}
+
+// conditionLoss is a tracked condition of a CRD going from true to anything else, end is zero until it is true again.
+type conditionLoss struct {
+	crd       string
+	condition apiextensionsv1.CustomResourceDefinitionConditionType
+	message   string
+	start     time.Time
+	end       time.Time
+}
+
+// crdConditionTracker follows the tracked conditions of every CRD, and the CRDs serving each group and kind to
+// locate conversion webhook failures.
+type crdConditionTracker struct {
+	wasTrue    map[string]bool
+	lost       map[string]*conditionLoss
+	losses     []*conditionLoss
+	groupKinds map[schema.GroupKind]string
+}
+
+func newCRDConditionTracker() *crdConditionTracker {
+	return &crdConditionTracker{
+		wasTrue:    map[string]bool{},
+		lost:       map[string]*conditionLoss{},
+		groupKinds: map[schema.GroupKind]string{},
+	}
+}
+
+// observe records a loss when a tracked condition that was true is not anymore.  New CRDs are not established until
+// their names are accepted, and deleted CRDs stop being served on purpose, neither is a loss.
+func (t *crdConditionTracker) observe(crd *apiextensionsv1.CustomResourceDefinition, now time.Time) {
+	t.groupKinds[schema.GroupKind{Group: crd.Spec.Group, Kind: crd.Spec.Names.Kind}] = crd.Name
+	for conditionType := range trackedConditions {
+		key := crd.Name + "/" + string(conditionType)
+		status, message := conditionStatus(crd, conditionType)
+		current, lost := t.lost[key]
+		switch {
+		case crd.DeletionTimestamp != nil || status:
+			if lost {
+				current.end = now
+				delete(t.lost, key)
+			}
+			if crd.DeletionTimestamp == nil {
+				t.wasTrue[key] = true
+			}
+		case !lost && t.wasTrue[key]:
+			current = &conditionLoss{crd: crd.Name, condition: conditionType, message: message, start: now}
+			t.lost[key] = current
+			t.losses = append(t.losses, current)
+		}
+	}
+}
+
+// forget ends the losses of a deleted CRD.
+func (t *crdConditionTracker) forget(name string, now time.Time) {
+	for conditionType := range trackedConditions {
+		key := name + "/" + string(conditionType)
+		if current, ok := t.lost[key]; ok {
+			current.end = now
+			delete(t.lost, key)
+		}
+		delete(t.wasTrue, key)
+	}
+}
+
+func conditionStatus(crd *apiextensionsv1.CustomResourceDefinition, conditionType apiextensionsv1.CustomResourceDefinitionConditionType) (bool, string) {
+	for _, condition := range crd.Status.Conditions {
+		if condition.Type == conditionType {
+			return condition.Status == apiextensionsv1.ConditionTrue, condition.Message
+		}
+	}
+	return false, ""
+}
+
+// conditionLossIntervals returns the losses, those still lost end with the run.
+func conditionLossIntervals(losses []*conditionLoss, end time.Time) monitorapi.Intervals {
+	ret := monitorapi.Intervals{}
+	for _, loss := range losses {
+		to := loss.end
+		if to.IsZero() {
+			to = end
+		}
+		message := monitorapi.NewMessage().Reason(trackedConditions[loss.condition]).
+			WithAnnotation(monitorapi.AnnotationCondition, string(loss.condition))
+		if len(loss.message) > 0 {
+			message.HumanMessagef("%s was not true: %s", loss.condition, loss.message)
+		} else {
+			message.HumanMessagef("%s was not true", loss.condition)
+		}
+		ret = append(ret, monitorapi.NewInterval(monitorapi.SourceCRDHealth, monitorapi.Error).
+			Locator(monitorapi.NewLocator().ForGVR(crdResource, "", loss.crd)).
+			Message(message).
+			Display().
+			Build(loss.start, to))
+	}
+	sort.Sort(ret)
+	return ret
+}
+
+// conversionWebhookFailureRegex matches the error of the apiserver when a conversion webhook fails, which controllers
+// report in their events.  The group version kind prints as "group/version, Kind=Kind".
+var conversionWebhookFailureRegex = regexp.MustCompile(`conversion webhook for (\S+), Kind=(\S+) failed: (.*)`)
+
+// conversionWebhookIntervals finds the conversion webhook failures in the events and locates them on the CRD of the
+// converted kind when it is known.
+func conversionWebhookIntervals(events monitorapi.Intervals, groupKinds map[schema.GroupKind]string) monitorapi.Intervals {
+	ret := monitorapi.Intervals{}
+	for _, event := range events {
+		if event.Source != monitorapi.SourceKubeEvent {
+			continue
+		}
+		matches := conversionWebhookFailureRegex.FindStringSubmatch(event.Message.HumanMessage)
+		if matches == nil {
+			continue
+		}
+		group := ""
+		if i := strings.LastIndex(matches[1], "/"); i >= 0 {
+			group = matches[1][:i]
+		}
+		locator := event.Locator
+		if crd, ok := groupKinds[schema.GroupKind{Group: group, Kind: matches[2]}]; ok {
+			locator = monitorapi.NewLocator().ForGVR(crdResource, "", crd)
+		}
+		ret = append(ret, monitorapi.NewInterval(monitorapi.SourceCRDHealth, monitorapi.Error).
+			Locator(locator).
+			Message(monitorapi.NewMessage().Reason(monitorapi.CRDConversionWebhookFailedReason).
+				HumanMessagef("conversion webhook for %s, Kind=%s failed: %s", matches[1], matches[2], matches[3])).
+			Display().
+			Build(event.From, event.To))
+	}
+	return ret
+}
+
+// crdHealthJUnits fails on the CRDs that lost their conditions.  Conversion webhooks are deployed by their operators
+// and by e2e tests, so their failures only flake.
+func crdHealthJUnits(intervals monitorapi.Intervals) []*junitapi.JUnitTestCase {
+	losses, webhookFailures := []string{}, map[string]int{}
+	for _, interval := range intervals {
+		if interval.Source != monitorapi.SourceCRDHealth {
+			continue
+		}
+		switch interval.Message.Reason {
+		case monitorapi.CRDNotEstablishedReason, monitorapi.CRDNamesNotAcceptedReason:
+			losses = append(losses, fmt.Sprintf("%s %s for %s: %s", interval.Locator.OldLocator(),
+				interval.From.Format(time.RFC3339), interval.To.Sub(interval.From).Round(time.Second), interval.Message.HumanMessage))
+		case monitorapi.CRDConversionWebhookFailedReason:
+			webhookFailures[interval.Locator.OldLocator()]++
+		}
+	}
+
+	ret := []*junitapi.JUnitTestCase{}
+	if len(losses) > 0 {
+		ret = append(ret, &junitapi.JUnitTestCase{
+			Name: crdConditionsTestName,
+			FailureOutput: &junitapi.FailureOutput{
+				Output: fmt.Sprintf("%d CRD conditions were lost:\n\n%s", len(losses), strings.Join(losses, "\n")),
+			},
+		})
+	} else {
+		ret = append(ret, &junitapi.JUnitTestCase{Name: crdConditionsTestName})
+	}
+
+	if len(webhookFailures) > 0 {
+		failures := []string{}
+		for locator, count := range webhookFailures {
+			failures = append(failures, fmt.Sprintf("%s failed %d times", locator, count))
+		}
+		sort.Strings(failures)
+		ret = append(ret, &junitapi.JUnitTestCase{
+			Name: crdConversionWebhookTestName,
+			FailureOutput: &junitapi.FailureOutput{
+				Output: fmt.Sprintf("conversion webhooks failed for %d CRDs:\n\n%s", len(webhookFailures), strings.Join(failures, "\n")),
+			},
+		})
+	}
+	ret = append(ret, &junitapi.JUnitTestCase{Name: crdConversionWebhookTestName})
+	return ret
+}
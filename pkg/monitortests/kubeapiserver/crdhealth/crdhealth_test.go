@@ -0,0 +1,93 @@
+package crdhealth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func crdWithConditions(established, namesAccepted apiextensionsv1.ConditionStatus) *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "machines.machine.openshift.io"},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: "machine.openshift.io",
+			Names: apiextensionsv1.CustomResourceDefinitionNames{Kind: "Machine"},
+		},
+		Status: apiextensionsv1.CustomResourceDefinitionStatus{
+			Conditions: []apiextensionsv1.CustomResourceDefinitionCondition{
+				{Type: apiextensionsv1.Established, Status: established, Message: "not served"},
+				{Type: apiextensionsv1.NamesAccepted, Status: namesAccepted},
+			},
+		},
+	}
+}
+
+func TestCRDConditionTracker(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	tracker := newCRDConditionTracker()
+	// a new CRD is not established until its names are accepted, that is not a loss.
+	tracker.observe(crdWithConditions(apiextensionsv1.ConditionFalse, apiextensionsv1.ConditionFalse), start)
+	tracker.observe(crdWithConditions(apiextensionsv1.ConditionFalse, apiextensionsv1.ConditionTrue), start.Add(time.Second))
+	tracker.observe(crdWithConditions(apiextensionsv1.ConditionTrue, apiextensionsv1.ConditionTrue), start.Add(2*time.Second))
+	assert.Empty(t, tracker.losses)
+
+	tracker.observe(crdWithConditions(apiextensionsv1.ConditionFalse, apiextensionsv1.ConditionTrue), start.Add(time.Minute))
+	tracker.observe(crdWithConditions(apiextensionsv1.ConditionFalse, apiextensionsv1.ConditionTrue), start.Add(2*time.Minute))
+	tracker.observe(crdWithConditions(apiextensionsv1.ConditionTrue, apiextensionsv1.ConditionTrue), start.Add(3*time.Minute))
+	// lost again until the end of the run.
+	tracker.observe(crdWithConditions(apiextensionsv1.ConditionTrue, apiextensionsv1.ConditionUnknown), start.Add(10*time.Minute))
+
+	intervals := conditionLossIntervals(tracker.losses, end)
+	require.Len(t, intervals, 2)
+	assert.Equal(t, monitorapi.CRDNotEstablishedReason, intervals[0].Message.Reason)
+	assert.Equal(t, "Established was not true: not served", intervals[0].Message.HumanMessage)
+	assert.Equal(t, 2*time.Minute, intervals[0].To.Sub(intervals[0].From))
+	assert.Equal(t, "machines.machine.openshift.io", intervals[0].Locator.Keys[monitorapi.LocatorNameKey])
+	assert.Equal(t, monitorapi.CRDNamesNotAcceptedReason, intervals[1].Message.Reason)
+	assert.Equal(t, end, intervals[1].To)
+
+	// deleting the CRD ends its losses.
+	tracker.forget("machines.machine.openshift.io", start.Add(20*time.Minute))
+	intervals = conditionLossIntervals(tracker.losses, end)
+	assert.Equal(t, start.Add(20*time.Minute), intervals[1].To)
+}
+
+func TestConversionWebhookIntervals(t *testing.T) {
+	tracker := newCRDConditionTracker()
+	tracker.observe(crdWithConditions(apiextensionsv1.ConditionTrue, apiextensionsv1.ConditionTrue), time.Now())
+
+	from := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	events := monitorapi.Intervals{
+		monitorapi.NewInterval(monitorapi.SourceKubeEvent, monitorapi.Warning).
+			Locator(monitorapi.NewLocator().NodeFromName("worker-0")).
+			Message(monitorapi.NewMessage().HumanMessage(`failed to list machine.openshift.io/v1beta1, Kind=Machine: conversion webhook for machine.openshift.io/v1beta1, Kind=Machine failed: Post "https://webhook.openshift-machine-api.svc:443/convert": connection refused`)).
+			Build(from, from.Add(time.Second)),
+		monitorapi.NewInterval(monitorapi.SourceKubeEvent, monitorapi.Warning).
+			Locator(monitorapi.NewLocator().NodeFromName("worker-0")).
+			Message(monitorapi.NewMessage().HumanMessage(`conversion webhook for example.com/v2, Kind=Widget failed: timeout`)).
+			Build(from, from.Add(time.Second)),
+		monitorapi.NewInterval(monitorapi.SourceKubeEvent, monitorapi.Warning).
+			Locator(monitorapi.NewLocator().NodeFromName("worker-0")).
+			Message(monitorapi.NewMessage().HumanMessage("Readiness probe failed")).
+			Build(from, from.Add(time.Second)),
+	}
+	intervals := conversionWebhookIntervals(events, tracker.groupKinds)
+	require.Len(t, intervals, 2)
+	assert.Equal(t, "machines.machine.openshift.io", intervals[0].Locator.Keys[monitorapi.LocatorNameKey])
+	assert.Contains(t, intervals[0].Message.HumanMessage, "connection refused")
+	assert.Equal(t, "worker-0", intervals[1].Locator.Keys[monitorapi.LocatorNodeKey], "unknown kinds keep the locator of the event")
+
+	junits := crdHealthJUnits(intervals)
+	require.Len(t, junits, 3)
+	assert.Nil(t, junits[0].FailureOutput)
+	require.NotNil(t, junits[1].FailureOutput)
+	assert.Contains(t, junits[1].FailureOutput.Output, "conversion webhooks failed for 2 CRDs")
+	assert.Nil(t, junits[2].FailureOutput, "conversion webhook failures only flake")
+}
@@ -0,0 +1,128 @@
+package crdhealth
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	exutil "github.com/openshift/origin/test/extended/util"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	apiextensionsinformers "k8s.io/apiextensions-apiserver/pkg/client/informers/externalversions"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+)
+
+type crdHealthTracker struct {
+	notSupportedReason error
+
+	lock    sync.Mutex
+	tracker *crdConditionTracker
+}
+
+// NewCRDHealthTracker records CRDs losing their Established or NamesAccepted conditions and the failures of their
+// conversion webhooks.  An unavailable CRD breaks every controller that watches it at once, which is hard to trace
+// back from the failures of the controllers.
+func NewCRDHealthTracker() monitortestframework.MonitorTest {
+	return &crdHealthTracker{tracker: newCRDConditionTracker()}
+}
+
+func (*crdHealthTracker) Describe() monitortestframework.MonitorTestDescription {
+	return monitortestframework.MonitorTestDescription{
+		WatchedResources: []string{"customresourcedefinitions.apiextensions.k8s.io", "events"},
+		JUnits:           []string{crdConditionsTestName, crdConversionWebhookTestName},
+	}
+}
+
+func (w *crdHealthTracker) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	kubeClient, err := kubernetes.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return err
+	}
+	isMicroShift, err := exutil.IsMicroShiftCluster(kubeClient)
+	if err != nil {
+		return fmt.Errorf("unable to determine if cluster is MicroShift: %v", err)
+	}
+	if isMicroShift {
+		w.notSupportedReason = &monitortestframework.NotSupportedError{
+			Reason: "platform MicroShift not supported",
+		}
+		return w.notSupportedReason
+	}
+	apiextensionsClient, err := apiextensionsclient.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return err
+	}
+
+	crdInformers := apiextensionsinformers.NewSharedInformerFactory(apiextensionsClient, 0)
+	observe := func(obj interface{}) {
+		crd, ok := obj.(*apiextensionsv1.CustomResourceDefinition)
+		if !ok {
+			return
+		}
+		w.lock.Lock()
+		defer w.lock.Unlock()
+		w.tracker.observe(crd, time.Now())
+	}
+	_, err = crdInformers.Apiextensions().V1().CustomResourceDefinitions().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: observe,
+		UpdateFunc: func(_, obj interface{}) {
+			observe(obj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			crd, ok := obj.(*apiextensionsv1.CustomResourceDefinition)
+			if !ok {
+				return
+			}
+			w.lock.Lock()
+			defer w.lock.Unlock()
+			w.tracker.forget(crd.Name, time.Now())
+		},
+	})
+	if err != nil {
+		return err
+	}
+	crdInformers.Start(ctx.Done())
+	return nil
+}
+
+func (w *crdHealthTracker) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	if w.notSupportedReason != nil {
+		return nil, nil, w.notSupportedReason
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return conditionLossIntervals(w.tracker.losses, end), nil, nil
+}
+
+func (w *crdHealthTracker) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	if w.notSupportedReason != nil {
+		return nil, w.notSupportedReason
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return conversionWebhookIntervals(startingIntervals, w.tracker.groupKinds), nil
+}
+
+func (w *crdHealthTracker) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	if w.notSupportedReason != nil {
+		return nil, w.notSupportedReason
+	}
+	return crdHealthJUnits(finalIntervals), nil
+}
+
+func (*crdHealthTracker) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	return nil
+}
+
+func (*crdHealthTracker) Cleanup(ctx context.Context) error {
+	return nil
+}
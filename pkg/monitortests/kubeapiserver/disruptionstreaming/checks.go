@@ -0,0 +1,198 @@
+package disruptionstreaming
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/backenddisruption"
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/transport/spdy"
+)
+
+const (
+	// checkTimeout bounds each exec and port-forward, a streaming request that hangs is as broken as one that fails.
+	checkTimeout = 15 * time.Second
+
+	execBackend        = "kube-api-exec"
+	portForwardBackend = "kube-api-port-forward"
+)
+
+// streamingCheck exercises one streaming path of the apiserver against the target pod.
+type streamingCheck func(ctx context.Context) error
+
+// execCheck runs a command in the target pod, which upgrades the connection through the apiserver to the kubelet.
+func execCheck(kubeClient kubernetes.Interface, restConfig *rest.Config, namespace, pod string) streamingCheck {
+	return func(ctx context.Context) error {
+		req := kubeClient.CoreV1().RESTClient().Post().Resource("pods").Namespace(namespace).Name(pod).SubResource("exec").
+			VersionedParams(&corev1.PodExecOptions{
+				Container: targetContainer,
+				Command:   []string{"/bin/sh", "-c", "exit 0"},
+				Stdout:    true,
+				Stderr:    true,
+			}, scheme.ParameterCodec)
+		executor, err := remotecommand.NewSPDYExecutor(restConfig, "POST", req.URL())
+		if err != nil {
+			return err
+		}
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		if err := executor.StreamWithContext(ctx, remotecommand.StreamOptions{Stdout: stdout, Stderr: stderr}); err != nil {
+			return fmt.Errorf("exec failed: %w: %s", err, stderr.String())
+		}
+		return nil
+	}
+}
+
+// portForwardCheck forwards a local port to the target pod and reads its hostname through the forward.
+func portForwardCheck(kubeClient kubernetes.Interface, restConfig *rest.Config, namespace, pod string) streamingCheck {
+	return func(ctx context.Context) error {
+		req := kubeClient.CoreV1().RESTClient().Post().Resource("pods").Namespace(namespace).Name(pod).SubResource("portforward")
+		transport, upgrader, err := spdy.RoundTripperFor(restConfig)
+		if err != nil {
+			return err
+		}
+		dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", req.URL())
+		stopCh, readyCh := make(chan struct{}), make(chan struct{})
+		forwarder, err := portforward.New(dialer, []string{fmt.Sprintf("0:%d", targetPort)}, stopCh, readyCh, io.Discard, io.Discard)
+		if err != nil {
+			return err
+		}
+		forwardErr := make(chan error, 1)
+		go func() {
+			forwardErr <- forwarder.ForwardPorts()
+		}()
+		defer close(stopCh)
+
+		select {
+		case <-readyCh:
+		case err := <-forwardErr:
+			return fmt.Errorf("port-forward failed: %w", err)
+		case <-ctx.Done():
+			return fmt.Errorf("port-forward was not ready: %w", ctx.Err())
+		}
+		ports, err := forwarder.GetPorts()
+		if err != nil {
+			return err
+		}
+		httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://localhost:%d/hostname", ports[0].Local), nil)
+		if err != nil {
+			return err
+		}
+		resp, err := http.DefaultClient.Do(httpReq)
+		if err != nil {
+			return fmt.Errorf("request through the port-forward failed: %w", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("request through the port-forward returned %d", resp.StatusCode)
+		}
+		return nil
+	}
+}
+
+// streamingSampler runs a check every interval and records when it failed as disruption of its backend, the same
+// way the backend samplers do, so the streaming paths show next to the REST availability of the apiservers.
+type streamingSampler struct {
+	locator  monitorapi.Locator
+	check    streamingCheck
+	interval time.Duration
+
+	lock sync.Mutex
+	// samples and failures count the checks run and failed.
+	samples  int
+	failures int
+}
+
+func newStreamingSampler(backend string, check streamingCheck) *streamingSampler {
+	return &streamingSampler{
+		locator: monitorapi.NewLocator().LocateDisruptionCheck(
+			fmt.Sprintf("%s-%v-connections", backend, monitorapi.NewConnectionType), backend, monitorapi.NewConnectionType),
+		check:    check,
+		interval: sampleInterval,
+	}
+}
+
+// run samples until ctx is done, then ends the interval it has open.
+func (s *streamingSampler) run(ctx context.Context, recorder monitorapi.RecorderWriter) {
+	previousIntervalID := -1
+	defer func() {
+		if previousIntervalID != -1 {
+			recorder.EndInterval(previousIntervalID, time.Now())
+		}
+	}()
+	var previousErr error
+	first := true
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		start := time.Now()
+		checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+		err := s.check(checkCtx)
+		cancel()
+		if ctx.Err() != nil {
+			// the check was interrupted by the end of the run, not by the apiserver.
+			return
+		}
+		s.record(err)
+
+		if interval, changed := s.transition(first, previousErr, err, start); changed {
+			if previousIntervalID != -1 {
+				recorder.EndInterval(previousIntervalID, start)
+			}
+			previousIntervalID = recorder.StartInterval(interval)
+		}
+		first, previousErr = false, err
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// transition returns the interval to start when the availability of the backend changed.  The errors of the checks
+// carry local ports, so unlike the backend samplers a new error does not start a new interval.
+func (s *streamingSampler) transition(first bool, previousErr, err error, at time.Time) (monitorapi.Interval, bool) {
+	locator := s.locator.OldLocator()
+	switch {
+	case err != nil && (first || previousErr == nil):
+		message, _, level := backenddisruption.DisruptionBegan(locator, monitorapi.NewConnectionType, err, "")
+		return monitorapi.NewInterval(monitorapi.SourceDisruption, level).
+			Locator(s.locator).
+			Message(message).
+			Display().
+			Build(at, time.Time{}), true
+	case err == nil && (first || previousErr != nil):
+		return monitorapi.NewInterval(monitorapi.SourceDisruption, monitorapi.Info).
+			Locator(s.locator).
+			Message(backenddisruption.DisruptionEndedMessage(locator, monitorapi.NewConnectionType)).
+			Build(at, time.Time{}), true
+	}
+	return monitorapi.Interval{}, false
+}
+
+func (s *streamingSampler) record(err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.samples++
+	if err != nil {
+		s.failures++
+	}
+}
+
+func (s *streamingSampler) counts() (int, int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.samples, s.failures
+}
@@ -0,0 +1,62 @@
+package disruptionstreaming
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/origin/pkg/monitor"
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+func TestStreamingSamplerRecordsAvailabilityChanges(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	checks := 0
+	results := []error{
+		nil,
+		fmt.Errorf("request through the port-forward failed: dial tcp 127.0.0.1:41234: connection refused"),
+		// a different error of the same outage does not start an interval.
+		fmt.Errorf("request through the port-forward failed: dial tcp 127.0.0.1:40321: connection refused"),
+		nil,
+		nil,
+	}
+	sampler := newStreamingSampler(portForwardBackend, func(context.Context) error {
+		defer func() { checks++ }()
+		if checks == len(results)-1 {
+			cancel()
+		}
+		if checks >= len(results) {
+			return errors.New("unexpected check")
+		}
+		return results[checks]
+	})
+	sampler.interval = time.Millisecond
+
+	recorder := monitor.NewRecorder()
+	start := time.Now()
+	sampler.run(ctx, recorder)
+
+	samples, failures := sampler.counts()
+	// the last check was interrupted by the end of the run.
+	assert.Equal(t, 4, samples)
+	assert.Equal(t, 2, failures)
+
+	intervals := recorder.Intervals(start.Add(-time.Minute), time.Now().Add(time.Minute))
+	require.Len(t, intervals, 3)
+	assert.Equal(t, monitorapi.DisruptionEndedEventReason, intervals[0].Message.Reason)
+	assert.Equal(t, monitorapi.DisruptionBeganEventReason, intervals[1].Message.Reason)
+	assert.Equal(t, monitorapi.Error, intervals[1].Level)
+	assert.Equal(t, "kube-api-port-forward-new-connections", intervals[1].Locator.Keys[monitorapi.LocatorBackendDisruptionNameKey])
+	assert.Contains(t, intervals[1].Message.HumanMessage, "41234")
+	assert.Equal(t, monitorapi.DisruptionEndedEventReason, intervals[2].Message.Reason)
+	for _, interval := range intervals {
+		assert.False(t, interval.To.IsZero(), "intervals are ended when the sampler stops")
+	}
+}
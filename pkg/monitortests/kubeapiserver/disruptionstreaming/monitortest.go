@@ -0,0 +1,166 @@
+package disruptionstreaming
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	k8simage "k8s.io/kubernetes/test/utils/image"
+	"k8s.io/utils/ptr"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	"github.com/openshift/origin/test/extended/util/image"
+)
+
+const (
+	// sampleInterval is how often each streaming path is exercised.  Every check opens a new upgraded connection
+	// through the apiserver and the kubelet, which is heavier than the REST samplers.
+	sampleInterval = 2 * time.Second
+
+	targetPodName   = "streaming-target"
+	targetContainer = "netexec"
+	targetPort      = 8080
+)
+
+// streamingAvailability execs into and port-forwards to a long-lived pod for the whole run.  These upgraded
+// connections are proxied by the apiserver to the kubelet and break independently from plain REST availability,
+// which the other disruption backends do not cover.
+type streamingAvailability struct {
+	kubeClient kubernetes.Interface
+	namespace  string
+	cancel     context.CancelFunc
+	wg         sync.WaitGroup
+	samplers   []*streamingSampler
+}
+
+func NewStreamingAvailabilityInvariant() monitortestframework.MonitorTest {
+	return &streamingAvailability{}
+}
+
+func (w *streamingAvailability) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	var err error
+	w.kubeClient, err = kubernetes.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return err
+	}
+	namespace, err := w.kubeClient.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{GenerateName: "e2e-streaming-disruption-"},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+	w.namespace = namespace.Name
+
+	if _, err := w.kubeClient.CoreV1().Pods(w.namespace).Create(ctx, targetPod(), metav1.CreateOptions{}); err != nil {
+		return err
+	}
+	if err := wait.PollUntilContextTimeout(ctx, 2*time.Second, 5*time.Minute, true, w.targetPodReady); err != nil {
+		return fmt.Errorf("pod/%s in %s did not become ready: %w", targetPodName, w.namespace, err)
+	}
+
+	w.samplers = []*streamingSampler{
+		newStreamingSampler(execBackend, execCheck(w.kubeClient, adminRESTConfig, w.namespace, targetPodName)),
+		newStreamingSampler(portForwardBackend, portForwardCheck(w.kubeClient, adminRESTConfig, w.namespace, targetPodName)),
+	}
+	// the checks run until CollectData, not for as long as the StartCollection context lives.
+	samplerCtx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	for _, sampler := range w.samplers {
+		w.wg.Add(1)
+		go func(sampler *streamingSampler) {
+			defer w.wg.Done()
+			sampler.run(samplerCtx, recorder)
+		}(sampler)
+	}
+	return nil
+}
+
+func targetPod() *corev1.Pod {
+	// force the image to use the "normal" global mapping.
+	originalAgnhost := k8simage.GetOriginalImageConfigs()[k8simage.Agnhost]
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: targetPodName},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:  targetContainer,
+				Image: image.LocationFor(originalAgnhost.GetE2EImage()),
+				Args:  []string{"netexec", fmt.Sprintf("--http-port=%d", targetPort)},
+				Ports: []corev1.ContainerPort{{ContainerPort: targetPort}},
+				ReadinessProbe: &corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{
+						HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromInt32(targetPort)},
+					},
+				},
+				SecurityContext: &corev1.SecurityContext{
+					AllowPrivilegeEscalation: ptr.To(false),
+					RunAsNonRoot:             ptr.To(true),
+					Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+					SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+				},
+			}},
+			TerminationGracePeriodSeconds: ptr.To[int64](1),
+		},
+	}
+}
+
+func (w *streamingAvailability) targetPodReady(ctx context.Context) (bool, error) {
+	pod, err := w.kubeClient.CoreV1().Pods(w.namespace).Get(ctx, targetPodName, metav1.GetOptions{})
+	if err != nil {
+		logrus.WithError(err).Warn("unable to get the streaming target pod")
+		return false, nil
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue, nil
+		}
+	}
+	return false, nil
+}
+
+func (w *streamingAvailability) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	w.stopSamplers()
+	for _, sampler := range w.samplers {
+		samples, failures := sampler.counts()
+		logrus.Infof("%s failed %d of %d checks", sampler.locator.Keys[monitorapi.LocatorBackendDisruptionNameKey], failures, samples)
+	}
+	return nil, nil, nil
+}
+
+func (w *streamingAvailability) stopSamplers() {
+	if w.cancel != nil {
+		w.cancel()
+		w.wg.Wait()
+	}
+}
+
+func (*streamingAvailability) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	return nil, nil
+}
+
+func (*streamingAvailability) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	return nil, nil
+}
+
+func (*streamingAvailability) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	return nil
+}
+
+func (w *streamingAvailability) Cleanup(ctx context.Context) error {
+	w.stopSamplers()
+	if len(w.namespace) > 0 && w.kubeClient != nil {
+		if err := w.kubeClient.CoreV1().Namespaces().Delete(ctx, w.namespace, metav1.DeleteOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
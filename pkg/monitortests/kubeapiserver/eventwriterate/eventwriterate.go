@@ -0,0 +1,180 @@
+package eventwriterate
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	routeclient "github.com/openshift/client-go/route/clientset/versioned"
+	"github.com/openshift/library-go/test/library/metrics"
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestlibrary/prometheus"
+	prometheusv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	prometheustypes "github.com/prometheus/common/model"
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// eventWriteRateQuery is the rate of event creates and updates accepted by all apiservers, for both the core and
+	// events.k8s.io groups.
+	eventWriteRateQuery = `sum(rate(apiserver_request_total{resource="events",verb=~"POST|PUT|PATCH|APPLY"}[1m]))`
+	// sampleStep is the resolution of the rate samples, the rate itself is over a minute so finer steps add nothing.
+	sampleStep = 15 * time.Second
+	// spikeThreshold is the event writes per second above which we record a spike.  A quiet cluster writes a few
+	// events a second, a storm of 100k events over ten minutes is well over 100.
+	spikeThreshold = 20.0
+)
+
+// RateSample is the cluster wide event write rate at one point in time.
+type RateSample struct {
+	Time time.Time
+	// WritesPerSecond is the rate of event writes accepted by the apiservers over the minute before Time.
+	WritesPerSecond float64
+}
+
+// Spike is a period where the event write rate stayed above spikeThreshold.
+type Spike struct {
+	From time.Time
+	To   time.Time
+	// PeakWritesPerSecond is the highest sampled rate during the spike.
+	PeakWritesPerSecond float64
+	// Writes is the approximate number of event writes the apiservers accepted during the spike.
+	Writes int
+	// WatchedEvents is the number of kube events the event watcher recorded during the spike.  Far fewer watched
+	// events than writes means the writes were updates of the same events, or the watcher fell behind.  Many watched
+	// events without a spike means the watcher replayed old events rather than the cluster emitting new ones.
+	WatchedEvents int
+}
+
+func fetchEventWriteRates(ctx context.Context, restConfig *rest.Config, beginning, end time.Time) ([]RateSample, error) {
+	logger := logrus.WithField("func", "fetchEventWriteRates")
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	routeClient, err := routeclient.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = kubeClient.CoreV1().Namespaces().Get(ctx, "openshift-monitoring", metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return []RateSample{}, nil
+	}
+
+	prometheusClient, err := metrics.NewPrometheusClient(ctx, kubeClient, routeClient)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := prometheus.EnsureThanosQueriersConnectedToPromSidecars(ctx, prometheusClient); err != nil {
+		return nil, err
+	}
+
+	timeRange := prometheusv1.Range{
+		Start: beginning,
+		End:   end,
+		Step:  sampleStep,
+	}
+	rates, warningsForQuery, err := prometheusClient.QueryRange(ctx, eventWriteRateQuery, timeRange)
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range warningsForQuery {
+		logger.Warnf("event write rate prom query warning: %s", w)
+	}
+	return samplesFromPrometheusValue(logger, rates), nil
+}
+
+func samplesFromPrometheusValue(logger logrus.FieldLogger, promVal prometheustypes.Value) []RateSample {
+	ret := []RateSample{}
+	matrix, ok := promVal.(prometheustypes.Matrix)
+	if !ok {
+		logger.WithField("type", promVal.Type()).Warning("unhandled prometheus type received")
+		return ret
+	}
+	// the query sums to a single series.
+	for _, sampleStream := range matrix {
+		for _, value := range sampleStream.Values {
+			ret = append(ret, RateSample{
+				Time:            value.Timestamp.Time(),
+				WritesPerSecond: float64(value.Value),
+			})
+		}
+	}
+	return ret
+}
+
+// spikesFromSamples groups consecutive samples above the threshold into spikes.  A missing sample ends a spike.
+func spikesFromSamples(samples []RateSample, step time.Duration, threshold float64) []Spike {
+	ret := []Spike{}
+	var current *Spike
+	var writes float64
+	var lastSample time.Time
+	for _, sample := range samples {
+		if current != nil && (sample.WritesPerSecond <= threshold || sample.Time.Sub(lastSample) > step) {
+			current.Writes = int(writes)
+			ret = append(ret, *current)
+			current = nil
+		}
+		lastSample = sample.Time
+		if sample.WritesPerSecond <= threshold {
+			continue
+		}
+		if current == nil {
+			// the rate is over the preceding step, so the spike started one step before the first high sample.
+			current = &Spike{From: sample.Time.Add(-step)}
+			writes = 0
+		}
+		current.To = sample.Time
+		writes += sample.WritesPerSecond * step.Seconds()
+		if sample.WritesPerSecond > current.PeakWritesPerSecond {
+			current.PeakWritesPerSecond = sample.WritesPerSecond
+		}
+	}
+	if current != nil {
+		current.Writes = int(writes)
+		ret = append(ret, *current)
+	}
+	return ret
+}
+
+// correlateWatchedEvents fills in how many kube events the event watcher recorded during each spike.
+func correlateWatchedEvents(spikes []Spike, intervals monitorapi.Intervals) []Spike {
+	kubeEvents := intervals.Filter(func(eventInterval monitorapi.Interval) bool {
+		return eventInterval.Source == monitorapi.SourceKubeEvent
+	})
+	ret := make([]Spike, 0, len(spikes))
+	for _, spike := range spikes {
+		spike.WatchedEvents = 0
+		for _, event := range kubeEvents {
+			if !event.From.Before(spike.From) && !event.From.After(spike.To) {
+				spike.WatchedEvents++
+			}
+		}
+		ret = append(ret, spike)
+	}
+	return ret
+}
+
+func intervalsFromSpikes(spikes []Spike) monitorapi.Intervals {
+	ret := monitorapi.Intervals{}
+	for _, spike := range spikes {
+		ret = append(ret,
+			monitorapi.NewInterval(monitorapi.SourceAPIServerEventRate, monitorapi.Warning).
+				Locator(monitorapi.NewLocator().KubeAPIServerWithLB("")).
+				Message(monitorapi.NewMessage().
+					Reason(monitorapi.EventWriteRateSpikeReason).
+					HumanMessage(fmt.Sprintf("apiservers accepted about %d event writes peaking at %.1f/s, the event watcher recorded %d events",
+						spike.Writes, spike.PeakWritesPerSecond, spike.WatchedEvents)).
+					WithAnnotation(monitorapi.AnnotationEventWrites, fmt.Sprintf("%d", spike.Writes)).
+					WithAnnotation(monitorapi.AnnotationWatchedEvents, fmt.Sprintf("%d", spike.WatchedEvents))).
+				Display().
+				Build(spike.From, spike.To),
+		)
+	}
+	return ret
+}
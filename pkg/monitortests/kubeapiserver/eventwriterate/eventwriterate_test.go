@@ -0,0 +1,80 @@
+package eventwriterate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+func TestSpikesFromSamples(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	at := func(step int) time.Time { return start.Add(time.Duration(step) * sampleStep) }
+
+	samples := []RateSample{
+		{Time: at(0), WritesPerSecond: 2},
+		{Time: at(1), WritesPerSecond: 30},
+		{Time: at(2), WritesPerSecond: 50},
+		{Time: at(3), WritesPerSecond: 5},
+		{Time: at(4), WritesPerSecond: 40},
+		// a missing sample ends the spike.
+		{Time: at(6), WritesPerSecond: 40},
+	}
+
+	spikes := spikesFromSamples(samples, sampleStep, spikeThreshold)
+	require.Len(t, spikes, 3)
+
+	assert.Equal(t, at(0), spikes[0].From)
+	assert.Equal(t, at(2), spikes[0].To)
+	assert.Equal(t, 50.0, spikes[0].PeakWritesPerSecond)
+	assert.Equal(t, 1200, spikes[0].Writes)
+
+	assert.Equal(t, at(3), spikes[1].From)
+	assert.Equal(t, at(4), spikes[1].To)
+	assert.Equal(t, 600, spikes[1].Writes)
+
+	assert.Equal(t, at(5), spikes[2].From)
+	assert.Equal(t, at(6), spikes[2].To)
+
+	assert.Empty(t, spikesFromSamples(samples[:1], sampleStep, spikeThreshold))
+}
+
+func TestCorrelateWatchedEvents(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	kubeEvent := func(at time.Time) monitorapi.Interval {
+		event := &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Namespace: "ns", Name: "event"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: "ns", Name: "pod"},
+			Reason:         "BackOff",
+		}
+		return monitorapi.NewInterval(monitorapi.SourceKubeEvent, monitorapi.Warning).
+			Locator(monitorapi.NewLocator().KubeEvent(event)).
+			Message(monitorapi.NewMessage().HumanMessage("Back-off restarting failed container")).
+			Build(at, at.Add(time.Second))
+	}
+
+	intervals := monitorapi.Intervals{
+		kubeEvent(start.Add(-time.Minute)),
+		kubeEvent(start),
+		kubeEvent(start.Add(30 * time.Second)),
+		kubeEvent(start.Add(time.Minute)),
+		kubeEvent(start.Add(2 * time.Minute)),
+		monitorapi.NewInterval(monitorapi.SourceAlert, monitorapi.Warning).Build(start, start.Add(time.Minute)),
+	}
+	spikes := correlateWatchedEvents([]Spike{{From: start, To: start.Add(time.Minute), PeakWritesPerSecond: 40, Writes: 2000}}, intervals)
+	require.Len(t, spikes, 1)
+	assert.Equal(t, 3, spikes[0].WatchedEvents)
+
+	spikeIntervals := intervalsFromSpikes(spikes)
+	require.Len(t, spikeIntervals, 1)
+	assert.Equal(t, monitorapi.SourceAPIServerEventRate, spikeIntervals[0].Source)
+	assert.Equal(t, monitorapi.EventWriteRateSpikeReason, spikeIntervals[0].Message.Reason)
+	assert.Equal(t, "2000", spikeIntervals[0].Message.Annotations[monitorapi.AnnotationEventWrites])
+	assert.Equal(t, "3", spikeIntervals[0].Message.Annotations[monitorapi.AnnotationWatchedEvents])
+	assert.Equal(t, "apiservers accepted about 2000 event writes peaking at 40.0/s, the event watcher recorded 3 events", spikeIntervals[0].Message.HumanMessage)
+}
@@ -0,0 +1,73 @@
+package eventwriterate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitortestframework"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	"k8s.io/client-go/rest"
+)
+
+// eventWriteRateCollector records when the apiservers accepted event writes at a high rate, so event storms seen by
+// the event watcher can be told apart from a watcher that fell behind or replayed old events.
+type eventWriteRateCollector struct {
+	adminRESTConfig *rest.Config
+
+	samples []RateSample
+	spikes  []Spike
+}
+
+// EventWriteRate is written as the event-write-rate artifact.
+type EventWriteRate struct {
+	Samples []RateSample
+	Spikes  []Spike
+}
+
+func NewEventWriteRateCollector() monitortestframework.MonitorTest {
+	return &eventWriteRateCollector{}
+}
+
+func (w *eventWriteRateCollector) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	w.adminRESTConfig = adminRESTConfig
+	return nil
+}
+
+func (w *eventWriteRateCollector) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	samples, err := fetchEventWriteRates(ctx, w.adminRESTConfig, beginning, end)
+	if err != nil {
+		return nil, nil, err
+	}
+	w.samples = samples
+	return nil, nil, nil
+}
+
+func (w *eventWriteRateCollector) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	w.spikes = correlateWatchedEvents(spikesFromSamples(w.samples, sampleStep, spikeThreshold), startingIntervals)
+	return intervalsFromSpikes(w.spikes), nil
+}
+
+func (*eventWriteRateCollector) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	return nil, nil
+}
+
+func (w *eventWriteRateCollector) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	if len(w.samples) == 0 {
+		return nil
+	}
+	jsonContent, err := json.MarshalIndent(EventWriteRate{Samples: w.samples, Spikes: w.spikes}, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(storageDir, fmt.Sprintf("event-write-rate%s.json", timeSuffix)), jsonContent, 0644)
+}
+
+func (*eventWriteRateCollector) Cleanup(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,219 @@
+package flowcontrol
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	routeclient "github.com/openshift/client-go/route/clientset/versioned"
+	"github.com/openshift/library-go/test/library/metrics"
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestlibrary/prometheus"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	prometheusv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	prometheustypes "github.com/prometheus/common/model"
+	"github.com/sirupsen/logrus"
+	flowcontrolv1 "k8s.io/api/flowcontrol/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// rejectedQuery is the rate of requests each flow schema had rejected, because its queues were full, it waited too
+	// long in them, or its priority level had no queues.
+	rejectedQuery = `sum by (flow_schema, priority_level) (rate(apiserver_flowcontrol_rejected_requests_total[1m]))`
+	// inQueueQuery is the number of requests of each flow schema waiting in the queues of all apiservers.
+	inQueueQuery = `sum by (flow_schema, priority_level) (apiserver_flowcontrol_current_inqueue_requests)`
+	// sampleStep is the resolution of the samples, the rates are over a minute so finer steps add nothing.
+	sampleStep = 15 * time.Second
+
+	// rejectionThreshold is the rejections per second above which a flow schema is rejecting requests.
+	rejectionThreshold = 0.0
+	// saturationThreshold is the number of queued requests above which the queues of a flow schema are saturated.
+	// A few requests queue whenever a priority level is busy.
+	saturationThreshold = 10.0
+	// sustainedRejection is how long system flows may be rejected before failing.
+	sustainedRejection = 2 * time.Minute
+
+	rejectionTestName = "[sig-api-machinery] API priority and fairness should not reject the requests of system flows for long"
+)
+
+var flowSchemasResource = flowcontrolv1.SchemeGroupVersion.WithResource("flowschemas")
+
+// systemPriorityLevels serve the requests the cluster needs to run.  Rejecting them leads to node, controller and
+// operator failures that look random.
+var systemPriorityLevels = sets.New[string]("exempt", "system", "leader-election", "node-high", "workload-high", "openshift-control-plane-operators")
+
+// Sample is the value of a flow control metric of a flow schema at one point in time.
+type Sample struct {
+	Time  time.Time
+	Value float64
+}
+
+// Series are the samples of one flow schema.
+type Series struct {
+	FlowSchema    string
+	PriorityLevel string
+	Samples       []Sample
+}
+
+// Episode is a period where a flow schema stayed above the threshold of a metric.
+type Episode struct {
+	FlowSchema    string
+	PriorityLevel string
+	Reason        monitorapi.IntervalReason
+	From          time.Time
+	To            time.Time
+	Peak          float64
+}
+
+func fetchFlowControlSeries(ctx context.Context, restConfig *rest.Config, beginning, end time.Time) (rejected, inQueue []Series, err error) {
+	logger := logrus.WithField("func", "fetchFlowControlSeries")
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	routeClient, err := routeclient.NewForConfig(restConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_, err = kubeClient.CoreV1().Namespaces().Get(ctx, "openshift-monitoring", metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return []Series{}, []Series{}, nil
+	}
+
+	prometheusClient, err := metrics.NewPrometheusClient(ctx, kubeClient, routeClient)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := prometheus.EnsureThanosQueriersConnectedToPromSidecars(ctx, prometheusClient); err != nil {
+		return nil, nil, err
+	}
+
+	timeRange := prometheusv1.Range{
+		Start: beginning,
+		End:   end,
+		Step:  sampleStep,
+	}
+	ret := [][]Series{}
+	for _, query := range []string{rejectedQuery, inQueueQuery} {
+		values, warningsForQuery, err := prometheusClient.QueryRange(ctx, query, timeRange)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, w := range warningsForQuery {
+			logger.Warnf("flow control prom query warning: %s", w)
+		}
+		ret = append(ret, seriesFromPrometheusValue(logger, values))
+	}
+	return ret[0], ret[1], nil
+}
+
+func seriesFromPrometheusValue(logger logrus.FieldLogger, promVal prometheustypes.Value) []Series {
+	ret := []Series{}
+	matrix, ok := promVal.(prometheustypes.Matrix)
+	if !ok {
+		logger.WithField("type", promVal.Type()).Warning("unhandled prometheus type received")
+		return ret
+	}
+	for _, sampleStream := range matrix {
+		series := Series{
+			FlowSchema:    string(sampleStream.Metric["flow_schema"]),
+			PriorityLevel: string(sampleStream.Metric["priority_level"]),
+		}
+		for _, value := range sampleStream.Values {
+			series.Samples = append(series.Samples, Sample{Time: value.Timestamp.Time(), Value: float64(value.Value)})
+		}
+		ret = append(ret, series)
+	}
+	return ret
+}
+
+// episodesFromSeries groups the consecutive samples of each series above the threshold into episodes.  A missing
+// sample ends an episode.
+func episodesFromSeries(series []Series, reason monitorapi.IntervalReason, step time.Duration, threshold float64) []Episode {
+	ret := []Episode{}
+	for _, s := range series {
+		var current *Episode
+		var lastSample time.Time
+		for _, sample := range s.Samples {
+			if current != nil && (sample.Value <= threshold || sample.Time.Sub(lastSample) > step) {
+				ret = append(ret, *current)
+				current = nil
+			}
+			lastSample = sample.Time
+			if sample.Value <= threshold {
+				continue
+			}
+			if current == nil {
+				// the samples cover the preceding step, so the episode started one step before the first sample.
+				current = &Episode{FlowSchema: s.FlowSchema, PriorityLevel: s.PriorityLevel, Reason: reason, From: sample.Time.Add(-step)}
+			}
+			current.To = sample.Time
+			current.Peak = max(current.Peak, sample.Value)
+		}
+		if current != nil {
+			ret = append(ret, *current)
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].From.Before(ret[j].From)
+	})
+	return ret
+}
+
+func intervalsFromEpisodes(episodes []Episode) monitorapi.Intervals {
+	ret := monitorapi.Intervals{}
+	for _, episode := range episodes {
+		level := monitorapi.Warning
+		message := monitorapi.NewMessage().Reason(episode.Reason).
+			WithAnnotation(monitorapi.AnnotationPriorityLevel, episode.PriorityLevel)
+		if episode.Reason == monitorapi.FlowControlRejectionReason {
+			level = monitorapi.Error
+			message.HumanMessagef("priority level %s rejected up to %.2f requests/s", episode.PriorityLevel, episode.Peak)
+		} else {
+			message.HumanMessagef("up to %.0f requests queued in priority level %s", episode.Peak, episode.PriorityLevel)
+		}
+		ret = append(ret, monitorapi.NewInterval(monitorapi.SourceAPIServerFlowControl, level).
+			Locator(monitorapi.NewLocator().ForGVR(flowSchemasResource, "", episode.FlowSchema)).
+			Message(message).
+			Display().
+			Build(episode.From, episode.To))
+	}
+	return ret
+}
+
+// rejectionJUnits fails when the requests of a system priority level were rejected for longer than sustainedRejection.
+// Rejecting other flows is what API priority and fairness is for.
+func rejectionJUnits(intervals monitorapi.Intervals) []*junitapi.JUnitTestCase {
+	failures := []string{}
+	for _, interval := range intervals {
+		if interval.Source != monitorapi.SourceAPIServerFlowControl || interval.Message.Reason != monitorapi.FlowControlRejectionReason {
+			continue
+		}
+		if !systemPriorityLevels.Has(interval.Message.Annotations[monitorapi.AnnotationPriorityLevel]) {
+			continue
+		}
+		if duration := interval.To.Sub(interval.From); duration >= sustainedRejection {
+			failures = append(failures, fmt.Sprintf("%s %s for %s: %s", interval.Locator.OldLocator(),
+				interval.From.Format(time.RFC3339), duration, interval.Message.HumanMessage))
+		}
+	}
+	if len(failures) == 0 {
+		return []*junitapi.JUnitTestCase{{Name: rejectionTestName}}
+	}
+	return []*junitapi.JUnitTestCase{{
+		Name: rejectionTestName,
+		FailureOutput: &junitapi.FailureOutput{
+			Output: fmt.Sprintf("%d flow schemas of system priority levels had requests rejected for %s or longer.  "+
+				"Clients of these flows time out, check the flow schemas and priority levels for misconfiguration:\n\n%s",
+				len(failures), sustainedRejection, strings.Join(failures, "\n")),
+		},
+	}}
+}
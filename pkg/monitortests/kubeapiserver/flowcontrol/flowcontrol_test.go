@@ -0,0 +1,67 @@
+package flowcontrol
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+func TestEpisodesFromSeries(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	at := func(step int) time.Time { return start.Add(time.Duration(step) * sampleStep) }
+
+	series := []Series{
+		{
+			FlowSchema: "system-nodes", PriorityLevel: "system",
+			Samples: []Sample{
+				{Time: at(0), Value: 0},
+				{Time: at(1), Value: 0.5},
+				{Time: at(2), Value: 2},
+				{Time: at(3), Value: 0},
+				{Time: at(4), Value: 1},
+				// a missing sample ends the episode.
+				{Time: at(6), Value: 1},
+			},
+		},
+		{
+			FlowSchema: "global-default", PriorityLevel: "global-default",
+			Samples: []Sample{{Time: at(0), Value: 3}},
+		},
+	}
+
+	episodes := episodesFromSeries(series, monitorapi.FlowControlRejectionReason, sampleStep, rejectionThreshold)
+	require.Len(t, episodes, 4)
+	assert.Equal(t, "global-default", episodes[0].FlowSchema)
+	assert.Equal(t, at(-1), episodes[0].From)
+	assert.Equal(t, Episode{FlowSchema: "system-nodes", PriorityLevel: "system", Reason: monitorapi.FlowControlRejectionReason, From: at(0), To: at(2), Peak: 2}, episodes[1])
+	assert.Equal(t, at(3), episodes[2].From)
+	assert.Equal(t, at(5), episodes[3].From)
+
+	assert.Empty(t, episodesFromSeries(series, monitorapi.FlowControlQueueSaturationReason, sampleStep, saturationThreshold))
+}
+
+func TestRejectionJUnits(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	intervals := intervalsFromEpisodes([]Episode{
+		{FlowSchema: "system-leader-election", PriorityLevel: "leader-election", Reason: monitorapi.FlowControlRejectionReason, From: start, To: start.Add(3 * time.Minute), Peak: 4},
+		{FlowSchema: "system-nodes", PriorityLevel: "system", Reason: monitorapi.FlowControlRejectionReason, From: start, To: start.Add(30 * time.Second), Peak: 1},
+		{FlowSchema: "global-default", PriorityLevel: "global-default", Reason: monitorapi.FlowControlRejectionReason, From: start, To: start.Add(time.Hour), Peak: 10},
+		{FlowSchema: "system-nodes", PriorityLevel: "system", Reason: monitorapi.FlowControlQueueSaturationReason, From: start, To: start.Add(time.Hour), Peak: 50},
+	})
+	require.Len(t, intervals, 4)
+	assert.Equal(t, "system-leader-election", intervals[0].Locator.Keys[monitorapi.LocatorNameKey])
+	assert.Equal(t, "priority level leader-election rejected up to 4.00 requests/s", intervals[0].Message.HumanMessage)
+	assert.Equal(t, "up to 50 requests queued in priority level system", intervals[3].Message.HumanMessage)
+
+	junits := rejectionJUnits(intervals)
+	require.Len(t, junits, 1)
+	require.NotNil(t, junits[0].FailureOutput)
+	assert.Contains(t, junits[0].FailureOutput.Output, "1 flow schemas")
+	assert.Contains(t, junits[0].FailureOutput.Output, "system-leader-election")
+
+	assert.Nil(t, rejectionJUnits(intervals[1:])[0].FailureOutput)
+}
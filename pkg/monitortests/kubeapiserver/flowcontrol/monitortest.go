@@ -0,0 +1,80 @@
+package flowcontrol
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	"k8s.io/client-go/rest"
+)
+
+// flowControlSaturationMonitor records when API priority and fairness queued or rejected the requests of a flow
+// schema, because a misconfigured flow schema or priority level shows as client timeouts that look random.
+type flowControlSaturationMonitor struct {
+	adminRESTConfig *rest.Config
+
+	rejected []Series
+	inQueue  []Series
+	episodes []Episode
+}
+
+// FlowControlSaturation is written as the flow-control-saturation artifact.
+type FlowControlSaturation struct {
+	Episodes []Episode
+}
+
+func NewFlowControlSaturationMonitor() monitortestframework.MonitorTest {
+	return &flowControlSaturationMonitor{}
+}
+
+func (*flowControlSaturationMonitor) Describe() monitortestframework.MonitorTestDescription {
+	return monitortestframework.MonitorTestDescription{
+		JUnits: []string{rejectionTestName},
+	}
+}
+
+func (w *flowControlSaturationMonitor) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	w.adminRESTConfig = adminRESTConfig
+	return nil
+}
+
+func (w *flowControlSaturationMonitor) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	rejected, inQueue, err := fetchFlowControlSeries(ctx, w.adminRESTConfig, beginning, end)
+	if err != nil {
+		return nil, nil, err
+	}
+	w.rejected, w.inQueue = rejected, inQueue
+	return nil, nil, nil
+}
+
+func (w *flowControlSaturationMonitor) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	w.episodes = append(
+		episodesFromSeries(w.rejected, monitorapi.FlowControlRejectionReason, sampleStep, rejectionThreshold),
+		episodesFromSeries(w.inQueue, monitorapi.FlowControlQueueSaturationReason, sampleStep, saturationThreshold)...)
+	return intervalsFromEpisodes(w.episodes), nil
+}
+
+func (*flowControlSaturationMonitor) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	return rejectionJUnits(finalIntervals), nil
+}
+
+func (w *flowControlSaturationMonitor) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	if len(w.episodes) == 0 {
+		return nil
+	}
+	jsonContent, err := json.MarshalIndent(FlowControlSaturation{Episodes: w.episodes}, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(storageDir, fmt.Sprintf("flow-control-saturation%s.json", timeSuffix)), jsonContent, 0644)
+}
+
+func (*flowControlSaturationMonitor) Cleanup(ctx context.Context) error {
+	return nil
+}
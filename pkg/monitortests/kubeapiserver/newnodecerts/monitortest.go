@@ -0,0 +1,231 @@
+package newnodecerts
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/openshift/origin/pkg/certs"
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/monitortestlibrary/ondiskcerts"
+	"github.com/openshift/origin/pkg/monitortests/network/disruptionpodnetwork"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	exutil "github.com/openshift/origin/test/extended/util"
+	ownership "github.com/openshift/origin/tls"
+)
+
+// fetchTimeout bounds the collection on one node, the collector pod gets a minute to start.
+const fetchTimeout = 5 * time.Minute
+
+// newNodeCertOwnership runs the on-disk tls artifact collection on every node that joins the cluster during the run,
+// from scale-ups or machine replacements, and checks that the artifacts it finds are registered.  The late tls
+// checks only cover the control plane nodes present when they run.
+type newNodeCertOwnership struct {
+	payloadImagePullSpec string
+	notSupportedReason   error
+
+	adminRESTConfig     *rest.Config
+	kubeClient          kubernetes.Interface
+	kubeInformers       informers.SharedInformerFactory
+	testsImagePullSpec  string
+	expectedPKIContent  *certs.PKIRegistryInfo
+	violationPKIContent *certs.PKIRegistryInfo
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	queue  workqueue.Interface
+
+	lock         sync.Mutex
+	initialNodes sets.Set[string]
+	queuedNodes  sets.Set[string]
+	results      []nodeResult
+}
+
+func NewNewNodeCertOwnership(info monitortestframework.MonitorTestInitializationInfo) monitortestframework.MonitorTest {
+	return &newNodeCertOwnership{
+		payloadImagePullSpec: info.UpgradeTargetPayloadImagePullSpec,
+	}
+}
+
+func (w *newNodeCertOwnership) SetSharedInformers(kubeInformers informers.SharedInformerFactory) {
+	w.kubeInformers = kubeInformers
+}
+
+func (w *newNodeCertOwnership) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	var err error
+	w.adminRESTConfig = adminRESTConfig
+	w.kubeClient, err = kubernetes.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return err
+	}
+	isMicroShift, err := exutil.IsMicroShiftCluster(w.kubeClient)
+	if err != nil {
+		return fmt.Errorf("unable to determine if cluster is MicroShift: %v", err)
+	}
+	if isMicroShift {
+		w.notSupportedReason = &monitortestframework.NotSupportedError{Reason: "platform MicroShift not supported"}
+		return w.notSupportedReason
+	}
+
+	w.testsImagePullSpec, err = disruptionpodnetwork.GetOpenshiftTestsImagePullSpec(ctx, adminRESTConfig, w.payloadImagePullSpec, nil)
+	if err != nil {
+		w.notSupportedReason = &monitortestframework.NotSupportedError{Reason: fmt.Sprintf("unable to determine openshift-tests image: %v", err)}
+		return w.notSupportedReason
+	}
+	w.expectedPKIContent, err = certs.GetPKIInfoFromEmbeddedOwnership(ownership.PKIOwnership)
+	if err != nil {
+		return err
+	}
+	w.violationPKIContent, err = certs.GetPKIInfoFromEmbeddedOwnership(ownership.PKIViolations)
+	if err != nil {
+		return err
+	}
+
+	nodes, err := w.kubeClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	w.initialNodes = sets.New[string]()
+	for _, node := range nodes.Items {
+		w.initialNodes.Insert(node.Name)
+	}
+	w.queuedNodes = sets.New[string]()
+	w.queue = workqueue.NewNamed("NewNodeCertOwnership")
+
+	// nodes are checked until CollectData, not for as long as the StartCollection context lives.
+	checkCtx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+
+	if w.kubeInformers == nil {
+		w.kubeInformers = informers.NewSharedInformerFactory(w.kubeClient, 0)
+		defer w.kubeInformers.Start(ctx.Done())
+	}
+	// the queue ignores the nodes that change after CollectData shut it down.
+	if _, err := w.kubeInformers.Core().V1().Nodes().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    w.nodeChanged,
+		UpdateFunc: func(_, obj interface{}) { w.nodeChanged(obj) },
+	}); err != nil {
+		return err
+	}
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		for w.processNextNode(checkCtx) {
+		}
+	}()
+	return nil
+}
+
+func (w *newNodeCertOwnership) nodeChanged(obj interface{}) {
+	node, ok := obj.(*corev1.Node)
+	if !ok {
+		return
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.queuedNodes.Has(node.Name) || !joinedAndReady(node, w.initialNodes) {
+		return
+	}
+	logrus.Infof("node/%s joined during the run, collecting its on-disk tls artifacts", node.Name)
+	w.queuedNodes.Insert(node.Name)
+	w.queue.Add(node.Name)
+}
+
+func (w *newNodeCertOwnership) processNextNode(ctx context.Context) bool {
+	key, quit := w.queue.Get()
+	if quit {
+		return false
+	}
+	defer w.queue.Done(key)
+	nodeName := key.(string)
+	// the nodes still queued at the end of the run are not checked.
+	if ctx.Err() != nil {
+		return true
+	}
+
+	// a collection in progress is allowed to finish, so that it removes the namespace it created.
+	fetchCtx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+	defer cancel()
+	node, err := w.kubeClient.CoreV1().Nodes().Get(fetchCtx, nodeName, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		logrus.Infof("node/%s was removed before its on-disk tls artifacts were collected", nodeName)
+		return true
+	case err != nil:
+		w.recordResult(nodeResult{nodeName: nodeName, err: err})
+		return true
+	}
+
+	pkiList, err := ondiskcerts.FetchOnDiskCertificates(fetchCtx, w.kubeClient, w.adminRESTConfig, []*corev1.Node{node}, w.testsImagePullSpec)
+	if err != nil {
+		w.recordResult(nodeResult{nodeName: nodeName, err: err})
+		return true
+	}
+	w.recordResult(nodeResult{
+		nodeName:     nodeName,
+		unregistered: ondiskcerts.UnregisteredOnDiskArtifacts(pkiList, w.expectedPKIContent, w.violationPKIContent),
+	})
+	return true
+}
+
+func (w *newNodeCertOwnership) recordResult(result nodeResult) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.results = append(w.results, result)
+}
+
+func (w *newNodeCertOwnership) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	if w.notSupportedReason != nil {
+		return nil, nil, w.notSupportedReason
+	}
+	w.stopChecks()
+	return nil, nil, nil
+}
+
+func (w *newNodeCertOwnership) stopChecks() {
+	if w.cancel != nil {
+		w.cancel()
+		w.queue.ShutDown()
+		w.wg.Wait()
+	}
+}
+
+func (w *newNodeCertOwnership) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	return nil, w.notSupportedReason
+}
+
+func (w *newNodeCertOwnership) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	if w.notSupportedReason != nil {
+		return nil, w.notSupportedReason
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	sort.SliceStable(w.results, func(i, j int) bool {
+		return w.results[i].nodeName < w.results[j].nodeName
+	})
+	return junitsForNodes(w.results), nil
+}
+
+func (w *newNodeCertOwnership) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	return w.notSupportedReason
+}
+
+func (w *newNodeCertOwnership) Cleanup(ctx context.Context) error {
+	w.stopChecks()
+	return nil
+}
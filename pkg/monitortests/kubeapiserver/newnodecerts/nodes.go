@@ -0,0 +1,71 @@
+package newnodecerts
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openshift/origin/pkg/certs"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+)
+
+// nodeResult is the outcome of the on-disk collection on one node that joined during the run.
+type nodeResult struct {
+	nodeName     string
+	unregistered *certs.PKIRegistryInfo
+	err          error
+}
+
+// joinedAndReady returns true for nodes that were not part of the cluster when collection started and can now
+// run the collector.  Nodes are only checked once they are ready, the kubelet has written its certificates by then.
+func joinedAndReady(node *corev1.Node, initialNodes sets.Set[string]) bool {
+	if initialNodes.Has(node.Name) || node.DeletionTimestamp != nil {
+		return false
+	}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// testName is the same for every node, so that the result can be tracked across runs with different nodes.
+var testName = fmt.Sprintf("[sig-arch][Jira:%q] all on-disk tls artifacts of nodes that joined during the run must be registered", "kube-apiserver")
+
+// junitsForNodes flakes like the late check of the nodes present at the end of the run does, until the registry is
+// complete for every node configuration.  The failure lists every node that could not be checked or has unregistered
+// artifacts.
+func junitsForNodes(results []nodeResult) []*junitapi.JUnitTestCase {
+	failures := []string{}
+	for _, result := range results {
+		switch {
+		case result.err != nil:
+			failures = append(failures, fmt.Sprintf("unable to collect the on-disk tls artifacts of node/%s: %v", result.nodeName, result.err))
+		case len(result.unregistered.CertKeyPairs) > 0 || len(result.unregistered.CertificateAuthorityBundles) > 0:
+			registryString, err := json.MarshalIndent(result.unregistered, "", "  ")
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("Failed to marshal registry of node/%s %#v: %v", result.nodeName, result.unregistered, err))
+				continue
+			}
+			failures = append(failures, fmt.Sprintf("Unregistered TLS certificates found on node/%s:\n%s", result.nodeName, registryString))
+		}
+	}
+
+	success := &junitapi.JUnitTestCase{Name: testName}
+	if len(failures) == 0 {
+		return []*junitapi.JUnitTestCase{success}
+	}
+	return []*junitapi.JUnitTestCase{
+		{
+			Name: testName,
+			FailureOutput: &junitapi.FailureOutput{
+				Output: fmt.Sprintf("%s\n\nSee tls/ownership/README.md in origin repo", strings.Join(failures, "\n\n")),
+			},
+		},
+		success,
+	}
+}
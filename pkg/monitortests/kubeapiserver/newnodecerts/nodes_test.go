@@ -0,0 +1,71 @@
+package newnodecerts
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/openshift/library-go/pkg/certs/cert-inspection/certgraphapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openshift/origin/pkg/certs"
+)
+
+func nodeWithReady(name string, ready corev1.ConditionStatus) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}},
+		},
+	}
+}
+
+func TestJoinedAndReady(t *testing.T) {
+	initialNodes := sets.New[string]("master-0", "worker-a")
+
+	assert.False(t, joinedAndReady(nodeWithReady("worker-a", corev1.ConditionTrue), initialNodes), "nodes present at the start are not checked")
+	assert.False(t, joinedAndReady(nodeWithReady("worker-b", corev1.ConditionFalse), initialNodes), "new nodes are checked once ready")
+	assert.False(t, joinedAndReady(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-b"}}, initialNodes))
+	assert.True(t, joinedAndReady(nodeWithReady("worker-b", corev1.ConditionTrue), initialNodes))
+
+	deleting := nodeWithReady("worker-c", corev1.ConditionTrue)
+	deleting.DeletionTimestamp = &metav1.Time{}
+	assert.False(t, joinedAndReady(deleting, initialNodes))
+}
+
+func TestJUnitsForNodes(t *testing.T) {
+	junits := junitsForNodes(nil)
+	require.Len(t, junits, 1, "the test passes when no node joined")
+	assert.Equal(t, testName, junits[0].Name)
+	assert.Nil(t, junits[0].FailureOutput)
+
+	junits = junitsForNodes([]nodeResult{{nodeName: "worker-b", unregistered: &certs.PKIRegistryInfo{}}})
+	require.Len(t, junits, 1)
+	assert.Nil(t, junits[0].FailureOutput)
+
+	junits = junitsForNodes([]nodeResult{
+		{nodeName: "worker-b", unregistered: &certs.PKIRegistryInfo{}},
+		{
+			nodeName: "worker-c",
+			unregistered: &certs.PKIRegistryInfo{
+				CertKeyPairs: []certgraphapi.PKIRegistryCertKeyPair{{
+					OnDiskLocation: &certgraphapi.PKIRegistryOnDiskCertKeyPair{
+						OnDiskLocation: certgraphapi.OnDiskLocation{Path: "/etc/kubernetes/new.crt"},
+					},
+				}},
+			},
+		},
+		{nodeName: "worker-d", err: errors.New("pod didn't start")},
+	})
+	require.Len(t, junits, 2, "unregistered artifacts flake")
+	assert.Equal(t, testName, junits[0].Name, "the node is not part of the test name")
+	require.NotNil(t, junits[0].FailureOutput)
+	assert.Contains(t, junits[0].FailureOutput.Output, "Unregistered TLS certificates found on node/worker-c")
+	assert.Contains(t, junits[0].FailureOutput.Output, "/etc/kubernetes/new.crt")
+	assert.Contains(t, junits[0].FailureOutput.Output, "unable to collect the on-disk tls artifacts of node/worker-d: pod didn't start")
+	assert.NotContains(t, junits[0].FailureOutput.Output, "worker-b")
+	assert.Nil(t, junits[1].FailureOutput)
+}
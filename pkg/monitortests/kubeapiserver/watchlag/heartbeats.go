@@ -0,0 +1,158 @@
+package watchlag
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+const (
+	// heartbeatInterval is how often the heartbeat config map is updated.
+	heartbeatInterval = 10 * time.Second
+	// lagThreshold is the lag of a heartbeat above which the watch latency spiked.  Updates normally reach the watch
+	// of the test process in well under a second.
+	lagThreshold = 5 * time.Second
+)
+
+// Heartbeat is one update of the heartbeat config map.  Sent is read before the update is written, so the lag includes
+// the write, not only the watch.
+type Heartbeat struct {
+	Sequence int
+	Sent     time.Time
+	// Received is zero while the informer has not seen the update.
+	Received time.Time
+}
+
+// WatchError is an error of the watch of the heartbeat informer, after which the informer relisted.
+type WatchError struct {
+	At    time.Time
+	Error string
+}
+
+// heartbeatTracker measures how long the updates of the test process take to reach its own informer, the same path
+// as the watches the tests wait on.  Both ends use the clock of the test process, so the clocks of the nodes do not
+// matter.
+type heartbeatTracker struct {
+	lock        sync.Mutex
+	heartbeats  []*Heartbeat
+	watchErrors []WatchError
+}
+
+func (t *heartbeatTracker) sent(sequence int, at time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.heartbeats = append(t.heartbeats, &Heartbeat{Sequence: sequence, Sent: at})
+}
+
+// received records the heartbeat as seen.  The heartbeats sent before it that were never seen were coalesced by a
+// relist, their content reached the informer no sooner than this one.
+func (t *heartbeatTracker) received(sequence int, at time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for _, heartbeat := range t.heartbeats {
+		if heartbeat.Sequence <= sequence && heartbeat.Received.IsZero() {
+			heartbeat.Received = at
+		}
+	}
+}
+
+func (t *heartbeatTracker) watchFailed(at time.Time, err error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.watchErrors = append(t.watchErrors, WatchError{At: at, Error: err.Error()})
+}
+
+// snapshot copies the heartbeats and watch errors recorded so far.
+func (t *heartbeatTracker) snapshot() ([]Heartbeat, []WatchError) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	heartbeats := make([]Heartbeat, 0, len(t.heartbeats))
+	for _, heartbeat := range t.heartbeats {
+		heartbeats = append(heartbeats, *heartbeat)
+	}
+	return heartbeats, append([]WatchError{}, t.watchErrors...)
+}
+
+// latencySpike is a run of consecutive heartbeats that lagged more than lagThreshold.
+type latencySpike struct {
+	from       time.Time
+	to         time.Time
+	heartbeats int
+	peak       time.Duration
+	// unreceived is the number of heartbeats that never reached the informer before the end of the run.
+	unreceived int
+}
+
+// latencySpikes groups the consecutive heartbeats that lagged more than lagThreshold.  Heartbeats that were never
+// received lag until end.
+func latencySpikes(heartbeats []Heartbeat, end time.Time) []latencySpike {
+	sort.SliceStable(heartbeats, func(i, j int) bool {
+		return heartbeats[i].Sequence < heartbeats[j].Sequence
+	})
+	ret := []latencySpike{}
+	var current *latencySpike
+	for _, heartbeat := range heartbeats {
+		received := heartbeat.Received
+		if received.IsZero() {
+			received = end
+		}
+		lag := received.Sub(heartbeat.Sent)
+		if lag <= lagThreshold {
+			if current != nil {
+				ret = append(ret, *current)
+				current = nil
+			}
+			continue
+		}
+		if current == nil {
+			current = &latencySpike{from: heartbeat.Sent}
+		}
+		current.heartbeats++
+		current.peak = max(current.peak, lag)
+		if received.After(current.to) {
+			current.to = received
+		}
+		if heartbeat.Received.IsZero() {
+			current.unreceived++
+		}
+	}
+	if current != nil {
+		ret = append(ret, *current)
+	}
+	return ret
+}
+
+func latencySpikeIntervals(spikes []latencySpike) monitorapi.Intervals {
+	ret := monitorapi.Intervals{}
+	for _, spike := range spikes {
+		message := monitorapi.NewMessage().Reason(monitorapi.WatchLatencySpikeReason)
+		if spike.unreceived > 0 {
+			message.HumanMessagef("%d heartbeats reached the watch up to %s after they were written, %d never did",
+				spike.heartbeats, spike.peak.Round(time.Millisecond), spike.unreceived)
+		} else {
+			message.HumanMessagef("%d heartbeats reached the watch up to %s after they were written",
+				spike.heartbeats, spike.peak.Round(time.Millisecond))
+		}
+		ret = append(ret, monitorapi.NewInterval(monitorapi.SourceWatchLag, monitorapi.Warning).
+			Locator(monitorapi.NewLocator().KubeAPIServerWithLB("")).
+			Message(message).
+			Display().
+			Build(spike.from, spike.to))
+	}
+	return ret
+}
+
+func watchErrorIntervals(watchErrors []WatchError) monitorapi.Intervals {
+	ret := monitorapi.Intervals{}
+	for _, watchError := range watchErrors {
+		ret = append(ret, monitorapi.NewInterval(monitorapi.SourceWatchLag, monitorapi.Warning).
+			Locator(monitorapi.NewLocator().KubeAPIServerWithLB("")).
+			Message(monitorapi.NewMessage().Reason(monitorapi.WatchErrorReason).
+				HumanMessagef("watch of the heartbeat config map failed: %s", watchError.Error)).
+			Display().
+			Build(watchError.At, watchError.At))
+	}
+	return ret
+}
@@ -0,0 +1,184 @@
+package watchlag
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	routeclient "github.com/openshift/client-go/route/clientset/versioned"
+	"github.com/openshift/library-go/test/library/metrics"
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestlibrary/prometheus"
+	prometheusv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	prometheustypes "github.com/prometheus/common/model"
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// cacheReadWaitQuery is the 99th percentile of the time reads of each resource waited for the watch cache of each
+	// apiserver to catch up with the resource version they asked for.
+	cacheReadWaitQuery = `histogram_quantile(0.99, sum by (le, apiserver, resource) (rate(apiserver_watch_cache_read_wait_seconds_bucket[1m])))`
+	// terminatedWatchersQuery is the rate of watchers of each resource each apiserver terminated because they did not
+	// read their events fast enough.
+	terminatedWatchersQuery = `sum by (apiserver, resource) (rate(apiserver_terminated_watchers_total[1m]))`
+	// sampleStep is the resolution of the samples, the rates are over a minute so finer steps add nothing.
+	sampleStep = 15 * time.Second
+
+	// cacheReadWaitThreshold is the read wait in seconds above which a watch cache lags.
+	cacheReadWaitThreshold = 1.0
+	// terminatedWatchersThreshold is the terminations per second above which an apiserver is terminating watchers.
+	terminatedWatchersThreshold = 0.0
+)
+
+// Sample is the value of a watch metric of a resource at one point in time.
+type Sample struct {
+	Time  time.Time
+	Value float64
+}
+
+// Series are the samples of one resource of one apiserver.
+type Series struct {
+	APIServer string
+	Resource  string
+	Samples   []Sample
+}
+
+// Episode is a period where a resource of an apiserver stayed above the threshold of a metric.
+type Episode struct {
+	APIServer string
+	Resource  string
+	Reason    monitorapi.IntervalReason
+	From      time.Time
+	To        time.Time
+	Peak      float64
+}
+
+func fetchWatchSeries(ctx context.Context, restConfig *rest.Config, beginning, end time.Time) (cacheReadWait, terminatedWatchers []Series, err error) {
+	logger := logrus.WithField("func", "fetchWatchSeries")
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	routeClient, err := routeclient.NewForConfig(restConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_, err = kubeClient.CoreV1().Namespaces().Get(ctx, "openshift-monitoring", metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return []Series{}, []Series{}, nil
+	}
+
+	prometheusClient, err := metrics.NewPrometheusClient(ctx, kubeClient, routeClient)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := prometheus.EnsureThanosQueriersConnectedToPromSidecars(ctx, prometheusClient); err != nil {
+		return nil, nil, err
+	}
+
+	timeRange := prometheusv1.Range{
+		Start: beginning,
+		End:   end,
+		Step:  sampleStep,
+	}
+	ret := [][]Series{}
+	for _, query := range []string{cacheReadWaitQuery, terminatedWatchersQuery} {
+		values, warningsForQuery, err := prometheusClient.QueryRange(ctx, query, timeRange)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, w := range warningsForQuery {
+			logger.Warnf("watch lag prom query warning: %s", w)
+		}
+		ret = append(ret, seriesFromPrometheusValue(logger, values))
+	}
+	return ret[0], ret[1], nil
+}
+
+func seriesFromPrometheusValue(logger logrus.FieldLogger, promVal prometheustypes.Value) []Series {
+	ret := []Series{}
+	matrix, ok := promVal.(prometheustypes.Matrix)
+	if !ok {
+		logger.WithField("type", promVal.Type()).Warning("unhandled prometheus type received")
+		return ret
+	}
+	for _, sampleStream := range matrix {
+		series := Series{
+			APIServer: string(sampleStream.Metric["apiserver"]),
+			Resource:  string(sampleStream.Metric["resource"]),
+		}
+		for _, value := range sampleStream.Values {
+			series.Samples = append(series.Samples, Sample{Time: value.Timestamp.Time(), Value: float64(value.Value)})
+		}
+		ret = append(ret, series)
+	}
+	return ret
+}
+
+// episodesFromSeries groups the consecutive samples of each series above the threshold into episodes.  A missing
+// sample ends an episode.  Quantiles of histograms without observations are NaN, which is never above the threshold.
+func episodesFromSeries(series []Series, reason monitorapi.IntervalReason, step time.Duration, threshold float64) []Episode {
+	ret := []Episode{}
+	for _, s := range series {
+		var current *Episode
+		var lastSample time.Time
+		for _, sample := range s.Samples {
+			above := sample.Value > threshold
+			if current != nil && (!above || sample.Time.Sub(lastSample) > step) {
+				ret = append(ret, *current)
+				current = nil
+			}
+			lastSample = sample.Time
+			if !above {
+				continue
+			}
+			if current == nil {
+				// the samples cover the preceding step, so the episode started one step before the first sample.
+				current = &Episode{APIServer: s.APIServer, Resource: s.Resource, Reason: reason, From: sample.Time.Add(-step)}
+			}
+			current.To = sample.Time
+			current.Peak = max(current.Peak, sample.Value)
+		}
+		if current != nil {
+			ret = append(ret, *current)
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].From.Before(ret[j].From)
+	})
+	return ret
+}
+
+// apiserverLocator locates the resource of an apiserver.  The apiserver label is the name of the apiserver binary,
+// kube-apiserver or openshift-apiserver.
+func apiserverLocator(apiserver, resource string) monitorapi.Locator {
+	locator := monitorapi.NewLocator().LocateServer(apiserver, "", "", "")
+	locator.Type = monitorapi.LocatorTypeAPIServer
+	if len(resource) > 0 {
+		locator.Keys[monitorapi.LocatorResourceKey] = resource
+	}
+	return locator
+}
+
+func intervalsFromEpisodes(episodes []Episode) monitorapi.Intervals {
+	ret := monitorapi.Intervals{}
+	for _, episode := range episodes {
+		message := monitorapi.NewMessage().Reason(episode.Reason)
+		if episode.Reason == monitorapi.WatchersTerminatedReason {
+			message.HumanMessagef("%s terminated up to %.2f watchers/s of %s that did not keep up", episode.APIServer, episode.Peak, episode.Resource)
+		} else {
+			message.HumanMessagef("reads of %s waited up to %.2fs for the watch cache of %s", episode.Resource, episode.Peak, episode.APIServer)
+		}
+		ret = append(ret, monitorapi.NewInterval(monitorapi.SourceWatchLag, monitorapi.Warning).
+			Locator(apiserverLocator(episode.APIServer, episode.Resource)).
+			Message(message).
+			Display().
+			Build(episode.From, episode.To))
+	}
+	return ret
+}
@@ -0,0 +1,187 @@
+package watchlag
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	heartbeatConfigMap = "watch-lag-heartbeat"
+	sequenceKey        = "sequence"
+)
+
+// watchLagMonitor records when the watches of the cluster lagged, both as seen by the test process through its own
+// informer and as reported by the apiservers, so that tests failing on stale data can be tied to server side lag.
+type watchLagMonitor struct {
+	adminRESTConfig *rest.Config
+	kubeClient      kubernetes.Interface
+	namespace       string
+	cancel          context.CancelFunc
+	wg              sync.WaitGroup
+
+	tracker heartbeatTracker
+
+	cacheReadWait      []Series
+	terminatedWatchers []Series
+	episodes           []Episode
+}
+
+// WatchLag is written as the watch-lag artifact.
+type WatchLag struct {
+	Heartbeats  []Heartbeat
+	WatchErrors []WatchError
+	Episodes    []Episode
+}
+
+func NewWatchLagMonitor() monitortestframework.MonitorTest {
+	return &watchLagMonitor{}
+}
+
+func (*watchLagMonitor) Describe() monitortestframework.MonitorTestDescription {
+	return monitortestframework.MonitorTestDescription{
+		WatchedResources: []string{"configmaps"},
+	}
+}
+
+func (w *watchLagMonitor) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	w.adminRESTConfig = adminRESTConfig
+	kubeClient, err := kubernetes.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return err
+	}
+	w.kubeClient = kubeClient
+
+	namespace, err := kubeClient.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{GenerateName: "e2e-watch-lag-"},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+	w.namespace = namespace.Name
+	if _, err := kubeClient.CoreV1().ConfigMaps(w.namespace).Create(ctx, heartbeatConfigMapWithSequence(0), metav1.CreateOptions{}); err != nil {
+		return err
+	}
+
+	// heartbeats are sent until CollectData, not for as long as the StartCollection context lives.
+	heartbeatCtx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+
+	kubeInformers := informers.NewSharedInformerFactoryWithOptions(kubeClient, 0, informers.WithNamespace(w.namespace))
+	informer := kubeInformers.Core().V1().ConfigMaps().Informer()
+	if err := informer.SetWatchErrorHandler(func(r *cache.Reflector, err error) {
+		w.tracker.watchFailed(time.Now(), err)
+		cache.DefaultWatchErrorHandler(r, err)
+	}); err != nil {
+		return err
+	}
+	if _, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(_, obj interface{}) {
+			configMap, ok := obj.(*corev1.ConfigMap)
+			if !ok || configMap.Name != heartbeatConfigMap {
+				return
+			}
+			if sequence, err := strconv.Atoi(configMap.Data[sequenceKey]); err == nil {
+				w.tracker.received(sequence, time.Now())
+			}
+		},
+	}); err != nil {
+		return err
+	}
+	kubeInformers.Start(heartbeatCtx.Done())
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		sequence := 0
+		wait.UntilWithContext(heartbeatCtx, func(ctx context.Context) {
+			sequence++
+			sent := time.Now()
+			if _, err := w.kubeClient.CoreV1().ConfigMaps(w.namespace).Update(ctx, heartbeatConfigMapWithSequence(sequence), metav1.UpdateOptions{}); err != nil {
+				logrus.WithError(err).Warn("unable to send watch lag heartbeat")
+				return
+			}
+			w.tracker.sent(sequence, sent)
+		}, heartbeatInterval)
+	}()
+	return nil
+}
+
+func heartbeatConfigMapWithSequence(sequence int) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: heartbeatConfigMap},
+		Data:       map[string]string{sequenceKey: strconv.Itoa(sequence)},
+	}
+}
+
+func (w *watchLagMonitor) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	w.stopHeartbeats()
+	cacheReadWait, terminatedWatchers, err := fetchWatchSeries(ctx, w.adminRESTConfig, beginning, end)
+	if err != nil {
+		return nil, nil, err
+	}
+	w.cacheReadWait, w.terminatedWatchers = cacheReadWait, terminatedWatchers
+	return nil, nil, nil
+}
+
+func (w *watchLagMonitor) stopHeartbeats() {
+	if w.cancel != nil {
+		w.cancel()
+		w.wg.Wait()
+	}
+}
+
+func (w *watchLagMonitor) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	heartbeats, watchErrors := w.tracker.snapshot()
+	w.episodes = append(
+		episodesFromSeries(w.cacheReadWait, monitorapi.WatchCacheLagReason, sampleStep, cacheReadWaitThreshold),
+		episodesFromSeries(w.terminatedWatchers, monitorapi.WatchersTerminatedReason, sampleStep, terminatedWatchersThreshold)...)
+
+	ret := latencySpikeIntervals(latencySpikes(heartbeats, end))
+	ret = append(ret, watchErrorIntervals(watchErrors)...)
+	ret = append(ret, intervalsFromEpisodes(w.episodes)...)
+	return ret, nil
+}
+
+func (*watchLagMonitor) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	return nil, nil
+}
+
+func (w *watchLagMonitor) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	heartbeats, watchErrors := w.tracker.snapshot()
+	if len(heartbeats) == 0 && len(w.episodes) == 0 {
+		return nil
+	}
+	jsonContent, err := json.MarshalIndent(WatchLag{Heartbeats: heartbeats, WatchErrors: watchErrors, Episodes: w.episodes}, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(storageDir, fmt.Sprintf("watch-lag%s.json", timeSuffix)), jsonContent, 0644)
+}
+
+func (w *watchLagMonitor) Cleanup(ctx context.Context) error {
+	w.stopHeartbeats()
+	if len(w.namespace) > 0 && w.kubeClient != nil {
+		if err := w.kubeClient.CoreV1().Namespaces().Delete(ctx, w.namespace, metav1.DeleteOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
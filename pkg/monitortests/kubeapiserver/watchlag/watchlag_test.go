@@ -0,0 +1,74 @@
+package watchlag
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+func TestLatencySpikes(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	at := func(beat int) time.Time { return start.Add(time.Duration(beat) * heartbeatInterval) }
+
+	tracker := &heartbeatTracker{}
+	for beat := 1; beat <= 7; beat++ {
+		tracker.sent(beat, at(beat))
+	}
+	tracker.received(1, at(1).Add(100*time.Millisecond))
+	// 2 and 3 are coalesced into 4 by a relist.
+	tracker.received(4, at(4).Add(time.Second))
+	tracker.received(5, at(5).Add(200*time.Millisecond))
+	tracker.received(6, at(6).Add(8*time.Second))
+	tracker.watchFailed(at(3), errors.New("very short watch"))
+
+	heartbeats, watchErrors := tracker.snapshot()
+	require.Len(t, watchErrors, 1)
+	spikes := latencySpikes(heartbeats, at(8))
+	require.Len(t, spikes, 2)
+	assert.Equal(t, latencySpike{from: at(2), to: at(4).Add(time.Second), heartbeats: 2, peak: 21 * time.Second}, spikes[0])
+	assert.Equal(t, latencySpike{from: at(6), to: at(8), heartbeats: 2, peak: 10 * time.Second, unreceived: 1}, spikes[1])
+
+	intervals := latencySpikeIntervals(spikes)
+	require.Len(t, intervals, 2)
+	assert.Equal(t, "2 heartbeats reached the watch up to 10s after they were written, 1 never did", intervals[1].Message.HumanMessage)
+	assert.Equal(t, "watch of the heartbeat config map failed: very short watch", watchErrorIntervals(watchErrors)[0].Message.HumanMessage)
+}
+
+func TestEpisodesFromSeries(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	at := func(step int) time.Time { return start.Add(time.Duration(step) * sampleStep) }
+
+	series := []Series{
+		{
+			APIServer: "kube-apiserver", Resource: "pods",
+			Samples: []Sample{
+				{Time: at(0), Value: math.NaN()},
+				{Time: at(1), Value: 1.5},
+				{Time: at(2), Value: 3},
+				{Time: at(3), Value: 0.2},
+				// a missing sample ends the episode.
+				{Time: at(4), Value: 2},
+				{Time: at(6), Value: 2},
+			},
+		},
+	}
+
+	episodes := episodesFromSeries(series, monitorapi.WatchCacheLagReason, sampleStep, cacheReadWaitThreshold)
+	require.Len(t, episodes, 3)
+	assert.Equal(t, Episode{APIServer: "kube-apiserver", Resource: "pods", Reason: monitorapi.WatchCacheLagReason, From: at(0), To: at(2), Peak: 3}, episodes[0])
+	assert.Equal(t, at(3), episodes[1].From)
+	assert.Equal(t, at(5), episodes[2].From)
+
+	intervals := intervalsFromEpisodes(episodes)
+	require.Len(t, intervals, 3)
+	assert.Equal(t, monitorapi.LocatorTypeAPIServer, intervals[0].Locator.Type)
+	assert.Equal(t, "kube-apiserver", intervals[0].Locator.Keys[monitorapi.LocatorServerKey])
+	assert.Equal(t, "pods", intervals[0].Locator.Keys[monitorapi.LocatorResourceKey])
+	assert.Equal(t, "reads of pods waited up to 3.00s for the watch cache of kube-apiserver", intervals[0].Message.HumanMessage)
+}
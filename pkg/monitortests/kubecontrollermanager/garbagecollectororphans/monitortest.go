@@ -0,0 +1,119 @@
+package garbagecollectororphans
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+)
+
+// orphanGracePeriod is how long the garbage collector has to delete the orphans found at the end of the run.  Their
+// owners may have been deleted just before.
+const orphanGracePeriod = 2 * time.Minute
+
+type garbageCollectorOrphans struct {
+	adminRESTConfig *rest.Config
+	orphans         []orphan
+}
+
+// NewGarbageCollectorOrphans scans for objects that outlived their owners at the end of the run.
+func NewGarbageCollectorOrphans() monitortestframework.MonitorTest {
+	return &garbageCollectorOrphans{}
+}
+
+func (w *garbageCollectorOrphans) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	w.adminRESTConfig = adminRESTConfig
+	return nil
+}
+
+func (w *garbageCollectorOrphans) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	kubeClient, err := kubernetes.NewForConfig(w.adminRESTConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	dynamicClient, err := dynamic.NewForConfig(w.adminRESTConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	checker := &ownerChecker{
+		dynamicClient: dynamicClient,
+		mapper:        restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(kubeClient.Discovery())),
+		exists:        map[types.UID]bool{},
+	}
+	terminatingNamespaces, err := listTerminatingNamespaces(ctx, kubeClient)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	first, err := findOrphans(ctx, dynamicClient, checker, terminatingNamespaces)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(first) == 0 {
+		w.orphans = first
+		return nil, nil, nil
+	}
+
+	logrus.Infof("found %d objects whose owners no longer exist, waiting %s for the garbage collector", len(first), orphanGracePeriod)
+	select {
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	case <-time.After(orphanGracePeriod):
+	}
+	// namespaces may have started terminating meanwhile, the owners found missing stay missing.
+	terminatingNamespaces, err = listTerminatingNamespaces(ctx, kubeClient)
+	if err != nil {
+		return nil, nil, err
+	}
+	second, err := findOrphans(ctx, dynamicClient, checker, terminatingNamespaces)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to scan for orphans again: %w", err)
+	}
+	w.orphans = stillOrphaned(first, second)
+	return nil, nil, nil
+}
+
+func listTerminatingNamespaces(ctx context.Context, kubeClient kubernetes.Interface) (sets.Set[string], error) {
+	namespaces, err := kubeClient.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	ret := sets.New[string]()
+	for _, namespace := range namespaces.Items {
+		if namespace.DeletionTimestamp != nil {
+			ret.Insert(namespace.Name)
+		}
+	}
+	return ret, nil
+}
+
+func (*garbageCollectorOrphans) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	return nil, nil
+}
+
+func (w *garbageCollectorOrphans) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	if w.orphans == nil {
+		return nil, nil
+	}
+	return orphansJunits(w.orphans), nil
+}
+
+func (*garbageCollectorOrphans) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	return nil
+}
+
+func (*garbageCollectorOrphans) Cleanup(ctx context.Context) error {
+	return nil
+}
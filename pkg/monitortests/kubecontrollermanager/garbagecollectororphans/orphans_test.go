@@ -0,0 +1,68 @@
+package garbagecollectororphans
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func TestFindOrphans(t *testing.T) {
+	object := func(apiVersion, kind, namespace, name string, owners ...metav1.OwnerReference) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion(apiVersion)
+		obj.SetKind(kind)
+		obj.SetNamespace(namespace)
+		obj.SetName(name)
+		obj.SetUID(types.UID(namespace + "-" + name))
+		obj.SetOwnerReferences(owners)
+		return obj
+	}
+	ownedBy := func(kind, name string, uid types.UID) metav1.OwnerReference {
+		return metav1.OwnerReference{APIVersion: "apps/v1", Kind: kind, Name: name, UID: uid}
+	}
+
+	deployment := object("apps/v1", "Deployment", "openshift-dns", "dns")
+	objects := []runtime.Object{
+		deployment,
+		object("apps/v1", "ReplicaSet", "openshift-dns", "dns-1", ownedBy("Deployment", "dns", deployment.GetUID())),
+		// the deployment was deleted and recreated, the replicaset of the old one is an orphan
+		object("apps/v1", "ReplicaSet", "openshift-dns", "dns-0", ownedBy("Deployment", "dns", "old-dns")),
+		object("v1", "Pod", "e2e-test-gc", "client", ownedBy("ReplicaSet", "client", "gone")),
+		object("v1", "Pod", "e2e-test-deleting", "client", ownedBy("ReplicaSet", "client", "gone-too")),
+	}
+	scheme := runtime.NewScheme()
+	listKinds := map[schema.GroupVersionResource]string{}
+	for _, resource := range ownedResources {
+		listKinds[resource] = "List"
+	}
+	listKinds[schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}] = "List"
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme, listKinds, objects...)
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "ReplicaSet"}, meta.RESTScopeNamespace)
+	checker := &ownerChecker{dynamicClient: dynamicClient, mapper: mapper, exists: map[types.UID]bool{}}
+
+	orphans, err := findOrphans(context.TODO(), dynamicClient, checker, sets.New("e2e-test-deleting"))
+	require.NoError(t, err)
+	require.Len(t, orphans, 2)
+
+	// only the orphans still there after the grace period are reported
+	orphans = stillOrphaned(orphans, orphans[1:])
+	junits := orphansJunits(orphans)
+	require.Len(t, junits, 2)
+	require.NotNil(t, junits[0].FailureOutput)
+	assert.Contains(t, junits[0].FailureOutput.Output, "replicasets.apps/dns-0: owner Deployment/dns (old-dns) no longer exists")
+	assert.NotContains(t, junits[0].FailureOutput.Output, "e2e-test-gc")
+	assert.Nil(t, junits[1].FailureOutput)
+}
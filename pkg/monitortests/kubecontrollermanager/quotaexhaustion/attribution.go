@@ -0,0 +1,112 @@
+package quotaexhaustion
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+// victimGrace is how long after a quota was no longer exhausted an admission failure is still attributed to it, the
+// events of the failures are recorded after the fact.
+const victimGrace = 30 * time.Second
+
+// Attribution tells apart the e2e tests that were running when a quota was exhausted, one of which likely exhausted
+// it, from the objects whose creation it denied meanwhile, whose tests were its victims.
+type Attribution struct {
+	Quota    string
+	Resource string
+	From     time.Time
+	To       time.Time
+	// Usage describes the usage when the quota was exhausted, with the namespaces using a ClusterResourceQuota.
+	Usage string
+	// RunningTests are the e2e tests running when the quota was exhausted.
+	RunningTests []string
+	Victims      []Victim
+}
+
+// Victim is an object whose creation was denied by the exhausted quota.
+type Victim struct {
+	Namespace string
+	Locator   string
+	Message   string
+}
+
+// quotaExceeded returns the name of the quota an admission failure reports as exceeded.  The message reads
+// `pods "name" is forbidden: exceeded quota: <quota>, requested: ...`.
+func quotaExceeded(interval monitorapi.Interval) (string, bool) {
+	if interval.Source != monitorapi.SourceKubeEvent {
+		return "", false
+	}
+	_, quota, ok := strings.Cut(interval.Message.HumanMessage, "exceeded quota: ")
+	if !ok {
+		return "", false
+	}
+	quota, _, _ = strings.Cut(quota, ",")
+	return strings.TrimSpace(quota), true
+}
+
+func runningTests(tests monitorapi.Intervals, at time.Time) []string {
+	ret := []string{}
+	for _, test := range tests {
+		if test.From.After(at) || test.To.Before(at) {
+			continue
+		}
+		if name, ok := monitorapi.E2ETestFromLocator(test.Locator); ok {
+			ret = append(ret, name)
+		}
+	}
+	sort.Strings(ret)
+	if len(ret) > monitorapi.MaxAttributedE2ETests {
+		ret = append(ret[:monitorapi.MaxAttributedE2ETests], fmt.Sprintf("and %d more", len(ret)-monitorapi.MaxAttributedE2ETests))
+	}
+	return ret
+}
+
+// attribute lists the tests running when every quota was exhausted, and the admission failures it caused.  Quota
+// failures in parallel runs are otherwise blamed on the tests that hit them.
+func attribute(intervals monitorapi.Intervals) []Attribution {
+	tests := intervals.Filter(monitorapi.IsE2ETestInterval)
+	exhaustions := intervals.Filter(func(interval monitorapi.Interval) bool {
+		return interval.Source == monitorapi.SourceResourceQuota && interval.Message.Reason == monitorapi.QuotaExhaustedReason
+	})
+	failures := intervals.Filter(func(interval monitorapi.Interval) bool {
+		_, ok := quotaExceeded(interval)
+		return ok
+	})
+
+	ret := []Attribution{}
+	for _, exhausted := range exhaustions {
+		quotaName := exhausted.Locator.Keys[monitorapi.LocatorNameKey]
+		quotaNamespace := exhausted.Locator.Keys[monitorapi.LocatorNamespaceKey]
+		attribution := Attribution{
+			Quota:        exhausted.Locator.OldLocator(),
+			Resource:     exhausted.Message.Cause,
+			From:         exhausted.From,
+			To:           exhausted.To,
+			Usage:        exhausted.Message.HumanMessage,
+			RunningTests: runningTests(tests, exhausted.From),
+			Victims:      []Victim{},
+		}
+		for _, failure := range failures {
+			quota, _ := quotaExceeded(failure)
+			namespace := failure.Locator.Keys[monitorapi.LocatorNamespaceKey]
+			// a ResourceQuota only limits its own namespace, a ClusterResourceQuota the namespaces it selects.
+			if quota != quotaName || (len(quotaNamespace) > 0 && namespace != quotaNamespace) {
+				continue
+			}
+			if failure.From.Before(exhausted.From) || failure.From.After(exhausted.To.Add(victimGrace)) {
+				continue
+			}
+			attribution.Victims = append(attribution.Victims, Victim{
+				Namespace: namespace,
+				Locator:   failure.Locator.OldLocator(),
+				Message:   failure.Message.HumanMessage,
+			})
+		}
+		ret = append(ret, attribution)
+	}
+	return ret
+}
@@ -0,0 +1,182 @@
+package quotaexhaustion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	quotav1 "github.com/openshift/api/quota/v1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+)
+
+// quotaExhaustion records the windows ResourceQuotas and ClusterResourceQuotas had used all of a resource, and
+// writes which e2e tests were running when they were exhausted, and which objects they denied meanwhile.
+type quotaExhaustion struct {
+	lock    sync.Mutex
+	tracker *quotaTracker
+
+	stopCollection context.CancelFunc
+}
+
+func NewQuotaExhaustion() monitortestframework.MonitorTest {
+	return &quotaExhaustion{
+		tracker: newQuotaTracker(),
+	}
+}
+
+func (*quotaExhaustion) Describe() monitortestframework.MonitorTestDescription {
+	return monitortestframework.MonitorTestDescription{
+		WatchedResources: []string{"resourcequotas", "clusterresourcequotas.quota.openshift.io"},
+	}
+}
+
+func (w *quotaExhaustion) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	kubeClient, err := kubernetes.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return err
+	}
+	dynamicClient, err := dynamic.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return err
+	}
+
+	ctx, w.stopCollection = context.WithCancel(context.Background())
+	informerFactory := informers.NewSharedInformerFactory(kubeClient, time.Hour)
+	informerFactory.Core().V1().ResourceQuotas().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { w.observeResourceQuota(obj) },
+		UpdateFunc: func(_, obj interface{}) { w.observeResourceQuota(obj) },
+		DeleteFunc: func(obj interface{}) { w.removeQuota(obj, resourceQuotasResource) },
+	})
+	informerFactory.Start(ctx.Done())
+
+	// MicroShift and HyperShift guest clusters do not serve ClusterResourceQuotas.
+	clusterResourceQuotas := dynamicClient.Resource(clusterResourceQuotasResource)
+	if _, err := clusterResourceQuotas.List(ctx, metav1.ListOptions{Limit: 1}); apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	informer := cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				return clusterResourceQuotas.List(ctx, options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return clusterResourceQuotas.Watch(ctx, options)
+			},
+		},
+		&unstructured.Unstructured{},
+		time.Hour,
+		nil,
+	)
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { w.observeClusterResourceQuota(obj) },
+		UpdateFunc: func(_, obj interface{}) { w.observeClusterResourceQuota(obj) },
+		DeleteFunc: func(obj interface{}) { w.removeQuota(obj, clusterResourceQuotasResource) },
+	})
+	go informer.Run(ctx.Done())
+	return nil
+}
+
+func (w *quotaExhaustion) observeResourceQuota(obj interface{}) {
+	quota, ok := obj.(*corev1.ResourceQuota)
+	if !ok {
+		return
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.tracker.observe(resourceQuotasResource, quota.Namespace, quota.Name, quotaUsage{hard: quota.Status.Hard, used: quota.Status.Used}, time.Now())
+}
+
+func (w *quotaExhaustion) observeClusterResourceQuota(obj interface{}) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	quota := &quotav1.ClusterResourceQuota{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, quota); err != nil {
+		logrus.WithError(err).Warnf("unable to decode clusterresourcequota/%s", u.GetName())
+		return
+	}
+	usage := quotaUsage{
+		hard:        quota.Status.Total.Hard,
+		used:        quota.Status.Total.Used,
+		byNamespace: map[string]corev1.ResourceList{},
+	}
+	for _, namespace := range quota.Status.Namespaces {
+		usage.byNamespace[namespace.Namespace] = namespace.Status.Used
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.tracker.observe(clusterResourceQuotasResource, "", quota.Name, usage, time.Now())
+}
+
+func (w *quotaExhaustion) removeQuota(obj interface{}, quota schema.GroupVersionResource) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.tracker.remove(quota, accessor.GetNamespace(), accessor.GetName(), time.Now())
+}
+
+func (w *quotaExhaustion) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	if w.stopCollection != nil {
+		w.stopCollection()
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.tracker.closeAll(end), nil, nil
+}
+
+func (*quotaExhaustion) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	return nil, nil
+}
+
+func (*quotaExhaustion) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	return nil, nil
+}
+
+func (*quotaExhaustion) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	attributions := attribute(finalIntervals)
+	if len(attributions) == 0 {
+		return nil
+	}
+	jsonContent, err := json.MarshalIndent(attributions, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(storageDir, fmt.Sprintf("quota-exhaustion-attribution%s.json", timeSuffix)), jsonContent, 0644)
+}
+
+func (w *quotaExhaustion) Cleanup(ctx context.Context) error {
+	if w.stopCollection != nil {
+		w.stopCollection()
+	}
+	return nil
+}
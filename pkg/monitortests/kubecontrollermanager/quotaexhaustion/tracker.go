@@ -0,0 +1,149 @@
+package quotaexhaustion
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+var (
+	resourceQuotasResource        = schema.GroupVersionResource{Version: "v1", Resource: "resourcequotas"}
+	clusterResourceQuotasResource = schema.GroupVersionResource{Group: "quota.openshift.io", Version: "v1", Resource: "clusterresourcequotas"}
+)
+
+// maxContributors caps the namespaces listed as using a ClusterResourceQuota.
+const maxContributors = 5
+
+// quotaKey is a resource limited by a quota.  The namespace is empty for ClusterResourceQuotas.
+type quotaKey struct {
+	quota     schema.GroupVersionResource
+	namespace string
+	name      string
+	resource  corev1.ResourceName
+}
+
+// quotaUsage is the usage of a quota, and for ClusterResourceQuotas, of every namespace it selects.
+type quotaUsage struct {
+	hard        corev1.ResourceList
+	used        corev1.ResourceList
+	byNamespace map[string]corev1.ResourceList
+}
+
+// exhaustion is a window a quota had used all of a resource.
+type exhaustion struct {
+	since time.Time
+	// message describes the usage when the quota was exhausted, the namespaces using a ClusterResourceQuota then
+	// are those that exhausted it.
+	message string
+}
+
+// quotaTracker follows the resources of every quota that are exhausted.  It is not safe for concurrent use.
+type quotaTracker struct {
+	exhausted map[quotaKey]*exhaustion
+	intervals monitorapi.Intervals
+}
+
+func newQuotaTracker() *quotaTracker {
+	return &quotaTracker{
+		exhausted: map[quotaKey]*exhaustion{},
+	}
+}
+
+// isExhausted returns true when all of the resource is used.  A hard limit of zero forbids the resource, the quota is
+// not exhausted by anything.
+func isExhausted(usage quotaUsage, resource corev1.ResourceName) bool {
+	hard := usage.hard[resource]
+	if hard.IsZero() {
+		return false
+	}
+	used, ok := usage.used[resource]
+	return ok && used.Cmp(hard) >= 0
+}
+
+// contributors lists the namespaces using the most of the resource, the most first.
+func contributors(usage quotaUsage, resource corev1.ResourceName) []string {
+	namespaces := []string{}
+	for namespace, used := range usage.byNamespace {
+		if quantity, ok := used[resource]; ok && !quantity.IsZero() {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	sort.Slice(namespaces, func(i, j int) bool {
+		lhs, rhs := usage.byNamespace[namespaces[i]][resource], usage.byNamespace[namespaces[j]][resource]
+		if c := lhs.Cmp(rhs); c != 0 {
+			return c > 0
+		}
+		return namespaces[i] < namespaces[j]
+	})
+	ret := []string{}
+	for i, namespace := range namespaces {
+		if i == maxContributors {
+			ret = append(ret, fmt.Sprintf("and %d more", len(namespaces)-maxContributors))
+			break
+		}
+		used := usage.byNamespace[namespace][resource]
+		ret = append(ret, fmt.Sprintf("ns/%s (%s)", namespace, used.String()))
+	}
+	return ret
+}
+
+func (t *quotaTracker) observe(quota schema.GroupVersionResource, namespace, name string, usage quotaUsage, now time.Time) {
+	for resource := range usage.hard {
+		key := quotaKey{quota: quota, namespace: namespace, name: name, resource: resource}
+		_, open := t.exhausted[key]
+		switch exhausted := isExhausted(usage, resource); {
+		case exhausted && !open:
+			used, hard := usage.used[resource], usage.hard[resource]
+			message := fmt.Sprintf("%s used %s of %s", resource, used.String(), hard.String())
+			if namespaces := contributors(usage, resource); len(namespaces) > 0 {
+				message += " by " + strings.Join(namespaces, ", ")
+			}
+			t.exhausted[key] = &exhaustion{since: now, message: message}
+		case !exhausted && open:
+			t.close(key, now)
+		}
+	}
+	// resources no longer limited
+	for key := range t.exhausted {
+		if key.quota == quota && key.namespace == namespace && key.name == name {
+			if _, ok := usage.hard[key.resource]; !ok {
+				t.close(key, now)
+			}
+		}
+	}
+}
+
+func (t *quotaTracker) remove(quota schema.GroupVersionResource, namespace, name string, now time.Time) {
+	for key := range t.exhausted {
+		if key.quota == quota && key.namespace == namespace && key.name == name {
+			t.close(key, now)
+		}
+	}
+}
+
+func (t *quotaTracker) close(key quotaKey, now time.Time) {
+	open := t.exhausted[key]
+	delete(t.exhausted, key)
+	t.intervals = append(t.intervals, monitorapi.NewInterval(monitorapi.SourceResourceQuota, monitorapi.Warning).
+		Locator(monitorapi.NewLocator().ForGVR(key.quota, key.namespace, key.name)).
+		Message(monitorapi.NewMessage().Reason(monitorapi.QuotaExhaustedReason).
+			Cause(string(key.resource)).
+			HumanMessage(open.message)).
+		Display().
+		Build(open.since, now))
+}
+
+// closeAll closes the quotas still exhausted at the end of the run and returns every window.
+func (t *quotaTracker) closeAll(end time.Time) monitorapi.Intervals {
+	for key := range t.exhausted {
+		t.close(key, end)
+	}
+	sort.Sort(t.intervals)
+	return t.intervals
+}
@@ -0,0 +1,74 @@
+package quotaexhaustion
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+func TestQuotaExhaustion(t *testing.T) {
+	start := time.Date(2024, 4, 12, 11, 0, 0, 0, time.UTC)
+	pods := func(count string) corev1.ResourceList {
+		return corev1.ResourceList{corev1.ResourcePods: resource.MustParse(count)}
+	}
+
+	tracker := newQuotaTracker()
+	tracker.observe(clusterResourceQuotasResource, "", "shared", quotaUsage{hard: pods("10"), used: pods("4")}, start)
+	tracker.observe(clusterResourceQuotasResource, "", "shared", quotaUsage{
+		hard: pods("10"),
+		used: pods("10"),
+		byNamespace: map[string]corev1.ResourceList{
+			"e2e-test-greedy": pods("8"),
+			"e2e-test-victim": pods("2"),
+		},
+	}, start.Add(5*time.Minute))
+	tracker.observe(clusterResourceQuotasResource, "", "shared", quotaUsage{hard: pods("10"), used: pods("3")}, start.Add(8*time.Minute))
+	// a hard limit of zero forbids the resource, it is not exhausted
+	tracker.observe(resourceQuotasResource, "e2e-test-forbidden", "none", quotaUsage{hard: pods("0"), used: pods("0")}, start)
+	tracker.observe(resourceQuotasResource, "e2e-test-full", "compute", quotaUsage{hard: pods("1"), used: pods("1")}, start.Add(20*time.Minute))
+
+	intervals := tracker.closeAll(start.Add(30 * time.Minute))
+	require.Len(t, intervals, 2)
+	shared := intervals[0]
+	assert.Equal(t, monitorapi.QuotaExhaustedReason, shared.Message.Reason)
+	assert.Equal(t, "pods", shared.Message.Cause)
+	assert.Equal(t, "pods used 10 of 10 by ns/e2e-test-greedy (8), ns/e2e-test-victim (2)", shared.Message.HumanMessage)
+	assert.Equal(t, start.Add(5*time.Minute), shared.From)
+	assert.Equal(t, start.Add(8*time.Minute), shared.To)
+	// still exhausted at the end of the run
+	assert.Equal(t, "e2e-test-full", intervals[1].Locator.Keys[monitorapi.LocatorNamespaceKey])
+	assert.Equal(t, start.Add(30*time.Minute), intervals[1].To)
+
+	test := func(name string, from, to time.Time) monitorapi.Interval {
+		return monitorapi.NewInterval(monitorapi.SourceE2ETest, monitorapi.Info).
+			Locator(monitorapi.NewLocator().E2ETest(name)).
+			Message(monitorapi.NewMessage().WithAnnotation(monitorapi.AnnotationStatus, "Passed")).
+			Build(from, to)
+	}
+	denied := func(namespace, quota string, at time.Time) monitorapi.Interval {
+		return monitorapi.NewInterval(monitorapi.SourceKubeEvent, monitorapi.Warning).
+			Locator(monitorapi.NewLocator().PodFromNames(namespace, "client", "")).
+			Message(monitorapi.NewMessage().Reason("FailedCreate").
+				HumanMessagef(`Error creating: pods "client" is forbidden: exceeded quota: %s, requested: pods=1, used: pods=10, limited: pods=10`, quota)).
+			Build(at, at)
+	}
+	all := append(intervals,
+		test("greedy creates many pods", start.Add(4*time.Minute), start.Add(6*time.Minute)),
+		test("victim creates one pod", start.Add(6*time.Minute), start.Add(9*time.Minute)),
+		denied("e2e-test-victim", "shared", start.Add(7*time.Minute)),
+		// another quota of the same name in another namespace
+		denied("e2e-test-victim", "compute", start.Add(21*time.Minute)),
+	)
+	attributions := attribute(all)
+	require.Len(t, attributions, 2)
+	assert.Equal(t, []string{"greedy creates many pods"}, attributions[0].RunningTests)
+	require.Len(t, attributions[0].Victims, 1)
+	assert.Equal(t, "e2e-test-victim", attributions[0].Victims[0].Namespace)
+	assert.Empty(t, attributions[1].Victims)
+}
@@ -0,0 +1,275 @@
+package disruptionsecondarynetwork
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	nadv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	nadclient "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/clientset/versioned"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/informers"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	k8simage "k8s.io/kubernetes/test/utils/image"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/monitortestlibrary/podaccess"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	exutil "github.com/openshift/origin/test/extended/util"
+	"github.com/openshift/origin/test/extended/util/image"
+)
+
+// secondaryNetworkAvailability probes every NetworkAttachmentDefinition of the cluster with a target and a prober
+// attached to it, so that secondary network breakage, during upgrades in particular, shows up as disruption.
+type secondaryNetworkAvailability struct {
+	notSupportedReason error
+	kubeClient         kubernetes.Interface
+
+	// created are the networks probes were created for, to clean up.  probed are the networks whose probes were all
+	// created, unprobed those that could not be probed.
+	created  []*nadv1.NetworkAttachmentDefinition
+	probed   []*nadv1.NetworkAttachmentDefinition
+	unprobed []string
+
+	tracker            *sampleTracker
+	stopCollection     context.CancelFunc
+	finishedCollecting []chan struct{}
+
+	addressLock sync.Mutex
+	addresses   map[string]string
+}
+
+func NewSecondaryNetworkAvailability() monitortestframework.MonitorTest {
+	return &secondaryNetworkAvailability{
+		tracker:   newSampleTracker(),
+		addresses: map[string]string{},
+	}
+}
+
+func (*secondaryNetworkAvailability) Describe() monitortestframework.MonitorTestDescription {
+	return monitortestframework.MonitorTestDescription{
+		WatchedResources: []string{"network-attachment-definitions.k8s.cni.cncf.io", "pods"},
+		JUnits:           []string{secondaryNetworksTestName},
+	}
+}
+
+func (w *secondaryNetworkAvailability) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	var err error
+	w.kubeClient, err = kubernetes.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return err
+	}
+	isMicroShift, err := exutil.IsMicroShiftCluster(w.kubeClient)
+	if err != nil {
+		return fmt.Errorf("unable to determine if cluster is MicroShift: %v", err)
+	}
+	if isMicroShift {
+		w.notSupportedReason = &monitortestframework.NotSupportedError{Reason: "platform MicroShift not supported"}
+		return w.notSupportedReason
+	}
+
+	nadClient, err := nadclient.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return err
+	}
+	nads, err := nadClient.K8sCniCncfIoV1().NetworkAttachmentDefinitions("").List(ctx, metav1.ListOptions{})
+	if apierrors.IsNotFound(err) {
+		w.notSupportedReason = &monitortestframework.NotSupportedError{Reason: "NetworkAttachmentDefinitions are not served"}
+		return w.notSupportedReason
+	}
+	if err != nil {
+		return err
+	}
+	if len(nads.Items) == 0 {
+		w.notSupportedReason = &monitortestframework.NotSupportedError{Reason: "no NetworkAttachmentDefinitions are defined"}
+		return w.notSupportedReason
+	}
+
+	// force the image to use the "normal" global mapping.
+	originalAgnhost := k8simage.GetOriginalImageConfigs()[k8simage.Agnhost]
+	targetImage := image.LocationFor(originalAgnhost.GetE2EImage())
+	proberImage := image.LimitedShellImage()
+
+	// a network that cannot be probed, for lack of room in a quota for instance, is reported rather than failing
+	// the other networks.
+	for i := range nads.Items {
+		nad := &nads.Items[i]
+		w.created = append(w.created, nad)
+		if err := w.createProbes(ctx, nad, targetImage, proberImage); err != nil {
+			logrus.WithError(err).Warnf("unable to probe secondary network %s/%s", nad.Namespace, nad.Name)
+			w.unprobed = append(w.unprobed, fmt.Sprintf("%s/%s", nad.Namespace, nad.Name))
+			continue
+		}
+		w.probed = append(w.probed, nad)
+	}
+
+	probeSelector := labels.NewSelector()
+	proberRequirement, err := labels.NewRequirement(roleLabel, selection.Equals, []string{roleProber})
+	if err != nil {
+		return err
+	}
+	probeRequirement, err := labels.NewRequirement(roleLabel, selection.Exists, nil)
+	if err != nil {
+		return err
+	}
+	probeSelector = probeSelector.Add(*probeRequirement)
+
+	ctx, w.stopCollection = context.WithCancel(context.Background())
+	kubeInformers := informers.NewSharedInformerFactoryWithOptions(w.kubeClient, 0, informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+		options.LabelSelector = probeSelector.String()
+	}))
+	for _, namespace := range namespacesOf(w.probed) {
+		podInformer := coreinformers.New(kubeInformers, namespace, nil).Pods()
+		if _, err := podInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(obj interface{}) { w.syncTargetAddress(ctx, obj, false) },
+			UpdateFunc: func(_, obj interface{}) { w.syncTargetAddress(ctx, obj, false) },
+			DeleteFunc: func(obj interface{}) { w.syncTargetAddress(ctx, obj, true) },
+		}); err != nil {
+			return err
+		}
+
+		finished := make(chan struct{})
+		w.finishedCollecting = append(w.finishedCollecting, finished)
+		podStreamer := podaccess.NewPodsStreamer(
+			w.kubeClient,
+			labels.NewSelector().Add(*proberRequirement),
+			namespace,
+			proberContainerName,
+			w.tracker,
+			podInformer,
+		)
+		go podStreamer.Run(ctx, finished)
+	}
+	go kubeInformers.Start(ctx.Done())
+
+	return nil
+}
+
+func (w *secondaryNetworkAvailability) createProbes(ctx context.Context, nad *nadv1.NetworkAttachmentDefinition, targetImage, proberImage string) error {
+	if _, err := w.kubeClient.CoreV1().ConfigMaps(nad.Namespace).Create(ctx, targetConfigMap(nad, ""), metav1.CreateOptions{}); err != nil {
+		return err
+	}
+	if _, err := w.kubeClient.AppsV1().Deployments(nad.Namespace).Create(ctx, targetDeployment(nad, targetImage), metav1.CreateOptions{}); err != nil {
+		return err
+	}
+	if _, err := w.kubeClient.AppsV1().Deployments(nad.Namespace).Create(ctx, proberDeployment(nad, proberImage), metav1.CreateOptions{}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func namespacesOf(nads []*nadv1.NetworkAttachmentDefinition) []string {
+	namespaces := map[string]bool{}
+	for _, nad := range nads {
+		namespaces[nad.Namespace] = true
+	}
+	ret := []string{}
+	for namespace := range namespaces {
+		ret = append(ret, namespace)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// syncTargetAddress points the prober at the address of the target whenever the target pod changes, the prober
+// reports no samples while the target has no address.
+func (w *secondaryNetworkAvailability) syncTargetAddress(ctx context.Context, obj interface{}, deleted bool) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	pod, ok := obj.(*corev1.Pod)
+	if !ok || pod.Labels[roleLabel] != roleTarget {
+		return
+	}
+	address := ""
+	if !deleted {
+		address = targetAddress(pod)
+	}
+
+	network := fmt.Sprintf("%s/%s", pod.Namespace, pod.Labels[networkLabel])
+	w.addressLock.Lock()
+	defer w.addressLock.Unlock()
+	if current, ok := w.addresses[network]; ok && current == address {
+		return
+	}
+
+	nad := &nadv1.NetworkAttachmentDefinition{ObjectMeta: metav1.ObjectMeta{Namespace: pod.Namespace, Name: pod.Labels[networkLabel]}}
+	if _, err := w.kubeClient.CoreV1().ConfigMaps(pod.Namespace).Update(ctx, targetConfigMap(nad, address), metav1.UpdateOptions{}); err != nil {
+		logrus.WithError(err).Warnf("unable to point the prober of secondary network %s at %q", network, address)
+		return
+	}
+	w.addresses[network] = address
+}
+
+func (w *secondaryNetworkAvailability) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	if w.notSupportedReason != nil {
+		return nil, nil, w.notSupportedReason
+	}
+	if w.stopCollection == nil {
+		return nil, nil, nil
+	}
+	w.stopCollection()
+	for _, finished := range w.finishedCollecting {
+		<-finished
+	}
+	return w.tracker.intervals(), nil, nil
+}
+
+func (w *secondaryNetworkAvailability) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	return nil, w.notSupportedReason
+}
+
+func (w *secondaryNetworkAvailability) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	if w.notSupportedReason != nil {
+		return nil, w.notSupportedReason
+	}
+
+	// networks whose target never got an address, without IPAM for instance, have no samples.
+	probed := w.tracker.networks()
+	unprobed := append([]string{}, w.unprobed...)
+	sampled := map[string]bool{}
+	for _, network := range probed {
+		sampled[network] = true
+	}
+	for _, nad := range w.probed {
+		if network := fmt.Sprintf("%s/%s", nad.Namespace, nad.Name); !sampled[network] {
+			unprobed = append(unprobed, network)
+		}
+	}
+	sort.Strings(unprobed)
+
+	return secondaryNetworksJunit(finalIntervals, probed, unprobed), nil
+}
+
+func (w *secondaryNetworkAvailability) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	return w.notSupportedReason
+}
+
+func (w *secondaryNetworkAvailability) Cleanup(ctx context.Context) error {
+	errs := []error{}
+	for _, nad := range w.created {
+		for _, role := range []string{roleProber, roleTarget} {
+			err := w.kubeClient.AppsV1().Deployments(nad.Namespace).Delete(ctx, probeName(nad, role), metav1.DeleteOptions{})
+			if err != nil && !apierrors.IsNotFound(err) {
+				errs = append(errs, err)
+			}
+		}
+		err := w.kubeClient.CoreV1().ConfigMaps(nad.Namespace).Delete(ctx, probeName(nad, roleTarget), metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
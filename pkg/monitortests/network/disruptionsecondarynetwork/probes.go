@@ -0,0 +1,152 @@
+package disruptionsecondarynetwork
+
+import (
+	"encoding/json"
+	"fmt"
+
+	nadv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// roleLabel tells the target and the prober of a network apart, networkLabel names the network they are attached to.
+	roleLabel    = "monitor.openshift.io/secondary-network-probe"
+	networkLabel = "monitor.openshift.io/secondary-network"
+	roleTarget   = "target"
+	roleProber   = "prober"
+
+	proberContainerName = "prober"
+	targetPort          = 8080
+
+	// targetConfigMapKey holds the address of the target on the network, kept current by the monitor as the target
+	// moves between nodes.  The prober reads it before every probe.
+	targetConfigMapKey = "address"
+)
+
+// probeScript connects to the target once a second and logs the outcome, the kubelet timestamps each line.
+var probeScript = fmt.Sprintf(`while true; do
+  address="$(cat /etc/secondary-network-target/%[1]s 2>/dev/null)"
+  if [ -z "${address}" ]; then
+    echo %[2]s
+  elif timeout 2 bash -c "echo > /dev/tcp/${address}/%[3]d" 2>/dev/null; then
+    echo %[4]s
+  else
+    echo %[5]s
+  fi
+  sleep 1
+done
+`, targetConfigMapKey, sampleNoTarget, targetPort, sampleReachable, sampleUnreachable)
+
+// probeName is the name of the probe objects of a network, distinct from whatever else lives in its namespace.
+func probeName(nad *nadv1.NetworkAttachmentDefinition, role string) string {
+	return fmt.Sprintf("e2e-secondary-network-%s-%s", role, nad.Name)
+}
+
+func probeLabels(nad *nadv1.NetworkAttachmentDefinition, role string) map[string]string {
+	return map[string]string{roleLabel: role, networkLabel: nad.Name}
+}
+
+// restrictedSecurityContext passes the restricted pod security level, the namespaces of the networks are not ours.
+func restrictedSecurityContext() *corev1.SecurityContext {
+	return &corev1.SecurityContext{
+		AllowPrivilegeEscalation: ptr.To(false),
+		RunAsNonRoot:             ptr.To(true),
+		Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+		SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}
+}
+
+func probeDeployment(nad *nadv1.NetworkAttachmentDefinition, role string, container corev1.Container, volumes []corev1.Volume) *appsv1.Deployment {
+	container.SecurityContext = restrictedSecurityContext()
+	container.ImagePullPolicy = corev1.PullIfNotPresent
+	container.TerminationMessagePolicy = corev1.TerminationMessageFallbackToLogsOnError
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      probeName(nad, role),
+			Namespace: nad.Namespace,
+			Labels:    probeLabels(nad, role),
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](1),
+			Selector: &metav1.LabelSelector{MatchLabels: probeLabels(nad, role)},
+			Strategy: appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      probeLabels(nad, role),
+					Annotations: map[string]string{nadv1.NetworkAttachmentAnnot: nad.Name},
+				},
+				Spec: corev1.PodSpec{
+					Containers:                    []corev1.Container{container},
+					Volumes:                       volumes,
+					TerminationGracePeriodSeconds: ptr.To[int64](5),
+				},
+			},
+		},
+	}
+}
+
+// targetDeployment serves connections on the secondary network.
+func targetDeployment(nad *nadv1.NetworkAttachmentDefinition, image string) *appsv1.Deployment {
+	return probeDeployment(nad, roleTarget, corev1.Container{
+		Name:  "target",
+		Image: image,
+		Args:  []string{"netexec", fmt.Sprintf("--http-port=%d", targetPort)},
+		Ports: []corev1.ContainerPort{{ContainerPort: targetPort}},
+	}, nil)
+}
+
+// proberDeployment connects to the address of the target on the secondary network.
+func proberDeployment(nad *nadv1.NetworkAttachmentDefinition, image string) *appsv1.Deployment {
+	return probeDeployment(nad, roleProber, corev1.Container{
+		Name:         proberContainerName,
+		Image:        image,
+		Command:      []string{"/bin/bash", "-c", probeScript},
+		VolumeMounts: []corev1.VolumeMount{{Name: "target", MountPath: "/etc/secondary-network-target"}},
+	}, []corev1.Volume{{
+		Name: "target",
+		VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: probeName(nad, roleTarget)},
+		}},
+	}})
+}
+
+func targetConfigMap(nad *nadv1.NetworkAttachmentDefinition, address string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      probeName(nad, roleTarget),
+			Namespace: nad.Namespace,
+			Labels:    probeLabels(nad, roleTarget),
+		},
+		Data: map[string]string{targetConfigMapKey: address},
+	}
+}
+
+// targetAddress returns the address of the ready target pod on its secondary network, or empty if it has none.
+func targetAddress(pod *corev1.Pod) string {
+	if pod.DeletionTimestamp != nil || !isPodReady(pod) {
+		return ""
+	}
+	network := fmt.Sprintf("%s/%s", pod.Namespace, pod.Labels[networkLabel])
+	statuses := []nadv1.NetworkStatus{}
+	if err := json.Unmarshal([]byte(pod.Annotations[nadv1.NetworkStatusAnnot]), &statuses); err != nil {
+		return ""
+	}
+	for _, status := range statuses {
+		if status.Name == network && len(status.IPs) > 0 {
+			return status.IPs[0]
+		}
+	}
+	return ""
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,157 @@
+package disruptionsecondarynetwork
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestlibrary/podaccess"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+)
+
+const (
+	secondaryNetworksTestName = "[sig-network][Feature:Multus] secondary networks should remain reachable"
+
+	// the prober logs one of these per second.
+	sampleReachable   = "ok"
+	sampleUnreachable = "fail"
+	// sampleNoTarget is logged while the target has no address on the network, it is neither up nor down.
+	sampleNoTarget = "skip"
+)
+
+type sample struct {
+	at        time.Time
+	reachable bool
+}
+
+// sampleTracker collects the probe results of every secondary network, keyed by "namespace/name" of the
+// NetworkAttachmentDefinition.  Log lines arrive concurrently from the streamers of each namespace.
+type sampleTracker struct {
+	lock    sync.Mutex
+	samples map[string][]sample
+}
+
+func newSampleTracker() *sampleTracker {
+	return &sampleTracker{samples: map[string][]sample{}}
+}
+
+func (t *sampleTracker) HandleLogLine(logLine podaccess.LogLineContent) {
+	if logLine.Pod == nil {
+		return
+	}
+	network := fmt.Sprintf("%s/%s", logLine.Pod.Namespace, logLine.Pod.Labels[networkLabel])
+	t.record(network, logLine.Instant, strings.TrimSpace(logLine.Line))
+}
+
+func (t *sampleTracker) record(network string, at time.Time, line string) {
+	var reachable bool
+	switch line {
+	case sampleReachable:
+		reachable = true
+	case sampleUnreachable:
+		reachable = false
+	default:
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.samples[network] = append(t.samples[network], sample{at: at, reachable: reachable})
+}
+
+// networks returns the networks with samples, sorted.
+func (t *sampleTracker) networks() []string {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	ret := []string{}
+	for network := range t.samples {
+		ret = append(ret, network)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// backendName is how the disruption of the network is stored and compared with history.
+func backendName(network string) string {
+	return "secondary-network-" + strings.ReplaceAll(network, "/", "-")
+}
+
+// intervals turns the samples of every network into alternating available and disrupted intervals.  A gap in the
+// samples, the prober being rescheduled, ends the current interval without counting as disruption.
+func (t *sampleTracker) intervals() monitorapi.Intervals {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	ret := monitorapi.Intervals{}
+	for network, samples := range t.samples {
+		sorted := append([]sample{}, samples...)
+		sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].at.Before(sorted[j].at) })
+
+		locator := monitorapi.NewLocator().LocateDisruptionCheck(backendName(network), fmt.Sprintf("secondary-network-prober to %s", network), monitorapi.NewConnectionType)
+		for start := 0; start < len(sorted); {
+			end := start + 1
+			for end < len(sorted) && sorted[end].reachable == sorted[start].reachable && sorted[end].at.Sub(sorted[end-1].at) <= maxSampleGap {
+				end++
+			}
+			to := sorted[end-1].at.Add(time.Second)
+			if end < len(sorted) && sorted[end].at.Sub(sorted[end-1].at) <= maxSampleGap {
+				to = sorted[end].at
+			}
+			ret = append(ret, networkInterval(locator, network, sorted[start].reachable, sorted[start].at, to))
+			start = end
+		}
+	}
+	sort.Sort(ret)
+	return ret
+}
+
+// maxSampleGap is how long the prober may be silent before its samples are no longer considered continuous.
+const maxSampleGap = 10 * time.Second
+
+func networkInterval(locator monitorapi.Locator, network string, reachable bool, from, to time.Time) monitorapi.Interval {
+	if reachable {
+		return monitorapi.NewInterval(monitorapi.SourceDisruption, monitorapi.Info).
+			Locator(locator).
+			Message(monitorapi.NewMessage().Reason(monitorapi.DisruptionEndedEventReason).
+				HumanMessagef("secondary network %s started accepting connections", network)).
+			Display().
+			Build(from, to)
+	}
+	return monitorapi.NewInterval(monitorapi.SourceDisruption, monitorapi.Error).
+		Locator(locator).
+		Message(monitorapi.NewMessage().Reason(monitorapi.DisruptionBeganEventReason).
+			HumanMessagef("secondary network %s stopped accepting connections", network)).
+		Display().
+		Build(from, to)
+}
+
+// secondaryNetworksJunit lists the disruption of every probed network, and the networks that could not be probed.
+func secondaryNetworksJunit(intervals monitorapi.Intervals, probed, unprobed []string) []*junitapi.JUnitTestCase {
+	var disrupted []string
+	for _, network := range probed {
+		duration, messages := monitorapi.BackendDisruptionSeconds(backendName(network), intervals)
+		if duration > 0 {
+			disrupted = append(disrupted, fmt.Sprintf("%s was unreachable for %s\n  %s", network, duration, strings.Join(messages, "\n  ")))
+		}
+	}
+
+	systemOut := fmt.Sprintf("probed secondary networks: %v", probed)
+	if len(unprobed) > 0 {
+		systemOut += fmt.Sprintf("\nsecondary networks without an address to probe: %v", unprobed)
+	}
+	if len(disrupted) == 0 {
+		return []*junitapi.JUnitTestCase{{Name: secondaryNetworksTestName, SystemOut: systemOut}}
+	}
+
+	failure := &junitapi.JUnitTestCase{
+		Name:      secondaryNetworksTestName,
+		SystemOut: systemOut,
+		FailureOutput: &junitapi.FailureOutput{
+			Output: fmt.Sprintf("%d secondary networks were disrupted.\n\n%s", len(disrupted), strings.Join(disrupted, "\n")),
+		},
+	}
+	// TODO: marked flaky until we have historical data to set disruption budgets from
+	return []*junitapi.JUnitTestCase{failure, {Name: secondaryNetworksTestName}}
+}
@@ -0,0 +1,68 @@
+package disruptionsecondarynetwork
+
+import (
+	"testing"
+	"time"
+
+	nadv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSampleTrackerIntervals(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	tracker := newSampleTracker()
+	for i, line := range []string{"ok", "ok", "fail", "fail", "skip", "fail", "ok"} {
+		tracker.record("ns/macvlan", start.Add(time.Duration(i)*time.Second), line)
+	}
+	// the prober was rescheduled, the gap is not disruption.
+	tracker.record("ns/macvlan", start.Add(time.Minute), "ok")
+
+	intervals := tracker.intervals()
+	require.Len(t, intervals, 4)
+	assert.Equal(t, monitorapi.DisruptionEndedEventReason, intervals[0].Message.Reason)
+	assert.Equal(t, start, intervals[0].From)
+	assert.Equal(t, start.Add(2*time.Second), intervals[0].To)
+
+	assert.Equal(t, monitorapi.DisruptionBeganEventReason, intervals[1].Message.Reason)
+	assert.Equal(t, monitorapi.Error, intervals[1].Level)
+	assert.Equal(t, start.Add(2*time.Second), intervals[1].From)
+	assert.Equal(t, start.Add(6*time.Second), intervals[1].To)
+	assert.Equal(t, "secondary-network-ns-macvlan", monitorapi.BackendDisruptionNameFromLocator(intervals[1].Locator))
+
+	assert.Equal(t, start.Add(6*time.Second), intervals[2].From)
+	assert.Equal(t, start.Add(7*time.Second), intervals[2].To)
+	assert.Equal(t, start.Add(time.Minute), intervals[3].From)
+
+	junits := secondaryNetworksJunit(intervals, tracker.networks(), []string{"other/no-ipam"})
+	require.Len(t, junits, 2, "disruption is a flake")
+	assert.Contains(t, junits[0].FailureOutput.Output, "ns/macvlan was unreachable for 4s")
+	assert.Contains(t, junits[0].SystemOut, "other/no-ipam")
+
+	assert.Len(t, secondaryNetworksJunit(monitorapi.Intervals{intervals[0]}, tracker.networks(), nil), 1)
+}
+
+func TestTargetAddress(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Labels:    map[string]string{networkLabel: "macvlan"},
+			Annotations: map[string]string{nadv1.NetworkStatusAnnot: `[
+				{"name": "ovn-kubernetes", "ips": ["10.128.0.5"], "default": true},
+				{"name": "ns/macvlan", "interface": "net1", "ips": ["192.168.1.5"]}
+			]`},
+		},
+		Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}},
+	}
+	assert.Equal(t, "192.168.1.5", targetAddress(pod))
+
+	pod.Labels[networkLabel] = "bridge"
+	assert.Empty(t, targetAddress(pod), "not attached to the network")
+
+	pod.Labels[networkLabel] = "macvlan"
+	pod.Status.Conditions[0].Status = corev1.ConditionFalse
+	assert.Empty(t, targetAddress(pod), "not ready")
+}
@@ -15,6 +15,9 @@ import (
 	"github.com/openshift/origin/pkg/monitortestframework"
 	"github.com/sirupsen/logrus"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/dynamic"
 
 	configv1 "github.com/openshift/api/config/v1"
 	configclient "github.com/openshift/client-go/config/clientset/versioned"
@@ -48,6 +51,25 @@ var (
 const (
 	newConnectionTestName    = "[sig-network-edge] disruption/service-load-balancer-with-pdb connection/new should be available throughout the test"
 	reusedConnectionTestName = "[sig-network-edge] disruption/service-load-balancer-with-pdb connection/reused should be available throughout the test"
+
+	metalLBNewConnectionTestName    = "[sig-network-edge] disruption/service-load-balancer-metallb connection/new should be available throughout the test"
+	metalLBReusedConnectionTestName = "[sig-network-edge] disruption/service-load-balancer-metallb connection/reused should be available throughout the test"
+)
+
+// metalLBAddressPoolsResource are the addresses MetalLB hands out to LoadBalancer services, a cluster without any
+// cannot expose one.
+var metalLBAddressPoolsResource = schema.GroupVersionResource{Group: "metallb.io", Version: "v1beta1", Resource: "ipaddresspools"}
+
+// onPremPlatforms do not program a cloud load balancer for services of type LoadBalancer.
+var onPremPlatforms = sets.New(
+	configv1.OvirtPlatformType,
+	configv1.KubevirtPlatformType,
+	configv1.LibvirtPlatformType,
+	configv1.NutanixPlatformType,
+	configv1.VSpherePlatformType,
+	configv1.BareMetalPlatformType,
+	configv1.OpenStackPlatformType,
+	configv1.NonePlatformType,
 )
 
 func init() {
@@ -61,6 +83,9 @@ type availability struct {
 
 	disruptionChecker *disruptionlibrary.Availability
 	suppressJunit     bool
+	// metalLB measures a service exposed by MetalLB on on-prem platforms instead of by the cloud.  When the node
+	// announcing the address goes away, the disruption is the time MetalLB takes to fail the address over.
+	metalLB bool
 }
 
 func NewAvailabilityInvariant() monitortestframework.MonitorTest {
@@ -73,6 +98,29 @@ func NewRecordAvailabilityOnly() monitortestframework.MonitorTest {
 	}
 }
 
+// NewMetalLBAvailabilityInvariant measures a LoadBalancer service on on-prem platforms using MetalLB.
+func NewMetalLBAvailabilityInvariant() monitortestframework.MonitorTest {
+	return &availability{
+		metalLB: true,
+	}
+}
+
+// backendName is the disruption backend of the connection type, distinct for MetalLB so that its history is not
+// mixed with the cloud load balancers.
+func (w *availability) backendName(connectionType string) string {
+	if w.metalLB {
+		return "service-load-balancer-metallb-" + connectionType
+	}
+	return "service-load-balancer-with-pdb-" + connectionType
+}
+
+func (w *availability) testNames() (string, string) {
+	if w.metalLB {
+		return metalLBNewConnectionTestName, metalLBReusedConnectionTestName
+	}
+	return newConnectionTestName, reusedConnectionTestName
+}
+
 func (w *availability) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
 	var err error
 
@@ -100,18 +148,21 @@ func (w *availability) StartCollection(ctx context.Context, adminRESTConfig *res
 	}
 	// ovirt does not support service type loadbalancer because it doesn't program a cloud.
 	// none platform does not have CCM which exposes the service of type loadbalancer.
-	if infra.Status.PlatformStatus.Type == configv1.OvirtPlatformType ||
-		infra.Status.PlatformStatus.Type == configv1.KubevirtPlatformType ||
-		infra.Status.PlatformStatus.Type == configv1.LibvirtPlatformType ||
-		infra.Status.PlatformStatus.Type == configv1.NutanixPlatformType ||
-		infra.Status.PlatformStatus.Type == configv1.VSpherePlatformType ||
-		infra.Status.PlatformStatus.Type == configv1.BareMetalPlatformType ||
-		infra.Status.PlatformStatus.Type == configv1.OpenStackPlatformType ||
-		infra.Status.PlatformStatus.Type == configv1.NonePlatformType {
+	// MetalLB is how those platforms expose one, and cloud platforms do not need it.
+	if onPremPlatforms.Has(infra.Status.PlatformStatus.Type) != w.metalLB {
 		w.notSupportedReason = &monitortestframework.NotSupportedError{
 			Reason: fmt.Sprintf("platform %q is not supported", infra.Status.PlatformStatus.Type),
 		}
 	}
+	if w.notSupportedReason == nil && w.metalLB {
+		reason, err := metalLBNotSupportedReason(ctx, adminRESTConfig)
+		if err != nil {
+			return err
+		}
+		if len(reason) > 0 {
+			w.notSupportedReason = &monitortestframework.NotSupportedError{Reason: reason}
+		}
+	}
 	// single node clusters are not supported because the replication controller has 2 replicas with anti-affinity for running on the same node.
 	if infra.Status.ControlPlaneTopology == configv1.SingleReplicaTopologyMode {
 		w.notSupportedReason = &monitortestframework.NotSupportedError{
@@ -227,19 +278,20 @@ func (w *availability) StartCollection(ctx context.Context, adminRESTConfig *res
 
 	newConnectionDisruptionSampler := backenddisruption.NewSimpleBackendFromOpenshiftTests(
 		baseURL,
-		"service-load-balancer-with-pdb-new-connections",
+		w.backendName("new-connections"),
 		path,
 		monitorapi.NewConnectionType).
 		WithExpectedBody("hello")
 	reusedConnectionDisruptionSampler := backenddisruption.NewSimpleBackendFromOpenshiftTests(
 		baseURL,
-		"service-load-balancer-with-pdb-reused-connections",
+		w.backendName("reused-connections"),
 		path,
 		monitorapi.ReusedConnectionType).
 		WithExpectedBody("hello")
 
+	newTestName, reusedTestName := w.testNames()
 	w.disruptionChecker = disruptionlibrary.NewAvailabilityInvariant(
-		newConnectionTestName, reusedConnectionTestName,
+		newTestName, reusedTestName,
 		newConnectionDisruptionSampler, reusedConnectionDisruptionSampler,
 	)
 	if err := w.disruptionChecker.StartCollection(ctx, adminRESTConfig, recorder); err != nil {
@@ -249,6 +301,25 @@ func (w *availability) StartCollection(ctx context.Context, adminRESTConfig *res
 	return nil
 }
 
+// metalLBNotSupportedReason returns why MetalLB cannot expose the service, empty if it has addresses to hand out.
+func metalLBNotSupportedReason(ctx context.Context, adminRESTConfig *rest.Config) (string, error) {
+	dynamicClient, err := dynamic.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return "", err
+	}
+	pools, err := dynamicClient.Resource(metalLBAddressPoolsResource).List(ctx, metav1.ListOptions{})
+	if apierrors.IsNotFound(err) {
+		return "MetalLB is not installed", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if len(pools.Items) == 0 {
+		return "MetalLB has no IPAddressPools", nil
+	}
+	return "", nil
+}
+
 func (w *availability) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
 	if w.notSupportedReason != nil {
 		return nil, nil, w.notSupportedReason
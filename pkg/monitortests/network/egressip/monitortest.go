@@ -0,0 +1,308 @@
+package egressip
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/informers"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/monitortestlibrary/podaccess"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	exutil "github.com/openshift/origin/test/extended/util"
+	"github.com/openshift/origin/test/extended/util/image"
+)
+
+const (
+	assignmentTestName = "[sig-network][Feature:EgressIP] egress IPs should remain assigned to a node"
+	sourceTestName     = "[sig-network][Feature:EgressIP] egress traffic should leave the cluster from its egress IP"
+
+	// handoverGrace is how long an egress IP address may be without a node, while ovn-kubernetes moves it off a
+	// node that is draining or went NotReady, before it counts.
+	handoverGrace = 30 * time.Second
+)
+
+// egressIPTracker follows the assignment of the EgressIPs of the cluster to nodes and the status of its
+// EgressFirewalls, so that egress IP flapping during node updates shows up on the timeline.  When EchoURLEnv is set,
+// it also sends traffic selected by an EgressIP outside of the cluster to check the source address it leaves with.
+type egressIPTracker struct {
+	notSupportedReason error
+	kubeClient         kubernetes.Interface
+	kubeInformers      informers.SharedInformerFactory
+
+	lock    sync.Mutex
+	tracker *egressTracker
+
+	// sourceNamespace holds the source probe, if one was created.
+	sourceNamespace string
+	source          *sourceTracker
+
+	stopCollection     context.CancelFunc
+	finishedCollecting chan struct{}
+}
+
+func NewEgressIPTracker() monitortestframework.MonitorTest {
+	return &egressIPTracker{
+		tracker: newEgressTracker(),
+	}
+}
+
+func (*egressIPTracker) Describe() monitortestframework.MonitorTestDescription {
+	return monitortestframework.MonitorTestDescription{
+		WatchedResources: []string{"egressips.k8s.ovn.org", "egressfirewalls.k8s.ovn.org", "pods"},
+		JUnits:           []string{assignmentTestName, sourceTestName},
+	}
+}
+
+func (w *egressIPTracker) SetSharedInformers(kubeInformers informers.SharedInformerFactory) {
+	w.kubeInformers = kubeInformers
+}
+
+func (w *egressIPTracker) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	var err error
+	w.kubeClient, err = kubernetes.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return err
+	}
+	isMicroShift, err := exutil.IsMicroShiftCluster(w.kubeClient)
+	if err != nil {
+		return fmt.Errorf("unable to determine if cluster is MicroShift: %v", err)
+	}
+	if isMicroShift {
+		w.notSupportedReason = &monitortestframework.NotSupportedError{Reason: "platform MicroShift not supported"}
+		return w.notSupportedReason
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return err
+	}
+	egressIPs, err := dynamicClient.Resource(egressIPsResource).List(ctx, metav1.ListOptions{})
+	// other network plugins do not serve EgressIPs.
+	if apierrors.IsNotFound(err) {
+		w.notSupportedReason = &monitortestframework.NotSupportedError{Reason: "EgressIPs are not served"}
+		return w.notSupportedReason
+	}
+	if err != nil {
+		return err
+	}
+	if len(egressIPs.Items) == 0 {
+		w.notSupportedReason = &monitortestframework.NotSupportedError{Reason: "no EgressIPs are configured"}
+		return w.notSupportedReason
+	}
+
+	ctx, w.stopCollection = context.WithCancel(context.Background())
+	w.watch(ctx, dynamicClient.Resource(egressIPsResource), w.observeEgressIP, w.removeEgressIP)
+	if _, err := dynamicClient.Resource(egressFirewallsResource).List(ctx, metav1.ListOptions{Limit: 1}); err == nil {
+		w.watch(ctx, dynamicClient.Resource(egressFirewallsResource), w.observeEgressFirewall, w.removeEgressFirewall)
+	} else if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	if echoURL := os.Getenv(EchoURLEnv); len(echoURL) > 0 {
+		// the source of the traffic is a signal on top of the assignments, the monitor works without it.
+		if err := w.startSourceProbe(ctx, egressIPs.Items, echoURL); err != nil {
+			logrus.WithError(err).Warn("unable to verify the source address of egress traffic")
+		}
+	}
+	return nil
+}
+
+// watch runs an informer on the resource until the context is done.
+func (w *egressIPTracker) watch(ctx context.Context, client dynamic.NamespaceableResourceInterface, observe, remove func(*unstructured.Unstructured, time.Time)) {
+	informer := cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				return client.List(ctx, options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return client.Watch(ctx, options)
+			},
+		},
+		&unstructured.Unstructured{},
+		time.Hour,
+		nil,
+	)
+	handle := func(obj interface{}, handler func(*unstructured.Unstructured, time.Time)) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		if u, ok := obj.(*unstructured.Unstructured); ok {
+			handler(u, time.Now())
+		}
+	}
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { handle(obj, observe) },
+		UpdateFunc: func(_, obj interface{}) { handle(obj, observe) },
+		DeleteFunc: func(obj interface{}) { handle(obj, remove) },
+	})
+	go informer.Run(ctx.Done())
+}
+
+func (w *egressIPTracker) observeEgressIP(egressIP *unstructured.Unstructured, now time.Time) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.tracker.observeEgressIP(egressIP, now)
+}
+
+func (w *egressIPTracker) removeEgressIP(egressIP *unstructured.Unstructured, now time.Time) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.tracker.removeEgressIP(egressIP.GetName(), now)
+}
+
+func (w *egressIPTracker) observeEgressFirewall(firewall *unstructured.Unstructured, now time.Time) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.tracker.observeEgressFirewall(firewall, now)
+}
+
+func (w *egressIPTracker) removeEgressFirewall(firewall *unstructured.Unstructured, now time.Time) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.tracker.removeEgressFirewall(firewall, now)
+}
+
+// startSourceProbe creates the source probe in a namespace selected by the first EgressIP whose selectors can be
+// satisfied, and follows its logs.
+func (w *egressIPTracker) startSourceProbe(ctx context.Context, egressIPs []unstructured.Unstructured, echoURL string) error {
+	for i := range egressIPs {
+		namespace, deployment, ok := sourceProbeFor(&egressIPs[i], echoURL, image.ShellImage())
+		if !ok {
+			continue
+		}
+		created, err := w.kubeClient.CoreV1().Namespaces().Create(ctx, namespace, metav1.CreateOptions{})
+		if err != nil {
+			return err
+		}
+		w.sourceNamespace = created.Name
+		if _, err := w.kubeClient.AppsV1().Deployments(created.Name).Create(ctx, deployment, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+		w.source = newSourceTracker(&egressIPs[i])
+
+		// the streamer lists the pods of its namespace, so the pods shared with the other monitor tests serve it.
+		var podInformer coreinformers.PodInformer
+		if w.kubeInformers != nil {
+			podInformer = w.kubeInformers.Core().V1().Pods()
+		} else {
+			kubeInformers := informers.NewSharedInformerFactory(w.kubeClient, 0)
+			podInformer = coreinformers.New(kubeInformers, created.Name, nil).Pods()
+			defer kubeInformers.Start(ctx.Done())
+		}
+		w.finishedCollecting = make(chan struct{})
+		podStreamer := podaccess.NewPodsStreamer(
+			w.kubeClient,
+			labels.SelectorFromSet(deployment.Spec.Selector.MatchLabels),
+			created.Name,
+			sourceContainerName,
+			w.source,
+			podInformer,
+		)
+		go podStreamer.Run(ctx, w.finishedCollecting)
+		return nil
+	}
+	return fmt.Errorf("no EgressIP selects namespaces and pods by labels alone")
+}
+
+func (w *egressIPTracker) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	if w.notSupportedReason != nil {
+		return nil, nil, w.notSupportedReason
+	}
+	if w.stopCollection == nil {
+		return nil, nil, nil
+	}
+	w.stopCollection()
+	if w.finishedCollecting != nil {
+		<-w.finishedCollecting
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.tracker.finish(end)
+	intervals := append(monitorapi.Intervals{}, w.tracker.intervals...)
+	if w.source != nil {
+		intervals = append(intervals, w.source.mismatches()...)
+	}
+	return intervals, nil, nil
+}
+
+func (w *egressIPTracker) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	return nil, w.notSupportedReason
+}
+
+func (w *egressIPTracker) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	if w.notSupportedReason != nil {
+		return nil, w.notSupportedReason
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	ret := assignmentJunit(w.tracker.longHandovers(handoverGrace))
+	if w.source != nil && w.source.sampled() {
+		ret = append(ret, sourceJunit(w.source.mismatches())...)
+	}
+	return ret, nil
+}
+
+// assignmentJunit lists the egress IP addresses that went without a node for longer than the handover grace.
+func assignmentJunit(longHandovers []string) []*junitapi.JUnitTestCase {
+	if len(longHandovers) == 0 {
+		return []*junitapi.JUnitTestCase{{Name: assignmentTestName}}
+	}
+	failure := &junitapi.JUnitTestCase{
+		Name: assignmentTestName,
+		FailureOutput: &junitapi.FailureOutput{
+			Output: fmt.Sprintf("%d egress IP addresses were unassigned for longer than %s.\n\n%s", len(longHandovers), handoverGrace, strings.Join(longHandovers, "\n")),
+		},
+	}
+	// TODO: marked flaky until we know how long handovers take during node updates
+	return []*junitapi.JUnitTestCase{failure, {Name: assignmentTestName}}
+}
+
+// sourceJunit lists the windows selected traffic left the cluster from an address other than its egress IPs.
+func sourceJunit(mismatches monitorapi.Intervals) []*junitapi.JUnitTestCase {
+	if len(mismatches) == 0 {
+		return []*junitapi.JUnitTestCase{{Name: sourceTestName}}
+	}
+	failure := &junitapi.JUnitTestCase{
+		Name: sourceTestName,
+		FailureOutput: &junitapi.FailureOutput{
+			Output: fmt.Sprintf("selected traffic left from the wrong address %d times.\n\n%s", len(mismatches), strings.Join(mismatches.Strings(), "\n")),
+		},
+	}
+	// TODO: marked flaky until we know how long handovers take during node updates
+	return []*junitapi.JUnitTestCase{failure, {Name: sourceTestName}}
+}
+
+func (w *egressIPTracker) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	return w.notSupportedReason
+}
+
+func (w *egressIPTracker) Cleanup(ctx context.Context) error {
+	if len(w.sourceNamespace) == 0 {
+		return nil
+	}
+	err := w.kubeClient.CoreV1().Namespaces().Delete(ctx, w.sourceNamespace, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
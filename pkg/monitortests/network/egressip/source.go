@@ -0,0 +1,192 @@
+package egressip
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestlibrary/podaccess"
+)
+
+const (
+	// EchoURLEnv names an endpoint outside of the cluster that replies with the source address of the request, like
+	// https://ifconfig.me/ip.  When set, traffic selected by an EgressIP is sent to it to verify its source address.
+	EchoURLEnv = "OPENSHIFT_TESTS_EGRESS_IP_ECHO_URL"
+
+	sourceContainerName = "source"
+	// the source probe logs one of these per second, the first followed by the address the endpoint saw.
+	sampleSource      = "source"
+	sampleUnreachable = "unreachable"
+
+	// maxSampleGap is how long the probe may be silent before its samples are no longer considered continuous.
+	maxSampleGap = 10 * time.Second
+)
+
+var sourceProbeScript = fmt.Sprintf(`while true; do
+  if address="$(curl -s -f -m 2 "${ECHO_URL}")"; then
+    echo "%[1]s ${address}"
+  else
+    echo %[2]s
+  fi
+  sleep 1
+done
+`, sampleSource, sampleUnreachable)
+
+// selectorLabels returns the labels that satisfy the selector at the path, false if it has expressions that cannot be
+// satisfied by labels alone.
+func selectorLabels(egressIP *unstructured.Unstructured, path ...string) (map[string]string, bool) {
+	expressions, _, _ := unstructured.NestedSlice(egressIP.Object, append(path, "matchExpressions")...)
+	if len(expressions) > 0 {
+		return nil, false
+	}
+	labels, _, _ := unstructured.NestedStringMap(egressIP.Object, append(path, "matchLabels")...)
+	return labels, true
+}
+
+// sourceProbeFor returns a namespace and deployment selected by the EgressIP, false if its selectors are not simple
+// enough to be satisfied.  An EgressIP must select namespaces, an empty namespace selector selects none.
+func sourceProbeFor(egressIP *unstructured.Unstructured, echoURL, image string) (*corev1.Namespace, *appsv1.Deployment, bool) {
+	namespaceLabels, ok := selectorLabels(egressIP, "spec", "namespaceSelector")
+	if !ok || len(namespaceLabels) == 0 {
+		return nil, nil, false
+	}
+	podLabels, ok := selectorLabels(egressIP, "spec", "podSelector")
+	if !ok {
+		return nil, nil, false
+	}
+	podLabels = mergeLabels(podLabels, map[string]string{"app": "egress-ip-source-probe"})
+
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "e2e-egress-ip-source-",
+			Labels:       namespaceLabels,
+		},
+	}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "egress-ip-source-probe"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](1),
+			Selector: &metav1.LabelSelector{MatchLabels: podLabels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: podLabels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:                     sourceContainerName,
+						Image:                    image,
+						Command:                  []string{"/bin/bash", "-c", sourceProbeScript},
+						Env:                      []corev1.EnvVar{{Name: "ECHO_URL", Value: echoURL}},
+						ImagePullPolicy:          corev1.PullIfNotPresent,
+						TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+						SecurityContext: &corev1.SecurityContext{
+							AllowPrivilegeEscalation: ptr.To(false),
+							RunAsNonRoot:             ptr.To(true),
+							Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+							SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+						},
+					}},
+					TerminationGracePeriodSeconds: ptr.To[int64](5),
+				},
+			},
+		},
+	}
+	return namespace, deployment, true
+}
+
+func mergeLabels(labels ...map[string]string) map[string]string {
+	ret := map[string]string{}
+	for _, l := range labels {
+		for k, v := range l {
+			ret[k] = v
+		}
+	}
+	return ret
+}
+
+type sourceSample struct {
+	at      time.Time
+	address string
+}
+
+// sourceTracker collects the source addresses the echo endpoint saw.  Log lines arrive concurrently from the
+// streamer.
+type sourceTracker struct {
+	egressIP  string
+	addresses sets.Set[string]
+
+	lock    sync.Mutex
+	samples []sourceSample
+}
+
+func newSourceTracker(egressIP *unstructured.Unstructured) *sourceTracker {
+	return &sourceTracker{
+		egressIP:  egressIP.GetName(),
+		addresses: sets.KeySet(nodesByAddress(egressIP)),
+	}
+}
+
+func (t *sourceTracker) HandleLogLine(logLine podaccess.LogLineContent) {
+	t.record(logLine.Instant, logLine.Line)
+}
+
+// record keeps the addresses the endpoint saw, an unreachable endpoint says nothing about the source address.
+func (t *sourceTracker) record(at time.Time, line string) {
+	fields := strings.Fields(line)
+	if len(fields) != 2 || fields[0] != sampleSource {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.samples = append(t.samples, sourceSample{at: at, address: fields[1]})
+}
+
+// mismatches returns an interval for every window the endpoint saw a source address other than the egress IPs.
+func (t *sourceTracker) mismatches() monitorapi.Intervals {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	sorted := append([]sourceSample{}, t.samples...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].at.Before(sorted[j].at) })
+
+	ret := monitorapi.Intervals{}
+	locator := monitorapi.NewLocator().ForGVR(egressIPsResource, "", t.egressIP)
+	for start := 0; start < len(sorted); {
+		if t.addresses.Has(sorted[start].address) {
+			start++
+			continue
+		}
+		end := start + 1
+		for end < len(sorted) && sorted[end].address == sorted[start].address && sorted[end].at.Sub(sorted[end-1].at) <= maxSampleGap {
+			end++
+		}
+		to := sorted[end-1].at.Add(time.Second)
+		if end < len(sorted) && sorted[end].at.Sub(sorted[end-1].at) <= maxSampleGap {
+			to = sorted[end].at
+		}
+		ret = append(ret, monitorapi.NewInterval(monitorapi.SourceEgressIP, monitorapi.Error).
+			Locator(locator).
+			Message(monitorapi.NewMessage().Reason(monitorapi.EgressIPSourceMismatchReason).
+				WithAnnotation(monitorapi.AnnotationEgressIP, sorted[start].address).
+				HumanMessagef("selected traffic left from %s instead of one of %v", sorted[start].address, sets.List(t.addresses))).
+			Display().
+			Build(sorted[start].at, to))
+		start = end
+	}
+	return ret
+}
+
+// sampled reports whether the endpoint ever saw a source address.
+func (t *sourceTracker) sampled() bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return len(t.samples) > 0
+}
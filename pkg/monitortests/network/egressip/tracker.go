@@ -0,0 +1,199 @@
+package egressip
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+var (
+	egressIPsResource       = schema.GroupVersionResource{Group: "k8s.ovn.org", Version: "v1", Resource: "egressips"}
+	egressFirewallsResource = schema.GroupVersionResource{Group: "k8s.ovn.org", Version: "v1", Resource: "egressfirewalls"}
+)
+
+// egressFirewallApplied is the status ovn-kubernetes reports once every rule of an EgressFirewall is in place.
+const egressFirewallApplied = "EgressFirewall Rules applied"
+
+// assignment is an egress IP address held by a node, or by none during a handover.
+type assignment struct {
+	egressIP string
+	address  string
+	node     string
+	since    time.Time
+}
+
+// firewallEpisode is a window the rules of an EgressFirewall were not applied, with the last status reported.
+type firewallEpisode struct {
+	namespace string
+	name      string
+	status    string
+	since     time.Time
+}
+
+// handover is a window an egress IP address was not assigned to any node.
+type handover struct {
+	egressIP string
+	address  string
+	from, to time.Time
+}
+
+func (h handover) String() string {
+	return fmt.Sprintf("egressip/%s %s was not assigned to any node for %s from %s", h.egressIP, h.address, h.to.Sub(h.from).Round(time.Second), h.from.Format(time.RFC3339))
+}
+
+// egressTracker follows the node every egress IP address is assigned to, and whether the rules of every
+// EgressFirewall are applied.  It is not safe for concurrent use.
+type egressTracker struct {
+	// assignments are keyed by egress IP address.
+	assignments map[string]*assignment
+	// firewalls are the EgressFirewalls whose rules are not applied, keyed by namespace as there is one per namespace.
+	firewalls map[string]*firewallEpisode
+
+	handovers []handover
+	intervals monitorapi.Intervals
+}
+
+func newEgressTracker() *egressTracker {
+	return &egressTracker{
+		assignments: map[string]*assignment{},
+		firewalls:   map[string]*firewallEpisode{},
+	}
+}
+
+// nodesByAddress returns the node every address of the EgressIP is assigned to, empty for those assigned to none.
+func nodesByAddress(egressIP *unstructured.Unstructured) map[string]string {
+	ret := map[string]string{}
+	addresses, _, _ := unstructured.NestedStringSlice(egressIP.Object, "spec", "egressIPs")
+	for _, address := range addresses {
+		ret[address] = ""
+	}
+	items, _, _ := unstructured.NestedSlice(egressIP.Object, "status", "items")
+	for _, item := range items {
+		itemMap, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		address, _, _ := unstructured.NestedString(itemMap, "egressIP")
+		node, _, _ := unstructured.NestedString(itemMap, "node")
+		if _, ok := ret[address]; ok {
+			ret[address] = node
+		}
+	}
+	return ret
+}
+
+// observeEgressIP closes the assignments of the addresses of the EgressIP that moved and opens their new ones.
+func (t *egressTracker) observeEgressIP(egressIP *unstructured.Unstructured, now time.Time) {
+	current := nodesByAddress(egressIP)
+	for address, node := range current {
+		previous, ok := t.assignments[address]
+		if ok && previous.node == node {
+			continue
+		}
+		if ok {
+			t.close(previous, now)
+		}
+		t.assignments[address] = &assignment{egressIP: egressIP.GetName(), address: address, node: node, since: now}
+	}
+	// addresses removed from the spec are no longer the business of the EgressIP.
+	for address, previous := range t.assignments {
+		if _, ok := current[address]; !ok && previous.egressIP == egressIP.GetName() {
+			t.close(previous, now)
+			delete(t.assignments, address)
+		}
+	}
+}
+
+// removeEgressIP ends the assignments of a deleted EgressIP.
+func (t *egressTracker) removeEgressIP(name string, now time.Time) {
+	for address, previous := range t.assignments {
+		if previous.egressIP == name {
+			t.close(previous, now)
+			delete(t.assignments, address)
+		}
+	}
+}
+
+func (t *egressTracker) close(a *assignment, now time.Time) {
+	locator := monitorapi.NewLocator().ForGVR(egressIPsResource, "", a.egressIP)
+	if len(a.node) > 0 {
+		t.intervals = append(t.intervals, monitorapi.NewInterval(monitorapi.SourceEgressIP, monitorapi.Info).
+			Locator(locator).
+			Message(monitorapi.NewMessage().Reason(monitorapi.EgressIPAssignedReason).
+				WithAnnotation(monitorapi.AnnotationEgressIP, a.address).
+				WithAnnotation(monitorapi.AnnotationNode, a.node).
+				HumanMessagef("%s assigned to node/%s", a.address, a.node)).
+			Display().
+			Build(a.since, now))
+		return
+	}
+	t.handovers = append(t.handovers, handover{egressIP: a.egressIP, address: a.address, from: a.since, to: now})
+	t.intervals = append(t.intervals, monitorapi.NewInterval(monitorapi.SourceEgressIP, monitorapi.Warning).
+		Locator(locator).
+		Message(monitorapi.NewMessage().Reason(monitorapi.EgressIPUnassignedReason).
+			WithAnnotation(monitorapi.AnnotationEgressIP, a.address).
+			HumanMessagef("%s not assigned to any node", a.address)).
+		Display().
+		Build(a.since, now))
+}
+
+// observeEgressFirewall tracks when the rules of the EgressFirewall stop and start applying.  A firewall without a
+// status has not been processed yet and is not counted.
+func (t *egressTracker) observeEgressFirewall(firewall *unstructured.Unstructured, now time.Time) {
+	status, _, _ := unstructured.NestedString(firewall.Object, "status", "status")
+	episode, wasNotApplied := t.firewalls[firewall.GetNamespace()]
+	switch notApplied := len(status) > 0 && status != egressFirewallApplied; {
+	case notApplied && wasNotApplied:
+		episode.status = status
+	case notApplied:
+		t.firewalls[firewall.GetNamespace()] = &firewallEpisode{namespace: firewall.GetNamespace(), name: firewall.GetName(), status: status, since: now}
+	case wasNotApplied:
+		t.closeFirewall(episode, now)
+	}
+}
+
+func (t *egressTracker) removeEgressFirewall(firewall *unstructured.Unstructured, now time.Time) {
+	if episode, ok := t.firewalls[firewall.GetNamespace()]; ok {
+		t.closeFirewall(episode, now)
+	}
+}
+
+func (t *egressTracker) closeFirewall(episode *firewallEpisode, now time.Time) {
+	t.intervals = append(t.intervals, monitorapi.NewInterval(monitorapi.SourceEgressIP, monitorapi.Warning).
+		Locator(monitorapi.NewLocator().ForGVR(egressFirewallsResource, episode.namespace, episode.name)).
+		Message(monitorapi.NewMessage().Reason(monitorapi.EgressFirewallNotAppliedReason).
+			HumanMessagef("rules not applied: %s", episode.status)).
+		Display().
+		Build(episode.since, now))
+	delete(t.firewalls, episode.namespace)
+}
+
+// finish closes everything still open at the end of the run.
+func (t *egressTracker) finish(now time.Time) {
+	for address, a := range t.assignments {
+		t.close(a, now)
+		delete(t.assignments, address)
+	}
+	for _, episode := range t.firewalls {
+		t.closeFirewall(episode, now)
+	}
+	sort.Sort(t.intervals)
+	sort.Slice(t.handovers, func(i, j int) bool { return t.handovers[i].from.Before(t.handovers[j].from) })
+}
+
+// longHandovers describes the handovers that took longer than the grace, including addresses left unassigned at the
+// end of the run.
+func (t *egressTracker) longHandovers(grace time.Duration) []string {
+	ret := []string{}
+	for _, h := range t.handovers {
+		if h.to.Sub(h.from) > grace {
+			ret = append(ret, h.String())
+		}
+	}
+	return ret
+}
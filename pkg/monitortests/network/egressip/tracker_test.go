@@ -0,0 +1,90 @@
+package egressip
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func egressIP(name string, assigned map[string]string, addresses ...string) *unstructured.Unstructured {
+	items := []interface{}{}
+	for address, node := range assigned {
+		items = append(items, map[string]interface{}{"egressIP": address, "node": node})
+	}
+	specAddresses := []interface{}{}
+	for _, address := range addresses {
+		specAddresses = append(specAddresses, address)
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": name},
+		"spec":     map[string]interface{}{"egressIPs": specAddresses},
+		"status":   map[string]interface{}{"items": items},
+	}}
+}
+
+func TestEgressTrackerHandovers(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	tracker := newEgressTracker()
+	tracker.observeEgressIP(egressIP("egress", map[string]string{"10.0.0.10": "worker-a"}, "10.0.0.10"), start)
+	// worker-a drains, the address is briefly without a node and then moves to worker-b.
+	tracker.observeEgressIP(egressIP("egress", nil, "10.0.0.10"), start.Add(time.Minute))
+	tracker.observeEgressIP(egressIP("egress", map[string]string{"10.0.0.10": "worker-b"}, "10.0.0.10"), start.Add(time.Minute+10*time.Second))
+	// worker-b drains and nothing takes the address until the end of the run.
+	tracker.observeEgressIP(egressIP("egress", nil, "10.0.0.10"), start.Add(2*time.Minute))
+	tracker.finish(start.Add(5 * time.Minute))
+
+	require.Len(t, tracker.intervals, 4)
+	assert.Equal(t, monitorapi.EgressIPAssignedReason, tracker.intervals[0].Message.Reason)
+	assert.Equal(t, "worker-a", tracker.intervals[0].Message.Annotations[monitorapi.AnnotationNode])
+	assert.Equal(t, monitorapi.EgressIPUnassignedReason, tracker.intervals[1].Message.Reason)
+	assert.Equal(t, start.Add(time.Minute+10*time.Second), tracker.intervals[1].To)
+	assert.Equal(t, "worker-b", tracker.intervals[2].Message.Annotations[monitorapi.AnnotationNode])
+	assert.Equal(t, monitorapi.EgressIPUnassignedReason, tracker.intervals[3].Message.Reason)
+
+	long := tracker.longHandovers(30 * time.Second)
+	require.Len(t, long, 1)
+	assert.Contains(t, long[0], "egressip/egress 10.0.0.10 was not assigned to any node for 3m0s")
+}
+
+func TestEgressTrackerFirewalls(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	firewall := func(status string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"metadata": map[string]interface{}{"namespace": "app", "name": "default"},
+			"status":   map[string]interface{}{"status": status},
+		}}
+	}
+	tracker := newEgressTracker()
+	tracker.observeEgressFirewall(firewall(""), start)
+	tracker.observeEgressFirewall(firewall(egressFirewallApplied), start.Add(time.Second))
+	tracker.observeEgressFirewall(firewall("EgressFirewall Rules not correctly applied"), start.Add(time.Minute))
+	tracker.observeEgressFirewall(firewall(egressFirewallApplied), start.Add(2*time.Minute))
+	tracker.finish(start.Add(5 * time.Minute))
+
+	require.Len(t, tracker.intervals, 1)
+	assert.Equal(t, monitorapi.EgressFirewallNotAppliedReason, tracker.intervals[0].Message.Reason)
+	assert.Equal(t, start.Add(time.Minute), tracker.intervals[0].From)
+	assert.Equal(t, start.Add(2*time.Minute), tracker.intervals[0].To)
+}
+
+func TestSourceTrackerMismatches(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	tracker := newSourceTracker(egressIP("egress", nil, "10.0.0.10"))
+	for i, line := range []string{"source 10.0.0.10", "source 10.0.1.5", "source 10.0.1.5", "unreachable", "source 10.0.0.10"} {
+		tracker.record(start.Add(time.Duration(i)*time.Second), line)
+	}
+
+	mismatches := tracker.mismatches()
+	require.Len(t, mismatches, 1)
+	assert.Equal(t, monitorapi.EgressIPSourceMismatchReason, mismatches[0].Message.Reason)
+	assert.Equal(t, start.Add(time.Second), mismatches[0].From)
+	assert.Equal(t, start.Add(4*time.Second), mismatches[0].To)
+
+	junits := sourceJunit(mismatches)
+	require.Len(t, junits, 2)
+	assert.NotNil(t, junits[0].FailureOutput)
+}
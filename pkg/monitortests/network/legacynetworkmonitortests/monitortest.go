@@ -4,7 +4,11 @@ import (
 	"context"
 	"time"
 
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/sirupsen/logrus"
+
 	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/monitortestlibrary/pathologicaleventlibrary"
 
 	"github.com/openshift/origin/pkg/monitor/monitorapi"
 	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
@@ -13,6 +17,7 @@ import (
 
 type legacyMonitorTests struct {
 	adminRESTConfig *rest.Config
+	topology        configv1.TopologyMode
 	duration        time.Duration
 }
 
@@ -22,6 +27,11 @@ func NewLegacyTests() monitortestframework.MonitorTest {
 
 func (w *legacyMonitorTests) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
 	w.adminRESTConfig = adminRESTConfig
+	_, topology, err := pathologicaleventlibrary.GetClusterInfraInfo(adminRESTConfig)
+	if err != nil {
+		logrus.WithError(err).Error("could not fetch cluster infra info")
+	}
+	w.topology = topology
 	return nil
 }
 
@@ -31,13 +41,13 @@ func (w *legacyMonitorTests) CollectData(ctx context.Context, storageDir string,
 }
 
 func (*legacyMonitorTests) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
-	return nil, nil
+	return correlateSandboxErrorsWithOVN(startingIntervals, end), nil
 }
 
 func (w *legacyMonitorTests) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
 	junits := []*junitapi.JUnitTestCase{}
 	junits = append(junits, testPodSandboxCreation(finalIntervals, w.adminRESTConfig)...)
-	junits = append(junits, testOvnNodeReadinessProbe(finalIntervals, w.adminRESTConfig)...)
+	junits = append(junits, testOvnNodeReadinessProbe(finalIntervals, w.adminRESTConfig, w.topology)...)
 	junits = append(junits, testNoDNSLookupErrorsInDisruptionSamplers(finalIntervals)...)
 	junits = append(junits, testNoOVSVswitchdUnreasonablyLongPollIntervals(finalIntervals)...)
 	junits = append(junits, testPodIPReuse(finalIntervals)...)
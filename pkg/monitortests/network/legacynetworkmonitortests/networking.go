@@ -11,6 +11,7 @@ import (
 	configclient "github.com/openshift/client-go/config/clientset/versioned"
 	"github.com/openshift/origin/pkg/monitor/monitorapi"
 	"github.com/openshift/origin/pkg/monitortestlibrary/pathologicaleventlibrary"
+	"github.com/openshift/origin/pkg/monitortests/node/kubeletlogcollector"
 	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
 	exutil "github.com/openshift/origin/test/extended/util"
 	"github.com/sirupsen/logrus"
@@ -50,6 +51,9 @@ func getPlatformType(clientConfig *rest.Config) (configv1.PlatformType, error) {
 	return infra.Status.PlatformStatus.Type, nil
 }
 
+// runtimeRestartedFlake marks the sandbox failures of a node whose container runtime was restarting.
+const runtimeRestartedFlake = "container runtime restarted"
+
 func testPodSandboxCreation(events monitorapi.Intervals, clientConfig *rest.Config) []*junitapi.JUnitTestCase {
 	const testName = "[sig-network] pods should successfully create sandboxes"
 	// we can further refine this signal by subdividing different failure modes if it is pertinent.  Right now I'm seeing
@@ -58,6 +62,7 @@ func testPodSandboxCreation(events monitorapi.Intervals, clientConfig *rest.Conf
 	// 3. error getting pod: pods "terminate-cmd-rpofb45fa14c-96bb-40f7-bd9e-346721740cac" not found
 	// 4. write child: broken pipe
 	bySubStrings := []testCategorizer{
+		{by: " by container runtime restart", substring: runtimeRestartedFlake},
 		{by: " by reading container", substring: "error reading container (probably exited) json message: EOF"},
 		{by: " by pinging container registry", substring: "pinging container registry"}, // likely combined with i/o timeout but separate test for visibility
 		{by: " by not timing out", substring: "i/o timeout"},
@@ -99,6 +104,9 @@ func testPodSandboxCreation(events monitorapi.Intervals, clientConfig *rest.Conf
 			eventInterval.Message.Reason == monitorapi.NodeNotReadyReason
 	})
 	logrus.Infof("found %d node NotReady intervals", len(nodeNotReadyIntervals))
+	// sandboxes cannot be created while the container runtime restarts, which is not a problem of the CNI.
+	runtimeRestarts := kubeletlogcollector.RuntimeRestarts(events)
+	ovnKubeNodeStates := ovnKubeNodeStatesOfSandboxErrors(events)
 
 	for _, event := range events {
 
@@ -118,6 +126,10 @@ func testPodSandboxCreation(events monitorapi.Intervals, clientConfig *rest.Conf
 		if foundOverlap {
 			continue
 		}
+		if kubeletlogcollector.DuringRuntimeRestart(runtimeRestarts, event) {
+			flakes = append(flakes, fmt.Sprintf("%v - %s - %v", event.Locator.OldLocator(), runtimeRestartedFlake, event.Message.OldMessage()))
+			continue
+		}
 
 		if strings.Contains(event.Locator.Keys[monitorapi.LocatorPodKey], "simpletest-rc-to-be-deleted") &&
 			(strings.Contains(event.Message.HumanMessage, "not found") ||
@@ -172,6 +184,12 @@ func testPodSandboxCreation(events monitorapi.Intervals, clientConfig *rest.Conf
 			}
 		}
 
+		// the state of ovnkube-node tells CNI problems of the node apart from problems of the pod.
+		message := event.Message.OldMessage()
+		if state, ok := ovnKubeNodeStates[sandboxErrorKey(event)]; ok && state != ovnKubeNodeReady {
+			message = fmt.Sprintf("%s (ovnkube-node %s)", message, state)
+		}
+
 		partialLocator := monitorapi.NonUniquePodLocatorFrom(event.Locator)
 		if deletionTime := getPodDeletionTime(eventsForPods[partialLocator], event.Locator); deletionTime == nil {
 			// mark sandboxes errors as flakes if networking is being updated
@@ -185,9 +203,9 @@ func testPodSandboxCreation(events monitorapi.Intervals, clientConfig *rest.Conf
 				}
 			}
 			if match != -1 {
-				flakes = append(flakes, fmt.Sprintf("%v - never deleted - network rollout - %v", event.Locator.OldLocator(), event.Message.OldMessage()))
+				flakes = append(flakes, fmt.Sprintf("%v - never deleted - network rollout - %v", event.Locator.OldLocator(), message))
 			} else {
-				failures = append(failures, fmt.Sprintf("%v - never deleted - %v", event.Locator.OldLocator(), event.Message.OldMessage()))
+				failures = append(failures, fmt.Sprintf("%v - never deleted - %v", event.Locator.OldLocator(), message))
 			}
 
 		} else {
@@ -197,13 +215,13 @@ func testPodSandboxCreation(events monitorapi.Intervals, clientConfig *rest.Conf
 				// nothing here, one second is close enough to be ok, the kubelet and CNI just didn't know
 			case timeBetweenDeleteAndFailure < 5*time.Second:
 				// withing five seconds, it ought to be long enough to know, but it's close enough to flake and not fail
-				flakes = append(flakes, fmt.Sprintf("%v - %0.2f seconds after deletion - %v", event.Locator.OldLocator(), timeBetweenDeleteAndFailure.Seconds(), event.Message.OldMessage()))
+				flakes = append(flakes, fmt.Sprintf("%v - %0.2f seconds after deletion - %v", event.Locator.OldLocator(), timeBetweenDeleteAndFailure.Seconds(), message))
 			case deletionTime.Before(event.From):
 				// something went wrong.  More than five seconds after the pod ws deleted, the CNI is trying to set up pod sandboxes and can't
-				failures = append(failures, fmt.Sprintf("%v - %0.2f seconds after deletion - %v", event.Locator.OldLocator(), timeBetweenDeleteAndFailure.Seconds(), event.Message.OldMessage()))
+				failures = append(failures, fmt.Sprintf("%v - %0.2f seconds after deletion - %v", event.Locator.OldLocator(), timeBetweenDeleteAndFailure.Seconds(), message))
 			default:
 				// something went wrong.  deletion happend after we had a failure to create the pod sandbox
-				failures = append(failures, fmt.Sprintf("%v - deletion came AFTER sandbox failure - %v", event.Locator.OldLocator(), event.Message.OldMessage()))
+				failures = append(failures, fmt.Sprintf("%v - deletion came AFTER sandbox failure - %v", event.Locator.OldLocator(), message))
 			}
 		}
 	}
@@ -310,7 +328,7 @@ func getPodDeletionTime(events monitorapi.Intervals, podLocator monitorapi.Locat
 
 // bug is tracked here: https://bugzilla.redhat.com/show_bug.cgi?id=2057181
 // It was closed working as designed.
-func testOvnNodeReadinessProbe(events monitorapi.Intervals, kubeClientConfig *rest.Config) []*junitapi.JUnitTestCase {
+func testOvnNodeReadinessProbe(events monitorapi.Intervals, kubeClientConfig *rest.Config, topology configv1.TopologyMode) []*junitapi.JUnitTestCase {
 	const testName = "[bz-networking] ovnkube-node readiness probe should not fail repeatedly"
 	var tests []*junitapi.JUnitTestCase
 	var failureOutput string
@@ -323,7 +341,7 @@ func testOvnNodeReadinessProbe(events monitorapi.Intervals, kubeClientConfig *re
 			if _, ok := msgMap[msg]; !ok {
 				msgMap[msg] = true
 				times := pathologicaleventlibrary.GetTimesAnEventHappened(event.Message)
-				if times > pathologicaleventlibrary.DuplicateEventThreshold {
+				if times > pathologicaleventlibrary.DuplicateEventThresholdForTopology(topology) {
 					// if the readiness probe failure for this pod happened AFTER the initial installation was complete,
 					// then this probe failure is unexpected and should fail.
 					isDuringInstall, err := pathologicaleventlibrary.IsEventAfterInstallation(event, kubeClientConfig)
@@ -0,0 +1,163 @@
+package legacynetworkmonitortests
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+const (
+	ovnKubernetesNamespace = "openshift-ovn-kubernetes"
+	ovnKubeNodePodPrefix   = "ovnkube-node-"
+
+	// ovnKubeNodeRestartLead is how long before the pod monitor saw the restart count of an ovnkube-node container
+	// change the container is considered down.  The count only changes once the new container started.
+	ovnKubeNodeRestartLead = time.Minute
+)
+
+// The states of the ovnkube-node pod of a node a sandbox error is correlated with, from the most to the least telling.
+const (
+	ovnKubeNodeRestarting = "Restarting"
+	ovnKubeNodeNotReady   = "NotReady"
+	ovnKubeNodeReady      = "Ready"
+)
+
+// isSandboxError matches the events of the kubelet failing to create a pod sandbox.
+func isSandboxError(interval monitorapi.Interval) bool {
+	return interval.Source == monitorapi.SourceKubeEvent && interval.Message.Reason == "FailedCreatePodSandBox"
+}
+
+func isOVNKubeNodePod(locator monitorapi.Locator) bool {
+	return locator.Keys[monitorapi.LocatorNamespaceKey] == ovnKubernetesNamespace &&
+		strings.HasPrefix(locator.Keys[monitorapi.LocatorPodKey], ovnKubeNodePodPrefix)
+}
+
+// ovnKubeNodeWindows returns the windows the ovnkube-node pod of every node was restarting or not ready, keyed by node
+// name.  The windows are built from the instants of the pod monitor, which carry the node of the pod.  The second
+// return is false when no ovnkube-node pod was seen at all, the cluster does not run OVN-Kubernetes then.
+func ovnKubeNodeWindows(intervals monitorapi.Intervals, end time.Time) (map[string]monitorapi.Intervals, bool) {
+	ret := map[string]monitorapi.Intervals{}
+	seen := false
+	// notReadySince tracks the containers currently not ready, by node and container.
+	notReadySince := map[string]map[string]monitorapi.Interval{}
+	for _, interval := range intervals {
+		if interval.Source != monitorapi.SourcePodMonitor || !isOVNKubeNodePod(interval.Locator) {
+			continue
+		}
+		seen = true
+		node := interval.Locator.Keys[monitorapi.LocatorNodeKey]
+		if len(node) == 0 {
+			continue
+		}
+		container := interval.Locator.Keys[monitorapi.LocatorPodKey] + "/" + interval.Locator.Keys[monitorapi.LocatorContainerKey]
+
+		switch interval.Message.Reason {
+		case monitorapi.ContainerReasonRestarted:
+			ret[node] = append(ret[node], ovnKubeNodeWindow(interval, ovnKubeNodeRestarting, interval.From.Add(-ovnKubeNodeRestartLead), interval.From))
+		case monitorapi.ContainerReasonNotReady:
+			if notReadySince[node] == nil {
+				notReadySince[node] = map[string]monitorapi.Interval{}
+			}
+			if _, ok := notReadySince[node][container]; !ok {
+				notReadySince[node][container] = interval
+			}
+		case monitorapi.ContainerReasonReady:
+			if notReady, ok := notReadySince[node][container]; ok {
+				ret[node] = append(ret[node], ovnKubeNodeWindow(notReady, ovnKubeNodeNotReady, notReady.From, interval.From))
+				delete(notReadySince[node], container)
+			}
+		}
+	}
+	// containers still not ready at the end of the run
+	for node, containers := range notReadySince {
+		for _, notReady := range containers {
+			ret[node] = append(ret[node], ovnKubeNodeWindow(notReady, ovnKubeNodeNotReady, notReady.From, end))
+		}
+	}
+	return ret, seen
+}
+
+func ovnKubeNodeWindow(interval monitorapi.Interval, state string, from, to time.Time) monitorapi.Interval {
+	return monitorapi.NewInterval(monitorapi.SourcePodMonitor, monitorapi.Warning).
+		Locator(interval.Locator).
+		Message(monitorapi.NewMessage().WithAnnotation(monitorapi.AnnotationState, state)).
+		Build(from, to)
+}
+
+// ovnKubeNodeStateAt returns the state of the ovnkube-node pod of the node at the time, and the window it was in.  A
+// restart takes precedence over the pod not being ready.
+func ovnKubeNodeStateAt(windows monitorapi.Intervals, at time.Time) (string, *monitorapi.Interval) {
+	var notReady *monitorapi.Interval
+	for i := range windows {
+		window := windows[i]
+		if at.Before(window.From) || at.After(window.To) {
+			continue
+		}
+		if window.Message.Annotations[monitorapi.AnnotationState] == ovnKubeNodeRestarting {
+			return ovnKubeNodeRestarting, &windows[i]
+		}
+		notReady = &windows[i]
+	}
+	if notReady != nil {
+		return ovnKubeNodeNotReady, notReady
+	}
+	return ovnKubeNodeReady, nil
+}
+
+// correlateSandboxErrorsWithOVN returns an interval for every pod sandbox error, annotated with whether the
+// ovnkube-node pod of its node was restarting, not ready or ready at the time.  Checking that by hand is the first
+// step of triaging sandbox errors.  Clusters without OVN-Kubernetes get no intervals.
+func correlateSandboxErrorsWithOVN(intervals monitorapi.Intervals, end time.Time) monitorapi.Intervals {
+	windows, isOVN := ovnKubeNodeWindows(intervals, end)
+	if !isOVN {
+		return nil
+	}
+
+	ret := monitorapi.Intervals{}
+	for _, interval := range intervals {
+		if !isSandboxError(interval) {
+			continue
+		}
+		node := interval.Locator.Keys[monitorapi.LocatorNodeKey]
+		if len(node) == 0 {
+			continue
+		}
+
+		state, window := ovnKubeNodeStateAt(windows[node], interval.From)
+		level := monitorapi.Warning
+		message := monitorapi.NewMessage().Reason(monitorapi.SandboxErrorCorrelatedReason).
+			WithAnnotation(monitorapi.AnnotationState, state)
+		switch {
+		case window != nil:
+			message = message.HumanMessagef("ovnkube-node pod/%s was %s: %s",
+				window.Locator.Keys[monitorapi.LocatorPodKey], strings.ToLower(state), interval.Message.HumanMessage)
+		default:
+			level = monitorapi.Info
+			message = message.HumanMessagef("ovnkube-node was ready: %s", interval.Message.HumanMessage)
+		}
+		ret = append(ret, monitorapi.NewInterval(monitorapi.SourcePodSandboxCorrelation, level).
+			Locator(interval.Locator).
+			Message(message).
+			Build(interval.From, interval.To))
+	}
+	sort.Sort(ret)
+	return ret
+}
+
+// sandboxErrorKey identifies a sandbox error and the correlation constructed for it.
+func sandboxErrorKey(interval monitorapi.Interval) string {
+	return interval.Locator.OldLocator() + " " + interval.From.UTC().Format(time.RFC3339Nano)
+}
+
+// ovnKubeNodeStatesOfSandboxErrors returns the correlated state of the ovnkube-node pod of every sandbox error.
+func ovnKubeNodeStatesOfSandboxErrors(intervals monitorapi.Intervals) map[string]string {
+	ret := map[string]string{}
+	for _, interval := range intervals {
+		if interval.Source == monitorapi.SourcePodSandboxCorrelation {
+			ret[sandboxErrorKey(interval)] = interval.Message.Annotations[monitorapi.AnnotationState]
+		}
+	}
+	return ret
+}
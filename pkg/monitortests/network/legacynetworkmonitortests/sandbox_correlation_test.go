@@ -0,0 +1,58 @@
+package legacynetworkmonitortests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestCorrelateSandboxErrorsWithOVN(t *testing.T) {
+	start := time.Date(2024, 4, 12, 11, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	ovnkube := func(node string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ovnKubernetesNamespace, Name: "ovnkube-node-" + node, UID: types.UID("uid-" + node)},
+			Spec:       corev1.PodSpec{NodeName: node},
+		}
+	}
+	podMonitor := func(pod *corev1.Pod, reason monitorapi.IntervalReason, at time.Time) monitorapi.Interval {
+		return monitorapi.NewInterval(monitorapi.SourcePodMonitor, monitorapi.Warning).
+			Locator(monitorapi.NewLocator().ContainerFromPod(pod, "ovnkube-controller")).
+			Message(monitorapi.NewMessage().Reason(reason)).
+			Build(at, at)
+	}
+	sandboxError := func(node string, at time.Time) monitorapi.Interval {
+		locator := monitorapi.NewLocator().PodFromNames("e2e-test", "client-"+node, "")
+		locator.Keys[monitorapi.LocatorNodeKey] = node
+		return monitorapi.NewInterval(monitorapi.SourceKubeEvent, monitorapi.Warning).
+			Locator(locator).
+			Message(monitorapi.NewMessage().Reason("FailedCreatePodSandBox").HumanMessage("failed to create pod network sandbox")).
+			Build(at, at)
+	}
+
+	intervals := monitorapi.Intervals{
+		podMonitor(ovnkube("worker-a"), monitorapi.ContainerReasonNotReady, start.Add(10*time.Minute)),
+		sandboxError("worker-a", start.Add(11*time.Minute)),
+		podMonitor(ovnkube("worker-a"), monitorapi.ContainerReasonReady, start.Add(12*time.Minute)),
+		sandboxError("worker-b", start.Add(20*time.Minute)),
+		podMonitor(ovnkube("worker-b"), monitorapi.ContainerReasonRestarted, start.Add(20*time.Minute+30*time.Second)),
+		sandboxError("worker-a", start.Add(30*time.Minute)),
+	}
+	correlations := correlateSandboxErrorsWithOVN(intervals, end)
+	require.Len(t, correlations, 3)
+
+	states := ovnKubeNodeStatesOfSandboxErrors(correlations)
+	assert.Equal(t, ovnKubeNodeNotReady, states[sandboxErrorKey(intervals[1])])
+	assert.Equal(t, ovnKubeNodeRestarting, states[sandboxErrorKey(intervals[3])])
+	assert.Equal(t, ovnKubeNodeReady, states[sandboxErrorKey(intervals[5])])
+	assert.Equal(t, "ovnkube-node pod/ovnkube-node-worker-b was restarting: failed to create pod network sandbox", correlations[1].Message.HumanMessage)
+
+	// without ovnkube-node pods the cluster does not run OVN-Kubernetes
+	assert.Empty(t, correlateSandboxErrorsWithOVN(monitorapi.Intervals{sandboxError("worker-a", start)}, end))
+}
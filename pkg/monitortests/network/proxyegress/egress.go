@@ -0,0 +1,270 @@
+package proxyegress
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+)
+
+// Attempt is a connection that never got a reply, seen in the connection tracking table of a node.
+type Attempt struct {
+	Node            string
+	SourceIP        string
+	DestinationIP   string
+	DestinationPort int
+	// First and Last are the first and last samples the connection was seen in.
+	First time.Time
+	Last  time.Time
+}
+
+type attemptKey struct {
+	node, sourceIP, destinationIP string
+	destinationPort               int
+}
+
+// parseProbeLog returns the unreplied connections of the output of the probe of a node.  The probe prints a
+// "sample <time>" line followed by the SYN_SENT entries of conntrack -L, like
+// "tcp      6 117 SYN_SENT src=10.128.2.12 dst=52.95.1.1 sport=41532 dport=443 [UNREPLIED] src=52.95.1.1 dst=...".
+func parseProbeLog(node string, log io.Reader) []Attempt {
+	attempts := map[attemptKey]*Attempt{}
+	order := []attemptKey{}
+	var sampledAt time.Time
+	scanner := bufio.NewScanner(log)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if timestamp, ok := strings.CutPrefix(line, "sample "); ok {
+			if at, err := time.Parse(time.RFC3339, timestamp); err == nil {
+				sampledAt = at
+			}
+			continue
+		}
+		if sampledAt.IsZero() || !strings.Contains(line, "[UNREPLIED]") {
+			continue
+		}
+		// the first src, dst and dport are those of the original direction.
+		fields := map[string]string{}
+		for _, field := range strings.Fields(line) {
+			name, value, ok := strings.Cut(field, "=")
+			if !ok {
+				continue
+			}
+			if _, seen := fields[name]; !seen {
+				fields[name] = value
+			}
+		}
+		port, err := strconv.Atoi(fields["dport"])
+		if err != nil || len(fields["src"]) == 0 || len(fields["dst"]) == 0 {
+			continue
+		}
+		key := attemptKey{node: node, sourceIP: fields["src"], destinationIP: fields["dst"], destinationPort: port}
+		attempt, ok := attempts[key]
+		if !ok {
+			attempt = &Attempt{Node: node, SourceIP: key.sourceIP, DestinationIP: key.destinationIP, DestinationPort: port, First: sampledAt}
+			attempts[key] = attempt
+			order = append(order, key)
+		}
+		attempt.Last = sampledAt
+	}
+
+	ret := []Attempt{}
+	for _, key := range order {
+		ret = append(ret, *attempts[key])
+	}
+	return ret
+}
+
+// allowedDestinations are the destinations that are reached without the proxy: the proxy itself, the networks of the
+// cluster and everything in noProxy.
+type allowedDestinations struct {
+	networks []*net.IPNet
+	ips      map[string]bool
+}
+
+// newAllowedDestinations builds the allowed destinations from the proxy status and the networks of the cluster.
+// Host names are resolved with lookupHost, those that do not resolve from the test process are skipped.
+func newAllowedDestinations(httpProxy, httpsProxy, noProxy string, networks []string, lookupHost func(string) ([]string, error)) *allowedDestinations {
+	ret := &allowedDestinations{ips: map[string]bool{}}
+	for _, network := range append([]string{"127.0.0.0/8", "169.254.0.0/16", "::1/128", "fe80::/10"}, networks...) {
+		if _, cidr, err := net.ParseCIDR(network); err == nil {
+			ret.networks = append(ret.networks, cidr)
+		}
+	}
+
+	hosts := []string{}
+	for _, proxy := range []string{httpProxy, httpsProxy} {
+		if proxyURL, err := url.Parse(proxy); err == nil && len(proxyURL.Hostname()) > 0 {
+			hosts = append(hosts, proxyURL.Hostname())
+		}
+	}
+	for _, entry := range strings.Split(noProxy, ",") {
+		entry = strings.TrimSpace(entry)
+		switch {
+		case len(entry) == 0, entry == "*", strings.HasPrefix(entry, "."):
+			// domains cannot be turned into addresses.
+		case strings.Contains(entry, "/"):
+			if _, cidr, err := net.ParseCIDR(entry); err == nil {
+				ret.networks = append(ret.networks, cidr)
+			}
+		default:
+			hosts = append(hosts, entry)
+		}
+	}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			ret.ips[ip.String()] = true
+			continue
+		}
+		addresses, err := lookupHost(host)
+		if err != nil {
+			continue
+		}
+		for _, address := range addresses {
+			if ip := net.ParseIP(address); ip != nil {
+				ret.ips[ip.String()] = true
+			}
+		}
+	}
+	return ret
+}
+
+func (a *allowedDestinations) allows(address string) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		// not something we can judge.
+		return true
+	}
+	if a.ips[ip.String()] {
+		return true
+	}
+	for _, network := range a.networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// podsByIP finds the pod that had an IP, among the pods recorded during the run.  Pods on the host network share the
+// IP of their node and are left out, their connections are those of the node.
+type podsByIP map[string][]*corev1.Pod
+
+func newPodsByIP(pods []*corev1.Pod) podsByIP {
+	ret := podsByIP{}
+	for _, pod := range pods {
+		if pod.Spec.HostNetwork {
+			continue
+		}
+		for _, podIP := range pod.Status.PodIPs {
+			ret[podIP.IP] = append(ret[podIP.IP], pod)
+		}
+	}
+	return ret
+}
+
+// podAt returns the pod that had the IP at a point in time, the most recently created one when IPs were reused.
+func (p podsByIP) podAt(ip string, at time.Time) *corev1.Pod {
+	var ret *corev1.Pod
+	for _, pod := range p[ip] {
+		if pod.CreationTimestamp.Time.After(at) {
+			continue
+		}
+		if ret == nil || pod.CreationTimestamp.Time.After(ret.CreationTimestamp.Time) {
+			ret = pod
+		}
+	}
+	return ret
+}
+
+func attemptIntervals(attempts []Attempt, allowed *allowedDestinations, pods podsByIP) monitorapi.Intervals {
+	ret := monitorapi.Intervals{}
+	for _, attempt := range attempts {
+		if allowed.allows(attempt.DestinationIP) {
+			continue
+		}
+		locator := monitorapi.NewLocator().NodeFromName(attempt.Node)
+		if pod := pods.podAt(attempt.SourceIP, attempt.First); pod != nil {
+			locator = monitorapi.NewLocator().PodFromNames(pod.Namespace, pod.Name, string(pod.UID))
+		} else if len(pods[attempt.SourceIP]) > 0 {
+			// a pod IP, but of a pod created after the attempt that we could not have recorded.
+			continue
+		}
+		ret = append(ret, monitorapi.NewInterval(monitorapi.SourceProxyEgress, monitorapi.Error).
+			Locator(locator).
+			Message(monitorapi.NewMessage().Reason(monitorapi.DirectEgressAttemptReason).
+				Node(attempt.Node).
+				HumanMessagef("connection from %s to %s was not sent through the proxy and got no reply",
+					attempt.SourceIP, net.JoinHostPort(attempt.DestinationIP, strconv.Itoa(attempt.DestinationPort)))).
+			Display().
+			Build(attempt.First, attempt.Last))
+	}
+	return ret
+}
+
+const hostNetworkTestName = "[sig-network] the host network of the nodes should use the cluster proxy for egress"
+
+func namespaceTestName(namespace string) string {
+	return fmt.Sprintf("[sig-network] pods in namespace %s should use the cluster proxy for egress", namespace)
+}
+
+// egressJUnits fails the platform namespaces that bypassed the proxy.  The host network only flakes, its connections
+// cannot be told apart from those of the pods on the host network.  The connections of the e2e namespaces are left to
+// the tests that make them.
+func egressJUnits(platformNamespaces []string, intervals monitorapi.Intervals) []*junitapi.JUnitTestCase {
+	failures := map[string][]string{}
+	for _, interval := range intervals {
+		if interval.Source != monitorapi.SourceProxyEgress || interval.Message.Reason != monitorapi.DirectEgressAttemptReason {
+			continue
+		}
+		testName := hostNetworkTestName
+		if namespace := interval.Locator.Keys[monitorapi.LocatorNamespaceKey]; len(namespace) > 0 {
+			if !strings.HasPrefix(namespace, "openshift-") {
+				continue
+			}
+			testName = namespaceTestName(namespace)
+		}
+		failures[testName] = append(failures[testName], interval.String())
+	}
+
+	testNames := []string{hostNetworkTestName}
+	for _, namespace := range platformNamespaces {
+		testNames = append(testNames, namespaceTestName(namespace))
+	}
+	for testName := range failures {
+		testNames = append(testNames, testName)
+	}
+	sort.Strings(testNames)
+
+	ret := []*junitapi.JUnitTestCase{}
+	seen := map[string]bool{}
+	for _, testName := range testNames {
+		if seen[testName] {
+			continue
+		}
+		seen[testName] = true
+		if len(failures[testName]) == 0 {
+			ret = append(ret, &junitapi.JUnitTestCase{Name: testName})
+			continue
+		}
+		ret = append(ret, &junitapi.JUnitTestCase{
+			Name: testName,
+			FailureOutput: &junitapi.FailureOutput{
+				Output: fmt.Sprintf("%d connections bypassed the cluster proxy:\n\n%s", len(failures[testName]), strings.Join(failures[testName], "\n")),
+			},
+		})
+		if testName == hostNetworkTestName {
+			ret = append(ret, &junitapi.JUnitTestCase{Name: testName})
+		}
+	}
+	return ret
+}
@@ -0,0 +1,77 @@
+package proxyegress
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+const probeLog = `sample 2026-10-01T10:00:00Z
+tcp      6 117 SYN_SENT src=10.128.2.12 dst=52.95.1.1 sport=41532 dport=443 [UNREPLIED] src=52.95.1.1 dst=10.0.0.5 sport=443 dport=41532 mark=0 zone=64000 use=1
+tcp      6 100 SYN_SENT src=10.0.0.5 dst=10.0.0.20 sport=52310 dport=3128 [UNREPLIED] src=10.0.0.20 dst=10.0.0.5 sport=3128 dport=52310 mark=0 use=1
+sample 2026-10-01T10:00:30Z
+tcp      6 87 SYN_SENT src=10.128.2.12 dst=52.95.1.1 sport=41532 dport=443 [UNREPLIED] src=52.95.1.1 dst=10.0.0.5 sport=443 dport=41532 mark=0 zone=64000 use=1
+tcp      6 119 SYN_SENT src=10.0.0.5 dst=151.101.1.1 sport=38122 dport=443 [UNREPLIED] src=151.101.1.1 dst=10.0.0.5 sport=443 dport=38122 mark=0 use=1
+tcp      6 119 SYN_SENT src=10.129.0.40 dst=151.101.1.1 sport=38122 dport=80 [UNREPLIED] src=151.101.1.1 dst=10.0.0.5 sport=80 dport=38122 mark=0 use=1
+`
+
+func pod(namespace, name, ip string, created time.Time) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, UID: types.UID("uid-" + name), CreationTimestamp: metav1.NewTime(created)},
+		Status:     corev1.PodStatus{PodIPs: []corev1.PodIP{{IP: ip}}},
+	}
+}
+
+func TestProxyEgress(t *testing.T) {
+	first := time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC)
+	attempts := parseProbeLog("worker-a", strings.NewReader(probeLog))
+	require.Len(t, attempts, 4)
+	assert.Equal(t, Attempt{Node: "worker-a", SourceIP: "10.128.2.12", DestinationIP: "52.95.1.1", DestinationPort: 443, First: first, Last: first.Add(30 * time.Second)}, attempts[0])
+
+	lookupHost := func(host string) ([]string, error) {
+		if host == "proxy.ci.example.com" {
+			return []string{"10.0.0.20"}, nil
+		}
+		return nil, errors.New("no such host")
+	}
+	allowed := newAllowedDestinations("http://proxy.ci.example.com:3128", "", ".cluster.local,.svc,10.0.0.0/24,api-int.ci.example.com,169.254.169.254",
+		[]string{"10.128.0.0/14", "172.30.0.0/16"}, lookupHost)
+	assert.True(t, allowed.allows("10.0.0.20"), "the proxy")
+	assert.True(t, allowed.allows("172.30.0.1"), "the service network")
+	assert.False(t, allowed.allows("52.95.1.1"))
+
+	pods := newPodsByIP([]*corev1.Pod{
+		pod("openshift-insights", "insights-operator-1", "10.128.2.12", first.Add(-time.Hour)),
+		// the IP was reused by an e2e pod after the attempt.
+		pod("e2e-test-foo", "client", "10.128.2.12", first.Add(time.Hour)),
+		pod("e2e-test-bar", "curl", "10.129.0.40", first.Add(-time.Minute)),
+	})
+	intervals := attemptIntervals(attempts, allowed, pods)
+	require.Len(t, intervals, 3)
+	assert.Equal(t, "openshift-insights", intervals[0].Locator.Keys[monitorapi.LocatorNamespaceKey])
+	assert.Equal(t, "insights-operator-1", intervals[0].Locator.Keys[monitorapi.LocatorPodKey])
+	assert.Equal(t, "worker-a", intervals[1].Locator.Keys[monitorapi.LocatorNodeKey], "connections from the node IP are those of the host network")
+	assert.Equal(t, "e2e-test-bar", intervals[2].Locator.Keys[monitorapi.LocatorNamespaceKey])
+
+	junits := egressJUnits([]string{"openshift-dns", "openshift-insights"}, intervals)
+	require.Len(t, junits, 4)
+	assert.Equal(t, namespaceTestName("openshift-dns"), junits[0].Name)
+	assert.Nil(t, junits[0].FailureOutput)
+	assert.Equal(t, namespaceTestName("openshift-insights"), junits[1].Name)
+	require.NotNil(t, junits[1].FailureOutput)
+	assert.Contains(t, junits[1].FailureOutput.Output, "52.95.1.1:443")
+	// the host network flakes.
+	assert.Equal(t, hostNetworkTestName, junits[2].Name)
+	assert.NotNil(t, junits[2].FailureOutput)
+	assert.Equal(t, hostNetworkTestName, junits[3].Name)
+	assert.Nil(t, junits[3].FailureOutput)
+}
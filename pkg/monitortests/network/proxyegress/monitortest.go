@@ -0,0 +1,214 @@
+package proxyegress
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	configclient "github.com/openshift/client-go/config/clientset/versioned"
+	"github.com/openshift/library-go/pkg/operator/resource/resourceread"
+	"github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/monitortests/network/disruptionpodnetwork"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	exutil "github.com/openshift/origin/test/extended/util"
+)
+
+var (
+	//go:embed *.yaml
+	yamls embed.FS
+
+	namespace      *corev1.Namespace
+	probeDaemonSet *appsv1.DaemonSet
+)
+
+func yamlOrDie(name string) []byte {
+	ret, err := yamls.ReadFile(name)
+	if err != nil {
+		panic(err)
+	}
+
+	return ret
+}
+
+func init() {
+	namespace = resourceread.ReadNamespaceV1OrDie(yamlOrDie("namespace.yaml"))
+	probeDaemonSet = resourceread.ReadDaemonSetV1OrDie(yamlOrDie("probe-daemonset.yaml"))
+}
+
+// proxyEgress checks that the platform honors the cluster proxy on proxied clusters.  Proxied and disconnected CI
+// clusters block egress that does not go through the proxy, so a connection bypassing it never gets a reply.  A probe
+// on every node samples those unreplied connections from the connection tracking table of the node for the whole run.
+type proxyEgress struct {
+	payloadImagePullSpec string
+	notSupportedReason   error
+	kubeClient           kubernetes.Interface
+	namespaceName        string
+
+	allowed            *allowedDestinations
+	attempts           []Attempt
+	platformNamespaces []string
+}
+
+func NewProxyEgressValidator(info monitortestframework.MonitorTestInitializationInfo) monitortestframework.MonitorTest {
+	return &proxyEgress{
+		payloadImagePullSpec: info.UpgradeTargetPayloadImagePullSpec,
+	}
+}
+
+func (w *proxyEgress) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	var err error
+	w.kubeClient, err = kubernetes.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return err
+	}
+	isMicroShift, err := exutil.IsMicroShiftCluster(w.kubeClient)
+	if err != nil {
+		return fmt.Errorf("unable to determine if cluster is MicroShift: %v", err)
+	}
+	if isMicroShift {
+		w.notSupportedReason = &monitortestframework.NotSupportedError{Reason: "platform MicroShift not supported"}
+		return w.notSupportedReason
+	}
+
+	configClient, err := configclient.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return err
+	}
+	proxy, err := configClient.ConfigV1().Proxies().Get(ctx, "cluster", metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if len(proxy.Status.HTTPProxy) == 0 && len(proxy.Status.HTTPSProxy) == 0 {
+		w.notSupportedReason = &monitortestframework.NotSupportedError{Reason: "the cluster is not proxied"}
+		return w.notSupportedReason
+	}
+	network, err := configClient.ConfigV1().Networks().Get(ctx, "cluster", metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	w.allowed = newAllowedDestinations(proxy.Status.HTTPProxy, proxy.Status.HTTPSProxy, proxy.Status.NoProxy, clusterNetworks(network), net.LookupHost)
+
+	openshiftTestsImagePullSpec, err := disruptionpodnetwork.GetOpenshiftTestsImagePullSpec(ctx, adminRESTConfig, w.payloadImagePullSpec, nil)
+	if err != nil {
+		w.notSupportedReason = &monitortestframework.NotSupportedError{Reason: fmt.Sprintf("unable to determine openshift-tests image: %v", err)}
+		return w.notSupportedReason
+	}
+
+	actualNamespace, err := w.kubeClient.CoreV1().Namespaces().Create(ctx, namespace, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+	w.namespaceName = actualNamespace.Name
+
+	daemonSet := probeDaemonSet.DeepCopy()
+	daemonSet.Spec.Template.Spec.Containers[0].Image = openshiftTestsImagePullSpec
+	if _, err := w.kubeClient.AppsV1().DaemonSets(w.namespaceName).Create(ctx, daemonSet, metav1.CreateOptions{}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func clusterNetworks(network *configv1.Network) []string {
+	ret := append([]string{}, network.Status.ServiceNetwork...)
+	for _, clusterNetwork := range network.Status.ClusterNetwork {
+		ret = append(ret, clusterNetwork.CIDR)
+	}
+	return ret
+}
+
+func (w *proxyEgress) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	if w.notSupportedReason != nil {
+		return nil, nil, w.notSupportedReason
+	}
+
+	probePods, err := w.kubeClient.CoreV1().Pods(w.namespaceName).List(ctx, metav1.ListOptions{
+		LabelSelector: "network.openshift.io/proxy-egress=probe",
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	errs := []error{}
+	for _, probePod := range probePods.Items {
+		logStream, err := w.kubeClient.CoreV1().Pods(w.namespaceName).GetLogs(probePod.Name, &corev1.PodLogOptions{}).Stream(ctx)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		w.attempts = append(w.attempts, parseProbeLog(probePod.Spec.NodeName, logStream)...)
+		logStream.Close()
+	}
+	if len(probePods.Items) == 0 {
+		errs = append(errs, fmt.Errorf("no proxy egress probe pods found in %s", w.namespaceName))
+	}
+	logrus.Infof("proxy egress probes saw %d unreplied connections", len(w.attempts))
+	return nil, nil, utilerrors.NewAggregate(errs)
+}
+
+func (w *proxyEgress) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	if w.notSupportedReason != nil {
+		return nil, w.notSupportedReason
+	}
+	pods := []*corev1.Pod{}
+	platformNamespaces := sets.New[string]()
+	for _, obj := range recordedResources["pods"] {
+		pod, ok := obj.(*corev1.Pod)
+		if !ok {
+			continue
+		}
+		pods = append(pods, pod)
+		if strings.HasPrefix(pod.Namespace, "openshift-") {
+			platformNamespaces.Insert(pod.Namespace)
+		}
+	}
+	w.platformNamespaces = sets.List(platformNamespaces)
+	return attemptIntervals(w.attempts, w.allowed, newPodsByIP(pods)), nil
+}
+
+func (w *proxyEgress) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	if w.notSupportedReason != nil {
+		return nil, w.notSupportedReason
+	}
+	return egressJUnits(w.platformNamespaces, finalIntervals), nil
+}
+
+func (w *proxyEgress) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	if w.notSupportedReason != nil {
+		return w.notSupportedReason
+	}
+	if len(w.attempts) == 0 {
+		return nil
+	}
+	// every unreplied connection, including those allowed to go around the proxy, to tune the allowed destinations.
+	attempts := append([]Attempt{}, w.attempts...)
+	sort.SliceStable(attempts, func(i, j int) bool { return attempts[i].First.Before(attempts[j].First) })
+	jsonContent, err := json.MarshalIndent(attempts, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(storageDir, fmt.Sprintf("proxy-egress-attempts%s.json", timeSuffix)), jsonContent, 0644)
+}
+
+func (w *proxyEgress) Cleanup(ctx context.Context) error {
+	if w.kubeClient == nil || len(w.namespaceName) == 0 {
+		return nil
+	}
+	return w.kubeClient.CoreV1().Namespaces().Delete(ctx, w.namespaceName, metav1.DeleteOptions{})
+}
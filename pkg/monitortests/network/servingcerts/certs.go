@@ -0,0 +1,184 @@
+package servingcerts
+
+import (
+	"crypto/x509"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+)
+
+const (
+	// minRemainingValidity is the validity a serving certificate must have left.  The certificates of the router and
+	// the apiserver load balancer are rotated long before they get this close to their expiry.
+	minRemainingValidity = 24 * time.Hour
+)
+
+// target is an endpoint whose serving certificate is checked.
+type target struct {
+	// name identifies the endpoint in the test names, like "ingress router".
+	name     string
+	testName string
+	// host is the name the certificate must be valid for.
+	host string
+	// address is the host and port dialed.
+	address string
+}
+
+// problemEpisode is a period where the certificate of a target had one problem.
+type problemEpisode struct {
+	reason  monitorapi.IntervalReason
+	from    time.Time
+	to      time.Time
+	message string
+}
+
+// rotation is a change of the certificate of a target by the same issuer.
+type rotation struct {
+	at      time.Time
+	message string
+}
+
+// certTracker follows the serving certificate of one target across the run.
+type certTracker struct {
+	target target
+
+	// issuer and serial are those of the first certificate observed, and of the last one.
+	issuer     string
+	lastSerial string
+
+	// observations is the number of chains observed, the target could not be dialed when it is zero.
+	observations int
+	open         map[monitorapi.IntervalReason]*problemEpisode
+	episodes     []problemEpisode
+	rotations    []rotation
+}
+
+func newCertTracker(target target) *certTracker {
+	return &certTracker{target: target, open: map[monitorapi.IntervalReason]*problemEpisode{}}
+}
+
+// observe checks the chain the target served at a point in time.
+func (t *certTracker) observe(at time.Time, chain []*x509.Certificate) {
+	if len(chain) == 0 {
+		return
+	}
+	leaf := chain[0]
+	t.observations++
+
+	issuer := leaf.Issuer.String()
+	serial := leaf.SerialNumber.String()
+	if len(t.issuer) == 0 {
+		t.issuer = issuer
+	}
+	if len(t.lastSerial) > 0 && serial != t.lastSerial && issuer == t.issuer {
+		t.rotations = append(t.rotations, rotation{
+			at:      at,
+			message: fmt.Sprintf("serving certificate rotated to serial %s, valid until %s", serial, leaf.NotAfter.UTC().Format(time.RFC3339)),
+		})
+	}
+	t.lastSerial = serial
+
+	problems := certificateProblems(t.target.host, chain, at)
+	if issuer != t.issuer {
+		problems[monitorapi.ServingCertIssuerChangedReason] = fmt.Sprintf("serving certificate issued by %q instead of %q", issuer, t.issuer)
+	}
+	for reason, episode := range t.open {
+		if _, ok := problems[reason]; !ok {
+			t.episodes = append(t.episodes, *episode)
+			delete(t.open, reason)
+		}
+	}
+	for reason, message := range problems {
+		episode, ok := t.open[reason]
+		if !ok {
+			episode = &problemEpisode{reason: reason, from: at}
+			t.open[reason] = episode
+		}
+		episode.to = at
+		episode.message = message
+	}
+}
+
+// certificateProblems returns the problems of a chain served for host, by reason.
+func certificateProblems(host string, chain []*x509.Certificate, at time.Time) map[monitorapi.IntervalReason]string {
+	problems := map[monitorapi.IntervalReason]string{}
+	leaf := chain[0]
+	switch {
+	case at.Before(leaf.NotBefore):
+		problems[monitorapi.ServingCertExpiringReason] = fmt.Sprintf("serving certificate not valid before %s", leaf.NotBefore.UTC().Format(time.RFC3339))
+	case !at.Before(leaf.NotAfter):
+		problems[monitorapi.ServingCertExpiringReason] = fmt.Sprintf("serving certificate expired at %s", leaf.NotAfter.UTC().Format(time.RFC3339))
+	case leaf.NotAfter.Sub(at) < minRemainingValidity:
+		problems[monitorapi.ServingCertExpiringReason] = fmt.Sprintf("serving certificate expires at %s, in %s",
+			leaf.NotAfter.UTC().Format(time.RFC3339), leaf.NotAfter.Sub(at).Round(time.Minute))
+	}
+	if err := leaf.VerifyHostname(host); err != nil {
+		problems[monitorapi.ServingCertHostnameMismatchReason] = fmt.Sprintf("serving certificate not valid for %s: %v", host, err)
+	}
+	for i := 0; i+1 < len(chain); i++ {
+		if err := chain[i].CheckSignatureFrom(chain[i+1]); err != nil {
+			problems[monitorapi.ServingCertInvalidChainReason] = fmt.Sprintf("certificate %d of the chain, %q, is not signed by the next one, %q: %v",
+				i, chain[i].Subject.String(), chain[i+1].Subject.String(), err)
+			break
+		}
+	}
+	return problems
+}
+
+// allEpisodes returns the episodes, those still open end at the last observation of their problem.
+func (t *certTracker) allEpisodes() []problemEpisode {
+	ret := append([]problemEpisode{}, t.episodes...)
+	for _, episode := range t.open {
+		ret = append(ret, *episode)
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		if !ret[i].from.Equal(ret[j].from) {
+			return ret[i].from.Before(ret[j].from)
+		}
+		return ret[i].reason < ret[j].reason
+	})
+	return ret
+}
+
+func (t *certTracker) intervals() monitorapi.Intervals {
+	locator := monitorapi.NewLocator().LocateServer(t.target.host, "", "", "")
+	ret := monitorapi.Intervals{}
+	for _, episode := range t.allEpisodes() {
+		ret = append(ret, monitorapi.NewInterval(monitorapi.SourceServingCertificate, monitorapi.Error).
+			Locator(locator).
+			Message(monitorapi.NewMessage().Reason(episode.reason).HumanMessage(episode.message)).
+			Display().
+			Build(episode.from, episode.to))
+	}
+	for _, rotation := range t.rotations {
+		ret = append(ret, monitorapi.NewInterval(monitorapi.SourceServingCertificate, monitorapi.Info).
+			Locator(locator).
+			Message(monitorapi.NewMessage().Reason(monitorapi.ServingCertRotatedReason).HumanMessage(rotation.message)).
+			Display().
+			Build(rotation.at, rotation.at))
+	}
+	return ret
+}
+
+// junit fails when the certificate of the target had any problem during the run, rotations are expected.
+func (t *certTracker) junit() *junitapi.JUnitTestCase {
+	failures := []string{}
+	for _, episode := range t.allEpisodes() {
+		failures = append(failures, fmt.Sprintf("%s from %s to %s: %s", episode.reason,
+			episode.from.UTC().Format(time.RFC3339), episode.to.UTC().Format(time.RFC3339), episode.message))
+	}
+	if len(failures) == 0 {
+		return &junitapi.JUnitTestCase{Name: t.target.testName}
+	}
+	return &junitapi.JUnitTestCase{
+		Name: t.target.testName,
+		FailureOutput: &junitapi.FailureOutput{
+			Output: fmt.Sprintf("the serving certificate of the %s at %s was invalid during the run:\n\n%s",
+				t.target.name, t.target.address, strings.Join(failures, "\n")),
+		},
+	}
+}
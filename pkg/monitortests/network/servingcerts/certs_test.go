@@ -0,0 +1,106 @@
+package servingcerts
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T, name string) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(365 * 24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key}
+}
+
+func (ca *testCA) issue(t *testing.T, serial int64, host string, notAfter time.Time) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: host},
+		DNSNames:     []string{host},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+func TestCertTracker(t *testing.T) {
+	host := "canary-openshift-ingress-canary.apps.example.com"
+	ingressCA, otherCA := newTestCA(t, "ingress-operator"), newTestCA(t, "other-signer")
+	now := time.Now()
+	at := func(minute int) time.Time { return now.Add(time.Duration(minute) * time.Minute) }
+	valid := now.Add(30 * 24 * time.Hour)
+
+	tracker := newCertTracker(target{name: "ingress router", testName: ingressTestName, host: host, address: host + ":443"})
+	first := ingressCA.issue(t, 2, host, valid)
+	tracker.observe(at(0), []*x509.Certificate{first, ingressCA.cert})
+	tracker.observe(at(1), []*x509.Certificate{first, ingressCA.cert})
+	assert.Empty(t, tracker.allEpisodes())
+	assert.Nil(t, tracker.junit().FailureOutput)
+
+	// a rotation by the same issuer is expected.
+	tracker.observe(at(2), []*x509.Certificate{ingressCA.issue(t, 3, host, valid), ingressCA.cert})
+	// a certificate for the wrong host, close to its expiry, served with the chain of another signer.
+	bad := ingressCA.issue(t, 4, "wrong.example.com", now.Add(time.Hour))
+	tracker.observe(at(3), []*x509.Certificate{bad, otherCA.cert})
+	tracker.observe(at(4), []*x509.Certificate{bad, otherCA.cert})
+	// a certificate of another issuer.
+	tracker.observe(at(5), []*x509.Certificate{otherCA.issue(t, 5, host, valid), otherCA.cert})
+
+	episodes := tracker.allEpisodes()
+	require.Len(t, episodes, 4)
+	assert.Equal(t, monitorapi.ServingCertExpiringReason, episodes[0].reason)
+	assert.Equal(t, at(3), episodes[0].from)
+	assert.Equal(t, at(4), episodes[0].to)
+	assert.Equal(t, monitorapi.ServingCertHostnameMismatchReason, episodes[1].reason)
+	assert.Equal(t, monitorapi.ServingCertInvalidChainReason, episodes[2].reason)
+	assert.Equal(t, monitorapi.ServingCertIssuerChangedReason, episodes[3].reason)
+	assert.Equal(t, at(5), episodes[3].from)
+
+	intervals := tracker.intervals()
+	require.Len(t, intervals, 6)
+	rotations := intervals.Filter(func(interval monitorapi.Interval) bool {
+		return interval.Message.Reason == monitorapi.ServingCertRotatedReason
+	})
+	// serial 4 was issued by the same issuer, serial 5 was not.
+	require.Len(t, rotations, 2)
+	assert.Equal(t, at(2), rotations[0].From)
+	assert.Equal(t, host, intervals[0].Locator.Keys[monitorapi.LocatorServerKey])
+
+	junit := tracker.junit()
+	require.NotNil(t, junit.FailureOutput)
+	assert.Contains(t, junit.FailureOutput.Output, "ServingCertIssuerChanged")
+}
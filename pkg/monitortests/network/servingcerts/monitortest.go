@@ -0,0 +1,172 @@
+package servingcerts
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	routeclient "github.com/openshift/client-go/route/clientset/versioned"
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+)
+
+const (
+	// checkInterval is how often the certificates are checked, rotations take minutes to roll out.
+	checkInterval = time.Minute
+	dialTimeout   = 10 * time.Second
+
+	// the canary route is served with the default certificate of the ingress router.
+	canaryRouteNamespace = "openshift-ingress-canary"
+	canaryRouteName      = "canary"
+
+	ingressTestName   = "[sig-network-edge] the serving certificate of the ingress router should remain valid throughout the test"
+	apiserverTestName = "[sig-api-machinery] the serving certificate of the apiserver load balancer should remain valid throughout the test"
+)
+
+// servingCertValidity checks the certificate chains served by the ingress router and the apiserver load balancer
+// throughout the run, so that a bad rotation fails the run that caused it rather than the clients hours later.
+type servingCertValidity struct {
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	lock     sync.Mutex
+	trackers []*certTracker
+}
+
+func NewServingCertValidityChecker() monitortestframework.MonitorTest {
+	return &servingCertValidity{}
+}
+
+func (*servingCertValidity) Describe() monitortestframework.MonitorTestDescription {
+	return monitortestframework.MonitorTestDescription{
+		JUnits: []string{ingressTestName, apiserverTestName},
+	}
+}
+
+func (w *servingCertValidity) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	apiserverURL, err := url.Parse(adminRESTConfig.Host)
+	if err != nil {
+		return err
+	}
+	apiserverAddress := apiserverURL.Host
+	if len(apiserverURL.Port()) == 0 {
+		apiserverAddress = net.JoinHostPort(apiserverURL.Hostname(), "443")
+	}
+	w.trackers = append(w.trackers, newCertTracker(target{
+		name:     "apiserver load balancer",
+		testName: apiserverTestName,
+		host:     apiserverURL.Hostname(),
+		address:  apiserverAddress,
+	}))
+
+	routeClient, err := routeclient.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return err
+	}
+	canary, err := routeClient.RouteV1().Routes(canaryRouteNamespace).Get(ctx, canaryRouteName, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		logrus.Infof("route/%s in %s not found, not checking the certificate of the ingress router", canaryRouteName, canaryRouteNamespace)
+	case err != nil:
+		return err
+	default:
+		w.trackers = append(w.trackers, newCertTracker(target{
+			name:     "ingress router",
+			testName: ingressTestName,
+			host:     canary.Spec.Host,
+			address:  net.JoinHostPort(canary.Spec.Host, "443"),
+		}))
+	}
+
+	// the certificates are checked until CollectData, not for as long as the StartCollection context lives.
+	checkCtx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		wait.UntilWithContext(checkCtx, w.check, checkInterval)
+	}()
+	return nil
+}
+
+func (w *servingCertValidity) check(ctx context.Context) {
+	for _, tracker := range w.trackers {
+		chain, err := servedChain(tracker.target)
+		if err != nil {
+			// availability is measured by the disruption backends, only the certificates matter here.
+			logrus.WithError(err).Debugf("unable to read the serving certificate of %s", tracker.target.address)
+			continue
+		}
+		w.lock.Lock()
+		tracker.observe(time.Now(), chain)
+		w.lock.Unlock()
+	}
+}
+
+// servedChain returns the chain the target serves for its host.  It is not verified, checking it is the point.
+func servedChain(target target) ([]*x509.Certificate, error) {
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: dialTimeout}, "tcp", target.address, &tls.Config{
+		ServerName:         target.host,
+		InsecureSkipVerify: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	return conn.ConnectionState().PeerCertificates, nil
+}
+
+func (w *servingCertValidity) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	w.stopChecks()
+	return nil, nil, nil
+}
+
+func (w *servingCertValidity) stopChecks() {
+	if w.cancel != nil {
+		w.cancel()
+		w.wg.Wait()
+	}
+}
+
+func (w *servingCertValidity) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	ret := monitorapi.Intervals{}
+	for _, tracker := range w.trackers {
+		ret = append(ret, tracker.intervals()...)
+	}
+	return ret, nil
+}
+
+func (w *servingCertValidity) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	ret := []*junitapi.JUnitTestCase{}
+	for _, tracker := range w.trackers {
+		if tracker.observations == 0 {
+			continue
+		}
+		ret = append(ret, tracker.junit())
+	}
+	return ret, nil
+}
+
+func (*servingCertValidity) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	return nil
+}
+
+func (w *servingCertValidity) Cleanup(ctx context.Context) error {
+	w.stopChecks()
+	return nil
+}
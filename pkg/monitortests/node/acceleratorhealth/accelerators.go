@@ -0,0 +1,237 @@
+package acceleratorhealth
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+)
+
+const (
+	// unavailableGrace is how long accelerators or a device plugin may be unavailable before the test fails.  The
+	// kubelet reports no allocatable accelerators while a device plugin restarts and registers again.
+	unavailableGrace = 5 * time.Minute
+
+	acceleratorsTestName  = "[sig-node][Feature:Accelerators] allocatable accelerators should not drop to zero on ready nodes"
+	devicePluginsTestName = "[sig-node][Feature:Accelerators] accelerator device plugin daemonsets should remain available"
+)
+
+var (
+	// acceleratorResourceRegex matches the extended resources advertised by the accelerator device plugins.
+	acceleratorResourceRegex = regexp.MustCompile(`^(nvidia\.com/(gpu|mig-.+)|amd\.com/gpu|gpu\.intel\.com/.+|habana\.ai/gaudi|aws\.amazon\.com/neuron(core|device)?)$`)
+	// devicePluginRegex matches the daemonsets of the device plugins, which are named after them by convention.
+	devicePluginRegex = regexp.MustCompile(`device-plugin`)
+
+	daemonSetsResource = schema.GroupVersionResource{Group: "apps", Resource: "daemonsets"}
+)
+
+// acceleratorResources returns the accelerator quantities of resources.
+func acceleratorResources(resources corev1.ResourceList) map[corev1.ResourceName]int64 {
+	ret := map[corev1.ResourceName]int64{}
+	for name, quantity := range resources {
+		if acceleratorResourceRegex.MatchString(string(name)) {
+			ret[name] = quantity.Value()
+		}
+	}
+	return ret
+}
+
+// advertisesAccelerators returns true when the node has the capacity for an accelerator resource.
+func advertisesAccelerators(node *corev1.Node) bool {
+	for _, quantity := range acceleratorResources(node.Status.Capacity) {
+		if quantity > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// expectsAccelerators returns true when the node should have its accelerators allocatable.  They are expected to go
+// away while a node is not ready, cordoned for an update or being removed.
+func expectsAccelerators(node *corev1.Node) bool {
+	if node.DeletionTimestamp != nil || node.Spec.Unschedulable {
+		return false
+	}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// episode is a period where the accelerators of a node, or the pods of a device plugin, were unavailable.
+type episode struct {
+	reason   monitorapi.IntervalReason
+	locator  monitorapi.Locator
+	resource corev1.ResourceName
+	from     time.Time
+	to       time.Time
+	message  string
+}
+
+func (e episode) duration() time.Duration {
+	return e.to.Sub(e.from)
+}
+
+// acceleratorTracker follows the allocatable accelerators of the nodes and the availability of the device plugins.
+type acceleratorTracker struct {
+	// advertised are the accelerator resources each node has had allocatable during the run.
+	advertised map[string]map[corev1.ResourceName]bool
+	open       map[string]*episode
+	episodes   []episode
+}
+
+func newAcceleratorTracker() *acceleratorTracker {
+	return &acceleratorTracker{
+		advertised: map[string]map[corev1.ResourceName]bool{},
+		open:       map[string]*episode{},
+	}
+}
+
+// observeNode records the allocatable accelerators of a node at a point in time.
+func (t *acceleratorTracker) observeNode(at time.Time, node *corev1.Node) {
+	if t.advertised[node.Name] == nil {
+		t.advertised[node.Name] = map[corev1.ResourceName]bool{}
+	}
+	for resource, quantity := range acceleratorResources(node.Status.Allocatable) {
+		if quantity > 0 {
+			t.advertised[node.Name][resource] = true
+		}
+	}
+
+	allocatable := acceleratorResources(node.Status.Allocatable)
+	for resource := range t.advertised[node.Name] {
+		key := fmt.Sprintf("node/%s %s", node.Name, resource)
+		if allocatable[resource] > 0 || !expectsAccelerators(node) {
+			t.close(key, at)
+			continue
+		}
+		t.openOrExtend(key, at, episode{
+			reason:   monitorapi.AcceleratorsUnallocatableReason,
+			locator:  monitorapi.NewLocator().NodeFromName(node.Name),
+			resource: resource,
+			message: fmt.Sprintf("node is ready and schedulable with %d %s in its capacity, but none allocatable",
+				acceleratorResources(node.Status.Capacity)[resource], resource),
+		})
+	}
+}
+
+// removeNode ends the episodes of a node that left the cluster, its accelerators went with it.
+func (t *acceleratorTracker) removeNode(at time.Time, nodeName string) {
+	for resource := range t.advertised[nodeName] {
+		t.close(fmt.Sprintf("node/%s %s", nodeName, resource), at)
+	}
+	delete(t.advertised, nodeName)
+}
+
+// observeDaemonSet records the availability of a device plugin daemonset at a point in time.
+func (t *acceleratorTracker) observeDaemonSet(at time.Time, daemonSet *appsv1.DaemonSet) {
+	key := fmt.Sprintf("daemonset/%s/%s", daemonSet.Namespace, daemonSet.Name)
+	if daemonSet.Status.NumberUnavailable == 0 {
+		t.close(key, at)
+		return
+	}
+	t.openOrExtend(key, at, episode{
+		reason:  monitorapi.DevicePluginUnavailableReason,
+		locator: monitorapi.NewLocator().ForGVR(daemonSetsResource, daemonSet.Namespace, daemonSet.Name),
+		message: fmt.Sprintf("%d of %d device plugin pods unavailable",
+			daemonSet.Status.NumberUnavailable, daemonSet.Status.DesiredNumberScheduled),
+	})
+}
+
+func (t *acceleratorTracker) openOrExtend(key string, at time.Time, e episode) {
+	if open, ok := t.open[key]; ok {
+		open.to = at
+		open.message = e.message
+		return
+	}
+	e.from, e.to = at, at
+	t.open[key] = &e
+}
+
+func (t *acceleratorTracker) close(key string, at time.Time) {
+	open, ok := t.open[key]
+	if !ok {
+		return
+	}
+	open.to = at
+	t.episodes = append(t.episodes, *open)
+	delete(t.open, key)
+}
+
+// finish ends the episodes still open at the end of the run.
+func (t *acceleratorTracker) finish(at time.Time) {
+	for key := range t.open {
+		t.close(key, at)
+	}
+	sort.SliceStable(t.episodes, func(i, j int) bool {
+		return t.episodes[i].from.Before(t.episodes[j].from)
+	})
+}
+
+func (t *acceleratorTracker) intervals() monitorapi.Intervals {
+	ret := monitorapi.Intervals{}
+	for _, e := range t.episodes {
+		message := monitorapi.NewMessage().Reason(e.reason).HumanMessage(e.message)
+		if len(e.resource) > 0 {
+			message = message.WithAnnotation(monitorapi.AnnotationExtendedResource, string(e.resource))
+		}
+		ret = append(ret, monitorapi.NewInterval(monitorapi.SourceAccelerators, monitorapi.Warning).
+			Locator(e.locator).
+			Message(message).
+			Display().
+			Build(e.from, e.to))
+	}
+	return ret
+}
+
+func (t *acceleratorTracker) junits() []*junitapi.JUnitTestCase {
+	return append(
+		junitsFor(acceleratorsTestName, t.episodesFor(monitorapi.AcceleratorsUnallocatableReason)),
+		junitsFor(devicePluginsTestName, t.episodesFor(monitorapi.DevicePluginUnavailableReason))...,
+	)
+}
+
+func (t *acceleratorTracker) episodesFor(reason monitorapi.IntervalReason) []episode {
+	ret := []episode{}
+	for _, e := range t.episodes {
+		if e.reason == reason {
+			ret = append(ret, e)
+		}
+	}
+	return ret
+}
+
+// junitsFor fails when an episode outlasted unavailableGrace, and flakes on shorter ones.
+func junitsFor(testName string, episodes []episode) []*junitapi.JUnitTestCase {
+	if len(episodes) == 0 {
+		return []*junitapi.JUnitTestCase{{Name: testName}}
+	}
+	long := false
+	lines := []string{}
+	for _, e := range episodes {
+		long = long || e.duration() > unavailableGrace
+		lines = append(lines, fmt.Sprintf("%s for %s from %s: %s", e.locator.OldLocator(), e.duration().Round(time.Second),
+			e.from.UTC().Format(time.RFC3339), e.message))
+	}
+	failure := &junitapi.JUnitTestCase{
+		Name: testName,
+		FailureOutput: &junitapi.FailureOutput{
+			Output: fmt.Sprintf("accelerators were unavailable %d times, failing above %s:\n\n%s",
+				len(episodes), unavailableGrace, strings.Join(lines, "\n")),
+		},
+	}
+	if long {
+		return []*junitapi.JUnitTestCase{failure}
+	}
+	return []*junitapi.JUnitTestCase{failure, {Name: testName}}
+}
@@ -0,0 +1,140 @@
+package acceleratorhealth
+
+import (
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+func gpuNode(allocatable int64, ready, unschedulable bool) *corev1.Node {
+	readyStatus := corev1.ConditionTrue
+	if !ready {
+		readyStatus = corev1.ConditionFalse
+	}
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "gpu-worker"},
+		Spec:       corev1.NodeSpec{Unschedulable: unschedulable},
+		Status: corev1.NodeStatus{
+			Capacity: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("16"),
+				"nvidia.com/gpu":   *resource.NewQuantity(4, resource.DecimalSI),
+			},
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("15"),
+				"nvidia.com/gpu":   *resource.NewQuantity(allocatable, resource.DecimalSI),
+			},
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: readyStatus}},
+		},
+	}
+}
+
+func TestAcceleratorTracker(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return start.Add(time.Duration(minutes) * time.Minute) }
+
+	tests := []struct {
+		name          string
+		observe       func(*acceleratorTracker)
+		wantIntervals int
+		wantJUnits    map[string][]bool
+	}{
+		{
+			name: "accelerators stay allocatable",
+			observe: func(tracker *acceleratorTracker) {
+				tracker.observeNode(at(0), gpuNode(4, true, false))
+				tracker.observeNode(at(10), gpuNode(4, true, false))
+			},
+			wantJUnits: map[string][]bool{acceleratorsTestName: {false}, devicePluginsTestName: {false}},
+		},
+		{
+			name: "drops while the node is not ready or cordoned are expected",
+			observe: func(tracker *acceleratorTracker) {
+				tracker.observeNode(at(0), gpuNode(4, true, false))
+				tracker.observeNode(at(1), gpuNode(0, false, false))
+				tracker.observeNode(at(20), gpuNode(0, true, true))
+				tracker.observeNode(at(40), gpuNode(4, true, false))
+			},
+			wantJUnits: map[string][]bool{acceleratorsTestName: {false}, devicePluginsTestName: {false}},
+		},
+		{
+			name: "short drop on a ready node flakes",
+			observe: func(tracker *acceleratorTracker) {
+				tracker.observeNode(at(0), gpuNode(4, true, false))
+				tracker.observeNode(at(1), gpuNode(0, true, false))
+				tracker.observeNode(at(2), gpuNode(4, true, false))
+			},
+			wantIntervals: 1,
+			wantJUnits:    map[string][]bool{acceleratorsTestName: {true, false}, devicePluginsTestName: {false}},
+		},
+		{
+			name: "drop lasting until the end of the run fails",
+			observe: func(tracker *acceleratorTracker) {
+				tracker.observeNode(at(0), gpuNode(4, true, false))
+				tracker.observeNode(at(1), gpuNode(0, true, false))
+			},
+			wantIntervals: 1,
+			wantJUnits:    map[string][]bool{acceleratorsTestName: {true}, devicePluginsTestName: {false}},
+		},
+		{
+			name: "node never advertising accelerators is ignored",
+			observe: func(tracker *acceleratorTracker) {
+				tracker.observeNode(at(0), gpuNode(0, true, false))
+			},
+			wantJUnits: map[string][]bool{acceleratorsTestName: {false}, devicePluginsTestName: {false}},
+		},
+		{
+			name: "unavailable device plugin pods flake",
+			observe: func(tracker *acceleratorTracker) {
+				daemonSet := &appsv1.DaemonSet{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "nvidia-gpu-operator", Name: "nvidia-device-plugin-daemonset"},
+					Status:     appsv1.DaemonSetStatus{DesiredNumberScheduled: 2, NumberUnavailable: 1},
+				}
+				tracker.observeDaemonSet(at(0), daemonSet)
+				daemonSet = daemonSet.DeepCopy()
+				daemonSet.Status.NumberUnavailable = 0
+				tracker.observeDaemonSet(at(1), daemonSet)
+			},
+			wantIntervals: 1,
+			wantJUnits:    map[string][]bool{acceleratorsTestName: {false}, devicePluginsTestName: {true, false}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := newAcceleratorTracker()
+			tt.observe(tracker)
+			tracker.finish(at(60))
+
+			intervals := tracker.intervals()
+			if len(intervals) != tt.wantIntervals {
+				t.Fatalf("expected %d intervals, got %d: %v", tt.wantIntervals, len(intervals), intervals)
+			}
+			for _, interval := range intervals {
+				if interval.Source != monitorapi.SourceAccelerators {
+					t.Errorf("unexpected source %q", interval.Source)
+				}
+			}
+
+			gotJUnits := map[string][]bool{}
+			for _, junit := range tracker.junits() {
+				gotJUnits[junit.Name] = append(gotJUnits[junit.Name], junit.FailureOutput != nil)
+			}
+			for name, want := range tt.wantJUnits {
+				got := gotJUnits[name]
+				if len(got) != len(want) {
+					t.Fatalf("%s: expected failures %v, got %v", name, want, got)
+				}
+				for i := range want {
+					if got[i] != want[i] {
+						t.Errorf("%s: expected failures %v, got %v", name, want, got)
+					}
+				}
+			}
+		})
+	}
+}
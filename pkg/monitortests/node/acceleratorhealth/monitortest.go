@@ -0,0 +1,174 @@
+package acceleratorhealth
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	exutil "github.com/openshift/origin/test/extended/util"
+)
+
+// acceleratorHealth watches the allocatable accelerators of the nodes and the device plugin daemonsets advertising
+// them.  A broken device plugin otherwise only shows up as pods failing to schedule for lack of accelerators.
+type acceleratorHealth struct {
+	notSupportedReason error
+	kubeInformers      informers.SharedInformerFactory
+
+	lock sync.Mutex
+	// stopped is set by CollectData, the shared informers keep delivering changes until the monitor stops.
+	stopped bool
+	tracker *acceleratorTracker
+}
+
+func NewAcceleratorHealthMonitor() monitortestframework.MonitorTest {
+	return &acceleratorHealth{tracker: newAcceleratorTracker()}
+}
+
+func (*acceleratorHealth) Describe() monitortestframework.MonitorTestDescription {
+	return monitortestframework.MonitorTestDescription{
+		WatchedResources: []string{"nodes", "daemonsets"},
+		JUnits:           []string{acceleratorsTestName, devicePluginsTestName},
+	}
+}
+
+func (w *acceleratorHealth) SetSharedInformers(kubeInformers informers.SharedInformerFactory) {
+	w.kubeInformers = kubeInformers
+}
+
+func (w *acceleratorHealth) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	kubeClient, err := kubernetes.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return err
+	}
+	isMicroShift, err := exutil.IsMicroShiftCluster(kubeClient)
+	if err != nil {
+		return fmt.Errorf("unable to determine if cluster is MicroShift: %v", err)
+	}
+	if isMicroShift {
+		w.notSupportedReason = &monitortestframework.NotSupportedError{Reason: "platform MicroShift not supported"}
+		return w.notSupportedReason
+	}
+
+	// accelerator nodes added during the run, to a cluster without any at the start, are not watched.
+	nodes, err := kubeClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	acceleratorNodes := 0
+	for i := range nodes.Items {
+		if advertisesAccelerators(&nodes.Items[i]) {
+			acceleratorNodes++
+		}
+	}
+	if acceleratorNodes == 0 {
+		w.notSupportedReason = &monitortestframework.NotSupportedError{Reason: "no node advertises accelerators"}
+		return w.notSupportedReason
+	}
+	logrus.Infof("watching the accelerators of %d nodes", acceleratorNodes)
+
+	if w.kubeInformers == nil {
+		w.kubeInformers = informers.NewSharedInformerFactory(kubeClient, 0)
+		defer w.kubeInformers.Start(ctx.Done())
+	}
+	if _, err := w.kubeInformers.Core().V1().Nodes().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    w.nodeChanged,
+		UpdateFunc: func(_, obj interface{}) { w.nodeChanged(obj) },
+		DeleteFunc: w.nodeDeleted,
+	}); err != nil {
+		return err
+	}
+	if _, err := w.kubeInformers.Apps().V1().DaemonSets().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    w.daemonSetChanged,
+		UpdateFunc: func(_, obj interface{}) { w.daemonSetChanged(obj) },
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (w *acceleratorHealth) nodeChanged(obj interface{}) {
+	node, ok := obj.(*corev1.Node)
+	if !ok {
+		return
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.stopped {
+		return
+	}
+	w.tracker.observeNode(time.Now(), node)
+}
+
+func (w *acceleratorHealth) nodeDeleted(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	node, ok := obj.(*corev1.Node)
+	if !ok {
+		return
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.stopped {
+		return
+	}
+	w.tracker.removeNode(time.Now(), node.Name)
+}
+
+func (w *acceleratorHealth) daemonSetChanged(obj interface{}) {
+	daemonSet, ok := obj.(*appsv1.DaemonSet)
+	if !ok || !devicePluginRegex.MatchString(daemonSet.Name) {
+		return
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.stopped {
+		return
+	}
+	w.tracker.observeDaemonSet(time.Now(), daemonSet)
+}
+
+func (w *acceleratorHealth) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	if w.notSupportedReason != nil {
+		return nil, nil, w.notSupportedReason
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.stopped = true
+	w.tracker.finish(time.Now())
+	return w.tracker.intervals(), nil, nil
+}
+
+func (w *acceleratorHealth) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	return nil, w.notSupportedReason
+}
+
+func (w *acceleratorHealth) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	if w.notSupportedReason != nil {
+		return nil, w.notSupportedReason
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.tracker.junits(), nil
+}
+
+func (w *acceleratorHealth) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	return w.notSupportedReason
+}
+
+func (w *acceleratorHealth) Cleanup(ctx context.Context) error {
+	return nil
+}
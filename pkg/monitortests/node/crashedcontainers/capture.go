@@ -0,0 +1,110 @@
+package crashedcontainers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// crashLogLines is the number of log lines captured from the end of the log of a crashed container.
+	crashLogLines = 100
+	// maxCaptures bounds the captures of a run, a crash looping platform is already failing without them.
+	maxCaptures = 500
+)
+
+// IsPlatformNamespace returns true for the namespaces whose crashed containers are captured.
+func IsPlatformNamespace(namespace string) bool {
+	return strings.HasPrefix(namespace, "openshift-") && !strings.HasPrefix(namespace, "openshift-must-gather")
+}
+
+// CaptureName is the name of the file holding the capture of a container that terminated.  It is annotated on the
+// ContainerExit interval of the container, the files are written to the crashed-containers directory of the run.
+func CaptureName(namespace, pod, container string, terminated *corev1.ContainerStateTerminated) string {
+	return fmt.Sprintf("%s_%s_%s_%d.log", namespace, pod, container, terminated.FinishedAt.Unix())
+}
+
+// crash is a container of a platform pod that terminated with a non-zero exit code.
+type crash struct {
+	namespace  string
+	pod        string
+	container  string
+	terminated corev1.ContainerStateTerminated
+	// previous is set when the container was restarted, its logs are then those of the previous instance.
+	previous bool
+
+	logs   string
+	logErr error
+}
+
+func (c *crash) name() string {
+	return CaptureName(c.namespace, c.pod, c.container, &c.terminated)
+}
+
+// content is the termination state followed by the last log lines.
+func (c *crash) content() string {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "pod: %s/%s\ncontainer: %s\n", c.namespace, c.pod, c.container)
+	fmt.Fprintf(b, "exitCode: %d\nreason: %s\n", c.terminated.ExitCode, c.terminated.Reason)
+	fmt.Fprintf(b, "startedAt: %s\nfinishedAt: %s\n", c.terminated.StartedAt.UTC().Format(time.RFC3339), c.terminated.FinishedAt.UTC().Format(time.RFC3339))
+	fmt.Fprintf(b, "\ntermination message:\n%s\n", c.terminated.Message)
+	if c.logErr != nil {
+		fmt.Fprintf(b, "\nunable to read the logs: %v\n", c.logErr)
+	} else {
+		fmt.Fprintf(b, "\nlast %d log lines:\n%s", crashLogLines, c.logs)
+	}
+	return b.String()
+}
+
+// crashesOf returns the containers of pod that terminated with a non-zero exit code since oldPod.  Pods seen for the
+// first time are skipped, their terminations happened before the run or were missed.
+func crashesOf(oldPod, pod *corev1.Pod) []*crash {
+	if oldPod == nil || !IsPlatformNamespace(pod.Namespace) {
+		return nil
+	}
+	ret := []*crash{}
+	for _, statuses := range []struct {
+		current, old []corev1.ContainerStatus
+	}{
+		{current: pod.Status.InitContainerStatuses, old: oldPod.Status.InitContainerStatuses},
+		{current: pod.Status.ContainerStatuses, old: oldPod.Status.ContainerStatuses},
+	} {
+		for _, status := range statuses.current {
+			var old *corev1.ContainerStatus
+			for i := range statuses.old {
+				if statuses.old[i].Name == status.Name {
+					old = &statuses.old[i]
+				}
+			}
+			if old == nil {
+				continue
+			}
+			if newlyFailed(old.LastTerminationState.Terminated, status.LastTerminationState.Terminated) {
+				ret = append(ret, newCrash(pod, status.Name, status.LastTerminationState.Terminated, true))
+			}
+			if newlyFailed(old.State.Terminated, status.State.Terminated) {
+				ret = append(ret, newCrash(pod, status.Name, status.State.Terminated, false))
+			}
+		}
+	}
+	return ret
+}
+
+func newlyFailed(old, current *corev1.ContainerStateTerminated) bool {
+	if current == nil || current.ExitCode == 0 {
+		return false
+	}
+	return old == nil || !old.FinishedAt.Equal(&current.FinishedAt)
+}
+
+func newCrash(pod *corev1.Pod, container string, terminated *corev1.ContainerStateTerminated, previous bool) *crash {
+	return &crash{
+		namespace:  pod.Namespace,
+		pod:        pod.Name,
+		container:  container,
+		terminated: *terminated.DeepCopy(),
+		previous:   previous,
+	}
+}
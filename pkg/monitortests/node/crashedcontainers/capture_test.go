@@ -0,0 +1,55 @@
+package crashedcontainers
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func podWithStatus(namespace string, status corev1.ContainerStatus) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "etcd-operator-5c9d8b7f6-x2v9k"},
+		Status:     corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{status}},
+	}
+}
+
+func TestCrashesOf(t *testing.T) {
+	finished := metav1.NewTime(time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC))
+	running := corev1.ContainerStatus{Name: "operator", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}}
+	restarted := corev1.ContainerStatus{
+		Name:         "operator",
+		RestartCount: 1,
+		State:        corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+		LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+			ExitCode: 255, Reason: "Error", Message: "panic: runtime error", FinishedAt: finished,
+		}},
+	}
+
+	crashes := crashesOf(podWithStatus("openshift-etcd-operator", running), podWithStatus("openshift-etcd-operator", restarted))
+	require.Len(t, crashes, 1)
+	assert.True(t, crashes[0].previous)
+	assert.Equal(t, "openshift-etcd-operator_etcd-operator-5c9d8b7f6-x2v9k_operator_1709287200.log", crashes[0].name())
+
+	assert.Empty(t, crashesOf(podWithStatus("openshift-etcd-operator", restarted), podWithStatus("openshift-etcd-operator", restarted)),
+		"the same termination is only captured once")
+	assert.Empty(t, crashesOf(nil, podWithStatus("openshift-etcd-operator", restarted)), "pods seen for the first time are skipped")
+	assert.Empty(t, crashesOf(podWithStatus("e2e-test", running), podWithStatus("e2e-test", restarted)), "only platform namespaces are captured")
+
+	completed := *restarted.DeepCopy()
+	completed.LastTerminationState.Terminated.ExitCode = 0
+	assert.Empty(t, crashesOf(podWithStatus("openshift-etcd-operator", running), podWithStatus("openshift-etcd-operator", completed)))
+
+	crashes[0].logs = "E0301 10:00:00.000000 1 main.go:42] exiting\n"
+	content := crashes[0].content()
+	assert.Contains(t, content, "exitCode: 255\n")
+	assert.Contains(t, content, "termination message:\npanic: runtime error\n")
+	assert.Contains(t, content, "last 100 log lines:\nE0301")
+
+	crashes[0].logErr = errors.New("container not found")
+	assert.Contains(t, crashes[0].content(), "unable to read the logs: container not found")
+}
@@ -0,0 +1,140 @@
+package crashedcontainers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+)
+
+type crashedContainerCollector struct {
+	kubeInformers informers.SharedInformerFactory
+	kubeClient    kubernetes.Interface
+
+	lock     sync.Mutex
+	captures []*crash
+	// inFlight tracks the log reads that have not completed.
+	inFlight sync.WaitGroup
+}
+
+// NewCrashedContainerCollector captures the termination message and the last log lines of every platform container
+// that exits non-zero, while the kubelet still has its logs, so that crashes can be triaged after the pod is gone.
+func NewCrashedContainerCollector() monitortestframework.MonitorTest {
+	return &crashedContainerCollector{}
+}
+
+func (w *crashedContainerCollector) SetSharedInformers(kubeInformers informers.SharedInformerFactory) {
+	w.kubeInformers = kubeInformers
+}
+
+func (*crashedContainerCollector) Describe() monitortestframework.MonitorTestDescription {
+	return monitortestframework.MonitorTestDescription{
+		WatchedResources: []string{"pods"},
+	}
+}
+
+func (w *crashedContainerCollector) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	kubeClient, err := kubernetes.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return err
+	}
+	w.kubeClient = kubeClient
+	if w.kubeInformers == nil {
+		w.kubeInformers = informers.NewSharedInformerFactory(kubeClient, 0)
+		defer w.kubeInformers.Start(ctx.Done())
+	}
+
+	_, err = w.kubeInformers.Core().V1().Pods().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, obj interface{}) {
+			oldPod, ok := oldObj.(*corev1.Pod)
+			if !ok {
+				return
+			}
+			pod, ok := obj.(*corev1.Pod)
+			if !ok {
+				return
+			}
+			for _, crash := range crashesOf(oldPod, pod) {
+				w.capture(ctx, crash)
+			}
+		},
+	})
+	return err
+}
+
+// capture reads the logs of a crash in the background, the informer must not wait on the kubelet.
+func (w *crashedContainerCollector) capture(ctx context.Context, crash *crash) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if len(w.captures) >= maxCaptures {
+		return
+	}
+	w.captures = append(w.captures, crash)
+	w.inFlight.Add(1)
+	go func() {
+		defer w.inFlight.Done()
+		logCtx, cancel := context.WithTimeout(ctx, time.Minute)
+		defer cancel()
+		logs, err := w.kubeClient.CoreV1().Pods(crash.namespace).GetLogs(crash.pod, &corev1.PodLogOptions{
+			Container: crash.container,
+			Previous:  crash.previous,
+			TailLines: ptr.To[int64](crashLogLines),
+		}).DoRaw(logCtx)
+		w.lock.Lock()
+		defer w.lock.Unlock()
+		crash.logs, crash.logErr = string(logs), err
+	}()
+}
+
+func (w *crashedContainerCollector) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	w.inFlight.Wait()
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	logrus.Infof("captured %d crashed platform containers", len(w.captures))
+	return nil, nil, nil
+}
+
+func (*crashedContainerCollector) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	return nil, nil
+}
+
+func (*crashedContainerCollector) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	return nil, nil
+}
+
+func (w *crashedContainerCollector) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if len(w.captures) == 0 {
+		return nil
+	}
+	captureDir := filepath.Join(storageDir, fmt.Sprintf("crashed-containers%s", timeSuffix))
+	if err := os.MkdirAll(captureDir, 0755); err != nil {
+		return err
+	}
+	errs := []error{}
+	for _, crash := range w.captures {
+		if err := os.WriteFile(filepath.Join(captureDir, crash.name()), []byte(crash.content()), 0644); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (*crashedContainerCollector) Cleanup(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,130 @@
+package disruptionkubelet
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	watch_kubelets "github.com/openshift/origin/pkg/cmd/openshift-tests/disruption/watch-kubelets"
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+// outage is a period where a poller, or most pollers, could not reach a kubelet.
+type outage struct {
+	from time.Time
+	to   time.Time
+	// pollers is the largest number of pollers that could not reach the kubelet at once.
+	pollers int
+}
+
+// kubeletUnavailableIntervals finds when the kubelet of each node did not respond to most of the pollers probing it.
+// A single poller failing is a problem of its node or of the network between them, not of the kubelet.  Pollers on
+// nodes that are down count as probing, which hides the outages of other kubelets while many nodes are down.
+func kubeletUnavailableIntervals(intervals monitorapi.Intervals, end time.Time) monitorapi.Intervals {
+	// pollerOutages holds the outages of each target node per poller.
+	pollerOutages := map[string]map[string][]outage{}
+	for _, interval := range intervals {
+		if interval.Source != monitorapi.SourceDisruption ||
+			!strings.HasPrefix(interval.Locator.Keys[monitorapi.LocatorBackendDisruptionNameKey], watch_kubelets.BackendPrefix+"-") {
+			continue
+		}
+		node := interval.Locator.Keys[monitorapi.LocatorNodeKey]
+		poller := interval.Locator.Keys[monitorapi.LocatorDisruptionKey]
+		if len(node) == 0 || len(poller) == 0 {
+			continue
+		}
+		if _, ok := pollerOutages[node]; !ok {
+			pollerOutages[node] = map[string][]outage{}
+		}
+		// available pollers count as probing the kubelet too.
+		if _, ok := pollerOutages[node][poller]; !ok {
+			pollerOutages[node][poller] = nil
+		}
+		if interval.Message.Reason != monitorapi.DisruptionBeganEventReason {
+			continue
+		}
+		to := interval.To
+		if to.IsZero() {
+			to = end
+		}
+		pollerOutages[node][poller] = append(pollerOutages[node][poller], outage{from: interval.From, to: to})
+	}
+
+	nodes := []string{}
+	for node := range pollerOutages {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+
+	ret := monitorapi.Intervals{}
+	for _, node := range nodes {
+		pollers := len(pollerOutages[node])
+		for _, unavailable := range majorityOutages(pollerOutages[node], pollers) {
+			ret = append(ret, monitorapi.NewInterval(monitorapi.SourceKubeletAvailability, monitorapi.Error).
+				Locator(monitorapi.NewLocator().NodeFromName(node)).
+				Message(monitorapi.NewMessage().Reason(monitorapi.KubeletUnavailableReason).
+					HumanMessagef("kubelet API did not respond to %d of %d pollers", unavailable.pollers, pollers)).
+				Display().
+				Build(unavailable.from, unavailable.to))
+		}
+	}
+	return ret
+}
+
+// majorityOutages returns the periods where more than half of the pollers were in an outage.  The outages of a poller
+// over new and reused connections overlap, so they are merged before counting.
+func majorityOutages(pollerOutages map[string][]outage, pollers int) []outage {
+	type edge struct {
+		at    time.Time
+		delta int
+	}
+	edges := []edge{}
+	for _, outages := range pollerOutages {
+		for _, merged := range mergeOutages(outages) {
+			edges = append(edges, edge{at: merged.from, delta: 1}, edge{at: merged.to, delta: -1})
+		}
+	}
+	sort.SliceStable(edges, func(i, j int) bool {
+		if !edges[i].at.Equal(edges[j].at) {
+			return edges[i].at.Before(edges[j].at)
+		}
+		// an outage ending when another starts does not overlap it.
+		return edges[i].delta < edges[j].delta
+	})
+
+	ret := []outage{}
+	var current *outage
+	down := 0
+	for _, edge := range edges {
+		down += edge.delta
+		majority := down*2 > pollers
+		switch {
+		case majority && current == nil:
+			current = &outage{from: edge.at, pollers: down}
+		case majority:
+			current.pollers = max(current.pollers, down)
+		case current != nil:
+			current.to = edge.at
+			ret = append(ret, *current)
+			current = nil
+		}
+	}
+	return ret
+}
+
+func mergeOutages(outages []outage) []outage {
+	sort.Slice(outages, func(i, j int) bool {
+		return outages[i].from.Before(outages[j].from)
+	})
+	ret := []outage{}
+	for _, o := range outages {
+		if len(ret) > 0 && !o.from.After(ret[len(ret)-1].to) {
+			if o.to.After(ret[len(ret)-1].to) {
+				ret[len(ret)-1].to = o.to
+			}
+			continue
+		}
+		ret = append(ret, o)
+	}
+	return ret
+}
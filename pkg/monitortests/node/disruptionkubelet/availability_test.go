@@ -0,0 +1,57 @@
+package disruptionkubelet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	watch_kubelets "github.com/openshift/origin/pkg/cmd/openshift-tests/disruption/watch-kubelets"
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+func pollerInterval(poller, target string, connectionType monitorapi.BackendConnectionType, reason monitorapi.IntervalReason, from, to time.Time) monitorapi.Interval {
+	locator := monitorapi.NewLocator().LocateDisruptionCheck(
+		"kubelet-"+string(connectionType)+"-connections", watch_kubelets.InstanceName(poller, target), connectionType)
+	locator.Keys[monitorapi.LocatorNodeKey] = target
+	return monitorapi.NewInterval(monitorapi.SourceDisruption, monitorapi.Error).
+		Locator(locator).
+		Message(monitorapi.NewMessage().Reason(reason).HumanMessage("sampled")).
+		Build(from, to)
+}
+
+func TestKubeletUnavailableIntervals(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	at := func(minute int) time.Time { return start.Add(time.Duration(minute) * time.Minute) }
+	end := at(30)
+
+	intervals := monitorapi.Intervals{
+		// node-a is unreachable from two of its three pollers between 2 and 5.
+		pollerInterval("node-a", "node-a", monitorapi.NewConnectionType, monitorapi.DisruptionBeganEventReason, at(1), at(5)),
+		pollerInterval("node-a", "node-a", monitorapi.ReusedConnectionType, monitorapi.DisruptionBeganEventReason, at(2), at(6)),
+		pollerInterval("node-b", "node-a", monitorapi.NewConnectionType, monitorapi.DisruptionBeganEventReason, at(2), at(5)),
+		pollerInterval("node-c", "node-a", monitorapi.NewConnectionType, monitorapi.DisruptionEndedEventReason, at(0), end),
+		// node-b is only unreachable from node-c, a problem of node-c or the network.
+		pollerInterval("node-a", "node-b", monitorapi.NewConnectionType, monitorapi.DisruptionEndedEventReason, at(0), end),
+		pollerInterval("node-b", "node-b", monitorapi.NewConnectionType, monitorapi.DisruptionEndedEventReason, at(0), end),
+		pollerInterval("node-c", "node-b", monitorapi.NewConnectionType, monitorapi.DisruptionBeganEventReason, at(10), at(20)),
+		// node-c is down until the end from its only poller.
+		pollerInterval("node-c", "node-c", monitorapi.NewConnectionType, monitorapi.DisruptionBeganEventReason, at(25), time.Time{}),
+		// other backends are ignored.
+		monitorapi.NewInterval(monitorapi.SourceDisruption, monitorapi.Error).
+			Locator(monitorapi.NewLocator().LocateDisruptionCheck("host-to-host-new-connections", "node-c", monitorapi.NewConnectionType)).
+			Message(monitorapi.NewMessage().Reason(monitorapi.DisruptionBeganEventReason).HumanMessage("sampled")).
+			Build(at(0), end),
+	}
+
+	unavailable := kubeletUnavailableIntervals(intervals, end)
+	require.Len(t, unavailable, 2)
+	assert.Equal(t, "node-a", unavailable[0].Locator.Keys[monitorapi.LocatorNodeKey])
+	assert.Equal(t, at(2), unavailable[0].From)
+	assert.Equal(t, at(5), unavailable[0].To)
+	assert.Equal(t, "kubelet API did not respond to 2 of 3 pollers", unavailable[0].Message.HumanMessage)
+	assert.Equal(t, "node-c", unavailable[1].Locator.Keys[monitorapi.LocatorNodeKey])
+	assert.Equal(t, at(25), unavailable[1].From)
+	assert.Equal(t, end, unavailable[1].To)
+}
@@ -0,0 +1,242 @@
+package disruptionkubelet
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openshift/library-go/pkg/operator/resource/resourceread"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	monitorserialization "github.com/openshift/origin/pkg/monitor/serialization"
+	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/monitortests/network/disruptionpodnetwork"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	exutil "github.com/openshift/origin/test/extended/util"
+)
+
+var (
+	//go:embed *.yaml
+	yamls embed.FS
+
+	namespace                *corev1.Namespace
+	pollerRoleBinding        *rbacv1.RoleBinding
+	pollerClusterRoleBinding *rbacv1.ClusterRoleBinding
+	pollerDeployment         *appsv1.Deployment
+)
+
+func yamlOrDie(name string) []byte {
+	ret, err := yamls.ReadFile(name)
+	if err != nil {
+		panic(err)
+	}
+
+	return ret
+}
+
+func init() {
+	namespace = resourceread.ReadNamespaceV1OrDie(yamlOrDie("namespace.yaml"))
+	pollerRoleBinding = resourceread.ReadRoleBindingV1OrDie(yamlOrDie("poller-rolebinding.yaml"))
+	pollerClusterRoleBinding = resourceread.ReadClusterRoleBindingV1OrDie(yamlOrDie("poller-clusterrolebinding.yaml"))
+	pollerDeployment = resourceread.ReadDeploymentV1OrDie(yamlOrDie("poller-deployment.yaml"))
+}
+
+// kubeletAvailability polls the kubelet API of every node from a poller on every node, so that node update analysis
+// can tell a kubelet that is down from a container runtime that is wedged while the kubelet still serves.
+type kubeletAvailability struct {
+	payloadImagePullSpec   string
+	notSupportedReason     error
+	namespaceName          string
+	clusterRoleBindingName string
+	kubeClient             kubernetes.Interface
+}
+
+func NewKubeletAvailabilityInvariant(info monitortestframework.MonitorTestInitializationInfo) monitortestframework.MonitorTest {
+	return &kubeletAvailability{
+		payloadImagePullSpec: info.UpgradeTargetPayloadImagePullSpec,
+	}
+}
+
+func (w *kubeletAvailability) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	var err error
+	w.kubeClient, err = kubernetes.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return err
+	}
+	isMicroShift, err := exutil.IsMicroShiftCluster(w.kubeClient)
+	if err != nil {
+		return fmt.Errorf("unable to determine if cluster is MicroShift: %v", err)
+	}
+	if isMicroShift {
+		w.notSupportedReason = &monitortestframework.NotSupportedError{Reason: "platform MicroShift not supported"}
+		return w.notSupportedReason
+	}
+
+	openshiftTestsImagePullSpec, err := disruptionpodnetwork.GetOpenshiftTestsImagePullSpec(ctx, adminRESTConfig, w.payloadImagePullSpec, nil)
+	if err != nil {
+		w.notSupportedReason = &monitortestframework.NotSupportedError{Reason: fmt.Sprintf("unable to determine openshift-tests image: %v", err)}
+		return w.notSupportedReason
+	}
+
+	actualNamespace, err := w.kubeClient.CoreV1().Namespaces().Create(ctx, namespace, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+	w.namespaceName = actualNamespace.Name
+
+	if _, err := w.kubeClient.RbacV1().RoleBindings(w.namespaceName).Create(ctx, pollerRoleBinding, metav1.CreateOptions{}); err != nil {
+		return err
+	}
+	// the pollers follow the nodes to poll every kubelet.
+	clusterRoleBinding := pollerClusterRoleBinding.DeepCopy()
+	clusterRoleBinding.Subjects[0].Namespace = w.namespaceName
+	actualClusterRoleBinding, err := w.kubeClient.RbacV1().ClusterRoleBindings().Create(ctx, clusterRoleBinding, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+	w.clusterRoleBindingName = actualClusterRoleBinding.Name
+
+	// our pods tolerate masters, so create one for each of them.
+	nodes, err := w.kubeClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	numNodes := int32(len(nodes.Items))
+
+	deployment := pollerDeployment.DeepCopy()
+	deployment.Spec.Replicas = &numNodes
+	deployment.Spec.Template.Spec.Containers[0].Image = openshiftTestsImagePullSpec
+	for i, env := range deployment.Spec.Template.Spec.Containers[0].Env {
+		if env.Name == "DEPLOYMENT_ID" {
+			deployment.Spec.Template.Spec.Containers[0].Env[i].Value = uuid.New().String()
+		}
+	}
+	if _, err := w.kubeClient.AppsV1().Deployments(w.namespaceName).Create(ctx, deployment, metav1.CreateOptions{}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (w *kubeletAvailability) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	if w.notSupportedReason != nil {
+		return nil, nil, w.notSupportedReason
+	}
+
+	// create the stop collecting configmap and wait for 30s to thing to have stopped.  the 30s is just a guess
+	if _, err := w.kubeClient.CoreV1().ConfigMaps(w.namespaceName).Create(ctx, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "stop-collecting"},
+	}, metav1.CreateOptions{}); err != nil {
+		return nil, nil, err
+	}
+
+	select {
+	case <-time.After(30 * time.Second):
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	}
+
+	pollerPods, err := w.kubeClient.CoreV1().Pods(w.namespaceName).List(ctx, metav1.ListOptions{
+		LabelSelector: "node.openshift.io/disruption-actor=poller",
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	retIntervals := monitorapi.Intervals{}
+	errs := []error{}
+	buf := &bytes.Buffer{}
+	podsWithoutIntervals := []string{}
+	for _, pollerPod := range pollerPods.Items {
+		fmt.Fprintf(buf, "\n\nLogs for -n %v pod/%v\n", pollerPod.Namespace, pollerPod.Name)
+		logStream, err := w.kubeClient.CoreV1().Pods(w.namespaceName).GetLogs(pollerPod.Name, &corev1.PodLogOptions{}).Stream(ctx)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		foundInterval := false
+		scanner := bufio.NewScanner(logStream)
+		for scanner.Scan() {
+			line := scanner.Bytes()
+			buf.Write(line)
+			buf.Write([]byte("\n"))
+			if len(line) == 0 {
+				continue
+			}
+
+			// not all lines are json, ignore errors.
+			if currInterval, err := monitorserialization.IntervalFromJSON(line); err == nil {
+				retIntervals = append(retIntervals, *currInterval)
+				foundInterval = true
+			}
+		}
+		logStream.Close()
+		if !foundInterval {
+			podsWithoutIntervals = append(podsWithoutIntervals, pollerPod.Name)
+		}
+	}
+
+	failures := []string{}
+	if len(podsWithoutIntervals) > 0 {
+		failures = append(failures, fmt.Sprintf("%d pods lacked sampler output: [%v]", len(podsWithoutIntervals), strings.Join(podsWithoutIntervals, ", ")))
+	}
+	if len(pollerPods.Items) == 0 {
+		failures = append(failures, "no pods found for the kubelet poller")
+	}
+	logJunit := &junitapi.JUnitTestCase{
+		Name:      "[sig-node] can collect kubelet poller pod logs",
+		SystemOut: buf.String(),
+	}
+	if len(failures) > 0 {
+		logJunit.FailureOutput = &junitapi.FailureOutput{
+			Output: strings.Join(failures, "\n"),
+		}
+	}
+
+	return retIntervals, []*junitapi.JUnitTestCase{logJunit}, utilerrors.NewAggregate(errs)
+}
+
+func (w *kubeletAvailability) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	if w.notSupportedReason != nil {
+		return nil, w.notSupportedReason
+	}
+	return kubeletUnavailableIntervals(startingIntervals, end), nil
+}
+
+func (w *kubeletAvailability) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	return nil, w.notSupportedReason
+}
+
+func (w *kubeletAvailability) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	return w.notSupportedReason
+}
+
+func (w *kubeletAvailability) Cleanup(ctx context.Context) error {
+	if w.kubeClient == nil {
+		return nil
+	}
+	errs := []error{}
+	if len(w.clusterRoleBindingName) > 0 {
+		if err := w.kubeClient.RbacV1().ClusterRoleBindings().Delete(ctx, w.clusterRoleBindingName, metav1.DeleteOptions{}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(w.namespaceName) > 0 {
+		if err := w.kubeClient.CoreV1().Namespaces().Delete(ctx, w.namespaceName, metav1.DeleteOptions{}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
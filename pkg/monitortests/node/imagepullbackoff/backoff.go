@@ -0,0 +1,207 @@
+package imagepullbackoff
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/distribution/reference"
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+)
+
+const (
+	clusterRegistryTestName  = "[sig-node] containers should not loop in ImagePullBackOff pulling from registries of the cluster"
+	externalRegistryTestName = "[sig-node] containers should not loop in ImagePullBackOff pulling from external registries"
+
+	// loopThreshold is the number of back-offs pulling the same image for the same pod that make a loop.  A single
+	// back-off happens when a registry hiccups, the kubelet backs off longer each time after that.
+	loopThreshold = 3
+)
+
+var (
+	backOffRegex    = regexp.MustCompile(`^Back-off pulling image "([^"]+)"`)
+	failedPullRegex = regexp.MustCompile(`^Failed to pull image "([^"]+)": (.*)`)
+)
+
+// pullLoop is a pod backing off pulling one image.
+type pullLoop struct {
+	namespace string
+	pod       string
+	image     string
+	registry  string
+	backOffs  int
+	from      time.Time
+	to        time.Time
+	// lastError is the error of the last failed pull of the image by the pod, when there was one.
+	lastError string
+}
+
+func (l *pullLoop) clusterRegistry() bool {
+	return isClusterRegistry(l.registry)
+}
+
+// registryHost returns the registry an image reference pulls from, docker.io when it has none.
+func registryHost(image string) string {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return "unknown"
+	}
+	return reference.Domain(named)
+}
+
+// isClusterRegistry is true for registries served by the cluster, like the image registry service, whose pull failures
+// are problems of the cluster rather than of a registry on the internet.
+func isClusterRegistry(registry string) bool {
+	host := registry
+	if i := strings.LastIndex(host, ":"); i >= 0 {
+		host = host[:i]
+	}
+	return host == "localhost" || strings.HasSuffix(host, ".svc") || strings.HasSuffix(host, ".cluster.local")
+}
+
+// imagePullLoops finds the pods that backed off pulling an image at least loopThreshold times in the kube events.
+func imagePullLoops(events monitorapi.Intervals) []*pullLoop {
+	loops := map[string]*pullLoop{}
+	lastErrors := map[string]string{}
+	for _, event := range events {
+		if event.Source != monitorapi.SourceKubeEvent {
+			continue
+		}
+		namespace := event.Locator.Keys[monitorapi.LocatorNamespaceKey]
+		pod := event.Locator.Keys[monitorapi.LocatorPodKey]
+		if len(pod) == 0 {
+			continue
+		}
+		if matches := failedPullRegex.FindStringSubmatch(event.Message.HumanMessage); matches != nil {
+			lastErrors[namespace+"/"+pod+"/"+matches[1]] = matches[2]
+			continue
+		}
+		matches := backOffRegex.FindStringSubmatch(event.Message.HumanMessage)
+		if matches == nil {
+			continue
+		}
+		key := namespace + "/" + pod + "/" + matches[1]
+		loop, ok := loops[key]
+		if !ok {
+			loop = &pullLoop{namespace: namespace, pod: pod, image: matches[1], registry: registryHost(matches[1]), from: event.From}
+			loops[key] = loop
+		}
+		count := 1
+		if annotated, err := strconv.Atoi(event.Message.Annotations[monitorapi.AnnotationCount]); err == nil {
+			count = annotated
+		}
+		// repeats of an event carry the total count, not the count since the last repeat.
+		loop.backOffs = max(loop.backOffs, count)
+		if first, err := time.Parse(time.RFC3339, event.Message.Annotations["firstTimestamp"]); err == nil && first.Before(loop.from) {
+			loop.from = first
+		}
+		if event.From.Before(loop.from) {
+			loop.from = event.From
+		}
+		if event.To.After(loop.to) {
+			loop.to = event.To
+		}
+	}
+
+	ret := []*pullLoop{}
+	for key, loop := range loops {
+		if loop.backOffs < loopThreshold {
+			continue
+		}
+		loop.lastError = lastErrors[key]
+		ret = append(ret, loop)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if !ret[i].from.Equal(ret[j].from) {
+			return ret[i].from.Before(ret[j].from)
+		}
+		return ret[i].namespace+"/"+ret[i].pod < ret[j].namespace+"/"+ret[j].pod
+	})
+	return ret
+}
+
+func describeRegistry(registry string) string {
+	if isClusterRegistry(registry) {
+		return "cluster registry " + registry
+	}
+	return "external registry " + registry
+}
+
+func pullLoopIntervals(loops []*pullLoop) monitorapi.Intervals {
+	ret := monitorapi.Intervals{}
+	for _, loop := range loops {
+		message := monitorapi.NewMessage().Reason(monitorapi.ImagePullBackOffLoopReason).
+			WithAnnotation(monitorapi.AnnotationImage, loop.image).
+			WithAnnotation(monitorapi.AnnotationRegistry, loop.registry).
+			WithAnnotation(monitorapi.AnnotationCount, strconv.Itoa(loop.backOffs))
+		if len(loop.lastError) > 0 {
+			message.HumanMessagef("backed off pulling from %s %d times: %s", describeRegistry(loop.registry), loop.backOffs, loop.lastError)
+		} else {
+			message.HumanMessagef("backed off pulling from %s %d times", describeRegistry(loop.registry), loop.backOffs)
+		}
+		ret = append(ret, monitorapi.NewInterval(monitorapi.SourceImagePullBackOff, monitorapi.Warning).
+			Locator(monitorapi.NewLocator().PodFromNames(loop.namespace, loop.pod, "")).
+			Message(message).
+			Display().
+			Build(loop.from, loop.to))
+	}
+	return ret
+}
+
+// pullLoopJUnits aggregates the loops per registry.  Loops pulling from the registries of the cluster are problems of
+// the cluster, those pulling from external registries are most likely problems of the registry, which is why the
+// tests are separate.  Tests pull images that do not exist on purpose, so both only flake.
+func pullLoopJUnits(intervals monitorapi.Intervals) []*junitapi.JUnitTestCase {
+	type registryLoops struct {
+		pods      int
+		backOffs  int
+		lastError string
+	}
+	clusterRegistries, externalRegistries := map[string]*registryLoops{}, map[string]*registryLoops{}
+	for _, interval := range intervals {
+		if interval.Source != monitorapi.SourceImagePullBackOff {
+			continue
+		}
+		registry := interval.Message.Annotations[monitorapi.AnnotationRegistry]
+		registries := externalRegistries
+		if isClusterRegistry(registry) {
+			registries = clusterRegistries
+		}
+		if _, ok := registries[registry]; !ok {
+			registries[registry] = &registryLoops{}
+		}
+		backOffs, _ := strconv.Atoi(interval.Message.Annotations[monitorapi.AnnotationCount])
+		registries[registry].pods++
+		registries[registry].backOffs += backOffs
+		registries[registry].lastError = interval.Message.HumanMessage
+	}
+
+	ret := []*junitapi.JUnitTestCase{}
+	for _, test := range []struct {
+		name       string
+		registries map[string]*registryLoops
+	}{
+		{name: clusterRegistryTestName, registries: clusterRegistries},
+		{name: externalRegistryTestName, registries: externalRegistries},
+	} {
+		if len(test.registries) > 0 {
+			lines := []string{}
+			for registry, loops := range test.registries {
+				lines = append(lines, fmt.Sprintf("%s: %d pods backed off %d times, last: %s", registry, loops.pods, loops.backOffs, loops.lastError))
+			}
+			sort.Strings(lines)
+			ret = append(ret, &junitapi.JUnitTestCase{
+				Name: test.name,
+				FailureOutput: &junitapi.FailureOutput{
+					Output: fmt.Sprintf("pods looped in ImagePullBackOff pulling from %d registries:\n\n%s", len(lines), strings.Join(lines, "\n")),
+				},
+			})
+		}
+		ret = append(ret, &junitapi.JUnitTestCase{Name: test.name})
+	}
+	return ret
+}
@@ -0,0 +1,72 @@
+package imagepullbackoff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func podEvent(namespace, pod, reason, message string, count string, at time.Time) monitorapi.Interval {
+	builder := monitorapi.NewMessage().Reason(monitorapi.IntervalReason(reason)).HumanMessage(message)
+	if len(count) > 0 {
+		builder = builder.WithAnnotation(monitorapi.AnnotationCount, count)
+	}
+	return monitorapi.NewInterval(monitorapi.SourceKubeEvent, monitorapi.Warning).
+		Locator(monitorapi.NewLocator().PodFromNames(namespace, pod, "")).
+		Message(builder).
+		Build(at, at)
+}
+
+func TestRegistryHost(t *testing.T) {
+	for image, expected := range map[string]string{
+		"quay.io/openshift/origin-cli:latest":                                  "quay.io",
+		"image-registry.openshift-image-registry.svc:5000/e2e-test/app:latest": "image-registry.openshift-image-registry.svc:5000",
+		"busybox": "docker.io",
+		"":        "unknown",
+	} {
+		assert.Equal(t, expected, registryHost(image), image)
+	}
+	assert.True(t, isClusterRegistry("image-registry.openshift-image-registry.svc:5000"))
+	assert.True(t, isClusterRegistry("localhost:5000"))
+	assert.False(t, isClusterRegistry("quay.io"))
+}
+
+func TestImagePullLoops(t *testing.T) {
+	at := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	events := monitorapi.Intervals{
+		podEvent("openshift-console", "console-1", "Failed", `Failed to pull image "quay.io/openshift/console:4.16": rpc error: code = Unknown desc = received unexpected HTTP status: 502 Bad Gateway`, "", at),
+		podEvent("openshift-console", "console-1", "BackOff", `Back-off pulling image "quay.io/openshift/console:4.16"`, "2", at.Add(time.Minute)),
+		podEvent("openshift-console", "console-1", "BackOff", `Back-off pulling image "quay.io/openshift/console:4.16"`, "7", at.Add(5*time.Minute)),
+		podEvent("e2e-test-build", "app-1", "BackOff", `Back-off pulling image "image-registry.openshift-image-registry.svc:5000/e2e-test-build/app:latest"`, "4", at.Add(2*time.Minute)),
+		// a single back-off is not a loop.
+		podEvent("e2e-test-build", "app-2", "BackOff", `Back-off pulling image "quay.io/other:latest"`, "", at.Add(3*time.Minute)),
+		podEvent("e2e-test-build", "app-2", "BackOff", `Back-off restarting failed container`, "12", at.Add(3*time.Minute)),
+	}
+
+	loops := imagePullLoops(events)
+	require.Len(t, loops, 2)
+	assert.Equal(t, "console-1", loops[0].pod)
+	assert.Equal(t, 7, loops[0].backOffs)
+	assert.Equal(t, at.Add(time.Minute), loops[0].from)
+	assert.Equal(t, at.Add(5*time.Minute), loops[0].to)
+	assert.Contains(t, loops[0].lastError, "502 Bad Gateway")
+	assert.True(t, loops[1].clusterRegistry())
+
+	intervals := pullLoopIntervals(loops)
+	require.Len(t, intervals, 2)
+	assert.Equal(t, "quay.io", intervals[0].Message.Annotations[monitorapi.AnnotationRegistry])
+	assert.Contains(t, intervals[0].Message.HumanMessage, "backed off pulling from external registry quay.io 7 times: rpc error")
+
+	junits := pullLoopJUnits(intervals)
+	require.Len(t, junits, 4)
+	assert.Equal(t, clusterRegistryTestName, junits[0].Name)
+	require.NotNil(t, junits[0].FailureOutput)
+	assert.Contains(t, junits[0].FailureOutput.Output, "image-registry.openshift-image-registry.svc:5000: 1 pods backed off 4 times")
+	assert.Nil(t, junits[1].FailureOutput)
+	require.NotNil(t, junits[2].FailureOutput)
+	assert.Contains(t, junits[2].FailureOutput.Output, "quay.io: 1 pods backed off 7 times")
+	assert.Nil(t, junits[3].FailureOutput)
+}
@@ -0,0 +1,51 @@
+package imagepullbackoff
+
+import (
+	"context"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	"k8s.io/client-go/rest"
+)
+
+type imagePullBackOffAnalyzer struct {
+}
+
+// NewAnalyzer returns a monitor test that finds the pods looping in ImagePullBackOff in the kube events and attributes
+// them to the registry of their image, to tell problems of the cluster from problems of an external registry.
+func NewAnalyzer() monitortestframework.MonitorTest {
+	return &imagePullBackOffAnalyzer{}
+}
+
+func (*imagePullBackOffAnalyzer) Describe() monitortestframework.MonitorTestDescription {
+	// the events are recorded by the event-collector monitor test.
+	return monitortestframework.MonitorTestDescription{
+		JUnits: []string{clusterRegistryTestName, externalRegistryTestName},
+	}
+}
+
+func (*imagePullBackOffAnalyzer) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	return nil
+}
+
+func (*imagePullBackOffAnalyzer) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	return nil, nil, nil
+}
+
+func (*imagePullBackOffAnalyzer) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	return pullLoopIntervals(imagePullLoops(startingIntervals)), nil
+}
+
+func (*imagePullBackOffAnalyzer) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	return pullLoopJUnits(finalIntervals), nil
+}
+
+func (*imagePullBackOffAnalyzer) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	return nil
+}
+
+func (*imagePullBackOffAnalyzer) Cleanup(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,142 @@
+package kubeletlogcollector
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+const (
+	// systemd logs these in the journal of the crio unit when it stops and starts the runtime.
+	crioStopping = "Stopping Container Runtime Interface for OCI (CRI-O)"
+	crioStarted  = "Started Container Runtime Interface for OCI (CRI-O)"
+	// crioExited is logged by systemd when the runtime exits on its own, followed by how.
+	crioExited = "crio.service: Main process exited"
+)
+
+// crioMessageRE extracts the message of a CRI-O log line, which is quoted and escaped.
+var crioMessageRE = regexp.MustCompile(`msg="((?:[^"\\]|\\.)*)"`)
+
+// intervalsFromCRIOLogs returns the restarts of the container runtime and the image layer errors it logged.  Any
+// errors during this creation are logged, but not returned because this is a best effort step
+func intervalsFromCRIOLogs(nodeName string, crioLog []byte) monitorapi.Intervals {
+	nodeLocator := monitorapi.NewLocator().NodeFromName(nodeName)
+	ret := monitorapi.Intervals{}
+
+	// restart is the line the runtime went down with, until it is started again.  The first start of the journal is
+	// the boot of the node, not a restart.
+	var restart string
+	var restartFrom time.Time
+	scanner := bufio.NewScanner(bytes.NewBuffer(crioLog))
+	for scanner.Scan() {
+		currLine := scanner.Text()
+		switch {
+		case strings.Contains(currLine, crioExited):
+			// a crash is more telling than the stop that may follow, when systemd restarts the unit.
+			restart, restartFrom = currLine, systemdJournalLogTime(currLine)
+		case strings.Contains(currLine, crioStopping):
+			if len(restart) == 0 {
+				restart, restartFrom = currLine, systemdJournalLogTime(currLine)
+			}
+		case strings.Contains(currLine, crioStarted):
+			if len(restart) > 0 {
+				ret = append(ret, crioRestart(nodeLocator, restart, restartFrom, systemdJournalLogTime(currLine)))
+			}
+			restart = ""
+		default:
+			ret = append(ret, imageLayerError(nodeLocator, currLine)...)
+		}
+	}
+	// the runtime was still down when the logs were collected.
+	if len(restart) > 0 {
+		ret = append(ret, crioRestart(nodeLocator, restart, restartFrom, time.Now()))
+	}
+
+	return ret
+}
+
+// crioRestart is an Error when the runtime exited on its own, a Warning when it was stopped, during a reboot for
+// instance.
+//
+// Apr 12 11:53:51.395838 ci-op-xs3rnrtc-2d4c7-4mhm7-worker-b-dwc7w systemd[1]: crio.service: Main process exited, code=killed, status=6/ABRT
+func crioRestart(nodeLocator monitorapi.Locator, logLine string, from, to time.Time) monitorapi.Interval {
+	level := monitorapi.Warning
+	cause := "Stopped"
+	if strings.Contains(logLine, crioExited) {
+		level = monitorapi.Error
+		cause = "Exited"
+	}
+	message := logLine
+	if i := strings.Index(logLine, "systemd["); i >= 0 {
+		message = logLine[i:]
+	}
+	return monitorapi.NewInterval(monitorapi.SourceCRIOLog, level).
+		Locator(nodeLocator).
+		Message(monitorapi.NewMessage().Reason(monitorapi.CRIORestartReason).Cause(cause).HumanMessage(message)).
+		Display().
+		Build(from, to)
+}
+
+// imageLayerError searches for errors storing or reading the layers of an image, which leave the image unusable.
+//
+// Apr 12 11:53:51.395838 ci-op-xs3rnrtc-2d4c7-4mhm7-worker-b-dwc7w crio[2104]: time="2024-04-12 11:53:51.395608" level=error
+// msg="Error pulling image quay.io/openshift/origin-cli:latest: writing blob: adding layer with blob \"sha256:...\": layer not known"
+func imageLayerError(nodeLocator monitorapi.Locator, logLine string) monitorapi.Intervals {
+	if !strings.Contains(logLine, "level=error") || !strings.Contains(logLine, "layer") {
+		return nil
+	}
+	match := crioMessageRE.FindStringSubmatch(logLine)
+	if match == nil || !strings.Contains(match[1], "layer") {
+		return nil
+	}
+	message := match[1]
+	if unquoted, err := strconv.Unquote(`"` + message + `"`); err == nil {
+		message = unquoted
+	}
+
+	logTime := systemdJournalLogTime(logLine)
+	return monitorapi.Intervals{
+		monitorapi.NewInterval(monitorapi.SourceCRIOLog, monitorapi.Error).
+			Locator(nodeLocator).
+			Message(monitorapi.NewMessage().Reason(monitorapi.CRIOImageLayerErrorReason).HumanMessage(message)).
+			Display().
+			Build(logTime, logTime.Add(1*time.Second)),
+	}
+}
+
+// RuntimeRestartGrace is how long after the container runtime started again failures are still attributed to its
+// restart, the kubelet takes a moment to reconnect.
+const RuntimeRestartGrace = 30 * time.Second
+
+// RuntimeRestarts returns the restarts of the container runtime of every node, keyed by node name.
+func RuntimeRestarts(intervals monitorapi.Intervals) map[string]monitorapi.Intervals {
+	ret := map[string]monitorapi.Intervals{}
+	for _, interval := range intervals {
+		if interval.Source != monitorapi.SourceCRIOLog || interval.Message.Reason != monitorapi.CRIORestartReason {
+			continue
+		}
+		node := interval.Locator.Keys[monitorapi.LocatorNodeKey]
+		ret[node] = append(ret[node], interval)
+	}
+	return ret
+}
+
+// DuringRuntimeRestart returns true if the interval started on a node while its container runtime was restarting,
+// or within the restart grace after.
+func DuringRuntimeRestart(restarts map[string]monitorapi.Intervals, interval monitorapi.Interval) bool {
+	node, ok := interval.Locator.Keys[monitorapi.LocatorNodeKey]
+	if !ok {
+		return false
+	}
+	for _, restart := range restarts[node] {
+		if !interval.From.Before(restart.From) && !interval.From.After(restart.To.Add(RuntimeRestartGrace)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package kubeletlogcollector
+
+import (
+	"testing"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntervalsFromCRIOLogs(t *testing.T) {
+	logs := `Apr 12 11:40:02.100000 worker-b systemd[1]: Started Container Runtime Interface for OCI (CRI-O).
+Apr 12 11:53:51.395838 worker-b systemd[1]: crio.service: Main process exited, code=killed, status=6/ABRT
+Apr 12 11:53:52.000000 worker-b systemd[1]: Stopping Container Runtime Interface for OCI (CRI-O)...
+Apr 12 11:53:56.500000 worker-b systemd[1]: Started Container Runtime Interface for OCI (CRI-O).
+Apr 12 12:01:10.250000 worker-b crio[2104]: time="2024-04-12 12:01:10.250000" level=error msg="Error pulling image quay.io/openshift/origin-cli:latest: writing blob: adding layer with blob \"sha256:abc\": layer not known"
+Apr 12 12:01:11.000000 worker-b crio[2104]: time="2024-04-12 12:01:11.000000" level=info msg="Pulled image layer sha256:abc"
+Apr 12 12:10:00.000000 worker-b systemd[1]: Stopping Container Runtime Interface for OCI (CRI-O)...
+Apr 12 12:10:03.000000 worker-b systemd[1]: Started Container Runtime Interface for OCI (CRI-O).
+`
+	intervals := intervalsFromCRIOLogs("worker-b", []byte(logs))
+	require.Len(t, intervals, 3)
+
+	crash := intervals[0]
+	assert.Equal(t, monitorapi.CRIORestartReason, crash.Message.Reason)
+	assert.Equal(t, monitorapi.Error, crash.Level)
+	assert.Equal(t, "Exited", crash.Message.Cause)
+	assert.Equal(t, systemdJournalLogTime("Apr 12 11:53:51.395838"), crash.From)
+	assert.Equal(t, systemdJournalLogTime("Apr 12 11:53:56.500000"), crash.To)
+	assert.Equal(t, "worker-b", crash.Locator.Keys[monitorapi.LocatorNodeKey])
+
+	layer := intervals[1]
+	assert.Equal(t, monitorapi.CRIOImageLayerErrorReason, layer.Message.Reason)
+	assert.Equal(t, `Error pulling image quay.io/openshift/origin-cli:latest: writing blob: adding layer with blob "sha256:abc": layer not known`, layer.Message.HumanMessage)
+
+	stop := intervals[2]
+	assert.Equal(t, monitorapi.Warning, stop.Level)
+	assert.Equal(t, "Stopped", stop.Message.Cause)
+
+	restarts := RuntimeRestarts(intervals)
+	require.Len(t, restarts["worker-b"], 2)
+
+	sandbox := monitorapi.Interval{
+		Condition: monitorapi.Condition{Locator: monitorapi.NewLocator().NodeFromName("worker-b")},
+		From:      crash.To.Add(RuntimeRestartGrace / 2),
+	}
+	assert.True(t, DuringRuntimeRestart(restarts, sandbox))
+	sandbox.From = crash.To.Add(2 * RuntimeRestartGrace)
+	assert.False(t, DuringRuntimeRestart(restarts, sandbox))
+}
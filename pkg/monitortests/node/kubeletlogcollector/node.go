@@ -62,11 +62,20 @@ func intervalsFromNodeLogs(ctx context.Context, kubeClient kubernetes.Interface,
 			}
 			newNetworkManagerIntervals := intervalsFromNetworkManagerLogs(nodeName, networkManagerLogs)
 
+			crioLogs, err := getNodeLog(ctx, kubeClient, nodeName, "crio")
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting node crio logs from %s: %s", nodeName, err.Error())
+				errCh <- err
+				return
+			}
+			newCRIOIntervals := intervalsFromCRIOLogs(nodeName, crioLogs)
+
 			lock.Lock()
 			defer lock.Unlock()
 			ret = append(ret, newEvents...)
 			ret = append(ret, newOVSEvents...)
 			ret = append(ret, newNetworkManagerIntervals...)
+			ret = append(ret, newCRIOIntervals...)
 		}(ctx, node.Name)
 	}
 	wg.Wait()
@@ -82,6 +91,12 @@ func intervalsFromNodeLogs(ctx context.Context, kubeClient kubernetes.Interface,
 	return ret, utilerrors.NewAggregate(errs)
 }
 
+// IntervalsFromKubeletLog returns the intervals for a kubelet journal collected outside of a monitor run, for
+// instance from a must-gather.
+func IntervalsFromKubeletLog(nodeName string, kubeletLog []byte) monitorapi.Intervals {
+	return eventsFromKubeletLogs(nodeName, kubeletLog)
+}
+
 // eventsFromKubeletLogs returns the produced intervals.  Any errors during this creation are logged, but
 // not returned because this is a best effort step
 func eventsFromKubeletLogs(nodeName string, kubeletLog []byte) monitorapi.Intervals {
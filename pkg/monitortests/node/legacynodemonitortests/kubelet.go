@@ -11,6 +11,8 @@ import (
 	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
 
 	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestlibrary/nodepressure"
+	"github.com/openshift/origin/pkg/monitortestlibrary/spotnodes"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 )
@@ -223,6 +225,8 @@ func testKubeAPIServerGracefulTermination(events monitorapi.Intervals) []*junita
 func testContainerFailures(events monitorapi.Intervals) []*junitapi.JUnitTestCase {
 	containerExits := make(map[string][]string)
 	failures := []string{}
+	// containers killed while their node was under pressure are reported by the node pressure test.
+	pressureWindows := nodepressure.Windows(events)
 	for _, event := range events {
 		if !strings.Contains(event.Locator.Keys[monitorapi.LocatorNamespaceKey], "openshift-") {
 			continue
@@ -239,6 +243,9 @@ func testContainerFailures(events monitorapi.Intervals) []*junitapi.JUnitTestCas
 
 		// workload containers should never exit non-zero during normal operations
 		case reason == monitorapi.ContainerReasonContainerExit && code != "0":
+			if nodepressure.During(pressureWindows, event) {
+				continue
+			}
 			containerExits[event.Locator.OldLocator()] = append(containerExits[event.Locator.OldLocator()], event.Message.OldMessage())
 		}
 	}
@@ -381,6 +388,9 @@ func testNodeUpgradeTransitions(events monitorapi.Intervals) []*junitapi.JUnitTe
 	var buf bytes.Buffer
 	var testCases []*junitapi.JUnitTestCase
 
+	// spot nodes going away while the cloud provider reclaims them is not a failure of the upgrade.
+	spotTerminations := spotnodes.TerminationWindows(events)
+
 	for len(events) > 0 {
 		nodesWentReady, nodesWentUnready := make(map[string][]time.Time), make(map[string][]time.Time)
 		currentNodeReady := make(map[string]bool)
@@ -407,6 +417,11 @@ func testNodeUpgradeTransitions(events monitorapi.Intervals) []*junitapi.JUnitTe
 			if event.Message.Annotations[monitorapi.AnnotationCondition] != "Ready " || !strings.HasSuffix(event.Message.HumanMessage, " changed") {
 				continue
 			}
+			if spotnodes.DuringTermination(spotTerminations, node, event.From) {
+				currentNodeReady[node] = event.Message.Annotations[monitorapi.AnnotationStatus] == "True"
+				fmt.Fprintf(&buf, "DEBUG: ignoring transition while the cloud provider terminated spot node: %v\n", event.String())
+				continue
+			}
 			if event.Message.Annotations[monitorapi.AnnotationStatus] == "True" {
 				if currentNodeReady[node] {
 					failures = append(failures, fmt.Sprintf("Node %s was reported ready twice in a row, this should be impossible", node))
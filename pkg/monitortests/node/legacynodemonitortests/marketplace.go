@@ -1,15 +1,16 @@
 package legacynodemonitortests
 
 import (
+	configv1 "github.com/openshift/api/config/v1"
 	"github.com/openshift/origin/pkg/monitortestlibrary/pathologicaleventlibrary"
 	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
 
 	"github.com/openshift/origin/pkg/monitor/monitorapi"
 )
 
-func testMarketplaceStartupProbeFailure(events monitorapi.Intervals) []*junitapi.JUnitTestCase {
+func testMarketplaceStartupProbeFailure(events monitorapi.Intervals, topology configv1.TopologyMode) []*junitapi.JUnitTestCase {
 	const testName = "[sig-arch] openshift-marketplace pods should not get excessive startupProbe failures"
 	return pathologicaleventlibrary.EventExprMatchThresholdTest(testName, events,
 		pathologicaleventlibrary.MarketplaceStartupProbeFailure,
-		pathologicaleventlibrary.DuplicateEventThreshold)
+		pathologicaleventlibrary.DuplicateEventThresholdForTopology(topology))
 }
@@ -4,7 +4,11 @@ import (
 	"context"
 	"time"
 
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/sirupsen/logrus"
+
 	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/monitortestlibrary/pathologicaleventlibrary"
 
 	"github.com/openshift/origin/pkg/monitortestlibrary/platformidentification"
 
@@ -14,6 +18,7 @@ import (
 )
 
 type legacyMonitorTests struct {
+	topology configv1.TopologyMode
 }
 
 func NewLegacyTests() monitortestframework.MonitorTest {
@@ -21,6 +26,11 @@ func NewLegacyTests() monitortestframework.MonitorTest {
 }
 
 func (w *legacyMonitorTests) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	_, topology, err := pathologicaleventlibrary.GetClusterInfraInfo(adminRESTConfig)
+	if err != nil {
+		logrus.WithError(err).Error("could not fetch cluster infra info")
+	}
+	w.topology = topology
 	return nil
 }
 
@@ -54,17 +64,17 @@ func (w *legacyMonitorTests) EvaluateTestsFromConstructedIntervals(ctx context.C
 	junits = append(junits, testErrImagePullUnrecognizedSignatureFormat(finalIntervals)...)
 	junits = append(junits, testLeaseUpdateError(finalIntervals)...)
 	junits = append(junits, testSystemDTimeout(finalIntervals)...)
-	junits = append(junits, testNodeHasNoDiskPressure(finalIntervals)...)
-	junits = append(junits, testNodeHasSufficientMemory(finalIntervals)...)
-	junits = append(junits, testNodeHasSufficientPID(finalIntervals)...)
+	junits = append(junits, testNodeHasNoDiskPressure(finalIntervals, w.topology)...)
+	junits = append(junits, testNodeHasSufficientMemory(finalIntervals, w.topology)...)
+	junits = append(junits, testNodeHasSufficientPID(finalIntervals, w.topology)...)
 	junits = append(junits, testBackoffPullingRegistryRedhatImage(finalIntervals)...)
-	junits = append(junits, testBackoffStartingFailedContainer(finalIntervals)...)
-	junits = append(junits, testConfigOperatorReadinessProbe(finalIntervals)...)
-	junits = append(junits, testConfigOperatorProbeErrorReadinessProbe(finalIntervals)...)
-	junits = append(junits, testConfigOperatorProbeErrorLivenessProbe(finalIntervals)...)
+	junits = append(junits, testBackoffStartingFailedContainer(finalIntervals, w.topology)...)
+	junits = append(junits, testConfigOperatorReadinessProbe(finalIntervals, w.topology)...)
+	junits = append(junits, testConfigOperatorProbeErrorReadinessProbe(finalIntervals, w.topology)...)
+	junits = append(junits, testConfigOperatorProbeErrorLivenessProbe(finalIntervals, w.topology)...)
 	junits = append(junits, testMasterNodesUpdated(finalIntervals)...)
-	junits = append(junits, testMarketplaceStartupProbeFailure(finalIntervals)...)
-	junits = append(junits, testFailedScheduling(finalIntervals)...)
+	junits = append(junits, testMarketplaceStartupProbeFailure(finalIntervals, w.topology)...)
+	junits = append(junits, testFailedScheduling(finalIntervals, w.topology)...)
 	junits = append(junits, testBackoffStartingFailedContainerForE2ENamespaces(finalIntervals)...)
 
 	isUpgrade := platformidentification.DidUpgradeHappenDuringCollection(finalIntervals, time.Time{}, time.Time{})
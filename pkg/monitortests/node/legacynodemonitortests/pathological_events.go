@@ -3,26 +3,34 @@ package legacynodemonitortests
 import (
 	"math"
 
+	configv1 "github.com/openshift/api/config/v1"
 	"github.com/openshift/origin/pkg/monitor/monitorapi"
 	"github.com/openshift/origin/pkg/monitortestlibrary/pathologicaleventlibrary"
+	"github.com/openshift/origin/pkg/monitortestlibrary/windowsnodes"
 	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
 )
 
-func testNodeHasNoDiskPressure(events monitorapi.Intervals) []*junitapi.JUnitTestCase {
+// notOnWindowsNodes leaves out the events of Windows nodes.  The Windows Machine Config Operator restarts their kubelet
+// service whenever it reconfigures them, and each start reports the node conditions again.
+func notOnWindowsNodes(events monitorapi.Intervals) monitorapi.Intervals {
+	return events.Filter(windowsnodes.NotOnNodes(windowsnodes.Nodes(events)))
+}
+
+func testNodeHasNoDiskPressure(events monitorapi.Intervals, topology configv1.TopologyMode) []*junitapi.JUnitTestCase {
 	const testName = "[sig-node] pathological event NodeHasNoDiskPressure condition does not occur too often"
-	return pathologicaleventlibrary.EventExprMatchThresholdTest(testName, events, pathologicaleventlibrary.NodeHasNoDiskPressure,
-		pathologicaleventlibrary.DuplicateEventThreshold)
+	return pathologicaleventlibrary.EventExprMatchThresholdTest(testName, notOnWindowsNodes(events), pathologicaleventlibrary.NodeHasNoDiskPressure,
+		pathologicaleventlibrary.DuplicateEventThresholdForTopology(topology))
 }
 
-func testNodeHasSufficientMemory(events monitorapi.Intervals) []*junitapi.JUnitTestCase {
+func testNodeHasSufficientMemory(events monitorapi.Intervals, topology configv1.TopologyMode) []*junitapi.JUnitTestCase {
 	const testName = "[sig-node] pathological event NodeHasSufficeintMemory condition does not occur too often"
-	return pathologicaleventlibrary.EventExprMatchThresholdTest(testName, events, pathologicaleventlibrary.NodeHasSufficientMemory, pathologicaleventlibrary.DuplicateEventThreshold)
+	return pathologicaleventlibrary.EventExprMatchThresholdTest(testName, notOnWindowsNodes(events), pathologicaleventlibrary.NodeHasSufficientMemory, pathologicaleventlibrary.DuplicateEventThresholdForTopology(topology))
 }
 
-func testNodeHasSufficientPID(events monitorapi.Intervals) []*junitapi.JUnitTestCase {
+func testNodeHasSufficientPID(events monitorapi.Intervals, topology configv1.TopologyMode) []*junitapi.JUnitTestCase {
 	const testName = "[sig-node] pathological event NodeHasSufficientPID condition does not occur too often"
-	return pathologicaleventlibrary.EventExprMatchThresholdTest(testName, events, pathologicaleventlibrary.NodeHasSufficientPID,
-		pathologicaleventlibrary.DuplicateEventThreshold)
+	return pathologicaleventlibrary.EventExprMatchThresholdTest(testName, notOnWindowsNodes(events), pathologicaleventlibrary.NodeHasSufficientPID,
+		pathologicaleventlibrary.DuplicateEventThresholdForTopology(topology))
 }
 
 // testBackoffStartingFailedContainerForE2ENamespaces looks for this symptom in e2e namespaces:
@@ -55,30 +63,30 @@ func testBackoffPullingRegistryRedhatImage(events monitorapi.Intervals) []*junit
 // testBackoffStartingFailedContainer looks for this symptom in core namespaces:
 //
 //	reason/BackOff Back-off restarting failed container
-func testBackoffStartingFailedContainer(events monitorapi.Intervals) []*junitapi.JUnitTestCase {
+func testBackoffStartingFailedContainer(events monitorapi.Intervals, topology configv1.TopologyMode) []*junitapi.JUnitTestCase {
 	testName := "[sig-cluster-lifecycle] pathological event should not see excessive Back-off restarting failed containers"
 
 	return pathologicaleventlibrary.NewSingleEventThresholdCheck(testName, pathologicaleventlibrary.AllowBackOffRestartingFailedContainer,
-		pathologicaleventlibrary.DuplicateEventThreshold, pathologicaleventlibrary.BackoffRestartingFlakeThreshold).
+		pathologicaleventlibrary.DuplicateEventThresholdForTopology(topology), pathologicaleventlibrary.BackoffRestartingFlakeThreshold).
 		NamespacedTest(events.Filter(monitorapi.Not(monitorapi.IsInE2ENamespace)))
 }
 
-func testConfigOperatorReadinessProbe(events monitorapi.Intervals) []*junitapi.JUnitTestCase {
+func testConfigOperatorReadinessProbe(events monitorapi.Intervals, topology configv1.TopologyMode) []*junitapi.JUnitTestCase {
 	const testName = "[sig-node] pathological event openshift-config-operator readiness probe should not fail due to timeout"
-	return pathologicaleventlibrary.MakeProbeTest(testName, events, "openshift-config-operator", pathologicaleventlibrary.ProbeErrorTimeoutAwaitingHeaders, pathologicaleventlibrary.DuplicateEventThreshold)
+	return pathologicaleventlibrary.MakeProbeTest(testName, events, "openshift-config-operator", pathologicaleventlibrary.ProbeErrorTimeoutAwaitingHeaders, pathologicaleventlibrary.DuplicateEventThresholdForTopology(topology))
 }
 
-func testConfigOperatorProbeErrorReadinessProbe(events monitorapi.Intervals) []*junitapi.JUnitTestCase {
+func testConfigOperatorProbeErrorReadinessProbe(events monitorapi.Intervals, topology configv1.TopologyMode) []*junitapi.JUnitTestCase {
 	const testName = "[sig-node] pathological event openshift-config-operator should not get probe error on readiness probe due to connection refused"
-	return pathologicaleventlibrary.MakeProbeTest(testName, events, "openshift-config-operator", pathologicaleventlibrary.ProbeErrorConnectionRefused, pathologicaleventlibrary.DuplicateEventThreshold)
+	return pathologicaleventlibrary.MakeProbeTest(testName, events, "openshift-config-operator", pathologicaleventlibrary.ProbeErrorConnectionRefused, pathologicaleventlibrary.DuplicateEventThresholdForTopology(topology))
 }
 
-func testConfigOperatorProbeErrorLivenessProbe(events monitorapi.Intervals) []*junitapi.JUnitTestCase {
+func testConfigOperatorProbeErrorLivenessProbe(events monitorapi.Intervals, topology configv1.TopologyMode) []*junitapi.JUnitTestCase {
 	const testName = "[sig-node] pathological event openshift-config-operator should not get probe error on liveness probe due to timeout"
-	return pathologicaleventlibrary.MakeProbeTest(testName, events, "openshift-config-operator", pathologicaleventlibrary.ProbeErrorLiveness, pathologicaleventlibrary.DuplicateEventThreshold)
+	return pathologicaleventlibrary.MakeProbeTest(testName, events, "openshift-config-operator", pathologicaleventlibrary.ProbeErrorLiveness, pathologicaleventlibrary.DuplicateEventThresholdForTopology(topology))
 }
 
-func testFailedScheduling(events monitorapi.Intervals) []*junitapi.JUnitTestCase {
+func testFailedScheduling(events monitorapi.Intervals, topology configv1.TopologyMode) []*junitapi.JUnitTestCase {
 	const testName = "[sig-node] pathological event FailedScheduling condition does not occur too often"
-	return pathologicaleventlibrary.EventExprMatchThresholdTest(testName, events, pathologicaleventlibrary.FailedScheduling, pathologicaleventlibrary.DuplicateEventThreshold)
+	return pathologicaleventlibrary.EventExprMatchThresholdTest(testName, events, pathologicaleventlibrary.FailedScheduling, pathologicaleventlibrary.DuplicateEventThresholdForTopology(topology))
 }
@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	configv1 "github.com/openshift/api/config/v1"
 	"github.com/openshift/origin/pkg/monitor/monitorapi"
 	"github.com/openshift/origin/pkg/monitortestlibrary/pathologicaleventlibrary"
 	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
@@ -112,7 +113,7 @@ func Test_testBackoffStartingFailedContainer(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			e := monitorapi.Intervals{tt.interval}
-			junits := testBackoffStartingFailedContainer(e)
+			junits := testBackoffStartingFailedContainer(e, "")
 
 			// Find the junit with the namespace of openshift-etcd-operator int the testname
 			var testJunits []*junitapi.JUnitTestCase
@@ -155,6 +156,7 @@ func Test_testFailedScheduling(t *testing.T) {
 	tests := []struct {
 		name     string
 		interval monitorapi.Interval
+		topology configv1.TopologyMode
 		kind     string
 	}{
 		{
@@ -181,11 +183,20 @@ func Test_testFailedScheduling(t *testing.T) {
 				21),
 			kind: "flake",
 		},
+		{
+			name: "event count over threshold should pass on a single node cluster",
+			interval: pathologicaleventlibrary.BuildTestDupeKubeEvent("", "",
+				"FailedScheduling",
+				"0/1 nodes are available: 1 node(s) didn't match pod anti-affinity rules. preemption: 0/1 nodes are available: 1 No preemption victims found for incoming pod..",
+				24),
+			topology: configv1.SingleReplicaTopologyMode,
+			kind:     "pass",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			e := monitorapi.Intervals{tt.interval}
-			junitTests := testFailedScheduling(e)
+			junitTests := testFailedScheduling(e, tt.topology)
 			switch tt.kind {
 			case "pass":
 				assert.Equal(t, 1, len(junitTests), "This should've been a single passing Test")
@@ -0,0 +1,165 @@
+package nodeinitialization
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestlibrary/windowsnodes"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	nodeReadyTestName       = "[sig-cloud-provider] nodes should become Ready within the platform budget after their machine is created"
+	nodeInitializedTestName = "[sig-cloud-provider] nodes should be initialized by the cloud provider within the platform budget after their machine is created"
+
+	// uninitializedTaint is set by the kubelet on nodes of external cloud providers until the cloud-controller-manager
+	// initializes them.
+	uninitializedTaint = "node.cloudprovider.kubernetes.io/uninitialized"
+)
+
+// nodeInitializationBudgets bound the time from the creation of a machine to its node being Ready and initialized,
+// a few times what it usually takes on each platform.  Instances are slower to clone on vSphere and OpenStack.
+var nodeInitializationBudgets = map[configv1.PlatformType]time.Duration{
+	configv1.AWSPlatformType:       15 * time.Minute,
+	configv1.GCPPlatformType:       15 * time.Minute,
+	configv1.AzurePlatformType:     20 * time.Minute,
+	configv1.VSpherePlatformType:   30 * time.Minute,
+	configv1.OpenStackPlatformType: 30 * time.Minute,
+}
+
+const defaultNodeInitializationBudget = 30 * time.Minute
+
+func nodeInitializationBudget(platform configv1.PlatformType) time.Duration {
+	if budget, ok := nodeInitializationBudgets[platform]; ok {
+		return budget
+	}
+	return defaultNodeInitializationBudget
+}
+
+// nodeInitialization is when a node was first seen Ready and initialized by the cloud provider.
+type nodeInitialization struct {
+	ready time.Time
+	// sawUninitialized is set once the node was seen with the uninitialized taint, nodes of platforms without an
+	// external cloud provider never have it.
+	sawUninitialized bool
+	initialized      time.Time
+	windows          bool
+}
+
+func (n *nodeInitialization) observe(node *corev1.Node, now time.Time) {
+	n.windows = windowsnodes.IsWindows(node)
+	if n.ready.IsZero() {
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
+				n.ready = condition.LastTransitionTime.Time
+			}
+		}
+	}
+	uninitialized := false
+	for _, taint := range node.Spec.Taints {
+		if taint.Key == uninitializedTaint {
+			uninitialized = true
+		}
+	}
+	switch {
+	case uninitialized:
+		n.sawUninitialized = true
+	case n.sawUninitialized && n.initialized.IsZero():
+		n.initialized = now
+	}
+}
+
+// initializationIntervals measures the nodes of the machines created during the run, from the creation of the
+// machine to the node being Ready and initialized.  Nodes that never got there are measured until the end.
+func initializationIntervals(machines []unstructured.Unstructured, nodes map[string]*nodeInitialization, beginning, end time.Time) monitorapi.Intervals {
+	ret := monitorapi.Intervals{}
+	for _, machine := range machines {
+		created := machine.GetCreationTimestamp().Time
+		if created.Before(beginning) || created.After(end) {
+			continue
+		}
+		nodeName, _, _ := unstructured.NestedString(machine.Object, "status", "nodeRef", "name")
+		node, ok := nodes[nodeName]
+		if len(nodeName) == 0 || !ok {
+			ret = append(ret, monitorapi.NewInterval(monitorapi.SourceNodeInitialization, monitorapi.Warning).
+				Locator(monitorapi.NewLocator().ForGVR(machinesResource, machine.GetNamespace(), machine.GetName())).
+				Message(monitorapi.NewMessage().Reason(monitorapi.NodeReadyAfterMachineCreatedReason).
+					HumanMessage("machine had no node by the end of the run")).
+				Display().
+				Build(created, end))
+			continue
+		}
+
+		measure := func(reason monitorapi.IntervalReason, what string, at time.Time) monitorapi.Interval {
+			level := monitorapi.Info
+			message := monitorapi.NewMessage().Reason(reason)
+			if node.windows {
+				message.WithAnnotation(monitorapi.AnnotationOS, windowsnodes.Windows)
+			}
+			if at.IsZero() {
+				level = monitorapi.Warning
+				at = end
+				message.HumanMessagef("node of machine/%s was not %s by the end of the run", machine.GetName(), what)
+			} else {
+				message.HumanMessagef("node of machine/%s was %s %s after the machine was created", machine.GetName(), what, at.Sub(created).Round(time.Second))
+			}
+			return monitorapi.NewInterval(monitorapi.SourceNodeInitialization, level).
+				Locator(monitorapi.NewLocator().NodeFromName(nodeName)).
+				Message(message).
+				Display().
+				Build(created, at)
+		}
+		ret = append(ret, measure(monitorapi.NodeReadyAfterMachineCreatedReason, "Ready", node.ready))
+		if node.sawUninitialized {
+			ret = append(ret, measure(monitorapi.NodeInitializedAfterMachineCreatedReason, "initialized", node.initialized))
+		}
+	}
+	sort.Sort(ret)
+	return ret
+}
+
+// initializationBudgetJUnits reports the nodes that took longer than the budget of the platform, extended by
+// windowsnodes.BootGrace for Windows nodes.  The time depends on the cloud provider as much as on the cluster, so
+// exceeding the budget only flakes.
+func initializationBudgetJUnits(intervals monitorapi.Intervals, platform configv1.PlatformType) []*junitapi.JUnitTestCase {
+	budget := nodeInitializationBudget(platform)
+	ret := []*junitapi.JUnitTestCase{}
+	for _, test := range []struct {
+		name   string
+		reason monitorapi.IntervalReason
+	}{
+		{name: nodeReadyTestName, reason: monitorapi.NodeReadyAfterMachineCreatedReason},
+		{name: nodeInitializedTestName, reason: monitorapi.NodeInitializedAfterMachineCreatedReason},
+	} {
+		overBudget := []string{}
+		for _, interval := range intervals {
+			if interval.Source != monitorapi.SourceNodeInitialization || interval.Message.Reason != test.reason {
+				continue
+			}
+			nodeBudget := budget
+			if windowsnodes.IsWindowsInterval(interval) {
+				nodeBudget += windowsnodes.BootGrace
+			}
+			if interval.To.Sub(interval.From) > nodeBudget {
+				overBudget = append(overBudget, fmt.Sprintf("%s %s", interval.Locator.OldLocator(), interval.Message.HumanMessage))
+			}
+		}
+		if len(overBudget) > 0 {
+			ret = append(ret, &junitapi.JUnitTestCase{
+				Name: test.name,
+				FailureOutput: &junitapi.FailureOutput{
+					Output: fmt.Sprintf("%d nodes took longer than the %s budget of platform %q, %s more for Windows nodes:\n\n%s",
+						len(overBudget), budget, platform, windowsnodes.BootGrace, strings.Join(overBudget, "\n")),
+				},
+			})
+		}
+		ret = append(ret, &junitapi.JUnitTestCase{Name: test.name})
+	}
+	return ret
+}
@@ -0,0 +1,106 @@
+package nodeinitialization
+
+import (
+	"testing"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestInitializationIntervals(t *testing.T) {
+	beginning := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	end := beginning.Add(time.Hour)
+
+	machine := func(name, nodeName string, created time.Time) unstructured.Unstructured {
+		machine := unstructured.Unstructured{Object: map[string]interface{}{
+			"metadata": map[string]interface{}{"namespace": "openshift-machine-api", "name": name},
+		}}
+		machine.SetCreationTimestamp(metav1.NewTime(created))
+		if len(nodeName) > 0 {
+			require.NoError(t, unstructured.SetNestedField(machine.Object, nodeName, "status", "nodeRef", "name"))
+		}
+		return machine
+	}
+
+	fast := &nodeInitialization{}
+	fast.observe(&corev1.Node{Spec: corev1.NodeSpec{Taints: []corev1.Taint{{Key: uninitializedTaint}}}}, beginning.Add(12*time.Minute))
+	fast.observe(&corev1.Node{Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+		{Type: corev1.NodeReady, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(beginning.Add(15 * time.Minute))},
+	}}}, beginning.Add(14*time.Minute))
+	assert.Equal(t, beginning.Add(14*time.Minute), fast.initialized)
+	assert.Equal(t, beginning.Add(15*time.Minute), fast.ready)
+
+	stuck := &nodeInitialization{}
+	stuck.observe(&corev1.Node{Spec: corev1.NodeSpec{Taints: []corev1.Taint{{Key: uninitializedTaint}}}}, beginning.Add(30*time.Minute))
+
+	intervals := initializationIntervals([]unstructured.Unstructured{
+		machine("worker-a", "node-a", beginning.Add(10*time.Minute)),
+		machine("worker-b", "node-b", beginning.Add(20*time.Minute)),
+		machine("worker-c", "", beginning.Add(50*time.Minute)),
+		machine("installed", "node-d", beginning.Add(-time.Hour)),
+	}, map[string]*nodeInitialization{"node-a": fast, "node-b": stuck}, beginning, end)
+	require.Len(t, intervals, 5, "ready and initialized per node, and a machine without a node")
+
+	byLocator := map[string]monitorapi.Interval{}
+	for _, interval := range intervals {
+		byLocator[interval.Locator.OldLocator()+" "+string(interval.Message.Reason)] = interval
+	}
+	ready := byLocator["node/node-a NodeReadyAfterMachineCreated"]
+	assert.Equal(t, "node of machine/worker-a was Ready 5m0s after the machine was created", ready.Message.HumanMessage)
+	assert.Equal(t, monitorapi.Warning, byLocator["node/node-b NodeInitializedAfterMachineCreated"].Level)
+	assert.Equal(t, end, byLocator["node/node-b NodeReadyAfterMachineCreated"].To)
+
+	junits := initializationBudgetJUnits(intervals, configv1.AWSPlatformType)
+	require.Len(t, junits, 4)
+	require.NotNil(t, junits[0].FailureOutput)
+	assert.Contains(t, junits[0].FailureOutput.Output, "node/node-b")
+	assert.NotContains(t, junits[0].FailureOutput.Output, "node/node-a")
+	assert.NotContains(t, junits[0].FailureOutput.Output, "worker-c", "10 minutes without a node is within the budget")
+	require.NotNil(t, junits[2].FailureOutput)
+	assert.Equal(t, nodeInitializedTestName, junits[2].Name)
+	assert.Nil(t, junits[3].FailureOutput)
+
+	assert.Equal(t, 30*time.Minute, nodeInitializationBudget(configv1.VSpherePlatformType), "vSphere is slower to clone instances")
+	assert.Equal(t, defaultNodeInitializationBudget, nodeInitializationBudget(configv1.NonePlatformType))
+}
+
+func TestWindowsNodeInitializationBudget(t *testing.T) {
+	beginning := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	end := beginning.Add(time.Hour)
+
+	machine := func(name, nodeName string) unstructured.Unstructured {
+		machine := unstructured.Unstructured{Object: map[string]interface{}{
+			"metadata": map[string]interface{}{"namespace": "openshift-machine-api", "name": name},
+		}}
+		machine.SetCreationTimestamp(metav1.NewTime(beginning))
+		require.NoError(t, unstructured.SetNestedField(machine.Object, nodeName, "status", "nodeRef", "name"))
+		return machine
+	}
+	node := func(os string) *nodeInitialization {
+		n := &nodeInitialization{}
+		n.observe(&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{corev1.LabelOSStable: os}},
+			Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(beginning.Add(25 * time.Minute))},
+			}},
+		}, beginning.Add(25*time.Minute))
+		return n
+	}
+
+	intervals := initializationIntervals([]unstructured.Unstructured{
+		machine("windows", "node-windows"),
+		machine("linux", "node-linux"),
+	}, map[string]*nodeInitialization{"node-windows": node("windows"), "node-linux": node("linux")}, beginning, end)
+	require.Len(t, intervals, 2)
+
+	junits := initializationBudgetJUnits(intervals, configv1.AWSPlatformType)
+	require.NotNil(t, junits[0].FailureOutput)
+	assert.Contains(t, junits[0].FailureOutput.Output, "node/node-linux")
+	assert.NotContains(t, junits[0].FailureOutput.Output, "node/node-windows", "Windows nodes have a longer budget")
+}
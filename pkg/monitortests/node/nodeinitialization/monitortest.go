@@ -0,0 +1,153 @@
+package nodeinitialization
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	configclient "github.com/openshift/client-go/config/clientset/versioned"
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	exutil "github.com/openshift/origin/test/extended/util"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+)
+
+var machinesResource = schema.GroupVersionResource{Group: "machine.openshift.io", Version: "v1beta1", Resource: "machines"}
+
+type nodeInitializationTracker struct {
+	kubeInformers      informers.SharedInformerFactory
+	dynamicClient      dynamic.Interface
+	platform           configv1.PlatformType
+	notSupportedReason error
+
+	lock  sync.Mutex
+	nodes map[string]*nodeInitialization
+}
+
+// NewNodeInitializationTracker measures how long the nodes of the machines created during the run take to become
+// Ready and to be initialized by the cloud provider, and compares it with a budget for the platform, to catch
+// regressions of the cloud-controller-manager and of machine provisioning.
+func NewNodeInitializationTracker() monitortestframework.MonitorTest {
+	return &nodeInitializationTracker{nodes: map[string]*nodeInitialization{}}
+}
+
+func (w *nodeInitializationTracker) SetSharedInformers(kubeInformers informers.SharedInformerFactory) {
+	w.kubeInformers = kubeInformers
+}
+
+func (*nodeInitializationTracker) Describe() monitortestframework.MonitorTestDescription {
+	return monitortestframework.MonitorTestDescription{
+		WatchedResources: []string{"infrastructures.config.openshift.io", "machines.machine.openshift.io", "nodes"},
+		JUnits:           []string{nodeReadyTestName, nodeInitializedTestName},
+	}
+}
+
+func (w *nodeInitializationTracker) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	kubeClient, err := kubernetes.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return err
+	}
+	isMicroShift, err := exutil.IsMicroShiftCluster(kubeClient)
+	if err != nil {
+		return fmt.Errorf("unable to determine if cluster is MicroShift: %v", err)
+	}
+	if isMicroShift {
+		w.notSupportedReason = &monitortestframework.NotSupportedError{
+			Reason: "platform MicroShift not supported",
+		}
+		return w.notSupportedReason
+	}
+
+	configClient, err := configclient.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return err
+	}
+	infra, err := configClient.ConfigV1().Infrastructures().Get(ctx, "cluster", metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if infra.Status.PlatformStatus != nil {
+		w.platform = infra.Status.PlatformStatus.Type
+	}
+
+	w.dynamicClient, err = dynamic.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return err
+	}
+	// nodes are only measured from the creation of their machine.
+	if _, err := w.dynamicClient.Resource(machinesResource).List(ctx, metav1.ListOptions{Limit: 1}); err != nil {
+		w.notSupportedReason = &monitortestframework.NotSupportedError{
+			Reason: fmt.Sprintf("unable to list machines: %v", err),
+		}
+		return w.notSupportedReason
+	}
+
+	if w.kubeInformers == nil {
+		w.kubeInformers = informers.NewSharedInformerFactory(kubeClient, 0)
+		defer w.kubeInformers.Start(ctx.Done())
+	}
+	observe := func(obj interface{}) {
+		node, ok := obj.(*corev1.Node)
+		if !ok {
+			return
+		}
+		w.lock.Lock()
+		defer w.lock.Unlock()
+		if _, ok := w.nodes[node.Name]; !ok {
+			w.nodes[node.Name] = &nodeInitialization{}
+		}
+		w.nodes[node.Name].observe(node, time.Now())
+	}
+	_, err = w.kubeInformers.Core().V1().Nodes().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: observe,
+		UpdateFunc: func(_, obj interface{}) {
+			observe(obj)
+		},
+	})
+	return err
+}
+
+func (w *nodeInitializationTracker) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	if w.notSupportedReason != nil {
+		return nil, nil, w.notSupportedReason
+	}
+	machines, err := w.dynamicClient.Resource(machinesResource).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	intervals := initializationIntervals(machines.Items, w.nodes, beginning, end)
+	logrus.Infof("measured the initialization of the nodes of %d machines created during the run", len(intervals))
+	return intervals, nil, nil
+}
+
+func (*nodeInitializationTracker) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	return nil, nil
+}
+
+func (w *nodeInitializationTracker) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	if w.notSupportedReason != nil {
+		return nil, w.notSupportedReason
+	}
+	return initializationBudgetJUnits(finalIntervals, w.platform), nil
+}
+
+func (*nodeInitializationTracker) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	return nil
+}
+
+func (*nodeInitializationTracker) Cleanup(ctx context.Context) error {
+	return nil
+}
@@ -34,7 +34,7 @@ func (*nodeStateAnalyzer) ConstructComputedIntervals(ctx context.Context, starti
 }
 
 func (*nodeStateAnalyzer) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
-	return nil, nil
+	return testNodePressure(finalIntervals), nil
 }
 
 func (*nodeStateAnalyzer) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
@@ -1,9 +1,13 @@
 package nodestateanalyzer
 
 import (
+	"strings"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+
 	"github.com/openshift/origin/pkg/monitortestlibrary/statetracker"
+	"github.com/openshift/origin/pkg/monitortestlibrary/windowsnodes"
 
 	"github.com/openshift/origin/pkg/monitor/monitorapi"
 )
@@ -14,10 +18,45 @@ const (
 	msgPhaseReboot   = "rebooted and kubelet started"
 )
 
+// pressureConditions maps the reasons the kubelet sets on the pressure conditions of a node, when the node comes
+// under pressure, to the condition.
+var pressureConditions = map[monitorapi.IntervalReason]corev1.NodeConditionType{
+	"KubeletHasInsufficientMemory": corev1.NodeMemoryPressure,
+	"KubeletHasDiskPressure":       corev1.NodeDiskPressure,
+	"KubeletHasInsufficientPID":    corev1.NodePIDPressure,
+}
+
+// relievedConditions maps the reasons the kubelet sets on the pressure conditions of a node, once the pressure is
+// gone, to the condition.
+var relievedConditions = map[monitorapi.IntervalReason]corev1.NodeConditionType{
+	"KubeletHasSufficientMemory": corev1.NodeMemoryPressure,
+	"KubeletHasNoDiskPressure":   corev1.NodeDiskPressure,
+	"KubeletHasSufficientPID":    corev1.NodePIDPressure,
+}
+
+func pressureState(condition corev1.NodeConditionType) statetracker.StateInfo {
+	return statetracker.State(string(condition), "NodePressure", monitorapi.NodeUnderPressureReason)
+}
+
+// reclaimedCondition returns the pressure condition of the resource an EvictionThresholdMet event says the kubelet is
+// reclaiming, "Attempting to reclaim memory" for instance.
+func reclaimedCondition(humanMessage string) corev1.NodeConditionType {
+	switch {
+	case strings.Contains(humanMessage, "reclaim "+string(corev1.ResourceMemory)):
+		return corev1.NodeMemoryPressure
+	case strings.Contains(humanMessage, "reclaim pids"):
+		return corev1.NodePIDPressure
+	default:
+		return corev1.NodeDiskPressure
+	}
+}
+
 func intervalsFromEvents_NodeChanges(events monitorapi.Intervals, _ monitorapi.ResourcesMap, beginning, end time.Time) monitorapi.Intervals {
 	var intervals monitorapi.Intervals
 	nodeStateTracker := statetracker.NewStateTracker(monitorapi.ConstructionOwnerNodeLifecycle, monitorapi.SourceNodeState, beginning)
 	locatorToMessageAnnotations := map[string]map[string]string{}
+	// the kubelet and machine-config-daemon events do not say which nodes run Windows, the node monitor does.
+	windowsNodes := windowsnodes.Nodes(events)
 
 	for _, event := range events {
 		// TODO: dangerous assumptions here without using interval source, we ended up picking up container
@@ -41,6 +80,11 @@ func intervalsFromEvents_NodeChanges(events monitorapi.Intervals, _ monitorapi.R
 			locatorToMessageAnnotations[nodeLocatorKey] = map[string]string{}
 		}
 		locatorToMessageAnnotations[nodeLocatorKey][string(monitorapi.AnnotationRoles)] = roles
+		osAnnotations := map[monitorapi.AnnotationKey]string{}
+		if windowsNodes.Has(node) {
+			osAnnotations[monitorapi.AnnotationOS] = windowsnodes.Windows
+			locatorToMessageAnnotations[nodeLocatorKey][string(monitorapi.AnnotationOS)] = windowsnodes.Windows
+		}
 
 		notReadyState := statetracker.State("NotReady", "NodeNotReady", monitorapi.NodeNotReadyReason)
 		updateState := statetracker.State("Update", "NodeUpdate", monitorapi.NodeUpdateReason)
@@ -66,11 +110,34 @@ func intervalsFromEvents_NodeChanges(events monitorapi.Intervals, _ monitorapi.R
 				mb := monitorapi.NewMessage().Reason(monitorapi.NodeNotReadyReason).
 					HumanMessage("node is not ready").
 					WithAnnotation(monitorapi.AnnotationConstructed, monitorapi.ConstructionOwnerNodeLifecycle).
-					WithAnnotation(monitorapi.AnnotationRoles, roles)
+					WithAnnotation(monitorapi.AnnotationRoles, roles).
+					WithAnnotations(osAnnotations)
 				intervals = append(intervals, nodeStateTracker.CloseIfOpenedInterval(nodeLocator, notReadyState,
 					statetracker.SimpleInterval(monitorapi.SourceNodeState, monitorapi.Warning, mb),
 					event.From)...)
 			}
+		case "KubeletHasInsufficientMemory", "KubeletHasDiskPressure", "KubeletHasInsufficientPID":
+			if event.Source == monitorapi.SourceNodeMonitor {
+				nodeStateTracker.OpenInterval(nodeLocator, pressureState(pressureConditions[reason]), event.From)
+			}
+		case "EvictionThresholdMet":
+			// the kubelet evicts pods as soon as a threshold is met, the condition may only follow.
+			if event.Source == monitorapi.SourceKubeEvent {
+				nodeStateTracker.OpenInterval(nodeLocator, pressureState(reclaimedCondition(event.Message.HumanMessage)), event.From)
+			}
+		case "KubeletHasSufficientMemory", "KubeletHasNoDiskPressure", "KubeletHasSufficientPID":
+			if event.Source == monitorapi.SourceNodeMonitor {
+				condition := relievedConditions[reason]
+				mb := monitorapi.NewMessage().Reason(monitorapi.NodeUnderPressureReason).
+					HumanMessagef("node reported %s", condition).
+					WithAnnotation(monitorapi.AnnotationConstructed, monitorapi.ConstructionOwnerNodeLifecycle).
+					WithAnnotation(monitorapi.AnnotationRoles, roles).
+					WithAnnotations(osAnnotations).
+					WithAnnotation(monitorapi.AnnotationState, string(condition))
+				intervals = append(intervals, nodeStateTracker.CloseIfOpenedInterval(nodeLocator, pressureState(condition),
+					statetracker.SimpleInterval(monitorapi.SourceNodeState, monitorapi.Warning, mb),
+					event.From)...)
+			}
 		case "MachineConfigChange":
 			if event.Source == monitorapi.SourceNodeMonitor {
 				nodeStateTracker.OpenInterval(nodeLocator, updateState, event.From)
@@ -81,6 +148,7 @@ func intervalsFromEvents_NodeChanges(events monitorapi.Intervals, _ monitorapi.R
 					HumanMessage(event.Message.HumanMessage). // re-use the human message from the MachineConfigReached event
 					WithAnnotation(monitorapi.AnnotationConstructed, monitorapi.ConstructionOwnerNodeLifecycle).
 					WithAnnotation(monitorapi.AnnotationRoles, roles).
+					WithAnnotations(osAnnotations).
 					WithAnnotation(monitorapi.AnnotationPhase, "Update")
 				intervals = append(intervals, nodeStateTracker.CloseIfOpenedInterval(nodeLocator, updateState,
 					statetracker.SimpleInterval(monitorapi.SourceNodeState, monitorapi.Info, mb),
@@ -95,6 +163,7 @@ func intervalsFromEvents_NodeChanges(events monitorapi.Intervals, _ monitorapi.R
 				HumanMessage(msgPhaseDrain).
 				WithAnnotation(monitorapi.AnnotationConstructed, monitorapi.ConstructionOwnerNodeLifecycle).
 				WithAnnotation(monitorapi.AnnotationRoles, roles).
+				WithAnnotations(osAnnotations).
 				WithAnnotation(monitorapi.AnnotationPhase, "Drain")
 			intervals = append(intervals, nodeStateTracker.CloseIfOpenedInterval(nodeLocator, drainState,
 				statetracker.SimpleInterval(monitorapi.SourceNodeState, monitorapi.Info, mb),
@@ -106,6 +175,7 @@ func intervalsFromEvents_NodeChanges(events monitorapi.Intervals, _ monitorapi.R
 				HumanMessage(msgPhaseDrain).
 				WithAnnotation(monitorapi.AnnotationConstructed, monitorapi.ConstructionOwnerNodeLifecycle).
 				WithAnnotation(monitorapi.AnnotationRoles, roles).
+				WithAnnotations(osAnnotations).
 				WithAnnotation(monitorapi.AnnotationPhase, "Drain")
 			intervals = append(intervals, nodeStateTracker.CloseIfOpenedInterval(nodeLocator, drainState,
 				statetracker.SimpleInterval(monitorapi.SourceNodeState, monitorapi.Info, mb),
@@ -115,6 +185,7 @@ func intervalsFromEvents_NodeChanges(events monitorapi.Intervals, _ monitorapi.R
 				HumanMessage(msgPhaseOSUpdate).
 				WithAnnotation(monitorapi.AnnotationConstructed, monitorapi.ConstructionOwnerNodeLifecycle).
 				WithAnnotation(monitorapi.AnnotationRoles, roles).
+				WithAnnotations(osAnnotations).
 				WithAnnotation(monitorapi.AnnotationPhase, "OperatingSystemUpdate")
 			intervals = append(intervals, nodeStateTracker.CloseIfOpenedInterval(nodeLocator, osUpdateState,
 				statetracker.SimpleInterval(monitorapi.SourceNodeState, monitorapi.Info, osUpdateMB),
@@ -126,6 +197,7 @@ func intervalsFromEvents_NodeChanges(events monitorapi.Intervals, _ monitorapi.R
 				HumanMessage(msgPhaseDrain).
 				WithAnnotation(monitorapi.AnnotationConstructed, monitorapi.ConstructionOwnerNodeLifecycle).
 				WithAnnotation(monitorapi.AnnotationRoles, roles).
+				WithAnnotations(osAnnotations).
 				WithAnnotation(monitorapi.AnnotationPhase, "Drain")
 			intervals = append(intervals, nodeStateTracker.CloseIfOpenedInterval(nodeLocator, drainState,
 				statetracker.SimpleInterval(monitorapi.SourceNodeState, monitorapi.Info, mb),
@@ -135,6 +207,7 @@ func intervalsFromEvents_NodeChanges(events monitorapi.Intervals, _ monitorapi.R
 				HumanMessage(msgPhaseOSUpdate).
 				WithAnnotation(monitorapi.AnnotationConstructed, monitorapi.ConstructionOwnerNodeLifecycle).
 				WithAnnotation(monitorapi.AnnotationRoles, roles).
+				WithAnnotations(osAnnotations).
 				WithAnnotation(monitorapi.AnnotationPhase, "OperatingSystemUpdate")
 			intervals = append(intervals, nodeStateTracker.CloseIfOpenedInterval(nodeLocator, osUpdateState,
 				statetracker.SimpleInterval(monitorapi.SourceNodeState, monitorapi.Info, osUpdateMB),
@@ -144,6 +217,7 @@ func intervalsFromEvents_NodeChanges(events monitorapi.Intervals, _ monitorapi.R
 				HumanMessage(msgPhaseReboot).
 				WithAnnotation(monitorapi.AnnotationConstructed, monitorapi.ConstructionOwnerNodeLifecycle).
 				WithAnnotation(monitorapi.AnnotationRoles, roles).
+				WithAnnotations(osAnnotations).
 				WithAnnotation(monitorapi.AnnotationPhase, "Reboot")
 			intervals = append(intervals, nodeStateTracker.CloseIfOpenedInterval(nodeLocator, rebootState,
 				statetracker.SimpleInterval(monitorapi.SourceNodeState, monitorapi.Info, rebootMB),
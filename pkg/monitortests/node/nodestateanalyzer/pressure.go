@@ -0,0 +1,70 @@
+package nodestateanalyzer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestlibrary/nodepressure"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+)
+
+const nodePressureTestName = "[sig-node] nodes should not come under memory, disk or PID pressure"
+
+// isPressureCasualty matches the workload disruption a node under pressure causes: evicted pods and containers that
+// exited non-zero, killed to reclaim memory for instance.
+func isPressureCasualty(interval monitorapi.Interval) bool {
+	if interval.Source != monitorapi.SourcePodMonitor {
+		return false
+	}
+	switch interval.Message.Reason {
+	case monitorapi.PodReasonEvicted:
+		return true
+	case monitorapi.ContainerReasonContainerExit:
+		return interval.Message.Annotations[monitorapi.AnnotationContainerExitCode] != "0"
+	}
+	return false
+}
+
+// testNodePressure flags every window a node was under pressure, with the workload disruption attributed to it.  The
+// tests of the workload excuse that disruption, so that it is reported once, as pressure.
+func testNodePressure(intervals monitorapi.Intervals) []*junitapi.JUnitTestCase {
+	windows := nodepressure.Windows(intervals)
+	if len(windows) == 0 {
+		return []*junitapi.JUnitTestCase{{Name: nodePressureTestName}}
+	}
+
+	casualties := map[string][]string{}
+	for _, interval := range intervals {
+		if isPressureCasualty(interval) && nodepressure.During(windows, interval) {
+			node := interval.Locator.Keys[monitorapi.LocatorNodeKey]
+			casualties[node] = append(casualties[node], interval.String())
+		}
+	}
+
+	nodes := []string{}
+	for node := range windows {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	failures := []string{}
+	for _, node := range nodes {
+		for _, window := range windows[node] {
+			failures = append(failures, fmt.Sprintf("node/%s reported %s for %s from %s", node, window.Message.Annotations[monitorapi.AnnotationState],
+				window.To.Sub(window.From), window.From.UTC().Format("15:04:05")))
+		}
+		if len(casualties[node]) > 0 {
+			failures = append(failures, fmt.Sprintf("  workload disrupted meanwhile:\n    %s", strings.Join(casualties[node], "\n    ")))
+		}
+	}
+
+	failure := &junitapi.JUnitTestCase{
+		Name: nodePressureTestName,
+		FailureOutput: &junitapi.FailureOutput{
+			Output: fmt.Sprintf("%d nodes came under resource pressure, evictions and restarts on them are attributed to it.\n\n%s", len(nodes), strings.Join(failures, "\n")),
+		},
+	}
+	// TODO: marked flaky until we know how often CI clusters run out of resources
+	return []*junitapi.JUnitTestCase{failure, {Name: nodePressureTestName}}
+}
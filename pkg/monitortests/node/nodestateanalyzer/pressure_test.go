@@ -0,0 +1,59 @@
+package nodestateanalyzer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func conditionChange(node, reason string, at time.Time) monitorapi.Interval {
+	return monitorapi.NewInterval(monitorapi.SourceNodeMonitor, monitorapi.Warning).
+		Locator(monitorapi.NewLocator().NodeFromName(node)).
+		Message(monitorapi.NewMessage().Reason(monitorapi.IntervalReason(reason)).HumanMessage("changed")).
+		Build(at, at)
+}
+
+func TestNodePressureWindows(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "openshift-monitoring", Name: "prometheus-k8s-0", UID: "uid"},
+		Spec:       corev1.PodSpec{NodeName: "worker-a"},
+	}
+	oomKill := monitorapi.NewInterval(monitorapi.SourcePodMonitor, monitorapi.Error).
+		Locator(monitorapi.NewLocator().ContainerFromPod(pod, "prometheus")).
+		Message(monitorapi.NewMessage().Reason(monitorapi.ContainerReasonContainerExit).
+			WithAnnotation(monitorapi.AnnotationContainerExitCode, "137").
+			Cause("OOMKilled")).
+		Build(start.Add(3*time.Minute), start.Add(3*time.Minute))
+
+	events := monitorapi.Intervals{
+		conditionChange("worker-a", "KubeletHasInsufficientMemory", start.Add(2*time.Minute)),
+		conditionChange("worker-a", "KubeletHasSufficientMemory", start.Add(4*time.Minute)),
+		// relieved without having come under pressure during the run
+		conditionChange("worker-b", "KubeletHasNoDiskPressure", start.Add(2*time.Minute)),
+		oomKill,
+	}
+	windows := intervalsFromEvents_NodeChanges(events, nil, start, start.Add(time.Hour))
+	require.Len(t, windows, 1)
+	assert.Equal(t, monitorapi.NodeUnderPressureReason, windows[0].Message.Reason)
+	assert.Equal(t, "MemoryPressure", windows[0].Message.Annotations[monitorapi.AnnotationState])
+	assert.Equal(t, start.Add(2*time.Minute), windows[0].From)
+	assert.Equal(t, start.Add(4*time.Minute), windows[0].To)
+
+	junits := testNodePressure(append(events, windows...))
+	require.Len(t, junits, 2, "pressure is reported as a flake")
+	require.NotNil(t, junits[0].FailureOutput)
+	assert.Contains(t, junits[0].FailureOutput.Output, "node/worker-a reported MemoryPressure for 2m0s")
+	assert.Contains(t, junits[0].FailureOutput.Output, "prometheus-k8s-0")
+}
+
+func TestReclaimedCondition(t *testing.T) {
+	assert.Equal(t, corev1.NodeMemoryPressure, reclaimedCondition("Attempting to reclaim memory"))
+	assert.Equal(t, corev1.NodeDiskPressure, reclaimedCondition("Attempting to reclaim ephemeral-storage"))
+	assert.Equal(t, corev1.NodePIDPressure, reclaimedCondition("Attempting to reclaim pids"))
+}
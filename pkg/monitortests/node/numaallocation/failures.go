@@ -0,0 +1,112 @@
+package numaallocation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const testName = "[sig-node] pods should not be rejected for lack of NUMA aligned resources or hugepages"
+
+// admissionFailure returns the reason of a kubelet admission rejection caused by the topology manager or hugepages,
+// and the reason the kubelet gave.  The kubelet both records an event and fails the pod, either may be missed.
+func admissionFailure(interval monitorapi.Interval) (monitorapi.IntervalReason, string, bool) {
+	var kubeletReason string
+	switch {
+	case interval.Source == monitorapi.SourceKubeEvent:
+		kubeletReason = string(interval.Message.Reason)
+	case interval.Source == monitorapi.SourcePodMonitor && interval.Message.Reason == monitorapi.PodReasonFailed:
+		// the pod monitor reports failures as "(<reason>): <message>"
+		if !strings.HasPrefix(interval.Message.HumanMessage, "(") {
+			return "", "", false
+		}
+		kubeletReason, _, _ = strings.Cut(strings.TrimPrefix(interval.Message.HumanMessage, "("), ")")
+	default:
+		return "", "", false
+	}
+
+	switch {
+	case kubeletReason == "TopologyAffinityError":
+		return monitorapi.TopologyAffinityErrorReason, kubeletReason, true
+	case strings.HasPrefix(kubeletReason, "OutOfhugepages-"):
+		return monitorapi.HugepageAllocationFailedReason, kubeletReason, true
+	case kubeletReason == "UnexpectedAdmissionError" && strings.Contains(interval.Message.HumanMessage, "hugepages"):
+		return monitorapi.HugepageAllocationFailedReason, kubeletReason, true
+	}
+	return "", "", false
+}
+
+// allocationFailures returns an interval on the node of every pod the kubelet rejected for lack of NUMA aligned
+// resources or hugepages, once per pod and reason.
+func allocationFailures(intervals monitorapi.Intervals) monitorapi.Intervals {
+	seen := sets.New[string]()
+	ret := monitorapi.Intervals{}
+	for _, interval := range intervals {
+		reason, kubeletReason, ok := admissionFailure(interval)
+		if !ok {
+			continue
+		}
+		node := interval.Locator.Keys[monitorapi.LocatorNodeKey]
+		if len(node) == 0 {
+			continue
+		}
+		namespace := monitorapi.NamespaceFromLocator(interval.Locator)
+		pod := fmt.Sprintf("%s/%s", namespace, interval.Locator.Keys[monitorapi.LocatorPodKey])
+		key := pod + "/" + string(reason)
+		if seen.Has(key) {
+			continue
+		}
+		seen.Insert(key)
+
+		// the failures are shown on the row of the node, the namespace tells the workload apart.
+		locator := monitorapi.NewLocator().NodeFromName(node)
+		locator.Keys[monitorapi.LocatorNamespaceKey] = namespace
+		ret = append(ret, monitorapi.NewInterval(monitorapi.SourceResourceAllocation, monitorapi.Error).
+			Locator(locator).
+			Message(monitorapi.NewMessage().Reason(reason).
+				Cause(kubeletReason).
+				HumanMessagef("pod/%s rejected: %s", pod, interval.Message.HumanMessage)).
+			Display().
+			Build(interval.From, interval.To))
+	}
+	sort.Sort(ret)
+	return ret
+}
+
+// isE2ENamespace matches the namespaces of e2e tests, the topology manager tests provoke rejections on purpose.
+func isE2ENamespace(namespace string) bool {
+	return strings.HasPrefix(namespace, "e2e-")
+}
+
+// allocationFailuresJunit lists the rejections of pods outside of e2e namespaces, by node.
+func allocationFailuresJunit(failures monitorapi.Intervals) []*junitapi.JUnitTestCase {
+	byNode := map[string][]string{}
+	for _, failure := range failures {
+		if isE2ENamespace(monitorapi.NamespaceFromLocator(failure.Locator)) {
+			continue
+		}
+		node := failure.Locator.Keys[monitorapi.LocatorNodeKey]
+		byNode[node] = append(byNode[node], fmt.Sprintf("%s %s", failure.From.UTC().Format("15:04:05"), failure.Message.HumanMessage))
+	}
+	if len(byNode) == 0 {
+		return []*junitapi.JUnitTestCase{{Name: testName}}
+	}
+
+	nodes := sets.List(sets.KeySet(byNode))
+	messages := []string{}
+	for _, node := range nodes {
+		messages = append(messages, fmt.Sprintf("node/%s:\n  %s", node, strings.Join(byNode[node], "\n  ")))
+	}
+	failure := &junitapi.JUnitTestCase{
+		Name: testName,
+		FailureOutput: &junitapi.FailureOutput{
+			Output: fmt.Sprintf("pods were rejected on %d nodes for lack of NUMA aligned resources or hugepages.\n\n%s", len(nodes), strings.Join(messages, "\n")),
+		},
+	}
+	// TODO: marked flaky until we know how often performance-tuned clusters reject pods
+	return []*junitapi.JUnitTestCase{failure, {Name: testName}}
+}
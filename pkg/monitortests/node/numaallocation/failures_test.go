@@ -0,0 +1,51 @@
+package numaallocation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func rejection(source monitorapi.IntervalSource, namespace, reason, message string, at time.Time) monitorapi.Interval {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "dpdk", UID: "uid"},
+		Spec:       corev1.PodSpec{NodeName: "worker-cnf"},
+	}
+	mb := monitorapi.NewMessage().Reason(monitorapi.IntervalReason(reason)).HumanMessage(message)
+	if source == monitorapi.SourcePodMonitor {
+		mb = monitorapi.NewMessage().Reason(monitorapi.PodReasonFailed).HumanMessagef("(%s): %s", reason, message)
+	}
+	return monitorapi.NewInterval(source, monitorapi.Warning).
+		Locator(monitorapi.NewLocator().PodFromPod(pod)).
+		Message(mb).
+		Build(at, at)
+}
+
+func TestAllocationFailures(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	intervals := monitorapi.Intervals{
+		rejection(monitorapi.SourceKubeEvent, "cnf", "TopologyAffinityError", "Resources cannot be allocated with Topology locality", start),
+		// the same rejection, seen by the pod monitor.
+		rejection(monitorapi.SourcePodMonitor, "cnf", "TopologyAffinityError", "Resources cannot be allocated with Topology locality", start.Add(time.Second)),
+		rejection(monitorapi.SourcePodMonitor, "e2e-topology-manager", "OutOfhugepages-1Gi", "Node didn't have enough resource: hugepages-1Gi", start.Add(time.Minute)),
+		rejection(monitorapi.SourceKubeEvent, "cnf", "FailedMount", "unrelated", start.Add(time.Minute)),
+	}
+
+	failures := allocationFailures(intervals)
+	require.Len(t, failures, 2)
+	assert.Equal(t, monitorapi.TopologyAffinityErrorReason, failures[0].Message.Reason)
+	assert.Equal(t, "worker-cnf", failures[0].Locator.Keys[monitorapi.LocatorNodeKey])
+	assert.Equal(t, "TopologyAffinityError", failures[0].Message.Cause)
+	assert.Equal(t, monitorapi.HugepageAllocationFailedReason, failures[1].Message.Reason)
+
+	junits := allocationFailuresJunit(failures)
+	require.Len(t, junits, 2, "rejections are reported as flakes")
+	require.NotNil(t, junits[0].FailureOutput)
+	assert.Contains(t, junits[0].FailureOutput.Output, "pod/cnf/dpdk rejected")
+	assert.NotContains(t, junits[0].FailureOutput.Output, "e2e-topology-manager", "e2e tests provoke rejections on purpose")
+}
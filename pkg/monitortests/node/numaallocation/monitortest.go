@@ -0,0 +1,132 @@
+package numaallocation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	exutil "github.com/openshift/origin/test/extended/util"
+)
+
+// performanceProfilesResource are the node tunings of the Node Tuning Operator, which configure the topology manager
+// and hugepages.
+var performanceProfilesResource = schema.GroupVersionResource{Group: "performance.openshift.io", Version: "v2", Resource: "performanceprofiles"}
+
+// numaAllocationFailures turns the pods the kubelet rejected because the topology manager could not align their
+// resources, or the node could not allocate their hugepages, into intervals on their node.  They otherwise only show
+// up as pods failing to start.
+type numaAllocationFailures struct {
+	notSupportedReason error
+}
+
+func NewNUMAAllocationFailures() monitortestframework.MonitorTest {
+	return &numaAllocationFailures{}
+}
+
+func (*numaAllocationFailures) Describe() monitortestframework.MonitorTestDescription {
+	// the rejections are recorded by the pod-lifecycle and event monitor tests.
+	return monitortestframework.MonitorTestDescription{
+		JUnits: []string{testName},
+	}
+}
+
+func (w *numaAllocationFailures) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	kubeClient, err := kubernetes.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return err
+	}
+	isMicroShift, err := exutil.IsMicroShiftCluster(kubeClient)
+	if err != nil {
+		return fmt.Errorf("unable to determine if cluster is MicroShift: %v", err)
+	}
+	if isMicroShift {
+		w.notSupportedReason = &monitortestframework.NotSupportedError{Reason: "platform MicroShift not supported"}
+		return w.notSupportedReason
+	}
+
+	tuned, err := isPerformanceTuned(ctx, kubeClient, adminRESTConfig)
+	if err != nil {
+		return err
+	}
+	if !tuned {
+		w.notSupportedReason = &monitortestframework.NotSupportedError{Reason: "no PerformanceProfile is defined and no node offers hugepages"}
+		return w.notSupportedReason
+	}
+	return nil
+}
+
+// isPerformanceTuned returns true if the cluster has a PerformanceProfile, or a node offering hugepages.
+func isPerformanceTuned(ctx context.Context, kubeClient kubernetes.Interface, adminRESTConfig *rest.Config) (bool, error) {
+	nodes, err := kubeClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return false, err
+	}
+	for _, node := range nodes.Items {
+		if offersHugepages(&node) {
+			return true, nil
+		}
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return false, err
+	}
+	profiles, err := dynamicClient.Resource(performanceProfilesResource).List(ctx, metav1.ListOptions{Limit: 1})
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return len(profiles.Items) > 0, nil
+}
+
+func offersHugepages(node *corev1.Node) bool {
+	for resource, quantity := range node.Status.Capacity {
+		if strings.HasPrefix(string(resource), corev1.ResourceHugePagesPrefix) && !quantity.IsZero() {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *numaAllocationFailures) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	return nil, nil, w.notSupportedReason
+}
+
+func (w *numaAllocationFailures) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	if w.notSupportedReason != nil {
+		return nil, w.notSupportedReason
+	}
+	return allocationFailures(startingIntervals), nil
+}
+
+func (w *numaAllocationFailures) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	if w.notSupportedReason != nil {
+		return nil, w.notSupportedReason
+	}
+	failures := finalIntervals.Filter(func(interval monitorapi.Interval) bool {
+		return interval.Source == monitorapi.SourceResourceAllocation
+	})
+	return allocationFailuresJunit(failures), nil
+}
+
+func (w *numaAllocationFailures) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	return w.notSupportedReason
+}
+
+func (*numaAllocationFailures) Cleanup(ctx context.Context) error {
+	return nil
+}
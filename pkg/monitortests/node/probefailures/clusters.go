@@ -0,0 +1,174 @@
+package probefailures
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+)
+
+const (
+	testName = "[sig-node] workloads should not fail their probes repeatedly outside of node updates"
+
+	// clusterGap is the longest time between two probe failures of a cluster.  Probes run every ten seconds by default,
+	// the kubelet only records a failure after a few of them.
+	clusterGap = 5 * time.Minute
+	// repeatedFailures is the number of failures outside node updates that flag a cluster.
+	repeatedFailures = 10
+)
+
+var unhealthyRegex = regexp.MustCompile(`^(Readiness|Liveness|Startup) probe (failed|errored)`)
+
+var (
+	// replicaSetPodSuffix is the pod template hash and the random suffix of the pods of a deployment, the random suffix
+	// alone names the pods of daemonsets and jobs, and an ordinal the pods of statefulsets.  The generated suffixes
+	// leave out vowels and easily confused characters.
+	replicaSetPodSuffix = regexp.MustCompile(`-[bcdfghjklmnpqrstvwxz2456789]{6,10}-[bcdfghjklmnpqrstvwxz2456789]{5}$`)
+	generatedPodSuffix  = regexp.MustCompile(`-[bcdfghjklmnpqrstvwxz2456789]{5}$`)
+	ordinalPodSuffix    = regexp.MustCompile(`-[0-9]+$`)
+)
+
+// workloadName returns the name the pods of a workload share.  Static pods are suffixed with their node.
+func workloadName(pod, node string) string {
+	if len(node) > 0 && strings.HasSuffix(pod, "-"+node) {
+		return strings.TrimSuffix(pod, "-"+node)
+	}
+	for _, suffix := range []*regexp.Regexp{replicaSetPodSuffix, generatedPodSuffix, ordinalPodSuffix} {
+		if suffix.MatchString(pod) {
+			return suffix.ReplaceAllString(pod, "")
+		}
+	}
+	return pod
+}
+
+// probeFailure is one Unhealthy event.
+type probeFailure struct {
+	node    string
+	at      time.Time
+	message string
+}
+
+// probeFailureCluster is a run of probe failures of one type for the pods of one workload.
+type probeFailureCluster struct {
+	namespace string
+	workload  string
+	probe     string
+	failures  []probeFailure
+	// outsideNodeUpdates is the number of failures on nodes that were not updating.
+	outsideNodeUpdates int
+}
+
+// clusterProbeFailures groups the Unhealthy events by namespace, workload and probe type, starting a new cluster when
+// a workload did not fail for longer than clusterGap.
+func clusterProbeFailures(intervals monitorapi.Intervals) []*probeFailureCluster {
+	nodeUpdates := map[string]monitorapi.Intervals{}
+	failures := map[string][]probeFailure{}
+	keys := map[string]*probeFailureCluster{}
+	for _, interval := range intervals {
+		if interval.Source == monitorapi.SourceNodeState && interval.Message.Reason == monitorapi.NodeUpdateReason {
+			node := interval.Locator.Keys[monitorapi.LocatorNodeKey]
+			nodeUpdates[node] = append(nodeUpdates[node], interval)
+			continue
+		}
+		if interval.Source != monitorapi.SourceKubeEvent || interval.Message.Reason != "Unhealthy" {
+			continue
+		}
+		matches := unhealthyRegex.FindStringSubmatch(interval.Message.HumanMessage)
+		pod := interval.Locator.Keys[monitorapi.LocatorPodKey]
+		if matches == nil || len(pod) == 0 {
+			continue
+		}
+		node := interval.Locator.Keys[monitorapi.LocatorNodeKey]
+		namespace := interval.Locator.Keys[monitorapi.LocatorNamespaceKey]
+		workload := workloadName(pod, node)
+		key := namespace + "/" + workload + "/" + matches[1]
+		if _, ok := keys[key]; !ok {
+			keys[key] = &probeFailureCluster{namespace: namespace, workload: workload, probe: matches[1]}
+		}
+		failures[key] = append(failures[key], probeFailure{node: node, at: interval.From, message: interval.Message.HumanMessage})
+	}
+
+	ret := []*probeFailureCluster{}
+	for key, workloadFailures := range failures {
+		sort.SliceStable(workloadFailures, func(i, j int) bool {
+			return workloadFailures[i].at.Before(workloadFailures[j].at)
+		})
+		var current *probeFailureCluster
+		for _, failure := range workloadFailures {
+			if current == nil || failure.at.Sub(current.failures[len(current.failures)-1].at) > clusterGap {
+				current = &probeFailureCluster{namespace: keys[key].namespace, workload: keys[key].workload, probe: keys[key].probe}
+				ret = append(ret, current)
+			}
+			current.failures = append(current.failures, failure)
+			if !duringNodeUpdate(nodeUpdates[failure.node], failure.at) {
+				current.outsideNodeUpdates++
+			}
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if !ret[i].failures[0].at.Equal(ret[j].failures[0].at) {
+			return ret[i].failures[0].at.Before(ret[j].failures[0].at)
+		}
+		return ret[i].namespace+"/"+ret[i].workload+"/"+ret[i].probe < ret[j].namespace+"/"+ret[j].workload+"/"+ret[j].probe
+	})
+	return ret
+}
+
+func duringNodeUpdate(updates monitorapi.Intervals, at time.Time) bool {
+	for _, update := range updates {
+		// open intervals extend to the end of the run
+		if !at.Before(update.From) && (update.To.IsZero() || !at.After(update.To)) {
+			return true
+		}
+	}
+	return false
+}
+
+// clusterIntervals summarizes each cluster in one interval.  Node updates are constructed along with these intervals,
+// so whether the failures were outside of them is only known to clusterJUnits.
+func clusterIntervals(clusters []*probeFailureCluster) monitorapi.Intervals {
+	ret := monitorapi.Intervals{}
+	for _, cluster := range clusters {
+		first, last := cluster.failures[0], cluster.failures[len(cluster.failures)-1]
+		ret = append(ret, monitorapi.NewInterval(monitorapi.SourceProbeFailures, monitorapi.Warning).
+			Locator(monitorapi.NewLocator().Workload(cluster.namespace, cluster.workload)).
+			Message(monitorapi.NewMessage().Reason(monitorapi.ProbeFailureClusterReason).
+				WithAnnotation(monitorapi.AnnotationProbe, cluster.probe).
+				WithAnnotation(monitorapi.AnnotationCount, strconv.Itoa(len(cluster.failures))).
+				HumanMessagef("%s probes failed %d times, last: %s", cluster.probe, len(cluster.failures), last.message)).
+			Display().
+			Build(first.at, last.at))
+	}
+	return ret
+}
+
+// clusterJUnits flags the clusters with repeated failures outside of node updates.  Tests break the probes of their
+// own workloads, so this only flakes until the platform workloads are known to pass it.
+func clusterJUnits(clusters []*probeFailureCluster) []*junitapi.JUnitTestCase {
+	flagged := []string{}
+	for _, cluster := range clusters {
+		if cluster.outsideNodeUpdates < repeatedFailures {
+			continue
+		}
+		first, last := cluster.failures[0], cluster.failures[len(cluster.failures)-1]
+		flagged = append(flagged, fmt.Sprintf("ns/%s workload/%s %s %s probes failed %d times over %s, %d outside of node updates, last: %s",
+			cluster.namespace, cluster.workload, first.at.Format(time.RFC3339), cluster.probe, len(cluster.failures),
+			last.at.Sub(first.at).Round(time.Second), cluster.outsideNodeUpdates, last.message))
+	}
+	if len(flagged) == 0 {
+		return []*junitapi.JUnitTestCase{{Name: testName}}
+	}
+	failure := &junitapi.JUnitTestCase{
+		Name: testName,
+		FailureOutput: &junitapi.FailureOutput{
+			Output: fmt.Sprintf("%d clusters of probe failures had at least %d failures outside of node updates:\n\n%s",
+				len(flagged), repeatedFailures, strings.Join(flagged, "\n")),
+		},
+	}
+	return []*junitapi.JUnitTestCase{failure, {Name: testName}}
+}
@@ -0,0 +1,73 @@
+package probefailures
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkloadName(t *testing.T) {
+	for _, test := range []struct {
+		pod, node, expected string
+	}{
+		{pod: "console-7d5f8b8c4d-x2v9k", expected: "console"},
+		{pod: "ovnkube-node-5xk2q", expected: "ovnkube-node"},
+		{pod: "prometheus-k8s-1", expected: "prometheus-k8s"},
+		{pod: "etcd-master-0", node: "master-0", expected: "etcd"},
+		{pod: "standalone", expected: "standalone"},
+	} {
+		assert.Equal(t, test.expected, workloadName(test.pod, test.node), test.pod)
+	}
+}
+
+func unhealthy(pod, node, message string, at time.Time) monitorapi.Interval {
+	locator := monitorapi.NewLocator().PodFromNames("openshift-console", pod, "")
+	locator.Keys[monitorapi.LocatorNodeKey] = node
+	return monitorapi.NewInterval(monitorapi.SourceKubeEvent, monitorapi.Warning).
+		Locator(locator).
+		Message(monitorapi.NewMessage().Reason("Unhealthy").HumanMessage(message)).
+		Build(at, at)
+}
+
+func TestClusterProbeFailures(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	intervals := monitorapi.Intervals{
+		monitorapi.NewInterval(monitorapi.SourceNodeState, monitorapi.Info).
+			Locator(monitorapi.NewLocator().NodeFromName("worker-1")).
+			Message(monitorapi.NewMessage().Reason(monitorapi.NodeUpdateReason)).
+			Build(start, start.Add(30*time.Minute)),
+	}
+	// a cluster on a node that was not updating, and one while the node of the pods was updating.
+	for i := 0; i < 12; i++ {
+		intervals = append(intervals,
+			unhealthy("console-7d5f8b8c4d-x2v9k", "worker-0", "Readiness probe failed: connection refused", start.Add(time.Duration(i)*time.Minute)),
+			unhealthy("downloads-6b8d9c7f5-q4zxw", "worker-1", "Liveness probe failed: timeout", start.Add(time.Duration(i)*time.Minute)))
+	}
+	// the same workload failing again after a pause starts a new cluster.
+	intervals = append(intervals, unhealthy("console-7d5f8b8c4d-b7n2m", "worker-0", "Readiness probe errored: EOF", start.Add(time.Hour)))
+
+	clusters := clusterProbeFailures(intervals)
+	require.Len(t, clusters, 3)
+	assert.Equal(t, "console", clusters[0].workload)
+	assert.Equal(t, "Readiness", clusters[0].probe)
+	assert.Len(t, clusters[0].failures, 12)
+	assert.Equal(t, 12, clusters[0].outsideNodeUpdates)
+	assert.Equal(t, "downloads", clusters[1].workload)
+	assert.Equal(t, 0, clusters[1].outsideNodeUpdates)
+	assert.Len(t, clusters[2].failures, 1)
+
+	summarized := clusterIntervals(clusters)
+	require.Len(t, summarized, 3)
+	assert.Equal(t, "console", summarized[0].Locator.Keys[monitorapi.LocatorWorkloadKey])
+	assert.Equal(t, "12", summarized[0].Message.Annotations[monitorapi.AnnotationCount])
+	assert.Equal(t, 11*time.Minute, summarized[0].To.Sub(summarized[0].From))
+
+	junits := clusterJUnits(clusters)
+	require.Len(t, junits, 2)
+	require.NotNil(t, junits[0].FailureOutput)
+	assert.Contains(t, junits[0].FailureOutput.Output, "ns/openshift-console workload/console")
+	assert.NotContains(t, junits[0].FailureOutput.Output, "downloads")
+}
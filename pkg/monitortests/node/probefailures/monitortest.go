@@ -0,0 +1,51 @@
+package probefailures
+
+import (
+	"context"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	"k8s.io/client-go/rest"
+)
+
+type probeFailureAnalyzer struct {
+}
+
+// NewAnalyzer returns a monitor test that summarizes the Unhealthy events of each workload into clusters of probe
+// failures, and flags the workloads whose probes failed repeatedly while their nodes were not updating.
+func NewAnalyzer() monitortestframework.MonitorTest {
+	return &probeFailureAnalyzer{}
+}
+
+func (*probeFailureAnalyzer) Describe() monitortestframework.MonitorTestDescription {
+	// the events are recorded by the event-collector monitor test, the node updates by the node-state-analyzer.
+	return monitortestframework.MonitorTestDescription{
+		JUnits: []string{testName},
+	}
+}
+
+func (*probeFailureAnalyzer) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	return nil
+}
+
+func (*probeFailureAnalyzer) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	return nil, nil, nil
+}
+
+func (*probeFailureAnalyzer) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	return clusterIntervals(clusterProbeFailures(startingIntervals)), nil
+}
+
+func (*probeFailureAnalyzer) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	return clusterJUnits(clusterProbeFailures(finalIntervals)), nil
+}
+
+func (*probeFailureAnalyzer) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	return nil
+}
+
+func (*probeFailureAnalyzer) Cleanup(ctx context.Context) error {
+	return nil
+}
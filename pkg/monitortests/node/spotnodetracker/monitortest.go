@@ -0,0 +1,172 @@
+package spotnodetracker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/monitortestlibrary/spotnodes"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	exutil "github.com/openshift/origin/test/extended/util"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+var machinesResource = schema.GroupVersionResource{Group: "machine.openshift.io", Version: "v1beta1", Resource: "machines"}
+
+type spotNodeTracker struct {
+	kubeClient         kubernetes.Interface
+	dynamicClient      dynamic.Interface
+	spotNodes          sets.Set[string]
+	notSupportedReason error
+}
+
+// NewSpotNodeTracker tags the nodes running on spot or preemptible instances, and marks the windows in which the cloud
+// provider was terminating them so that node and disruption invariants can tolerate them.
+func NewSpotNodeTracker() monitortestframework.MonitorTest {
+	return &spotNodeTracker{spotNodes: sets.New[string]()}
+}
+
+func (w *spotNodeTracker) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	kubeClient, err := kubernetes.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return err
+	}
+	isMicroShift, err := exutil.IsMicroShiftCluster(kubeClient)
+	if err != nil {
+		return fmt.Errorf("unable to determine if cluster is MicroShift: %v", err)
+	}
+	if isMicroShift {
+		w.notSupportedReason = &monitortestframework.NotSupportedError{
+			Reason: "platform MicroShift not supported",
+		}
+		return w.notSupportedReason
+	}
+	dynamicClient, err := dynamic.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return err
+	}
+	w.kubeClient = kubeClient
+	w.dynamicClient = dynamicClient
+
+	// nodes may be replaced during the run, so we look both now and when collecting.
+	spotNodes, err := w.findSpotNodes(ctx)
+	if err != nil {
+		logrus.WithError(err).Warn("failed to find spot nodes at start")
+		return nil
+	}
+	w.spotNodes.Insert(spotNodes.UnsortedList()...)
+	return nil
+}
+
+func (w *spotNodeTracker) findSpotNodes(ctx context.Context) (sets.Set[string], error) {
+	ret := sets.New[string]()
+	nodes, err := w.kubeClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodes.Items {
+		if _, ok := node.Labels[spotnodes.InterruptibleInstanceLabel]; ok {
+			ret.Insert(node.Name)
+		}
+	}
+
+	// clusters without the machine-api have no machines to inspect, the node label is all we have.
+	machines, err := w.dynamicClient.Resource(machinesResource).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		logrus.WithError(err).Info("unable to list machines, relying on node labels for spot nodes")
+		return ret, nil
+	}
+	for _, machine := range machines.Items {
+		providerSpec, _, _ := unstructured.NestedMap(machine.Object, "spec", "providerSpec", "value")
+		nodeName, _, _ := unstructured.NestedString(machine.Object, "status", "nodeRef", "name")
+		if len(nodeName) > 0 && spotnodes.IsSpotProviderSpec(providerSpec) {
+			ret.Insert(nodeName)
+		}
+	}
+	return ret, nil
+}
+
+func (w *spotNodeTracker) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	if w.notSupportedReason != nil {
+		return nil, nil, w.notSupportedReason
+	}
+	spotNodes, err := w.findSpotNodes(ctx)
+	if err != nil {
+		logrus.WithError(err).Warn("failed to find spot nodes at end")
+	} else {
+		w.spotNodes.Insert(spotNodes.UnsortedList()...)
+	}
+
+	names := sets.List(w.spotNodes)
+	logrus.Infof("found %d spot nodes: %v", len(names), names)
+	ret := monitorapi.Intervals{}
+	for _, name := range names {
+		ret = append(ret, monitorapi.NewInterval(monitorapi.SourceSpotNode, monitorapi.Info).
+			Locator(monitorapi.NewLocator().NodeFromName(name)).
+			Message(monitorapi.NewMessage().Reason(monitorapi.SpotNodeReason).
+				HumanMessage("node runs on a spot or preemptible instance")).
+			Build(beginning, end))
+	}
+	return ret, nil, nil
+}
+
+func (*spotNodeTracker) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	return terminationWindows(startingIntervals), nil
+}
+
+// terminationWindows covers every cloud provider interruption of a spot node, plus the time the node needs to come
+// back.
+func terminationWindows(intervals monitorapi.Intervals) monitorapi.Intervals {
+	spotNodes := sets.New[string]()
+	for _, interval := range intervals {
+		if interval.Source == monitorapi.SourceSpotNode && interval.Message.Reason == monitorapi.SpotNodeReason {
+			spotNodes.Insert(interval.Locator.Keys[monitorapi.LocatorNodeKey])
+		}
+	}
+
+	ret := monitorapi.Intervals{}
+	for _, interval := range intervals {
+		if interval.Source != monitorapi.SourceCloudNodeInterruption {
+			continue
+		}
+		nodeName := interval.Locator.Keys[monitorapi.LocatorNodeKey]
+		if !spotNodes.Has(nodeName) {
+			continue
+		}
+		to := interval.To
+		if to.IsZero() {
+			to = interval.From
+		}
+		ret = append(ret, monitorapi.NewInterval(monitorapi.SourceSpotNode, monitorapi.Warning).
+			Locator(monitorapi.NewLocator().NodeFromName(nodeName)).
+			Message(monitorapi.NewMessage().Reason(monitorapi.SpotNodeTerminationReason).
+				Constructed("spot-node-tracker").
+				HumanMessagef("spot node terminated by the cloud provider: %s", interval.Message.Reason)).
+			Display().
+			Build(interval.From, to.Add(spotnodes.TerminationGrace)))
+	}
+	sort.Sort(ret)
+	return ret
+}
+
+func (*spotNodeTracker) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	return nil, nil
+}
+
+func (*spotNodeTracker) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	return nil
+}
+
+func (*spotNodeTracker) Cleanup(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,38 @@
+package spotnodetracker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestlibrary/spotnodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTerminationWindows(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	spotNode := monitorapi.NewInterval(monitorapi.SourceSpotNode, monitorapi.Info).
+		Locator(monitorapi.NewLocator().NodeFromName("worker-a")).
+		Message(monitorapi.NewMessage().Reason(monitorapi.SpotNodeReason)).
+		Build(start, start.Add(time.Hour))
+	interruption := func(node string, at time.Time) monitorapi.Interval {
+		return monitorapi.NewInterval(monitorapi.SourceCloudNodeInterruption, monitorapi.Warning).
+			Locator(monitorapi.NewLocator().NodeFromName(node)).
+			Message(monitorapi.NewMessage().Reason(monitorapi.CloudNodePreemptedReason)).
+			Build(at, at.Add(time.Minute))
+	}
+
+	actual := terminationWindows(monitorapi.Intervals{
+		spotNode,
+		interruption("worker-a", start.Add(10*time.Minute)),
+		// on demand nodes interrupted by the provider are not tolerated.
+		interruption("worker-b", start.Add(20*time.Minute)),
+	})
+	require.Len(t, actual, 1)
+	assert.Equal(t, "worker-a", actual[0].Locator.Keys[monitorapi.LocatorNodeKey])
+	assert.Equal(t, monitorapi.SpotNodeTerminationReason, actual[0].Message.Reason)
+	assert.Equal(t, start.Add(10*time.Minute), actual[0].From)
+	assert.Equal(t, start.Add(11*time.Minute).Add(spotnodes.TerminationGrace), actual[0].To)
+	assert.True(t, actual[0].Display)
+}
@@ -0,0 +1,178 @@
+package unexpectedpoddeletion
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestlibrary/platformidentification"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const testName = "[sig-node] pods in platform namespaces should not be deleted outside of node drains or operator rollouts"
+
+// isPlatformNamespace returns true for the namespaces we own, skipping those that are generated per run.
+func isPlatformNamespace(namespace string) bool {
+	if !strings.HasPrefix(namespace, "openshift-") {
+		return false
+	}
+	// must-gather namespaces are created and torn down by the tooling itself
+	if strings.HasPrefix(namespace, "openshift-must-gather") {
+		return false
+	}
+	return true
+}
+
+// isPodDeletion matches the instants the pod monitor records when a running pod is removed.
+// Pods that never got scheduled and pods that already completed are not reported with these reasons.
+func isPodDeletion(interval monitorapi.Interval) bool {
+	if interval.Source != monitorapi.SourcePodMonitor {
+		return false
+	}
+	switch interval.Message.Reason {
+	case monitorapi.PodReasonGracefulDeleteStarted, monitorapi.PodReasonForceDelete, monitorapi.PodReasonEvicted:
+		return true
+	}
+	return false
+}
+
+// isNodeDisruptionWindow matches constructed node intervals where pods are expected to be moved off of a node,
+// including the cloud provider terminating a spot node and the kubelet evicting pods to relieve pressure.
+func isNodeDisruptionWindow(interval monitorapi.Interval) bool {
+	if interval.Source == monitorapi.SourceSpotNode {
+		return interval.Message.Reason == monitorapi.SpotNodeTerminationReason
+	}
+	if interval.Source != monitorapi.SourceNodeState {
+		return false
+	}
+	switch interval.Message.Reason {
+	case monitorapi.NodeUpdateReason, monitorapi.NodeNotReadyReason, monitorapi.NodeUnderPressureReason:
+		return true
+	}
+	return false
+}
+
+// isChaosPodKill matches the pods the chaos injector killed on purpose.
+func isChaosPodKill(interval monitorapi.Interval) bool {
+	return interval.Source == monitorapi.SourceChaos && interval.Message.Reason == monitorapi.ChaosPodKilledReason
+}
+
+func podKey(interval monitorapi.Interval) string {
+	return monitorapi.NamespaceFromLocator(interval.Locator) + "/" + interval.Locator.Keys[monitorapi.LocatorPodKey]
+}
+
+// isOperatorRolloutWindow matches constructed intervals where a cluster operator reported Progressing=True.
+// Operators roll out their operands during this time, so replacing pods is expected.
+func isOperatorRolloutWindow(interval monitorapi.Interval) bool {
+	if interval.Source != monitorapi.SourceOperatorState {
+		return false
+	}
+	return interval.Message.Annotations[monitorapi.AnnotationCondition] == string(configv1.OperatorProgressing)
+}
+
+// operatorOwnsNamespace returns true if the namespace holds the operator or its operands.  The operator and
+// namespace are matched by name, or by the component that owns both of them.
+func operatorOwnsNamespace(operator, namespace string, namespaceComponents map[string]string) bool {
+	if namespace == "openshift-"+operator || namespace == "openshift-"+operator+"-operator" {
+		return true
+	}
+	component := platformidentification.GetBugzillaComponentForOperator(operator)
+	if component == "Unknown" {
+		return false
+	}
+	return namespaceComponents[namespace] == component
+}
+
+func anyOverlaps(windows monitorapi.Intervals, at time.Time) bool {
+	for _, window := range windows {
+		if at.Before(window.From) {
+			continue
+		}
+		// open intervals extend to the end of the run
+		if window.To.IsZero() || !at.After(window.To) {
+			return true
+		}
+	}
+	return false
+}
+
+// duringOwnerRollout returns true if the deletion happened while the operator owning the pod's namespace was rolling out.
+func duringOwnerRollout(rolloutWindows map[string]monitorapi.Intervals, namespaceComponents map[string]string, deletion monitorapi.Interval) bool {
+	namespace := monitorapi.NamespaceFromLocator(deletion.Locator)
+	for operator, windows := range rolloutWindows {
+		if operatorOwnsNamespace(operator, namespace, namespaceComponents) && anyOverlaps(windows, deletion.From) {
+			return true
+		}
+	}
+	return false
+}
+
+// findUnexpectedPodDeletions returns the pod deletions in platform namespaces that do not overlap a node
+// disruption window for the node the pod was running on, or a rollout of the operator owning the pod's namespace, and
+// that the chaos injector did not kill.
+func findUnexpectedPodDeletions(intervals monitorapi.Intervals) monitorapi.Intervals {
+	chaosKilledPods := sets.NewString()
+	nodeWindows := map[string]monitorapi.Intervals{}
+	rolloutWindows := map[string]monitorapi.Intervals{}
+	deletions := monitorapi.Intervals{}
+	for _, interval := range intervals {
+		switch {
+		case isChaosPodKill(interval):
+			chaosKilledPods.Insert(podKey(interval))
+		case isNodeDisruptionWindow(interval):
+			node := interval.Locator.Keys[monitorapi.LocatorNodeKey]
+			nodeWindows[node] = append(nodeWindows[node], interval)
+		case isOperatorRolloutWindow(interval):
+			operator := interval.Locator.Keys[monitorapi.LocatorClusterOperatorKey]
+			rolloutWindows[operator] = append(rolloutWindows[operator], interval)
+		case isPodDeletion(interval):
+			if isPlatformNamespace(monitorapi.NamespaceFromLocator(interval.Locator)) {
+				deletions = append(deletions, interval)
+			}
+		}
+	}
+
+	namespaceComponents := platformidentification.GetNamespacesToBugzillaComponents()
+	unexpected := monitorapi.Intervals{}
+	for _, deletion := range deletions {
+		if chaosKilledPods.Has(podKey(deletion)) {
+			continue
+		}
+		node := deletion.Locator.Keys[monitorapi.LocatorNodeKey]
+		if anyOverlaps(nodeWindows[node], deletion.From) {
+			continue
+		}
+		if duringOwnerRollout(rolloutWindows, namespaceComponents, deletion) {
+			continue
+		}
+		unexpected = append(unexpected, deletion)
+	}
+	sort.Sort(unexpected)
+	return unexpected
+}
+
+func testUnexpectedPlatformPodDeletions(intervals monitorapi.Intervals) []*junitapi.JUnitTestCase {
+	unexpected := findUnexpectedPodDeletions(intervals)
+	if len(unexpected) == 0 {
+		return []*junitapi.JUnitTestCase{{Name: testName}}
+	}
+
+	failures := []string{}
+	for _, deletion := range unexpected {
+		failures = append(failures, deletion.String())
+	}
+	failure := &junitapi.JUnitTestCase{
+		Name:      testName,
+		SystemOut: strings.Join(failures, "\n"),
+		FailureOutput: &junitapi.FailureOutput{
+			Output: fmt.Sprintf("%d pods in platform namespaces were deleted while their node was not draining and their operator was not progressing.  "+
+				"This usually indicates a rogue controller or an unexpected eviction.\n\n%v", len(failures), strings.Join(failures, "\n")),
+		},
+	}
+	// TODO: marked flaky until we have monitored it for consistency
+	return []*junitapi.JUnitTestCase{failure, {Name: testName}}
+}
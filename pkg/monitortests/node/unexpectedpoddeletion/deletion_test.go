@@ -0,0 +1,124 @@
+package unexpectedpoddeletion
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func podDeletion(namespace, node string, reason monitorapi.IntervalReason, at time.Time) monitorapi.Interval {
+	locator := monitorapi.NewLocator().PodFromNames(namespace, "pod-a", "uid-a")
+	locator.Keys[monitorapi.LocatorNodeKey] = node
+	return monitorapi.NewInterval(monitorapi.SourcePodMonitor, monitorapi.Info).
+		Locator(locator).
+		Message(monitorapi.NewMessage().Reason(reason)).
+		Build(at, at)
+}
+
+func TestFindUnexpectedPodDeletions(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	drain := monitorapi.NewInterval(monitorapi.SourceNodeState, monitorapi.Info).
+		Locator(monitorapi.NewLocator().NodeFromName("worker-a")).
+		Message(monitorapi.NewMessage().Reason(monitorapi.NodeUpdateReason).WithAnnotation(monitorapi.AnnotationPhase, "Drain")).
+		Build(start, start.Add(5*time.Minute))
+	rollout := monitorapi.NewInterval(monitorapi.SourceOperatorState, monitorapi.Warning).
+		Locator(monitorapi.NewLocator().ClusterOperator("dns")).
+		Message(monitorapi.NewMessage().WithAnnotation(monitorapi.AnnotationCondition, "Progressing")).
+		Build(start.Add(20*time.Minute), start.Add(25*time.Minute))
+	spotTermination := monitorapi.NewInterval(monitorapi.SourceSpotNode, monitorapi.Warning).
+		Locator(monitorapi.NewLocator().NodeFromName("worker-c")).
+		Message(monitorapi.NewMessage().Reason(monitorapi.SpotNodeTerminationReason)).
+		Build(start.Add(30*time.Minute), start.Add(40*time.Minute))
+	pressure := monitorapi.NewInterval(monitorapi.SourceNodeState, monitorapi.Warning).
+		Locator(monitorapi.NewLocator().NodeFromName("worker-d")).
+		Message(monitorapi.NewMessage().Reason(monitorapi.NodeUnderPressureReason).WithAnnotation(monitorapi.AnnotationState, "MemoryPressure")).
+		Build(start.Add(60*time.Minute), start.Add(65*time.Minute))
+	chaosKill := monitorapi.NewInterval(monitorapi.SourceChaos, monitorapi.Warning).
+		Locator(monitorapi.NewLocator().PodFromNames("openshift-apiserver", "pod-a", "uid-a")).
+		Message(monitorapi.NewMessage().Reason(monitorapi.ChaosPodKilledReason)).
+		Build(start.Add(50*time.Minute), start.Add(50*time.Minute))
+
+	tests := []struct {
+		name     string
+		deletion monitorapi.Interval
+		expected int
+	}{
+		{
+			name:     "deleted during drain of its node",
+			deletion: podDeletion("openshift-dns", "worker-a", monitorapi.PodReasonGracefulDeleteStarted, start.Add(time.Minute)),
+		},
+		{
+			name:     "deleted during drain of another node",
+			deletion: podDeletion("openshift-dns", "worker-b", monitorapi.PodReasonGracefulDeleteStarted, start.Add(time.Minute)),
+			expected: 1,
+		},
+		{
+			name:     "deleted during operator rollout",
+			deletion: podDeletion("openshift-dns", "worker-b", monitorapi.PodReasonForceDelete, start.Add(21*time.Minute)),
+		},
+		{
+			name:     "deleted during rollout of an operator that does not own the namespace",
+			deletion: podDeletion("openshift-monitoring", "worker-b", monitorapi.PodReasonGracefulDeleteStarted, start.Add(21*time.Minute)),
+			expected: 1,
+		},
+		{
+			name:     "evicted outside of any window",
+			deletion: podDeletion("openshift-monitoring", "worker-a", monitorapi.PodReasonEvicted, start.Add(10*time.Minute)),
+			expected: 1,
+		},
+		{
+			name:     "deleted while its spot node was terminated",
+			deletion: podDeletion("openshift-monitoring", "worker-c", monitorapi.PodReasonGracefulDeleteStarted, start.Add(35*time.Minute)),
+		},
+		{
+			name:     "deleted from a spot node outside of its termination",
+			deletion: podDeletion("openshift-monitoring", "worker-c", monitorapi.PodReasonGracefulDeleteStarted, start.Add(45*time.Minute)),
+			expected: 1,
+		},
+		{
+			name:     "killed by the chaos injector",
+			deletion: podDeletion("openshift-apiserver", "worker-a", monitorapi.PodReasonForceDelete, start.Add(50*time.Minute)),
+		},
+		{
+			name:     "evicted while its node was under pressure",
+			deletion: podDeletion("openshift-monitoring", "worker-d", monitorapi.PodReasonEvicted, start.Add(62*time.Minute)),
+		},
+		{
+			name:     "e2e namespaces are ignored",
+			deletion: podDeletion("e2e-test-foo", "worker-a", monitorapi.PodReasonGracefulDeleteStarted, start.Add(10*time.Minute)),
+		},
+		{
+			name:     "must-gather namespaces are ignored",
+			deletion: podDeletion("openshift-must-gather-abcde", "worker-a", monitorapi.PodReasonGracefulDeleteStarted, start.Add(10*time.Minute)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := findUnexpectedPodDeletions(monitorapi.Intervals{drain, rollout, spotTermination, pressure, chaosKill, tt.deletion})
+			require.Len(t, actual, tt.expected)
+
+			junits := testUnexpectedPlatformPodDeletions(monitorapi.Intervals{drain, rollout, spotTermination, pressure, chaosKill, tt.deletion})
+			if tt.expected == 0 {
+				require.Len(t, junits, 1)
+				assert.Nil(t, junits[0].FailureOutput)
+				return
+			}
+			require.Len(t, junits, 2, "failures are reported as flakes")
+			assert.NotNil(t, junits[0].FailureOutput)
+			assert.Nil(t, junits[1].FailureOutput)
+		})
+	}
+}
+
+func TestOperatorOwnsNamespace(t *testing.T) {
+	namespaceComponents := map[string]string{"openshift-kube-apiserver": "kube-apiserver", "openshift-monitoring": "Monitoring"}
+	assert.True(t, operatorOwnsNamespace("dns", "openshift-dns", namespaceComponents))
+	assert.True(t, operatorOwnsNamespace("dns", "openshift-dns-operator", namespaceComponents))
+	assert.True(t, operatorOwnsNamespace("kube-apiserver", "openshift-kube-apiserver", namespaceComponents))
+	assert.False(t, operatorOwnsNamespace("dns", "openshift-monitoring", namespaceComponents))
+	assert.False(t, operatorOwnsNamespace("unknown-operator", "openshift-vsphere-infra", map[string]string{"openshift-vsphere-infra": "Unknown"}))
+}
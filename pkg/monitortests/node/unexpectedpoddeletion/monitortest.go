@@ -0,0 +1,53 @@
+package unexpectedpoddeletion
+
+import (
+	"context"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitortestframework"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	"k8s.io/client-go/rest"
+)
+
+type unexpectedPodDeletionAnalyzer struct {
+}
+
+// NewAnalyzer returns a monitor test that fails when pods in platform namespaces are deleted outside
+// of windows where we expect pods to go away, like node drains and operator rollouts.
+func NewAnalyzer() monitortestframework.MonitorTest {
+	return &unexpectedPodDeletionAnalyzer{}
+}
+
+func (*unexpectedPodDeletionAnalyzer) Describe() monitortestframework.MonitorTestDescription {
+	// the pod deletions are recorded by the pod-lifecycle monitor test.
+	return monitortestframework.MonitorTestDescription{
+		JUnits: []string{testName},
+	}
+}
+
+func (w *unexpectedPodDeletionAnalyzer) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	// pod deletions are recorded by the pod-lifecycle monitor test, we only need to inspect them.
+	return nil
+}
+
+func (w *unexpectedPodDeletionAnalyzer) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	return nil, nil, nil
+}
+
+func (*unexpectedPodDeletionAnalyzer) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	return nil, nil
+}
+
+func (*unexpectedPodDeletionAnalyzer) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	return testUnexpectedPlatformPodDeletions(finalIntervals), nil
+}
+
+func (*unexpectedPodDeletionAnalyzer) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	return nil
+}
+
+func (*unexpectedPodDeletionAnalyzer) Cleanup(ctx context.Context) error {
+	return nil
+}
@@ -8,24 +8,40 @@ import (
 
 	"github.com/openshift/origin/pkg/monitor/monitorapi"
 	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 )
 
 type nodeWatcher struct {
+	kubeInformers informers.SharedInformerFactory
 }
 
 func NewNodeWatcher() monitortestframework.MonitorTest {
 	return &nodeWatcher{}
 }
 
+func (w *nodeWatcher) SetSharedInformers(kubeInformers informers.SharedInformerFactory) {
+	w.kubeInformers = kubeInformers
+}
+
+func (*nodeWatcher) Describe() monitortestframework.MonitorTestDescription {
+	return monitortestframework.MonitorTestDescription{
+		WatchedResources: []string{"nodes"},
+	}
+}
+
 func (w *nodeWatcher) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
-	kubeClient, err := kubernetes.NewForConfig(adminRESTConfig)
-	if err != nil {
-		return err
+	if w.kubeInformers == nil {
+		kubeClient, err := kubernetes.NewForConfig(adminRESTConfig)
+		if err != nil {
+			return err
+		}
+		w.kubeInformers = informers.NewSharedInformerFactory(kubeClient, 0)
+		defer w.kubeInformers.Start(ctx.Done())
 	}
 
-	startNodeMonitoring(ctx, recorder, kubeClient)
+	startNodeMonitoring(ctx, recorder, w.kubeInformers)
 
 	return nil
 }
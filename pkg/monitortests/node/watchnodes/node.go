@@ -9,14 +9,14 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestlibrary/windowsnodes"
 
 	corev1 "k8s.io/api/core/v1"
-	informercorev1 "k8s.io/client-go/informers/core/v1"
-	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/informers"
 	"k8s.io/client-go/tools/cache"
 )
 
-func startNodeMonitoring(ctx context.Context, m monitorapi.RecorderWriter, client kubernetes.Interface) {
+func startNodeMonitoring(ctx context.Context, m monitorapi.RecorderWriter, kubeInformers informers.SharedInformerFactory) {
 	nodeReadyFn := func(node, oldNode *corev1.Node) []monitorapi.Interval {
 		isCreate := false
 		if oldNode == nil {
@@ -44,6 +44,7 @@ func startNodeMonitoring(ctx context.Context, m monitorapi.RecorderWriter, clien
 					Locator(monitorapi.NewLocator().NodeFromName(node.Name)).
 					Message(monitorapi.NewMessage().Reason("NotReady").
 						WithAnnotation(monitorapi.AnnotationRoles, nodeRoles(node)).
+						WithAnnotations(windowsnodes.Annotations(node)).
 						HumanMessage("node is not ready")).Build(now, now),
 			}
 
@@ -53,6 +54,7 @@ func startNodeMonitoring(ctx context.Context, m monitorapi.RecorderWriter, clien
 					Locator(monitorapi.NewLocator().NodeFromName(node.Name)).
 					Message(monitorapi.NewMessage().Reason("Ready").
 						WithAnnotation(monitorapi.AnnotationRoles, nodeRoles(node)).
+						WithAnnotations(windowsnodes.Annotations(node)).
 						HumanMessage("node is ready")).Build(now, now),
 			}
 
@@ -62,6 +64,7 @@ func startNodeMonitoring(ctx context.Context, m monitorapi.RecorderWriter, clien
 					Locator(monitorapi.NewLocator().NodeFromName(node.Name)).
 					Message(monitorapi.NewMessage().Reason("NotReady").
 						WithAnnotation(monitorapi.AnnotationRoles, nodeRoles(node)).
+						WithAnnotations(windowsnodes.Annotations(node)).
 						HumanMessage("node is not ready")).Build(now, now),
 			}
 
@@ -71,6 +74,7 @@ func startNodeMonitoring(ctx context.Context, m monitorapi.RecorderWriter, clien
 					Locator(monitorapi.NewLocator().NodeFromName(node.Name)).
 					Message(monitorapi.NewMessage().Reason("Ready").
 						WithAnnotation(monitorapi.AnnotationRoles, nodeRoles(node)).
+						WithAnnotations(windowsnodes.Annotations(node)).
 						HumanMessage("node is ready")).Build(now, now),
 			}
 		}
@@ -104,6 +108,7 @@ func startNodeMonitoring(ctx context.Context, m monitorapi.RecorderWriter, clien
 								WithAnnotations(map[monitorapi.AnnotationKey]string{
 									monitorapi.AnnotationRoles: roles,
 								}).
+								WithAnnotations(windowsnodes.Annotations(node)).
 								HumanMessage("changed")).
 							Build(now, now))
 				}
@@ -116,6 +121,7 @@ func startNodeMonitoring(ctx context.Context, m monitorapi.RecorderWriter, clien
 							WithAnnotations(map[monitorapi.AnnotationKey]string{
 								monitorapi.AnnotationRoles: roles,
 							}).
+							WithAnnotations(windowsnodes.Annotations(node)).
 							HumanMessage("node was deleted and recreated")).
 						Build(now, now))
 			}
@@ -140,6 +146,7 @@ func startNodeMonitoring(ctx context.Context, m monitorapi.RecorderWriter, clien
 								monitorapi.AnnotationRoles:  roles,
 								monitorapi.AnnotationConfig: newDesired,
 							}).
+							WithAnnotations(windowsnodes.Annotations(node)).
 							HumanMessage("config change requested")).
 						Build(now, now))
 			}
@@ -152,6 +159,7 @@ func startNodeMonitoring(ctx context.Context, m monitorapi.RecorderWriter, clien
 								monitorapi.AnnotationRoles:  roles,
 								monitorapi.AnnotationConfig: newDesired,
 							}).
+							WithAnnotations(windowsnodes.Annotations(node)).
 							HumanMessage("reached desired config")).
 						Build(now, now))
 			}
@@ -159,8 +167,7 @@ func startNodeMonitoring(ctx context.Context, m monitorapi.RecorderWriter, clien
 		},
 	}
 
-	nodeInformer := informercorev1.NewNodeInformer(client, time.Hour, nil)
-	nodeInformer.AddEventHandler(
+	kubeInformers.Core().V1().Nodes().Informer().AddEventHandler(
 		cache.ResourceEventHandlerFuncs{
 			AddFunc: func(obj interface{}) {
 				node, ok := obj.(*corev1.Node)
@@ -183,6 +190,7 @@ func startNodeMonitoring(ctx context.Context, m monitorapi.RecorderWriter, clien
 						WithAnnotations(map[monitorapi.AnnotationKey]string{
 							monitorapi.AnnotationRoles: nodeRoles(node),
 						}).
+						WithAnnotations(windowsnodes.Annotations(node)).
 						HumanMessage("deleted")).
 					Build(now, now)
 				m.AddIntervals(i)
@@ -202,8 +210,6 @@ func startNodeMonitoring(ctx context.Context, m monitorapi.RecorderWriter, clien
 			},
 		},
 	)
-
-	go nodeInformer.Run(ctx.Done())
 }
 
 func nodeRoles(node *corev1.Node) string {
@@ -6,14 +6,12 @@ import (
 	"time"
 
 	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortests/node/crashedcontainers"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/client-go/informers"
-	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/tools/cache"
 )
 
-func startPodMonitoring(ctx context.Context, recorderWriter monitorapi.RecorderWriter, client kubernetes.Interface) {
+func startPodMonitoring(ctx context.Context, recorderWriter monitorapi.RecorderWriter, kubeInformers informers.SharedInformerFactory) {
 	podPendingFn := func(pod, oldPod *corev1.Pod) []monitorapi.Interval {
 		isCreate := oldPod == nil
 		oldPodIsPending := oldPod != nil && oldPod.Status.Phase == "Pending"
@@ -224,6 +222,7 @@ func startPodMonitoring(ctx context.Context, recorderWriter monitorapi.RecorderW
 							Message(monitorapi.NewMessage().
 								Reason(monitorapi.ContainerReasonContainerExit).
 								WithAnnotation(monitorapi.AnnotationContainerExitCode, fmt.Sprintf("%d", containerStatus.LastTerminationState.Terminated.ExitCode)).
+								WithAnnotations(crashCaptureAnnotations(pod, containerName, containerStatus.LastTerminationState.Terminated)).
 								Cause(containerStatus.LastTerminationState.Terminated.Reason).
 								HumanMessage(containerStatus.LastTerminationState.Terminated.Message),
 							).BuildNow(),
@@ -250,6 +249,7 @@ func startPodMonitoring(ctx context.Context, recorderWriter monitorapi.RecorderW
 							Message(monitorapi.NewMessage().
 								Reason(monitorapi.ContainerReasonContainerExit).
 								WithAnnotation(monitorapi.AnnotationContainerExitCode, fmt.Sprintf("%d", containerStatus.State.Terminated.ExitCode)).
+								WithAnnotations(crashCaptureAnnotations(pod, containerName, containerStatus.State.Terminated)).
 								Cause(containerStatus.State.Terminated.Reason).
 								HumanMessage(containerStatus.State.Terminated.Message),
 							).
@@ -524,7 +524,7 @@ func startPodMonitoring(ctx context.Context, recorderWriter monitorapi.RecorderW
 		},
 	}
 
-	listWatch := cache.NewListWatchFromClient(client.CoreV1().RESTClient(), "pods", "", fields.Everything())
+	podInformer := kubeInformers.Core().V1().Pods()
 	customStore := newMonitoringStore(
 		"pods",
 		toCreateFns(podCreatedFns),
@@ -533,14 +533,11 @@ func startPodMonitoring(ctx context.Context, recorderWriter monitorapi.RecorderW
 		recorderWriter,
 		recorderWriter,
 	)
-	reflector := cache.NewReflector(listWatch, &corev1.Pod{}, customStore, 0)
-	go reflector.Run(ctx.Done())
+	podInformer.Informer().AddEventHandler(customStore.eventHandler())
 
 	// start controller to watch for shared pod IPs.
-	sharedInformers := informers.NewSharedInformerFactory(client, 24*time.Hour)
-	podIPController := NewSimultaneousPodIPController(recorderWriter, sharedInformers.Core().V1().Pods())
+	podIPController := NewSimultaneousPodIPController(recorderWriter, podInformer)
 	go podIPController.Run(ctx)
-	go sharedInformers.Start(ctx.Done())
 
 }
 
@@ -626,3 +623,13 @@ func findContainerStatus(status []corev1.ContainerStatus, name string, position
 	}
 	return nil
 }
+
+// crashCaptureAnnotations references the capture of a crashed platform container by the crashed-container-collector.
+func crashCaptureAnnotations(pod *corev1.Pod, containerName string, terminated *corev1.ContainerStateTerminated) map[monitorapi.AnnotationKey]string {
+	if !crashedcontainers.IsPlatformNamespace(pod.Namespace) {
+		return nil
+	}
+	return map[monitorapi.AnnotationKey]string{
+		monitorapi.AnnotationCrashCapture: crashedcontainers.CaptureName(pod.Namespace, pod.Name, containerName, terminated),
+	}
+}
@@ -29,7 +29,7 @@ func intervalsFromEvents_PodChanges(events monitorapi.Intervals, beginning, end
 			continue
 		}
 
-		podPendingState := statetracker.State("Pending", "", "PodWasPending")
+		podPendingState := statetracker.State("Pending", "", monitorapi.PodWasPendingReason)
 
 		switch reason {
 		case monitorapi.PodPendingReason:
@@ -137,20 +137,20 @@ func createPodIntervalsFromInstants(input monitorapi.Intervals, recordedResource
 
 	// inject readiness failures.  These are done separately because they don't impact the overall ready or not ready
 	// recall that a container can fail multiple readiness checks before the failure causes readyz=false on the pod overall.
-	// to do this, we find all the readiness failures, make them one second long, so they appear.
+	// to do this, we find all the readiness failures and mark them for display, so they appear.
 	// we have to render them as a separate bar because we don't want to force the timeline for readiness to be
 	// broken up and the timeline rendering logic we have
 	for locatorKey, instantEvents := range containerToKubeletReadinessChecks {
 		locator := locatorKeyToLocator[locatorKey]
 		for _, instantEvent := range instantEvents {
-			ret = append(ret, monitorapi.NewInterval(monitorapi.SourcePodState, monitorapi.Info).
+			ret = append(ret, monitorapi.NewInterval(monitorapi.SourcePodState, monitorapi.Info).Display().
 				Locator(locator).Message(
 				monitorapi.NewMessage().
 					// Re-use the structured message, but make sure to set our constructed annotation:
 					WithAnnotations(instantEvent.Message.Annotations).
 					Constructed(monitorapi.ConstructionOwnerPodLifecycle).
 					HumanMessage(instantEvent.Message.HumanMessage)).
-				Build(instantEvent.From, instantEvent.From.Add(1*time.Second)))
+				Build(instantEvent.From, instantEvent.From))
 		}
 	}
 
@@ -147,6 +147,25 @@ func newMonitoringStore(
 	return s
 }
 
+// eventHandler feeds the store from a shared informer, which delivers the initial list as adds and resolves
+// relists into adds, updates and deletes itself.
+func (s *monitoringStore) eventHandler() cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			s.AddFunc(obj)
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			s.UpdateFunc(obj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			s.DeleteFunc(obj)
+		},
+	}
+}
+
 func resourceVersionAsInt(obj interface{}) int {
 	metadata, err := meta.Accessor(obj)
 	if err != nil {
@@ -0,0 +1,51 @@
+package watchpods
+
+import (
+	"testing"
+
+	"github.com/openshift/origin/pkg/monitor"
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestMonitoringStoreEventHandler(t *testing.T) {
+	recorder := monitor.NewRecorder()
+	var created, updated, deleted []string
+	store := newMonitoringStore(
+		"pods",
+		[]objCreateFunc{func(obj interface{}) []monitorapi.Interval {
+			created = append(created, obj.(*corev1.Pod).ResourceVersion)
+			return nil
+		}},
+		[]objUpdateFunc{func(obj, oldObj interface{}) []monitorapi.Interval {
+			updated = append(updated, oldObj.(*corev1.Pod).ResourceVersion+"->"+obj.(*corev1.Pod).ResourceVersion)
+			return nil
+		}},
+		[]objDeleteFunc{func(obj interface{}) []monitorapi.Interval {
+			deleted = append(deleted, obj.(*corev1.Pod).ResourceVersion)
+			return nil
+		}},
+		recorder,
+		recorder,
+	)
+	pod := func(uid, resourceVersion string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: uid, UID: types.UID("uid-" + uid), ResourceVersion: resourceVersion}}
+	}
+
+	handler := store.eventHandler()
+	handler.OnAdd(pod("a", "1"), true)
+	handler.OnUpdate(pod("a", "1"), pod("a", "2"))
+	// a resync or redelivery of a version already seen is ignored.
+	handler.OnUpdate(pod("a", "2"), pod("a", "2"))
+	handler.OnDelete(pod("a", "3"))
+	handler.OnAdd(pod("b", "4"), false)
+	handler.OnDelete(cache.DeletedFinalStateUnknown{Key: "ns/b", Obj: pod("b", "5")})
+
+	assert.Equal(t, []string{"1", "4"}, created)
+	assert.Equal(t, []string{"1->2"}, updated)
+	assert.Equal(t, []string{"3", "5"}, deleted)
+}
@@ -8,24 +8,40 @@ import (
 
 	"github.com/openshift/origin/pkg/monitor/monitorapi"
 	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 )
 
 type podWatcher struct {
+	kubeInformers informers.SharedInformerFactory
 }
 
 func NewPodWatcher() monitortestframework.MonitorTest {
 	return &podWatcher{}
 }
 
+func (w *podWatcher) SetSharedInformers(kubeInformers informers.SharedInformerFactory) {
+	w.kubeInformers = kubeInformers
+}
+
+func (*podWatcher) Describe() monitortestframework.MonitorTestDescription {
+	return monitortestframework.MonitorTestDescription{
+		WatchedResources: []string{"pods"},
+	}
+}
+
 func (w *podWatcher) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
-	kubeClient, err := kubernetes.NewForConfig(adminRESTConfig)
-	if err != nil {
-		return err
+	if w.kubeInformers == nil {
+		kubeClient, err := kubernetes.NewForConfig(adminRESTConfig)
+		if err != nil {
+			return err
+		}
+		w.kubeInformers = informers.NewSharedInformerFactory(kubeClient, 0)
+		defer w.kubeInformers.Start(ctx.Done())
 	}
 
-	startPodMonitoring(ctx, recorder, kubeClient)
+	startPodMonitoring(ctx, recorder, w.kubeInformers)
 
 	return nil
 }
@@ -46,6 +46,12 @@ func fetchEventIntervalsForAllAlerts(ctx context.Context, restConfig *rest.Confi
 		return intervals, err
 	}
 
+	// silences are best effort, without them alerts are evaluated as if nothing was silenced.
+	silences, err := fetchAlertSilences(ctx, kubeClient, routeClient)
+	if err != nil {
+		logrus.WithError(err).Warning("unable to list alertmanager silences, silenced alerts will not be annotated")
+	}
+
 	timeRange := prometheusv1.Range{
 		Start: startTime,
 		End:   time.Now(),
@@ -59,7 +65,7 @@ func fetchEventIntervalsForAllAlerts(ctx context.Context, restConfig *rest.Confi
 		fmt.Printf("#### warnings \n\t%v\n", strings.Join(warningsForQuery, "\n\t"))
 	}
 
-	firingAlerts, err := createEventIntervalsForAlerts(ctx, alerts, startTime)
+	firingAlerts, err := createEventIntervalsForAlerts(ctx, alerts, startTime, silences)
 	if err != nil {
 		return nil, err
 	}
@@ -71,7 +77,7 @@ func fetchEventIntervalsForAllAlerts(ctx context.Context, restConfig *rest.Confi
 	if len(warningsForQuery) > 0 {
 		fmt.Printf("#### warnings \n\t%v\n", strings.Join(warningsForQuery, "\n\t"))
 	}
-	pendingAlerts, err := createEventIntervalsForAlerts(ctx, alerts, startTime)
+	pendingAlerts, err := createEventIntervalsForAlerts(ctx, alerts, startTime, silences)
 	if err != nil {
 		return nil, err
 	}
@@ -267,7 +273,7 @@ func indexByLocator(events []monitorapi.Interval) map[string][]monitorapi.Interv
 	return ret
 }
 
-func createEventIntervalsForAlerts(ctx context.Context, alerts prometheustypes.Value, startTime time.Time) ([]monitorapi.Interval, error) {
+func createEventIntervalsForAlerts(ctx context.Context, alerts prometheustypes.Value, startTime time.Time, silences []alertSilence) ([]monitorapi.Interval, error) {
 	ret := []monitorapi.Interval{}
 
 	switch {
@@ -324,7 +330,7 @@ func createEventIntervalsForAlerts(ctx context.Context, alerts prometheustypes.V
 				}
 
 				// if it has been more than five seconds, consider this the start of a new occurrence and add the interval
-				ret = append(ret, alertIntervalTemplate.Build(*alertStartTime, *lastTime))
+				ret = append(ret, splitSilencedAlert(alertIntervalTemplate.Build(*alertStartTime, *lastTime), alert.Metric, silences)...)
 
 				// now reset the tracking
 				alertStartTime = &currTime
@@ -338,7 +344,7 @@ func createEventIntervalsForAlerts(ctx context.Context, alerts prometheustypes.V
 				t := alertStartTime.Add(5 * time.Second)
 				lastTime = &t
 			}
-			ret = append(ret, alertIntervalTemplate.Build(*alertStartTime, *lastTime))
+			ret = append(ret, splitSilencedAlert(alertIntervalTemplate.Build(*alertStartTime, *lastTime), alert.Metric, silences)...)
 		}
 
 	default:
@@ -13,6 +13,8 @@ import (
 
 type alertSummarySerializer struct {
 	adminRESTConfig *rest.Config
+	beginning       time.Time
+	end             time.Time
 }
 
 func NewAlertSummarySerializer() monitortestframework.MonitorTest {
@@ -25,6 +27,8 @@ func (w *alertSummarySerializer) StartCollection(ctx context.Context, adminRESTC
 }
 
 func (w *alertSummarySerializer) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	w.beginning = beginning
+	w.end = end
 	intervals, err := fetchEventIntervalsForAllAlerts(ctx, w.adminRESTConfig, beginning)
 	return intervals, nil, err
 }
@@ -33,12 +37,15 @@ func (*alertSummarySerializer) ConstructComputedIntervals(ctx context.Context, s
 	return nil, nil
 }
 
-func (*alertSummarySerializer) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
-	return nil, nil
+func (w *alertSummarySerializer) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	return testLongPendingAlerts(finalIntervals, w.beginning, w.end), nil
 }
 
-func (*alertSummarySerializer) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
-	return writeAlertDataForJobRun(storageDir, nil, finalIntervals, timeSuffix)
+func (w *alertSummarySerializer) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	if err := writeAlertDataForJobRun(storageDir, nil, finalIntervals, timeSuffix); err != nil {
+		return err
+	}
+	return writePendingAlertDataForJobRun(storageDir, finalIntervals, w.beginning, w.end, timeSuffix)
 }
 
 func (*alertSummarySerializer) Cleanup(ctx context.Context) error {
@@ -0,0 +1,143 @@
+package alertanalyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestlibrary/allowedalerts"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+)
+
+// longPendingFraction is the fraction of the run an alert may be pending before it is reported.  Pending alerts are
+// conditions that did not last long enough to fire, one pending most of the run is an early warning of a problem.
+const longPendingFraction = 0.5
+
+// PendingAlert is the time an alert was pending during the run, not counting the time it was firing.
+type PendingAlert struct {
+	AlertKey    `json:",inline"`
+	Duration    metav1.Duration
+	RunFraction float64
+	Fired       bool
+}
+
+type PendingAlertList struct {
+	Alerts []PendingAlert
+}
+
+func computePendingAlertData(intervals monitorapi.Intervals, beginning, end time.Time) *PendingAlertList {
+	runDuration := end.Sub(beginning)
+
+	firing := map[AlertKey]bool{}
+	pendingByKey := map[AlertKey][]monitorapi.Interval{}
+	for _, interval := range intervals {
+		if interval.Source != monitorapi.SourceAlert {
+			continue
+		}
+		alertName := interval.Locator.Keys[monitorapi.LocatorAlertKey]
+		if len(alertName) == 0 || isIgnoredPendingAlert(interval) {
+			continue
+		}
+		key := AlertKey{
+			Name:      alertName,
+			Namespace: interval.Locator.Keys[monitorapi.LocatorNamespaceKey],
+			Level:     getAlertLevelFromEvent(interval),
+		}
+		switch {
+		case monitorapi.AlertFiring()(interval):
+			firing[key] = true
+		case monitorapi.AlertPending()(interval):
+			// alerts pending for different pods overlap, clip to the run so each moment is only counted once.
+			clipped := interval
+			if clipped.From.Before(beginning) {
+				clipped.From = beginning
+			}
+			if clipped.To.IsZero() || clipped.To.After(end) {
+				clipped.To = end
+			}
+			if clipped.To.After(clipped.From) {
+				pendingByKey[key] = append(pendingByKey[key], clipped)
+			}
+		}
+	}
+
+	ret := &PendingAlertList{Alerts: []PendingAlert{}}
+	for key, pendingIntervals := range pendingByKey {
+		var duration time.Duration
+		for _, window := range nonOverlappingBlackoutWindowsFromEvents(pendingIntervals) {
+			duration += window.To.Sub(window.From)
+		}
+		pendingAlert := PendingAlert{
+			AlertKey: key,
+			Duration: metav1.Duration{Duration: duration},
+			Fired:    firing[key],
+		}
+		if runDuration > 0 {
+			pendingAlert.RunFraction = duration.Seconds() / runDuration.Seconds()
+		}
+		ret.Alerts = append(ret.Alerts, pendingAlert)
+	}
+	sort.Slice(ret.Alerts, func(i, j int) bool {
+		if ret.Alerts[i].Duration != ret.Alerts[j].Duration {
+			return ret.Alerts[i].Duration.Duration > ret.Alerts[j].Duration.Duration
+		}
+		return ret.Alerts[i].Name+ret.Alerts[i].Namespace < ret.Alerts[j].Name+ret.Alerts[j].Namespace
+	})
+	return ret
+}
+
+func isIgnoredPendingAlert(interval monitorapi.Interval) bool {
+	alertName := interval.Locator.Keys[monitorapi.LocatorAlertKey]
+	for _, allowed := range allowedalerts.AllowedAlertNames {
+		if allowed == alertName {
+			return true
+		}
+	}
+	return allowedalerts.IsUserAllowedAlert(interval) || monitorapi.AlertSilenced()(interval)
+}
+
+func writePendingAlertDataForJobRun(artifactDir string, intervals monitorapi.Intervals, beginning, end time.Time, timeSuffix string) error {
+	jsonContent, err := json.MarshalIndent(computePendingAlertData(intervals, beginning, end), "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(artifactDir, fmt.Sprintf("alerts-pending%s.json", timeSuffix)), jsonContent, 0644)
+}
+
+func testLongPendingAlerts(intervals monitorapi.Intervals, beginning, end time.Time) []*junitapi.JUnitTestCase {
+	const testName = "[sig-trt][invariant] alerts should not be pending for more than half of the run"
+
+	longPending := []string{}
+	for _, alert := range computePendingAlertData(intervals, beginning, end).Alerts {
+		if alert.RunFraction < longPendingFraction {
+			continue
+		}
+		fired := "it never fired"
+		if alert.Fired {
+			fired = "it also fired"
+		}
+		longPending = append(longPending, fmt.Sprintf("alert %s in namespace %q was pending for %s (%.0f%% of the run), %s",
+			alert.Name, alert.Namespace, alert.Duration.Duration.Round(time.Second), 100*alert.RunFraction, fired))
+	}
+	if len(longPending) == 0 {
+		return []*junitapi.JUnitTestCase{{Name: testName}}
+	}
+
+	output := fmt.Sprintf("%d alerts were pending for more than half of the run:\n\n%s", len(longPending), strings.Join(longPending, "\n"))
+	failure := &junitapi.JUnitTestCase{
+		Name:      testName,
+		SystemOut: output,
+		FailureOutput: &junitapi.FailureOutput{
+			Output: output,
+		},
+	}
+	// pending alerts are early warnings, they are only reported as flakes.
+	return []*junitapi.JUnitTestCase{failure, {Name: testName}}
+}
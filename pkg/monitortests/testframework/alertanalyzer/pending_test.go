@@ -0,0 +1,63 @@
+package alertanalyzer
+
+import (
+	"testing"
+	"time"
+
+	prometheustypes "github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+func Test_computePendingAlertData(t *testing.T) {
+	start := timeOrDie("2024-03-01T10:00:00Z")
+	end := start.Add(100 * time.Minute)
+
+	alert := func(name, namespace, state string, level monitorapi.IntervalLevel, from, to time.Time) monitorapi.Interval {
+		sample := &prometheustypes.SampleStream{Metric: prometheustypes.Metric{
+			prometheustypes.AlertNameLabel: prometheustypes.LabelValue(name),
+			"namespace":                    prometheustypes.LabelValue(namespace),
+		}}
+		return monitorapi.NewInterval(monitorapi.SourceAlert, level).
+			Locator(monitorapi.NewLocator().AlertFromPromSampleStream(sample)).
+			Message(monitorapi.NewMessage().HumanMessage(name).WithAnnotation(monitorapi.AnnotationAlertState, state)).
+			Build(from, to)
+	}
+
+	intervals := monitorapi.Intervals{
+		// overlapping pending intervals count once, the part before the run is clipped: 60m in total.
+		alert("KubePodNotReady", "ns-a", "pending", monitorapi.Warning, start.Add(-10*time.Minute), start.Add(40*time.Minute)),
+		alert("KubePodNotReady", "ns-a", "pending", monitorapi.Warning, start.Add(30*time.Minute), start.Add(50*time.Minute)),
+		alert("KubePodNotReady", "ns-a", "pending", monitorapi.Warning, start.Add(90*time.Minute), end.Add(10*time.Minute)),
+		// pending briefly before it fired.
+		alert("etcdMembersDown", "openshift-etcd", "pending", monitorapi.Error, start.Add(10*time.Minute), start.Add(20*time.Minute)),
+		alert("etcdMembersDown", "openshift-etcd", "firing", monitorapi.Error, start.Add(20*time.Minute), start.Add(30*time.Minute)),
+		// allowed alerts are never reported.
+		alert("Watchdog", "openshift-monitoring", "pending", monitorapi.Info, start, end),
+	}
+
+	pending := computePendingAlertData(intervals, start, end)
+	require.Len(t, pending.Alerts, 2)
+
+	assert.Equal(t, AlertKey{Name: "KubePodNotReady", Namespace: "ns-a", Level: WarningAlertLevel}, pending.Alerts[0].AlertKey)
+	assert.Equal(t, 60*time.Minute, pending.Alerts[0].Duration.Duration)
+	assert.InDelta(t, 0.6, pending.Alerts[0].RunFraction, 0.001)
+	assert.False(t, pending.Alerts[0].Fired)
+
+	assert.Equal(t, "etcdMembersDown", pending.Alerts[1].Name)
+	assert.Equal(t, 10*time.Minute, pending.Alerts[1].Duration.Duration)
+	assert.True(t, pending.Alerts[1].Fired)
+
+	junits := testLongPendingAlerts(intervals, start, end)
+	require.Len(t, junits, 2)
+	require.NotNil(t, junits[0].FailureOutput)
+	assert.Contains(t, junits[0].FailureOutput.Output, `alert KubePodNotReady in namespace "ns-a" was pending for 1h0m0s (60% of the run), it never fired`)
+	assert.NotContains(t, junits[0].FailureOutput.Output, "etcdMembersDown")
+	assert.Nil(t, junits[1].FailureOutput)
+
+	passing := testLongPendingAlerts(intervals[3:], start, end)
+	require.Len(t, passing, 1)
+	assert.Nil(t, passing[0].FailureOutput)
+}
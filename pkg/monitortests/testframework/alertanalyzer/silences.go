@@ -0,0 +1,195 @@
+package alertanalyzer
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"time"
+
+	routeclient "github.com/openshift/client-go/route/clientset/versioned"
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	prometheustypes "github.com/prometheus/common/model"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/transport"
+)
+
+// alertSilence is the part of an Alertmanager v2 silence we need to decide which alerts it silenced.
+type alertSilence struct {
+	ID        string           `json:"id"`
+	Matchers  []silenceMatcher `json:"matchers"`
+	StartsAt  time.Time        `json:"startsAt"`
+	EndsAt    time.Time        `json:"endsAt"`
+	CreatedBy string           `json:"createdBy"`
+	Comment   string           `json:"comment"`
+}
+
+type silenceMatcher struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	IsRegex bool   `json:"isRegex"`
+	// IsEqual is missing from silences created by older Alertmanagers, those matchers are all equality matchers.
+	IsEqual *bool `json:"isEqual,omitempty"`
+}
+
+func (m silenceMatcher) matches(labels prometheustypes.Metric) bool {
+	value := string(labels[prometheustypes.LabelName(m.Name)])
+	matched := value == m.Value
+	if m.IsRegex {
+		// Alertmanager anchors silence regexes.
+		re, err := regexp.Compile("^(?:" + m.Value + ")$")
+		if err != nil {
+			return false
+		}
+		matched = re.MatchString(value)
+	}
+	if m.IsEqual != nil && !*m.IsEqual {
+		return !matched
+	}
+	return matched
+}
+
+// silences returns true if the silence was in effect for part of from-to and matches every label of the alert.
+func (s alertSilence) silences(labels prometheustypes.Metric, from, to time.Time) bool {
+	if len(s.Matchers) == 0 || !s.StartsAt.Before(to) || !s.EndsAt.After(from) {
+		return false
+	}
+	for _, matcher := range s.Matchers {
+		if !matcher.matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// splitSilencedAlert splits the interval at the boundaries of the silences that match the alert, and marks the parts
+// a silence was in effect for as silenced by it.  Silenced parts stay in the intervals so they are charted, but the
+// alert tests skip them, so a short silence must not exempt the rest of a long firing alert.
+func splitSilencedAlert(interval monitorapi.Interval, labels prometheustypes.Metric, silences []alertSilence) monitorapi.Intervals {
+	matching := []alertSilence{}
+	for _, silence := range silences {
+		if silence.silences(labels, interval.From, interval.To) {
+			matching = append(matching, silence)
+		}
+	}
+	if len(matching) == 0 {
+		return monitorapi.Intervals{interval}
+	}
+	// a single sample has nothing to split.
+	if !interval.To.After(interval.From) {
+		return monitorapi.Intervals{markSilenced(interval, matching[0], interval.From, interval.To)}
+	}
+	sort.SliceStable(matching, func(i, j int) bool { return matching[i].StartsAt.Before(matching[j].StartsAt) })
+
+	ret := monitorapi.Intervals{}
+	current := interval.From
+	for _, silence := range matching {
+		to := silence.EndsAt
+		if to.After(interval.To) {
+			to = interval.To
+		}
+		if !to.After(current) {
+			// an earlier silence already covered this one.
+			continue
+		}
+		from := silence.StartsAt
+		if from.After(current) {
+			unsilenced := interval
+			unsilenced.From, unsilenced.To = current, from
+			ret = append(ret, unsilenced)
+		} else {
+			from = current
+		}
+		ret = append(ret, markSilenced(interval, silence, from, to))
+		current = to
+	}
+	if current.Before(interval.To) {
+		unsilenced := interval
+		unsilenced.From = current
+		ret = append(ret, unsilenced)
+	}
+	return ret
+}
+
+// markSilenced returns a copy of the interval, limited to from-to, that is annotated as silenced by the silence.
+func markSilenced(interval monitorapi.Interval, silence alertSilence, from, to time.Time) monitorapi.Interval {
+	annotations := map[monitorapi.AnnotationKey]string{}
+	for k, v := range interval.Message.Annotations {
+		annotations[k] = v
+	}
+	annotations[monitorapi.AnnotationAlertSilencedBy] = silence.ID
+	interval.Message.Annotations = annotations
+	interval.Message.HumanMessage = fmt.Sprintf("%s silenced by %s: %s", interval.Message.HumanMessage, silence.CreatedBy, silence.Comment)
+	interval.From, interval.To = from, to
+	return interval
+}
+
+// fetchAlertSilences lists the silences, including expired ones, from Alertmanager through its route.
+func fetchAlertSilences(ctx context.Context, kubeClient kubernetes.Interface, routeClient routeclient.Interface) ([]alertSilence, error) {
+	route, err := routeClient.RouteV1().Routes("openshift-monitoring").Get(ctx, "alertmanager-main", metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alertmanager-main route: %w", err)
+	}
+	if len(route.Status.Ingress) == 0 {
+		return nil, fmt.Errorf("alertmanager-main route has not been admitted")
+	}
+	host := route.Status.Ingress[0].Host
+
+	expirationSeconds := int64(time.Hour / time.Second)
+	tokenRequest, err := kubeClient.CoreV1().ServiceAccounts("openshift-monitoring").CreateToken(ctx, "prometheus-k8s",
+		&authenticationv1.TokenRequest{
+			Spec: authenticationv1.TokenRequestSpec{ExpirationSeconds: &expirationSeconds},
+		}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error requesting token for service account prometheus-k8s: %w", err)
+	}
+	routerCAConfigMap, err := kubeClient.CoreV1().ConfigMaps("openshift-config-managed").Get(ctx, "default-ingress-cert", metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get route CA: %w", err)
+	}
+	roots := x509.NewCertPool()
+	roots.AppendCertsFromPEM([]byte(routerCAConfigMap.Data["ca-bundle.crt"]))
+
+	client := &http.Client{
+		Timeout: 30 * time.Second,
+		Transport: transport.NewBearerAuthRoundTripper(tokenRequest.Status.Token, &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{
+				RootCAs:    roots,
+				ServerName: host,
+			},
+		}),
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://"+host+"/api/v2/silences", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("listing silences failed with %s: %s", resp.Status, string(body))
+	}
+	return parseAlertSilences(body)
+}
+
+func parseAlertSilences(body []byte) ([]alertSilence, error) {
+	silences := []alertSilence{}
+	if err := json.Unmarshal(body, &silences); err != nil {
+		return nil, fmt.Errorf("unable to parse silences: %w", err)
+	}
+	return silences, nil
+}
@@ -0,0 +1,150 @@
+package alertanalyzer
+
+import (
+	"testing"
+	"time"
+
+	prometheustypes "github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+func Test_splitSilencedAlert(t *testing.T) {
+	silences, err := parseAlertSilences([]byte(`[
+  {
+    "id": "equal",
+    "status": {"state": "active"},
+    "createdBy": "admin",
+    "comment": "known noisy in this lab",
+    "startsAt": "2024-03-01T10:00:00Z",
+    "endsAt": "2024-03-01T11:00:00Z",
+    "matchers": [
+      {"name": "alertname", "value": "KubePodNotReady", "isRegex": false},
+      {"name": "namespace", "value": "ns-.*", "isRegex": true, "isEqual": true}
+    ]
+  },
+  {
+    "id": "not-equal",
+    "status": {"state": "expired"},
+    "createdBy": "admin",
+    "comment": "everything but etcd",
+    "startsAt": "2024-03-01T12:00:00Z",
+    "endsAt": "2024-03-01T13:00:00Z",
+    "matchers": [
+      {"name": "namespace", "value": "openshift-etcd", "isRegex": false, "isEqual": false}
+    ]
+  }
+]`))
+	require.NoError(t, err)
+	require.Len(t, silences, 2)
+
+	alert := func(name, namespace string, from time.Time) (monitorapi.Interval, prometheustypes.Metric) {
+		labels := prometheustypes.Metric{
+			prometheustypes.AlertNameLabel: prometheustypes.LabelValue(name),
+			"namespace":                    prometheustypes.LabelValue(namespace),
+		}
+		interval := monitorapi.NewInterval(monitorapi.SourceAlert, monitorapi.Warning).
+			Locator(monitorapi.NewLocator().AlertFromPromSampleStream(&prometheustypes.SampleStream{Metric: labels})).
+			Message(monitorapi.NewMessage().HumanMessage(labels.String()).WithAnnotation(monitorapi.AnnotationAlertState, "firing")).
+			Build(from, from.Add(10*time.Minute))
+		return interval, labels
+	}
+
+	tests := []struct {
+		name       string
+		alertName  string
+		namespace  string
+		from       time.Time
+		silencedBy string
+	}{
+		{
+			name:       "matches equal and regex matchers",
+			alertName:  "KubePodNotReady",
+			namespace:  "ns-a",
+			from:       timeOrDie("2024-03-01T10:30:00Z"),
+			silencedBy: "equal",
+		},
+		{
+			name:      "regex is anchored",
+			alertName: "KubePodNotReady",
+			namespace: "other-ns-a",
+			from:      timeOrDie("2024-03-01T10:30:00Z"),
+		},
+		{
+			name:      "before the silence started",
+			alertName: "KubePodNotReady",
+			namespace: "ns-a",
+			from:      timeOrDie("2024-03-01T09:30:00Z"),
+		},
+		{
+			name:       "expired silences still apply to alerts while they were in effect",
+			alertName:  "TargetDown",
+			namespace:  "openshift-monitoring",
+			from:       timeOrDie("2024-03-01T12:30:00Z"),
+			silencedBy: "not-equal",
+		},
+		{
+			name:      "not equal matcher",
+			alertName: "etcdMembersDown",
+			namespace: "openshift-etcd",
+			from:      timeOrDie("2024-03-01T12:30:00Z"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interval, labels := alert(tt.alertName, tt.namespace, tt.from)
+			split := splitSilencedAlert(interval, labels, silences)
+			require.Len(t, split, 1)
+			assert.Equal(t, interval.From, split[0].From)
+			assert.Equal(t, interval.To, split[0].To)
+			assert.Equal(t, tt.silencedBy, split[0].Message.Annotations[monitorapi.AnnotationAlertSilencedBy])
+			assert.Equal(t, len(tt.silencedBy) > 0, monitorapi.AlertSilenced()(split[0]))
+			// the original interval is not modified.
+			assert.False(t, monitorapi.AlertSilenced()(interval))
+		})
+	}
+}
+
+func Test_splitSilencedAlertPartialOverlap(t *testing.T) {
+	labels := prometheustypes.Metric{prometheustypes.AlertNameLabel: "KubePodNotReady"}
+	silence := func(id, from, to string) alertSilence {
+		return alertSilence{
+			ID:       id,
+			Matchers: []silenceMatcher{{Name: "alertname", Value: "KubePodNotReady"}},
+			StartsAt: timeOrDie(from),
+			EndsAt:   timeOrDie(to),
+		}
+	}
+	interval := monitorapi.NewInterval(monitorapi.SourceAlert, monitorapi.Warning).
+		Locator(monitorapi.NewLocator().AlertFromPromSampleStream(&prometheustypes.SampleStream{Metric: labels})).
+		Message(monitorapi.NewMessage().HumanMessage(labels.String()).WithAnnotation(monitorapi.AnnotationAlertState, "firing")).
+		Build(timeOrDie("2024-03-01T10:00:00Z"), timeOrDie("2024-03-01T12:00:00Z"))
+
+	// a one minute silence must not exempt a two hour alert, overlapping silences are only counted once.
+	split := splitSilencedAlert(interval, labels, []alertSilence{
+		silence("later", "2024-03-01T11:00:00Z", "2024-03-01T11:01:00Z"),
+		silence("short", "2024-03-01T10:30:00Z", "2024-03-01T10:31:00Z"),
+		silence("overlapping", "2024-03-01T10:30:30Z", "2024-03-01T10:32:00Z"),
+	})
+
+	expected := []struct {
+		from, to   string
+		silencedBy string
+	}{
+		{from: "2024-03-01T10:00:00Z", to: "2024-03-01T10:30:00Z"},
+		{from: "2024-03-01T10:30:00Z", to: "2024-03-01T10:31:00Z", silencedBy: "short"},
+		{from: "2024-03-01T10:31:00Z", to: "2024-03-01T10:32:00Z", silencedBy: "overlapping"},
+		{from: "2024-03-01T10:32:00Z", to: "2024-03-01T11:00:00Z"},
+		{from: "2024-03-01T11:00:00Z", to: "2024-03-01T11:01:00Z", silencedBy: "later"},
+		{from: "2024-03-01T11:01:00Z", to: "2024-03-01T12:00:00Z"},
+	}
+	require.Len(t, split, len(expected))
+	for i, e := range expected {
+		assert.Equal(t, timeOrDie(e.from), split[i].From, "interval %d", i)
+		assert.Equal(t, timeOrDie(e.to), split[i].To, "interval %d", i)
+		assert.Equal(t, e.silencedBy, split[i].Message.Annotations[monitorapi.AnnotationAlertSilencedBy], "interval %d", i)
+	}
+	assert.Len(t, split.Filter(monitorapi.Not(monitorapi.AlertSilenced())), 3)
+}
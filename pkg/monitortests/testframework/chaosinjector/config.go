@@ -0,0 +1,109 @@
+package chaosinjector
+
+import (
+	"fmt"
+	"os"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+)
+
+// FaultType is the kind of fault the injector introduces.
+type FaultType string
+
+const (
+	// FaultKillControlPlanePod kills a random running pod of a control plane namespace.
+	FaultKillControlPlanePod FaultType = "KillControlPlanePod"
+	// FaultRestartKubelet restarts the kubelet of a random node.
+	FaultRestartKubelet FaultType = "RestartKubelet"
+	// FaultAPILatency delays the responses of the kube-apiserver on a random control plane node.
+	FaultAPILatency FaultType = "APILatency"
+)
+
+// defaultControlPlaneNamespaces are the namespaces a KillControlPlanePod fault picks from when it does not name one.
+var defaultControlPlaneNamespaces = []string{
+	"openshift-apiserver",
+	"openshift-etcd",
+	"openshift-kube-apiserver",
+	"openshift-kube-controller-manager",
+	"openshift-kube-scheduler",
+	"openshift-oauth-apiserver",
+}
+
+// Config is the file of faults passed with --chaos-config, for instance:
+//
+//	faults:
+//	- name: kill a control plane pod
+//	  type: KillControlPlanePod
+//	  after: 10m
+//	- name: restart a worker kubelet
+//	  type: RestartKubelet
+//	  after: 20m
+//	  nodeRole: worker
+//	- name: slow kube-apiserver
+//	  type: APILatency
+//	  after: 30m
+//	  duration: 5m
+//	  latency: 200ms
+type Config struct {
+	Faults []Fault `json:"faults"`
+}
+
+// Fault is injected once, After the monitor starts.
+type Fault struct {
+	Name  string          `json:"name"`
+	Type  FaultType       `json:"type"`
+	After metav1.Duration `json:"after"`
+
+	// Namespace limits a KillControlPlanePod fault to the pods of one namespace.
+	Namespace string `json:"namespace,omitempty"`
+	// NodeRole limits a RestartKubelet fault to the nodes with the node-role.kubernetes.io/<NodeRole> label.
+	NodeRole string `json:"nodeRole,omitempty"`
+	// Duration and Latency are how long and how much an APILatency fault delays the kube-apiserver.
+	Duration metav1.Duration `json:"duration,omitempty"`
+	Latency  metav1.Duration `json:"latency,omitempty"`
+}
+
+func loadConfig(filename string) (*Config, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	config := &Config{}
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %w", filename, err)
+	}
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", filename, err)
+	}
+	return config, nil
+}
+
+func (c *Config) validate() error {
+	names := sets.NewString()
+	for i := range c.Faults {
+		fault := &c.Faults[i]
+		if len(fault.Name) == 0 {
+			return fmt.Errorf("faults[%d] must have a name", i)
+		}
+		if names.Has(fault.Name) {
+			return fmt.Errorf("fault %q is defined more than once", fault.Name)
+		}
+		names.Insert(fault.Name)
+
+		if fault.After.Duration < 0 {
+			return fmt.Errorf("fault %q must not have a negative after", fault.Name)
+		}
+		switch fault.Type {
+		case FaultKillControlPlanePod, FaultRestartKubelet:
+		case FaultAPILatency:
+			if fault.Duration.Duration <= 0 || fault.Latency.Duration <= 0 {
+				return fmt.Errorf("fault %q must have a positive duration and latency", fault.Name)
+			}
+		default:
+			return fmt.Errorf("fault %q has unknown type %q, must be %s, %s or %s", fault.Name, fault.Type, FaultKillControlPlanePod, FaultRestartKubelet, FaultAPILatency)
+		}
+	}
+	return nil
+}
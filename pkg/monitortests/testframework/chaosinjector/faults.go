@@ -0,0 +1,226 @@
+package chaosinjector
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+const (
+	controlPlaneNodeRole = "master"
+	// nodeCommandTimeout bounds how long a node command may run past the duration of its fault.
+	nodeCommandTimeout = 5 * time.Minute
+)
+
+// apiLatencyScript marks the packets the kube-apiserver sends from port 6443 and delays them with netem until the
+// duration passes or the pod is deleted.
+const apiLatencyScript = `set -euo pipefail
+iface=$(chroot /host ip route get 1.1.1.1 | awk '{for (i = 1; i < NF; i++) if ($i == "dev") { print $(i+1); exit }}')
+cleanup() {
+  chroot /host tc qdisc del dev "${iface}" root || true
+  chroot /host iptables -t mangle -D OUTPUT -p tcp --sport 6443 -j MARK --set-mark 0x6443 || true
+}
+trap cleanup EXIT
+chroot /host iptables -t mangle -A OUTPUT -p tcp --sport 6443 -j MARK --set-mark 0x6443
+chroot /host tc qdisc add dev "${iface}" root handle 1: prio
+chroot /host tc qdisc add dev "${iface}" parent 1:3 handle 30: netem delay %dms
+chroot /host tc filter add dev "${iface}" parent 1: protocol ip handle 0x6443 fw flowid 1:3
+sleep %d & wait $!
+`
+
+type injector struct {
+	kubeClient kubernetes.Interface
+	recorder   monitorapi.RecorderWriter
+	// namespace and image run the privileged pods that execute commands on nodes.
+	namespace string
+	image     string
+	random    *rand.Rand
+}
+
+func (i *injector) inject(ctx context.Context, fault Fault) error {
+	switch fault.Type {
+	case FaultKillControlPlanePod:
+		return i.killControlPlanePod(ctx, fault)
+	case FaultRestartKubelet:
+		return i.restartKubelet(ctx, fault)
+	case FaultAPILatency:
+		return i.addAPILatency(ctx, fault)
+	}
+	return fmt.Errorf("unknown fault type %q", fault.Type)
+}
+
+func (i *injector) killControlPlanePod(ctx context.Context, fault Fault) error {
+	namespaces := defaultControlPlaneNamespaces
+	if len(fault.Namespace) > 0 {
+		namespaces = []string{fault.Namespace}
+	}
+	candidates := []corev1.Pod{}
+	for _, namespace := range namespaces {
+		pods, err := i.kubeClient.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		candidates = append(candidates, killablePods(pods.Items)...)
+	}
+	if len(candidates) == 0 {
+		return fmt.Errorf("no running pods in %v", namespaces)
+	}
+	pod := candidates[i.random.Intn(len(candidates))]
+
+	from := time.Now()
+	var err error
+	if isMirrorPod(pod) {
+		// deleting the mirror of a static pod leaves its containers running, stop the sandbox on the node instead.
+		script := fmt.Sprintf("chroot /host crictl stopp $(chroot /host crictl pods --namespace %s --name %s --state ready -q)", pod.Namespace, pod.Name)
+		err = i.runNodeCommand(ctx, pod.Spec.NodeName, script, nodeCommandTimeout)
+	} else {
+		err = i.kubeClient.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{GracePeriodSeconds: ptr.To[int64](0)})
+	}
+	if err != nil {
+		return fmt.Errorf("unable to kill pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	}
+	i.recorder.AddIntervals(faultInterval(fault, monitorapi.ChaosPodKilledReason, monitorapi.NewLocator().PodFromPod(&pod),
+		fmt.Sprintf("killed pod on node/%s", pod.Spec.NodeName)).Build(from, time.Now()))
+	return nil
+}
+
+func (i *injector) restartKubelet(ctx context.Context, fault Fault) error {
+	node, err := i.pickNode(ctx, fault.NodeRole)
+	if err != nil {
+		return err
+	}
+	return i.runRecordedNodeCommand(ctx, fault, monitorapi.ChaosKubeletRestartedReason, node, "restarted the kubelet",
+		"chroot /host systemctl restart kubelet", nodeCommandTimeout)
+}
+
+func (i *injector) addAPILatency(ctx context.Context, fault Fault) error {
+	node, err := i.pickNode(ctx, controlPlaneNodeRole)
+	if err != nil {
+		return err
+	}
+	script := fmt.Sprintf(apiLatencyScript, fault.Latency.Milliseconds(), int64(fault.Duration.Seconds()))
+	return i.runRecordedNodeCommand(ctx, fault, monitorapi.ChaosAPILatencyReason, node,
+		fmt.Sprintf("delayed kube-apiserver responses by %s", fault.Latency.Duration), script, fault.Duration.Duration+nodeCommandTimeout)
+}
+
+// runRecordedNodeCommand records an interval from when the command starts on the node to when it completes.
+func (i *injector) runRecordedNodeCommand(ctx context.Context, fault Fault, reason monitorapi.IntervalReason, node, humanMessage, script string, timeout time.Duration) error {
+	intervalID := i.recorder.StartInterval(faultInterval(fault, reason, monitorapi.NewLocator().NodeFromName(node), humanMessage).Build(time.Now(), time.Time{}))
+	err := i.runNodeCommand(ctx, node, script, timeout)
+	i.recorder.EndInterval(intervalID, time.Now())
+	if err != nil {
+		return fmt.Errorf("node/%s: %w", node, err)
+	}
+	return nil
+}
+
+func (i *injector) pickNode(ctx context.Context, role string) (string, error) {
+	listOptions := metav1.ListOptions{}
+	if len(role) > 0 {
+		listOptions.LabelSelector = "node-role.kubernetes.io/" + role
+	}
+	nodes, err := i.kubeClient.CoreV1().Nodes().List(ctx, listOptions)
+	if err != nil {
+		return "", err
+	}
+	if len(nodes.Items) == 0 {
+		return "", fmt.Errorf("no nodes match %q", listOptions.LabelSelector)
+	}
+	return nodes.Items[i.random.Intn(len(nodes.Items))].Name, nil
+}
+
+// runNodeCommand runs script in a privileged pod on the node with the host filesystem at /host, and waits for it to
+// complete.
+func (i *injector) runNodeCommand(ctx context.Context, node, script string, timeout time.Duration) error {
+	pod, err := i.kubeClient.CoreV1().Pods(i.namespace).Create(ctx, nodeCommandPod(node, i.image, script), metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// the pod has already completed, or deleting it ends the fault early.
+		_ = i.kubeClient.CoreV1().Pods(i.namespace).Delete(context.Background(), pod.Name, metav1.DeleteOptions{})
+	}()
+
+	var phase corev1.PodPhase
+	err = wait.PollUntilContextTimeout(ctx, 5*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		current, err := i.kubeClient.CoreV1().Pods(i.namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if err != nil {
+			// the kubelet may be restarting, keep trying.
+			return false, nil
+		}
+		phase = current.Status.Phase
+		return phase == corev1.PodSucceeded || phase == corev1.PodFailed, nil
+	})
+	if err != nil {
+		return fmt.Errorf("command did not complete, last phase %q: %w", phase, err)
+	}
+	if phase == corev1.PodFailed {
+		return fmt.Errorf("command failed in pod %s/%s", i.namespace, pod.Name)
+	}
+	return nil
+}
+
+func nodeCommandPod(node, image, script string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "chaos-",
+		},
+		Spec: corev1.PodSpec{
+			NodeName:      node,
+			HostPID:       true,
+			HostNetwork:   true,
+			RestartPolicy: corev1.RestartPolicyNever,
+			Tolerations:   []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+			Containers: []corev1.Container{
+				{
+					Name:            "chaos",
+					Image:           image,
+					Command:         []string{"/bin/bash", "-c", script},
+					SecurityContext: &corev1.SecurityContext{Privileged: ptr.To(true), RunAsUser: ptr.To[int64](0)},
+					VolumeMounts:    []corev1.VolumeMount{{Name: "host", MountPath: "/host"}},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{
+					Name:         "host",
+					VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/"}},
+				},
+			},
+		},
+	}
+}
+
+// killablePods are the running pods, completed installers and pruners are not worth killing.
+func killablePods(pods []corev1.Pod) []corev1.Pod {
+	ret := []corev1.Pod{}
+	for _, pod := range pods {
+		if pod.Status.Phase != corev1.PodRunning || len(pod.Spec.NodeName) == 0 || pod.DeletionTimestamp != nil {
+			continue
+		}
+		ret = append(ret, pod)
+	}
+	return ret
+}
+
+func isMirrorPod(pod corev1.Pod) bool {
+	_, ok := pod.Annotations[corev1.MirrorPodAnnotationKey]
+	return ok
+}
+
+func faultInterval(fault Fault, reason monitorapi.IntervalReason, locator monitorapi.Locator, humanMessage string) *monitorapi.IntervalBuilder {
+	return monitorapi.NewInterval(monitorapi.SourceChaos, monitorapi.Warning).
+		Locator(locator).
+		Message(monitorapi.NewMessage().Reason(reason).
+			WithAnnotation(monitorapi.AnnotationChaosFault, fault.Name).
+			HumanMessagef("chaos fault %q %s", fault.Name, humanMessage)).
+		Display()
+}
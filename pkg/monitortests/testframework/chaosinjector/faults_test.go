@@ -0,0 +1,124 @@
+package chaosinjector
+
+import (
+	"context"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openshift/origin/pkg/monitor"
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name: "valid",
+			content: `faults:
+- name: kill
+  type: KillControlPlanePod
+  after: 10m
+- name: slow
+  type: APILatency
+  after: 30m
+  duration: 5m
+  latency: 200ms
+`,
+		},
+		{
+			name: "unknown type",
+			content: `faults:
+- name: reboot
+  type: RebootNode
+`,
+			wantErr: `fault "reboot" has unknown type "RebootNode"`,
+		},
+		{
+			name: "latency without duration",
+			content: `faults:
+- name: slow
+  type: APILatency
+  latency: 200ms
+`,
+			wantErr: `fault "slow" must have a positive duration and latency`,
+		},
+		{
+			name: "duplicate names",
+			content: `faults:
+- name: kill
+  type: KillControlPlanePod
+- name: kill
+  type: RestartKubelet
+`,
+			wantErr: `fault "kill" is defined more than once`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "chaos.yaml")
+			require.NoError(t, os.WriteFile(filename, []byte(tt.content), 0644))
+			config, err := loadConfig(filename)
+			if len(tt.wantErr) > 0 {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 10*time.Minute, config.Faults[0].After.Duration)
+			assert.Equal(t, 200*time.Millisecond, config.Faults[1].Latency.Duration)
+		})
+	}
+}
+
+func TestKillControlPlanePod(t *testing.T) {
+	pod := func(name string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "openshift-apiserver", Name: name, UID: types.UID("uid-" + name)},
+			Spec:       corev1.PodSpec{NodeName: "master-0"},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+	}
+	kubeClient := fake.NewSimpleClientset(pod("apiserver-a", corev1.PodRunning), pod("apiserver-b", corev1.PodPending))
+	recorder := monitor.NewRecorder()
+	injector := &injector{kubeClient: kubeClient, recorder: recorder, random: rand.New(rand.NewSource(1))}
+
+	fault := Fault{Name: "kill", Type: FaultKillControlPlanePod, Namespace: "openshift-apiserver"}
+	require.NoError(t, injector.inject(context.Background(), fault))
+
+	pods, err := kubeClient.CoreV1().Pods("openshift-apiserver").List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, pods.Items, 1, "only the running pod is killed")
+	assert.Equal(t, "apiserver-b", pods.Items[0].Name)
+
+	intervals := recorder.Intervals(time.Time{}, time.Time{})
+	require.Len(t, intervals, 1)
+	assert.Equal(t, monitorapi.SourceChaos, intervals[0].Source)
+	assert.Equal(t, monitorapi.ChaosPodKilledReason, intervals[0].Message.Reason)
+	assert.Equal(t, "kill", intervals[0].Message.Annotations[monitorapi.AnnotationChaosFault])
+	assert.Equal(t, "apiserver-a", intervals[0].Locator.Keys[monitorapi.LocatorPodKey])
+
+	err = injector.inject(context.Background(), Fault{Name: "kill", Type: FaultKillControlPlanePod, Namespace: "openshift-etcd"})
+	assert.Error(t, err, "there is nothing to kill")
+}
+
+func TestFaultJunit(t *testing.T) {
+	fault := Fault{Name: "kill", Type: FaultKillControlPlanePod, After: metav1.Duration{Duration: time.Hour}}
+	assert.NotNil(t, faultJunit(fault, false, nil).SkipMessage, "faults that were never due are skipped")
+	assert.NotNil(t, faultJunit(fault, true, assert.AnError).FailureOutput)
+	passed := faultJunit(fault, true, nil)
+	assert.Nil(t, passed.FailureOutput)
+	assert.Nil(t, passed.SkipMessage)
+}
@@ -0,0 +1,188 @@
+package chaosinjector
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/monitortests/network/disruptionpodnetwork"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+)
+
+type chaosInjector struct {
+	configFile           string
+	payloadImagePullSpec string
+
+	config     *Config
+	kubeClient kubernetes.Interface
+	namespace  string
+	cancel     context.CancelFunc
+	wg         sync.WaitGroup
+
+	lock sync.Mutex
+	// results holds the error of every fault that was due, nil for the faults injected successfully.
+	results map[string]error
+}
+
+// NewChaosInjector injects the faults in configFile while the monitor runs and records what it did as intervals, so
+// that the disruption it causes can be correlated with the faults.  It does nothing when configFile is empty.
+func NewChaosInjector(configFile, payloadImagePullSpec string) monitortestframework.MonitorTest {
+	return &chaosInjector{
+		configFile:           configFile,
+		payloadImagePullSpec: payloadImagePullSpec,
+		results:              map[string]error{},
+	}
+}
+
+func (w *chaosInjector) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	if len(w.configFile) == 0 {
+		return nil
+	}
+	config, err := loadConfig(w.configFile)
+	if err != nil {
+		return err
+	}
+	w.config = config
+
+	w.kubeClient, err = kubernetes.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return err
+	}
+	image, err := disruptionpodnetwork.GetOpenshiftTestsImagePullSpec(ctx, adminRESTConfig, w.payloadImagePullSpec, nil)
+	if err != nil {
+		return fmt.Errorf("unable to determine the image to run node commands with: %w", err)
+	}
+	namespace, err := w.kubeClient.CoreV1().Namespaces().Create(ctx, chaosNamespace(), metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+	w.namespace = namespace.Name
+
+	injector := &injector{
+		kubeClient: w.kubeClient,
+		recorder:   recorder,
+		namespace:  w.namespace,
+		image:      image,
+		random:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	// faults are injected until CollectData, not for as long as the StartCollection context lives.
+	injectCtx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	for _, fault := range w.config.Faults {
+		w.wg.Add(1)
+		go func(fault Fault) {
+			defer w.wg.Done()
+			select {
+			case <-injectCtx.Done():
+				return
+			case <-time.After(fault.After.Duration):
+			}
+			logrus.Infof("injecting chaos fault %q", fault.Name)
+			err := injector.inject(injectCtx, fault)
+			if err != nil {
+				logrus.WithError(err).Errorf("failed to inject chaos fault %q", fault.Name)
+			}
+			w.lock.Lock()
+			defer w.lock.Unlock()
+			w.results[fault.Name] = err
+		}(fault)
+	}
+	return nil
+}
+
+func (w *chaosInjector) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	if w.cancel != nil {
+		w.cancel()
+		w.wg.Wait()
+	}
+	return nil, nil, nil
+}
+
+func (*chaosInjector) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	return nil, nil
+}
+
+func (w *chaosInjector) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	if w.config == nil {
+		return nil, nil
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	ret := []*junitapi.JUnitTestCase{}
+	for _, fault := range w.config.Faults {
+		err, due := w.results[fault.Name]
+		ret = append(ret, faultJunit(fault, due, err))
+	}
+	return ret, nil
+}
+
+func (*chaosInjector) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	return nil
+}
+
+func (w *chaosInjector) Cleanup(ctx context.Context) error {
+	if w.cancel != nil {
+		w.cancel()
+		w.wg.Wait()
+	}
+	if len(w.namespace) > 0 && w.kubeClient != nil {
+		if err := w.kubeClient.CoreV1().Namespaces().Delete(ctx, w.namespace, metav1.DeleteOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func faultJunit(fault Fault, due bool, err error) *junitapi.JUnitTestCase {
+	testName := fmt.Sprintf("[sig-trt] chaos fault %s should be injected", fault.Name)
+	switch {
+	case !due:
+		return &junitapi.JUnitTestCase{
+			Name: testName,
+			SkipMessage: &junitapi.SkipMessage{
+				Message: fmt.Sprintf("the run ended before the fault was due %s after the monitor started", fault.After.Duration),
+			},
+		}
+	case err != nil:
+		return &junitapi.JUnitTestCase{
+			Name: testName,
+			FailureOutput: &junitapi.FailureOutput{
+				Output: fmt.Sprintf("unable to inject %s fault: %v", fault.Type, err),
+			},
+		}
+	default:
+		return &junitapi.JUnitTestCase{
+			Name:      testName,
+			SystemOut: fmt.Sprintf("injected %s fault", fault.Type),
+		}
+	}
+}
+
+func chaosNamespace() *corev1.Namespace {
+	return &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "e2e-chaos-injector-",
+			Labels: map[string]string{
+				"pod-security.kubernetes.io/enforce": "privileged",
+				"pod-security.kubernetes.io/audit":   "privileged",
+				"pod-security.kubernetes.io/warn":    "privileged",
+				// the node command pods run privileged on the host, bypass SCC rather than waiting for a binding to sync.
+				"security.openshift.io/disable-securitycontextconstraints": "true",
+				"security.openshift.io/scc.podSecurityLabelSync":           "false",
+			},
+			Annotations: map[string]string{
+				"workload.openshift.io/allowed": "management",
+			},
+		},
+	}
+}
@@ -0,0 +1,61 @@
+package controlplaneresources
+
+import "github.com/openshift/origin/pkg/monitortestlibrary/platformidentification"
+
+// component is a control plane component, measured as the busiest of its pods in namespace.
+type component struct {
+	name      string
+	namespace string
+}
+
+var controlPlaneComponents = []component{
+	{name: "kube-apiserver", namespace: "openshift-kube-apiserver"},
+	{name: "etcd", namespace: "openshift-etcd"},
+	{name: "kube-controller-manager", namespace: "openshift-kube-controller-manager"},
+	{name: "kube-scheduler", namespace: "openshift-kube-scheduler"},
+	{name: "openshift-apiserver", namespace: "openshift-apiserver"},
+	{name: "oauth-apiserver", namespace: "openshift-oauth-apiserver"},
+}
+
+// Budget is the most a single pod of a component may use.  CPU is compared with the average over the run, short
+// bursts are expected, while memory is compared with the peak working set because that is what gets a pod OOM killed.
+type Budget struct {
+	AverageCPUCores float64
+	MaxMemoryBytes  float64
+}
+
+const gib = 1024 * 1024 * 1024
+
+// budgetsByTopology are keyed by the JobType topology.  There are no budgets for external control planes, their pods
+// are not on the cluster under test.
+var budgetsByTopology = map[string]map[string]Budget{
+	"ha": {
+		"kube-apiserver":          {AverageCPUCores: 2, MaxMemoryBytes: 6 * gib},
+		"etcd":                    {AverageCPUCores: 1.5, MaxMemoryBytes: 3 * gib},
+		"kube-controller-manager": {AverageCPUCores: 0.5, MaxMemoryBytes: 2 * gib},
+		"kube-scheduler":          {AverageCPUCores: 0.3, MaxMemoryBytes: 1 * gib},
+		"openshift-apiserver":     {AverageCPUCores: 0.5, MaxMemoryBytes: 2 * gib},
+		"oauth-apiserver":         {AverageCPUCores: 0.2, MaxMemoryBytes: 0.5 * gib},
+	},
+	// a single replica serves every request, so the apiservers and etcd are allowed more.
+	"single": {
+		"kube-apiserver":          {AverageCPUCores: 3, MaxMemoryBytes: 8 * gib},
+		"etcd":                    {AverageCPUCores: 2, MaxMemoryBytes: 4 * gib},
+		"kube-controller-manager": {AverageCPUCores: 0.5, MaxMemoryBytes: 2 * gib},
+		"kube-scheduler":          {AverageCPUCores: 0.3, MaxMemoryBytes: 1 * gib},
+		"openshift-apiserver":     {AverageCPUCores: 0.75, MaxMemoryBytes: 3 * gib},
+		"oauth-apiserver":         {AverageCPUCores: 0.3, MaxMemoryBytes: 0.75 * gib},
+	},
+	// two replicas share what three do on an HA cluster, and one serves everything while the other node reboots.
+	platformidentification.TopologyTwoNodeArbiter: twoNodeBudgets,
+	platformidentification.TopologyTwoNodeFencing: twoNodeBudgets,
+}
+
+var twoNodeBudgets = map[string]Budget{
+	"kube-apiserver":          {AverageCPUCores: 2.5, MaxMemoryBytes: 7 * gib},
+	"etcd":                    {AverageCPUCores: 1.75, MaxMemoryBytes: 3.5 * gib},
+	"kube-controller-manager": {AverageCPUCores: 0.5, MaxMemoryBytes: 2 * gib},
+	"kube-scheduler":          {AverageCPUCores: 0.3, MaxMemoryBytes: 1 * gib},
+	"openshift-apiserver":     {AverageCPUCores: 0.6, MaxMemoryBytes: 2.5 * gib},
+	"oauth-apiserver":         {AverageCPUCores: 0.25, MaxMemoryBytes: 0.6 * gib},
+}
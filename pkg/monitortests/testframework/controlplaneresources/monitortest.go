@@ -0,0 +1,73 @@
+package controlplaneresources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/monitortestlibrary/platformidentification"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	"k8s.io/client-go/rest"
+)
+
+type controlPlaneResources struct {
+	adminRESTConfig *rest.Config
+	usage           *ResourceUsage
+}
+
+// NewControlPlaneResources tracks the cpu and memory used by the control plane components over the run and fails
+// components that exceed the budget for the cluster topology.
+func NewControlPlaneResources() monitortestframework.MonitorTest {
+	return &controlPlaneResources{}
+}
+
+func (w *controlPlaneResources) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	w.adminRESTConfig = adminRESTConfig
+	return nil
+}
+
+func (w *controlPlaneResources) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	jobType, err := platformidentification.GetJobType(ctx, w.adminRESTConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	cpu, memory, err := fetchResourceUsage(ctx, w.adminRESTConfig, beginning, end)
+	if err != nil {
+		return nil, nil, err
+	}
+	usage := computeResourceUsage(jobType.Topology, cpu, memory)
+	w.usage = &usage
+	return nil, nil, nil
+}
+
+func (*controlPlaneResources) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	return nil, nil
+}
+
+func (w *controlPlaneResources) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	if w.usage == nil {
+		return nil, nil
+	}
+	return budgetJunits(*w.usage), nil
+}
+
+func (w *controlPlaneResources) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	if w.usage == nil {
+		return nil
+	}
+	jsonContent, err := json.MarshalIndent(w.usage, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(storageDir, fmt.Sprintf("control-plane-resource-usage%s.json", timeSuffix)), jsonContent, 0644)
+}
+
+func (*controlPlaneResources) Cleanup(ctx context.Context) error {
+	return nil
+}
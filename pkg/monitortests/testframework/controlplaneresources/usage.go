@@ -0,0 +1,177 @@
+package controlplaneresources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	routeclient "github.com/openshift/client-go/route/clientset/versioned"
+	"github.com/openshift/library-go/test/library/metrics"
+	"github.com/openshift/origin/pkg/monitortestlibrary/prometheus"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	prometheusv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	prometheustypes "github.com/prometheus/common/model"
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// the busiest pod of each component, so the budgets do not depend on the number of replicas.
+	cpuQueryTemplate    = `max by (namespace) (sum by (namespace, pod) (rate(container_cpu_usage_seconds_total{namespace=~"%s",container!="",container!="POD"}[5m])))`
+	memoryQueryTemplate = `max by (namespace) (sum by (namespace, pod) (container_memory_working_set_bytes{namespace=~"%s",container!="",container!="POD"}))`
+	sampleStep          = time.Minute
+)
+
+// ComponentUsage is the resource usage of the busiest pod of a control plane component over the run.
+type ComponentUsage struct {
+	Component       string
+	Namespace       string
+	AverageCPUCores float64
+	MaxCPUCores     float64
+	MaxMemoryBytes  float64
+	// Budget is nil when there is no budget for the topology.
+	Budget *Budget `json:",omitempty"`
+}
+
+// ResourceUsage is written as the control-plane-resource-usage artifact.
+type ResourceUsage struct {
+	Topology   string
+	Components []ComponentUsage
+}
+
+func fetchResourceUsage(ctx context.Context, restConfig *rest.Config, beginning, end time.Time) (prometheustypes.Matrix, prometheustypes.Matrix, error) {
+	logger := logrus.WithField("MonitorTest", "ControlPlaneResources")
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	routeClient, err := routeclient.NewForConfig(restConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_, err = kubeClient.CoreV1().Namespaces().Get(ctx, "openshift-monitoring", metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil, nil
+	}
+
+	prometheusClient, err := metrics.NewPrometheusClient(ctx, kubeClient, routeClient)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := prometheus.EnsureThanosQueriersConnectedToPromSidecars(ctx, prometheusClient); err != nil {
+		return nil, nil, err
+	}
+
+	namespaces := []string{}
+	for _, c := range controlPlaneComponents {
+		namespaces = append(namespaces, c.namespace)
+	}
+	namespaceRegex := strings.Join(namespaces, "|")
+	timeRange := prometheusv1.Range{
+		Start: beginning,
+		End:   end,
+		Step:  sampleStep,
+	}
+
+	query := func(template string) (prometheustypes.Matrix, error) {
+		value, warningsForQuery, err := prometheusClient.QueryRange(ctx, fmt.Sprintf(template, namespaceRegex), timeRange)
+		if err != nil {
+			return nil, err
+		}
+		for _, w := range warningsForQuery {
+			logger.Warnf("resource usage prom query warning: %s", w)
+		}
+		matrix, ok := value.(prometheustypes.Matrix)
+		if !ok {
+			return nil, fmt.Errorf("unexpected prometheus type %s", value.Type())
+		}
+		return matrix, nil
+	}
+	cpu, err := query(cpuQueryTemplate)
+	if err != nil {
+		return nil, nil, err
+	}
+	memory, err := query(memoryQueryTemplate)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cpu, memory, nil
+}
+
+// computeResourceUsage summarizes the cpu and memory series, which are keyed by namespace, per component.
+func computeResourceUsage(topology string, cpu, memory prometheustypes.Matrix) ResourceUsage {
+	cpuByNamespace := map[string]*prometheustypes.SampleStream{}
+	for _, stream := range cpu {
+		cpuByNamespace[string(stream.Metric["namespace"])] = stream
+	}
+	memoryByNamespace := map[string]*prometheustypes.SampleStream{}
+	for _, stream := range memory {
+		memoryByNamespace[string(stream.Metric["namespace"])] = stream
+	}
+
+	ret := ResourceUsage{Topology: topology, Components: []ComponentUsage{}}
+	for _, c := range controlPlaneComponents {
+		cpuStream, memoryStream := cpuByNamespace[c.namespace], memoryByNamespace[c.namespace]
+		if cpuStream == nil && memoryStream == nil {
+			continue
+		}
+		usage := ComponentUsage{Component: c.name, Namespace: c.namespace}
+		if cpuStream != nil && len(cpuStream.Values) > 0 {
+			var total float64
+			for _, value := range cpuStream.Values {
+				total += float64(value.Value)
+				if float64(value.Value) > usage.MaxCPUCores {
+					usage.MaxCPUCores = float64(value.Value)
+				}
+			}
+			usage.AverageCPUCores = total / float64(len(cpuStream.Values))
+		}
+		if memoryStream != nil {
+			for _, value := range memoryStream.Values {
+				if float64(value.Value) > usage.MaxMemoryBytes {
+					usage.MaxMemoryBytes = float64(value.Value)
+				}
+			}
+		}
+		if budget, ok := budgetsByTopology[topology][c.name]; ok {
+			usage.Budget = &budget
+		}
+		ret.Components = append(ret.Components, usage)
+	}
+	return ret
+}
+
+func budgetJunits(usage ResourceUsage) []*junitapi.JUnitTestCase {
+	ret := []*junitapi.JUnitTestCase{}
+	for _, component := range usage.Components {
+		if component.Budget == nil {
+			continue
+		}
+		testName := fmt.Sprintf("[sig-arch] control plane component %s should stay within its %s topology resource budget", component.Component, usage.Topology)
+		violations := []string{}
+		if component.AverageCPUCores > component.Budget.AverageCPUCores {
+			violations = append(violations, fmt.Sprintf("average cpu usage was %.2f cores, the budget is %.2f", component.AverageCPUCores, component.Budget.AverageCPUCores))
+		}
+		if component.MaxMemoryBytes > component.Budget.MaxMemoryBytes {
+			violations = append(violations, fmt.Sprintf("peak memory working set was %.2fGiB, the budget is %.2fGiB", component.MaxMemoryBytes/gib, component.Budget.MaxMemoryBytes/gib))
+		}
+		if len(violations) == 0 {
+			ret = append(ret, &junitapi.JUnitTestCase{Name: testName})
+			continue
+		}
+		output := fmt.Sprintf("the busiest pod in %s exceeded its budget: %s", component.Namespace, strings.Join(violations, ", "))
+		ret = append(ret, &junitapi.JUnitTestCase{
+			Name:      testName,
+			SystemOut: output,
+			FailureOutput: &junitapi.FailureOutput{
+				Output: output,
+			},
+		})
+	}
+	return ret
+}
@@ -0,0 +1,60 @@
+package controlplaneresources
+
+import (
+	"testing"
+	"time"
+
+	prometheustypes "github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeResourceUsage(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	series := func(namespace string, values ...float64) *prometheustypes.SampleStream {
+		stream := &prometheustypes.SampleStream{Metric: prometheustypes.Metric{"namespace": prometheustypes.LabelValue(namespace)}}
+		for i, value := range values {
+			stream.Values = append(stream.Values, prometheustypes.SamplePair{
+				Timestamp: prometheustypes.TimeFromUnixNano(start.Add(time.Duration(i) * sampleStep).UnixNano()),
+				Value:     prometheustypes.SampleValue(value),
+			})
+		}
+		return stream
+	}
+
+	cpu := prometheustypes.Matrix{
+		series("openshift-kube-apiserver", 1, 4, 4),
+		series("openshift-etcd", 0.5, 1.5, 1),
+	}
+	memory := prometheustypes.Matrix{
+		series("openshift-kube-apiserver", 2*gib, 3*gib),
+		series("openshift-etcd", 2*gib, 3.5*gib),
+		series("openshift-kube-scheduler", 0.25*gib),
+	}
+
+	usage := computeResourceUsage("ha", cpu, memory)
+	require.Len(t, usage.Components, 3)
+
+	apiserver := usage.Components[0]
+	assert.Equal(t, "kube-apiserver", apiserver.Component)
+	assert.InDelta(t, 3, apiserver.AverageCPUCores, 0.001)
+	assert.InDelta(t, 4, apiserver.MaxCPUCores, 0.001)
+	assert.InDelta(t, 3*gib, apiserver.MaxMemoryBytes, 1)
+	require.NotNil(t, apiserver.Budget)
+
+	scheduler := usage.Components[2]
+	assert.Equal(t, "kube-scheduler", scheduler.Component)
+	assert.Zero(t, scheduler.AverageCPUCores)
+
+	junits := budgetJunits(usage)
+	require.Len(t, junits, 3)
+	assert.Equal(t, "[sig-arch] control plane component kube-apiserver should stay within its ha topology resource budget", junits[0].Name)
+	require.NotNil(t, junits[0].FailureOutput)
+	assert.Equal(t, "the busiest pod in openshift-kube-apiserver exceeded its budget: average cpu usage was 3.00 cores, the budget is 2.00", junits[0].FailureOutput.Output)
+	require.NotNil(t, junits[1].FailureOutput)
+	assert.Equal(t, "the busiest pod in openshift-etcd exceeded its budget: peak memory working set was 3.50GiB, the budget is 3.00GiB", junits[1].FailureOutput.Output)
+	assert.Nil(t, junits[2].FailureOutput)
+
+	// no budgets for external control planes.
+	assert.Empty(t, budgetJunits(computeResourceUsage("external", cpu, memory)))
+}
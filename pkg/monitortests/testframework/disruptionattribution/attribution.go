@@ -0,0 +1,171 @@
+package disruptionattribution
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// causeMatcher identifies the intervals during which a known cause of disruption was happening.
+type causeMatcher struct {
+	cause string
+	// slack extends the end of the cause, the effects of instants and short operations trail them.
+	slack   time.Duration
+	matches monitorapi.EventIntervalMatchesFunc
+}
+
+var loadBalancerEventReasons = sets.NewString("EnsuringLoadBalancer", "EnsuredLoadBalancer", "UpdatedLoadBalancer", "DeletingLoadBalancer")
+
+// leaderElectionReasons are the moments etcd changes leaders.  The other etcd leadership intervals are whole leader
+// tenures, which together cover the run and would explain any disruption.
+var leaderElectionReasons = sets.New[monitorapi.IntervalReason](
+	monitorapi.EtcdLeaderElectedReason,
+	monitorapi.EtcdLeaderLostReason,
+	monitorapi.EtcdLeaderMissingReason,
+)
+
+var causeMatchers = []causeMatcher{
+	{
+		cause: "node-update",
+		matches: func(interval monitorapi.Interval) bool {
+			return interval.Source == monitorapi.SourceNodeState && interval.Message.Reason == monitorapi.NodeUpdateReason
+		},
+	},
+	{
+		cause: "load-balancer-reconfiguration",
+		slack: time.Minute,
+		matches: func(interval monitorapi.Interval) bool {
+			return interval.Source == monitorapi.SourceKubeEvent && loadBalancerEventReasons.Has(string(interval.Message.Reason))
+		},
+	},
+	{
+		cause: "revision-rollout",
+		matches: func(interval monitorapi.Interval) bool {
+			return interval.Source == monitorapi.SourceOperatorState && interval.Message.Reason == monitorapi.NodeInstallerReason
+		},
+	},
+	{
+		cause: "leader-election",
+		slack: 10 * time.Second,
+		matches: func(interval monitorapi.Interval) bool {
+			return interval.Source == monitorapi.SourceEtcdLeadership && leaderElectionReasons.Has(interval.Message.Reason)
+		},
+	},
+	{
+		cause: "cloud-node-interruption",
+		slack: time.Minute,
+		matches: func(interval monitorapi.Interval) bool {
+			return interval.Source == monitorapi.SourceCloudNodeInterruption
+		},
+	},
+	{
+		cause: "chaos-injection",
+		slack: time.Minute,
+		matches: func(interval monitorapi.Interval) bool {
+			return interval.Source == monitorapi.SourceChaos
+		},
+	},
+	{
+		cause: "apiserver-shutdown",
+		matches: func(interval monitorapi.Interval) bool {
+			return interval.Source == monitorapi.APIServerGracefulShutdown
+		},
+	},
+}
+
+type causeWindow struct {
+	cause    string
+	from, to time.Time
+}
+
+func isDisruption(interval monitorapi.Interval) bool {
+	// outages of the sampler itself are a problem in the cluster running the tests.
+	return interval.Source == monitorapi.SourceDisruption && interval.Level == monitorapi.Error &&
+		interval.Message.Reason != monitorapi.DisruptionSamplerOutageBeganEventReason
+}
+
+// causeWindows finds every known cause, open causes are bounded by end.
+func causeWindows(intervals monitorapi.Intervals, end time.Time) []causeWindow {
+	ret := []causeWindow{}
+	for _, interval := range intervals {
+		for _, matcher := range causeMatchers {
+			if !matcher.matches(interval) {
+				continue
+			}
+			to := interval.To
+			if to.IsZero() {
+				to = end
+			}
+			ret = append(ret, causeWindow{cause: matcher.cause, from: interval.From, to: to.Add(matcher.slack)})
+		}
+	}
+	return ret
+}
+
+// likelyCauses returns the causes overlapping from-to, the cause overlapping the longest first.
+func likelyCauses(from, to time.Time, windows []causeWindow) []string {
+	overlaps := map[string]time.Duration{}
+	for _, window := range windows {
+		if window.from.After(to) || window.to.Before(from) {
+			continue
+		}
+		start, stop := window.from, window.to
+		if start.Before(from) {
+			start = from
+		}
+		if stop.After(to) {
+			stop = to
+		}
+		if overlap := stop.Sub(start); overlap >= overlaps[window.cause] {
+			overlaps[window.cause] = overlap
+		}
+	}
+
+	ret := []string{}
+	for cause := range overlaps {
+		ret = append(ret, cause)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if overlaps[ret[i]] != overlaps[ret[j]] {
+			return overlaps[ret[i]] > overlaps[ret[j]]
+		}
+		return ret[i] < ret[j]
+	})
+	return ret
+}
+
+// attributeDisruption constructs an interval for every disruption naming its likely causes, or noting that it has
+// none.
+func attributeDisruption(intervals monitorapi.Intervals, end time.Time) monitorapi.Intervals {
+	windows := causeWindows(intervals, end)
+
+	ret := monitorapi.Intervals{}
+	for _, disruption := range intervals.Filter(isDisruption) {
+		to := disruption.To
+		if to.IsZero() {
+			to = end
+		}
+		causes := likelyCauses(disruption.From, to, windows)
+
+		message := monitorapi.NewMessage().Constructed("disruption-attribution")
+		level := monitorapi.Info
+		if len(causes) == 0 {
+			level = monitorapi.Warning
+			message = message.Reason(monitorapi.DisruptionUnattributedReason).
+				HumanMessage("disruption overlapped no known cause")
+		} else {
+			message = message.Reason(monitorapi.DisruptionAttributedReason).
+				WithAnnotation(monitorapi.AnnotationDisruptionCauses, strings.Join(causes, ",")).
+				HumanMessage(fmt.Sprintf("disruption most likely caused by %s", strings.Join(causes, ", ")))
+		}
+		ret = append(ret, monitorapi.NewInterval(monitorapi.SourceDisruptionAttribution, level).
+			Locator(disruption.Locator).
+			Message(message).
+			Build(disruption.From, disruption.To))
+	}
+	return ret
+}
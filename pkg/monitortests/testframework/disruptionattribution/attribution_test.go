@@ -0,0 +1,68 @@
+package disruptionattribution
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttributeDisruption(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	disruption := func(backend string, from, to time.Duration) monitorapi.Interval {
+		return monitorapi.NewInterval(monitorapi.SourceDisruption, monitorapi.Error).
+			Locator(monitorapi.NewLocator().DisruptionRequiredOnly(backend, "openshift-tests")).
+			Message(monitorapi.NewMessage().Reason(monitorapi.DisruptionBeganEventReason).HumanMessage("stopped responding")).
+			Build(start.Add(from), start.Add(to))
+	}
+	nodeUpdate := monitorapi.NewInterval(monitorapi.SourceNodeState, monitorapi.Info).
+		Locator(monitorapi.NewLocator().NodeFromName("worker-0")).
+		Message(monitorapi.NewMessage().Reason(monitorapi.NodeUpdateReason).HumanMessage("updating")).
+		Build(start.Add(10*time.Minute), start.Add(20*time.Minute))
+	leaderElected := monitorapi.NewInterval(monitorapi.SourceEtcdLeadership, monitorapi.Warning).
+		Locator(monitorapi.NewLocator().NodeFromName("master-0")).
+		Message(monitorapi.NewMessage().Reason(monitorapi.EtcdLeaderElectedReason).HumanMessage("elected")).
+		Build(start.Add(19*time.Minute), start.Add(19*time.Minute))
+	// a load balancer event is an instant, its effect trails it.
+	loadBalancerUpdated := monitorapi.NewInterval(monitorapi.SourceKubeEvent, monitorapi.Info).
+		Locator(monitorapi.NewLocator().NodeFromName("worker-1")).
+		Message(monitorapi.NewMessage().Reason("UpdatedLoadBalancer").HumanMessage("updated")).
+		Build(start.Add(30*time.Minute), start.Add(30*time.Minute))
+	// leader tenures span the run, only the elections explain disruption.
+	leaderTenure := monitorapi.NewInterval(monitorapi.SourceEtcdLeadership, monitorapi.Warning).
+		Locator(monitorapi.NewLocator().EtcdMemberFromNames("master-0", "member-0")).
+		Message(monitorapi.NewMessage().Constructed(monitorapi.ConstructionOwnerEtcdLifecycle).HumanMessage("")).
+		Build(start, end)
+	samplerOutage := monitorapi.NewInterval(monitorapi.SourceDisruption, monitorapi.Error).
+		Locator(monitorapi.NewLocator().DisruptionRequiredOnly("ingress", "openshift-tests")).
+		Message(monitorapi.NewMessage().Reason(monitorapi.DisruptionSamplerOutageBeganEventReason).HumanMessage("dns")).
+		Build(start, start.Add(time.Minute))
+
+	intervals := monitorapi.Intervals{
+		disruption("kube-api", 15*time.Minute, 19*time.Minute+5*time.Second),
+		disruption("ingress", 30*time.Minute+30*time.Second, 31*time.Minute),
+		disruption("oauth-api", 45*time.Minute, 46*time.Minute),
+		nodeUpdate, leaderElected, leaderTenure, loadBalancerUpdated, samplerOutage,
+	}
+	attributed := attributeDisruption(intervals, end)
+	require.Len(t, attributed, 3)
+
+	assert.Equal(t, monitorapi.DisruptionAttributedReason, attributed[0].Message.Reason)
+	assert.Equal(t, "node-update,leader-election", attributed[0].Message.Annotations[monitorapi.AnnotationDisruptionCauses])
+	assert.Equal(t, "kube-api", monitorapi.BackendDisruptionNameFromLocator(attributed[0].Locator))
+
+	assert.Equal(t, "load-balancer-reconfiguration", attributed[1].Message.Annotations[monitorapi.AnnotationDisruptionCauses])
+
+	assert.Equal(t, monitorapi.DisruptionUnattributedReason, attributed[2].Message.Reason)
+	assert.Equal(t, monitorapi.Warning, attributed[2].Level)
+
+	junits := unattributedJunits(attributed.Filter(func(interval monitorapi.Interval) bool {
+		return interval.Message.Reason == monitorapi.DisruptionUnattributedReason
+	}))
+	require.Len(t, junits, 2, "unattributed disruption flakes")
+	assert.Contains(t, junits[0].FailureOutput.Output, "oauth-api")
+	assert.Len(t, unattributedJunits(nil), 1)
+}
@@ -0,0 +1,82 @@
+package healthdelta
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+)
+
+const healthDeltaTestName = "[sig-arch] cluster health should not be worse at the end of the run than at the start"
+
+// healthDelta lists every indicator that got worse between the snapshots.  What only existed at one end, a node
+// added or removed during the run, is not compared.
+func healthDelta(before, after *healthSnapshot) []string {
+	var worse []string
+
+	for operator, afterConditions := range after.OperatorConditions {
+		beforeConditions, ok := before.OperatorConditions[operator]
+		if !ok {
+			continue
+		}
+		if beforeConditions[configv1.OperatorAvailable] == configv1.ConditionTrue && afterConditions[configv1.OperatorAvailable] != configv1.ConditionTrue {
+			worse = append(worse, fmt.Sprintf("clusteroperator/%s was Available and is no longer", operator))
+		}
+		if beforeConditions[configv1.OperatorDegraded] != configv1.ConditionTrue && afterConditions[configv1.OperatorDegraded] == configv1.ConditionTrue {
+			worse = append(worse, fmt.Sprintf("clusteroperator/%s was not Degraded and now is", operator))
+		}
+	}
+
+	for node, afterConditions := range after.NodeConditions {
+		beforeConditions, ok := before.NodeConditions[node]
+		if !ok {
+			continue
+		}
+		if beforeConditions[corev1.NodeReady] == corev1.ConditionTrue && afterConditions[corev1.NodeReady] != corev1.ConditionTrue {
+			worse = append(worse, fmt.Sprintf("node/%s was Ready and is no longer", node))
+		}
+		for _, pressure := range []corev1.NodeConditionType{corev1.NodeMemoryPressure, corev1.NodeDiskPressure, corev1.NodePIDPressure} {
+			if beforeConditions[pressure] != corev1.ConditionTrue && afterConditions[pressure] == corev1.ConditionTrue {
+				worse = append(worse, fmt.Sprintf("node/%s did not have %s and now has", node, pressure))
+			}
+		}
+	}
+
+	if len(before.AlertsUnavailable) == 0 && len(after.AlertsUnavailable) == 0 {
+		for _, alert := range sets.List(sets.New(after.FiringAlerts...).Difference(sets.New(before.FiringAlerts...))) {
+			worse = append(worse, fmt.Sprintf("alert %s was not firing and now is", alert))
+		}
+	}
+
+	for container, afterCount := range after.RestartCounts {
+		beforeCount, ok := before.RestartCounts[container]
+		if !ok || afterCount <= beforeCount {
+			continue
+		}
+		worse = append(worse, fmt.Sprintf("container %s restarted %d times", container, afterCount-beforeCount))
+	}
+
+	sort.Strings(worse)
+	return worse
+}
+
+// healthDeltaJunit reports what got worse, regardless of which tests passed.
+func healthDeltaJunit(worse []string) []*junitapi.JUnitTestCase {
+	if len(worse) == 0 {
+		return []*junitapi.JUnitTestCase{{Name: healthDeltaTestName}}
+	}
+	failure := &junitapi.JUnitTestCase{
+		Name:      healthDeltaTestName,
+		SystemOut: strings.Join(worse, "\n"),
+		FailureOutput: &junitapi.FailureOutput{
+			Output: fmt.Sprintf("%d cluster health indicators got worse over the run.\n\n%s", len(worse), strings.Join(worse, "\n")),
+		},
+	}
+	// TODO: marked flaky until we know which regressions are expected of upgrades and disruptive jobs
+	return []*junitapi.JUnitTestCase{failure, {Name: healthDeltaTestName}}
+}
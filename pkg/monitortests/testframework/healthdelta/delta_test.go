@@ -0,0 +1,73 @@
+package healthdelta
+
+import (
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestHealthDelta(t *testing.T) {
+	before := &healthSnapshot{
+		OperatorConditions: map[string]map[configv1.ClusterStatusConditionType]configv1.ConditionStatus{
+			"etcd":    {configv1.OperatorAvailable: configv1.ConditionTrue, configv1.OperatorDegraded: configv1.ConditionFalse},
+			"ingress": {configv1.OperatorAvailable: configv1.ConditionTrue, configv1.OperatorDegraded: configv1.ConditionFalse},
+			"dns":     {configv1.OperatorAvailable: configv1.ConditionFalse},
+		},
+		NodeConditions: map[string]map[corev1.NodeConditionType]corev1.ConditionStatus{
+			"master-0": {corev1.NodeReady: corev1.ConditionTrue, corev1.NodeDiskPressure: corev1.ConditionFalse},
+			"worker-0": {corev1.NodeReady: corev1.ConditionTrue},
+		},
+		FiringAlerts: []string{"KubePodNotReady namespace/openshift-etcd severity/warning"},
+		RestartCounts: map[string]int32{
+			"openshift-etcd/etcd-master-0/etcd":   1,
+			"openshift-dns/dns-default-abcde/dns": 0,
+		},
+	}
+	after := &healthSnapshot{
+		OperatorConditions: map[string]map[configv1.ClusterStatusConditionType]configv1.ConditionStatus{
+			"etcd":    {configv1.OperatorAvailable: configv1.ConditionTrue, configv1.OperatorDegraded: configv1.ConditionTrue},
+			"ingress": {configv1.OperatorAvailable: configv1.ConditionFalse, configv1.OperatorDegraded: configv1.ConditionFalse},
+			"dns":     {configv1.OperatorAvailable: configv1.ConditionFalse},
+		},
+		NodeConditions: map[string]map[corev1.NodeConditionType]corev1.ConditionStatus{
+			"master-0": {corev1.NodeReady: corev1.ConditionTrue, corev1.NodeDiskPressure: corev1.ConditionTrue},
+			"worker-0": {corev1.NodeReady: corev1.ConditionFalse},
+			"worker-1": {corev1.NodeReady: corev1.ConditionFalse},
+		},
+		FiringAlerts: []string{
+			"KubePodNotReady namespace/openshift-etcd severity/warning",
+			"etcdMembersDown namespace/openshift-etcd severity/critical",
+		},
+		RestartCounts: map[string]int32{
+			"openshift-etcd/etcd-master-0/etcd":   3,
+			"openshift-dns/dns-default-fghij/dns": 5,
+		},
+	}
+
+	assert.Equal(t, []string{
+		"alert etcdMembersDown namespace/openshift-etcd severity/critical was not firing and now is",
+		"clusteroperator/etcd was not Degraded and now is",
+		"clusteroperator/ingress was Available and is no longer",
+		"container openshift-etcd/etcd-master-0/etcd restarted 2 times",
+		"node/master-0 did not have DiskPressure and now has",
+		"node/worker-0 was Ready and is no longer",
+	}, healthDelta(before, after))
+
+	after.AlertsUnavailable = "no route to prometheus"
+	assert.NotContains(t, healthDelta(before, after), "alert etcdMembersDown namespace/openshift-etcd severity/critical was not firing and now is")
+
+	assert.Empty(t, healthDelta(before, before))
+}
+
+func TestHealthDeltaJunit(t *testing.T) {
+	passed := healthDeltaJunit(nil)
+	assert.Len(t, passed, 1)
+	assert.Nil(t, passed[0].FailureOutput)
+
+	flaked := healthDeltaJunit([]string{"node/worker-0 was Ready and is no longer"})
+	assert.Len(t, flaked, 2)
+	assert.Equal(t, "1 cluster health indicators got worse over the run.\n\nnode/worker-0 was Ready and is no longer", flaked[0].FailureOutput.Output)
+	assert.Nil(t, flaked[1].FailureOutput)
+}
@@ -0,0 +1,113 @@
+package healthdelta
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	configclient "github.com/openshift/client-go/config/clientset/versioned"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	exutil "github.com/openshift/origin/test/extended/util"
+)
+
+type healthDeltaReport struct {
+	notSupportedReason error
+	snapshotter        *snapshotter
+
+	before *healthSnapshot
+	after  *healthSnapshot
+}
+
+// NewHealthDeltaReport compares the health of the cluster at the start and the end of the run, so that a cluster
+// left worse than it was found is noticed even when every test passed.
+func NewHealthDeltaReport() monitortestframework.MonitorTest {
+	return &healthDeltaReport{}
+}
+
+func (*healthDeltaReport) Describe() monitortestframework.MonitorTestDescription {
+	return monitortestframework.MonitorTestDescription{
+		WatchedResources: []string{"clusteroperators.config.openshift.io", "nodes", "pods"},
+		JUnits:           []string{healthDeltaTestName},
+	}
+}
+
+func (w *healthDeltaReport) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	kubeClient, err := kubernetes.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return err
+	}
+	isMicroShift, err := exutil.IsMicroShiftCluster(kubeClient)
+	if err != nil {
+		return fmt.Errorf("unable to determine if cluster is MicroShift: %v", err)
+	}
+	if isMicroShift {
+		w.notSupportedReason = &monitortestframework.NotSupportedError{Reason: "platform MicroShift not supported"}
+		return w.notSupportedReason
+	}
+	configClient, err := configclient.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return err
+	}
+
+	w.snapshotter = &snapshotter{kubeClient: kubeClient, configClient: configClient, restConfig: adminRESTConfig}
+	w.before, err = w.snapshotter.take(ctx)
+	return err
+}
+
+func (w *healthDeltaReport) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	if w.notSupportedReason != nil {
+		return nil, nil, w.notSupportedReason
+	}
+	if w.before == nil {
+		return nil, nil, nil
+	}
+	var err error
+	w.after, err = w.snapshotter.take(ctx)
+	return nil, nil, err
+}
+
+func (*healthDeltaReport) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	return nil, nil
+}
+
+func (w *healthDeltaReport) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	if w.notSupportedReason != nil {
+		return nil, w.notSupportedReason
+	}
+	if w.before == nil || w.after == nil {
+		return nil, nil
+	}
+	return healthDeltaJunit(healthDelta(w.before, w.after)), nil
+}
+
+func (w *healthDeltaReport) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	if w.before == nil || w.after == nil {
+		return nil
+	}
+	report := struct {
+		Worse  []string        `json:"worse"`
+		Before *healthSnapshot `json:"before"`
+		After  *healthSnapshot `json:"after"`
+	}{
+		Worse:  healthDelta(w.before, w.after),
+		Before: w.before,
+		After:  w.after,
+	}
+	jsonContent, err := json.MarshalIndent(report, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(storageDir, fmt.Sprintf("cluster-health-delta%s.json", timeSuffix)), jsonContent, 0644)
+}
+
+func (*healthDeltaReport) Cleanup(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,154 @@
+package healthdelta
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	configclient "github.com/openshift/client-go/config/clientset/versioned"
+	routeclient "github.com/openshift/client-go/route/clientset/versioned"
+	"github.com/openshift/library-go/test/library/metrics"
+	prometheustypes "github.com/prometheus/common/model"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// firingAlertsQuery excludes the alerts that always fire, like Watchdog, and informational ones.
+const firingAlertsQuery = `ALERTS{alertstate="firing",severity=~"warning|critical"}`
+
+// healthSnapshot holds the health indicators of the cluster at one point of the run.
+type healthSnapshot struct {
+	Time time.Time `json:"time"`
+	// OperatorConditions maps clusteroperators to the status of their Available and Degraded conditions.
+	OperatorConditions map[string]map[configv1.ClusterStatusConditionType]configv1.ConditionStatus `json:"operatorConditions"`
+	// NodeConditions maps nodes to the status of their Ready and pressure conditions.
+	NodeConditions map[string]map[corev1.NodeConditionType]corev1.ConditionStatus `json:"nodeConditions"`
+	// FiringAlerts lists the warning and critical alerts that are firing, as "ALERT namespace/NAMESPACE severity/SEVERITY".
+	FiringAlerts []string `json:"firingAlerts"`
+	// AlertsUnavailable is why the alerts could not be read, alerts are not compared unless both snapshots have them.
+	AlertsUnavailable string `json:"alertsUnavailable,omitempty"`
+	// RestartCounts maps the containers of platform pods, as "namespace/pod/container", to their restart count.
+	// Pods are keyed by name, a recreated pod starts over with a lower count.
+	RestartCounts map[string]int32 `json:"restartCounts"`
+}
+
+var (
+	operatorConditionTypes = []configv1.ClusterStatusConditionType{configv1.OperatorAvailable, configv1.OperatorDegraded}
+	nodeConditionTypes     = []corev1.NodeConditionType{corev1.NodeReady, corev1.NodeMemoryPressure, corev1.NodeDiskPressure, corev1.NodePIDPressure}
+)
+
+type snapshotter struct {
+	kubeClient   kubernetes.Interface
+	configClient configclient.Interface
+	restConfig   *rest.Config
+}
+
+// take records every indicator, failing only if the cluster cannot be read.  Alerts are best effort, the monitoring
+// stack may be unavailable.
+func (s *snapshotter) take(ctx context.Context) (*healthSnapshot, error) {
+	snapshot := &healthSnapshot{
+		Time:               time.Now(),
+		OperatorConditions: map[string]map[configv1.ClusterStatusConditionType]configv1.ConditionStatus{},
+		NodeConditions:     map[string]map[corev1.NodeConditionType]corev1.ConditionStatus{},
+		RestartCounts:      map[string]int32{},
+	}
+
+	operators, err := s.configClient.ConfigV1().ClusterOperators().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list clusteroperators: %w", err)
+	}
+	for _, operator := range operators.Items {
+		conditions := map[configv1.ClusterStatusConditionType]configv1.ConditionStatus{}
+		for _, condition := range operator.Status.Conditions {
+			for _, conditionType := range operatorConditionTypes {
+				if condition.Type == conditionType {
+					conditions[condition.Type] = condition.Status
+				}
+			}
+		}
+		snapshot.OperatorConditions[operator.Name] = conditions
+	}
+
+	nodes, err := s.kubeClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list nodes: %w", err)
+	}
+	for _, node := range nodes.Items {
+		conditions := map[corev1.NodeConditionType]corev1.ConditionStatus{}
+		for _, condition := range node.Status.Conditions {
+			for _, conditionType := range nodeConditionTypes {
+				if condition.Type == conditionType {
+					conditions[condition.Type] = condition.Status
+				}
+			}
+		}
+		snapshot.NodeConditions[node.Name] = conditions
+	}
+
+	pods, err := s.kubeClient.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list pods: %w", err)
+	}
+	for _, pod := range pods.Items {
+		if !isPlatformNamespace(pod.Namespace) {
+			continue
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			snapshot.RestartCounts[fmt.Sprintf("%s/%s/%s", pod.Namespace, pod.Name, status.Name)] = status.RestartCount
+		}
+	}
+
+	snapshot.FiringAlerts, err = s.firingAlerts(ctx)
+	if err != nil {
+		logrus.WithError(err).Warn("unable to read the firing alerts for the health snapshot")
+		snapshot.AlertsUnavailable = err.Error()
+	}
+	return snapshot, nil
+}
+
+// isPlatformNamespace excludes test namespaces, whose pods are expected to restart and go away.
+func isPlatformNamespace(namespace string) bool {
+	return strings.HasPrefix(namespace, "openshift-") || strings.HasPrefix(namespace, "kube-")
+}
+
+func (s *snapshotter) firingAlerts(ctx context.Context) ([]string, error) {
+	if _, err := s.kubeClient.CoreV1().Namespaces().Get(ctx, "openshift-monitoring", metav1.GetOptions{}); apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	routeClient, err := routeclient.NewForConfig(s.restConfig)
+	if err != nil {
+		return nil, err
+	}
+	prometheusClient, err := metrics.NewPrometheusClient(ctx, s.kubeClient, routeClient)
+	if err != nil {
+		return nil, err
+	}
+	result, _, err := prometheusClient.Query(ctx, firingAlertsQuery, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	vector, ok := result.(prometheustypes.Vector)
+	if !ok {
+		return nil, fmt.Errorf("expected a vector from %s, got %s", firingAlertsQuery, result.Type())
+	}
+	return alertKeys(vector), nil
+}
+
+func alertKeys(vector prometheustypes.Vector) []string {
+	keys := []string{}
+	for _, sample := range vector {
+		key := string(sample.Metric[prometheustypes.AlertNameLabel])
+		if namespace := sample.Metric["namespace"]; len(namespace) > 0 {
+			key += " namespace/" + string(namespace)
+		}
+		key += " severity/" + string(sample.Metric["severity"])
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,79 @@
+package intervalreasons
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	"k8s.io/client-go/rest"
+)
+
+const testName = "[sig-trt] intervals should only use registered reasons"
+
+type reasonValidator struct {
+}
+
+// NewReasonValidator flags intervals whose reasons are not part of the monitorapi reason taxonomy.
+func NewReasonValidator() monitortestframework.MonitorTest {
+	return &reasonValidator{}
+}
+
+func (*reasonValidator) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	return nil
+}
+
+func (*reasonValidator) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	return nil, nil, nil
+}
+
+func (*reasonValidator) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	return nil, nil
+}
+
+func (*reasonValidator) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	return reasonJunits(monitorapi.UnknownIntervalReasons(finalIntervals)), nil
+}
+
+func (*reasonValidator) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	return nil
+}
+
+func (*reasonValidator) Cleanup(ctx context.Context) error {
+	return nil
+}
+
+// reasonJunits flakes rather than fails, an unregistered reason only makes the charts and queries less coherent.
+func reasonJunits(unknown map[monitorapi.IntervalSource][]monitorapi.IntervalReason) []*junitapi.JUnitTestCase {
+	if len(unknown) == 0 {
+		return []*junitapi.JUnitTestCase{{Name: testName}}
+	}
+
+	sources := []string{}
+	for source := range unknown {
+		sources = append(sources, string(source))
+	}
+	sort.Strings(sources)
+
+	lines := []string{}
+	for _, source := range sources {
+		reasons := []string{}
+		for _, reason := range unknown[monitorapi.IntervalSource(source)] {
+			reasons = append(reasons, string(reason))
+		}
+		lines = append(lines, fmt.Sprintf("source/%s produced unregistered reasons: %s", source, strings.Join(reasons, ", ")))
+	}
+	output := fmt.Sprintf("register these reasons with monitorapi.RegisterIntervalReason:\n%s", strings.Join(lines, "\n"))
+	return []*junitapi.JUnitTestCase{
+		{
+			Name:          testName,
+			SystemOut:     output,
+			FailureOutput: &junitapi.FailureOutput{Output: output},
+		},
+		{Name: testName},
+	}
+}
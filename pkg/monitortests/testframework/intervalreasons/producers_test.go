@@ -0,0 +1,86 @@
+package intervalreasons
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+// TestProducedReasonsAreRegistered walks the source of pkg for the reasons produced in the tree, so that the reason
+// validator does not flake on reasons we produce ourselves.  It finds string literals passed to Reason(...), converted
+// with IntervalReason(...), passed as the reason of a statetracker.State(...), and the IntervalReason constants of
+// monitorapi.
+func TestProducedReasonsAreRegistered(t *testing.T) {
+	pkgDir := filepath.Join("..", "..", "..")
+	produced := map[monitorapi.IntervalReason]string{}
+	addLiteral := func(fileSet *token.FileSet, expr ast.Expr) {
+		literal, ok := expr.(*ast.BasicLit)
+		if !ok || literal.Kind != token.STRING {
+			return
+		}
+		value, err := strconv.Unquote(literal.Value)
+		require.NoError(t, err)
+		if len(value) > 0 {
+			produced[monitorapi.IntervalReason(value)] = fileSet.Position(literal.Pos()).String()
+		}
+	}
+
+	err := filepath.WalkDir(pkgDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		fileSet := token.NewFileSet()
+		file, err := parser.ParseFile(fileSet, path, nil, 0)
+		if err != nil {
+			return err
+		}
+		isMonitorAPI := file.Name.Name == "monitorapi"
+		ast.Inspect(file, func(node ast.Node) bool {
+			switch node := node.(type) {
+			case *ast.CallExpr:
+				name := ""
+				switch fun := node.Fun.(type) {
+				case *ast.SelectorExpr:
+					name = fun.Sel.Name
+				case *ast.Ident:
+					name = fun.Name
+				}
+				switch {
+				case (name == "Reason" || name == "IntervalReason") && len(node.Args) == 1:
+					addLiteral(fileSet, node.Args[0])
+				case name == "State" && len(node.Args) == 3:
+					addLiteral(fileSet, node.Args[2])
+				}
+			case *ast.ValueSpec:
+				if ident, ok := node.Type.(*ast.Ident); isMonitorAPI && ok && ident.Name == "IntervalReason" {
+					for _, value := range node.Values {
+						addLiteral(fileSet, value)
+					}
+				}
+			}
+			return true
+		})
+		return nil
+	})
+	require.NoError(t, err)
+	// make sure the walk found the producers at all.
+	require.Contains(t, produced, monitorapi.EtcdLeaderElectedReason)
+
+	for reason, position := range produced {
+		_, ok := monitorapi.IntervalReasonDescription(reason)
+		assert.True(t, ok, "%s produces unregistered reason %q", position, reason)
+	}
+}
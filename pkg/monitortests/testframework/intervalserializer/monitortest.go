@@ -2,7 +2,9 @@ package intervalserializer
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
 	"path/filepath"
 	"time"
 
@@ -39,7 +41,30 @@ func (*intervalSerializer) EvaluateTestsFromConstructedIntervals(ctx context.Con
 }
 
 func (*intervalSerializer) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
-	return monitorserialization.EventsToFile(filepath.Join(storageDir, fmt.Sprintf("e2e-events%s.json", timeSuffix)), finalIntervals)
+	// the partitions are compacted to keep flapping conditions from inflating them.
+	partitionedDir := filepath.Join(storageDir, fmt.Sprintf("e2e-intervals%s", timeSuffix))
+	if err := monitorserialization.IntervalsToPartitionedDir(partitionedDir, finalIntervals.Compact(monitorapi.DefaultCompactionGap)); err != nil {
+		return err
+	}
+	// e2e-events is still read by tooling outside this repo.
+	if err := monitorserialization.EventsToFile(filepath.Join(storageDir, fmt.Sprintf("e2e-events%s.json", timeSuffix)), finalIntervals); err != nil {
+		return err
+	}
+	return writeE2ETestAttribution(filepath.Join(storageDir, fmt.Sprintf("e2e-test-attribution%s.json", timeSuffix)), finalIntervals)
+}
+
+// writeE2ETestAttribution records which e2e tests were running during every interval, kept apart from the intervals
+// so that they are not copied onto each of them.
+func writeE2ETestAttribution(filename string, finalIntervals monitorapi.Intervals) error {
+	attribution := finalIntervals.AttributeToRunningE2ETests()
+	if len(attribution) == 0 {
+		return nil
+	}
+	jsonContent, err := json.MarshalIndent(attribution, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, jsonContent, 0644)
 }
 
 func (*intervalSerializer) Cleanup(ctx context.Context) error {
@@ -0,0 +1,46 @@
+package intervaltriggers
+
+import (
+	"context"
+	"encoding/json"
+
+	operatorv1client "github.com/openshift/client-go/operator/clientset/versioned/typed/operator/v1"
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// collectEtcdMemberStatus dumps the etcd operator's view of the members and the etcd pods, while a member is crash
+// looping and before the operator has a chance to recover it.
+func collectEtcdMemberStatus(ctx context.Context, adminRESTConfig *rest.Config, trigger monitorapi.Interval) ([]Artifact, error) {
+	operatorClient, err := operatorv1client.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return nil, err
+	}
+	kubeClient, err := kubernetes.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	etcd, err := operatorClient.Etcds().Get(ctx, "cluster", metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	etcdContent, err := json.MarshalIndent(etcd.Status, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+	pods, err := kubeClient.CoreV1().Pods("openshift-etcd").List(ctx, metav1.ListOptions{LabelSelector: "app=etcd"})
+	if err != nil {
+		return nil, err
+	}
+	podContent, err := json.MarshalIndent(pods, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+	return []Artifact{
+		{Name: "etcd-operator-status.json", Content: etcdContent},
+		{Name: "etcd-pods.json", Content: podContent},
+	}, nil
+}
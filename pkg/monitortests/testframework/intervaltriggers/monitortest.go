@@ -0,0 +1,142 @@
+package intervaltriggers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	"k8s.io/client-go/rest"
+)
+
+const pollInterval = 15 * time.Second
+
+// intervalTriggeredCollectors runs collectors while the run is in progress, as soon as the intervals they react to
+// are recorded, instead of at the fixed points of the monitor test lifecycle.
+type intervalTriggeredCollectors struct {
+	rules []TriggerRule
+
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	lock sync.Mutex
+	runs []triggeredRun
+}
+
+func NewIntervalTriggeredCollectors(rules []TriggerRule) monitortestframework.MonitorTest {
+	return &intervalTriggeredCollectors{rules: rules}
+}
+
+func (w *intervalTriggeredCollectors) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	reader, ok := recorder.(monitorapi.RecorderReader)
+	if !ok {
+		logrus.Warn("recorder cannot be read, interval triggered collectors will not run")
+		return nil
+	}
+
+	ctx, w.cancel = context.WithCancel(ctx)
+	w.done = make(chan struct{})
+	go func() {
+		defer close(w.done)
+		w.watchIntervals(ctx, adminRESTConfig, reader)
+	}()
+	return nil
+}
+
+func (w *intervalTriggeredCollectors) watchIntervals(ctx context.Context, adminRESTConfig *rest.Config, reader monitorapi.RecorderReader) {
+	engine := newTriggerEngine(w.rules)
+	since := time.Now()
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		now := time.Now()
+		rules, triggers := engine.triggered(reader.Intervals(since, now))
+		since = now
+		for i, rule := range rules {
+			w.lock.Lock()
+			w.runs = append(w.runs, runCollector(ctx, adminRESTConfig, rule, triggers[i]))
+			w.lock.Unlock()
+		}
+	}
+}
+
+func runCollector(ctx context.Context, adminRESTConfig *rest.Config, rule TriggerRule, trigger monitorapi.Interval) triggeredRun {
+	logrus.Infof("running collector %s, triggered by %s", rule.Name, trigger.String())
+	run := triggeredRun{Rule: rule.Name, Trigger: trigger.String()}
+	artifacts, err := rule.Collect(ctx, adminRESTConfig, trigger)
+	if err != nil {
+		logrus.WithError(err).Warnf("collector %s failed", rule.Name)
+		run.Error = err.Error()
+	}
+	run.artifacts = artifacts
+	return run
+}
+
+func (w *intervalTriggeredCollectors) stop() {
+	if w.cancel == nil {
+		return
+	}
+	w.cancel()
+	<-w.done
+}
+
+func (w *intervalTriggeredCollectors) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	w.stop()
+	return nil, nil, nil
+}
+
+func (*intervalTriggeredCollectors) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	return nil, nil
+}
+
+func (*intervalTriggeredCollectors) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	return nil, nil
+}
+
+func (w *intervalTriggeredCollectors) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if len(w.runs) == 0 {
+		return nil
+	}
+
+	triggerDir := filepath.Join(storageDir, fmt.Sprintf("interval-triggers%s", timeSuffix))
+	for i := range w.runs {
+		run := &w.runs[i]
+		runDir := fmt.Sprintf("%s-%d", run.Rule, i)
+		if err := os.MkdirAll(filepath.Join(triggerDir, runDir), 0755); err != nil {
+			return err
+		}
+		for _, artifact := range run.artifacts {
+			name := filepath.Join(runDir, artifact.Name)
+			if err := os.WriteFile(filepath.Join(triggerDir, name), artifact.Content, 0644); err != nil {
+				return err
+			}
+			run.Artifacts = append(run.Artifacts, name)
+		}
+	}
+	jsonContent, err := json.MarshalIndent(w.runs, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(triggerDir, "runs.json"), jsonContent, 0644)
+}
+
+func (w *intervalTriggeredCollectors) Cleanup(ctx context.Context) error {
+	w.stop()
+	return nil
+}
@@ -0,0 +1,103 @@
+package intervaltriggers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/rest"
+)
+
+// Artifact is a file written by a collector, Name is relative to the directory of the trigger.
+type Artifact struct {
+	Name    string
+	Content []byte
+}
+
+// Collector gathers data from the cluster in reaction to the interval that triggered it.
+type Collector func(ctx context.Context, adminRESTConfig *rest.Config, trigger monitorapi.Interval) ([]Artifact, error)
+
+// TriggerRule runs Collect when an interval matching Matches is recorded.
+type TriggerRule struct {
+	Name    string
+	Matches monitorapi.EventIntervalMatchesFunc
+	Collect Collector
+	// MaxRuns bounds how often the collector runs, a condition that keeps recurring does not need to be collected
+	// every time.  Defaults to 1.
+	MaxRuns int
+}
+
+// DefaultTriggerRules are run by the interval-triggered-collectors monitor test.
+var DefaultTriggerRules = []TriggerRule{
+	{
+		Name: "etcd-crashloop",
+		Matches: monitorapi.And(
+			monitorapi.IsInNamespaces(sets.NewString("openshift-etcd")),
+			isPathologicalBackOff,
+		),
+		Collect: collectEtcdMemberStatus,
+		MaxRuns: 2,
+	},
+}
+
+func isPathologicalBackOff(interval monitorapi.Interval) bool {
+	return interval.Source == monitorapi.SourceKubeEvent &&
+		interval.Message.Reason == "BackOff" &&
+		interval.Message.Annotations[monitorapi.AnnotationPathological] == "true" &&
+		strings.Contains(interval.Message.HumanMessage, "restarting failed container")
+}
+
+// triggeredRun is one run of a collector.
+type triggeredRun struct {
+	Rule      string
+	Trigger   string
+	Artifacts []string `json:",omitempty"`
+	Error     string   `json:",omitempty"`
+
+	artifacts []Artifact
+}
+
+// triggerEngine matches recorded intervals against the rules.  Each interval is only considered once, even when it is
+// returned by several polls of the recorder.
+type triggerEngine struct {
+	rules []TriggerRule
+	runs  map[string]int
+	seen  sets.String
+}
+
+func newTriggerEngine(rules []TriggerRule) *triggerEngine {
+	return &triggerEngine{
+		rules: rules,
+		runs:  map[string]int{},
+		seen:  sets.NewString(),
+	}
+}
+
+// triggered returns the rules to run for the intervals and the interval that triggered each.
+func (e *triggerEngine) triggered(intervals monitorapi.Intervals) ([]TriggerRule, monitorapi.Intervals) {
+	rules := []TriggerRule{}
+	triggers := monitorapi.Intervals{}
+	for _, interval := range intervals {
+		key := fmt.Sprintf("%s %s", interval.From.UTC().Format("2006-01-02T15:04:05.000000Z"), interval.String())
+		if e.seen.Has(key) {
+			continue
+		}
+		e.seen.Insert(key)
+
+		for _, rule := range e.rules {
+			maxRuns := rule.MaxRuns
+			if maxRuns == 0 {
+				maxRuns = 1
+			}
+			if e.runs[rule.Name] >= maxRuns || !rule.Matches(interval) {
+				continue
+			}
+			e.runs[rule.Name]++
+			rules = append(rules, rule)
+			triggers = append(triggers, interval)
+		}
+	}
+	return rules, triggers
+}
@@ -0,0 +1,54 @@
+package intervaltriggers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+func TestTriggerEngine(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	backOff := func(namespace string, pathological bool, at time.Time) monitorapi.Interval {
+		event := &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Namespace: namespace, Name: "event"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: namespace, Name: "etcd-master-0"},
+			Reason:         "BackOff",
+		}
+		message := monitorapi.NewMessage().Reason("BackOff").HumanMessage("Back-off restarting failed container etcd in pod etcd-master-0")
+		if pathological {
+			message = message.WithAnnotation(monitorapi.AnnotationPathological, "true")
+		}
+		return monitorapi.NewInterval(monitorapi.SourceKubeEvent, monitorapi.Warning).
+			Locator(monitorapi.NewLocator().KubeEvent(event)).
+			Message(message).
+			Build(at, at.Add(time.Second))
+	}
+
+	engine := newTriggerEngine(DefaultTriggerRules)
+
+	rules, triggers := engine.triggered(monitorapi.Intervals{
+		backOff("openshift-etcd", false, start),
+		backOff("openshift-kube-apiserver", true, start),
+	})
+	assert.Empty(t, rules, "only pathological back offs in openshift-etcd trigger")
+
+	first := backOff("openshift-etcd", true, start.Add(time.Minute))
+	rules, triggers = engine.triggered(monitorapi.Intervals{first})
+	require.Len(t, rules, 1)
+	assert.Equal(t, "etcd-crashloop", rules[0].Name)
+	assert.Equal(t, first, triggers[0])
+
+	// the same interval returned by the next poll is not triggered again.
+	rules, _ = engine.triggered(monitorapi.Intervals{first, backOff("openshift-etcd", true, start.Add(2*time.Minute))})
+	require.Len(t, rules, 1)
+
+	// the rule has run MaxRuns times.
+	rules, _ = engine.triggered(monitorapi.Intervals{backOff("openshift-etcd", true, start.Add(3*time.Minute))})
+	assert.Empty(t, rules)
+}
@@ -0,0 +1,162 @@
+package leakedresources
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	leakedNamespacesTestName            = "[sig-arch] e2e tests should not leak namespaces"
+	leakedClusterScopedResourceTestName = "[sig-arch] e2e tests should not leak cluster-scoped resources"
+)
+
+// findLeakedResources returns everything present in after that was not present in before, is not already being
+// deleted, and was created by an e2e test.  Only namespaces created by the e2e framework are considered, monitor
+// tests clean up their own.  Cluster-scoped objects must either look like an e2e fixture or have been created
+// while a test was running, operators and dynamic provisioning create them throughout the run.
+func findLeakedResources(before, after clusterSnapshot, testIntervals monitorapi.Intervals) (namespaces, clusterScoped []resourceRecord) {
+	for uid, record := range after {
+		if _, existed := before[uid]; existed {
+			continue
+		}
+		if record.Terminating {
+			continue
+		}
+		if record.Resource == namespacesResource {
+			if _, ok := record.Labels[e2eFrameworkNamespaceLabel]; ok {
+				namespaces = append(namespaces, record)
+			}
+			continue
+		}
+		if isClusterScopedE2EResource(record, testIntervals) {
+			clusterScoped = append(clusterScoped, record)
+		}
+	}
+	sortRecords(namespaces)
+	sortRecords(clusterScoped)
+	return namespaces, clusterScoped
+}
+
+// isClusterScopedE2EResource decides whether a new cluster-scoped object belongs to an e2e test.
+func isClusterScopedE2EResource(record resourceRecord, testIntervals monitorapi.Intervals) bool {
+	// owned objects are removed by the garbage collector along with their owner.
+	if record.HasOwner {
+		return false
+	}
+	if len(record.RelatedNamespace) > 0 {
+		// dynamically provisioned volumes and bindings for platform service accounts belong to the platform.
+		return strings.HasPrefix(record.RelatedNamespace, "e2e-")
+	}
+	if strings.Contains(record.Name, "e2e") {
+		return true
+	}
+	for label := range record.Labels {
+		if strings.Contains(label, "e2e") {
+			return true
+		}
+	}
+	for _, prefix := range platformNamePrefixes {
+		if strings.HasPrefix(record.Name, prefix) {
+			return false
+		}
+	}
+	return len(testsRunningAt(testIntervals, record.CreationTimestamp)) > 0
+}
+
+// platformNamePrefixes are used by the objects created by kube and operators, including during upgrades.
+var platformNamePrefixes = []string{"system:", "openshift", "cluster-", "csi-"}
+
+func sortRecords(records []resourceRecord) {
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].String() < records[j].String()
+	})
+}
+
+// creationTimesByNamespace uses the resources recorded during the run to find when objects in every namespace
+// were created.  These let us narrow down which of several parallel tests actually used a namespace.
+func creationTimesByNamespace(recordedResources monitorapi.ResourcesMap) map[string][]time.Time {
+	ret := map[string][]time.Time{}
+	for _, instances := range recordedResources {
+		for key, obj := range instances {
+			if len(key.Namespace) == 0 {
+				continue
+			}
+			accessor, err := meta.Accessor(obj)
+			if err != nil {
+				continue
+			}
+			ret[key.Namespace] = append(ret[key.Namespace], accessor.GetCreationTimestamp().Time)
+		}
+	}
+	return ret
+}
+
+// testsRunningAt returns the names of the e2e tests whose constructed interval contains the given time.
+func testsRunningAt(testIntervals monitorapi.Intervals, at time.Time) sets.String {
+	ret := sets.NewString()
+	for _, interval := range testIntervals {
+		if at.Before(interval.From) || at.After(interval.To) {
+			continue
+		}
+		if testName, ok := monitorapi.E2ETestFromLocator(interval.Locator); ok {
+			ret.Insert(testName)
+		}
+	}
+	return ret
+}
+
+// attributeToTests returns the tests that were running when the resource was created.  Objects recorded during the
+// run inside the namespace, or inside the namespace a cluster-scoped object was created for, further narrow the
+// candidates, as long as at least one candidate remains.
+func attributeToTests(record resourceRecord, testIntervals monitorapi.Intervals, namespaceCreationTimes map[string][]time.Time) []string {
+	candidates := testsRunningAt(testIntervals, record.CreationTimestamp)
+	namespace := record.RelatedNamespace
+	if record.Resource == namespacesResource {
+		namespace = record.Name
+	}
+	if len(namespace) == 0 {
+		return candidates.List()
+	}
+	for _, createdAt := range namespaceCreationTimes[namespace] {
+		narrowed := candidates.Intersection(testsRunningAt(testIntervals, createdAt))
+		if len(narrowed) > 0 {
+			candidates = narrowed
+		}
+	}
+	return candidates.List()
+}
+
+func describeLeaks(records []resourceRecord, testIntervals monitorapi.Intervals, namespaceCreationTimes map[string][]time.Time) []string {
+	ret := []string{}
+	for _, record := range records {
+		tests := attributeToTests(record, testIntervals, namespaceCreationTimes)
+		if len(tests) == 0 {
+			ret = append(ret, fmt.Sprintf("%v created at %v by an unknown test", record, record.CreationTimestamp.UTC().Format(time.RFC3339)))
+			continue
+		}
+		ret = append(ret, fmt.Sprintf("%v created at %v by one of:\n\t%v", record, record.CreationTimestamp.UTC().Format(time.RFC3339), strings.Join(tests, "\n\t")))
+	}
+	return ret
+}
+
+func leakJunit(testName string, leaks []string) []*junitapi.JUnitTestCase {
+	if len(leaks) == 0 {
+		return []*junitapi.JUnitTestCase{{Name: testName}}
+	}
+	failure := &junitapi.JUnitTestCase{
+		Name:      testName,
+		SystemOut: strings.Join(leaks, "\n"),
+		FailureOutput: &junitapi.FailureOutput{
+			Output: fmt.Sprintf("%d resources were created during the run and not removed.\n\n%v", len(leaks), strings.Join(leaks, "\n")),
+		},
+	}
+	// TODO: marked flaky until we have monitored it for consistency
+	return []*junitapi.JUnitTestCase{failure, {Name: testName}}
+}
@@ -0,0 +1,102 @@
+package leakedresources
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func e2eTestInterval(testName string, from, to time.Time) monitorapi.Interval {
+	return monitorapi.NewInterval(monitorapi.SourceE2ETest, monitorapi.Info).
+		Locator(monitorapi.NewLocator().E2ETest(testName)).
+		Message(monitorapi.NewMessage().WithAnnotation(monitorapi.AnnotationStatus, "Passed")).
+		Build(from, to)
+}
+
+func TestFindAndAttributeLeakedResources(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	before := clusterSnapshot{
+		"ns-existing": {Resource: namespacesResource, Name: "openshift-etcd", UID: "ns-existing"},
+	}
+	after := clusterSnapshot{
+		"ns-existing": before["ns-existing"],
+		"ns-leaked": {Resource: namespacesResource, Name: "e2e-test-foo-abcde", UID: "ns-leaked",
+			Labels: map[string]string{e2eFrameworkNamespaceLabel: "foo"}, CreationTimestamp: start.Add(time.Minute)},
+		"ns-monitor": {Resource: namespacesResource, Name: "e2e-pod-network-disruption-test-abcde", UID: "ns-monitor",
+			CreationTimestamp: start},
+		"ns-terminating": {Resource: namespacesResource, Name: "e2e-test-bar-abcde", UID: "ns-terminating",
+			Labels: map[string]string{e2eFrameworkNamespaceLabel: "bar"}, Terminating: true},
+		"crd-leaked": {Resource: clusterScopedResources[0], Name: "foos.example.com", UID: "crd-leaked",
+			CreationTimestamp: start.Add(6 * time.Minute)},
+		"crd-after-tests": {Resource: clusterScopedResources[0], Name: "bars.example.com", UID: "crd-after-tests",
+			CreationTimestamp: start.Add(time.Hour)},
+		"clusterrole-operator": {Resource: clusterScopedResources[1], Name: "openshift-csi-driver-role", UID: "clusterrole-operator",
+			CreationTimestamp: start.Add(6 * time.Minute)},
+		"clusterrole-owned": {Resource: clusterScopedResources[1], Name: "e2e-owned", UID: "clusterrole-owned",
+			CreationTimestamp: start.Add(6 * time.Minute), HasOwner: true},
+		"pv-platform": {Resource: clusterScopedResources[7], Name: "pvc-1234", UID: "pv-platform",
+			CreationTimestamp: start.Add(time.Minute), RelatedNamespace: "openshift-monitoring"},
+		"pv-leaked": {Resource: clusterScopedResources[7], Name: "pvc-5678", UID: "pv-leaked",
+			CreationTimestamp: start.Add(time.Minute), RelatedNamespace: "e2e-test-foo-abcde"},
+	}
+
+	testIntervals := monitorapi.Intervals{
+		e2eTestInterval("test-a", start, start.Add(5*time.Minute)),
+		e2eTestInterval("test-b", start, start.Add(2*time.Minute)),
+		e2eTestInterval("test-c", start.Add(5*time.Minute), start.Add(10*time.Minute)),
+	}
+
+	namespaces, clusterScoped := findLeakedResources(before, after, testIntervals)
+	require.Len(t, namespaces, 1)
+	assert.Equal(t, "e2e-test-foo-abcde", namespaces[0].Name)
+	require.Len(t, clusterScoped, 2)
+	assert.Equal(t, "customresourcedefinitions.apiextensions.k8s.io/foos.example.com", clusterScoped[0].String())
+	assert.Equal(t, "persistentvolumes/pvc-5678", clusterScoped[1].String())
+
+	// both test-a and test-b were running when the namespace was created, but a pod in the namespace
+	// was created after test-b finished.
+	recorded := monitorapi.ResourcesMap{
+		"pods": monitorapi.InstanceMap{
+			{Namespace: "e2e-test-foo-abcde", Name: "pod", UID: "pod"}: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "e2e-test-foo-abcde", Name: "pod",
+					CreationTimestamp: metav1.NewTime(start.Add(3 * time.Minute))},
+			},
+		},
+	}
+	creationTimes := creationTimesByNamespace(recorded)
+
+	assert.Equal(t, []string{"test-a"}, attributeToTests(namespaces[0], testIntervals, creationTimes))
+	assert.Equal(t, []string{"test-c"}, attributeToTests(clusterScoped[0], testIntervals, creationTimes))
+	assert.Equal(t, []string{"test-a"}, attributeToTests(clusterScoped[1], testIntervals, creationTimes), "volumes are attributed through their claim's namespace")
+
+	junits := leakJunit(leakedNamespacesTestName, describeLeaks(namespaces, testIntervals, creationTimes))
+	require.Len(t, junits, 2)
+	require.NotNil(t, junits[0].FailureOutput)
+	assert.Contains(t, junits[0].FailureOutput.Output, "test-a")
+
+	require.Len(t, leakJunit(leakedNamespacesTestName, nil), 1)
+}
+
+func TestRelatedNamespace(t *testing.T) {
+	pv := unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{"claimRef": map[string]interface{}{"namespace": "e2e-test-foo-abcde", "name": "claim"}},
+	}}
+	assert.Equal(t, "e2e-test-foo-abcde", relatedNamespace(pv))
+
+	binding := unstructured.Unstructured{Object: map[string]interface{}{
+		"subjects": []interface{}{
+			map[string]interface{}{"kind": "Group", "name": "system:authenticated"},
+			map[string]interface{}{"kind": "ServiceAccount", "name": "default", "namespace": "e2e-test-bar-abcde"},
+		},
+	}}
+	assert.Equal(t, "e2e-test-bar-abcde", relatedNamespace(binding))
+
+	assert.Empty(t, relatedNamespace(unstructured.Unstructured{Object: map[string]interface{}{}}))
+}
@@ -0,0 +1,85 @@
+package leakedresources
+
+import (
+	"context"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitortestframework"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+)
+
+type leakedResourceChecker struct {
+	dynamicClient dynamic.Interface
+
+	before clusterSnapshot
+	after  clusterSnapshot
+
+	namespaceCreationTimes map[string][]time.Time
+}
+
+// NewLeakedResourceChecker compares cluster-scoped resources before and after the run to find what e2e tests left behind.
+func NewLeakedResourceChecker() monitortestframework.MonitorTest {
+	return &leakedResourceChecker{}
+}
+
+func (*leakedResourceChecker) Describe() monitortestframework.MonitorTestDescription {
+	resources := []string{namespacesResource.GroupResource().String()}
+	for _, resource := range clusterScopedResources {
+		resources = append(resources, resource.GroupResource().String())
+	}
+	return monitortestframework.MonitorTestDescription{
+		WatchedResources: resources,
+		JUnits:           []string{leakedNamespacesTestName, leakedClusterScopedResourceTestName},
+	}
+}
+
+func (w *leakedResourceChecker) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	var err error
+	w.dynamicClient, err = dynamic.NewForConfig(adminRESTConfig)
+	if err != nil {
+		return err
+	}
+	w.before, err = takeSnapshot(ctx, w.dynamicClient)
+	return err
+}
+
+func (w *leakedResourceChecker) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	if w.before == nil {
+		return nil, nil, nil
+	}
+	var err error
+	w.after, err = takeSnapshot(ctx, w.dynamicClient)
+	return nil, nil, err
+}
+
+func (w *leakedResourceChecker) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	// recorded resources are not available when evaluating, so keep what we need to attribute leaks.
+	w.namespaceCreationTimes = creationTimesByNamespace(recordedResources)
+	return nil, nil
+}
+
+func (w *leakedResourceChecker) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	if w.before == nil || w.after == nil {
+		return nil, nil
+	}
+
+	testIntervals := finalIntervals.Filter(monitorapi.IsE2ETestInterval)
+	namespaces, clusterScoped := findLeakedResources(w.before, w.after, testIntervals)
+
+	ret := []*junitapi.JUnitTestCase{}
+	ret = append(ret, leakJunit(leakedNamespacesTestName, describeLeaks(namespaces, testIntervals, w.namespaceCreationTimes))...)
+	ret = append(ret, leakJunit(leakedClusterScopedResourceTestName, describeLeaks(clusterScoped, testIntervals, w.namespaceCreationTimes))...)
+	return ret, nil
+}
+
+func (*leakedResourceChecker) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	return nil
+}
+
+func (*leakedResourceChecker) Cleanup(ctx context.Context) error {
+	return nil
+}
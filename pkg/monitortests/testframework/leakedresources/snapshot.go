@@ -0,0 +1,96 @@
+package leakedresources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	// e2eFrameworkNamespaceLabel is set by the e2e framework on every namespace it creates for a test.
+	// Namespaces created by monitor tests do not carry it, so it separates test leftovers from our own fixtures.
+	e2eFrameworkNamespaceLabel = "e2e-framework"
+)
+
+var (
+	namespacesResource = schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+
+	// clusterScopedResources are the cluster-scoped resources that tests commonly create and forget to remove.
+	clusterScopedResources = []schema.GroupVersionResource{
+		{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"},
+		{Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "clusterroles"},
+		{Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "clusterrolebindings"},
+		{Group: "admissionregistration.k8s.io", Version: "v1", Resource: "validatingwebhookconfigurations"},
+		{Group: "admissionregistration.k8s.io", Version: "v1", Resource: "mutatingwebhookconfigurations"},
+		{Group: "storage.k8s.io", Version: "v1", Resource: "storageclasses"},
+		{Group: "scheduling.k8s.io", Version: "v1", Resource: "priorityclasses"},
+		{Version: "v1", Resource: "persistentvolumes"},
+	}
+)
+
+// resourceRecord is the subset of object metadata we need to compare two snapshots.
+type resourceRecord struct {
+	Resource          schema.GroupVersionResource
+	Name              string
+	UID               string
+	Labels            map[string]string
+	CreationTimestamp time.Time
+	Terminating       bool
+	// HasOwner is set for objects that are garbage collected along with their owner.
+	HasOwner bool
+	// RelatedNamespace is the namespace a cluster-scoped object was created for, the namespace of the claim bound
+	// to a persistentvolume or of the subject of a clusterrolebinding.
+	RelatedNamespace string
+}
+
+func (r resourceRecord) String() string {
+	return fmt.Sprintf("%s/%s", r.Resource.GroupResource().String(), r.Name)
+}
+
+// clusterSnapshot holds every tracked cluster-scoped resource, keyed by UID so that recreations are noticed.
+type clusterSnapshot map[string]resourceRecord
+
+func takeSnapshot(ctx context.Context, client dynamic.Interface) (clusterSnapshot, error) {
+	snapshot := clusterSnapshot{}
+	for _, gvr := range append([]schema.GroupVersionResource{namespacesResource}, clusterScopedResources...) {
+		list, err := client.Resource(gvr).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("unable to list %v: %w", gvr.GroupResource(), err)
+		}
+		for _, item := range list.Items {
+			snapshot[string(item.GetUID())] = resourceRecord{
+				Resource:          gvr,
+				Name:              item.GetName(),
+				UID:               string(item.GetUID()),
+				Labels:            item.GetLabels(),
+				CreationTimestamp: item.GetCreationTimestamp().Time,
+				Terminating:       item.GetDeletionTimestamp() != nil,
+				HasOwner:          len(item.GetOwnerReferences()) > 0,
+				RelatedNamespace:  relatedNamespace(item),
+			}
+		}
+	}
+	return snapshot, nil
+}
+
+func relatedNamespace(item unstructured.Unstructured) string {
+	if namespace, found, _ := unstructured.NestedString(item.Object, "spec", "claimRef", "namespace"); found {
+		return namespace
+	}
+	subjects, _, _ := unstructured.NestedSlice(item.Object, "subjects")
+	for _, subject := range subjects {
+		subjectMap, ok := subject.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if namespace, found, _ := unstructured.NestedString(subjectMap, "namespace"); found && len(namespace) > 0 {
+			return namespace
+		}
+	}
+	return ""
+}
@@ -86,6 +86,9 @@ func RunAlertTests(jobType *platformidentification.JobType,
 	events monitorapi.Intervals,
 	recordedResource monitorapi.ResourcesMap) []*junitapi.JUnitTestCase {
 
+	// alerts silenced in Alertmanager were expected by whoever runs the cluster, they stay charted but are not tested.
+	events = events.Filter(monitorapi.Not(monitorapi.AlertSilenced()))
+
 	ret := []*junitapi.JUnitTestCase{}
 	alertTests := allowedalerts.AllAlertTests(jobType, clusterStability, etcdAllowance)
 
@@ -129,6 +132,8 @@ func runBackstopTest(
 
 	firingAlertsWithBugs, allowedFiringAlerts, pendingAlertsWithBugs, allowedPendingAlerts :=
 		allowancesFunc(featureSet)
+	allowedFiringAlerts = append(allowedFiringAlerts, allowedalerts.UserAllowedAlertConditions()...)
+	allowedPendingAlerts = append(allowedPendingAlerts, allowedalerts.UserAllowedAlertConditions()...)
 
 	logrus.Infof("filtered down to %d pending intervals", len(pendingIntervals))
 	logrus.Infof("filtered down to %d firing intervals", len(firingIntervals))
@@ -264,7 +269,7 @@ func runNoNewAlertsFiringTest(historicalData *historicaldata.AlertBestMatcher,
 	for _, interval := range firingIntervals {
 		alertName := interval.Locator.Keys[monitorapi.LocatorAlertKey]
 
-		if isSkippedAlert(alertName) {
+		if isSkippedAlert(alertName) || allowedalerts.IsUserAllowedAlert(interval) {
 			continue
 		}
 
@@ -1,10 +1,13 @@
 package legacytestframeworkmonitortests
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestlibrary/allowedalerts"
 	"github.com/openshift/origin/pkg/monitortestlibrary/historicaldata"
 	"github.com/openshift/origin/pkg/monitortestlibrary/platformidentification"
 	"github.com/stretchr/testify/assert"
@@ -150,3 +153,31 @@ func TestNoNewAlertsFiringBackstop(t *testing.T) {
 	}
 
 }
+
+func TestNoNewAlertsFiringUserAllowed(t *testing.T) {
+	dir := t.TempDir()
+	allowedAlertsFile := filepath.Join(dir, "allowed-alerts.yaml")
+	require.NoError(t, os.WriteFile(allowedAlertsFile, []byte("alerts:\n- alertName: FakeAlert\n  reason: expected on this cluster\n"), 0644))
+	noAlertsFile := filepath.Join(dir, "no-alerts.yaml")
+	require.NoError(t, os.WriteFile(noAlertsFile, []byte("alerts: []\n"), 0644))
+	require.NoError(t, allowedalerts.LoadUserAllowedAlerts(allowedAlertsFile))
+	t.Cleanup(func() { require.NoError(t, allowedalerts.LoadUserAllowedAlerts(noAlertsFile)) })
+
+	interval := monitorapi.NewInterval(monitorapi.SourceAlert, monitorapi.Warning).
+		Locator(monitorapi.Locator{
+			Type: monitorapi.LocatorTypeAlert,
+			Keys: map[monitorapi.LocatorKey]string{
+				monitorapi.LocatorAlertKey:     "FakeAlert",
+				monitorapi.LocatorNamespaceKey: "fakens",
+			},
+		}).
+		Message(monitorapi.NewMessage().
+			WithAnnotation(monitorapi.AnnotationAlertState, "firing").
+			WithAnnotation(monitorapi.AnnotationSeverity, "warning")).
+		Build(time.Now().Add(-6*time.Hour), time.Now().Add(-5*time.Hour))
+	historicalData := historicaldata.NewAlertMatcherWithHistoricalData(map[historicaldata.AlertDataKey]historicaldata.AlertStatisticalData{})
+
+	results := runNoNewAlertsFiringTest(historicalData, monitorapi.Intervals{interval})
+	require.Len(t, results, 1)
+	assert.Nil(t, results[0].FailureOutput)
+}
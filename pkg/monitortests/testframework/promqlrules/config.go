@@ -0,0 +1,133 @@
+package promqlrules
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+// Operator compares each sample of a rule's query with its threshold.
+type Operator string
+
+const (
+	GreaterThan        Operator = ">"
+	GreaterThanOrEqual Operator = ">="
+	LessThan           Operator = "<"
+	LessThanOrEqual    Operator = "<="
+)
+
+const defaultStep = 30 * time.Second
+
+// Config is the file of PromQL rules passed with --promql-rules, for instance:
+//
+//	rules:
+//	- name: etcd backend commit latency
+//	  query: histogram_quantile(0.99, sum by (instance, le) (rate(etcd_disk_backend_commit_duration_seconds_bucket[5m])))
+//	  operator: ">"
+//	  threshold: 0.025
+//	  for: 5m
+//	- name: control plane CPU throttling
+//	  query: sum by (namespace, pod) (rate(container_cpu_cfs_throttled_periods_total{namespace=~"openshift-kube-apiserver|openshift-etcd"}[5m]))
+//	  operator: ">"
+//	  threshold: 10
+//	  severity: Warning
+type Config struct {
+	Rules []Rule `json:"rules"`
+}
+
+// Rule is violated while a series returned by Query compares to Threshold with Operator for at least For.
+type Rule struct {
+	Name      string   `json:"name"`
+	Query     string   `json:"query"`
+	Operator  Operator `json:"operator"`
+	Threshold float64  `json:"threshold"`
+	// For is how long a series must be in violation before it is reported, every violation is reported when empty.
+	For metav1.Duration `json:"for,omitempty"`
+	// Step is the resolution of the range query, defaults to 30s.
+	Step metav1.Duration `json:"step,omitempty"`
+	// Severity is Error, which fails the rule's junit, or Warning, which only flakes it.  Defaults to Error.
+	Severity string `json:"severity,omitempty"`
+}
+
+func (r Rule) violates(value float64) bool {
+	switch r.Operator {
+	case GreaterThan:
+		return value > r.Threshold
+	case GreaterThanOrEqual:
+		return value >= r.Threshold
+	case LessThan:
+		return value < r.Threshold
+	case LessThanOrEqual:
+		return value <= r.Threshold
+	default:
+		return false
+	}
+}
+
+func (r Rule) level() monitorapi.IntervalLevel {
+	if r.Severity == monitorapi.Warning.String() {
+		return monitorapi.Warning
+	}
+	return monitorapi.Error
+}
+
+func loadConfig(filename string) (*Config, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	config := &Config{}
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %w", filename, err)
+	}
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", filename, err)
+	}
+	return config, nil
+}
+
+func (c *Config) validate() error {
+	names := sets.NewString()
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if len(rule.Name) == 0 {
+			return fmt.Errorf("rules[%d] must have a name", i)
+		}
+		if names.Has(rule.Name) {
+			return fmt.Errorf("rule %q is defined more than once", rule.Name)
+		}
+		names.Insert(rule.Name)
+
+		if len(rule.Query) == 0 {
+			return fmt.Errorf("rule %q must have a query", rule.Name)
+		}
+		switch rule.Operator {
+		case GreaterThan, GreaterThanOrEqual, LessThan, LessThanOrEqual:
+		default:
+			return fmt.Errorf("rule %q has unknown operator %q, must be one of >, >=, < or <=", rule.Name, rule.Operator)
+		}
+		if rule.For.Duration < 0 {
+			return fmt.Errorf("rule %q must not have a negative for", rule.Name)
+		}
+		if rule.Step.Duration < 0 {
+			return fmt.Errorf("rule %q must not have a negative step", rule.Name)
+		}
+		if rule.Step.Duration == 0 {
+			rule.Step.Duration = defaultStep
+		}
+		switch rule.Severity {
+		case "":
+			rule.Severity = monitorapi.Error.String()
+		case monitorapi.Error.String(), monitorapi.Warning.String():
+		default:
+			return fmt.Errorf("rule %q has unknown severity %q, must be %s or %s", rule.Name, rule.Severity, monitorapi.Error, monitorapi.Warning)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package promqlrules
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	routeclient "github.com/openshift/client-go/route/clientset/versioned"
+	"github.com/openshift/library-go/test/library/metrics"
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestlibrary/prometheus"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	prometheusv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	prometheustypes "github.com/prometheus/common/model"
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// runRules queries every rule over the run and returns the violations as intervals.  A rule whose query fails is
+// returned in queryErrors so it can be reported without hiding the results of the other rules.
+func runRules(ctx context.Context, restConfig *rest.Config, config *Config, beginning, end time.Time) (monitorapi.Intervals, map[string]error, error) {
+	logger := logrus.WithField("MonitorTest", "PromQLRules")
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	routeClient, err := routeclient.NewForConfig(restConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_, err = kubeClient.CoreV1().Namespaces().Get(ctx, "openshift-monitoring", metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return monitorapi.Intervals{}, nil, nil
+	}
+
+	prometheusClient, err := metrics.NewPrometheusClient(ctx, kubeClient, routeClient)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := prometheus.EnsureThanosQueriersConnectedToPromSidecars(ctx, prometheusClient); err != nil {
+		return nil, nil, err
+	}
+
+	ret := monitorapi.Intervals{}
+	queryErrors := map[string]error{}
+	for _, rule := range config.Rules {
+		timeRange := prometheusv1.Range{
+			Start: beginning,
+			End:   end,
+			Step:  rule.Step.Duration,
+		}
+		value, warningsForQuery, err := prometheusClient.QueryRange(ctx, rule.Query, timeRange)
+		if err != nil {
+			queryErrors[rule.Name] = err
+			continue
+		}
+		for _, w := range warningsForQuery {
+			logger.Warnf("rule %q prom query warning: %s", rule.Name, w)
+		}
+		matrix, ok := value.(prometheustypes.Matrix)
+		if !ok {
+			queryErrors[rule.Name] = fmt.Errorf("query returned %s, a range query must return a matrix", value.Type())
+			continue
+		}
+		ret = append(ret, violationsFromMatrix(rule, matrix)...)
+	}
+	return ret, queryErrors, nil
+}
+
+// violationsFromMatrix returns an interval for every period a series violated the rule for at least rule.For.  A
+// missing sample ends the period.
+func violationsFromMatrix(rule Rule, matrix prometheustypes.Matrix) monitorapi.Intervals {
+	ret := monitorapi.Intervals{}
+	for _, sampleStream := range matrix {
+		intervalTmpl := monitorapi.NewInterval(monitorapi.SourcePromQLRule, rule.level()).
+			Locator(monitorapi.NewLocator().PromQLRuleFromPromSampleStream(rule.Name, sampleStream)).
+			Display()
+
+		var from, last time.Time
+		var worst float64
+		addViolation := func() {
+			if from.IsZero() {
+				return
+			}
+			// each sample stands for the step that follows it.
+			to := last.Add(rule.Step.Duration)
+			if to.Sub(from) >= rule.For.Duration {
+				ret = append(ret, intervalTmpl.Message(monitorapi.NewMessage().
+					HumanMessage(fmt.Sprintf("%s was %g, %s %g, for %s: %s",
+						rule.Name, worst, rule.Operator, rule.Threshold, to.Sub(from), sampleStream.Metric.String()))).
+					Build(from, to))
+			}
+			from = time.Time{}
+		}
+		for _, value := range sampleStream.Values {
+			currTime := value.Timestamp.Time()
+			if !from.IsZero() && currTime.Sub(last) > rule.Step.Duration {
+				addViolation()
+			}
+			if !rule.violates(float64(value.Value)) {
+				addViolation()
+				continue
+			}
+			if from.IsZero() {
+				from = currTime
+				worst = float64(value.Value)
+			}
+			if furtherFrom(rule, float64(value.Value), worst) {
+				worst = float64(value.Value)
+			}
+			last = currTime
+		}
+		addViolation()
+	}
+	return ret
+}
+
+// furtherFrom returns true if value is a worse violation of the rule than worst.
+func furtherFrom(rule Rule, value, worst float64) bool {
+	switch rule.Operator {
+	case LessThan, LessThanOrEqual:
+		return value < worst
+	default:
+		return value > worst
+	}
+}
+
+func ruleJunit(rule Rule, violations monitorapi.Intervals, queryErr error) []*junitapi.JUnitTestCase {
+	testName := fmt.Sprintf("[sig-trt] PromQL rule %s should not be violated", rule.Name)
+	var output string
+	switch {
+	case queryErr != nil:
+		output = fmt.Sprintf("unable to run query %q: %v", rule.Query, queryErr)
+	case len(violations) > 0:
+		messages := []string{}
+		for _, violation := range violations {
+			messages = append(messages, violation.String())
+		}
+		output = fmt.Sprintf("%d violations of %s %s %g:\n\n%s", len(violations), rule.Query, rule.Operator, rule.Threshold, strings.Join(messages, "\n"))
+	default:
+		return []*junitapi.JUnitTestCase{{Name: testName}}
+	}
+
+	failure := &junitapi.JUnitTestCase{
+		Name:      testName,
+		SystemOut: output,
+		FailureOutput: &junitapi.FailureOutput{
+			Output: output,
+		},
+	}
+	if rule.level() == monitorapi.Warning {
+		return []*junitapi.JUnitTestCase{failure, {Name: testName}}
+	}
+	return []*junitapi.JUnitTestCase{failure}
+}
@@ -0,0 +1,146 @@
+package promqlrules
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	prometheustypes "github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name: "valid",
+			content: `rules:
+- name: etcd commit latency
+  query: histogram_quantile(0.99, sum by (instance, le) (rate(etcd_disk_backend_commit_duration_seconds_bucket[5m])))
+  operator: ">"
+  threshold: 0.025
+  for: 5m
+`,
+		},
+		{
+			name: "unknown field",
+			content: `rules:
+- name: a
+  query: up
+  operator: ">"
+  limit: 1
+`,
+			wantErr: `unknown field "limit"`,
+		},
+		{
+			name: "duplicate name",
+			content: `rules:
+- {name: a, query: up, operator: ">"}
+- {name: a, query: up, operator: ">"}
+`,
+			wantErr: `rule "a" is defined more than once`,
+		},
+		{
+			name: "no query",
+			content: `rules:
+- {name: a, operator: ">"}
+`,
+			wantErr: `rule "a" must have a query`,
+		},
+		{
+			name: "unknown operator",
+			content: `rules:
+- {name: a, query: up, operator: "=="}
+`,
+			wantErr: `unknown operator "=="`,
+		},
+		{
+			name: "unknown severity",
+			content: `rules:
+- {name: a, query: up, operator: ">", severity: Info}
+`,
+			wantErr: `unknown severity "Info"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "rules.yaml")
+			require.NoError(t, os.WriteFile(filename, []byte(tt.content), 0644))
+
+			config, err := loadConfig(filename)
+			if len(tt.wantErr) > 0 {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, config.Rules, 1)
+			assert.Equal(t, 5*time.Minute, config.Rules[0].For.Duration)
+			assert.Equal(t, defaultStep, config.Rules[0].Step.Duration)
+			assert.Equal(t, monitorapi.Error, config.Rules[0].level())
+		})
+	}
+}
+
+func TestViolationsFromMatrix(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	step := 30 * time.Second
+	series := func(node string, values ...float64) *prometheustypes.SampleStream {
+		stream := &prometheustypes.SampleStream{Metric: prometheustypes.Metric{"instance": prometheustypes.LabelValue(node)}}
+		for i, value := range values {
+			stream.Values = append(stream.Values, prometheustypes.SamplePair{
+				Timestamp: prometheustypes.TimeFromUnixNano(start.Add(time.Duration(i) * step).UnixNano()),
+				Value:     prometheustypes.SampleValue(value),
+			})
+		}
+		return stream
+	}
+	rule := Rule{
+		Name:      "etcd commit latency",
+		Operator:  GreaterThan,
+		Threshold: 0.025,
+		For:       metav1.Duration{Duration: time.Minute},
+		Step:      metav1.Duration{Duration: step},
+		Severity:  "Warning",
+	}
+
+	violations := violationsFromMatrix(rule, prometheustypes.Matrix{
+		// violated for 90s, then a single sample that is too short to count.
+		series("master-0", 0.01, 0.03, 0.05, 0.04, 0.01, 0.03, 0.01),
+		series("master-1", 0.01, 0.02, 0.01),
+	})
+	require.Len(t, violations, 1)
+	violation := violations[0]
+	assert.Equal(t, monitorapi.SourcePromQLRule, violation.Source)
+	assert.Equal(t, monitorapi.Warning, violation.Level)
+	assert.Equal(t, "etcd commit latency", violation.Locator.Keys[monitorapi.LocatorPromQLRuleKey])
+	assert.Equal(t, "master-0", violation.Locator.Keys[monitorapi.LocatorInstanceKey])
+	assert.Equal(t, start.Add(step), violation.From.UTC())
+	assert.Equal(t, start.Add(4*step), violation.To.UTC())
+	assert.Contains(t, violation.Message.HumanMessage, "etcd commit latency was 0.05, > 0.025, for 1m30s")
+
+	junits := ruleJunit(rule, violations, nil)
+	require.Len(t, junits, 2, "warning rules flake")
+	assert.Equal(t, "[sig-trt] PromQL rule etcd commit latency should not be violated", junits[0].Name)
+	require.NotNil(t, junits[0].FailureOutput)
+	assert.Nil(t, junits[1].FailureOutput)
+
+	rule.Severity = "Error"
+	junits = ruleJunit(rule, nil, errors.New("bad query"))
+	require.Len(t, junits, 1)
+	require.NotNil(t, junits[0].FailureOutput)
+	assert.Contains(t, junits[0].FailureOutput.Output, "bad query")
+
+	junits = ruleJunit(rule, nil, nil)
+	require.Len(t, junits, 1)
+	assert.Nil(t, junits[0].FailureOutput)
+}
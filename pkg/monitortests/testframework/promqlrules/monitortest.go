@@ -0,0 +1,75 @@
+package promqlrules
+
+import (
+	"context"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitortestframework"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	"k8s.io/client-go/rest"
+)
+
+type promQLRules struct {
+	rulesFile string
+
+	adminRESTConfig *rest.Config
+	config          *Config
+	queryErrors     map[string]error
+}
+
+// NewPromQLRules runs the range queries in rulesFile over the run and reports their threshold violations.  It does
+// nothing when rulesFile is empty.
+func NewPromQLRules(rulesFile string) monitortestframework.MonitorTest {
+	return &promQLRules{rulesFile: rulesFile}
+}
+
+func (w *promQLRules) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	if len(w.rulesFile) == 0 {
+		return nil
+	}
+	config, err := loadConfig(w.rulesFile)
+	if err != nil {
+		return err
+	}
+	w.adminRESTConfig = adminRESTConfig
+	w.config = config
+	return nil
+}
+
+func (w *promQLRules) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	if w.config == nil {
+		return nil, nil, nil
+	}
+	intervals, queryErrors, err := runRules(ctx, w.adminRESTConfig, w.config, beginning, end)
+	w.queryErrors = queryErrors
+	return intervals, nil, err
+}
+
+func (*promQLRules) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	return nil, nil
+}
+
+func (w *promQLRules) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	if w.config == nil {
+		return nil, nil
+	}
+	ret := []*junitapi.JUnitTestCase{}
+	for _, rule := range w.config.Rules {
+		violations := finalIntervals.Filter(func(eventInterval monitorapi.Interval) bool {
+			return eventInterval.Source == monitorapi.SourcePromQLRule &&
+				eventInterval.Locator.Keys[monitorapi.LocatorPromQLRuleKey] == rule.Name
+		})
+		ret = append(ret, ruleJunit(rule, violations, w.queryErrors[rule.Name])...)
+	}
+	return ret, nil
+}
+
+func (*promQLRules) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	return nil
+}
+
+func (*promQLRules) Cleanup(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,117 @@
+package sloevaluator
+
+import (
+	"fmt"
+	"os"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+// Window selects the part of the run an objective is measured over.
+type Window string
+
+const (
+	// WindowRun measures the objective from the start to the end of the monitor.
+	WindowRun Window = "Run"
+	// WindowUpgrade measures the objective from the start of the first upgrade to the end of the last one.
+	WindowUpgrade Window = "Upgrade"
+)
+
+// Config is the file of service level objectives passed with --slo-config, for instance:
+//
+//	objectives:
+//	- name: kube-apiserver new connections available during upgrade
+//	  window: Upgrade
+//	  targetPercent: 99.9
+//	  unavailable:
+//	    source: Disruption
+//	    level: Error
+//	    locatorKeys:
+//	      backend-disruption-name: kube-api-new-connections
+type Config struct {
+	Objectives []Objective `json:"objectives"`
+}
+
+// Objective is met when the intervals matching Unavailable cover no more than (100 - TargetPercent)% of the window.
+type Objective struct {
+	Name          string          `json:"name"`
+	Window        Window          `json:"window,omitempty"`
+	TargetPercent float64         `json:"targetPercent"`
+	Unavailable   IntervalMatcher `json:"unavailable"`
+}
+
+// IntervalMatcher selects intervals, every field that is set must match.
+type IntervalMatcher struct {
+	Source      monitorapi.IntervalSource        `json:"source,omitempty"`
+	Level       string                           `json:"level,omitempty"`
+	Reason      monitorapi.IntervalReason        `json:"reason,omitempty"`
+	LocatorKeys map[monitorapi.LocatorKey]string `json:"locatorKeys,omitempty"`
+}
+
+func (m IntervalMatcher) matches(interval monitorapi.Interval) bool {
+	if len(m.Source) > 0 && interval.Source != m.Source {
+		return false
+	}
+	if len(m.Level) > 0 && interval.Level.String() != m.Level {
+		return false
+	}
+	if len(m.Reason) > 0 && interval.Message.Reason != m.Reason {
+		return false
+	}
+	for k, v := range m.LocatorKeys {
+		if interval.Locator.Keys[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+func (m IntervalMatcher) isEmpty() bool {
+	return len(m.Source) == 0 && len(m.Level) == 0 && len(m.Reason) == 0 && len(m.LocatorKeys) == 0
+}
+
+func loadConfig(filename string) (*Config, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	config := &Config{}
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %w", filename, err)
+	}
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", filename, err)
+	}
+	return config, nil
+}
+
+func (c *Config) validate() error {
+	names := sets.NewString()
+	for i := range c.Objectives {
+		objective := &c.Objectives[i]
+		if len(objective.Name) == 0 {
+			return fmt.Errorf("objectives[%d] must have a name", i)
+		}
+		if names.Has(objective.Name) {
+			return fmt.Errorf("objective %q is defined more than once", objective.Name)
+		}
+		names.Insert(objective.Name)
+
+		if len(objective.Window) == 0 {
+			objective.Window = WindowRun
+		}
+		if objective.Window != WindowRun && objective.Window != WindowUpgrade {
+			return fmt.Errorf("objective %q has unknown window %q, must be %s or %s", objective.Name, objective.Window, WindowRun, WindowUpgrade)
+		}
+		if objective.TargetPercent <= 0 || objective.TargetPercent > 100 {
+			return fmt.Errorf("objective %q must have a targetPercent greater than 0 and at most 100", objective.Name)
+		}
+		if objective.Unavailable.isEmpty() {
+			return fmt.Errorf("objective %q must select the unavailable intervals", objective.Name)
+		}
+	}
+	return nil
+}
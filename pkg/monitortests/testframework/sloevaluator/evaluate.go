@@ -0,0 +1,142 @@
+package sloevaluator
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestlibrary/platformidentification"
+)
+
+// Report is written as the slo-report artifact.
+type Report struct {
+	Objectives []ObjectiveResult `json:"objectives"`
+}
+
+// ObjectiveResult is the outcome of evaluating one objective.  Skipped objectives have no window to measure, for
+// instance an Upgrade objective in a run that did not upgrade.
+type ObjectiveResult struct {
+	Name                      string    `json:"name"`
+	Window                    Window    `json:"window"`
+	WindowStart               time.Time `json:"windowStart,omitempty"`
+	WindowEnd                 time.Time `json:"windowEnd,omitempty"`
+	TargetPercent             float64   `json:"targetPercent"`
+	AchievedPercent           float64   `json:"achievedPercent"`
+	UnavailableSeconds        float64   `json:"unavailableSeconds"`
+	UnavailableIntervals      int       `json:"unavailableIntervals"`
+	AllowedUnavailableSeconds float64   `json:"allowedUnavailableSeconds"`
+	Met                       bool      `json:"met"`
+	Skipped                   bool      `json:"skipped,omitempty"`
+	SkipReason                string    `json:"skipReason,omitempty"`
+}
+
+func evaluate(config *Config, intervals monitorapi.Intervals, beginning, end time.Time) Report {
+	report := Report{Objectives: []ObjectiveResult{}}
+	for _, objective := range config.Objectives {
+		report.Objectives = append(report.Objectives, evaluateObjective(objective, intervals, beginning, end))
+	}
+	return report
+}
+
+func evaluateObjective(objective Objective, intervals monitorapi.Intervals, beginning, end time.Time) ObjectiveResult {
+	result := ObjectiveResult{
+		Name:          objective.Name,
+		Window:        objective.Window,
+		TargetPercent: objective.TargetPercent,
+	}
+
+	windowStart, windowEnd, skipReason := objectiveWindow(objective.Window, intervals, beginning, end)
+	if len(skipReason) > 0 {
+		result.Skipped = true
+		result.SkipReason = skipReason
+		return result
+	}
+	result.WindowStart = windowStart
+	result.WindowEnd = windowEnd
+
+	var unavailable []timeRange
+	for _, interval := range intervals {
+		if !objective.Unavailable.matches(interval) {
+			continue
+		}
+		to := interval.To
+		if to.IsZero() {
+			// still unavailable when the run ended.
+			to = windowEnd
+		}
+		clipped, ok := timeRange{from: interval.From, to: to}.clip(windowStart, windowEnd)
+		if !ok {
+			continue
+		}
+		result.UnavailableIntervals++
+		unavailable = append(unavailable, clipped)
+	}
+
+	windowDuration := windowEnd.Sub(windowStart)
+	unavailableDuration := unionDuration(unavailable)
+	result.UnavailableSeconds = unavailableDuration.Seconds()
+	result.AllowedUnavailableSeconds = windowDuration.Seconds() * (100 - objective.TargetPercent) / 100
+	result.AchievedPercent = 100 * (1 - unavailableDuration.Seconds()/windowDuration.Seconds())
+	result.Met = result.AchievedPercent >= objective.TargetPercent
+	return result
+}
+
+// objectiveWindow returns the part of the run the objective is measured over, or the reason it cannot be measured.
+func objectiveWindow(window Window, intervals monitorapi.Intervals, beginning, end time.Time) (time.Time, time.Time, string) {
+	start, stop := beginning, end
+	if window == WindowUpgrade {
+		hops := platformidentification.UpgradeHopsFromIntervals(intervals)
+		if len(hops) == 0 {
+			return time.Time{}, time.Time{}, "no upgrade was observed during the run"
+		}
+		start, stop = hops[0].From, hops[len(hops)-1].To
+		if stop.IsZero() {
+			// the last upgrade had not completed when the run ended.
+			stop = end
+		}
+	}
+	if !stop.After(start) {
+		return time.Time{}, time.Time{}, fmt.Sprintf("the %s window is empty", window)
+	}
+	return start, stop, ""
+}
+
+type timeRange struct {
+	from, to time.Time
+}
+
+func (r timeRange) clip(start, end time.Time) (timeRange, bool) {
+	if r.from.Before(start) {
+		r.from = start
+	}
+	if r.to.After(end) {
+		r.to = end
+	}
+	return r, r.to.After(r.from)
+}
+
+// unionDuration counts overlapping ranges once, disruption is often reported by several samplers at the same time.
+func unionDuration(ranges []timeRange) time.Duration {
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].from.Before(ranges[j].from) })
+
+	var total time.Duration
+	var current *timeRange
+	for i := range ranges {
+		r := ranges[i]
+		if current != nil && !r.from.After(current.to) {
+			if r.to.After(current.to) {
+				current.to = r.to
+			}
+			continue
+		}
+		if current != nil {
+			total += current.to.Sub(current.from)
+		}
+		current = &r
+	}
+	if current != nil {
+		total += current.to.Sub(current.from)
+	}
+	return total
+}
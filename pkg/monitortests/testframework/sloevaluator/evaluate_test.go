@@ -0,0 +1,196 @@
+package sloevaluator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name: "valid",
+			content: `objectives:
+- name: kube-apiserver new connections
+  window: Upgrade
+  targetPercent: 99.9
+  unavailable:
+    source: Disruption
+    level: Error
+    locatorKeys:
+      backend-disruption-name: kube-api-new-connections
+`,
+		},
+		{
+			name: "unknown field",
+			content: `objectives:
+- name: kube-apiserver new connections
+  target: 99.9
+`,
+			wantErr: `unknown field "target"`,
+		},
+		{
+			name: "duplicate name",
+			content: `objectives:
+- name: a
+  targetPercent: 99
+  unavailable: {source: Disruption}
+- name: a
+  targetPercent: 99
+  unavailable: {source: Disruption}
+`,
+			wantErr: `objective "a" is defined more than once`,
+		},
+		{
+			name: "unknown window",
+			content: `objectives:
+- name: a
+  window: Install
+  targetPercent: 99
+  unavailable: {source: Disruption}
+`,
+			wantErr: `unknown window "Install"`,
+		},
+		{
+			name: "target out of range",
+			content: `objectives:
+- name: a
+  targetPercent: 101
+  unavailable: {source: Disruption}
+`,
+			wantErr: "targetPercent greater than 0 and at most 100",
+		},
+		{
+			name: "no matcher",
+			content: `objectives:
+- name: a
+  targetPercent: 99
+`,
+			wantErr: "must select the unavailable intervals",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "slo.yaml")
+			require.NoError(t, os.WriteFile(filename, []byte(tt.content), 0644))
+
+			config, err := loadConfig(filename)
+			if len(tt.wantErr) > 0 {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, config.Objectives, 1)
+			assert.Equal(t, WindowUpgrade, config.Objectives[0].Window)
+			assert.Equal(t, "kube-api-new-connections", config.Objectives[0].Unavailable.LocatorKeys[monitorapi.LocatorBackendDisruptionNameKey])
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(100 * time.Minute)
+
+	disruption := func(backend string, level monitorapi.IntervalLevel, from, to time.Time) monitorapi.Interval {
+		return monitorapi.NewInterval(monitorapi.SourceDisruption, level).
+			Locator(monitorapi.NewLocator().DisruptionRequiredOnly(backend, "kube-api")).
+			Message(monitorapi.NewMessage().HumanMessage("disrupted")).
+			Build(from, to)
+	}
+	upgradeHop := func(index string, from, to time.Time) monitorapi.Interval {
+		return monitorapi.NewInterval(monitorapi.SourceUpgradeHop, monitorapi.Info).
+			Message(monitorapi.NewMessage().Reason(monitorapi.UpgradeHopReason).
+				WithAnnotation(monitorapi.AnnotationUpgradeHop, index)).
+			Build(from, to)
+	}
+	objective := func(name string, window Window, target float64) Objective {
+		return Objective{
+			Name:          name,
+			Window:        window,
+			TargetPercent: target,
+			Unavailable: IntervalMatcher{
+				Source:      monitorapi.SourceDisruption,
+				Level:       monitorapi.Error.String(),
+				LocatorKeys: map[monitorapi.LocatorKey]string{monitorapi.LocatorBackendDisruptionNameKey: "kube-api-new-connections"},
+			},
+		}
+	}
+
+	disruptionIntervals := monitorapi.Intervals{
+		// overlapping intervals are only counted once: 60s in total.
+		disruption("kube-api-new-connections", monitorapi.Error, start.Add(10*time.Minute), start.Add(10*time.Minute+40*time.Second)),
+		disruption("kube-api-new-connections", monitorapi.Error, start.Add(10*time.Minute+20*time.Second), start.Add(11*time.Minute)),
+		// other backends and levels do not count.
+		disruption("kube-api-reused-connections", monitorapi.Error, start.Add(20*time.Minute), start.Add(30*time.Minute)),
+		disruption("kube-api-new-connections", monitorapi.Info, start.Add(20*time.Minute), start.Add(30*time.Minute)),
+		// only the part inside the upgrade window counts: 30s.
+		disruption("kube-api-new-connections", monitorapi.Error, start.Add(60*time.Minute-30*time.Second), start.Add(61*time.Minute)),
+	}
+
+	t.Run("run window", func(t *testing.T) {
+		config := &Config{Objectives: []Objective{
+			objective("met", WindowRun, 97.5),
+			objective("missed", WindowRun, 99.9),
+		}}
+		report := evaluate(config, disruptionIntervals, start, end)
+		require.Len(t, report.Objectives, 2)
+
+		met := report.Objectives[0]
+		assert.True(t, met.Met)
+		assert.Equal(t, 3, met.UnavailableIntervals)
+		assert.InDelta(t, 150, met.UnavailableSeconds, 0.001)
+		assert.InDelta(t, 97.5, met.AchievedPercent, 0.001)
+		assert.InDelta(t, 150, met.AllowedUnavailableSeconds, 0.001)
+
+		missed := report.Objectives[1]
+		assert.False(t, missed.Met)
+		assert.InDelta(t, 6, missed.AllowedUnavailableSeconds, 0.001)
+	})
+
+	t.Run("upgrade window", func(t *testing.T) {
+		intervals := append(monitorapi.Intervals{
+			upgradeHop("1", start.Add(5*time.Minute), start.Add(30*time.Minute)),
+			upgradeHop("2", start.Add(30*time.Minute), start.Add(60*time.Minute)),
+		}, disruptionIntervals...)
+		config := &Config{Objectives: []Objective{objective("upgrade", WindowUpgrade, 90)}}
+
+		result := evaluate(config, intervals, start, end).Objectives[0]
+		assert.Equal(t, start.Add(5*time.Minute), result.WindowStart)
+		assert.Equal(t, start.Add(60*time.Minute), result.WindowEnd)
+		assert.Equal(t, 3, result.UnavailableIntervals)
+		assert.InDelta(t, 90, result.UnavailableSeconds, 0.001)
+		assert.True(t, result.Met)
+	})
+
+	t.Run("upgrade window without an upgrade", func(t *testing.T) {
+		config := &Config{Objectives: []Objective{objective("upgrade", WindowUpgrade, 90)}}
+
+		result := evaluate(config, disruptionIntervals, start, end).Objectives[0]
+		assert.True(t, result.Skipped)
+		assert.False(t, result.Met)
+		assert.Equal(t, "no upgrade was observed during the run", result.SkipReason)
+		assert.NotNil(t, objectiveJunit(result).SkipMessage)
+	})
+}
+
+func TestObjectiveJunit(t *testing.T) {
+	failed := objectiveJunit(ObjectiveResult{Name: "api", Window: WindowRun, TargetPercent: 99.9, AchievedPercent: 99.5, UnavailableSeconds: 30, UnavailableIntervals: 2, AllowedUnavailableSeconds: 6})
+	assert.Equal(t, "[sig-trt] SLO api should be met", failed.Name)
+	require.NotNil(t, failed.FailureOutput)
+	assert.Contains(t, failed.FailureOutput.Output, "achieved 99.5000% availability over the Run window, the target is 99.9000%")
+
+	passed := objectiveJunit(ObjectiveResult{Name: "api", Window: WindowRun, TargetPercent: 99, AchievedPercent: 99.5, Met: true})
+	assert.Nil(t, passed.FailureOutput)
+	assert.Nil(t, passed.SkipMessage)
+}
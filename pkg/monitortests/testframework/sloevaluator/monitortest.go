@@ -0,0 +1,108 @@
+package sloevaluator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitortestframework"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	"k8s.io/client-go/rest"
+)
+
+type sloEvaluator struct {
+	configFile string
+
+	config    *Config
+	beginning time.Time
+	end       time.Time
+	report    *Report
+}
+
+// NewSLOEvaluator evaluates the objectives in configFile against the final intervals.  It does nothing when
+// configFile is empty.
+func NewSLOEvaluator(configFile string) monitortestframework.MonitorTest {
+	return &sloEvaluator{configFile: configFile}
+}
+
+func (w *sloEvaluator) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	if len(w.configFile) == 0 {
+		return nil
+	}
+	config, err := loadConfig(w.configFile)
+	if err != nil {
+		return err
+	}
+	w.config = config
+	return nil
+}
+
+func (w *sloEvaluator) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	w.beginning = beginning
+	w.end = end
+	return nil, nil, nil
+}
+
+func (*sloEvaluator) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	return nil, nil
+}
+
+func (w *sloEvaluator) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	if w.config == nil {
+		return nil, nil
+	}
+	report := evaluate(w.config, finalIntervals, w.beginning, w.end)
+	w.report = &report
+
+	ret := []*junitapi.JUnitTestCase{}
+	for _, result := range report.Objectives {
+		ret = append(ret, objectiveJunit(result))
+	}
+	return ret, nil
+}
+
+func (w *sloEvaluator) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	if w.report == nil {
+		return nil
+	}
+	jsonContent, err := json.MarshalIndent(w.report, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(storageDir, fmt.Sprintf("slo-report%s.json", timeSuffix)), jsonContent, 0644)
+}
+
+func (*sloEvaluator) Cleanup(ctx context.Context) error {
+	return nil
+}
+
+func objectiveJunit(result ObjectiveResult) *junitapi.JUnitTestCase {
+	testName := fmt.Sprintf("[sig-trt] SLO %s should be met", result.Name)
+	switch {
+	case result.Skipped:
+		return &junitapi.JUnitTestCase{
+			Name: testName,
+			SkipMessage: &junitapi.SkipMessage{
+				Message: result.SkipReason,
+			},
+		}
+	case !result.Met:
+		return &junitapi.JUnitTestCase{
+			Name: testName,
+			FailureOutput: &junitapi.FailureOutput{
+				Output: fmt.Sprintf("achieved %.4f%% availability over the %s window, the target is %.4f%%: unavailable for %.1fs in %d intervals, %.1fs allowed",
+					result.AchievedPercent, result.Window, result.TargetPercent, result.UnavailableSeconds, result.UnavailableIntervals, result.AllowedUnavailableSeconds),
+			},
+		}
+	default:
+		return &junitapi.JUnitTestCase{
+			Name:      testName,
+			SystemOut: fmt.Sprintf("achieved %.4f%% availability over the %s window, the target is %.4f%%", result.AchievedPercent, result.Window, result.TargetPercent),
+		}
+	}
+}
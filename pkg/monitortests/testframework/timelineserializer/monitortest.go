@@ -38,6 +38,11 @@ func (*timelineSerializer) EvaluateTestsFromConstructedIntervals(ctx context.Con
 }
 
 func (*timelineSerializer) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	return WriteTimelines(storageDir, timeSuffix, finalIntervals)
+}
+
+// WriteTimelines writes the e2e-timelines json and html files, and the summary of the intervals, into storageDir.
+func WriteTimelines(storageDir, timeSuffix string, finalIntervals monitorapi.Intervals) error {
 	errs := []error{}
 	var err error
 
@@ -74,6 +79,9 @@ func (*timelineSerializer) WriteContentToStorage(ctx context.Context, storageDir
 	if err != nil {
 		errs = append(errs, err)
 	}
+	if err := writeTimelineSummary(storageDir, timeSuffix, finalIntervals); err != nil {
+		errs = append(errs, err)
+	}
 
 	return utilerrors.NewAggregate(errs)
 }
@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/openshift/origin/pkg/monitor/monitorapi"
 	monitorserialization "github.com/openshift/origin/pkg/monitor/serialization"
@@ -49,26 +50,24 @@ func (r eventIntervalRenderer) WriteRunData(artifactDir string, _ monitorapi.Res
 
 func (r eventIntervalRenderer) writeEventData(artifactDir, filenameBase string, events monitorapi.Intervals, timeSuffix string) error {
 	errs := []error{}
-	interestingEvents := events.Filter(r.filter)
+	// flapping conditions produce many identical intervals that make the chart hard to read.
+	interestingEvents := events.Filter(r.filter).Compact(monitorapi.DefaultCompactionGap)
 
 	if err := monitorserialization.IntervalsToFile(filepath.Join(artifactDir, fmt.Sprintf("%s.json", filenameBase)), interestingEvents); err != nil {
 		errs = append(errs, err)
 	}
 
-	eventIntervalsJSON, err := monitorserialization.EventsIntervalsToJSON(interestingEvents)
-	if err != nil {
-		errs = append(errs, err)
-		return utilerrors.NewAggregate(errs)
-
-	}
 	e2eChartTemplate := testdata.MustAsset("e2echart/e2e-chart-template.html")
 	// choosing to intercept here because it should be temporary until TRT transitions to a new mechanism to display these intervals.
 	if !strings.Contains(r.name, "spyglass") {
 		e2eChartTemplate = testdata.MustAsset("e2echart/non-spyglass-e2e-chart-template.html")
 	}
 	e2eChartTitle := fmt.Sprintf("Intervals - %s%s", r.name, timeSuffix)
-	e2eChartHTML := bytes.ReplaceAll(e2eChartTemplate, []byte("EVENT_INTERVAL_TITLE_GOES_HERE"), []byte(e2eChartTitle))
-	e2eChartHTML = bytes.ReplaceAll(e2eChartHTML, []byte("EVENT_INTERVAL_JSON_GOES_HERE"), eventIntervalsJSON)
+	e2eChartHTML, err := RenderChartHTML(e2eChartTemplate, e2eChartTitle, interestingEvents)
+	if err != nil {
+		errs = append(errs, err)
+		return utilerrors.NewAggregate(errs)
+	}
 	e2eChartHTMLPath := filepath.Join(artifactDir, fmt.Sprintf("%s.html", filenameBase))
 	if err := ioutil.WriteFile(e2eChartHTMLPath, e2eChartHTML, 0644); err != nil {
 		errs = append(errs, err)
@@ -77,11 +76,46 @@ func (r eventIntervalRenderer) writeEventData(artifactDir, filenameBase string,
 	return utilerrors.NewAggregate(errs)
 }
 
+// RenderChartHTML fills in an e2echart template with the intervals and the summary tables for them.
+func RenderChartHTML(e2eChartTemplate []byte, title string, intervals monitorapi.Intervals) ([]byte, error) {
+	eventIntervalsJSON, err := monitorserialization.EventsIntervalsToJSON(widenInstants(intervals))
+	if err != nil {
+		return nil, err
+	}
+	eventSummaryJSON, err := summaryJSON(intervals)
+	if err != nil {
+		return nil, err
+	}
+	e2eChartHTML := bytes.ReplaceAll(e2eChartTemplate, []byte("EVENT_INTERVAL_TITLE_GOES_HERE"), []byte(title))
+	e2eChartHTML = bytes.ReplaceAll(e2eChartHTML, []byte("EVENT_INTERVAL_JSON_GOES_HERE"), eventIntervalsJSON)
+	e2eChartHTML = bytes.ReplaceAll(e2eChartHTML, []byte("EVENT_INTERVAL_SUMMARY_GOES_HERE"), eventSummaryJSON)
+	return e2eChartHTML, nil
+}
+
+// minimumChartedDuration is how long an instant is drawn, a bar without a width is not visible.
+const minimumChartedDuration = time.Second
+
+// widenInstants gives displayed instants a visible width in the chart only, the serialized intervals keep their
+// real timestamps.
+func widenInstants(intervals monitorapi.Intervals) monitorapi.Intervals {
+	ret := make(monitorapi.Intervals, 0, len(intervals))
+	for _, interval := range intervals {
+		if interval.Display && interval.IsInstant() {
+			interval.To = interval.From.Add(minimumChartedDuration)
+		}
+		ret = append(ret, interval)
+	}
+	return ret
+}
+
 func BelongsInEverything(eventInterval monitorapi.Interval) bool {
 	return true
 }
 
 func BelongsInSpyglass(eventInterval monitorapi.Interval) bool {
+	if !eventInterval.Chartable() {
+		return false
+	}
 	if isLessInterestingAlert(eventInterval) {
 		return false
 	}
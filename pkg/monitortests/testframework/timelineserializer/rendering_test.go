@@ -3,8 +3,12 @@ package timelineserializer
 import (
 	_ "embed"
 	"testing"
+	"time"
 
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
 	monitorserialization "github.com/openshift/origin/pkg/monitor/serialization"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 //go:embed render_test_01_skip_e2e.json
@@ -27,3 +31,31 @@ func TestBelongsInKubeAPIServer(t *testing.T) {
 		}
 	}
 }
+
+func TestChartingInstants(t *testing.T) {
+	at := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	builder := func() *monitorapi.IntervalBuilder {
+		return monitorapi.NewInterval(monitorapi.SourceDisruption, monitorapi.Error).
+			Locator(monitorapi.NewLocator().NodeFromName("node")).
+			Message(monitorapi.NewMessage().HumanMessage("message"))
+	}
+	displayed := builder().Display().Build(at, at)
+	hidden := builder().Build(at, at)
+	open := builder().Build(at, time.Time{})
+
+	assert.True(t, BelongsInSpyglass(displayed))
+	assert.False(t, BelongsInSpyglass(hidden))
+	assert.True(t, BelongsInSpyglass(open))
+
+	chartJSON, err := monitorserialization.EventsIntervalsToJSON(monitorapi.Intervals{displayed, hidden, open})
+	require.NoError(t, err)
+	charted, err := monitorserialization.IntervalsFromJSON(chartJSON)
+	require.NoError(t, err)
+	require.Len(t, charted, 2)
+	assert.True(t, charted[0].To.IsZero() || charted[1].To.IsZero())
+
+	widened := widenInstants(monitorapi.Intervals{displayed, open})
+	assert.Equal(t, at.Add(time.Second), widened[0].To)
+	assert.True(t, widened[1].To.IsZero())
+	assert.Equal(t, at, displayed.To, "the intervals themselves are unchanged")
+}
@@ -0,0 +1,186 @@
+package timelineserializer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+// maxRepeatedEvents bounds the repeated events table, the long tail is rarely useful for triage.
+const maxRepeatedEvents = 25
+
+// TimelineSummary condenses the intervals of a timeline into the tables most often needed to triage a run, so they
+// can be read without loading the full chart.
+type TimelineSummary struct {
+	RepeatedEvents []RepeatedEventSummary `json:"repeatedEvents"`
+	Alerts         []AlertSummary         `json:"alerts"`
+	Disruption     []DisruptionSummary    `json:"disruption"`
+}
+
+// RepeatedEventSummary is a kube event that was seen more than once.  Known events matched one of the pathological
+// event allowances, pathological events repeated more than the threshold.
+type RepeatedEventSummary struct {
+	Locator      string `json:"locator"`
+	Reason       string `json:"reason"`
+	Message      string `json:"message"`
+	Count        int    `json:"count"`
+	Pathological bool   `json:"pathological"`
+	Known        bool   `json:"known"`
+}
+
+// AlertSummary is the total time an alert was firing in a namespace.
+type AlertSummary struct {
+	Alert           string  `json:"alert"`
+	Namespace       string  `json:"namespace"`
+	Severity        string  `json:"severity"`
+	Intervals       int     `json:"intervals"`
+	DurationSeconds float64 `json:"durationSeconds"`
+}
+
+// DisruptionSummary is the total disruption observed for a backend.
+type DisruptionSummary struct {
+	Backend         string  `json:"backend"`
+	Intervals       int     `json:"intervals"`
+	DurationSeconds float64 `json:"durationSeconds"`
+}
+
+func summarizeIntervals(intervals monitorapi.Intervals) TimelineSummary {
+	return TimelineSummary{
+		RepeatedEvents: summarizeRepeatedEvents(intervals),
+		Alerts:         summarizeAlerts(intervals),
+		Disruption:     summarizeDisruption(intervals),
+	}
+}
+
+func summarizeRepeatedEvents(intervals monitorapi.Intervals) []RepeatedEventSummary {
+	// an event is updated many times over a run, keep the highest count seen for each.
+	byEvent := map[string]*RepeatedEventSummary{}
+	for _, interval := range intervals {
+		if interval.Source != monitorapi.SourceKubeEvent {
+			continue
+		}
+		count, err := strconv.Atoi(interval.Message.Annotations[monitorapi.AnnotationCount])
+		if err != nil || count < 2 {
+			continue
+		}
+		locator := interval.Locator.OldLocator()
+		key := locator + " " + string(interval.Message.Reason) + " " + interval.Message.HumanMessage
+		summary, ok := byEvent[key]
+		if !ok {
+			summary = &RepeatedEventSummary{
+				Locator: locator,
+				Reason:  string(interval.Message.Reason),
+				Message: interval.Message.HumanMessage,
+			}
+			byEvent[key] = summary
+		}
+		if count > summary.Count {
+			summary.Count = count
+		}
+		summary.Pathological = summary.Pathological || interval.Message.Annotations[monitorapi.AnnotationPathological] == "true"
+		summary.Known = summary.Known || interval.Message.Annotations[monitorapi.AnnotationInteresting] == "true"
+	}
+
+	ret := []RepeatedEventSummary{}
+	for _, summary := range byEvent {
+		ret = append(ret, *summary)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Count != ret[j].Count {
+			return ret[i].Count > ret[j].Count
+		}
+		return ret[i].Locator+ret[i].Message < ret[j].Locator+ret[j].Message
+	})
+	if len(ret) > maxRepeatedEvents {
+		ret = ret[:maxRepeatedEvents]
+	}
+	return ret
+}
+
+func summarizeAlerts(intervals monitorapi.Intervals) []AlertSummary {
+	byAlert := map[string]*AlertSummary{}
+	for _, interval := range intervals.Filter(monitorapi.AlertFiring()) {
+		if interval.Source != monitorapi.SourceAlert {
+			continue
+		}
+		alert := interval.Locator.Keys[monitorapi.LocatorAlertKey]
+		namespace := interval.Locator.Keys[monitorapi.LocatorNamespaceKey]
+		severity := interval.Message.Annotations[monitorapi.AnnotationSeverity]
+		key := alert + " " + namespace + " " + severity
+		summary, ok := byAlert[key]
+		if !ok {
+			summary = &AlertSummary{Alert: alert, Namespace: namespace, Severity: severity}
+			byAlert[key] = summary
+		}
+		summary.Intervals++
+		summary.DurationSeconds += intervalDuration(interval).Seconds()
+	}
+
+	ret := []AlertSummary{}
+	for _, summary := range byAlert {
+		ret = append(ret, *summary)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].DurationSeconds != ret[j].DurationSeconds {
+			return ret[i].DurationSeconds > ret[j].DurationSeconds
+		}
+		return ret[i].Alert+ret[i].Namespace < ret[j].Alert+ret[j].Namespace
+	})
+	return ret
+}
+
+func summarizeDisruption(intervals monitorapi.Intervals) []DisruptionSummary {
+	byBackend := map[string]*DisruptionSummary{}
+	for _, interval := range intervals {
+		if interval.Source != monitorapi.SourceDisruption || !monitorapi.IsErrorEvent(interval) {
+			continue
+		}
+		backend := interval.Locator.Keys[monitorapi.LocatorBackendDisruptionNameKey]
+		summary, ok := byBackend[backend]
+		if !ok {
+			summary = &DisruptionSummary{Backend: backend}
+			byBackend[backend] = summary
+		}
+		summary.Intervals++
+		summary.DurationSeconds += intervalDuration(interval).Seconds()
+	}
+
+	ret := []DisruptionSummary{}
+	for _, summary := range byBackend {
+		ret = append(ret, *summary)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].DurationSeconds != ret[j].DurationSeconds {
+			return ret[i].DurationSeconds > ret[j].DurationSeconds
+		}
+		return ret[i].Backend < ret[j].Backend
+	})
+	return ret
+}
+
+// intervalDuration treats intervals that are still open at the end of the run as instants.
+func intervalDuration(interval monitorapi.Interval) time.Duration {
+	if interval.To.IsZero() || interval.To.Before(interval.From) {
+		return 0
+	}
+	return interval.To.Sub(interval.From)
+}
+
+// summaryJSON is embedded in the chart html, json.Marshal escapes the characters that could end the script element.
+func summaryJSON(intervals monitorapi.Intervals) ([]byte, error) {
+	return json.Marshal(summarizeIntervals(intervals))
+}
+
+func writeTimelineSummary(storageDir, timeSuffix string, intervals monitorapi.Intervals) error {
+	jsonContent, err := json.MarshalIndent(summarizeIntervals(intervals), "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(storageDir, fmt.Sprintf("e2e-timelines-summary%s.json", timeSuffix)), jsonContent, 0644)
+}
@@ -0,0 +1,94 @@
+package timelineserializer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+func TestSummarizeIntervals(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	event := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Namespace: "openshift-etcd", Name: "etcd-0.1"},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: "openshift-etcd", Name: "etcd-0"},
+	}
+	repeatedEvent := func(count string, annotations ...monitorapi.AnnotationKey) monitorapi.Interval {
+		message := monitorapi.NewMessage().Reason("Unhealthy").HumanMessage("Readiness probe failed").
+			WithAnnotation(monitorapi.AnnotationCount, count)
+		for _, annotation := range annotations {
+			message = message.WithAnnotation(annotation, "true")
+		}
+		return monitorapi.NewInterval(monitorapi.SourceKubeEvent, monitorapi.Warning).
+			Locator(monitorapi.NewLocator().KubeEvent(event)).Message(message).Build(start, start.Add(time.Second))
+	}
+	alert := func(name, state string, from, to time.Time) monitorapi.Interval {
+		return monitorapi.NewInterval(monitorapi.SourceAlert, monitorapi.Warning).
+			Locator(monitorapi.Locator{
+				Type: monitorapi.LocatorTypeAlert,
+				Keys: map[monitorapi.LocatorKey]string{
+					monitorapi.LocatorAlertKey:     name,
+					monitorapi.LocatorNamespaceKey: "openshift-monitoring",
+				},
+			}).
+			Message(monitorapi.NewMessage().
+				WithAnnotation(monitorapi.AnnotationAlertState, state).
+				WithAnnotation(monitorapi.AnnotationSeverity, "warning")).
+			Build(from, to)
+	}
+	disruption := func(level monitorapi.IntervalLevel, from, to time.Time) monitorapi.Interval {
+		return monitorapi.NewInterval(monitorapi.SourceDisruption, level).
+			Locator(monitorapi.NewLocator().DisruptionRequiredOnly("kube-api-new-connections", "kube-api")).
+			Message(monitorapi.NewMessage().HumanMessage("disrupted")).
+			Build(from, to)
+	}
+
+	summary := summarizeIntervals(monitorapi.Intervals{
+		repeatedEvent("5"),
+		repeatedEvent("30", monitorapi.AnnotationPathological, monitorapi.AnnotationInteresting),
+		repeatedEvent("1"),
+		alert("KubePodNotReady", "firing", start, start.Add(2*time.Minute)),
+		alert("KubePodNotReady", "firing", start.Add(5*time.Minute), start.Add(6*time.Minute)),
+		alert("KubePodNotReady", "pending", start, start.Add(10*time.Minute)),
+		disruption(monitorapi.Error, start, start.Add(3*time.Second)),
+		disruption(monitorapi.Info, start.Add(3*time.Second), start.Add(time.Minute)),
+	})
+
+	assert.Equal(t, []RepeatedEventSummary{{
+		Locator:      monitorapi.NewLocator().KubeEvent(event).OldLocator(),
+		Reason:       "Unhealthy",
+		Message:      "Readiness probe failed",
+		Count:        30,
+		Pathological: true,
+		Known:        true,
+	}}, summary.RepeatedEvents)
+	assert.Equal(t, []AlertSummary{{
+		Alert:           "KubePodNotReady",
+		Namespace:       "openshift-monitoring",
+		Severity:        "warning",
+		Intervals:       2,
+		DurationSeconds: 180,
+	}}, summary.Alerts)
+	assert.Equal(t, []DisruptionSummary{{
+		Backend:         "kube-api-new-connections",
+		Intervals:       1,
+		DurationSeconds: 3,
+	}}, summary.Disruption)
+}
+
+func TestRenderChartHTML(t *testing.T) {
+	chartTemplate := []byte(`<title>EVENT_INTERVAL_TITLE_GOES_HERE</title>
+<script>var eventIntervals = EVENT_INTERVAL_JSON_GOES_HERE</script>
+<script type="application/json" id="timeline-summary">EVENT_INTERVAL_SUMMARY_GOES_HERE</script>`)
+
+	html, err := RenderChartHTML(chartTemplate, "Intervals - everything", monitorapi.Intervals{})
+	require.NoError(t, err)
+	assert.False(t, strings.Contains(string(html), "GOES_HERE"), "every placeholder must be replaced: %s", html)
+	assert.Contains(t, string(html), `"repeatedEvents":[]`)
+}
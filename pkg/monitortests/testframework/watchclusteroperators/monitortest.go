@@ -20,6 +20,12 @@ func NewOperatorWatcher() monitortestframework.MonitorTest {
 	return &operatorWatcher{}
 }
 
+func (*operatorWatcher) Describe() monitortestframework.MonitorTestDescription {
+	return monitortestframework.MonitorTestDescription{
+		WatchedResources: []string{"clusteroperators.config.openshift.io", "clusterversions.config.openshift.io"},
+	}
+}
+
 func (w *operatorWatcher) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
 	configClient, err := configclient.NewForConfig(adminRESTConfig)
 	if err != nil {
@@ -11,7 +11,9 @@ import (
 	v1 "github.com/openshift/api/config/v1"
 	"github.com/sirupsen/logrus"
 
+	"github.com/openshift/origin/pkg/monitor"
 	"github.com/openshift/origin/pkg/monitortestlibrary/pathologicaleventlibrary"
+	"github.com/openshift/origin/pkg/monitortestlibrary/windowsnodes"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -26,7 +28,9 @@ import (
 
 var reMatchFirstQuote = regexp.MustCompile(`"([^"]+)"( in (\d+(\.\d+)?(s|ms)$))?`)
 
-func startEventMonitoring(ctx context.Context, m monitorapi.RecorderWriter, adminRESTConfig *rest.Config, client kubernetes.Interface) {
+// startEventMonitoring watches events until the context is done.  The returned function records the final counts of
+// events that repeated after the watch last delivered them.
+func startEventMonitoring(ctx context.Context, m monitorapi.RecorderWriter, adminRESTConfig *rest.Config, client kubernetes.Interface, namespaces *namespaceFilter, logger *eventLogger, stats *eventWatchStats) func(ctx context.Context) error {
 
 	// filter out events written "now" but with significantly older start times (events
 	// created in test jobs are the most common)
@@ -35,13 +39,43 @@ func startEventMonitoring(ctx context.Context, m monitorapi.RecorderWriter, admi
 	// map event UIDs to the last resource version we observed, used to skip recording resources
 	// we've already recorded.
 	processedEventUIDs := map[types.UID]string{}
+	sampler := newEventSampler()
+	counts := newObservedEventCounts()
 
 	_, topology, err := pathologicaleventlibrary.GetClusterInfraInfo(adminRESTConfig)
 	if err != nil {
 		logrus.WithError(err).Error("could not fetch cluster infra info")
 	}
 
-	listWatch := cache.NewListWatchFromClient(client.CoreV1().RESTClient(), "events", "", fields.Everything())
+	addOrUpdate := func(obj interface{}) error {
+		event, ok := obj.(*corev1.Event)
+		if !ok {
+			return nil
+		}
+		stats.seen()
+		if !namespaces.recordEvent(event.Namespace, event.UID) {
+			stats.namespaceFiltered()
+			return nil
+		}
+		if processedEventUIDs[event.UID] == event.ResourceVersion {
+			stats.duplicate()
+			return nil
+		}
+		record, represents := sampler.sample(event)
+		if !record {
+			stats.sampled()
+		} else if recordAddOrUpdateEvent(ctx, m, topology, client, significantlyBeforeNow, event, represents) {
+			stats.recorded()
+			counts.observe(event)
+		} else {
+			stats.stale()
+		}
+		logger.log(event, record)
+		processedEventUIDs[event.UID] = event.ResourceVersion
+		return nil
+	}
+
+	listWatch := stats.countWatchErrors(cache.NewListWatchFromClient(client.CoreV1().RESTClient(), "events", "", fields.Everything()))
 	customStore := &cache.FakeCustomStore{
 		// ReplaceFunc called when we do our initial list on starting the reflector. With no resync period,
 		// it should not get called again.
@@ -51,6 +85,9 @@ func startEventMonitoring(ctx context.Context, m monitorapi.RecorderWriter, admi
 				if !ok {
 					continue
 				}
+				if !namespaces.recordEvent(event.Namespace, event.UID) {
+					continue
+				}
 				if processedEventUIDs[event.UID] != event.ResourceVersion {
 					m.RecordResource("events", event)
 					processedEventUIDs[event.UID] = event.ResourceVersion
@@ -58,40 +95,47 @@ func startEventMonitoring(ctx context.Context, m monitorapi.RecorderWriter, admi
 			}
 			return nil
 		},
-		AddFunc: func(obj interface{}) error {
-			event, ok := obj.(*corev1.Event)
-			if !ok {
-				return nil
-			}
-			if processedEventUIDs[event.UID] != event.ResourceVersion {
-				recordAddOrUpdateEvent(ctx, m, topology, client, significantlyBeforeNow, event)
-				processedEventUIDs[event.UID] = event.ResourceVersion
-			}
-			return nil
-		},
-		UpdateFunc: func(obj interface{}) error {
-			event, ok := obj.(*corev1.Event)
-			if !ok {
-				return nil
-			}
-			if processedEventUIDs[event.UID] != event.ResourceVersion {
-				recordAddOrUpdateEvent(ctx, m, topology, client, significantlyBeforeNow, event)
-				processedEventUIDs[event.UID] = event.ResourceVersion
-			}
-			return nil
-		},
+		AddFunc:    addOrUpdate,
+		UpdateFunc: addOrUpdate,
 	}
 	reflector := cache.NewReflector(listWatch, &corev1.Event{}, customStore, 0)
 	go reflector.Run(ctx.Done())
+
+	return func(ctx context.Context) error {
+		events, err := client.CoreV1().Events("").List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		for i := range events.Items {
+			if counts.increased(&events.Items[i]) {
+				recordAddOrUpdateEvent(ctx, m, topology, client, significantlyBeforeNow, &events.Items[i], 1)
+			}
+		}
+		return nil
+	}
 }
 
+// IntervalsFromEvents converts events collected outside of a monitor run, for instance from a must-gather or
+// gather-extra, into the same intervals the event watcher would have recorded.  There is no cluster to look up
+// node roles, so those annotations are omitted.
+func IntervalsFromEvents(ctx context.Context, events []corev1.Event) monitorapi.Intervals {
+	recorder := monitor.NewRecorder()
+	for i := range events {
+		recordAddOrUpdateEvent(ctx, recorder, "", nil, time.Time{}, &events[i], 1)
+	}
+	return recorder.Intervals(time.Time{}, time.Time{})
+}
+
+// recordAddOrUpdateEvent records the event as a resource and an interval, and returns false if the event was too old
+// to record an interval for.
 func recordAddOrUpdateEvent(
 	ctx context.Context,
 	recorder monitorapi.RecorderWriter,
 	topology v1.TopologyMode,
 	client kubernetes.Interface,
 	significantlyBeforeNow time.Time,
-	obj *corev1.Event) {
+	obj *corev1.Event,
+	represents int) bool {
 
 	recorder.RecordResource("events", obj)
 
@@ -112,10 +156,16 @@ func recordAddOrUpdateEvent(
 	if obj.Count > 1 {
 		message = message.WithAnnotation(monitorapi.AnnotationCount, fmt.Sprintf("%d", obj.Count))
 	}
+	// a sampled event stands for the events of its storm that were not recorded.
+	if represents > 1 {
+		message = message.WithAnnotation(monitorapi.AnnotationSampledEvents, fmt.Sprintf("%d", represents))
+	}
 
-	if obj.InvolvedObject.Kind == "Node" {
+	// without a client, as when converting collected events, the node roles are unknown.
+	if obj.InvolvedObject.Kind == "Node" && client != nil {
 		if node, err := client.CoreV1().Nodes().Get(ctx, obj.InvolvedObject.Name, metav1.GetOptions{}); err == nil {
-			message = message.WithAnnotation(monitorapi.AnnotationRoles, nodeRoles(node))
+			message = message.WithAnnotation(monitorapi.AnnotationRoles, nodeRoles(node)).
+				WithAnnotations(windowsnodes.Annotations(node))
 		}
 	}
 	if obj.Reason != "" {
@@ -172,16 +222,13 @@ func recordAddOrUpdateEvent(
 			logrus.Infof("OS update event filtered for being too old: %s - %s - %s",
 				obj.Reason, obj.InvolvedObject.Name, obj.LastTimestamp.Format(time.RFC3339))
 		}
-		return
+		return false
 	}
 
 	message = message.WithAnnotation("firstTimestamp", obj.FirstTimestamp.Format(time.RFC3339))
 	message = message.WithAnnotation("lastTimestamp", obj.LastTimestamp.Format(time.RFC3339))
 
-	// We start with to equal to from, the majority of kube event intervals had this, and these get filtered out
-	// when generating spyglass html. For interesting/pathological events, we're adding a second, which causes them
-	// to get included in the html.
-	to := pathoFrom // we may override later for some events we want to have a duration and get charted
+	// Kube events are instants, they are only charted when marked for display below.
 	locator := monitorapi.NewLocator().KubeEvent(obj)
 
 	// Flag any event that matches one of our allowances as "interesting", regardless how many
@@ -209,27 +256,25 @@ func recordAddOrUpdateEvent(
 			intervalBuilder = intervalBuilder.Display()
 		}
 
-		isPathological := obj.Count > pathologicaleventlibrary.DuplicateEventThreshold
+		isPathological := obj.Count > int32(pathologicaleventlibrary.DuplicateEventThresholdForTopology(topology))
 		if isPathological {
 			// This is a repeated event that exceeds threshold
 			message = message.WithAnnotation(monitorapi.AnnotationPathological, "true")
 			intervalBuilder = intervalBuilder.Display()
 		}
 
-		// serialize.go EventsIntervalsToJSON filters out any with from == to, so we add a second here to
-		// allow these to be charted.
-		to = pathoFrom.Add(1 * time.Second)
+		// repeated events are charted even when they are not interesting.
+		intervalBuilder = intervalBuilder.Display()
 	} else if isInteresting {
 		message = message.WithAnnotation(monitorapi.AnnotationInteresting, "true")
 		intervalBuilder = intervalBuilder.Display()
-		// make sure we add 1 second to the to timestamp so it doesn't get filtered out when creating the spyglass html
-		to = pathoFrom.Add(1 * time.Second)
 	}
 
 	interval := intervalBuilder.Locator(locator).
-		Message(message).Build(pathoFrom, to)
+		Message(message).Build(pathoFrom, pathoFrom)
 
 	recorder.AddIntervals(interval)
+	return true
 }
 
 func eventForContainer(fieldPath string) (string, bool) {
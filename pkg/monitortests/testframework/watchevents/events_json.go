@@ -0,0 +1,51 @@
+package watchevents
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// writeEventsJSON writes the recorded events in the same format as gather-extra's events.json
+// (`oc get events -A -o json`), so tooling built for gather-extra works with runs that only have monitor
+// output, and the two can be diffed to check what the watch missed.  The monitor.openshift.io annotations
+// are kept, they show how many updates the watch observed for each event.  The file is named events.json, without the
+// time suffix of the other artifacts, so that the same tooling finds it.
+func writeEventsJSON(storageDir string, finalResourceState monitorapi.ResourcesMap) error {
+	jsonContent, err := json.MarshalIndent(eventListFromResources(finalResourceState), "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(storageDir, "events.json"), jsonContent, 0644)
+}
+
+func eventListFromResources(finalResourceState monitorapi.ResourcesMap) *corev1.EventList {
+	ret := &corev1.EventList{Items: []corev1.Event{}}
+	// oc emits a generic List when printing more than one object.
+	ret.Kind = "List"
+	ret.APIVersion = "v1"
+
+	for _, obj := range finalResourceState["events"] {
+		event, ok := obj.(*corev1.Event)
+		if !ok {
+			continue
+		}
+		item := *event.DeepCopy()
+		// objects from the informer have no type information, but oc always prints it.
+		item.Kind = "Event"
+		item.APIVersion = "v1"
+		ret.Items = append(ret.Items, item)
+	}
+
+	sort.Slice(ret.Items, func(i, j int) bool {
+		if ret.Items[i].Namespace != ret.Items[j].Namespace {
+			return ret.Items[i].Namespace < ret.Items[j].Namespace
+		}
+		return ret.Items[i].Name < ret.Items[j].Name
+	})
+	return ret
+}
@@ -0,0 +1,38 @@
+package watchevents
+
+import (
+	"testing"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestEventListFromResources(t *testing.T) {
+	event := func(namespace, name string) *corev1.Event {
+		return &corev1.Event{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, UID: types.UID("uid-" + name)}}
+	}
+	resources := monitorapi.ResourcesMap{
+		"events": monitorapi.InstanceMap{
+			{Namespace: "openshift-etcd", Name: "b", UID: "uid-b"}:     event("openshift-etcd", "b"),
+			{Namespace: "default", Name: "c", UID: "uid-c"}:            event("default", "c"),
+			{Namespace: "openshift-etcd", Name: "a", UID: "uid-a"}:     event("openshift-etcd", "a"),
+			{Namespace: "openshift-etcd", Name: "pod", UID: "uid-pod"}: &corev1.Pod{},
+		},
+	}
+
+	list := eventListFromResources(resources)
+	assert.Equal(t, "List", list.Kind)
+	assert.Equal(t, "v1", list.APIVersion)
+	require.Len(t, list.Items, 3)
+	assert.Equal(t, "default", list.Items[0].Namespace)
+	assert.Equal(t, "a", list.Items[1].Name)
+	assert.Equal(t, "b", list.Items[2].Name)
+	assert.Equal(t, "Event", list.Items[0].Kind)
+	assert.Empty(t, resources["events"][monitorapi.InstanceKey{Namespace: "default", Name: "c", UID: "uid-c"}].GetObjectKind().GroupVersionKind().Kind, "recorded resources must not be modified")
+
+	assert.Empty(t, eventListFromResources(monitorapi.ResourcesMap{}).Items)
+}
@@ -0,0 +1,40 @@
+package watchevents
+
+import (
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// observedEventCounts remembers the count of every event recorded by the watch, so that events which kept repeating
+// after the watch last delivered them can be recorded with their final count when the run ends.
+type observedEventCounts struct {
+	lock   sync.Mutex
+	counts map[types.UID]int32
+}
+
+func newObservedEventCounts() *observedEventCounts {
+	return &observedEventCounts{counts: map[types.UID]int32{}}
+}
+
+func (o *observedEventCounts) observe(event *corev1.Event) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	if event.Count > o.counts[event.UID] {
+		o.counts[event.UID] = event.Count
+	}
+}
+
+// increased returns whether a recorded event has a higher count than was recorded, observing the new count if so.
+// Events the watch never recorded, because they were filtered or stale, are left alone.
+func (o *observedEventCounts) increased(event *corev1.Event) bool {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	last, ok := o.counts[event.UID]
+	if !ok || event.Count <= last {
+		return false
+	}
+	o.counts[event.UID] = event.Count
+	return true
+}
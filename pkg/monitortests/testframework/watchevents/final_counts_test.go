@@ -0,0 +1,25 @@
+package watchevents
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestObservedEventCounts(t *testing.T) {
+	counts := newObservedEventCounts()
+	event := func(uid string, count int32) *corev1.Event {
+		return &corev1.Event{ObjectMeta: metav1.ObjectMeta{UID: types.UID(uid)}, Count: count}
+	}
+
+	counts.observe(event("a", 5))
+	counts.observe(event("b", 1))
+
+	assert.False(t, counts.increased(event("a", 5)), "unchanged")
+	assert.True(t, counts.increased(event("a", 40)), "repeated after the watch last saw it")
+	assert.False(t, counts.increased(event("a", 40)), "the final count is only recorded once")
+	assert.False(t, counts.increased(event("c", 40)), "never recorded by the watch")
+}
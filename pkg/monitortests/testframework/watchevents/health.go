@@ -0,0 +1,146 @@
+package watchevents
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+)
+
+// lossyFractionThreshold is the fraction of the events seen that may be sampled away before the event timeline is
+// flagged as incomplete.
+const lossyFractionThreshold = 0.01
+
+// eventWatchHealth counts what happened to every event notification the watch delivered, so we can tell how complete
+// the event timeline is.
+type eventWatchHealth struct {
+	// Seen is every add or update the watch delivered.
+	Seen int `json:"seen"`
+	// Recorded were recorded as intervals.
+	Recorded int `json:"recorded"`
+	// Duplicates were redelivered at a resource version already recorded.
+	Duplicates int `json:"duplicates"`
+	// Stale happened too long before the monitor started to be recorded.
+	Stale int `json:"stale"`
+	// NamespaceFiltered were in namespaces excluded from recording.
+	NamespaceFiltered int `json:"namespaceFiltered"`
+	// Sampled were dropped while sampling an event storm.
+	Sampled int `json:"sampled"`
+	// WatchErrors is the number of failed list and watch calls, during which events may have been missed.
+	WatchErrors int `json:"watchErrors"`
+}
+
+// lossy returns whether the watch lost enough events, as opposed to filtering them on purpose, that the event
+// timeline should not be trusted to be complete.
+func (h eventWatchHealth) lossy() bool {
+	if h.WatchErrors > 0 {
+		return true
+	}
+	return h.Seen > 0 && float64(h.Sampled)/float64(h.Seen) > lossyFractionThreshold
+}
+
+type eventWatchStats struct {
+	lock   sync.Mutex
+	health eventWatchHealth
+}
+
+func (s *eventWatchStats) seen() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.health.Seen++
+}
+
+func (s *eventWatchStats) recorded() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.health.Recorded++
+}
+
+func (s *eventWatchStats) duplicate() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.health.Duplicates++
+}
+
+func (s *eventWatchStats) stale() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.health.Stale++
+}
+
+func (s *eventWatchStats) namespaceFiltered() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.health.NamespaceFiltered++
+}
+
+func (s *eventWatchStats) sampled() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.health.Sampled++
+}
+
+func (s *eventWatchStats) watchError() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.health.WatchErrors++
+}
+
+func (s *eventWatchStats) snapshot() eventWatchHealth {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.health
+}
+
+// countWatchErrors wraps the list and watch calls of the reflector to count the ones that fail.
+func (s *eventWatchStats) countWatchErrors(listWatch *cache.ListWatch) *cache.ListWatch {
+	listFunc, watchFunc := listWatch.ListFunc, listWatch.WatchFunc
+	return &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			ret, err := listFunc(options)
+			if err != nil {
+				s.watchError()
+			}
+			return ret, err
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			ret, err := watchFunc(options)
+			if err != nil {
+				s.watchError()
+			}
+			return ret, err
+		},
+	}
+}
+
+// healthIntervals marks the run as having an incomplete event timeline when the watch was lossy.
+func healthIntervals(health eventWatchHealth, beginning, end time.Time) monitorapi.Intervals {
+	if !health.lossy() {
+		return nil
+	}
+	return monitorapi.Intervals{
+		monitorapi.NewInterval(monitorapi.SourceEventWatcherHealth, monitorapi.Warning).
+			Locator(monitorapi.NewLocator().EventWatcher()).
+			Message(monitorapi.NewMessage().Reason(monitorapi.EventsDroppedReason).
+				HumanMessage(fmt.Sprintf("event timeline is incomplete: %d of %d events were sampled away and %d list or watch calls failed",
+					health.Sampled, health.Seen, health.WatchErrors))).
+			Display().
+			Build(beginning, end),
+	}
+}
+
+func writeHealthSummary(storageDir, timeSuffix string, health eventWatchHealth) error {
+	jsonContent, err := json.MarshalIndent(health, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(storageDir, fmt.Sprintf("event-watcher-health%s.json", timeSuffix)), jsonContent, 0644)
+}
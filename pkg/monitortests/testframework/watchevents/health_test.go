@@ -0,0 +1,53 @@
+package watchevents
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthIntervals(t *testing.T) {
+	beginning := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	end := beginning.Add(time.Hour)
+
+	tests := []struct {
+		name   string
+		health eventWatchHealth
+		lossy  bool
+	}{
+		{
+			name:   "filtered on purpose",
+			health: eventWatchHealth{Seen: 1000, Recorded: 500, Duplicates: 100, Stale: 200, NamespaceFiltered: 195, Sampled: 5},
+		},
+		{
+			name:   "storm sampled away",
+			health: eventWatchHealth{Seen: 1000, Recorded: 900, Sampled: 100},
+			lossy:  true,
+		},
+		{
+			name:   "watch errors",
+			health: eventWatchHealth{Seen: 1000, Recorded: 1000, WatchErrors: 1},
+			lossy:  true,
+		},
+		{
+			name: "nothing seen",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			intervals := healthIntervals(tt.health, beginning, end)
+			if !tt.lossy {
+				assert.Empty(t, intervals)
+				return
+			}
+			require.Len(t, intervals, 1)
+			assert.Equal(t, monitorapi.SourceEventWatcherHealth, intervals[0].Source)
+			assert.Equal(t, monitorapi.EventsDroppedReason, intervals[0].Message.Reason)
+			assert.Equal(t, beginning, intervals[0].From)
+			assert.Equal(t, end, intervals[0].To)
+		})
+	}
+}
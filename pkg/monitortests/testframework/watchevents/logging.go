@@ -0,0 +1,85 @@
+package watchevents
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// EventLogVerbosityEnv sets the event log verbosity when it is not set by flag.
+	EventLogVerbosityEnv = "OPENSHIFT_TESTS_EVENT_LOG_VERBOSITY"
+
+	// EventLogNone logs nothing per event, the default.
+	EventLogNone = "none"
+	// EventLogSampled logs one in every eventLogSampleRate events.
+	EventLogSampled = "sampled"
+	// EventLogAll logs every event the watch sees.
+	EventLogAll = "all"
+
+	eventLogSampleRate = 100
+)
+
+// ValidateEventLogVerbosity returns an error for anything but an empty or known verbosity.
+func ValidateEventLogVerbosity(verbosity string) error {
+	switch verbosity {
+	case "", EventLogNone, EventLogSampled, EventLogAll:
+		return nil
+	default:
+		return fmt.Errorf("unknown event log verbosity %q, expected %s, %s or %s", verbosity, EventLogNone, EventLogSampled, EventLogAll)
+	}
+}
+
+// eventLogger logs the events the watch sees.  Logging every event of a large run produces gigabytes of build log,
+// so it is opt-in.
+type eventLogger struct {
+	verbosity string
+	seen      int
+}
+
+func newEventLogger(verbosity string) (*eventLogger, error) {
+	if len(verbosity) == 0 {
+		verbosity = os.Getenv(EventLogVerbosityEnv)
+	}
+	if err := ValidateEventLogVerbosity(verbosity); err != nil {
+		return nil, err
+	}
+	if len(verbosity) == 0 {
+		verbosity = EventLogNone
+	}
+	return &eventLogger{verbosity: verbosity}, nil
+}
+
+// shouldLog counts the event and returns whether it is logged at the logger's verbosity.
+func (l *eventLogger) shouldLog() bool {
+	l.seen++
+	switch l.verbosity {
+	case EventLogAll:
+		return true
+	case EventLogSampled:
+		return l.seen%eventLogSampleRate == 1
+	default:
+		return false
+	}
+}
+
+func (l *eventLogger) log(event *corev1.Event, recorded bool) {
+	if !l.shouldLog() {
+		return
+	}
+	fields := logrus.Fields{
+		"namespace": event.Namespace,
+		"reason":    event.Reason,
+		"kind":      event.InvolvedObject.Kind,
+		"name":      event.InvolvedObject.Name,
+		"count":     event.Count,
+		"recorded":  recorded,
+	}
+	if l.verbosity == EventLogSampled {
+		fields["sampleRate"] = eventLogSampleRate
+		fields["seen"] = l.seen
+	}
+	logrus.WithFields(fields).Info("watched event")
+}
@@ -0,0 +1,35 @@
+package watchevents
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventLogger(t *testing.T) {
+	t.Setenv(EventLogVerbosityEnv, "")
+
+	logged := func(verbosity string) int {
+		logger, err := newEventLogger(verbosity)
+		require.NoError(t, err)
+		count := 0
+		for i := 0; i < 250; i++ {
+			if logger.shouldLog() {
+				count++
+			}
+		}
+		return count
+	}
+	assert.Equal(t, 0, logged(""))
+	assert.Equal(t, 0, logged(EventLogNone))
+	assert.Equal(t, 3, logged(EventLogSampled))
+	assert.Equal(t, 250, logged(EventLogAll))
+
+	t.Setenv(EventLogVerbosityEnv, EventLogAll)
+	assert.Equal(t, 250, logged(""))
+	assert.Equal(t, 3, logged(EventLogSampled), "the flag wins over the environment")
+
+	_, err := newEventLogger("verbose")
+	assert.Error(t, err)
+}
@@ -8,15 +8,38 @@ import (
 
 	"github.com/openshift/origin/pkg/monitor/monitorapi"
 	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	"github.com/sirupsen/logrus"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 )
 
 type eventWatcher struct {
+	namespaceInclude []string
+	namespaceExclude []string
+	logVerbosity     string
+
+	namespaces *namespaceFilter
+	stats      *eventWatchStats
+
+	recordFinalCounts func(ctx context.Context) error
+}
+
+// NewEventWatcher records events as intervals.  The namespaces of the events recorded can be limited with include
+// and exclude regexes, events in other namespaces are only counted.  logVerbosity controls logging of each event
+// seen, falling back to EventLogVerbosityEnv when empty.
+func NewEventWatcher(namespaceInclude, namespaceExclude []string, logVerbosity string) monitortestframework.MonitorTest {
+	return &eventWatcher{
+		namespaceInclude: namespaceInclude,
+		namespaceExclude: namespaceExclude,
+		logVerbosity:     logVerbosity,
+		stats:            &eventWatchStats{},
+	}
 }
 
-func NewEventWatcher() monitortestframework.MonitorTest {
-	return &eventWatcher{}
+func (*eventWatcher) Describe() monitortestframework.MonitorTestDescription {
+	return monitortestframework.MonitorTestDescription{
+		WatchedResources: []string{"events"},
+	}
 }
 
 func (w *eventWatcher) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
@@ -25,14 +48,31 @@ func (w *eventWatcher) StartCollection(ctx context.Context, adminRESTConfig *res
 		return err
 	}
 
-	startEventMonitoring(ctx, recorder, adminRESTConfig, kubeClient)
+	w.namespaces, err = newNamespaceFilter(w.namespaceInclude, w.namespaceExclude)
+	if err != nil {
+		return err
+	}
+
+	logger, err := newEventLogger(w.logVerbosity)
+	if err != nil {
+		return err
+	}
+
+	w.recordFinalCounts = startEventMonitoring(ctx, recorder, adminRESTConfig, kubeClient, w.namespaces, logger, w.stats)
 
 	return nil
 }
 
 func (w *eventWatcher) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
-	// because we are sharing a recorder that we're streaming into, we don't need to have a separate data collection step.
-	return nil, nil, nil
+	// because we are sharing a recorder that we're streaming into, we don't need to have a separate data collection step
+	// beyond catching up on the counts of repeated events.
+	if w.recordFinalCounts != nil {
+		if err := w.recordFinalCounts(ctx); err != nil {
+			// without the final counts the pathological event tests use the counts last seen by the watch.
+			logrus.WithError(err).Warning("unable to record the final counts of repeated events")
+		}
+	}
+	return healthIntervals(w.stats.snapshot(), beginning, end), nil, nil
 }
 
 func (*eventWatcher) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
@@ -45,8 +85,18 @@ func (*eventWatcher) EvaluateTestsFromConstructedIntervals(ctx context.Context,
 	return nil, nil
 }
 
-func (*eventWatcher) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
-	return nil
+func (w *eventWatcher) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	if err := writeEventsJSON(storageDir, finalResourceState); err != nil {
+		return err
+	}
+	if w.namespaces == nil {
+		// collection never started.
+		return nil
+	}
+	if err := writeHealthSummary(storageDir, timeSuffix, w.stats.snapshot()); err != nil {
+		return err
+	}
+	return w.namespaces.writeSummary(storageDir, timeSuffix)
 }
 
 func (*eventWatcher) Cleanup(ctx context.Context) error {
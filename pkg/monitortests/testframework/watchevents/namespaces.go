@@ -0,0 +1,117 @@
+package watchevents
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// namespaceFilter decides which namespaces the event watcher records events for.  An empty include list includes
+// every namespace, and exclusions win over inclusions.
+type namespaceFilter struct {
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+
+	lock sync.Mutex
+	// excluded counts the distinct events seen in each excluded namespace.
+	excluded    map[string]int
+	excludedUID map[types.UID]bool
+}
+
+func newNamespaceFilter(include, exclude []string) (*namespaceFilter, error) {
+	ret := &namespaceFilter{
+		excluded:    map[string]int{},
+		excludedUID: map[types.UID]bool{},
+	}
+	for _, expr := range include {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid event namespace include %q: %w", expr, err)
+		}
+		ret.include = append(ret.include, re)
+	}
+	for _, expr := range exclude {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid event namespace exclude %q: %w", expr, err)
+		}
+		ret.exclude = append(ret.exclude, re)
+	}
+	return ret, nil
+}
+
+func (f *namespaceFilter) configured() bool {
+	return len(f.include) > 0 || len(f.exclude) > 0
+}
+
+func (f *namespaceFilter) allows(namespace string) bool {
+	for _, re := range f.exclude {
+		if re.MatchString(namespace) {
+			return false
+		}
+	}
+	if len(f.include) == 0 {
+		return true
+	}
+	for _, re := range f.include {
+		if re.MatchString(namespace) {
+			return true
+		}
+	}
+	return false
+}
+
+// recordEvent returns whether the event should be recorded, tallying it against its namespace when it is not.
+func (f *namespaceFilter) recordEvent(namespace string, uid types.UID) bool {
+	if f.allows(namespace) {
+		return true
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if !f.excludedUID[uid] {
+		f.excludedUID[uid] = true
+		f.excluded[namespace]++
+	}
+	return false
+}
+
+type excludedEventsSummary struct {
+	Include []string `json:"include,omitempty"`
+	Exclude []string `json:"exclude,omitempty"`
+	// ExcludedEvents is the number of distinct events that were not recorded, by namespace.
+	ExcludedEvents map[string]int `json:"excludedEvents"`
+	Total          int            `json:"total"`
+}
+
+func (f *namespaceFilter) summary() excludedEventsSummary {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	ret := excludedEventsSummary{ExcludedEvents: map[string]int{}}
+	for _, re := range f.include {
+		ret.Include = append(ret.Include, re.String())
+	}
+	for _, re := range f.exclude {
+		ret.Exclude = append(ret.Exclude, re.String())
+	}
+	for namespace, count := range f.excluded {
+		ret.ExcludedEvents[namespace] = count
+		ret.Total += count
+	}
+	return ret
+}
+
+func (f *namespaceFilter) writeSummary(storageDir, timeSuffix string) error {
+	if !f.configured() {
+		return nil
+	}
+	jsonContent, err := json.MarshalIndent(f.summary(), "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(storageDir, fmt.Sprintf("excluded-events-summary%s.json", timeSuffix)), jsonContent, 0644)
+}
@@ -0,0 +1,75 @@
+package watchevents
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespaceFilter(t *testing.T) {
+	tests := []struct {
+		name       string
+		include    []string
+		exclude    []string
+		allowed    []string
+		disallowed []string
+	}{
+		{
+			name:    "unconfigured",
+			allowed: []string{"openshift-etcd", "e2e-test-foo"},
+		},
+		{
+			name:       "exclude test namespaces",
+			exclude:    []string{"^e2e-"},
+			allowed:    []string{"openshift-etcd", "default"},
+			disallowed: []string{"e2e-test-foo"},
+		},
+		{
+			name:       "single component",
+			include:    []string{"^openshift-etcd(-operator)?$"},
+			allowed:    []string{"openshift-etcd", "openshift-etcd-operator"},
+			disallowed: []string{"openshift-etcd-foo", "default"},
+		},
+		{
+			name:       "exclude wins",
+			include:    []string{"^openshift-"},
+			exclude:    []string{"^openshift-marketplace$"},
+			allowed:    []string{"openshift-etcd"},
+			disallowed: []string{"openshift-marketplace"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := newNamespaceFilter(tt.include, tt.exclude)
+			require.NoError(t, err)
+			for _, namespace := range tt.allowed {
+				assert.True(t, filter.allows(namespace), namespace)
+			}
+			for _, namespace := range tt.disallowed {
+				assert.False(t, filter.allows(namespace), namespace)
+			}
+		})
+	}
+
+	_, err := newNamespaceFilter([]string{"("}, nil)
+	assert.Error(t, err)
+}
+
+func TestNamespaceFilterSummary(t *testing.T) {
+	filter, err := newNamespaceFilter(nil, []string{"^e2e-"})
+	require.NoError(t, err)
+
+	assert.True(t, filter.recordEvent("openshift-etcd", "a"))
+	assert.False(t, filter.recordEvent("e2e-test-one", "b"))
+	// updates to the same event are only counted once.
+	assert.False(t, filter.recordEvent("e2e-test-one", "b"))
+	assert.False(t, filter.recordEvent("e2e-test-one", "c"))
+	assert.False(t, filter.recordEvent("e2e-test-two", "d"))
+
+	assert.Equal(t, excludedEventsSummary{
+		Exclude:        []string{"^e2e-"},
+		ExcludedEvents: map[string]int{"e2e-test-one": 2, "e2e-test-two": 1},
+		Total:          3,
+	}, filter.summary())
+}
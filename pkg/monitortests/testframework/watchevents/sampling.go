@@ -0,0 +1,109 @@
+package watchevents
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// eventSamplingThreshold is the number of updates one (namespace, reason, involved object) may see within
+	// eventSamplingWindow before its events are sampled.
+	eventSamplingThreshold = 60
+	eventSamplingWindow    = time.Minute
+	// eventSamplingRate records one in every eventSamplingRate events of a sampled tuple.
+	eventSamplingRate = 10
+)
+
+type eventSampleKey struct {
+	namespace string
+	reason    string
+	kind      string
+	name      string
+}
+
+type eventSampleTuple struct {
+	windowStart time.Time
+	inWindow    int
+	// skipped counts the events seen since the last one that was recorded.
+	skipped int
+}
+
+// eventSampler bounds how many events a storm of one (namespace, reason, involved object) records.  Once a tuple
+// exceeds the threshold within the window only one in every rate events is recorded, annotated with the number of
+// events it stands for, until the next window starts.
+type eventSampler struct {
+	threshold int
+	window    time.Duration
+	rate      int
+	now       func() time.Time
+
+	tuples    map[eventSampleKey]*eventSampleTuple
+	lastPrune time.Time
+}
+
+func newEventSampler() *eventSampler {
+	return &eventSampler{
+		threshold: eventSamplingThreshold,
+		window:    eventSamplingWindow,
+		rate:      eventSamplingRate,
+		now:       time.Now,
+		tuples:    map[eventSampleKey]*eventSampleTuple{},
+	}
+}
+
+// sample returns whether the event should be recorded and how many events it stands for.
+func (s *eventSampler) sample(event *corev1.Event) (bool, int) {
+	now := s.now()
+	s.prune(now)
+
+	key := eventSampleKey{
+		namespace: event.Namespace,
+		reason:    event.Reason,
+		kind:      event.InvolvedObject.Kind,
+		name:      event.InvolvedObject.Name,
+	}
+	tuple, ok := s.tuples[key]
+	if !ok {
+		tuple = &eventSampleTuple{windowStart: now}
+		s.tuples[key] = tuple
+	}
+	if now.Sub(tuple.windowStart) >= s.window {
+		tuple.windowStart = now
+		tuple.inWindow = 0
+	}
+	tuple.inWindow++
+
+	if tuple.inWindow <= s.threshold {
+		// events skipped before the storm ended are carried by the next recorded event.
+		represents := tuple.skipped + 1
+		tuple.skipped = 0
+		return true, represents
+	}
+	if tuple.inWindow == s.threshold+1 {
+		logrus.Infof("sampling one in %d events for namespace/%s reason/%s %s/%s, more than %d seen within %s",
+			s.rate, key.namespace, key.reason, key.kind, key.name, s.threshold, s.window)
+	}
+
+	tuple.skipped++
+	if tuple.skipped < s.rate {
+		return false, 0
+	}
+	represents := tuple.skipped
+	tuple.skipped = 0
+	return true, represents
+}
+
+// prune forgets tuples that have been quiet for a window so the sampler does not grow with every event seen.
+func (s *eventSampler) prune(now time.Time) {
+	if now.Sub(s.lastPrune) < s.window {
+		return
+	}
+	s.lastPrune = now
+	for key, tuple := range s.tuples {
+		if tuple.skipped == 0 && now.Sub(tuple.windowStart) >= s.window {
+			delete(s.tuples, key)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package watchevents
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEventSampler(t *testing.T) {
+	now := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	sampler := newEventSampler()
+	sampler.threshold = 3
+	sampler.rate = 2
+	sampler.now = func() time.Time { return now }
+
+	storm := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Namespace: "openshift-etcd"},
+		Reason:         "BackOff",
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "etcd-0"},
+	}
+	other := storm.DeepCopy()
+	other.InvolvedObject.Name = "etcd-1"
+
+	type result struct {
+		record     bool
+		represents int
+	}
+	sample := func(event *corev1.Event) result {
+		record, represents := sampler.sample(event)
+		return result{record, represents}
+	}
+
+	// under the threshold every event is recorded.
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, result{true, 1}, sample(storm))
+	}
+	// over it, one in two.
+	assert.Equal(t, result{false, 0}, sample(storm))
+	assert.Equal(t, result{true, 2}, sample(storm))
+	assert.Equal(t, result{false, 0}, sample(storm))
+	// other objects are tracked separately.
+	assert.Equal(t, result{true, 1}, sample(other))
+
+	// a new window stops sampling and the skipped event is carried by the next one recorded.
+	now = now.Add(time.Minute)
+	assert.Equal(t, result{true, 2}, sample(storm))
+	assert.Equal(t, result{true, 1}, sample(storm))
+
+	// quiet tuples are forgotten.
+	now = now.Add(2 * time.Minute)
+	sampler.prune(now)
+	assert.Len(t, sampler.tuples, 0)
+}
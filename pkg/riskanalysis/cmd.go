@@ -40,6 +40,10 @@ const (
 type Options struct {
 	JUnitDir string
 	SippyURL string
+	// HistoricalPassRatesFile, when set, is used to analyze the risk locally instead of requesting it from sippy.
+	HistoricalPassRatesFile string
+	// TestRenamesFile maps the previous names of renamed tests to their current names, so their history is found.
+	TestRenamesFile string
 }
 
 // Run performs the test risk analysis by reading the output files from the test run, submitting them to sippy,
@@ -92,7 +96,9 @@ func (opt *Options) Run() error {
 		}
 		finalProwJobRun.Tests = append(finalProwJobRun.Tests, pjr.Tests...)
 		finalProwJobRun.TestCount += pjr.TestCount
+		finalProwJobRun.IntervalFeatures = mergeIntervalFeatures(finalProwJobRun.IntervalFeatures, pjr.IntervalFeatures)
 	}
+	opt.writeIntervalFeatures(finalProwJobRun.IntervalFeatures)
 
 	inputBytes, err := json.Marshal(finalProwJobRun)
 	if err != nil {
@@ -100,7 +106,7 @@ func (opt *Options) Run() error {
 		return nil
 	}
 
-	riskAnalysisBytes, errRA := opt.readWriteRiskAnalysis(inputBytes)
+	riskAnalysisBytes, errRA := opt.readWriteRiskAnalysis(finalProwJobRun, inputBytes)
 	// don't fail out yet, still run disruption if RA fails
 
 	disruptionBytes := []byte(`{Backends: []}`)
@@ -144,8 +150,15 @@ type raRequestLog struct {
 
 // readWriteRiskAnalysis requests Risk Analysis from sippy, writes the results to disk, and returns the RA html to include in prow job output.
 // If the request fails, it will try up to maxTries times before returning an error; an error means no RA data returned.
-func (opt *Options) readWriteRiskAnalysis(inputBytes []byte) ([]byte, error) {
-	riskAnalysisBytes, err := opt.requestRiskAnalysis(inputBytes, &http.Client{}, &realSleeper{})
+// With a HistoricalPassRatesFile the analysis is computed locally instead.
+func (opt *Options) readWriteRiskAnalysis(jobRun *ProwJobRun, inputBytes []byte) ([]byte, error) {
+	var riskAnalysisBytes []byte
+	var err error
+	if len(opt.HistoricalPassRatesFile) > 0 {
+		riskAnalysisBytes, err = opt.localRiskAnalysis(jobRun)
+	} else {
+		riskAnalysisBytes, err = opt.requestRiskAnalysis(inputBytes, &http.Client{}, &realSleeper{})
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -159,6 +172,7 @@ func (opt *Options) readWriteRiskAnalysis(inputBytes []byte) ([]byte, error) {
 	}
 
 	opt.writeRAResults(riskAnalysisBytes)
+	opt.writeTestRiskScores(riskAnalysisBytes)
 	return riskAnalysisBytes, nil // whether or not the file was written
 }
 
@@ -265,31 +279,7 @@ func (opt *Options) writeRARequestLogs(logs *[]*raRequestLog) {
 
 // writeRAResults writes the RA test results to autodl files in the junit directory; errors abort with a log message
 func (opt *Options) writeRAResults(analysisBytes []byte) {
-	var analysis struct {
-		Tests []struct {
-			Name   string
-			TestId int
-			Risk   struct {
-				Level struct {
-					Name  string
-					Level int
-				}
-				CurrentRuns           int
-				CurrentPasses         int
-				CurrentPassPercentage float64
-			}
-		}
-		OverallRisk struct {
-			Level struct {
-				Name  string
-				Level int
-			}
-			JobRunTestCount        int
-			JobRunTestFailures     int
-			NeverStableJob         bool
-			HistoricalRunTestCount int
-		}
-	}
+	var analysis riskAnalysisResult
 	err := json.Unmarshal(analysisBytes, &analysis)
 	if err != nil {
 		logrus.WithError(err).Error("Error unmarshalling risk analysis json")
@@ -0,0 +1,84 @@
+package riskanalysis
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+const raFeaturesFile = "risk-analysis-features.json"
+
+// IntervalFeatures summarize the health of a job run from its intervals, so the risk analysis can tell test failures
+// in an otherwise healthy run from failures in a run that was disrupted throughout.
+type IntervalFeatures struct {
+	// DisruptionSeconds is the total time any backend was disrupted.
+	DisruptionSeconds float64
+	// PathologicalEventCount is the number of events that repeated more often than allowed.
+	PathologicalEventCount int
+	// AlertFiringMinutes is the total time alerts were firing, excluding the always firing Watchdog.
+	AlertFiringMinutes float64
+}
+
+func computeIntervalFeatures(intervals monitorapi.Intervals) *IntervalFeatures {
+	features := &IntervalFeatures{}
+	for _, interval := range intervals {
+		switch {
+		case interval.Source == monitorapi.SourceDisruption && monitorapi.IsErrorEvent(interval):
+			features.DisruptionSeconds += intervalSeconds(interval)
+		case interval.Source == monitorapi.SourceAlert && monitorapi.AlertFiring()(interval):
+			if interval.Locator.Keys[monitorapi.LocatorAlertKey] == "Watchdog" {
+				continue
+			}
+			features.AlertFiringMinutes += intervalSeconds(interval) / 60
+		case interval.Message.Annotations[monitorapi.AnnotationPathological] == "true":
+			features.PathologicalEventCount++
+		}
+	}
+	return features
+}
+
+// intervalSeconds treats intervals that were still open at the end of the run as instants.
+func intervalSeconds(interval monitorapi.Interval) float64 {
+	if interval.To.IsZero() || interval.To.Before(interval.From) {
+		return 0
+	}
+	return interval.To.Sub(interval.From).Seconds()
+}
+
+// mergeIntervalFeatures adds the features of each invocation of openshift-tests in the job run.
+func mergeIntervalFeatures(existing, additional *IntervalFeatures) *IntervalFeatures {
+	if additional == nil {
+		return existing
+	}
+	if existing == nil {
+		merged := *additional
+		return &merged
+	}
+	return &IntervalFeatures{
+		DisruptionSeconds:      existing.DisruptionSeconds + additional.DisruptionSeconds,
+		PathologicalEventCount: existing.PathologicalEventCount + additional.PathologicalEventCount,
+		AlertFiringMinutes:     existing.AlertFiringMinutes + additional.AlertFiringMinutes,
+	}
+}
+
+// writeIntervalFeatures records the features that were submitted so the analysis can be reproduced.
+func (opt *Options) writeIntervalFeatures(features *IntervalFeatures) {
+	if features == nil {
+		return
+	}
+	jsonContent, err := json.MarshalIndent(features, "", "    ")
+	if err != nil {
+		logrus.WithError(err).Error("Error marshalling risk analysis features")
+		return
+	}
+	outputFile := filepath.Join(opt.JUnitDir, raFeaturesFile)
+	if err := os.WriteFile(outputFile, jsonContent, 0644); err != nil {
+		logrus.WithError(err).Error("Error writing risk analysis features artifact")
+		return
+	}
+	logrus.Infof("Successfully wrote: %s", outputFile)
+}
@@ -0,0 +1,87 @@
+package riskanalysis
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+func TestComputeIntervalFeatures(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	disruption := func(level monitorapi.IntervalLevel, duration time.Duration) monitorapi.Interval {
+		return monitorapi.NewInterval(monitorapi.SourceDisruption, level).
+			Locator(monitorapi.NewLocator().DisruptionRequiredOnly("kube-api-new-connections", "kube-api")).
+			Message(monitorapi.NewMessage().HumanMessage("disrupted")).
+			Build(start, start.Add(duration))
+	}
+	alert := func(name, state string, duration time.Duration) monitorapi.Interval {
+		return monitorapi.NewInterval(monitorapi.SourceAlert, monitorapi.Warning).
+			Locator(monitorapi.Locator{
+				Type: monitorapi.LocatorTypeAlert,
+				Keys: map[monitorapi.LocatorKey]string{monitorapi.LocatorAlertKey: name},
+			}).
+			Message(monitorapi.NewMessage().WithAnnotation(monitorapi.AnnotationAlertState, state)).
+			Build(start, start.Add(duration))
+	}
+	event := func(pathological bool) monitorapi.Interval {
+		message := monitorapi.NewMessage().Reason("BackOff").HumanMessage("Back-off restarting failed container")
+		if pathological {
+			message = message.WithAnnotation(monitorapi.AnnotationPathological, "true")
+		}
+		return monitorapi.NewInterval(monitorapi.SourceKubeEvent, monitorapi.Warning).
+			Message(message).
+			Build(start, start.Add(time.Second))
+	}
+
+	features := computeIntervalFeatures(monitorapi.Intervals{
+		disruption(monitorapi.Error, 10*time.Second),
+		disruption(monitorapi.Error, 5*time.Second),
+		disruption(monitorapi.Info, time.Minute),
+		alert("KubePodNotReady", "firing", 3*time.Minute),
+		alert("KubePodNotReady", "pending", 10*time.Minute),
+		alert("Watchdog", "firing", time.Hour),
+		event(true),
+		event(true),
+		event(false),
+	})
+
+	assert.Equal(t, &IntervalFeatures{
+		DisruptionSeconds:      15,
+		PathologicalEventCount: 2,
+		AlertFiringMinutes:     3,
+	}, features)
+}
+
+func TestMergeIntervalFeatures(t *testing.T) {
+	first := &IntervalFeatures{DisruptionSeconds: 1, PathologicalEventCount: 2, AlertFiringMinutes: 3}
+	second := &IntervalFeatures{DisruptionSeconds: 10, PathologicalEventCount: 20, AlertFiringMinutes: 30}
+
+	assert.Nil(t, mergeIntervalFeatures(nil, nil))
+	assert.Equal(t, first, mergeIntervalFeatures(first, nil))
+	assert.Equal(t, first, mergeIntervalFeatures(nil, first))
+	assert.Equal(t, &IntervalFeatures{DisruptionSeconds: 11, PathologicalEventCount: 22, AlertFiringMinutes: 33}, mergeIntervalFeatures(first, second))
+	assert.Equal(t, 1.0, first.DisruptionSeconds, "merging must not modify the inputs")
+}
+
+func TestWriteIntervalFeatures(t *testing.T) {
+	tmp := t.TempDir()
+	opt := &Options{JUnitDir: tmp}
+
+	opt.writeIntervalFeatures(nil)
+	_, err := os.Stat(filepath.Join(tmp, raFeaturesFile))
+	assert.True(t, os.IsNotExist(err), "no features should not write the artifact")
+
+	opt.writeIntervalFeatures(&IntervalFeatures{DisruptionSeconds: 12.5, PathologicalEventCount: 1})
+	content, err := os.ReadFile(filepath.Join(tmp, raFeaturesFile))
+	require.NoError(t, err)
+	features := &IntervalFeatures{}
+	require.NoError(t, json.Unmarshal(content, features))
+	assert.Equal(t, &IntervalFeatures{DisruptionSeconds: 12.5, PathologicalEventCount: 1}, features)
+}
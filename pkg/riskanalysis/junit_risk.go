@@ -0,0 +1,144 @@
+package riskanalysis
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+)
+
+const (
+	raTestScoresFile = "risk-analysis-test-scores.json"
+
+	riskLevelProperty     = "RiskLevel"
+	riskLevelNameProperty = "RiskLevelName"
+)
+
+// TestRiskScore is the risk that the failure of a test is a real regression rather than a known flake.
+type TestRiskScore struct {
+	Name          string
+	RiskLevel     int
+	RiskLevelName string
+}
+
+func testRiskScores(analysis riskAnalysisResult) []TestRiskScore {
+	scores := []TestRiskScore{}
+	for _, test := range analysis.Tests {
+		scores = append(scores, TestRiskScore{
+			Name:          test.Name,
+			RiskLevel:     test.Risk.Level.Level,
+			RiskLevelName: test.Risk.Level.Name,
+		})
+	}
+	// the most likely regressions first.
+	sort.SliceStable(scores, func(i, j int) bool {
+		if scores[i].RiskLevel != scores[j].RiskLevel {
+			return scores[i].RiskLevel > scores[j].RiskLevel
+		}
+		return scores[i].Name < scores[j].Name
+	})
+	return scores
+}
+
+// writeTestRiskScores writes the risk of each failed test to a standalone json file and into the junit xml files of
+// the run, so failures can be sorted by risk without fetching the risk analysis.  Errors are logged and skipped.
+func (opt *Options) writeTestRiskScores(analysisBytes []byte) {
+	var analysis riskAnalysisResult
+	if err := json.Unmarshal(analysisBytes, &analysis); err != nil {
+		logrus.WithError(err).Error("Error unmarshalling risk analysis json")
+		return
+	}
+	scores := testRiskScores(analysis)
+
+	jsonContent, err := json.MarshalIndent(scores, "", "    ")
+	if err != nil {
+		logrus.WithError(err).Error("Error marshalling test risk scores")
+		return
+	}
+	outputFile := filepath.Join(opt.JUnitDir, raTestScoresFile)
+	if err := os.WriteFile(outputFile, jsonContent, 0644); err != nil {
+		logrus.WithError(err).Error("Error writing test risk scores artifact")
+	} else {
+		logrus.Infof("Successfully wrote: %s", outputFile)
+	}
+
+	junitFiles, err := filepath.Glob(filepath.Join(opt.JUnitDir, "junit*.xml"))
+	if err != nil {
+		logrus.WithError(err).Error("Error scanning for junit files")
+		return
+	}
+	scoresByName := map[string]TestRiskScore{}
+	for _, score := range scores {
+		scoresByName[score.Name] = score
+	}
+	for _, junitFile := range junitFiles {
+		if err := addRiskPropertiesToJUnit(junitFile, scoresByName); err != nil {
+			logrus.WithError(err).Warnf("Unable to add risk scores to %s", junitFile)
+		}
+	}
+}
+
+// addRiskPropertiesToJUnit only rewrites junit files containing a single suite, which is how openshift-tests writes them.
+func addRiskPropertiesToJUnit(junitFile string, scoresByName map[string]TestRiskScore) error {
+	content, err := os.ReadFile(junitFile)
+	if err != nil {
+		return err
+	}
+	suite := &junitapi.JUnitTestSuite{}
+	if err := xml.Unmarshal(content, suite); err != nil {
+		return err
+	}
+	// suite properties are written as direct children of the suite, which the suite type does not read back.
+	suiteProperties := &struct {
+		Properties []*junitapi.TestSuiteProperty `xml:"property"`
+	}{}
+	if err := xml.Unmarshal(content, suiteProperties); err != nil {
+		return err
+	}
+	suite.Properties = suiteProperties.Properties
+	if !addRiskProperties(suite, scoresByName) {
+		return nil
+	}
+
+	out, err := xml.MarshalIndent(suite, "", "    ")
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(junitFile)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(junitFile, out, info.Mode().Perm())
+}
+
+// addRiskProperties sets the risk properties of the failed test cases, replacing any from an earlier analysis.
+func addRiskProperties(suite *junitapi.JUnitTestSuite, scoresByName map[string]TestRiskScore) bool {
+	changed := false
+	for _, testCase := range suite.TestCases {
+		score, ok := scoresByName[testCase.Name]
+		if !ok || testCase.FailureOutput == nil {
+			continue
+		}
+		properties := []*junitapi.TestSuiteProperty{}
+		for _, property := range testCase.Properties {
+			if property.Name != riskLevelProperty && property.Name != riskLevelNameProperty {
+				properties = append(properties, property)
+			}
+		}
+		testCase.Properties = append(properties,
+			&junitapi.TestSuiteProperty{Name: riskLevelProperty, Value: strconv.Itoa(score.RiskLevel)},
+			&junitapi.TestSuiteProperty{Name: riskLevelNameProperty, Value: score.RiskLevelName},
+		)
+		changed = true
+	}
+	for _, child := range suite.Children {
+		changed = addRiskProperties(child, scoresByName) || changed
+	}
+	return changed
+}
@@ -0,0 +1,81 @@
+package riskanalysis
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+)
+
+const testRiskAnalysis = `{
+  "Tests": [
+    {"Name": "test-flaky", "Risk": {"Level": {"Name": "Low", "Level": 1}}},
+    {"Name": "test-regression", "Risk": {"Level": {"Name": "High", "Level": 10}}}
+  ]
+}`
+
+func TestWriteTestRiskScores(t *testing.T) {
+	tmp := t.TempDir()
+	suite := &junitapi.JUnitTestSuite{
+		Name:       "openshift-tests",
+		Properties: []*junitapi.TestSuiteProperty{{Name: "TestVersion", Value: "v4.16.0"}},
+		TestCases: []*junitapi.JUnitTestCase{
+			{Name: "test-regression", FailureOutput: &junitapi.FailureOutput{Output: "failed"}},
+			// a flake passed as well, only the failure gets the risk.
+			{Name: "test-flaky", FailureOutput: &junitapi.FailureOutput{Output: "failed"}},
+			{Name: "test-flaky"},
+			{Name: "test-passed"},
+		},
+	}
+	out, err := xml.MarshalIndent(suite, "", "    ")
+	require.NoError(t, err)
+	junitFile := filepath.Join(tmp, "junit_e2e__20240301-100000.xml")
+	require.NoError(t, os.WriteFile(junitFile, out, 0640))
+	// files written by other tools are left alone.
+	otherFile := filepath.Join(tmp, "junit_other.xml")
+	require.NoError(t, os.WriteFile(otherFile, []byte(`<testsuites><testsuite name="other"></testsuite></testsuites>`), 0640))
+
+	opt := &Options{JUnitDir: tmp}
+	// running the analysis twice must not duplicate the properties.
+	opt.writeTestRiskScores([]byte(testRiskAnalysis))
+	opt.writeTestRiskScores([]byte(testRiskAnalysis))
+
+	content, err := os.ReadFile(filepath.Join(tmp, raTestScoresFile))
+	require.NoError(t, err)
+	scores := []TestRiskScore{}
+	require.NoError(t, json.Unmarshal(content, &scores))
+	assert.Equal(t, []TestRiskScore{
+		{Name: "test-regression", RiskLevel: 10, RiskLevelName: "High"},
+		{Name: "test-flaky", RiskLevel: 1, RiskLevelName: "Low"},
+	}, scores)
+
+	content, err = os.ReadFile(junitFile)
+	require.NoError(t, err)
+	annotated := &junitapi.JUnitTestSuite{}
+	require.NoError(t, xml.Unmarshal(content, annotated))
+	properties := map[string][]string{}
+	for _, testCase := range annotated.TestCases {
+		for _, property := range testCase.Properties {
+			properties[testCase.Name] = append(properties[testCase.Name], property.Name+"="+property.Value)
+		}
+	}
+	assert.Equal(t, map[string][]string{
+		"test-regression": {"RiskLevel=10", "RiskLevelName=High"},
+		"test-flaky":      {"RiskLevel=1", "RiskLevelName=Low"},
+	}, properties)
+	require.Len(t, annotated.TestCases, 4)
+	assert.Contains(t, string(content), `<property name="TestVersion" value="v4.16.0"></property>`)
+	info, err := os.Stat(junitFile)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0640), info.Mode().Perm())
+
+	content, err = os.ReadFile(otherFile)
+	require.NoError(t, err)
+	assert.Equal(t, `<testsuites><testsuite name="other"></testsuite></testsuites>`, string(content))
+}
@@ -0,0 +1,137 @@
+package riskanalysis
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/origin/pkg/test/testrenames"
+)
+
+const (
+	// minHistoricalRuns is the number of runs below which a pass rate says little about a test.
+	minHistoricalRuns = 7
+	// maxAnalyzedFailures mirrors sippy, a job run with more failures than this is high risk regardless of the tests.
+	maxAnalyzedFailures = 20
+)
+
+var (
+	riskLevelNone   = riskLevel{Name: "None", Level: 0}
+	riskLevelLow    = riskLevel{Name: "Low", Level: 1}
+	riskLevelMedium = riskLevel{Name: "Medium", Level: 5}
+	riskLevelHigh   = riskLevel{Name: "High", Level: 10}
+)
+
+// HistoricalPassRate is how often a test passed in comparable job runs, the local replacement for the sippy data.
+type HistoricalPassRate struct {
+	Name   string
+	Runs   int
+	Passes int
+}
+
+// loadHistoricalPassRates keys the pass rates by the current name of each test, the pass rates recorded under the
+// previous names of a renamed test are added to those of its current name.
+func loadHistoricalPassRates(filename string, renames *testrenames.Renames) (map[string]HistoricalPassRate, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	passRates := []HistoricalPassRate{}
+	if err := json.Unmarshal(content, &passRates); err != nil {
+		return nil, fmt.Errorf("unable to parse historical pass rates from %s: %w", filename, err)
+	}
+	ret := map[string]HistoricalPassRate{}
+	for _, passRate := range passRates {
+		if passRate.Passes > passRate.Runs {
+			return nil, fmt.Errorf("%s: test %q passed %d times in %d runs", filename, passRate.Name, passRate.Passes, passRate.Runs)
+		}
+		name := renames.CurrentName(passRate.Name)
+		merged := ret[name]
+		merged.Name = name
+		merged.Runs += passRate.Runs
+		merged.Passes += passRate.Passes
+		ret[name] = merged
+	}
+	return ret, nil
+}
+
+// localRiskAnalysis produces the same response as sippy from a local dataset, for disconnected environments.  A test
+// that usually passes is a likely regression, one that often fails is likely a known problem.
+func localRiskAnalysis(jobRun *ProwJobRun, passRates map[string]HistoricalPassRate, renames *testrenames.Renames) riskAnalysisResult {
+	result := riskAnalysisResult{
+		ProwJobName:  jobRun.ProwJob.Name,
+		ProwJobRunID: jobRun.ID,
+		Tests:        []testRiskResult{},
+		OverallRisk: jobRunRisk{
+			Level:              riskLevelNone,
+			Reasons:            []string{},
+			JobRunTestCount:    jobRun.TestCount,
+			JobRunTestFailures: len(jobRun.Tests),
+		},
+		OpenBugs: []json.RawMessage{},
+	}
+
+	if len(jobRun.Tests) > maxAnalyzedFailures {
+		result.OverallRisk.Level = riskLevelHigh
+		result.OverallRisk.Reasons = append(result.OverallRisk.Reasons,
+			fmt.Sprintf("%d tests failed in this job run, which is more than the %d analyzed individually.", len(jobRun.Tests), maxAnalyzedFailures))
+		return result
+	}
+
+	for _, test := range jobRun.Tests {
+		testResult := testRiskResult{
+			Name:     test.Test.Name,
+			Risk:     testRisk{Level: riskLevelMedium, Reasons: []string{}},
+			OpenBugs: []json.RawMessage{},
+		}
+		// the failure may come from a binary that still uses a previous name.
+		passRate, ok := passRates[renames.CurrentName(test.Test.Name)]
+		switch {
+		case !ok || passRate.Runs < minHistoricalRuns:
+			testResult.Risk.Reasons = append(testResult.Risk.Reasons, "There is not enough historical data for this test.")
+		default:
+			percentage := 100 * float64(passRate.Passes) / float64(passRate.Runs)
+			testResult.Risk.CurrentRuns = passRate.Runs
+			testResult.Risk.CurrentPasses = passRate.Passes
+			testResult.Risk.CurrentPassPercentage = percentage
+			switch {
+			case percentage >= 98:
+				testResult.Risk.Level = riskLevelHigh
+			case percentage >= 80:
+				testResult.Risk.Level = riskLevelMedium
+			default:
+				testResult.Risk.Level = riskLevelLow
+			}
+			testResult.Risk.Reasons = append(testResult.Risk.Reasons,
+				fmt.Sprintf("This test has passed %.2f%% of %d runs in the historical data.", percentage, passRate.Runs))
+			result.OverallRisk.HistoricalRunTestCount++
+		}
+
+		if testResult.Risk.Level.Level > result.OverallRisk.Level.Level {
+			result.OverallRisk.Level = testResult.Risk.Level
+			result.OverallRisk.Reasons = []string{fmt.Sprintf("Maximum failed test risk: %s", testResult.Risk.Level.Name)}
+		}
+		result.Tests = append(result.Tests, testResult)
+	}
+	return result
+}
+
+func (opt *Options) localRiskAnalysis(jobRun *ProwJobRun) ([]byte, error) {
+	var renames *testrenames.Renames
+	if len(opt.TestRenamesFile) > 0 {
+		var err error
+		renames, err = testrenames.LoadRenames(opt.TestRenamesFile)
+		if err != nil {
+			logrus.WithError(err).Error("Error loading test renames for local risk analysis")
+			return nil, err
+		}
+	}
+	passRates, err := loadHistoricalPassRates(opt.HistoricalPassRatesFile, renames)
+	if err != nil {
+		logrus.WithError(err).Error("Error loading historical pass rates for local risk analysis")
+		return nil, err
+	}
+	return json.Marshal(localRiskAnalysis(jobRun, passRates, renames))
+}
@@ -0,0 +1,134 @@
+package riskanalysis
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalRiskAnalysis(t *testing.T) {
+	passRates := map[string]HistoricalPassRate{
+		"test-stable":   {Name: "test-stable", Runs: 100, Passes: 99},
+		"test-flaky":    {Name: "test-flaky", Runs: 100, Passes: 85},
+		"test-broken":   {Name: "test-broken", Runs: 100, Passes: 10},
+		"test-new":      {Name: "test-new", Runs: 3, Passes: 3},
+		"test-unfailed": {Name: "test-unfailed", Runs: 100, Passes: 100},
+	}
+	failed := func(names ...string) *ProwJobRun {
+		jobRun := &ProwJobRun{ID: 1234, ProwJob: ProwJob{Name: "periodic-ci-e2e"}, TestCount: 500}
+		for _, name := range names {
+			jobRun.Tests = append(jobRun.Tests, ProwJobRunTest{Test: Test{Name: name}, Status: 12})
+		}
+		return jobRun
+	}
+
+	t.Run("risk per test", func(t *testing.T) {
+		result := localRiskAnalysis(failed("test-broken", "test-flaky", "test-stable", "test-new", "test-unknown"), passRates, nil)
+
+		levels := map[string]string{}
+		for _, test := range result.Tests {
+			levels[test.Name] = test.Risk.Level.Name
+			assert.NotEmpty(t, test.Risk.Reasons, test.Name)
+		}
+		assert.Equal(t, map[string]string{
+			"test-broken":  "Low",
+			"test-flaky":   "Medium",
+			"test-stable":  "High",
+			"test-new":     "Medium",
+			"test-unknown": "Medium",
+		}, levels)
+		assert.InDelta(t, 99, result.Tests[2].Risk.CurrentPassPercentage, 0.001)
+
+		assert.Equal(t, riskLevelHigh, result.OverallRisk.Level)
+		assert.Equal(t, 500, result.OverallRisk.JobRunTestCount)
+		assert.Equal(t, 5, result.OverallRisk.JobRunTestFailures)
+		assert.Equal(t, 3, result.OverallRisk.HistoricalRunTestCount)
+		assert.Equal(t, "periodic-ci-e2e", result.ProwJobName)
+	})
+
+	t.Run("no failures", func(t *testing.T) {
+		result := localRiskAnalysis(failed(), passRates, nil)
+		assert.Equal(t, riskLevelNone, result.OverallRisk.Level)
+
+		// the html expects lists rather than nulls.
+		content, err := json.Marshal(result)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), `"Tests":[]`)
+		assert.Contains(t, string(content), `"OpenBugs":[]`)
+		assert.Contains(t, string(content), `"Reasons":[]`)
+	})
+
+	t.Run("too many failures", func(t *testing.T) {
+		names := []string{}
+		for i := 0; i <= maxAnalyzedFailures; i++ {
+			names = append(names, "test-broken")
+		}
+		result := localRiskAnalysis(failed(names...), passRates, nil)
+		assert.Equal(t, riskLevelHigh, result.OverallRisk.Level)
+		assert.Empty(t, result.Tests)
+	})
+}
+
+func TestLocalRiskAnalysisArtifacts(t *testing.T) {
+	tmp := t.TempDir()
+	passRatesFile := filepath.Join(tmp, "pass-rates.json")
+	require.NoError(t, os.WriteFile(passRatesFile, []byte(`[{"Name": "test-stable", "Runs": 100, "Passes": 100}]`), 0644))
+	opt := &Options{JUnitDir: tmp, HistoricalPassRatesFile: passRatesFile}
+
+	jobRun := &ProwJobRun{TestCount: 10, Tests: []ProwJobRunTest{{Test: Test{Name: "test-stable"}, Status: 12}}}
+	_, err := opt.readWriteRiskAnalysis(jobRun, nil)
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(tmp, raDataFile))
+	require.NoError(t, err)
+	result := riskAnalysisResult{}
+	require.NoError(t, json.Unmarshal(content, &result))
+	require.Len(t, result.Tests, 1)
+	assert.Equal(t, riskLevelHigh, result.Tests[0].Risk.Level)
+
+	content, err = os.ReadFile(filepath.Join(tmp, raTestScoresFile))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `"RiskLevelName": "High"`)
+}
+
+func TestLocalRiskAnalysisRenamedTests(t *testing.T) {
+	tmp := t.TempDir()
+	passRatesFile := filepath.Join(tmp, "pass-rates.json")
+	require.NoError(t, os.WriteFile(passRatesFile, []byte(`[
+		{"Name": "test-old", "Runs": 95, "Passes": 95},
+		{"Name": "test-new", "Runs": 5, "Passes": 4}
+	]`), 0644))
+	renamesFile := filepath.Join(tmp, "renames.yaml")
+	require.NoError(t, os.WriteFile(renamesFile, []byte("renames:\n- from: test-old\n  to: test-new\n"), 0644))
+	opt := &Options{JUnitDir: tmp, HistoricalPassRatesFile: passRatesFile, TestRenamesFile: renamesFile}
+
+	jobRun := &ProwJobRun{TestCount: 10, Tests: []ProwJobRunTest{
+		{Test: Test{Name: "test-new"}, Status: 12},
+		{Test: Test{Name: "test-old"}, Status: 12},
+	}}
+	content, err := opt.localRiskAnalysis(jobRun)
+	require.NoError(t, err)
+	result := riskAnalysisResult{}
+	require.NoError(t, json.Unmarshal(content, &result))
+	require.Len(t, result.Tests, 2)
+	for _, test := range result.Tests {
+		assert.Equal(t, 100, test.Risk.CurrentRuns, "%s uses the history of both names", test.Name)
+		assert.Equal(t, 99, test.Risk.CurrentPasses, test.Name)
+		assert.Equal(t, riskLevelHigh, test.Risk.Level, test.Name)
+	}
+}
+
+func TestLoadHistoricalPassRates(t *testing.T) {
+	tmp := t.TempDir()
+	invalid := filepath.Join(tmp, "invalid.json")
+	require.NoError(t, os.WriteFile(invalid, []byte(`[{"Name": "test", "Runs": 1, "Passes": 2}]`), 0644))
+	_, err := loadHistoricalPassRates(invalid, nil)
+	assert.ErrorContains(t, err, `test "test" passed 2 times in 1 runs`)
+
+	_, err = loadHistoricalPassRates(filepath.Join(tmp, "missing.json"), nil)
+	assert.Error(t, err)
+}
@@ -1,6 +1,8 @@
 package riskanalysis
 
 import (
+	"encoding/json"
+
 	"github.com/openshift/origin/pkg/monitortestlibrary/platformidentification"
 )
 
@@ -15,6 +17,8 @@ type ProwJobRun struct {
 	ClusterData platformidentification.ClusterData
 	Tests       []ProwJobRunTest
 	TestCount   int
+	// IntervalFeatures is only set by the invocations that monitored the cluster.
+	IntervalFeatures *IntervalFeatures `json:",omitempty"`
 }
 
 type ProwJob struct {
@@ -34,3 +38,43 @@ type ProwJobRunTest struct {
 	Suite  Suite
 	Status int // would like to use smallint here, but gorm auto-migrate breaks trying to change the type every start
 }
+
+// riskAnalysisResult is the subset of the sippy risk analysis response that is recorded in the artifacts and
+// rendered in the risk analysis html.
+type riskAnalysisResult struct {
+	ProwJobName    string `json:",omitempty"`
+	ProwJobRunID   int    `json:",omitempty"`
+	CompareRelease string `json:",omitempty"`
+	Tests          []testRiskResult
+	OverallRisk    jobRunRisk
+	OpenBugs       []json.RawMessage
+}
+
+type testRiskResult struct {
+	Name     string
+	TestId   int
+	Risk     testRisk
+	OpenBugs []json.RawMessage
+}
+
+type testRisk struct {
+	Level                 riskLevel
+	Reasons               []string
+	CurrentRuns           int
+	CurrentPasses         int
+	CurrentPassPercentage float64
+}
+
+type jobRunRisk struct {
+	Level                  riskLevel
+	Reasons                []string
+	JobRunTestCount        int
+	JobRunTestFailures     int
+	NeverStableJob         bool
+	HistoricalRunTestCount int
+}
+
+type riskLevel struct {
+	Name  string
+	Level int
+}
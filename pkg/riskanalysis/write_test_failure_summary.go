@@ -9,6 +9,7 @@ import (
 	"strconv"
 
 	"github.com/openshift/origin/pkg/clioptions/clusterinfo"
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
 
 	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
 )
@@ -17,7 +18,8 @@ import (
 // job run, and what tests flaked and failed. (successful tests are omitted)
 // This is intended to be later submitted to sippy for a risk analysis of how unusual the
 // test failures were, but that final step is handled elsewhere.
-func WriteJobRunTestFailureSummary(artifactDir, timeSuffix string, finalSuiteResults *junitapi.JUnitTestSuite, wasMasterNodeUpdated, outputFileSubStr string) error {
+// When intervals are provided, the features computed from them are included in the summary.
+func WriteJobRunTestFailureSummary(artifactDir, timeSuffix string, finalSuiteResults *junitapi.JUnitTestSuite, wasMasterNodeUpdated, outputFileSubStr string, intervals monitorapi.Intervals) error {
 
 	tests := map[string]*passFail{}
 
@@ -50,6 +52,9 @@ func WriteJobRunTestFailureSummary(artifactDir, timeSuffix string, finalSuiteRes
 		Tests:       []ProwJobRunTest{},
 		TestCount:   len(tests),
 	}
+	if intervals != nil {
+		jr.IntervalFeatures = computeIntervalFeatures(intervals)
+	}
 
 	for k, v := range tests {
 		if !v.Failed {
@@ -0,0 +1,70 @@
+package ginkgo
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/openshift/origin/pkg/monitortestlibrary/platformidentification"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	exutil "github.com/openshift/origin/test/extended/util"
+)
+
+// clusterMetadataProperties describes the cluster under test as junit suite properties so that results can be
+// sliced by aggregation tooling without parsing job names.  Values that cannot be determined are omitted.
+func clusterMetadataProperties(ctx context.Context, restConfig *rest.Config) []*junitapi.TestSuiteProperty {
+	clusterData, errs := platformidentification.BuildClusterData(ctx, restConfig)
+	if errs != nil {
+		for _, err := range *errs {
+			logrus.WithError(err).Warn("unable to determine all cluster metadata for junit properties")
+		}
+	}
+
+	version := ""
+	if len(clusterData.ClusterVersionHistory) > 0 {
+		version = clusterData.ClusterVersionHistory[0]
+	}
+
+	fips := ""
+	if kubeClient, err := kubernetes.NewForConfig(restConfig); err != nil {
+		logrus.WithError(err).Warn("unable to determine FIPS mode for junit properties")
+	} else if isFIPS, err := exutil.IsFIPS(kubeClient.CoreV1()); err != nil {
+		logrus.WithError(err).Warn("unable to determine FIPS mode for junit properties")
+	} else {
+		fips = strconv.FormatBool(isFIPS)
+	}
+
+	return clusterPropertiesFrom(clusterData, version, fips)
+}
+
+func clusterPropertiesFrom(clusterData platformidentification.ClusterData, version, fips string) []*junitapi.TestSuiteProperty {
+	candidates := []struct {
+		name  string
+		value string
+	}{
+		{name: "ClusterPlatform", value: clusterData.Platform},
+		{name: "ClusterTopology", value: clusterData.Topology},
+		{name: "ClusterNetworkType", value: clusterData.Network},
+		{name: "ClusterNetworkStack", value: clusterData.NetworkStack},
+		{name: "ClusterArchitecture", value: clusterData.Architecture},
+		{name: "ClusterRelease", value: clusterData.Release},
+		{name: "ClusterFromRelease", value: clusterData.FromRelease},
+		{name: "ClusterVersion", value: version},
+		{name: "ClusterFIPS", value: fips},
+	}
+
+	properties := []*junitapi.TestSuiteProperty{}
+	for _, candidate := range candidates {
+		if len(candidate.value) == 0 {
+			continue
+		}
+		properties = append(properties, &junitapi.TestSuiteProperty{
+			Name:  candidate.name,
+			Value: candidate.value,
+		})
+	}
+	return properties
+}
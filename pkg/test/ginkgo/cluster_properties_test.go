@@ -0,0 +1,72 @@
+package ginkgo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/origin/pkg/monitortestlibrary/platformidentification"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+)
+
+func Test_clusterPropertiesFrom(t *testing.T) {
+	tests := []struct {
+		name        string
+		clusterData platformidentification.ClusterData
+		version     string
+		fips        string
+		want        []*junitapi.TestSuiteProperty
+	}{
+		{
+			name: "nothing known",
+			want: []*junitapi.TestSuiteProperty{},
+		},
+		{
+			name: "every property",
+			clusterData: platformidentification.ClusterData{
+				JobType: platformidentification.JobType{
+					Release:      "4.16",
+					FromRelease:  "4.15",
+					Platform:     "aws",
+					Architecture: "amd64",
+					Network:      "ovn",
+					Topology:     "ha",
+				},
+				NetworkStack: "IPv4",
+			},
+			version: "4.16.0-0.nightly-2024-03-01-000000",
+			fips:    "false",
+			want: []*junitapi.TestSuiteProperty{
+				{Name: "ClusterPlatform", Value: "aws"},
+				{Name: "ClusterTopology", Value: "ha"},
+				{Name: "ClusterNetworkType", Value: "ovn"},
+				{Name: "ClusterNetworkStack", Value: "IPv4"},
+				{Name: "ClusterArchitecture", Value: "amd64"},
+				{Name: "ClusterRelease", Value: "4.16"},
+				{Name: "ClusterFromRelease", Value: "4.15"},
+				{Name: "ClusterVersion", Value: "4.16.0-0.nightly-2024-03-01-000000"},
+				{Name: "ClusterFIPS", Value: "false"},
+			},
+		},
+		{
+			name: "empty values are omitted",
+			clusterData: platformidentification.ClusterData{
+				JobType: platformidentification.JobType{
+					Platform: "metal",
+					Topology: "single",
+				},
+			},
+			fips: "true",
+			want: []*junitapi.TestSuiteProperty{
+				{Name: "ClusterPlatform", Value: "metal"},
+				{Name: "ClusterTopology", Value: "single"},
+				{Name: "ClusterFIPS", Value: "true"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, clusterPropertiesFrom(tt.clusterData, tt.version, tt.fips))
+		})
+	}
+}
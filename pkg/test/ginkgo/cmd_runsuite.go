@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"math/rand"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
 	"sync"
@@ -27,10 +29,15 @@ import (
 	"github.com/openshift/origin/pkg/clioptions/clusterinfo"
 	"github.com/openshift/origin/pkg/defaultmonitortests"
 	"github.com/openshift/origin/pkg/monitor"
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
 	monitorserialization "github.com/openshift/origin/pkg/monitor/serialization"
 	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/monitortestlibrary/allowedalerts"
+	"github.com/openshift/origin/pkg/monitortestlibrary/allowedbackenddisruption"
+	"github.com/openshift/origin/pkg/monitortests/testframework/watchevents"
 	"github.com/openshift/origin/pkg/riskanalysis"
 	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	"github.com/openshift/origin/pkg/test/testrenames"
 )
 
 const (
@@ -63,17 +70,80 @@ type GinkgoRunSuiteOptions struct {
 
 	DryRun        bool
 	PrintCommands bool
+	// DryRunMonitors prints the monitor tests that would run instead of running the suite.
+	DryRunMonitors bool
 	genericclioptions.IOStreams
 
 	StartTime time.Time
 
 	ExactMonitorTests   []string
 	DisableMonitorTests []string
+
+	// OutputFormat controls which result files are written to JUnitDir in addition to the junit xml.
+	OutputFormat string
+
+	// ShardIndex and ShardCount partition the selected tests across multiple invocations of the same suite.
+	ShardIndex int
+	ShardCount int
+
+	// Duration overrides how long a monitor-only suite observes the cluster.
+	Duration time.Duration
+
+	// QuarantineFile lists known-flaky tests whose failures are reported as flakes.
+	QuarantineFile string
+
+	// KnownFailuresCatalog is a file or URL listing known issues whose failures are linked to their tracking reference.
+	KnownFailuresCatalog string
+
+	// ExtensionManifestFile lists the extension binaries in the release payload whose tests are added to the suites.
+	ExtensionManifestFile string
+
+	// TestRenamesFile maps the previous names of renamed tests to their current names.
+	TestRenamesFile string
+
+	// HistoricalDataFile replaces the embedded historical disruption data used to compute disruption budgets.
+	HistoricalDataFile string
+
+	// HistoricalTestDurationsFile lists the historical runtimes that test runtimes are compared against.
+	HistoricalTestDurationsFile string
+
+	// AllowedAlertsFile lists alerts that are expected on the cluster under test and never fail the alert tests.
+	AllowedAlertsFile string
+
+	// SLOConfigFile lists service level objectives that are evaluated against the intervals of the run.
+	SLOConfigFile string
+
+	// ChaosConfigFile lists faults to inject into the cluster while the suite runs.
+	ChaosConfigFile string
+
+	// PromQLRulesFile lists PromQL range queries and thresholds that are checked over the run.
+	PromQLRulesFile string
+
+	// EventNamespaceInclude and EventNamespaceExclude limit the namespaces whose events are recorded.
+	EventNamespaceInclude []string
+	EventNamespaceExclude []string
+
+	// EventLogVerbosity controls logging of each event seen by the event watcher.
+	EventLogVerbosity string
+
+	// ProgressAddress, if set, is the address to serve the live progress of the suite on.
+	ProgressAddress string
+
+	// ProgressEventsFile, if set, is the file to write line-delimited json progress events to.
+	ProgressEventsFile string
+
+	// Preflight validates that the cluster is healthy enough to run the suite before any test starts.
+	Preflight bool
+
+	// PostUpgradeSuite, if set, is run against the cluster once the upgrade suite completes, within the same
+	// monitor session so the intervals and results of both phases are reported together.
+	PostUpgradeSuite *TestSuite
 }
 
 func NewGinkgoRunSuiteOptions(streams genericclioptions.IOStreams) *GinkgoRunSuiteOptions {
 	return &GinkgoRunSuiteOptions{
-		IOStreams: streams,
+		IOStreams:    streams,
+		OutputFormat: OutputFormatJUnit,
 	}
 }
 
@@ -82,6 +152,7 @@ func (o *GinkgoRunSuiteOptions) BindFlags(flags *pflag.FlagSet) {
 	monitorNames := defaultmonitortests.ListAllMonitorTests()
 
 	flags.BoolVar(&o.DryRun, "dry-run", o.DryRun, "Print the tests to run without executing them.")
+	flags.BoolVar(&o.DryRunMonitors, "dry-run-monitors", o.DryRunMonitors, "Print the monitor tests that would run against the cluster as json, with the resources they watch and the junits they can produce, without running the suite.")
 	flags.BoolVar(&o.PrintCommands, "print-commands", o.PrintCommands, "Print the sub-commands that would be executed instead.")
 	flags.StringVar(&o.ClusterStabilityDuringTest, "cluster-stability", o.ClusterStabilityDuringTest, "cluster stability during test, usually dependent on the job: Stable or Disruptive. Empty default will be treated as Stable.")
 	flags.StringVar(&o.JUnitDir, "junit-dir", o.JUnitDir, "The directory to write test reports to.")
@@ -93,6 +164,26 @@ func (o *GinkgoRunSuiteOptions) BindFlags(flags *pflag.FlagSet) {
 	flags.StringSliceVar(&o.ExactMonitorTests, "monitor", o.ExactMonitorTests,
 		fmt.Sprintf("list of exactly which monitors to enable. All others will be disabled.  Current monitors are: [%s]", strings.Join(monitorNames, ", ")))
 	flags.StringSliceVar(&o.DisableMonitorTests, "disable-monitor", o.DisableMonitorTests, "list of monitors to disable.  Defaults for others will be honored.")
+	flags.IntVar(&o.ShardIndex, "shard-index", o.ShardIndex, "The zero-based index of the shard of tests to run.  Requires --shard-count.")
+	flags.IntVar(&o.ShardCount, "shard-count", o.ShardCount, "Deterministically partition the selected tests into this many shards and only run the one selected by --shard-index.")
+	flags.DurationVar(&o.Duration, "duration", o.Duration, "How long a monitor-only suite, such as openshift/monitoring-only, observes the cluster before evaluating the invariants.  Defaults to the duration of the suite.")
+	flags.StringVar(&o.QuarantineFile, "quarantine-file", o.QuarantineFile, "A yaml file listing quarantined tests by name or nameRegex with a trackingReference.  Failures of quarantined tests are reported as flakes.")
+	flags.StringVar(&o.KnownFailuresCatalog, "known-failures", o.KnownFailuresCatalog, "A yaml file or http(s) URL of a catalog of known issues, each with a name, a trackingReference and matchers of the failure fingerprint, output or intervals.  Matching failures are linked to the issue in the junit and reported as flakes if the issue sets flake.")
+	flags.StringVar(&o.ExtensionManifestFile, "extension-manifest", o.ExtensionManifestFile, "A yaml file listing test extension binaries by name, the imageTag of the release payload image that contains them and their binaryPath.  Defaults to the k8s-tests binary of the hyperkube image.")
+	flags.StringVar(&o.TestRenamesFile, "test-renames-file", o.TestRenamesFile, "A yaml file listing renamed tests by from and to name.  Renamed tests are reported with a PreviousName junit property for each of their previous names so they keep their history.")
+	flags.StringVar(&o.HistoricalDataFile, "historical-data-file", o.HistoricalDataFile, "A json file of historical disruption percentiles to compute disruption budgets from instead of the data embedded in this binary.  Refresh it with 'openshift-tests disruption refresh-historical-data'.")
+	flags.StringVar(&o.HistoricalTestDurationsFile, "historical-test-durations-file", o.HistoricalTestDurationsFile, "A json file of the historical P95 runtime of tests, {\"tests\": {\"<name>\": {\"p95Seconds\": 42}}}.  Tests that ran much longer are reported in the test-timing artifact and a junit result.")
+	flags.StringVar(&o.AllowedAlertsFile, "allowed-alerts-file", o.AllowedAlertsFile, "A yaml file listing alerts by alertName, an optional namespace and a reason.  The listed alerts are expected on the cluster under test and never fail the alert tests.")
+	flags.StringVar(&o.SLOConfigFile, "slo-config", o.SLOConfigFile, "A yaml file of service level objectives to evaluate against the intervals of the run.  Each objective is reported as a junit result and in the slo-report artifact.")
+	flags.StringVar(&o.ChaosConfigFile, "chaos-config", o.ChaosConfigFile, "A yaml file of faults to inject into the cluster while the suite runs: killing control plane pods, restarting kubelets and delaying the kube-apiserver.  Each fault is recorded as an interval and reported as a junit result.")
+	flags.StringVar(&o.PromQLRulesFile, "promql-rules", o.PromQLRulesFile, "A yaml file of PromQL range queries and thresholds to check over the run.  Violations are charted as intervals and each rule is reported as a junit result.")
+	flags.StringSliceVar(&o.EventNamespaceInclude, "event-namespace-include", o.EventNamespaceInclude, "Regexes of the namespaces to record events for.  Defaults to every namespace.")
+	flags.StringSliceVar(&o.EventNamespaceExclude, "event-namespace-exclude", o.EventNamespaceExclude, "Regexes of the namespaces not to record events for, even if included.  Excluded events are counted in the excluded-events-summary artifact.")
+	flags.StringVar(&o.EventLogVerbosity, "event-log-verbosity", o.EventLogVerbosity, fmt.Sprintf("How many of the events seen by the event watcher to log: none, sampled or all.  Defaults to $%s, or none.", watchevents.EventLogVerbosityEnv))
+	flags.StringVar(&o.ProgressAddress, "progress-address", o.ProgressAddress, "An address, such as localhost:8080, to serve the live progress of the suite on while it runs: the running tests, the latest results, the warnings and errors recorded by each monitor and a timeline of the last half hour.  /status serves the same as json.")
+	flags.StringVar(&o.ProgressEventsFile, "progress-events", o.ProgressEventsFile, "A file to write line-delimited json progress events to for wrapping CI systems: the start and end of the suite, the start and result of each test and when the monitor starts and stops.  A path such as /dev/fd/3 hands the events to the wrapper on a descriptor of their own, the log stays on stdout and stderr.")
+	flags.BoolVar(&o.Preflight, "preflight", o.Preflight, "Before starting the suite, check that every clusteroperator is available, every node is Ready, no critical alert is firing and the schedulable nodes have room for the parallel tests.  If not, fail immediately with a junit result listing the problems.")
+	flags.StringVar(&o.OutputFormat, "output-format", o.OutputFormat, "The format of the results written to --junit-dir: junit or json.  json writes a structured results file alongside the junit xml.")
 }
 
 func (o *GinkgoRunSuiteOptions) Validate() error {
@@ -101,6 +192,22 @@ func (o *GinkgoRunSuiteOptions) Validate() error {
 	default:
 		return fmt.Errorf("unknown --cluster-stability, %q, expected Stable or Disruptive", o.ClusterStabilityDuringTest)
 	}
+	switch o.OutputFormat {
+	case "", OutputFormatJUnit, OutputFormatJSON:
+	default:
+		return fmt.Errorf("unknown --output-format, %q, expected %s or %s", o.OutputFormat, OutputFormatJUnit, OutputFormatJSON)
+	}
+	if err := validateShard(o.ShardIndex, o.ShardCount); err != nil {
+		return err
+	}
+	if err := watchevents.ValidateEventLogVerbosity(o.EventLogVerbosity); err != nil {
+		return err
+	}
+	for _, expr := range append(append([]string{}, o.EventNamespaceInclude...), o.EventNamespaceExclude...) {
+		if _, err := regexp.Compile(expr); err != nil {
+			return fmt.Errorf("invalid event namespace regex %q: %w", expr, err)
+		}
+	}
 	return nil
 }
 
@@ -125,6 +232,33 @@ func max(a, b int) int {
 func (o *GinkgoRunSuiteOptions) Run(suite *TestSuite, junitSuiteName string, monitorTestInfo monitortestframework.MonitorTestInitializationInfo, upgrade bool) error {
 	ctx := context.Background()
 
+	if o.DryRunMonitors {
+		return o.dryRunMonitors(ctx, monitorTestInfo)
+	}
+
+	var progressEvents *progressEventWriter
+	if len(o.ProgressEventsFile) > 0 {
+		var eventsFile io.Closer
+		var err error
+		progressEvents, eventsFile, err = createProgressEventWriter(o.ProgressEventsFile)
+		if err != nil {
+			return err
+		}
+		defer eventsFile.Close()
+	}
+
+	monitorOnlyDuration := suite.MonitorOnlyDuration
+	switch {
+	case o.Duration > 0 && monitorOnlyDuration == 0:
+		return fmt.Errorf("--duration is only supported by monitor-only suites, %q runs tests", suite.Name)
+	case o.Duration > 0:
+		monitorOnlyDuration = o.Duration
+	}
+	monitorOnly := monitorOnlyDuration > 0
+	if monitorOnly && o.ShardCount > 1 {
+		return fmt.Errorf("suite %q runs no tests and cannot be sharded", suite.Name)
+	}
+
 	tests, err := testsForSuite()
 	if err != nil {
 		return fmt.Errorf("failed reading origin test suites: %w", err)
@@ -133,26 +267,29 @@ func (o *GinkgoRunSuiteOptions) Run(suite *TestSuite, junitSuiteName string, mon
 	fmt.Fprintf(o.Out, "found %d tests for suite\n", len(tests))
 
 	var fallbackSyntheticTestResult []*junitapi.JUnitTestCase
-	if len(os.Getenv("OPENSHIFT_SKIP_EXTERNAL_TESTS")) == 0 {
+	switch {
+	case monitorOnly:
+		fmt.Fprintf(o.Out, "Not pulling tests from external binaries, suite %q runs no tests\n", suite.Name)
+	case len(os.Getenv("OPENSHIFT_SKIP_EXTERNAL_TESTS")) == 0:
+		extensionManifest := defaultTestExtensionManifest
+		if len(o.ExtensionManifestFile) > 0 {
+			extensionManifest, err = LoadTestExtensionManifest(o.ExtensionManifestFile)
+			if err != nil {
+				return err
+			}
+		}
 		buf := &bytes.Buffer{}
 		fmt.Fprintf(buf, "Attempting to pull tests from external binary...\n")
-		externalTests, err := externalTestsForSuite(ctx)
-		if err == nil {
-			filteredTests := []*testCase{}
-			for _, test := range tests {
-				// tests contains all the tests "registered" in openshif-tests binary,
-				// this also includes vendored k8s tests, since this path assumes we're
-				// using external binary to run these tests we need to remove them
-				// from the final lists, which contains:
-				// 1. origin tests, only
-				// 2. k8s tests, coming from external binary
-				if !strings.Contains(test.name, "[Suite:k8s]") {
-					filteredTests = append(filteredTests, test)
-				}
-			}
-			tests = append(filteredTests, externalTests...)
-			fmt.Fprintf(buf, "Got %d tests from external binary\n", len(externalTests))
-		} else {
+		externalTests, replacedLabels, err := externalTestsForSuite(ctx, extensionManifest)
+		// tests contains all the tests "registered" in openshif-tests binary,
+		// this also includes vendored k8s tests, since this path assumes we're
+		// using external binaries to run these tests we need to remove them
+		// from the final lists, which contains:
+		// 1. origin tests, and built-in tests no extension replaces
+		// 2. tests coming from the extension binaries that could be listed
+		tests = append(withoutReplacedTests(tests, externalTests, replacedLabels), externalTests...)
+		fmt.Fprintf(buf, "Got %d tests from external binaries\n", len(externalTests))
+		if err != nil {
 			fmt.Fprintf(buf, "Falling back to built-in suite, failed reading external test suites: %v\n", err)
 			// adding this test twice (one failure here, and success below) will
 			// ensure it gets picked as flake further down in synthetic tests processing
@@ -169,7 +306,7 @@ func (o *GinkgoRunSuiteOptions) Run(suite *TestSuite, junitSuiteName string, mon
 			Name:      "[sig-arch] External binary usage",
 			SystemOut: buf.String(),
 		})
-	} else {
+	default:
 		fmt.Fprintf(o.Out, "Using built-in tests only due to OPENSHIFT_SKIP_EXTERNAL_TESTS being set\n")
 	}
 
@@ -181,13 +318,82 @@ func (o *GinkgoRunSuiteOptions) Run(suite *TestSuite, junitSuiteName string, mon
 	r := rand.New(rand.NewSource(suiteConfig.RandomSeed))
 	r.Shuffle(len(tests), func(i, j int) { tests[i], tests[j] = tests[j], tests[i] })
 
+	allTests := tests
 	tests = suite.Filter(tests)
-	if len(tests) == 0 {
+	if len(tests) == 0 && !monitorOnly {
 		return fmt.Errorf("suite %q does not contain any tests", suite.Name)
 	}
 
 	fmt.Fprintf(o.Out, "found %d filtered tests\n", len(tests))
 
+	if o.ShardCount > 1 {
+		tests = shardTests(tests, o.ShardIndex, o.ShardCount)
+		fmt.Fprintf(o.Out, "running %d tests in shard %d of %d\n", len(tests), o.ShardIndex, o.ShardCount)
+		if len(tests) == 0 {
+			return fmt.Errorf("shard %d of %d of suite %q does not contain any tests", o.ShardIndex, o.ShardCount, suite.Name)
+		}
+	}
+
+	environmentSkipped, tests := skipTestsForEnvironment(suite, tests)
+	if len(environmentSkipped) > 0 {
+		fmt.Fprintf(o.Out, "skipping %d tests the cluster does not support\n", len(environmentSkipped))
+	}
+
+	var postUpgradeSkipped, postUpgradeTests []*testCase
+	if o.PostUpgradeSuite != nil {
+		postUpgradeSkipped, postUpgradeTests = postUpgradeTestsFor(o.PostUpgradeSuite, allTests)
+		if o.ShardCount > 1 {
+			postUpgradeTests = shardTests(postUpgradeTests, o.ShardIndex, o.ShardCount)
+		}
+		fmt.Fprintf(o.Out, "found %d tests to run from suite %q after the upgrade\n", len(postUpgradeTests), o.PostUpgradeSuite.Name)
+	}
+
+	var quarantinePolicy *QuarantinePolicy
+	if len(o.QuarantineFile) > 0 {
+		quarantinePolicy, err = LoadQuarantinePolicy(o.QuarantineFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	var knownFailures *KnownFailuresCatalog
+	if len(o.KnownFailuresCatalog) > 0 {
+		knownFailures, err = LoadKnownFailuresCatalog(o.KnownFailuresCatalog)
+		if err != nil {
+			return err
+		}
+	}
+
+	var renames *testrenames.Renames
+	if len(o.TestRenamesFile) > 0 {
+		renames, err = testrenames.LoadRenames(o.TestRenamesFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	var historicalTestDurations *HistoricalTestDurations
+	if len(o.HistoricalTestDurationsFile) > 0 {
+		historicalTestDurations, err = LoadHistoricalTestDurations(o.HistoricalTestDurationsFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(o.HistoricalDataFile) > 0 {
+		if err := allowedbackenddisruption.UseHistoricalDataFile(o.HistoricalDataFile); err != nil {
+			return err
+		}
+		fmt.Fprintf(o.Out, "using historical disruption data from %s\n", o.HistoricalDataFile)
+	}
+
+	if len(o.AllowedAlertsFile) > 0 {
+		if err := allowedalerts.LoadUserAllowedAlerts(o.AllowedAlertsFile); err != nil {
+			return err
+		}
+		fmt.Fprintf(o.Out, "allowing the alerts listed in %s\n", o.AllowedAlertsFile)
+	}
+
 	count := o.Count
 	if count == 0 {
 		count = suite.Count
@@ -207,13 +413,16 @@ func (o *GinkgoRunSuiteOptions) Run(suite *TestSuite, junitSuiteName string, mon
 	}
 
 	testRunnerContext := newCommandContext(o.AsEnv(), timeout)
+	if len(o.JUnitDir) > 0 {
+		testRunnerContext.extensionArtifactDir = filepath.Join(o.JUnitDir, "extensions")
+	}
 
 	if o.PrintCommands {
-		newParallelTestQueue(testRunnerContext).OutputCommands(ctx, tests, o.Out)
+		newParallelTestQueue(testRunnerContext).OutputCommands(ctx, append(tests, postUpgradeTests...), o.Out)
 		return nil
 	}
 	if o.DryRun {
-		for _, test := range sortedTests(tests) {
+		for _, test := range sortedTests(append(tests, postUpgradeTests...)) {
 			fmt.Fprintf(o.Out, "%q\n", test.name)
 		}
 		return nil
@@ -224,11 +433,21 @@ func (o *GinkgoRunSuiteOptions) Run(suite *TestSuite, junitSuiteName string, mon
 		return err
 	}
 
+	// capture the cluster metadata before the run, upgrades change it.  It is only reported in the junit.
+	var clusterProperties []*junitapi.TestSuiteProperty
+	if len(o.JUnitDir) > 0 {
+		clusterProperties = clusterMetadataProperties(ctx, restConfig)
+	}
+
 	// skip tests due to newer k8s
 	tests, err = o.filterOutRebaseTests(restConfig, tests)
 	if err != nil {
 		return err
 	}
+	postUpgradeTests, err = o.filterOutRebaseTests(restConfig, postUpgradeTests)
+	if err != nil {
+		return err
+	}
 
 	if len(o.JUnitDir) > 0 {
 		if _, err := os.Stat(o.JUnitDir); err != nil {
@@ -249,6 +468,12 @@ func (o *GinkgoRunSuiteOptions) Run(suite *TestSuite, junitSuiteName string, mon
 		parallelism = 10
 	}
 
+	if o.Preflight {
+		if err := o.runPreflight(ctx, restConfig, parallelism); err != nil {
+			return err
+		}
+	}
+
 	ctx, cancelFn := context.WithCancel(context.Background())
 	defer cancelFn()
 	abortCh := make(chan os.Signal, 2)
@@ -273,6 +498,12 @@ func (o *GinkgoRunSuiteOptions) Run(suite *TestSuite, junitSuiteName string, mon
 	}
 
 	monitorEventRecorder := monitor.NewRecorder()
+	var liveProgress *liveProgress
+	if len(o.ProgressAddress) > 0 {
+		// created before the monitor starts, so that it hears about every interval.
+		notifier, _ := monitorEventRecorder.(monitorapi.RecorderNotifier)
+		liveProgress = newLiveProgress(notifier, start)
+	}
 	m := monitor.NewMonitor(
 		monitorEventRecorder,
 		restConfig,
@@ -282,6 +513,7 @@ func (o *GinkgoRunSuiteOptions) Run(suite *TestSuite, junitSuiteName string, mon
 	if err := m.Start(ctx); err != nil {
 		return err
 	}
+	progressEvents.Write(ProgressEvent{Type: MonitorStartedEvent})
 
 	pc, err := SetupNewPodCollector(ctx)
 	if err != nil {
@@ -296,9 +528,6 @@ func (o *GinkgoRunSuiteOptions) Run(suite *TestSuite, junitSuiteName string, mon
 	if len(tests) == 1 && count == 1 {
 		includeSuccess = true
 	}
-	testOutputLock := &sync.Mutex{}
-	testOutputConfig := newTestOutputConfig(testOutputLock, o.Out, monitorEventRecorder, includeSuccess)
-
 	early, notEarly := splitTests(tests, func(t *testCase) bool {
 		return strings.Contains(t.name, "[Early]")
 	})
@@ -336,6 +565,21 @@ func (o *GinkgoRunSuiteOptions) Run(suite *TestSuite, junitSuiteName string, mon
 	}
 	expectedTestCount += len(openshiftTests) + len(kubeTests) + len(storageTests) + len(mustGatherTests)
 
+	var progress progressObservers
+	if liveProgress != nil {
+		liveProgress.setExpected(expectedTestCount)
+		if err := liveProgress.serve(ctx, o.ProgressAddress, o.ErrOut); err != nil {
+			return err
+		}
+		progress = append(progress, liveProgress)
+	}
+	if progressEvents != nil {
+		progress = append(progress, progressEvents)
+	}
+	progressEvents.Write(ProgressEvent{Type: SuiteStartedEvent, Suite: suite.Name, ExpectedTests: expectedTestCount})
+	testOutputLock := &sync.Mutex{}
+	testOutputConfig := newTestOutputConfig(testOutputLock, o.Out, monitorEventRecorder, progress, includeSuccess)
+
 	abortFn := neverAbort
 	testCtx := ctx
 	if o.FailFast {
@@ -344,6 +588,15 @@ func (o *GinkgoRunSuiteOptions) Run(suite *TestSuite, junitSuiteName string, mon
 
 	tests = nil
 
+	if monitorOnly {
+		fmt.Fprintf(o.Out, "monitoring the cluster for %s without running tests\n", monitorOnlyDuration)
+		select {
+		case <-testCtx.Done():
+			fmt.Fprintf(o.Out, "monitoring interrupted after %s\n", time.Since(start).Round(time.Second))
+		case <-time.After(monitorOnlyDuration):
+		}
+	}
+
 	// run our Early tests
 	q := newParallelTestQueue(testRunnerContext)
 	q.Execute(testCtx, early, parallelism, testOutputConfig, abortFn)
@@ -381,6 +634,25 @@ func (o *GinkgoRunSuiteOptions) Run(suite *TestSuite, junitSuiteName string, mon
 	q.Execute(testCtx, late, parallelism, testOutputConfig, abortFn)
 	tests = append(tests, late...)
 
+	// run the post-upgrade suite against the upgraded cluster, unless the upgrade itself failed or never finished
+	if len(postUpgradeTests) > 0 {
+		failed, _ := splitTests(tests, func(t *testCase) bool { return t.failed })
+		switch {
+		case testCtx.Err() != nil:
+			skipPostUpgradeTests(postUpgradeTests, fmt.Sprintf("the upgrade did not complete: %v", testCtx.Err()))
+			fmt.Fprintf(o.Out, "skipping %d tests from suite %q because the upgrade did not complete\n", len(postUpgradeTests), o.PostUpgradeSuite.Name)
+		case len(failed) > 0:
+			skipPostUpgradeTests(postUpgradeTests, fmt.Sprintf("%d upgrade tests failed", len(failed)))
+			fmt.Fprintf(o.Out, "skipping %d tests from suite %q because the upgrade failed\n", len(postUpgradeTests), o.PostUpgradeSuite.Name)
+		default:
+			fmt.Fprintf(o.Out, "upgrade complete, running %d tests from suite %q\n", len(postUpgradeTests), o.PostUpgradeSuite.Name)
+			for _, phase := range postUpgradeTestPhases(postUpgradeTests) {
+				q.Execute(testCtx, phase, postUpgradeParallelism(o.Parallelism, o.PostUpgradeSuite), testOutputConfig, abortFn)
+			}
+		}
+		tests = append(tests, postUpgradeTests...)
+	}
+
 	// TODO: will move to the monitor
 	if len(o.JUnitDir) > 0 {
 		pc.ComputePodTransitions()
@@ -400,6 +672,11 @@ func (o *GinkgoRunSuiteOptions) Run(suite *TestSuite, junitSuiteName string, mon
 
 	// calculate the effective test set we ran, excluding any incompletes
 	tests, _ = splitTests(tests, func(t *testCase) bool { return t.success || t.flake || t.failed || t.skipped })
+	tests = append(tests, environmentSkipped...)
+	tests = append(tests, postUpgradeSkipped...)
+
+	// quarantined tests are downgraded to flakes before we decide what to retry.
+	quarantineOutcomes := applyQuarantine(tests, quarantinePolicy)
 
 	end := time.Now()
 	duration := end.Sub(start).Round(time.Second / 10)
@@ -479,21 +756,56 @@ func (o *GinkgoRunSuiteOptions) Run(suite *TestSuite, junitSuiteName string, mon
 	// monitor the cluster while the tests are running and report any detected anomalies
 	var syntheticTestResults []*junitapi.JUnitTestCase
 	var syntheticFailure bool
+	syntheticTestResults = append(syntheticTestResults, quarantineSummaryJUnit(quarantineOutcomes)...)
 
 	timeSuffix := fmt.Sprintf("_%s", start.UTC().Format("20060102-150405"))
 
+	timingReport := generateTestTimingReport(tests, historicalTestDurations)
+	syntheticTestResults = append(syntheticTestResults, testRuntimeRegressionJUnit(timingReport, historicalTestDurations)...)
+	if len(o.JUnitDir) > 0 {
+		if err := writeTestTimingReport(timingReport, timeSuffix, o.JUnitDir, o.ErrOut); err != nil {
+			fmt.Fprintf(o.ErrOut, "error: Unable to write test timing: %v\n", err)
+		}
+	}
+
+	// the extensions report their own results and intervals alongside those of the run.
+	if len(testRunnerContext.extensionArtifactDir) > 0 {
+		extensionJUnits, extensionIntervals, errs := extensionArtifacts(testRunnerContext.extensionArtifactDir)
+		for _, err := range errs {
+			fmt.Fprintf(o.ErrOut, "error: Unable to read extension artifacts: %v\n", err)
+		}
+		syntheticTestResults = append(syntheticTestResults, extensionJUnits...)
+		monitorEventRecorder.AddIntervals(extensionIntervals...)
+	}
+
 	monitorTestResultState, err := m.Stop(ctx)
 	if err != nil {
 		fmt.Fprintf(o.ErrOut, "error: Failed to stop monitor test: %v\n", err)
 		monitorTestResultState = monitor.Failed
 	}
+	progressEvents.Write(ProgressEvent{Type: MonitorStoppedEvent, MonitorResult: string(monitorTestResultState)})
 	if err := m.SerializeResults(ctx, junitSuiteName, timeSuffix); err != nil {
 		fmt.Fprintf(o.ErrOut, "error: Failed to serialize run-data: %v\n", err)
 	}
 
 	// default is empty string as that is what entries prior to adding this will have
 	wasMasterNodeUpdated := ""
-	if events := monitorEventRecorder.Intervals(start, end); len(events) > 0 {
+	events := monitorEventRecorder.Intervals(start, end)
+
+	// failures of known issues are linked to them, and downgraded to flakes before we decide the outcome of the run.
+	knownFailureMatches := applyKnownFailures(tests, knownFailures, events)
+	if len(knownFailureMatches) > 0 {
+		for _, match := range knownFailureMatches {
+			// only the first attempts were counted as failures
+			if match.downgraded && !match.retry {
+				fail--
+			}
+		}
+		failing, _ = splitTests(failing, func(t *testCase) bool { return t.failed })
+		fmt.Fprintf(o.Out, "%d failures match known issues\n", len(knownFailureMatches))
+	}
+
+	if len(events) > 0 {
 		buf := &bytes.Buffer{}
 		if !upgrade {
 			// the current mechanism for external binaries does not support upgrade
@@ -501,6 +813,10 @@ func (o *GinkgoRunSuiteOptions) Run(suite *TestSuite, junitSuiteName string, mon
 			syntheticTestResults = append(syntheticTestResults, fallbackSyntheticTestResult...)
 		}
 
+		var knownSyntheticFailureMatches []knownFailure
+		syntheticTestResults, knownSyntheticFailureMatches = applyKnownSyntheticFailures(syntheticTestResults, knownFailures, events)
+		knownFailureMatches = append(knownFailureMatches, knownSyntheticFailureMatches...)
+
 		if len(syntheticTestResults) > 0 {
 			// mark any failures by name
 			failingSyntheticTestNames, flakySyntheticTestNames := sets.NewString(), sets.NewString()
@@ -542,6 +858,12 @@ func (o *GinkgoRunSuiteOptions) Run(suite *TestSuite, junitSuiteName string, mon
 		}
 
 		wasMasterNodeUpdated = clusterinfo.WasMasterNodeUpdated(events)
+
+		for _, test := range tests {
+			if test.failed || test.flake {
+				test.overlappingIntervals = events.SummarizeOverlappingIntervals(test.start, test.end)
+			}
+		}
 	}
 
 	// report the outcome of the test
@@ -552,15 +874,30 @@ func (o *GinkgoRunSuiteOptions) Run(suite *TestSuite, junitSuiteName string, mon
 
 	if len(o.JUnitDir) > 0 {
 		finalSuiteResults := generateJUnitTestSuiteResults(junitSuiteName, duration, tests, syntheticTestResults...)
+		finalSuiteResults.Properties = append(finalSuiteResults.Properties, clusterProperties...)
+		finalSuiteResults.Properties = append(finalSuiteResults.Properties, runContextProperties(suite, o)...)
+		renames.AddPreviousNameProperties(finalSuiteResults)
+		addKnownIssueProperties(finalSuiteResults, knownFailureMatches)
 		if err := writeJUnitReport(finalSuiteResults, "junit_e2e", timeSuffix, o.JUnitDir, o.ErrOut); err != nil {
 			fmt.Fprintf(o.Out, "error: Unable to write e2e JUnit xml results: %v", err)
 		}
+		if err := writeFailureClusterReport(generateFailureClusterReport(finalSuiteResults), timeSuffix, o.JUnitDir, o.ErrOut); err != nil {
+			fmt.Fprintf(o.Out, "error: Unable to write failure clusters: %v", err)
+		}
+		if o.OutputFormat == OutputFormatJSON {
+			results := generateTestResults(finalSuiteResults, start, end, tests)
+			if err := writeJSONResults(results, "e2e-results", timeSuffix, o.JUnitDir, o.ErrOut); err != nil {
+				fmt.Fprintf(o.Out, "error: Unable to write e2e JSON results: %v", err)
+			}
+		}
 
-		if err := riskanalysis.WriteJobRunTestFailureSummary(o.JUnitDir, timeSuffix, finalSuiteResults, wasMasterNodeUpdated, ""); err != nil {
+		if err := riskanalysis.WriteJobRunTestFailureSummary(o.JUnitDir, timeSuffix, finalSuiteResults, wasMasterNodeUpdated, "", nil); err != nil {
 			fmt.Fprintf(o.Out, "error: Unable to write e2e job run failures summary: %v", err)
 		}
 	}
 
+	progressEvents.Write(ProgressEvent{Type: SuiteFinishedEvent, Suite: suite.Name, Passed: pass, Failed: fail, Skipped: skip, InvariantFailed: syntheticFailure})
+
 	if fail > 0 {
 		if len(failing) > 0 || suite.MaximumAllowedFlakes == 0 {
 			return fmt.Errorf("%d fail, %d pass, %d skip (%s)", fail, pass, skip, duration)
@@ -0,0 +1,70 @@
+package ginkgo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/openshift/origin/pkg/clioptions/clusterinfo"
+	"github.com/openshift/origin/pkg/defaultmonitortests"
+	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/monitortestlibrary/platformidentification"
+)
+
+// MonitorTestPlan is printed by --dry-run-monitors.
+type MonitorTestPlan struct {
+	// Platform and Topology are those of the cluster, empty when they could not be detected.
+	Platform                   string `json:"platform"`
+	Topology                   string `json:"topology"`
+	ClusterStabilityDuringTest string `json:"clusterStabilityDuringTest"`
+
+	// MonitorTests would run against the cluster.  Monitor tests that are not described may still decide not to run
+	// once they inspect the cluster.
+	MonitorTests []monitortestframework.DescribedMonitorTest `json:"monitorTests"`
+	// NotApplicable are the monitor tests whose description excludes the platform or topology of the cluster.
+	NotApplicable []monitortestframework.DescribedMonitorTest `json:"notApplicable"`
+}
+
+func newMonitorTestPlan(registry monitortestframework.MonitorTestRegistry, stability monitortestframework.ClusterStabilityDuringTest, platform, topology string) *MonitorTestPlan {
+	plan := &MonitorTestPlan{
+		Platform:                   platform,
+		Topology:                   topology,
+		ClusterStabilityDuringTest: string(stability),
+		MonitorTests:               []monitortestframework.DescribedMonitorTest{},
+		NotApplicable:              []monitortestframework.DescribedMonitorTest{},
+	}
+	for _, monitorTest := range registry.DescribeMonitorTests() {
+		if monitorTest.RunsOn(platform, topology) {
+			plan.MonitorTests = append(plan.MonitorTests, monitorTest)
+		} else {
+			plan.NotApplicable = append(plan.NotApplicable, monitorTest)
+		}
+	}
+	return plan
+}
+
+// dryRunMonitors prints the monitor tests that would run against the cluster as json, without starting them.  When
+// the cluster can't be inspected every monitor test is listed.
+func (o *GinkgoRunSuiteOptions) dryRunMonitors(ctx context.Context, monitorTestInfo monitortestframework.MonitorTestInitializationInfo) error {
+	registry, err := defaultmonitortests.NewMonitorTestsFor(monitorTestInfo)
+	if err != nil {
+		return err
+	}
+
+	jobType := &platformidentification.JobType{}
+	restConfig, err := clusterinfo.GetMonitorRESTConfig()
+	if err == nil {
+		jobType, err = platformidentification.GetJobType(ctx, restConfig)
+	}
+	if err != nil {
+		fmt.Fprintf(o.ErrOut, "warning: Unable to detect the platform and topology of the cluster, listing every monitor test: %v\n", err)
+		jobType = &platformidentification.JobType{}
+	}
+
+	out, err := json.MarshalIndent(newMonitorTestPlan(registry, monitorTestInfo.ClusterStabilityDuringTest, jobType.Platform, jobType.Topology), "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(o.Out, string(out))
+	return nil
+}
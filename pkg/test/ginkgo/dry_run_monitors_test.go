@@ -0,0 +1,74 @@
+package ginkgo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/rest"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/monitortestframework"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+)
+
+type describedMonitorTest struct {
+	description monitortestframework.MonitorTestDescription
+}
+
+func (d *describedMonitorTest) Describe() monitortestframework.MonitorTestDescription {
+	return d.description
+}
+
+func (*describedMonitorTest) StartCollection(ctx context.Context, adminRESTConfig *rest.Config, recorder monitorapi.RecorderWriter) error {
+	return nil
+}
+
+func (*describedMonitorTest) CollectData(ctx context.Context, storageDir string, beginning, end time.Time) (monitorapi.Intervals, []*junitapi.JUnitTestCase, error) {
+	return nil, nil, nil
+}
+
+func (*describedMonitorTest) ConstructComputedIntervals(ctx context.Context, startingIntervals monitorapi.Intervals, recordedResources monitorapi.ResourcesMap, beginning, end time.Time) (monitorapi.Intervals, error) {
+	return nil, nil
+}
+
+func (*describedMonitorTest) EvaluateTestsFromConstructedIntervals(ctx context.Context, finalIntervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, error) {
+	return nil, nil
+}
+
+func (*describedMonitorTest) WriteContentToStorage(ctx context.Context, storageDir, timeSuffix string, finalIntervals monitorapi.Intervals, finalResourceState monitorapi.ResourcesMap) error {
+	return nil
+}
+
+func (*describedMonitorTest) Cleanup(ctx context.Context) error {
+	return nil
+}
+
+func TestNewMonitorTestPlan(t *testing.T) {
+	registry := monitortestframework.NewMonitorTestRegistry()
+	registry.AddMonitorTestOrDie("pod-lifecycle", "Node / Kubelet", &describedMonitorTest{
+		description: monitortestframework.MonitorTestDescription{WatchedResources: []string{"pods"}},
+	})
+	registry.AddMonitorTestOrDie("azure-metrics-collector", "Test Framework", &describedMonitorTest{
+		description: monitortestframework.MonitorTestDescription{Platforms: []string{"azure"}},
+	})
+	registry.AddMonitorTestOrDie("sno-only", "Test Framework", &describedMonitorTest{
+		description: monitortestframework.MonitorTestDescription{Topologies: []string{"single"}, JUnits: []string{"[sig-node] single node"}},
+	})
+
+	plan := newMonitorTestPlan(registry, monitortestframework.Stable, "aws", "ha")
+	assert.Equal(t, "Stable", plan.ClusterStabilityDuringTest)
+	assert.Len(t, plan.MonitorTests, 1)
+	assert.Equal(t, "pod-lifecycle", plan.MonitorTests[0].Name)
+	assert.Equal(t, "Node / Kubelet", plan.MonitorTests[0].JiraComponent)
+	assert.True(t, plan.MonitorTests[0].Described)
+	assert.Equal(t, []string{"pods"}, plan.MonitorTests[0].WatchedResources)
+	assert.Len(t, plan.NotApplicable, 2)
+
+	plan = newMonitorTestPlan(registry, monitortestframework.Stable, "azure", "single")
+	assert.Len(t, plan.MonitorTests, 3)
+
+	plan = newMonitorTestPlan(registry, monitortestframework.Stable, "", "")
+	assert.Len(t, plan.MonitorTests, 3, "every monitor test is listed when the cluster is unknown")
+}
@@ -0,0 +1,44 @@
+package ginkgo
+
+import (
+	"fmt"
+)
+
+// TestSkipFunc returns a non-empty reason if the named test cannot run in the current environment, for instance
+// because it requires a capability or feature gate the cluster does not have.
+type TestSkipFunc func(name string) string
+
+// AddEnvironmentSkipFunc registers a check that skips tests instead of removing them from the suite, so the
+// reason they did not run is reported in the results.
+func (s *TestSuite) AddEnvironmentSkipFunc(skipFn TestSkipFunc) {
+	if skipFn == nil {
+		return
+	}
+	s.SkipFns = append(s.SkipFns, skipFn)
+}
+
+// SkipReason returns the reason the first registered skip function gives for the test, or empty if it can run.
+func (s *TestSuite) SkipReason(name string) string {
+	for _, skipFn := range s.SkipFns {
+		if reason := skipFn(name); len(reason) > 0 {
+			return reason
+		}
+	}
+	return ""
+}
+
+// skipTestsForEnvironment marks the tests the suite's environment cannot run as skipped.  They are returned
+// separately so they are reported without being executed.
+func skipTestsForEnvironment(suite *TestSuite, tests []*testCase) (skipped, remaining []*testCase) {
+	for _, test := range tests {
+		reason := suite.SkipReason(test.name)
+		if len(reason) == 0 {
+			remaining = append(remaining, test)
+			continue
+		}
+		test.skipped = true
+		test.testOutputBytes = []byte(fmt.Sprintf("skip [environment]: %s\n", reason))
+		skipped = append(skipped, test)
+	}
+	return skipped, remaining
+}
@@ -0,0 +1,30 @@
+package ginkgo
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkipTestsForEnvironment(t *testing.T) {
+	suite := &TestSuite{}
+	suite.AddEnvironmentSkipFunc(nil)
+	suite.AddEnvironmentSkipFunc(func(name string) string {
+		if strings.Contains(name, "[Capability:Build]") {
+			return "cluster capability Build is not enabled"
+		}
+		return ""
+	})
+
+	build := &testCase{name: "[sig-builds] builds should run [Capability:Build]"}
+	node := &testCase{name: "[sig-node] pods should run"}
+
+	skipped, remaining := skipTestsForEnvironment(suite, []*testCase{build, node})
+	require.Equal(t, []*testCase{build}, skipped)
+	require.Equal(t, []*testCase{node}, remaining)
+	assert.True(t, build.skipped)
+	assert.Equal(t, "skip [environment]: cluster capability Build is not enabled",
+		lastLinesUntil(string(build.testOutputBytes), 100, "skip ["))
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -14,8 +15,14 @@ import (
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
 
 	imagev1 "github.com/openshift/api/image/v1"
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	monitorserialization "github.com/openshift/origin/pkg/monitor/serialization"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
 	"github.com/openshift/origin/test/extended/util"
 )
 
@@ -24,15 +31,91 @@ type serializedTest struct {
 	Labels string
 }
 
-// externalTestsForSuite reads tests from external binary, currently only
-// k8s-tests is supported
-func externalTestsForSuite(ctx context.Context) ([]*testCase, error) {
+// extensionArtifactDirEnv is set for the tests of an extension binary to a directory where they can write junit xml
+// (junit*.xml) and intervals (e2e-intervals*.json) that are merged into the artifacts of the run.
+const extensionArtifactDirEnv = "EXTENSION_ARTIFACT_DIR"
+
+// TestExtension is a binary shipped in a payload image that lists and runs tests like openshift-tests does, with
+// "list" and "run-test <name>" commands.
+type TestExtension struct {
+	Name string `json:"name"`
+	// ImageTag is the tag of the image in the release payload that contains the binary.
+	ImageTag   string `json:"imageTag"`
+	BinaryPath string `json:"binaryPath"`
+	// ReplacesLabel, when set, drops the tests built into openshift-tests that carry the label in favor of the tests
+	// of the extension.
+	ReplacesLabel string `json:"replacesLabel,omitempty"`
+}
+
+// TestExtensionManifest is the content of the file passed with --extension-manifest, for instance:
+//
+//	extensions:
+//	- name: k8s-tests
+//	  imageTag: hyperkube
+//	  binaryPath: /usr/bin/k8s-tests
+//	  replacesLabel: "[Suite:k8s]"
+type TestExtensionManifest struct {
+	Extensions []TestExtension `json:"extensions"`
+}
+
+// defaultTestExtensionManifest runs the kubernetes tests from the hyperkube image, the built-in vendored copies are
+// only used when the binary can't be extracted.
+var defaultTestExtensionManifest = &TestExtensionManifest{
+	Extensions: []TestExtension{
+		{Name: "k8s-tests", ImageTag: "hyperkube", BinaryPath: "/usr/bin/k8s-tests", ReplacesLabel: "[Suite:k8s]"},
+	},
+}
+
+// LoadTestExtensionManifest reads a yaml or json manifest of extension binaries.
+func LoadTestExtensionManifest(path string) (*TestExtensionManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &TestExtensionManifest{}
+	if err := yaml.UnmarshalStrict(data, manifest); err != nil {
+		return nil, fmt.Errorf("unable to parse extension manifest %q: %w", path, err)
+	}
+	names := sets.NewString()
+	for i, extension := range manifest.Extensions {
+		switch {
+		case len(extension.Name) == 0 || len(extension.ImageTag) == 0 || len(extension.BinaryPath) == 0:
+			return nil, fmt.Errorf("extension %d must set name, imageTag and binaryPath", i)
+		case names.Has(extension.Name):
+			return nil, fmt.Errorf("extension %q is listed twice", extension.Name)
+		case strings.ContainsAny(extension.Name, `/\`):
+			return nil, fmt.Errorf("extension name %q may not contain path separators", extension.Name)
+		}
+		names.Insert(extension.Name)
+	}
+	return manifest, nil
+}
+
+// externalTestsForSuite extracts each extension binary of the manifest from the release payload and lists its tests.
+// The tests of the extensions that could be listed are returned along with the labels they replace, and an error
+// for the extensions that could not.
+func externalTestsForSuite(ctx context.Context, manifest *TestExtensionManifest) ([]*testCase, []string, error) {
 	var tests []*testCase
+	var replacedLabels []string
+	var errs []error
+	for _, extension := range manifest.Extensions {
+		extensionTests, err := testsForExtension(ctx, extension)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("extension %q: %w", extension.Name, err))
+			continue
+		}
+		tests = append(tests, extensionTests...)
+		if len(extension.ReplacesLabel) > 0 {
+			replacedLabels = append(replacedLabels, extension.ReplacesLabel)
+		}
+	}
+	return tests, replacedLabels, utilerrors.NewAggregate(errs)
+}
 
-	// TODO: add support for binaries from other images
-	testBinary, err := extractBinaryFromReleaseImage("hyperkube", "/usr/bin/k8s-tests")
+func testsForExtension(ctx context.Context, extension TestExtension) ([]*testCase, error) {
+	testBinary, err := extractBinaryFromReleaseImage(extension.ImageTag, extension.BinaryPath)
 	if err != nil {
-		return nil, fmt.Errorf("unable to extract k8s-tests binary: %w", err)
+		return nil, fmt.Errorf("unable to extract %s binary: %w", extension.BinaryPath, err)
 	}
 
 	command := exec.Command(testBinary, "list")
@@ -40,6 +123,12 @@ func externalTestsForSuite(ctx context.Context) ([]*testCase, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed running '%s list': %w", testBinary, err)
 	}
+	return parseExtensionTestList(testList, testBinary, extension.Name)
+}
+
+// parseExtensionTestList reads the json lists of tests in the output of the list command, other lines are logging.
+func parseExtensionTestList(testList []byte, testBinary, extensionName string) ([]*testCase, error) {
+	var tests []*testCase
 	buf := bytes.NewBuffer(testList)
 	for {
 		line, err := buf.ReadString('\n')
@@ -59,12 +148,81 @@ func externalTestsForSuite(ctx context.Context) ([]*testCase, error) {
 				name:       test.Name + test.Labels,
 				rawName:    test.Name,
 				binaryName: testBinary,
+				extension:  extensionName,
 			})
 		}
 	}
 	return tests, nil
 }
 
+// withoutReplacedTests drops the built-in tests provided by the extensions, either because they carry a label an
+// extension replaces or because an extension has a test of the same name.
+func withoutReplacedTests(builtIn, external []*testCase, replacedLabels []string) []*testCase {
+	externalNames := sets.NewString()
+	for _, test := range external {
+		externalNames.Insert(test.name)
+	}
+	ret := []*testCase{}
+	for _, test := range builtIn {
+		if externalNames.Has(test.name) {
+			continue
+		}
+		replaced := false
+		for _, label := range replacedLabels {
+			if strings.Contains(test.name, label) {
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			ret = append(ret, test)
+		}
+	}
+	return ret
+}
+
+// extensionArtifacts reads the junit results and intervals the extension tests wrote under dir, one directory per
+// extension.  Malformed files are reported rather than failing the run.
+func extensionArtifacts(dir string) ([]*junitapi.JUnitTestCase, monitorapi.Intervals, []error) {
+	var testCases []*junitapi.JUnitTestCase
+	var intervals monitorapi.Intervals
+	var errs []error
+
+	junitFiles, _ := filepath.Glob(filepath.Join(dir, "*", "junit*.xml"))
+	for _, junitFile := range junitFiles {
+		content, err := os.ReadFile(junitFile)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		suite := &junitapi.JUnitTestSuite{}
+		if err := xml.Unmarshal(content, suite); err != nil {
+			errs = append(errs, fmt.Errorf("unable to parse %s: %w", junitFile, err))
+			continue
+		}
+		testCases = append(testCases, junitTestCases(suite)...)
+	}
+
+	intervalFiles, _ := filepath.Glob(filepath.Join(dir, "*", "e2e-intervals*.json"))
+	for _, intervalFile := range intervalFiles {
+		fileIntervals, err := monitorserialization.EventsFromFile(intervalFile)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("unable to parse %s: %w", intervalFile, err))
+			continue
+		}
+		intervals = append(intervals, fileIntervals...)
+	}
+	return testCases, intervals, errs
+}
+
+func junitTestCases(suite *junitapi.JUnitTestSuite) []*junitapi.JUnitTestCase {
+	ret := append([]*junitapi.JUnitTestCase{}, suite.TestCases...)
+	for _, child := range suite.Children {
+		ret = append(ret, junitTestCases(child)...)
+	}
+	return ret
+}
+
 // extractBinaryFromReleaseImage is responsible for resolving the tag from
 // release image and extracting binary, returns path to the binary or error
 func extractBinaryFromReleaseImage(tag, binary string) (string, error) {
@@ -0,0 +1,104 @@
+package ginkgo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	monitorserialization "github.com/openshift/origin/pkg/monitor/serialization"
+)
+
+func TestLoadTestExtensionManifest(t *testing.T) {
+	dir := t.TempDir()
+	write := func(content string) string {
+		path := filepath.Join(dir, "manifest.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+
+	manifest, err := LoadTestExtensionManifest(write(`extensions:
+- name: k8s-tests
+  imageTag: hyperkube
+  binaryPath: /usr/bin/k8s-tests
+  replacesLabel: "[Suite:k8s]"
+- name: storage-tests
+  imageTag: csi-driver-tests
+  binaryPath: /usr/bin/storage-tests
+`))
+	require.NoError(t, err)
+	require.Len(t, manifest.Extensions, 2)
+	assert.Equal(t, "[Suite:k8s]", manifest.Extensions[0].ReplacesLabel)
+
+	invalid := map[string]string{
+		"missing binaryPath": "extensions:\n- name: a\n  imageTag: b\n",
+		"duplicate name":     "extensions:\n- {name: a, imageTag: b, binaryPath: /c}\n- {name: a, imageTag: d, binaryPath: /e}\n",
+		"path in name":       "extensions:\n- {name: ../a, imageTag: b, binaryPath: /c}\n",
+		"unknown field":      "extensions:\n- {name: a, image: b, binaryPath: /c}\n",
+	}
+	for name, content := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := LoadTestExtensionManifest(write(content))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseExtensionTestList(t *testing.T) {
+	output := `I0301 10:00:00.000000 logging before the list
+[{"Name": "[sig-storage] volumes should mount", "Labels": " [Suite:k8s]"}, {"Name": "[sig-node] pods should run", "Labels": ""}]
+`
+	tests, err := parseExtensionTestList([]byte(output), "/tmp/k8s-tests", "k8s-tests")
+	require.NoError(t, err)
+	require.Len(t, tests, 2)
+	assert.Equal(t, "[sig-storage] volumes should mount [Suite:k8s]", tests[0].name)
+	assert.Equal(t, "[sig-storage] volumes should mount", tests[0].rawName)
+	assert.Equal(t, "/tmp/k8s-tests", tests[0].binaryName)
+	assert.Equal(t, "k8s-tests", tests[0].extension)
+	assert.Equal(t, "k8s-tests", tests[0].Retry().extension, "retries run in the same extension")
+}
+
+func TestWithoutReplacedTests(t *testing.T) {
+	builtIn := []*testCase{
+		{name: "[sig-network] services should work [Suite:k8s]"},
+		{name: "[sig-storage] csi should attach"},
+		{name: "[sig-apps] origin test [Suite:openshift/conformance/parallel]"},
+	}
+	external := []*testCase{
+		{name: "[sig-storage] csi should attach", extension: "storage-tests"},
+	}
+	assert.Equal(t, []string{"[sig-apps] origin test [Suite:openshift/conformance/parallel]"},
+		testNames(withoutReplacedTests(builtIn, external, []string{"[Suite:k8s]"})))
+	assert.Len(t, withoutReplacedTests(builtIn, nil, nil), 3, "built-in tests are kept when no extension could be listed")
+}
+
+func TestExtensionArtifacts(t *testing.T) {
+	dir := t.TempDir()
+	extensionDir := filepath.Join(dir, "storage-tests")
+	require.NoError(t, os.MkdirAll(extensionDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(extensionDir, "junit_storage.xml"), []byte(`<testsuite name="storage">
+    <testcase name="[sig-storage] csi driver should stay available"></testcase>
+    <testcase name="[sig-storage] csi driver should not leak volumes"><failure message="">leaked 2 volumes</failure></testcase>
+</testsuite>`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(extensionDir, "junit_broken.xml"), []byte(`<testsuite`), 0644))
+
+	from := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	interval := monitorapi.NewInterval(monitorapi.SourceE2ETest, monitorapi.Warning).
+		Locator(monitorapi.NewLocator().NodeFromName("worker-0")).
+		Message(monitorapi.NewMessage().HumanMessage("csi driver restarted")).
+		Build(from, from.Add(time.Minute))
+	require.NoError(t, monitorserialization.EventsToFile(filepath.Join(extensionDir, "e2e-intervals_storage.json"), monitorapi.Intervals{interval}))
+
+	testCases, intervals, errs := extensionArtifacts(dir)
+	require.Len(t, testCases, 2)
+	assert.Equal(t, "[sig-storage] csi driver should stay available", testCases[0].Name)
+	require.NotNil(t, testCases[1].FailureOutput)
+	assert.Equal(t, "leaked 2 volumes", testCases[1].FailureOutput.Output)
+	require.Len(t, intervals, 1)
+	assert.Equal(t, "csi driver restarted", intervals[0].Message.HumanMessage)
+	assert.Len(t, errs, 1, "the malformed junit is reported")
+}
@@ -0,0 +1,118 @@
+package ginkgo
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// failureOutputNormalizers replace the parts of failure output that differ between occurrences of the same failure,
+// in order.  Timestamps go first, their digits would otherwise be mistaken for IPs.
+var failureOutputNormalizers = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	// 2024-03-01T10:00:00.123456Z, 2024-03-01 10:00:00 +0000 UTC
+	{regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:?\d{2})?( [A-Z]{3,4})?`), "<timestamp>"},
+	// Mar  1 10:00:00.123, the ginkgo and klog format
+	{regexp.MustCompile(`\b(Jan|Feb|Mar|Apr|May|Jun|Jul|Aug|Sep|Oct|Nov|Dec) +\d{1,2} \d{2}:\d{2}:\d{2}(\.\d+)?`), "<timestamp>"},
+	// I0301 10:00:00.123456, the klog header
+	{regexp.MustCompile(`\b[IWEF]\d{4} \d{2}:\d{2}:\d{2}\.\d+`), "<timestamp>"},
+	{regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`), "<uid>"},
+	{regexp.MustCompile(`\b\d{1,3}\.\d{1,3}\.\d{1,3}\.\d{1,3}(:\d+)?\b`), "<ip>"},
+	{regexp.MustCompile(`(?i)\[?\b[0-9a-f]{1,4}(:[0-9a-f]{0,4}){2,7}\]?(:\d+)?`), "<ip>"},
+	// e2e-test-router-xk2lq, the generated names of e2e namespaces
+	{regexp.MustCompile(`\be2e-([a-z0-9]+-)*[a-z0-9]{5}\b`), "<e2e-namespace>"},
+}
+
+// normalizeFailureOutput strips what differs between occurrences of the same failure from its output.
+func normalizeFailureOutput(output string) string {
+	for _, normalizer := range failureOutputNormalizers {
+		output = normalizer.pattern.ReplaceAllString(output, normalizer.replacement)
+	}
+	return output
+}
+
+// failureFingerprint returns the normalized failure output and its fingerprint, which identifies the failure across
+// runs.
+func failureFingerprint(output string) (string, string) {
+	normalized := normalizeFailureOutput(output)
+	return normalized, fmt.Sprintf("%x", sha256.Sum256([]byte(normalized)))[:16]
+}
+
+// FailureCluster is a set of failures whose output is identical once normalized, that likely share a root cause.
+type FailureCluster struct {
+	Fingerprint string `json:"fingerprint"`
+	// Count is the number of failures in the cluster, a test that failed again when retried counts twice.
+	Count int      `json:"count"`
+	Tests []string `json:"tests"`
+	// NormalizedOutput is the output the failures share, ExampleOutput the output of one of them.
+	NormalizedOutput string `json:"normalizedOutput"`
+	ExampleOutput    string `json:"exampleOutput"`
+}
+
+// FailureClusterReport is the content of the failure-clusters artifact.
+type FailureClusterReport struct {
+	Failures int              `json:"failures"`
+	Clusters []FailureCluster `json:"clusters"`
+}
+
+// generateFailureClusterReport fingerprints the failure output of every failed and flaked test of the suite, including
+// the synthetic ones, and clusters the failures sharing a fingerprint, the largest cluster first.
+func generateFailureClusterReport(suite *junitapi.JUnitTestSuite) *FailureClusterReport {
+	report := &FailureClusterReport{Clusters: []FailureCluster{}}
+	byFingerprint := map[string]*FailureCluster{}
+	tests := map[string]sets.Set[string]{}
+	for _, test := range suite.TestCases {
+		if test.FailureOutput == nil {
+			continue
+		}
+		output := test.FailureOutput.Output
+		if len(output) == 0 {
+			output = test.FailureOutput.Message
+		}
+		normalized, fingerprint := failureFingerprint(output)
+		cluster, ok := byFingerprint[fingerprint]
+		if !ok {
+			cluster = &FailureCluster{
+				Fingerprint:      fingerprint,
+				NormalizedOutput: normalized,
+				ExampleOutput:    output,
+			}
+			byFingerprint[fingerprint] = cluster
+			tests[fingerprint] = sets.New[string]()
+		}
+		cluster.Count++
+		tests[fingerprint].Insert(test.Name)
+		report.Failures++
+	}
+	for fingerprint, cluster := range byFingerprint {
+		cluster.Tests = sets.List(tests[fingerprint])
+		report.Clusters = append(report.Clusters, *cluster)
+	}
+	sort.Slice(report.Clusters, func(i, j int) bool {
+		if report.Clusters[i].Count != report.Clusters[j].Count {
+			return report.Clusters[i].Count > report.Clusters[j].Count
+		}
+		return report.Clusters[i].Fingerprint < report.Clusters[j].Fingerprint
+	})
+	return report
+}
+
+func writeFailureClusterReport(report *FailureClusterReport, fileSuffix, dir string, errOut io.Writer) error {
+	out, err := json.MarshalIndent(report, "", "    ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("failure-clusters%s.json", fileSuffix))
+	fmt.Fprintf(errOut, "Writing %d failures in %d clusters to %s\n", report.Failures, len(report.Clusters), path)
+	return os.WriteFile(path, out, 0640)
+}
@@ -0,0 +1,67 @@
+package ginkgo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+)
+
+func TestNormalizeFailureOutput(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   string
+	}{
+		{
+			name:   "timestamps",
+			output: `Mar  1 10:00:00.123: INFO: pod started at 2024-03-01T10:00:00.123456Z, I0301 10:00:01.000001 retrying`,
+			want:   `<timestamp>: INFO: pod started at <timestamp>, <timestamp> retrying`,
+		},
+		{
+			name:   "uids and ips",
+			output: `pod 0a1b2c3d-4e5f-6a7b-8c9d-0e1f2a3b4c5d: dial tcp 10.128.2.14:8443: connect: connection refused, [fd01:0:0:1::5]:443`,
+			want:   `pod <uid>: dial tcp <ip>: connect: connection refused, <ip>`,
+		},
+		{
+			name:   "e2e namespaces",
+			output: `namespace e2e-test-router-xk2lq is terminating, e2e-kubectl-9z8y7`,
+			want:   `namespace <e2e-namespace> is terminating, <e2e-namespace>`,
+		},
+		{
+			name:   "source locations are kept",
+			output: `fail [github.com/openshift/origin/test/extended/router/router.go:123]: timed out`,
+			want:   `fail [github.com/openshift/origin/test/extended/router/router.go:123]: timed out`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, normalizeFailureOutput(tt.output))
+		})
+	}
+}
+
+func TestGenerateFailureClusterReport(t *testing.T) {
+	failure := func(name, output string) *junitapi.JUnitTestCase {
+		return &junitapi.JUnitTestCase{Name: name, FailureOutput: &junitapi.FailureOutput{Output: output}}
+	}
+	suite := &junitapi.JUnitTestSuite{TestCases: []*junitapi.JUnitTestCase{
+		failure("[sig-network] a", "fail [router.go:12]: dial tcp 10.0.0.1:443: i/o timeout"),
+		failure("[sig-network] b", "fail [router.go:12]: dial tcp 10.0.0.2:443: i/o timeout"),
+		failure("[sig-network] b", "fail [router.go:12]: dial tcp 10.0.0.3:443: i/o timeout"),
+		{Name: "[sig-network] b"},
+		failure("[sig-storage] c", "fail [volume.go:40]: volume never attached"),
+		{Name: "[sig-storage] passed"},
+	}}
+
+	report := generateFailureClusterReport(suite)
+	assert.Equal(t, 4, report.Failures)
+	require.Len(t, report.Clusters, 2)
+	assert.Equal(t, 3, report.Clusters[0].Count, "the largest cluster goes first")
+	assert.Equal(t, []string{"[sig-network] a", "[sig-network] b"}, report.Clusters[0].Tests)
+	assert.Equal(t, "fail [router.go:12]: dial tcp <ip>: i/o timeout", report.Clusters[0].NormalizedOutput)
+	assert.Equal(t, "fail [router.go:12]: dial tcp 10.0.0.1:443: i/o timeout", report.Clusters[0].ExampleOutput)
+	assert.Equal(t, []string{"[sig-storage] c"}, report.Clusters[1].Tests)
+}
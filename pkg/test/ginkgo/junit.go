@@ -49,7 +49,7 @@ func generateJUnitTestSuiteResults(
 			s.NumFailed++
 			s.TestCases = append(s.TestCases, &junitapi.JUnitTestCase{
 				Name:      test.name,
-				SystemOut: string(test.testOutputBytes),
+				SystemOut: systemOutWithOverlappingIntervals(test),
 				Duration:  test.duration.Seconds(),
 				FailureOutput: &junitapi.FailureOutput{
 					Output: lastLinesUntil(string(test.testOutputBytes), 100, "fail ["),
@@ -60,10 +60,10 @@ func generateJUnitTestSuiteResults(
 			s.NumFailed++
 			s.TestCases = append(s.TestCases, &junitapi.JUnitTestCase{
 				Name:      test.name,
-				SystemOut: string(test.testOutputBytes),
+				SystemOut: systemOutWithOverlappingIntervals(test),
 				Duration:  test.duration.Seconds(),
 				FailureOutput: &junitapi.FailureOutput{
-					Output: lastLinesUntil(string(test.testOutputBytes), 100, "flake:"),
+					Output: flakeOutput(test),
 				},
 			})
 
@@ -94,6 +94,25 @@ func generateJUnitTestSuiteResults(
 	return s
 }
 
+// systemOutWithOverlappingIntervals appends the summary of cluster intervals observed during the test, if any, to the
+// test output so that failures can be triaged without opening the timeline.
+func systemOutWithOverlappingIntervals(test *testCase) string {
+	systemOut := string(test.testOutputBytes)
+	if len(test.flakeReason) > 0 {
+		systemOut = fmt.Sprintf("flake: %s\n\n%s", test.flakeReason, systemOut)
+	}
+	if len(test.overlappingIntervals) == 0 {
+		return systemOut
+	}
+	return fmt.Sprintf("%s\n\n%s", systemOut, test.overlappingIntervals)
+}
+
+// flakeOutput is the failure of a flake.  Failures that are reported as flakes, because of a quarantine or a known
+// issue, end with the failure instead of a flake line.
+func flakeOutput(test *testCase) string {
+	return lastLinesUntil(string(test.testOutputBytes), 100, "flake:", "fail [")
+}
+
 func writeJUnitReport(s *junitapi.JUnitTestSuite, filePrefix, fileSuffix, dir string, errOut io.Writer) error {
 	out, err := xml.MarshalIndent(s, "", "    ")
 	if err != nil {
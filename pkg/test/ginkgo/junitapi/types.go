@@ -77,6 +77,9 @@ type JUnitTestCase struct {
 
 	// SystemErr is output written to stderr during the execution of this test case
 	SystemErr string `xml:"system-err,omitempty"`
+
+	// Properties holds facts about the test case added after it ran, such as its risk analysis
+	Properties []*TestSuiteProperty `xml:"properties>property,omitempty"`
 }
 
 // SkipMessage holds a message explaining why a test was skipped
@@ -0,0 +1,273 @@
+package ginkgo
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+)
+
+// KnownIssueProperty is the junit property that links a failure matching a known issue to its tracking reference.
+const KnownIssueProperty = "KnownIssue"
+
+// knownFailuresFetchTimeout bounds how long fetching a remote catalog may take before the suite starts.
+const knownFailuresFetchTimeout = 30 * time.Second
+
+// KnownIssue describes the failures caused by an issue that is already tracked.  A failure matches when it matches
+// every matcher that is set: the name of the test, the fingerprint of its output from the failure-clusters artifact, a
+// regular expression of its output, or an interval observed while it ran.
+type KnownIssue struct {
+	Name              string `json:"name"`
+	TrackingReference string `json:"trackingReference"`
+
+	TestNameRegex string                     `json:"testNameRegex,omitempty"`
+	Fingerprint   string                     `json:"fingerprint,omitempty"`
+	OutputRegex   string                     `json:"outputRegex,omitempty"`
+	Interval      *KnownIssueIntervalPattern `json:"interval,omitempty"`
+
+	// Flake reports the matching failures as flakes instead of failures.
+	Flake bool `json:"flake,omitempty"`
+
+	testNameRegex *regexp.Regexp
+	outputRegex   *regexp.Regexp
+}
+
+// KnownIssueIntervalPattern matches the intervals observed while a failed test ran, or during the run for the
+// synthetic tests.  Unset fields match every interval.
+type KnownIssueIntervalPattern struct {
+	Source       string `json:"source,omitempty"`
+	Reason       string `json:"reason,omitempty"`
+	LocatorRegex string `json:"locatorRegex,omitempty"`
+	MessageRegex string `json:"messageRegex,omitempty"`
+
+	locatorRegex *regexp.Regexp
+	messageRegex *regexp.Regexp
+}
+
+// KnownFailuresCatalog is the content of the file or URL passed with --known-failures.
+type KnownFailuresCatalog struct {
+	Issues []KnownIssue `json:"issues"`
+}
+
+// LoadKnownFailuresCatalog reads a yaml or json catalog of known issues from a file, or from an http(s) URL.
+func LoadKnownFailuresCatalog(location string) (*KnownFailuresCatalog, error) {
+	var data []byte
+	var err error
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		data, err = fetchKnownFailuresCatalog(location)
+	} else {
+		data, err = os.ReadFile(location)
+	}
+	if err != nil {
+		return nil, err
+	}
+	catalog := &KnownFailuresCatalog{}
+	if err := yaml.Unmarshal(data, catalog); err != nil {
+		return nil, fmt.Errorf("unable to parse known failures catalog %q: %w", location, err)
+	}
+	for i := range catalog.Issues {
+		if err := catalog.Issues[i].compile(); err != nil {
+			return nil, fmt.Errorf("known issue %d: %w", i, err)
+		}
+	}
+	return catalog, nil
+}
+
+func fetchKnownFailuresCatalog(url string) ([]byte, error) {
+	client := &http.Client{Timeout: knownFailuresFetchTimeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to fetch known failures catalog %q: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func (i *KnownIssue) compile() error {
+	var err error
+	if len(i.Name) == 0 || len(i.TrackingReference) == 0 {
+		return fmt.Errorf("must have a name and a trackingReference")
+	}
+	// the name of the test alone is what the quarantine file is for
+	if len(i.Fingerprint) == 0 && len(i.OutputRegex) == 0 && i.Interval == nil {
+		return fmt.Errorf("%s must set one of fingerprint, outputRegex or interval", i.Name)
+	}
+	if len(i.TestNameRegex) > 0 {
+		if i.testNameRegex, err = regexp.Compile(i.TestNameRegex); err != nil {
+			return fmt.Errorf("%s has an invalid testNameRegex: %w", i.Name, err)
+		}
+	}
+	if len(i.OutputRegex) > 0 {
+		if i.outputRegex, err = regexp.Compile(i.OutputRegex); err != nil {
+			return fmt.Errorf("%s has an invalid outputRegex: %w", i.Name, err)
+		}
+	}
+	if i.Interval != nil {
+		if len(i.Interval.LocatorRegex) > 0 {
+			if i.Interval.locatorRegex, err = regexp.Compile(i.Interval.LocatorRegex); err != nil {
+				return fmt.Errorf("%s has an invalid interval locatorRegex: %w", i.Name, err)
+			}
+		}
+		if len(i.Interval.MessageRegex) > 0 {
+			if i.Interval.messageRegex, err = regexp.Compile(i.Interval.MessageRegex); err != nil {
+				return fmt.Errorf("%s has an invalid interval messageRegex: %w", i.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (p *KnownIssueIntervalPattern) matches(interval monitorapi.Interval) bool {
+	switch {
+	case len(p.Source) > 0 && string(interval.Source) != p.Source:
+		return false
+	case len(p.Reason) > 0 && string(interval.Message.Reason) != p.Reason:
+		return false
+	case p.locatorRegex != nil && !p.locatorRegex.MatchString(interval.Locator.OldLocator()):
+		return false
+	case p.messageRegex != nil && !p.messageRegex.MatchString(interval.Message.HumanMessage):
+		return false
+	}
+	return true
+}
+
+// matches returns true if the failure of the named test, with the output and the intervals observed while it ran,
+// matches the issue.
+func (i *KnownIssue) matches(testName, output string, intervals monitorapi.Intervals) bool {
+	if i.testNameRegex != nil && !i.testNameRegex.MatchString(testName) {
+		return false
+	}
+	if len(i.Fingerprint) > 0 {
+		if _, fingerprint := failureFingerprint(output); fingerprint != i.Fingerprint {
+			return false
+		}
+	}
+	if i.outputRegex != nil && !i.outputRegex.MatchString(output) {
+		return false
+	}
+	if i.Interval != nil {
+		for _, interval := range intervals {
+			if i.Interval.matches(interval) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// Match returns the first known issue the failure matches, or nil.
+func (c *KnownFailuresCatalog) Match(testName, output string, intervals monitorapi.Intervals) *KnownIssue {
+	if c == nil {
+		return nil
+	}
+	for i := range c.Issues {
+		if c.Issues[i].matches(testName, output, intervals) {
+			return &c.Issues[i]
+		}
+	}
+	return nil
+}
+
+// knownFailure is a failure that matched a known issue.
+type knownFailure struct {
+	testName string
+	issue    *KnownIssue
+	// retry is true if the failure is that of a retried test.
+	retry bool
+	// downgraded is true if the failure is reported as a flake because of the issue.
+	downgraded bool
+}
+
+// applyKnownFailures matches the failed tests against the catalog, using the intervals observed while each of them
+// ran, and reports the failures of the issues that allow it as flakes.  Failures whose retry passed already report as
+// flakes and are only linked to the issue.  It returns the failures that matched.
+func applyKnownFailures(tests []*testCase, catalog *KnownFailuresCatalog, intervals monitorapi.Intervals) []knownFailure {
+	if catalog == nil {
+		return nil
+	}
+	passedOnRetry := map[*testCase]bool{}
+	for _, test := range tests {
+		if test.previous != nil && (test.success || test.flake) {
+			passedOnRetry[test.previous] = true
+		}
+	}
+	var matched []knownFailure
+	for _, test := range tests {
+		if !test.failed {
+			continue
+		}
+		overlapping := intervals.Filter(func(interval monitorapi.Interval) bool {
+			return !interval.From.After(test.end) && (interval.To.IsZero() || !interval.To.Before(test.start))
+		})
+		issue := catalog.Match(test.name, lastLinesUntil(string(test.testOutputBytes), 100, "fail ["), overlapping)
+		if issue == nil {
+			continue
+		}
+		failure := knownFailure{testName: test.name, issue: issue, retry: test.previous != nil}
+		if issue.Flake && !passedOnRetry[test] {
+			test.failed = false
+			test.flake = true
+			test.flakeReason = fmt.Sprintf("failure matches known issue %q, tracked by %s", issue.Name, issue.TrackingReference)
+			failure.downgraded = true
+		}
+		matched = append(matched, failure)
+	}
+	return matched
+}
+
+// applyKnownSyntheticFailures matches the failed synthetic tests against the catalog, using every interval of the run.
+// Their system out links to the issue they matched, and a passing result is added to those the issue allows to flake.
+func applyKnownSyntheticFailures(results []*junitapi.JUnitTestCase, catalog *KnownFailuresCatalog, intervals monitorapi.Intervals) ([]*junitapi.JUnitTestCase, []knownFailure) {
+	if catalog == nil {
+		return results, nil
+	}
+	var matched []knownFailure
+	var flakes []*junitapi.JUnitTestCase
+	for _, result := range results {
+		if result.FailureOutput == nil {
+			continue
+		}
+		issue := catalog.Match(result.Name, result.FailureOutput.Output, intervals)
+		if issue == nil {
+			continue
+		}
+		matched = append(matched, knownFailure{testName: result.Name, issue: issue})
+		note := fmt.Sprintf("failure matches known issue %q, tracked by %s", issue.Name, issue.TrackingReference)
+		if len(result.SystemOut) > 0 {
+			note += "\n\n" + result.SystemOut
+		}
+		result.SystemOut = note
+		if issue.Flake {
+			flakes = append(flakes, &junitapi.JUnitTestCase{Name: result.Name})
+		}
+	}
+	return append(results, flakes...), matched
+}
+
+// addKnownIssueProperties links the failures of the suite that matched a known issue to its tracking reference.
+func addKnownIssueProperties(suite *junitapi.JUnitTestSuite, matched []knownFailure) {
+	byTest := map[string]*KnownIssue{}
+	for _, failure := range matched {
+		byTest[failure.testName] = failure.issue
+	}
+	for _, testCase := range suite.TestCases {
+		if testCase.FailureOutput == nil {
+			continue
+		}
+		if issue, ok := byTest[testCase.Name]; ok {
+			testCase.Properties = append(testCase.Properties, &junitapi.TestSuiteProperty{Name: KnownIssueProperty, Value: issue.TrackingReference})
+		}
+	}
+}
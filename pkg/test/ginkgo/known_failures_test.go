@@ -0,0 +1,158 @@
+package ginkgo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+)
+
+const testKnownFailuresCatalog = `issues:
+- name: router timeouts
+  trackingReference: https://issues.redhat.com/browse/OCPBUGS-1
+  testNameRegex: "\\[sig-network-edge\\]"
+  outputRegex: "i/o timeout"
+  flake: true
+- name: etcd leader elections
+  trackingReference: https://issues.redhat.com/browse/OCPBUGS-2
+  interval:
+    source: EtcdLog
+    messageRegex: "elected leader"
+`
+
+func TestLoadKnownFailuresCatalog(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			content: testKnownFailuresCatalog,
+		},
+		{
+			name: "missing tracking reference",
+			content: `issues:
+- name: router timeouts
+  outputRegex: "i/o timeout"
+`,
+			wantErr: true,
+		},
+		{
+			name: "test name only",
+			content: `issues:
+- name: router timeouts
+  trackingReference: OCPBUGS-1
+  testNameRegex: "router"
+`,
+			wantErr: true,
+		},
+		{
+			name: "invalid interval regex",
+			content: `issues:
+- name: router timeouts
+  trackingReference: OCPBUGS-1
+  interval:
+    locatorRegex: "("
+`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "known-failures.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0644))
+			_, err := LoadKnownFailuresCatalog(path)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testKnownFailuresCatalog))
+	}))
+	defer server.Close()
+	catalog, err := LoadKnownFailuresCatalog(server.URL)
+	require.NoError(t, err)
+	assert.Len(t, catalog.Issues, 2)
+}
+
+func TestApplyKnownFailures(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "known-failures.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testKnownFailuresCatalog), 0644))
+	catalog, err := LoadKnownFailuresCatalog(path)
+	require.NoError(t, err)
+
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	failed := func(name, output string, from, to time.Time) *testCase {
+		return &testCase{name: name, failed: true, start: from, end: to, testOutputBytes: []byte(output)}
+	}
+	router := failed("[sig-network-edge] router works", "fail [router.go:12]: dial tcp 10.0.0.1:443: i/o timeout", start, start.Add(time.Minute))
+	retriedRouter := router.Retry()
+	retriedRouter.failed, retriedRouter.testOutputBytes = true, router.testOutputBytes
+	election := failed("[sig-api-machinery] watch works", "fail [watch.go:40]: context deadline exceeded", start.Add(10*time.Minute), start.Add(11*time.Minute))
+	unrelated := failed("[sig-api-machinery] list works", "fail [list.go:40]: context deadline exceeded", start, start.Add(time.Minute))
+	flakedRouter := failed("[sig-network-edge] router reloads", "fail [router.go:30]: dial tcp 10.0.0.1:443: i/o timeout", start, start.Add(time.Minute))
+	passedRetry := flakedRouter.Retry()
+	passedRetry.success = true
+
+	leaderElected := monitorapi.NewInterval(monitorapi.SourceEtcdLog, monitorapi.Info).
+		Locator(monitorapi.NewLocator().EtcdMemberFromNames("master-0", "")).
+		Message(monitorapi.NewMessage().HumanMessage("elected leader at term 5")).
+		Build(start.Add(10*time.Minute+30*time.Second), start.Add(10*time.Minute+30*time.Second))
+
+	matched := applyKnownFailures([]*testCase{router, retriedRouter, election, unrelated, flakedRouter, passedRetry}, catalog, monitorapi.Intervals{leaderElected})
+	require.Len(t, matched, 4, "the leader election happened before the unrelated test ran")
+	assert.Equal(t, "router timeouts", matched[0].issue.Name)
+	assert.True(t, matched[1].retry)
+	assert.Equal(t, "etcd leader elections", matched[2].issue.Name)
+	assert.False(t, matched[2].downgraded)
+	assert.Equal(t, "[sig-network-edge] router reloads", matched[3].testName)
+	assert.False(t, matched[3].downgraded, "the retry passed, the test already flaked")
+	assert.True(t, flakedRouter.failed)
+
+	assert.True(t, router.flake)
+	assert.False(t, router.failed)
+	assert.True(t, matched[0].downgraded)
+	assert.Equal(t, "failure matches known issue \"router timeouts\", tracked by https://issues.redhat.com/browse/OCPBUGS-1", router.flakeReason)
+	assert.True(t, election.failed, "the issue does not allow its failures to flake")
+	assert.True(t, unrelated.failed)
+
+	synthetic := []*junitapi.JUnitTestCase{
+		{Name: "[sig-etcd] leader changes", FailureOutput: &junitapi.FailureOutput{Output: "2 leader changes"}},
+		{Name: "[sig-network-edge] router availability", FailureOutput: &junitapi.FailureOutput{Output: "dial tcp: i/o timeout"}},
+	}
+	synthetic, syntheticMatched := applyKnownSyntheticFailures(synthetic, catalog, monitorapi.Intervals{leaderElected})
+	require.Len(t, syntheticMatched, 2)
+	require.Len(t, synthetic, 3, "a passing result makes the router failure a flake")
+	assert.Equal(t, &junitapi.JUnitTestCase{Name: "[sig-network-edge] router availability"}, synthetic[2])
+	assert.Equal(t, "2 leader changes", synthetic[0].FailureOutput.Output, "the failure output is still the failure")
+	assert.Contains(t, synthetic[0].SystemOut, "tracked by https://issues.redhat.com/browse/OCPBUGS-2")
+
+	suite := generateJUnitTestSuiteResults("openshift-tests", time.Hour, []*testCase{router, election, unrelated}, synthetic...)
+	assert.Equal(t, "fail [router.go:12]: dial tcp 10.0.0.1:443: i/o timeout", suite.TestCases[0].FailureOutput.Output)
+	addKnownIssueProperties(suite, append(matched, syntheticMatched...))
+	properties := map[string]string{}
+	for _, testCase := range suite.TestCases {
+		for _, property := range testCase.Properties {
+			properties[testCase.Name] = property.Value
+		}
+	}
+	assert.Equal(t, map[string]string{
+		"[sig-network-edge] router works":        "https://issues.redhat.com/browse/OCPBUGS-1",
+		"[sig-api-machinery] watch works":        "https://issues.redhat.com/browse/OCPBUGS-2",
+		"[sig-etcd] leader changes":              "https://issues.redhat.com/browse/OCPBUGS-2",
+		"[sig-network-edge] router availability": "https://issues.redhat.com/browse/OCPBUGS-1",
+	}, properties)
+}
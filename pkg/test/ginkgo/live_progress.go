@@ -0,0 +1,355 @@
+package ginkgo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+// progressObserver is told about every test as the suite runs.  It must be threadsafe, tests run in parallel.
+type progressObserver interface {
+	TestStarted(name string, start time.Time)
+	TestFinished(name string, state TestState, start, end time.Time)
+}
+
+const (
+	// liveTimelineWindow is how far back the live timeline and monitor health look.
+	liveTimelineWindow = 30 * time.Minute
+	// liveRecentResults is how many of the latest results the live page lists.
+	liveRecentResults = 20
+)
+
+// liveProgress serves the progress of the suite over http while it runs: the tests running and their results, the
+// intervals recorded lately by source, and a timeline of the warnings and errors of the last half hour.
+type liveProgress struct {
+	lock     sync.Mutex
+	start    time.Time
+	expected int
+	running  map[string]time.Time
+	counts   map[TestState]int
+	recent   []LiveTestResult
+	// warnings are the warning and error intervals the recorder told us about that reach into the window, kept as
+	// they are recorded so that serving a page does not read back every interval of the run.
+	warnings monitorapi.Intervals
+
+	now func() time.Time
+}
+
+// LiveTestResult is a test that finished, as served by /status.
+type LiveTestResult struct {
+	Name            string    `json:"name"`
+	Result          TestState `json:"result"`
+	End             time.Time `json:"end"`
+	DurationSeconds float64   `json:"durationSeconds"`
+}
+
+// LiveRunningTest is a test that is running, as served by /status.
+type LiveRunningTest struct {
+	Name           string    `json:"name"`
+	Start          time.Time `json:"start"`
+	RunningSeconds float64   `json:"runningSeconds"`
+}
+
+// LiveSourceHealth counts the warning and error intervals a source recorded within the window.
+type LiveSourceHealth struct {
+	Source   monitorapi.IntervalSource `json:"source"`
+	Warnings int                       `json:"warnings"`
+	Errors   int                       `json:"errors"`
+}
+
+// LiveStatus is the content of /status.
+type LiveStatus struct {
+	Start          time.Time          `json:"start"`
+	ElapsedSeconds float64            `json:"elapsedSeconds"`
+	Expected       int                `json:"expected"`
+	Finished       int                `json:"finished"`
+	Results        map[TestState]int  `json:"results"`
+	Running        []LiveRunningTest  `json:"running"`
+	Recent         []LiveTestResult   `json:"recent"`
+	MonitorHealth  []LiveSourceHealth `json:"monitorHealth"`
+	timeline       monitorapi.Intervals
+	timelineFrom   time.Time
+	timelineTo     time.Time
+}
+
+// newLiveProgress follows the intervals written to the notifier from now on, if there is one.
+func newLiveProgress(notifier monitorapi.RecorderNotifier, start time.Time) *liveProgress {
+	p := &liveProgress{
+		start:   start,
+		running: map[string]time.Time{},
+		counts:  map[TestState]int{},
+		now:     time.Now,
+	}
+	if notifier != nil {
+		notifier.AddIntervalHandler(p.intervalRecorded)
+	}
+	return p
+}
+
+// setExpected sets the number of tests the suite runs, known once the tests are split.
+func (p *liveProgress) setExpected(expected int) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.expected = expected
+}
+
+// intervalRecorded keeps the warnings and errors.  An interval that ends replaces the one that was open since it
+// started.
+func (p *liveProgress) intervalRecorded(interval monitorapi.Interval) {
+	if interval.Level < monitorapi.Warning {
+		return
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.pruneWarningsLocked(p.now().Add(-liveTimelineWindow))
+	if !interval.To.IsZero() {
+		for i := len(p.warnings) - 1; i >= 0; i-- {
+			if startedAs(p.warnings[i], interval) {
+				p.warnings[i] = interval
+				return
+			}
+		}
+	}
+	p.warnings = append(p.warnings, interval)
+}
+
+// startedAs returns true if open is the started interval that ended as ended.
+func startedAs(open, ended monitorapi.Interval) bool {
+	return open.To.IsZero() &&
+		open.From.Equal(ended.From) &&
+		open.Source == ended.Source &&
+		open.Level == ended.Level &&
+		open.Locator.OldLocator() == ended.Locator.OldLocator() &&
+		open.Message.OldMessage() == ended.Message.OldMessage()
+}
+
+// pruneWarningsLocked drops the warnings that ended before from.
+func (p *liveProgress) pruneWarningsLocked(from time.Time) {
+	kept := p.warnings[:0]
+	for _, interval := range p.warnings {
+		if interval.To.IsZero() || !interval.To.Before(from) {
+			kept = append(kept, interval)
+		}
+	}
+	p.warnings = kept
+}
+
+func (p *liveProgress) TestStarted(name string, start time.Time) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.running[name] = start
+}
+
+func (p *liveProgress) TestFinished(name string, state TestState, start, end time.Time) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	delete(p.running, name)
+	p.counts[state]++
+	p.recent = append(p.recent, LiveTestResult{Name: name, Result: state, End: end, DurationSeconds: end.Sub(start).Seconds()})
+	if len(p.recent) > liveRecentResults {
+		p.recent = p.recent[len(p.recent)-liveRecentResults:]
+	}
+}
+
+func (p *liveProgress) status() *LiveStatus {
+	now := p.now()
+	status := &LiveStatus{
+		Start:          p.start,
+		ElapsedSeconds: now.Sub(p.start).Round(time.Second).Seconds(),
+		Results:        map[TestState]int{},
+		Running:        []LiveRunningTest{},
+		Recent:         []LiveTestResult{},
+		MonitorHealth:  []LiveSourceHealth{},
+		timelineFrom:   now.Add(-liveTimelineWindow),
+		timelineTo:     now,
+	}
+
+	p.lock.Lock()
+	status.Expected = p.expected
+	for state, count := range p.counts {
+		status.Results[state] = count
+		status.Finished += count
+	}
+	for name, start := range p.running {
+		status.Running = append(status.Running, LiveRunningTest{Name: name, Start: start, RunningSeconds: now.Sub(start).Round(time.Second).Seconds()})
+	}
+	// the latest first
+	for i := len(p.recent) - 1; i >= 0; i-- {
+		status.Recent = append(status.Recent, p.recent[i])
+	}
+	p.pruneWarningsLocked(status.timelineFrom)
+	status.timeline = append(monitorapi.Intervals{}, p.warnings...)
+	p.lock.Unlock()
+	sort.Slice(status.Running, func(i, j int) bool {
+		return status.Running[i].Start.Before(status.Running[j].Start)
+	})
+
+	bySource := map[monitorapi.IntervalSource]*LiveSourceHealth{}
+	for _, interval := range status.timeline {
+		health, ok := bySource[interval.Source]
+		if !ok {
+			health = &LiveSourceHealth{Source: interval.Source}
+			bySource[interval.Source] = health
+		}
+		if interval.Level == monitorapi.Error {
+			health.Errors++
+		} else {
+			health.Warnings++
+		}
+	}
+	for _, health := range bySource {
+		status.MonitorHealth = append(status.MonitorHealth, *health)
+	}
+	sort.Slice(status.MonitorHealth, func(i, j int) bool {
+		return status.MonitorHealth[i].Source < status.MonitorHealth[j].Source
+	})
+	return status
+}
+
+// timelineBar is an interval placed on the live timeline, in percent of its width.
+type timelineBar struct {
+	Row   int
+	X     float64
+	Width float64
+	Color string
+	Title string
+}
+
+// timelineBars places the intervals of the window on the rows of their sources.  Intervals still open run to the
+// end of the window, instants get a visible width.
+func (s *LiveStatus) timelineBars() []timelineBar {
+	window := s.timelineTo.Sub(s.timelineFrom)
+	rows := map[monitorapi.IntervalSource]int{}
+	for i, health := range s.MonitorHealth {
+		rows[health.Source] = i
+	}
+	bars := []timelineBar{}
+	for _, interval := range s.timeline {
+		from, to := interval.From, interval.To
+		if from.Before(s.timelineFrom) {
+			from = s.timelineFrom
+		}
+		if to.IsZero() || to.After(s.timelineTo) {
+			to = s.timelineTo
+		}
+		bar := timelineBar{
+			Row:   rows[interval.Source],
+			X:     percentOf(from.Sub(s.timelineFrom), window),
+			Width: percentOf(to.Sub(from), window),
+			Color: "#e69500",
+			Title: fmt.Sprintf("%s %s %s", interval.From.UTC().Format(time.TimeOnly), interval.Locator.OldLocator(), interval.Message.OldMessage()),
+		}
+		if interval.Level == monitorapi.Error {
+			bar.Color = "#c00"
+		}
+		if bar.Width < 0.2 {
+			bar.Width = 0.2
+		}
+		bars = append(bars, bar)
+	}
+	return bars
+}
+
+// percentOf rounds to a hundredth of a percent, finer than a pixel of the timeline.
+func percentOf(d, window time.Duration) float64 {
+	return math.Round(10000*float64(d)/float64(window)) / 100
+}
+
+var liveProgressTemplate = template.Must(template.New("live").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="10">
+<title>openshift-tests: {{.Status.Finished}}/{{.Status.Expected}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 1em; }
+td, th { border: 1px solid #ccc; padding: 0.2em 0.6em; text-align: left; }
+.Failed, .TimedOut, .Unknown { color: #c00; }
+.Flaked { color: #b60; }
+.Success { color: #070; }
+</style>
+</head>
+<body>
+<h1>openshift-tests</h1>
+<p>Running for {{.Status.ElapsedSeconds}}s, {{.Status.Finished}} of {{.Status.Expected}} tests finished:
+{{- range $state, $count := .Status.Results}} <span class="{{$state}}">{{$count}} {{$state}}</span>{{end}}.</p>
+
+<h2>Running ({{len .Status.Running}})</h2>
+<table>
+{{- range .Status.Running}}
+<tr><td>{{.RunningSeconds}}s</td><td>{{.Name}}</td></tr>
+{{- end}}
+</table>
+
+<h2>Latest results</h2>
+<table>
+{{- range .Status.Recent}}
+<tr><td class="{{.Result}}">{{.Result}}</td><td>{{.DurationSeconds}}s</td><td>{{.Name}}</td></tr>
+{{- end}}
+</table>
+
+<h2>Monitor health, last {{.Window}}</h2>
+{{- if .Status.MonitorHealth}}
+<table>
+<tr><th>Source</th><th>Warnings</th><th>Errors</th></tr>
+{{- range .Status.MonitorHealth}}
+<tr><td>{{.Source}}</td><td>{{.Warnings}}</td><td class="{{if .Errors}}Failed{{end}}">{{.Errors}}</td></tr>
+{{- end}}
+</table>
+<svg width="100%" height="{{.Height}}" style="border: 1px solid #ccc">
+{{- range .Bars}}
+<rect x="{{.X}}%" y="{{.Row}}em" width="{{.Width}}%" height="0.8em" fill="{{.Color}}"><title>{{.Title}}</title></rect>
+{{- end}}
+</svg>
+{{- else}}
+<p>No warnings or errors recorded.</p>
+{{- end}}
+</body>
+</html>
+`))
+
+func (p *liveProgress) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	status := p.status()
+	switch req.URL.Path {
+	case "/status":
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(status)
+	case "/":
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		liveProgressTemplate.Execute(w, map[string]interface{}{
+			"Status": status,
+			"Window": liveTimelineWindow,
+			"Bars":   status.timelineBars(),
+			"Height": fmt.Sprintf("%dem", len(status.MonitorHealth)+1),
+		})
+	default:
+		http.NotFound(w, req)
+	}
+}
+
+// serve listens on the address and serves the progress until the context is done.
+func (p *liveProgress) serve(ctx context.Context, address string, out io.Writer) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf("unable to serve the progress on %s: %w", address, err)
+	}
+	server := &http.Server{Handler: p, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+	go server.Serve(listener)
+	fmt.Fprintf(out, "Serving the progress of the suite on http://%s\n", listener.Addr())
+	return nil
+}
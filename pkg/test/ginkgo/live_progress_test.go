@@ -0,0 +1,96 @@
+package ginkgo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/origin/pkg/monitor"
+	"github.com/openshift/origin/pkg/monitor/monitorapi"
+)
+
+func TestLiveProgress(t *testing.T) {
+	now := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	recorder := monitor.NewRecorder()
+	progress := newLiveProgress(recorder.(monitorapi.RecorderNotifier), now.Add(-10*time.Minute))
+	progress.setExpected(3)
+	progress.now = func() time.Time { return now }
+
+	firing := recorder.StartInterval(monitorapi.NewInterval(monitorapi.SourceAlert, monitorapi.Error).
+		Locator(monitorapi.NewLocator().LocateNamespace("openshift-kube-apiserver")).
+		Message(monitorapi.NewMessage().HumanMessage("<firing>")).
+		Build(now.Add(-5*time.Minute), time.Time{}))
+	recorder.EndInterval(firing, now.Add(-2*time.Minute))
+	recorder.AddIntervals(
+		monitorapi.NewInterval(monitorapi.SourceAlert, monitorapi.Warning).
+			Locator(monitorapi.NewLocator().LocateNamespace("openshift-monitoring")).
+			Message(monitorapi.NewMessage().HumanMessage("pending")).
+			Build(now.Add(-time.Minute), time.Time{}),
+		monitorapi.NewInterval(monitorapi.SourceAlert, monitorapi.Info).
+			Locator(monitorapi.NewLocator().LocateNamespace("openshift-monitoring")).
+			Message(monitorapi.NewMessage().HumanMessage("informational")).
+			Build(now.Add(-time.Minute), now),
+		monitorapi.NewInterval(monitorapi.SourceAlert, monitorapi.Error).
+			Locator(monitorapi.NewLocator().LocateNamespace("openshift-monitoring")).
+			Message(monitorapi.NewMessage().HumanMessage("long gone")).
+			Build(now.Add(-2*time.Hour), now.Add(-time.Hour)),
+	)
+
+	progress.TestStarted("[sig-node] passes", now.Add(-3*time.Minute))
+	progress.TestStarted("[sig-node] fails", now.Add(-2*time.Minute))
+	progress.TestStarted("[sig-network] <runs>", now.Add(-time.Minute))
+	progress.TestFinished("[sig-node] passes", TestSucceeded, now.Add(-3*time.Minute), now.Add(-2*time.Minute))
+	progress.TestFinished("[sig-node] fails", TestFailed, now.Add(-2*time.Minute), now.Add(-time.Minute))
+
+	server := httptest.NewServer(progress)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/status")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	status := &LiveStatus{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(status))
+	assert.Equal(t, 600.0, status.ElapsedSeconds)
+	assert.Equal(t, 3, status.Expected)
+	assert.Equal(t, 2, status.Finished)
+	assert.Equal(t, map[TestState]int{TestSucceeded: 1, TestFailed: 1}, status.Results)
+	assert.Equal(t, []LiveRunningTest{{Name: "[sig-network] <runs>", Start: now.Add(-time.Minute), RunningSeconds: 60}}, status.Running)
+	require.Len(t, status.Recent, 2)
+	assert.Equal(t, "[sig-node] fails", status.Recent[0].Name, "the latest result first")
+	assert.Equal(t, []LiveSourceHealth{{Source: monitorapi.SourceAlert, Warnings: 1, Errors: 1}}, status.MonitorHealth,
+		"info, intervals outside of the window, and the start of an ended interval are not counted")
+
+	resp, err = http.Get(server.URL + "/")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	page := string(body)
+	assert.Contains(t, page, "2 of 3 tests finished")
+	assert.Contains(t, page, "<td>60s</td><td>[sig-network] &lt;runs&gt;</td>", "names are escaped")
+	assert.Contains(t, page, "<td>Alert</td><td>1</td><td class=\"Failed\">1</td>")
+	assert.Contains(t, page, `<rect x="83.33%" y="0em" width="10%"`, "the error runs from 5 to 2 minutes ago")
+	assert.Contains(t, page, `<rect x="96.67%" y="0em" width="3.33%"`, "the open warning runs to now")
+
+	resp, err = http.Get(server.URL + "/unknown")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestLiveProgressServe(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	out := &bytes.Buffer{}
+	progress := newLiveProgress(nil, time.Now())
+	require.NoError(t, progress.serve(ctx, "127.0.0.1:0", out))
+	assert.Contains(t, out.String(), "Serving the progress of the suite on http://127.0.0.1:")
+}
@@ -0,0 +1,51 @@
+package ginkgo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// postUpgradeTestsFor selects the tests of the suite to run after an upgrade completes.  The tests are copies so
+// they never share state with the upgrade tests, and they inherit the suite timeout when they do not set their own.
+func postUpgradeTestsFor(suite *TestSuite, allTests []*testCase) (skipped, remaining []*testCase) {
+	tests := copyTests(suite.Filter(allTests))
+	for _, test := range tests {
+		if test.testTimeout == 0 {
+			test.testTimeout = suite.TestTimeout
+		}
+	}
+	return skipTestsForEnvironment(suite, tests)
+}
+
+// postUpgradeParallelism prefers the parallelism requested on the command line, then the suite's own.
+func postUpgradeParallelism(requested int, suite *TestSuite) int {
+	switch {
+	case requested > 0:
+		return requested
+	case suite.Parallelism > 0:
+		return suite.Parallelism
+	default:
+		return 10
+	}
+}
+
+// postUpgradeTestPhases orders the post-upgrade tests the same way as the main run: [Early] tests first and [Late]
+// tests after everything else, so tests that rely on that ordering behave the same in both.
+func postUpgradeTestPhases(tests []*testCase) [][]*testCase {
+	early, notEarly := splitTests(tests, func(t *testCase) bool {
+		return strings.Contains(t.name, "[Early]")
+	})
+	late, primaryTests := splitTests(notEarly, func(t *testCase) bool {
+		return strings.Contains(t.name, "[Late]")
+	})
+	return [][]*testCase{early, primaryTests, late}
+}
+
+// skipPostUpgradeTests reports the post-upgrade tests as skipped when the upgrade did not succeed, running them
+// against a partially upgraded cluster would only produce noise.
+func skipPostUpgradeTests(tests []*testCase, reason string) {
+	for _, test := range tests {
+		test.skipped = true
+		test.testOutputBytes = []byte(fmt.Sprintf("skip [post-upgrade]: %s\n", reason))
+	}
+}
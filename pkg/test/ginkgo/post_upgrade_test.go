@@ -0,0 +1,59 @@
+package ginkgo
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostUpgradeTestsFor(t *testing.T) {
+	suite := &TestSuite{
+		Name: "openshift/conformance",
+		Matches: func(name string) bool {
+			return strings.Contains(name, "[Suite:openshift/conformance/")
+		},
+		TestTimeout: 15 * time.Minute,
+	}
+	suite.AddEnvironmentSkipFunc(func(name string) string {
+		if strings.Contains(name, "[Capability:Build]") {
+			return "cluster capability Build is not enabled"
+		}
+		return ""
+	})
+
+	upgrade := &testCase{name: "[sig-arch] Cluster should remain functional during upgrade [Suite:openshift/upgrade]"}
+	conformance := &testCase{name: "[sig-node] pods should run [Suite:openshift/conformance/parallel]"}
+	slow := &testCase{name: "[sig-node] pods should run slowly [Timeout:30m] [Suite:openshift/conformance/parallel]", testTimeout: 30 * time.Minute}
+	build := &testCase{name: "[sig-builds] builds should run [Capability:Build] [Suite:openshift/conformance/parallel]"}
+
+	skipped, tests := postUpgradeTestsFor(suite, []*testCase{upgrade, conformance, slow, build})
+	require.Len(t, tests, 2)
+	require.Len(t, skipped, 1)
+	assert.Equal(t, build.name, skipped[0].name)
+	assert.False(t, build.skipped, "the original test must not be modified")
+
+	assert.Equal(t, conformance.name, tests[0].name)
+	assert.NotSame(t, conformance, tests[0])
+	assert.Equal(t, 15*time.Minute, tests[0].testTimeout)
+	assert.Equal(t, 30*time.Minute, tests[1].testTimeout)
+
+	skipPostUpgradeTests(tests, "1 upgrade tests failed")
+	assert.True(t, tests[0].skipped)
+	assert.False(t, conformance.skipped)
+
+	assert.Equal(t, 3, postUpgradeParallelism(3, &TestSuite{Parallelism: 5}))
+	assert.Equal(t, 5, postUpgradeParallelism(0, &TestSuite{Parallelism: 5}))
+	assert.Equal(t, 10, postUpgradeParallelism(0, &TestSuite{}))
+}
+
+func TestPostUpgradeTestPhases(t *testing.T) {
+	early := &testCase{name: "[sig-arch] [Early] checks the cluster first"}
+	primary := &testCase{name: "[sig-node] pods should run"}
+	late := &testCase{name: "[sig-arch] [Late] checks the cluster last"}
+
+	phases := postUpgradeTestPhases([]*testCase{late, primary, early})
+	assert.Equal(t, [][]*testCase{{early}, {primary}, {late}}, phases)
+}
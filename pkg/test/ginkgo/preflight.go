@@ -0,0 +1,248 @@
+package ginkgo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	configclient "github.com/openshift/client-go/config/clientset/versioned"
+	routeclient "github.com/openshift/client-go/route/clientset/versioned"
+	"github.com/openshift/library-go/test/library/metrics"
+	prometheustypes "github.com/prometheus/common/model"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+)
+
+const (
+	preflightTestName = "[sig-arch] cluster should be ready before the suite starts"
+
+	// preflightCriticalAlertsQuery lists the critical alerts firing when the suite starts.
+	preflightCriticalAlertsQuery = `ALERTS{alertstate="firing",severity="critical"}`
+)
+
+var (
+	// preflightCPUPerTest and preflightPodsPerTest estimate what a single running test needs.  Most tests create one
+	// or two small pods, the estimate only has to catch clusters that cannot hold the suite at all.
+	preflightCPUPerTest  = resource.MustParse("100m")
+	preflightPodsPerTest = int64(2)
+)
+
+// preflightProblems checks that the cluster is healthy enough to run the suite at the parallelism and returns why it
+// is not.  Failures to reach the cluster are problems, failures to reach the monitoring stack are only logged.
+func preflightProblems(ctx context.Context, restConfig *rest.Config, parallelism int) ([]string, error) {
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	configClient, err := configclient.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []string
+	operators, err := configClient.ConfigV1().ClusterOperators().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("unable to list clusteroperators: %v", err))
+	} else {
+		problems = append(problems, unavailableClusterOperators(operators.Items)...)
+	}
+
+	nodes, err := kubeClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("unable to list nodes: %v", err))
+	} else {
+		problems = append(problems, notReadyNodes(nodes.Items)...)
+
+		pods, err := kubeClient.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("unable to list pods: %v", err))
+		} else {
+			problems = append(problems, insufficientCapacity(nodes.Items, pods.Items, parallelism)...)
+		}
+	}
+
+	alerts, err := firingCriticalAlerts(ctx, kubeClient, restConfig)
+	if err != nil {
+		logrus.WithError(err).Warn("unable to check for firing critical alerts before the suite starts")
+	}
+	problems = append(problems, alerts...)
+
+	return problems, nil
+}
+
+func unavailableClusterOperators(operators []configv1.ClusterOperator) []string {
+	var problems []string
+	for _, operator := range operators {
+		available := false
+		reason := "no Available condition"
+		for _, condition := range operator.Status.Conditions {
+			if condition.Type != configv1.OperatorAvailable {
+				continue
+			}
+			available = condition.Status == configv1.ConditionTrue
+			reason = fmt.Sprintf("%s: %s", condition.Reason, condition.Message)
+		}
+		if !available {
+			problems = append(problems, fmt.Sprintf("clusteroperator/%s is not available, %s", operator.Name, reason))
+		}
+	}
+	sort.Strings(problems)
+	return problems
+}
+
+func isNodeReady(node corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func notReadyNodes(nodes []corev1.Node) []string {
+	var problems []string
+	for _, node := range nodes {
+		if !isNodeReady(node) {
+			problems = append(problems, fmt.Sprintf("node/%s is not Ready", node.Name))
+		}
+	}
+	sort.Strings(problems)
+	return problems
+}
+
+// insufficientCapacity compares what is left on the schedulable, Ready nodes to what the suite needs at the
+// parallelism.
+func insufficientCapacity(nodes []corev1.Node, pods []corev1.Pod, parallelism int) []string {
+	schedulable := map[string]bool{}
+	freeCPU := resource.Quantity{}
+	freePods := int64(0)
+	for _, node := range nodes {
+		if node.Spec.Unschedulable || !isNodeReady(node) || hasNoScheduleTaint(node) {
+			continue
+		}
+		schedulable[node.Name] = true
+		freeCPU.Add(*node.Status.Allocatable.Cpu())
+		freePods += node.Status.Allocatable.Pods().Value()
+	}
+
+	for _, pod := range pods {
+		if !schedulable[pod.Spec.NodeName] || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		freePods--
+		for _, container := range pod.Spec.Containers {
+			freeCPU.Sub(*container.Resources.Requests.Cpu())
+		}
+	}
+
+	neededCPU := resource.Quantity{}
+	for i := 0; i < parallelism; i++ {
+		neededCPU.Add(preflightCPUPerTest)
+	}
+	neededPods := preflightPodsPerTest * int64(parallelism)
+
+	var problems []string
+	if freeCPU.Cmp(neededCPU) < 0 {
+		problems = append(problems, fmt.Sprintf("schedulable nodes have %s of cpu unrequested, %d parallel tests need about %s", freeCPU.String(), parallelism, neededCPU.String()))
+	}
+	if freePods < neededPods {
+		problems = append(problems, fmt.Sprintf("schedulable nodes have room for %d more pods, %d parallel tests need about %d", freePods, parallelism, neededPods))
+	}
+	return problems
+}
+
+func hasNoScheduleTaint(node corev1.Node) bool {
+	for _, taint := range node.Spec.Taints {
+		if taint.Effect == corev1.TaintEffectNoSchedule || taint.Effect == corev1.TaintEffectNoExecute {
+			return true
+		}
+	}
+	return false
+}
+
+func firingCriticalAlerts(ctx context.Context, kubeClient kubernetes.Interface, restConfig *rest.Config) ([]string, error) {
+	if _, err := kubeClient.CoreV1().Namespaces().Get(ctx, "openshift-monitoring", metav1.GetOptions{}); apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	routeClient, err := routeclient.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	prometheusClient, err := metrics.NewPrometheusClient(ctx, kubeClient, routeClient)
+	if err != nil {
+		return nil, err
+	}
+	result, _, err := prometheusClient.Query(ctx, preflightCriticalAlertsQuery, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	vector, ok := result.(prometheustypes.Vector)
+	if !ok {
+		return nil, fmt.Errorf("expected a vector from %s, got %s", preflightCriticalAlertsQuery, result.Type())
+	}
+	return criticalAlertProblems(vector), nil
+}
+
+func criticalAlertProblems(vector prometheustypes.Vector) []string {
+	var problems []string
+	for _, sample := range vector {
+		name := string(sample.Metric[prometheustypes.AlertNameLabel])
+		if namespace := sample.Metric["namespace"]; len(namespace) > 0 {
+			name = fmt.Sprintf("%s in namespace/%s", name, namespace)
+		}
+		problems = append(problems, fmt.Sprintf("critical alert %s is firing", name))
+	}
+	sort.Strings(problems)
+	return problems
+}
+
+// preflightJUnit reports the pre-flight checks as a suite of one test that fails with every problem.
+func preflightJUnit(problems []string, duration time.Duration) *junitapi.JUnitTestSuite {
+	testCase := &junitapi.JUnitTestCase{
+		Name:     preflightTestName,
+		Duration: duration.Seconds(),
+	}
+	suite := &junitapi.JUnitTestSuite{
+		Name:      "openshift-tests-preflight",
+		NumTests:  1,
+		Duration:  duration.Seconds(),
+		TestCases: []*junitapi.JUnitTestCase{testCase},
+	}
+	if len(problems) > 0 {
+		message := fmt.Sprintf("cluster is not ready to run the suite:\n%s", strings.Join(problems, "\n"))
+		testCase.FailureOutput = &junitapi.FailureOutput{Output: message}
+		testCase.SystemOut = message
+		suite.NumFailed = 1
+	}
+	return suite
+}
+
+// runPreflight fails fast, with a junit result, if the cluster is not ready to run the suite.
+func (o *GinkgoRunSuiteOptions) runPreflight(ctx context.Context, restConfig *rest.Config, parallelism int) error {
+	start := time.Now()
+	problems, err := preflightProblems(ctx, restConfig, parallelism)
+	if err != nil {
+		return fmt.Errorf("unable to run the pre-flight checks: %w", err)
+	}
+	if len(o.JUnitDir) > 0 {
+		timeSuffix := fmt.Sprintf("_%s", start.UTC().Format("20060102-150405"))
+		if err := writeJUnitReport(preflightJUnit(problems, time.Since(start)), "junit_preflight", timeSuffix, o.JUnitDir, o.ErrOut); err != nil {
+			fmt.Fprintf(o.ErrOut, "error: Unable to write pre-flight JUnit results: %v\n", err)
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("cluster is not ready to run the suite:\n  %s", strings.Join(problems, "\n  "))
+	}
+	fmt.Fprintf(o.Out, "Pre-flight checks passed\n")
+	return nil
+}
@@ -0,0 +1,118 @@
+package ginkgo
+
+import (
+	"testing"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	prometheustypes "github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func preflightNode(name string, ready bool, cpu string, pods int64) corev1.Node {
+	status := corev1.ConditionTrue
+	if !ready {
+		status = corev1.ConditionFalse
+	}
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}},
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:  resource.MustParse(cpu),
+				corev1.ResourcePods: *resource.NewQuantity(pods, resource.DecimalSI),
+			},
+		},
+	}
+}
+
+func preflightPod(node, cpu string) corev1.Pod {
+	return corev1.Pod{
+		Spec: corev1.PodSpec{
+			NodeName: node,
+			Containers: []corev1.Container{{
+				Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)}},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+func Test_unavailableClusterOperators(t *testing.T) {
+	operators := []configv1.ClusterOperator{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "etcd"},
+			Status: configv1.ClusterOperatorStatus{Conditions: []configv1.ClusterOperatorStatusCondition{
+				{Type: configv1.OperatorAvailable, Status: configv1.ConditionTrue},
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "ingress"},
+			Status: configv1.ClusterOperatorStatus{Conditions: []configv1.ClusterOperatorStatusCondition{
+				{Type: configv1.OperatorAvailable, Status: configv1.ConditionFalse, Reason: "IngressUnavailable", Message: "no routers"},
+			}},
+		},
+		{ObjectMeta: metav1.ObjectMeta{Name: "dns"}},
+	}
+	assert.Equal(t, []string{
+		"clusteroperator/dns is not available, no Available condition",
+		"clusteroperator/ingress is not available, IngressUnavailable: no routers",
+	}, unavailableClusterOperators(operators))
+}
+
+func Test_notReadyNodes(t *testing.T) {
+	nodes := []corev1.Node{preflightNode("a", true, "4", 250), preflightNode("b", false, "4", 250)}
+	assert.Equal(t, []string{"node/b is not Ready"}, notReadyNodes(nodes))
+}
+
+func Test_insufficientCapacity(t *testing.T) {
+	tests := []struct {
+		name        string
+		nodes       []corev1.Node
+		pods        []corev1.Pod
+		parallelism int
+		want        []string
+	}{
+		{
+			name:        "room to spare",
+			nodes:       []corev1.Node{preflightNode("a", true, "4", 250)},
+			pods:        []corev1.Pod{preflightPod("a", "1")},
+			parallelism: 10,
+		},
+		{
+			name:        "cpu is requested",
+			nodes:       []corev1.Node{preflightNode("a", true, "4", 250)},
+			pods:        []corev1.Pod{preflightPod("a", "3500m")},
+			parallelism: 10,
+			want:        []string{"schedulable nodes have 500m of cpu unrequested, 10 parallel tests need about 1"},
+		},
+		{
+			name:        "not ready nodes have no room",
+			nodes:       []corev1.Node{preflightNode("a", true, "4", 10), preflightNode("b", false, "4", 250)},
+			parallelism: 10,
+			want:        []string{"schedulable nodes have room for 10 more pods, 10 parallel tests need about 20"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, insufficientCapacity(tt.nodes, tt.pods, tt.parallelism))
+		})
+	}
+}
+
+func Test_preflightJUnit(t *testing.T) {
+	passed := preflightJUnit(nil, time.Second)
+	assert.Equal(t, uint(0), passed.NumFailed)
+	assert.Nil(t, passed.TestCases[0].FailureOutput)
+
+	problems := criticalAlertProblems(prometheustypes.Vector{
+		{Metric: prometheustypes.Metric{prometheustypes.AlertNameLabel: "KubeAPIDown", "namespace": "openshift-kube-apiserver"}},
+	})
+	failed := preflightJUnit(append([]string{"node/b is not Ready"}, problems...), time.Second)
+	assert.Equal(t, uint(1), failed.NumFailed)
+	assert.Equal(t, preflightTestName, failed.TestCases[0].Name)
+	assert.Equal(t, "cluster is not ready to run the suite:\nnode/b is not Ready\ncritical alert KubeAPIDown in namespace/openshift-kube-apiserver is firing", failed.TestCases[0].FailureOutput.Output)
+}
@@ -0,0 +1,112 @@
+package ginkgo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// ProgressEventType is the type of a --progress-events event.
+type ProgressEventType string
+
+const (
+	SuiteStartedEvent   ProgressEventType = "SuiteStarted"
+	SuiteFinishedEvent  ProgressEventType = "SuiteFinished"
+	TestStartedEvent    ProgressEventType = "TestStarted"
+	TestFinishedEvent   ProgressEventType = "TestFinished"
+	MonitorStartedEvent ProgressEventType = "MonitorStarted"
+	MonitorStoppedEvent ProgressEventType = "MonitorStopped"
+)
+
+// ProgressEvent is a line written by --progress-events.  Only the fields of its type are set.
+type ProgressEvent struct {
+	Type ProgressEventType `json:"type"`
+	Time time.Time         `json:"time"`
+
+	// Suite and ExpectedTests are set when the suite starts.
+	Suite         string `json:"suite,omitempty"`
+	ExpectedTests int    `json:"expectedTests,omitempty"`
+
+	// Test is set when a test starts and finishes, Result and DurationSeconds when it finishes.
+	Test            string    `json:"test,omitempty"`
+	Result          TestState `json:"result,omitempty"`
+	DurationSeconds float64   `json:"durationSeconds,omitempty"`
+
+	// MonitorResult is set when the monitor stops.
+	MonitorResult string `json:"monitorResult,omitempty"`
+
+	// Passed, Failed and Skipped count the tests when the suite finishes.  InvariantFailed is set if a monitor test
+	// or another synthetic test failed.
+	Passed          int  `json:"passed,omitempty"`
+	Failed          int  `json:"failed,omitempty"`
+	Skipped         int  `json:"skipped,omitempty"`
+	InvariantFailed bool `json:"invariantFailed,omitempty"`
+}
+
+// progressEventWriter writes each event as a line of json, so that a wrapping CI system can follow the suite
+// without parsing its log.  The events get a destination of their own, monitors and tests print to stdout.
+type progressEventWriter struct {
+	lock    sync.Mutex
+	encoder *json.Encoder
+	now     func() time.Time
+}
+
+func newProgressEventWriter(out io.Writer) *progressEventWriter {
+	encoder := json.NewEncoder(out)
+	// test names are not html
+	encoder.SetEscapeHTML(false)
+	return &progressEventWriter{
+		encoder: encoder,
+		now:     time.Now,
+	}
+}
+
+// createProgressEventWriter writes the events to filename, truncating it.  The file is closed by the caller once the
+// suite finished.
+func createProgressEventWriter(filename string) (*progressEventWriter, io.Closer, error) {
+	eventsFile, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to write the progress events: %w", err)
+	}
+	return newProgressEventWriter(eventsFile), eventsFile, nil
+}
+
+// Write stamps the event with the current time, unless it has one.  Errors are ignored, the events are best effort
+// and must never fail the suite.
+func (w *progressEventWriter) Write(event ProgressEvent) {
+	if w == nil {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = w.now()
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.encoder.Encode(event)
+}
+
+func (w *progressEventWriter) TestStarted(name string, start time.Time) {
+	w.Write(ProgressEvent{Type: TestStartedEvent, Time: start, Test: name})
+}
+
+func (w *progressEventWriter) TestFinished(name string, state TestState, start, end time.Time) {
+	w.Write(ProgressEvent{Type: TestFinishedEvent, Time: end, Test: name, Result: state, DurationSeconds: end.Sub(start).Seconds()})
+}
+
+// progressObservers tells each of the observers about the tests.
+type progressObservers []progressObserver
+
+func (observers progressObservers) TestStarted(name string, start time.Time) {
+	for _, observer := range observers {
+		observer.TestStarted(name, start)
+	}
+}
+
+func (observers progressObservers) TestFinished(name string, state TestState, start, end time.Time) {
+	for _, observer := range observers {
+		observer.TestFinished(name, state, start, end)
+	}
+}
@@ -0,0 +1,76 @@
+package ginkgo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressEventWriter(t *testing.T) {
+	now := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	out := &bytes.Buffer{}
+	events := newProgressEventWriter(out)
+	events.now = func() time.Time { return now }
+
+	var observer progressObserver = progressObservers{events}
+	events.Write(ProgressEvent{Type: SuiteStartedEvent, Suite: "openshift/conformance", ExpectedTests: 1})
+	observer.TestStarted("[sig-node] passes", now.Add(time.Second))
+	observer.TestFinished("[sig-node] passes", TestSucceeded, now.Add(time.Second), now.Add(3*time.Second))
+	events.Write(ProgressEvent{Type: MonitorStoppedEvent, MonitorResult: "Succeeded"})
+	events.Write(ProgressEvent{Type: SuiteFinishedEvent, Suite: "openshift/conformance", Passed: 1})
+
+	assert.Equal(t, `{"type":"SuiteStarted","time":"2024-03-01T10:00:00Z","suite":"openshift/conformance","expectedTests":1}
+{"type":"TestStarted","time":"2024-03-01T10:00:01Z","test":"[sig-node] passes"}
+{"type":"TestFinished","time":"2024-03-01T10:00:03Z","test":"[sig-node] passes","result":"Success","durationSeconds":2}
+{"type":"MonitorStopped","time":"2024-03-01T10:00:00Z","monitorResult":"Succeeded"}
+{"type":"SuiteFinished","time":"2024-03-01T10:00:00Z","suite":"openshift/conformance","passed":1}
+`, out.String())
+
+	var disabled *progressEventWriter
+	disabled.Write(ProgressEvent{Type: SuiteStartedEvent})
+}
+
+func TestProgressEventsFileOnlyHoldsEvents(t *testing.T) {
+	dir := t.TempDir()
+	stdout, err := os.Create(filepath.Join(dir, "stdout"))
+	require.NoError(t, err)
+	defer stdout.Close()
+	originalStdout := os.Stdout
+	os.Stdout = stdout
+	defer func() { os.Stdout = originalStdout }()
+
+	eventsFilename := filepath.Join(dir, "events.jsonl")
+	events, eventsFile, err := createProgressEventWriter(eventsFilename)
+	require.NoError(t, err)
+	events.Write(ProgressEvent{Type: MonitorStartedEvent})
+	// the monitor and its tests print to stdout while the suite runs.
+	fmt.Printf("All monitor tests started.\n")
+	events.Write(ProgressEvent{Type: SuiteStartedEvent, Suite: "openshift/conformance", ExpectedTests: 1})
+	fmt.Printf("Starting the pod network disruption sampler\n")
+	events.TestStarted("[sig-node] passes", time.Now())
+	require.NoError(t, eventsFile.Close())
+
+	eventsContent, err := os.ReadFile(eventsFilename)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(string(eventsContent), "\n"), "\n")
+	require.Len(t, lines, 3)
+	for _, line := range lines {
+		decoder := json.NewDecoder(strings.NewReader(line))
+		decoder.DisallowUnknownFields()
+		event := ProgressEvent{}
+		require.NoError(t, decoder.Decode(&event), "every line is an event: %s", line)
+		assert.NotEmpty(t, event.Type)
+	}
+
+	stdoutContent, err := os.ReadFile(stdout.Name())
+	require.NoError(t, err)
+	assert.Equal(t, "All monitor tests started.\nStarting the pod network disruption sampler\n", string(stdoutContent))
+}
@@ -0,0 +1,129 @@
+package ginkgo
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+)
+
+const quarantineSummaryTestName = "[sig-arch] quarantined tests summary"
+
+// QuarantinedTest identifies a known-flaky test by exact name or regular expression.  Quarantined tests still
+// run, but their failures are reported as flakes referencing the tracking issue instead of failing the suite.
+type QuarantinedTest struct {
+	Name              string `json:"name,omitempty"`
+	NameRegex         string `json:"nameRegex,omitempty"`
+	TrackingReference string `json:"trackingReference"`
+
+	nameRegex *regexp.Regexp
+}
+
+// QuarantinePolicy is the content of the file passed with --quarantine-file.
+type QuarantinePolicy struct {
+	Tests []QuarantinedTest `json:"tests"`
+}
+
+// LoadQuarantinePolicy reads a yaml or json quarantine policy.
+func LoadQuarantinePolicy(path string) (*QuarantinePolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	policy := &QuarantinePolicy{}
+	if err := yaml.Unmarshal(data, policy); err != nil {
+		return nil, fmt.Errorf("unable to parse quarantine file %q: %w", path, err)
+	}
+	for i := range policy.Tests {
+		entry := &policy.Tests[i]
+		if len(entry.TrackingReference) == 0 {
+			return nil, fmt.Errorf("quarantine entry %d must have a trackingReference", i)
+		}
+		switch {
+		case len(entry.Name) > 0 && len(entry.NameRegex) > 0:
+			return nil, fmt.Errorf("quarantine entry %d must set only one of name or nameRegex", i)
+		case len(entry.NameRegex) > 0:
+			if entry.nameRegex, err = regexp.Compile(entry.NameRegex); err != nil {
+				return nil, fmt.Errorf("quarantine entry %d has an invalid nameRegex: %w", i, err)
+			}
+		case len(entry.Name) == 0:
+			return nil, fmt.Errorf("quarantine entry %d must set one of name or nameRegex", i)
+		}
+	}
+	return policy, nil
+}
+
+// Match returns the quarantine entry for the test, or nil if the test is not quarantined.
+func (p *QuarantinePolicy) Match(testName string) *QuarantinedTest {
+	if p == nil {
+		return nil
+	}
+	for i := range p.Tests {
+		entry := &p.Tests[i]
+		if entry.Name == testName || (entry.nameRegex != nil && entry.nameRegex.MatchString(testName)) {
+			return entry
+		}
+	}
+	return nil
+}
+
+// quarantineOutcome records how a quarantined test did, so it can be summarized.
+type quarantineOutcome struct {
+	test  *testCase
+	entry *QuarantinedTest
+	// failed is true if the test failed and was downgraded to a flake.
+	failed bool
+}
+
+// applyQuarantine converts failures of quarantined tests into flakes.  The returned outcomes cover every
+// quarantined test that ran, regardless of result.
+func applyQuarantine(tests []*testCase, policy *QuarantinePolicy) []quarantineOutcome {
+	var outcomes []quarantineOutcome
+	for _, test := range tests {
+		entry := policy.Match(test.name)
+		if entry == nil {
+			continue
+		}
+		outcome := quarantineOutcome{test: test, entry: entry}
+		if test.failed {
+			outcome.failed = true
+			test.failed = false
+			test.flake = true
+			test.flakeReason = fmt.Sprintf("test is quarantined, failure is tracked by %s", entry.TrackingReference)
+		}
+		outcomes = append(outcomes, outcome)
+	}
+	return outcomes
+}
+
+// quarantineSummaryJUnit reports the outcome of every quarantined test.  It never fails, the individual tests
+// already report as flakes.
+func quarantineSummaryJUnit(outcomes []quarantineOutcome) []*junitapi.JUnitTestCase {
+	if len(outcomes) == 0 {
+		return nil
+	}
+	lines := []string{}
+	failures := 0
+	for _, outcome := range outcomes {
+		result := "passed"
+		switch {
+		case outcome.failed:
+			result = "failed"
+			failures++
+		case outcome.test.skipped:
+			result = "skipped"
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s (%s)", result, outcome.test.name, outcome.entry.TrackingReference))
+	}
+	return []*junitapi.JUnitTestCase{
+		{
+			Name: quarantineSummaryTestName,
+			SystemOut: fmt.Sprintf("%d quarantined tests ran, %d failed and were reported as flakes.\n\n%s",
+				len(outcomes), failures, strings.Join(lines, "\n")),
+		},
+	}
+}
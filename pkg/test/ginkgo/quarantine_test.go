@@ -0,0 +1,102 @@
+package ginkgo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadQuarantinePolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			content: `tests:
+- name: "[sig-node] exact test"
+  trackingReference: OCPBUGS-1
+- nameRegex: "\\[sig-network\\] flaky .*"
+  trackingReference: OCPBUGS-2
+`,
+		},
+		{
+			name: "missing tracking reference",
+			content: `tests:
+- name: "[sig-node] exact test"
+`,
+			wantErr: true,
+		},
+		{
+			name: "name and regex",
+			content: `tests:
+- name: "[sig-node] exact test"
+  nameRegex: "foo"
+  trackingReference: OCPBUGS-1
+`,
+			wantErr: true,
+		},
+		{
+			name: "invalid regex",
+			content: `tests:
+- nameRegex: "("
+  trackingReference: OCPBUGS-1
+`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "quarantine.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0644))
+			_, err := LoadQuarantinePolicy(path)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestApplyQuarantine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "quarantine.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`tests:
+- name: "[sig-node] exact test"
+  trackingReference: OCPBUGS-1
+- nameRegex: "^\\[sig-network\\] flaky"
+  trackingReference: OCPBUGS-2
+`), 0644))
+	policy, err := LoadQuarantinePolicy(path)
+	require.NoError(t, err)
+
+	exact := &testCase{name: "[sig-node] exact test", failed: true, testOutputBytes: []byte("setup\nfail [node.go:1]: timed out")}
+	regex := &testCase{name: "[sig-network] flaky service test", success: true}
+	other := &testCase{name: "[sig-apps] other test", failed: true}
+
+	outcomes := applyQuarantine([]*testCase{exact, regex, other}, policy)
+	require.Len(t, outcomes, 2)
+
+	assert.False(t, exact.failed)
+	assert.True(t, exact.flake)
+	assert.Contains(t, exact.flakeReason, "OCPBUGS-1")
+	suite := generateJUnitTestSuiteResults("openshift-tests", 0, []*testCase{exact})
+	require.Len(t, suite.TestCases, 2)
+	assert.Equal(t, "fail [node.go:1]: timed out", suite.TestCases[0].FailureOutput.Output, "the failure output is still the failure")
+	assert.Contains(t, suite.TestCases[0].SystemOut, "flake: test is quarantined, failure is tracked by OCPBUGS-1")
+	assert.True(t, outcomes[0].failed)
+	assert.False(t, outcomes[1].failed)
+	assert.True(t, other.failed, "tests outside the policy must be untouched")
+
+	summary := quarantineSummaryJUnit(outcomes)
+	require.Len(t, summary, 1)
+	assert.Nil(t, summary[0].FailureOutput)
+	assert.Contains(t, summary[0].SystemOut, "failed: [sig-node] exact test (OCPBUGS-1)")
+
+	assert.Empty(t, quarantineSummaryJUnit(nil))
+	assert.Nil(t, (*QuarantinePolicy)(nil).Match("anything"))
+}
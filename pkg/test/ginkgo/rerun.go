@@ -0,0 +1,106 @@
+package ginkgo
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+)
+
+// The junit suite properties that record how a run was set up, so that its failures can be rerun the same way.
+const (
+	SuiteProperty            = "Suite"
+	MonitorsProperty         = "Monitors"
+	DisabledMonitorsProperty = "DisabledMonitors"
+	ClusterStabilityProperty = "ClusterStability"
+)
+
+// runContextProperties records the suite and the monitor setup of the run.
+func runContextProperties(suite *TestSuite, o *GinkgoRunSuiteOptions) []*junitapi.TestSuiteProperty {
+	candidates := []struct {
+		name  string
+		value string
+	}{
+		{name: SuiteProperty, value: suite.Name},
+		{name: MonitorsProperty, value: strings.Join(o.ExactMonitorTests, ",")},
+		{name: DisabledMonitorsProperty, value: strings.Join(o.DisableMonitorTests, ",")},
+		{name: ClusterStabilityProperty, value: o.ClusterStabilityDuringTest},
+	}
+	properties := []*junitapi.TestSuiteProperty{}
+	for _, candidate := range candidates {
+		if len(candidate.value) == 0 {
+			continue
+		}
+		properties = append(properties, &junitapi.TestSuiteProperty{Name: candidate.name, Value: candidate.value})
+	}
+	return properties
+}
+
+// Rerun is what --rerun-from reads from the junit of a previous run: the tests that failed, and the suite and
+// monitors they ran with.
+type Rerun struct {
+	Suite               string
+	ExactMonitorTests   []string
+	DisableMonitorTests []string
+	ClusterStability    string
+
+	FailedTests sets.Set[string]
+}
+
+// LoadRerun reads the junit xml written by a previous run.  A test failed if it has a failure and no passing
+// result, flakes passed in the end and are not rerun.
+func LoadRerun(path string) (*Rerun, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	suite := &junitapi.JUnitTestSuite{}
+	if err := xml.Unmarshal(data, suite); err != nil {
+		return nil, fmt.Errorf("unable to parse junit %q: %w", path, err)
+	}
+	// the properties of the suite are written as property elements, which JUnitTestSuite does not read back.
+	properties := &struct {
+		Properties []*junitapi.TestSuiteProperty `xml:"property"`
+	}{}
+	if err := xml.Unmarshal(data, properties); err != nil {
+		return nil, fmt.Errorf("unable to parse junit %q: %w", path, err)
+	}
+
+	rerun := &Rerun{FailedTests: sets.New[string]()}
+	for _, property := range properties.Properties {
+		switch property.Name {
+		case SuiteProperty:
+			rerun.Suite = property.Value
+		case MonitorsProperty:
+			rerun.ExactMonitorTests = strings.Split(property.Value, ",")
+		case DisabledMonitorsProperty:
+			rerun.DisableMonitorTests = strings.Split(property.Value, ",")
+		case ClusterStabilityProperty:
+			rerun.ClusterStability = property.Value
+		}
+	}
+	passed := sets.New[string]()
+	for _, testCase := range suite.TestCases {
+		switch {
+		case testCase.FailureOutput != nil:
+			rerun.FailedTests.Insert(testCase.Name)
+		case testCase.SkipMessage == nil:
+			passed.Insert(testCase.Name)
+		}
+	}
+	rerun.FailedTests = rerun.FailedTests.Difference(passed)
+	if rerun.FailedTests.Len() == 0 {
+		return nil, fmt.Errorf("no tests failed in %q", path)
+	}
+	return rerun, nil
+}
+
+// Matches selects the tests that failed.  The monitor invariants that failed are not tests of the suite, they are
+// evaluated again by the monitors of the rerun.
+func (r *Rerun) Matches(name string) bool {
+	return r.FailedTests.Has(name)
+}
@@ -0,0 +1,53 @@
+package ginkgo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+)
+
+func TestLoadRerun(t *testing.T) {
+	tests := []*testCase{
+		{name: "[sig-node] failed", failed: true, testOutputBytes: []byte("fail [node.go:1]: boom")},
+		{name: "[sig-node] flaked", flake: true, testOutputBytes: []byte("flake: boom")},
+		{name: "[sig-node] passed", success: true},
+		{name: "[sig-node] skipped", skipped: true},
+	}
+	retried := tests[0].Retry()
+	retried.failed = true
+	tests = append(tests, retried)
+
+	o := &GinkgoRunSuiteOptions{
+		DisableMonitorTests:        []string{"etcd-object-growth", "quota-exhaustion"},
+		ClusterStabilityDuringTest: string(Disruptive),
+	}
+	suite := generateJUnitTestSuiteResults("openshift-tests", time.Hour, tests, &junitapi.JUnitTestCase{
+		Name:          "[sig-arch] invariant",
+		FailureOutput: &junitapi.FailureOutput{Output: "violated"},
+	})
+	suite.Properties = append(suite.Properties, runContextProperties(&TestSuite{Name: "openshift/conformance/parallel"}, o)...)
+	dir := t.TempDir()
+	require.NoError(t, writeJUnitReport(suite, "junit_e2e", "", dir, os.Stderr))
+
+	rerun, err := LoadRerun(filepath.Join(dir, "junit_e2e_.xml"))
+	require.NoError(t, err)
+	assert.Equal(t, "openshift/conformance/parallel", rerun.Suite)
+	assert.Empty(t, rerun.ExactMonitorTests)
+	assert.Equal(t, []string{"etcd-object-growth", "quota-exhaustion"}, rerun.DisableMonitorTests)
+	assert.Equal(t, "Disruptive", rerun.ClusterStability)
+	assert.Equal(t, sets.New("[sig-node] failed", "[sig-arch] invariant"), rerun.FailedTests, "flakes passed in the end")
+	assert.True(t, rerun.Matches("[sig-node] failed"))
+	assert.False(t, rerun.Matches("[sig-node] flaked"))
+
+	passing := generateJUnitTestSuiteResults("openshift-tests", time.Hour, tests[2:4])
+	require.NoError(t, writeJUnitReport(passing, "junit_e2e", "passing", dir, os.Stderr))
+	_, err = LoadRerun(filepath.Join(dir, "junit_e2e_passing.xml"))
+	assert.Error(t, err, "nothing to rerun")
+}
@@ -0,0 +1,107 @@
+package ginkgo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+)
+
+const (
+	// OutputFormatJUnit writes only junit xml, this is the default.
+	OutputFormatJUnit = "junit"
+	// OutputFormatJSON writes a structured results file in addition to the junit xml.
+	OutputFormatJSON = "json"
+)
+
+// labelRegex matches the bracketed labels embedded in test names, like [sig-node] or [Suite:openshift/conformance].
+var labelRegex = regexp.MustCompile(`\[[^\[\]]+\]`)
+
+// TestResults is the structured form of a suite run written by --output-format=json.
+type TestResults struct {
+	Suite      string            `json:"suite"`
+	StartTime  time.Time         `json:"startTime"`
+	EndTime    time.Time         `json:"endTime"`
+	Duration   float64           `json:"durationSeconds"`
+	Properties map[string]string `json:"properties,omitempty"`
+	Tests      []TestResult      `json:"tests"`
+}
+
+// TestResult is the outcome of a single attempt of a test.  Retried tests appear once per attempt.
+type TestResult struct {
+	Name   string   `json:"name"`
+	Labels []string `json:"labels,omitempty"`
+	Result string   `json:"result"`
+	// StartTime and EndTime are unset for tests that never ran.
+	StartTime     *time.Time `json:"startTime,omitempty"`
+	EndTime       *time.Time `json:"endTime,omitempty"`
+	Duration      float64    `json:"durationSeconds"`
+	Retry         bool       `json:"retry,omitempty"`
+	FailureOutput string     `json:"failureOutput,omitempty"`
+}
+
+// optionalTime returns nil for the zero time.
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func testResultFor(test *testCase) TestResult {
+	result := TestResult{
+		Name:      test.name,
+		Labels:    labelRegex.FindAllString(test.name, -1),
+		StartTime: optionalTime(test.start),
+		EndTime:   optionalTime(test.end),
+		Duration:  test.duration.Seconds(),
+		Retry:     test.previous != nil,
+	}
+	switch {
+	case test.skipped:
+		result.Result = "Skipped"
+	case test.failed:
+		result.Result = "Failed"
+		result.FailureOutput = lastLinesUntil(string(test.testOutputBytes), 100, "fail [")
+	case test.flake:
+		result.Result = "Flaked"
+		result.FailureOutput = flakeOutput(test)
+	case test.success:
+		result.Result = "Passed"
+	default:
+		result.Result = "Unknown"
+	}
+	return result
+}
+
+func generateTestResults(suite *junitapi.JUnitTestSuite, start, end time.Time, tests []*testCase) *TestResults {
+	results := &TestResults{
+		Suite:      suite.Name,
+		StartTime:  start,
+		EndTime:    end,
+		Duration:   suite.Duration,
+		Properties: map[string]string{},
+	}
+	for _, property := range suite.Properties {
+		results.Properties[property.Name] = property.Value
+	}
+	for _, test := range tests {
+		results.Tests = append(results.Tests, testResultFor(test))
+	}
+	return results
+}
+
+func writeJSONResults(results *TestResults, filePrefix, fileSuffix, dir string, errOut io.Writer) error {
+	out, err := json.MarshalIndent(results, "", "    ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%s_%s.json", filePrefix, fileSuffix))
+	fmt.Fprintf(errOut, "Writing JSON results to %s\n\n", path)
+	return os.WriteFile(path, out, 0640)
+}
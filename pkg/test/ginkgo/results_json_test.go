@@ -0,0 +1,52 @@
+package ginkgo
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateTestResults(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	failed := &testCase{
+		name:            "[sig-node] pods should work [Suite:openshift/conformance/parallel]",
+		start:           start,
+		end:             start.Add(time.Minute),
+		duration:        time.Minute,
+		testOutputBytes: []byte("some setup\nfail [foo.go:12]: it broke"),
+		failed:          true,
+	}
+	retried := failed.Retry()
+	retried.success = true
+
+	suite := &junitapi.JUnitTestSuite{
+		Name:       "openshift-tests",
+		Duration:   120,
+		Properties: []*junitapi.TestSuiteProperty{{Name: "ClusterPlatform", Value: "aws"}},
+	}
+	notRun := &testCase{name: "[sig-node] never ran", skipped: true}
+	results := generateTestResults(suite, start, start.Add(2*time.Minute), []*testCase{failed, retried, notRun})
+
+	assert.Equal(t, "openshift-tests", results.Suite)
+	assert.Equal(t, "aws", results.Properties["ClusterPlatform"])
+	require.Len(t, results.Tests, 3)
+
+	assert.Equal(t, "Failed", results.Tests[0].Result)
+	assert.Equal(t, []string{"[sig-node]", "[Suite:openshift/conformance/parallel]"}, results.Tests[0].Labels)
+	assert.Equal(t, "fail [foo.go:12]: it broke", results.Tests[0].FailureOutput)
+	assert.False(t, results.Tests[0].Retry)
+	assert.Equal(t, start, *results.Tests[0].StartTime)
+
+	assert.Equal(t, "Passed", results.Tests[1].Result)
+	assert.True(t, results.Tests[1].Retry)
+	assert.Empty(t, results.Tests[1].FailureOutput)
+
+	assert.Equal(t, "Skipped", results.Tests[2].Result)
+	out, err := json.Marshal(results.Tests[2])
+	require.NoError(t, err)
+	assert.NotContains(t, string(out), "startTime", "the times of tests that never ran are left out")
+}
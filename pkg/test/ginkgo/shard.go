@@ -0,0 +1,41 @@
+package ginkgo
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// validateShard ensures the shard flags describe a real partition.  A zero shardCount disables sharding.
+func validateShard(shardIndex, shardCount int) error {
+	switch {
+	case shardCount < 0:
+		return fmt.Errorf("--shard-count must not be negative, got %d", shardCount)
+	case shardCount == 0 && shardIndex != 0:
+		return fmt.Errorf("--shard-index requires --shard-count")
+	case shardCount > 0 && (shardIndex < 0 || shardIndex >= shardCount):
+		return fmt.Errorf("--shard-index must be between 0 and %d, got %d", shardCount-1, shardIndex)
+	}
+	return nil
+}
+
+// shardForTest assigns a test to a shard by hashing its name, so that every pod running the same suite
+// with the same shard count agrees on the partition without coordinating.
+func shardForTest(testName string, shardCount int) int {
+	h := fnv.New32a()
+	h.Write([]byte(testName))
+	return int(h.Sum32() % uint32(shardCount))
+}
+
+// shardTests returns the tests belonging to shardIndex of shardCount.  Order is preserved.
+func shardTests(tests []*testCase, shardIndex, shardCount int) []*testCase {
+	if shardCount <= 1 {
+		return tests
+	}
+	ret := make([]*testCase, 0, len(tests)/shardCount+1)
+	for _, test := range tests {
+		if shardForTest(test.name, shardCount) == shardIndex {
+			ret = append(ret, test)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,43 @@
+package ginkgo
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardTests(t *testing.T) {
+	tests := []*testCase{}
+	for i := 0; i < 200; i++ {
+		tests = append(tests, &testCase{name: fmt.Sprintf("[sig-test] test %d", i)})
+	}
+
+	seen := map[string]int{}
+	for shard := 0; shard < 3; shard++ {
+		sharded := shardTests(tests, shard, 3)
+		assert.NotEmpty(t, sharded)
+		for _, test := range sharded {
+			seen[test.name]++
+		}
+		// the partition must be stable across invocations
+		assert.Equal(t, sharded, shardTests(tests, shard, 3))
+	}
+	require.Len(t, seen, len(tests))
+	for name, count := range seen {
+		assert.Equal(t, 1, count, "test %q assigned to more than one shard", name)
+	}
+
+	assert.Equal(t, tests, shardTests(tests, 0, 1))
+	assert.Equal(t, tests, shardTests(tests, 0, 0))
+}
+
+func TestValidateShard(t *testing.T) {
+	assert.NoError(t, validateShard(0, 0))
+	assert.NoError(t, validateShard(2, 3))
+	assert.Error(t, validateShard(3, 3))
+	assert.Error(t, validateShard(-1, 3))
+	assert.Error(t, validateShard(1, 0))
+	assert.Error(t, validateShard(0, -1))
+}
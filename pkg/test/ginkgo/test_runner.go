@@ -8,6 +8,7 @@ import (
 	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"sync"
 	"syscall"
@@ -55,6 +56,11 @@ func (r *testSuiteRunnerImpl) RunOneTest(ctx context.Context, test *testCase) {
 	defer r.testSuiteProgress.TestEnded(test.name, testRunResult)
 	defer recordTestResultInLogWithoutOverlap(testRunResult, r.testOutput.testOutputLock, r.testOutput.out, r.testOutput.includeSuccessfulOutput)
 
+	if r.testOutput.progress != nil {
+		r.testOutput.progress.TestStarted(test.name, time.Now())
+		defer recordTestResultInProgress(testRunResult, r.testOutput.progress)
+	}
+
 	testRunResult.testRunResult = r.commandContext.RunTestInNewProcess(ctx, test)
 	mutateTestCaseWithResults(test, testRunResult)
 }
@@ -71,6 +77,8 @@ func mutateTestCaseWithResults(test *testCase, testRunResult *testRunResultHandl
 	test.duration = duration
 
 	test.testOutputBytes = testRunResult.testOutputBytes
+	test.cpuTime = testRunResult.cpuTime
+	test.peakRSSBytes = testRunResult.peakRSSBytes
 
 	switch testRunResult.testState {
 	case TestFlaked:
@@ -117,6 +125,8 @@ func mutateTestCaseWithResults(test *testCase, testRunResult *testRunResultHandl
 type commandContext struct {
 	env     []string
 	timeout time.Duration
+	// extensionArtifactDir holds a directory per extension where the tests of extension binaries write artifacts.
+	extensionArtifactDir string
 
 	testOutputConfig testOutputConfig
 }
@@ -125,6 +135,8 @@ type testOutputConfig struct {
 	testOutputLock  *sync.Mutex
 	out             io.Writer
 	monitorRecorder monitorapi.Recorder
+	// progress, if set, is told about each test as it starts and finishes.
+	progress progressObserver
 
 	includeSuccessfulOutput bool
 }
@@ -139,6 +151,9 @@ type testRunResult struct {
 	end             time.Time
 	testState       TestState
 	testOutputBytes []byte
+	// cpuTime and peakRSSBytes are the resources used by the process that ran the test.
+	cpuTime      time.Duration
+	peakRSSBytes int64
 }
 
 func (r testRunResult) duration() time.Duration {
@@ -173,11 +188,12 @@ func isTestFailed(testState TestState) bool {
 }
 
 // testOutputLock prevents parallel tests from interleaving their output.
-func newTestOutputConfig(testOutputLock *sync.Mutex, out io.Writer, monitorRecorder monitorapi.Recorder, includeSuccessfulOutput bool) testOutputConfig {
+func newTestOutputConfig(testOutputLock *sync.Mutex, out io.Writer, monitorRecorder monitorapi.Recorder, progress progressObserver, includeSuccessfulOutput bool) testOutputConfig {
 	return testOutputConfig{
 		testOutputLock:          testOutputLock,
 		out:                     out,
 		monitorRecorder:         monitorRecorder,
+		progress:                progress,
 		includeSuccessfulOutput: includeSuccessfulOutput,
 	}
 }
@@ -214,6 +230,10 @@ func (c *commandContext) extractCommands(test *testCase) (string, string) {
 	return testBinary, testName
 }
 
+func recordTestResultInProgress(testRunResult *testRunResultHandle, progress progressObserver) {
+	progress.TestFinished(testRunResult.name, testRunResult.testState, testRunResult.start, testRunResult.end)
+}
+
 func recordTestResultInLogWithoutOverlap(testRunResult *testRunResultHandle, testOutputLock *sync.Mutex, out io.Writer, includeSuccessfulOutput bool) {
 	testOutputLock.Lock()
 	defer testOutputLock.Unlock()
@@ -308,6 +328,12 @@ func (c *commandContext) RunTestInNewProcess(ctx context.Context, test *testCase
 	testBinary, testName := c.extractCommands(test)
 	command := exec.Command(testBinary, "run-test", testName)
 	command.Env = append(os.Environ(), updateEnvVars(c.env)...)
+	if len(test.extension) > 0 && len(c.extensionArtifactDir) > 0 {
+		dir := filepath.Join(c.extensionArtifactDir, test.extension)
+		if err := os.MkdirAll(dir, 0755); err == nil {
+			command.Env = append(command.Env, fmt.Sprintf("%s=%s", extensionArtifactDirEnv, dir))
+		}
+	}
 
 	timeout := c.timeout
 	if test.testTimeout != 0 {
@@ -316,6 +342,7 @@ func (c *commandContext) RunTestInNewProcess(ctx context.Context, test *testCase
 
 	testOutputBytes, err := runWithTimeout(ctx, command, timeout)
 	ret.end = time.Now()
+	ret.cpuTime, ret.peakRSSBytes = processUsage(command.ProcessState)
 
 	ret.testOutputBytes = testOutputBytes
 	if err == nil {
@@ -352,6 +379,19 @@ func (c *commandContext) RunTestInNewProcess(ctx context.Context, test *testCase
 	return ret
 }
 
+// processUsage returns the user and system CPU time and the peak resident set size of an exited process.
+func processUsage(state *os.ProcessState) (time.Duration, int64) {
+	if state == nil {
+		return 0, 0
+	}
+	rusage, ok := state.SysUsage().(*syscall.Rusage)
+	if !ok {
+		return state.UserTime() + state.SystemTime(), 0
+	}
+	// Maxrss is in kilobytes on linux.
+	return state.UserTime() + state.SystemTime(), int64(rusage.Maxrss) * 1024
+}
+
 func updateEnvVars(envs []string) []string {
 	result := []string{}
 	for _, env := range envs {
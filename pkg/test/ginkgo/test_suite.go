@@ -75,8 +75,10 @@ type testCase struct {
 	rawName string
 	// binaryName is the name of the external binary
 	binaryName string
-	spec       types.TestSpec
-	locations  []types.CodeLocation
+	// extension is the name of the extension the external binary was listed in
+	extension string
+	spec      types.TestSpec
+	locations []types.CodeLocation
 
 	// identifies which tests can be run in parallel (ginkgo runs suites linearly)
 	testExclusion string
@@ -88,6 +90,14 @@ type testCase struct {
 	end             time.Time
 	duration        time.Duration
 	testOutputBytes []byte
+	// cpuTime and peakRSSBytes are the resources used by the process that ran the test.
+	cpuTime      time.Duration
+	peakRSSBytes int64
+	// overlappingIntervals summarizes the Warning and Error intervals observed while a failed test was running.
+	overlappingIntervals string
+	// flakeReason explains why a failure is reported as a flake, it is kept out of the output so that the failure
+	// output of the junit is still the failure.
+	flakeReason string
 
 	flake    bool
 	failed   bool
@@ -104,6 +114,7 @@ func (t *testCase) Retry() *testCase {
 		spec:          t.spec,
 		rawName:       t.rawName,
 		binaryName:    t.binaryName,
+		extension:     t.extension,
 		locations:     t.locations,
 		testExclusion: t.testExclusion,
 
@@ -129,6 +140,8 @@ type TestSuite struct {
 	Description string
 
 	Matches TestMatchFunc
+	// SkipFns report tests that match the suite but cannot run in the current environment.
+	SkipFns []TestSkipFunc
 
 	// The number of times to execute each test in this suite.
 	Count int
@@ -140,6 +153,10 @@ type TestSuite struct {
 	ClusterStabilityDuringTest ClusterStabilityDuringTest
 
 	TestTimeout time.Duration
+
+	// MonitorOnlyDuration, when set, makes the suite run no tests.  The monitor tests observe the cluster for this
+	// long, or for --duration, and then evaluate the invariants.
+	MonitorOnlyDuration time.Duration
 }
 
 type TestMatchFunc func(name string) bool
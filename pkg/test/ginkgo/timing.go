@@ -0,0 +1,158 @@
+package ginkgo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+)
+
+const testRuntimeRegressionTestName = "[sig-trt] test runtimes should not regress beyond their historical norms"
+
+const (
+	// runtimeRegressionFactor and runtimeRegressionMinimum are how much slower than its historical P95 a test must be
+	// to count as regressed.  The minimum keeps the noise of short tests out.
+	runtimeRegressionFactor  = 1.5
+	runtimeRegressionMinimum = time.Minute
+)
+
+// TestTiming is the resource usage of one attempt of a test, written to the test-timing artifact.
+type TestTiming struct {
+	Name            string    `json:"name"`
+	Result          string    `json:"result"`
+	StartTime       time.Time `json:"startTime,omitempty"`
+	DurationSeconds float64   `json:"durationSeconds"`
+	CPUSeconds      float64   `json:"cpuSeconds"`
+	PeakRSSBytes    int64     `json:"peakRSSBytes"`
+	Retry           bool      `json:"retry,omitempty"`
+}
+
+// TestTimingReport is the content of the test-timing artifact.
+type TestTimingReport struct {
+	Tests       []TestTiming        `json:"tests"`
+	Regressions []RuntimeRegression `json:"regressions,omitempty"`
+}
+
+// RuntimeRegression is a test that took much longer than its historical P95.
+type RuntimeRegression struct {
+	Name                 string  `json:"name"`
+	DurationSeconds      float64 `json:"durationSeconds"`
+	HistoricalP95Seconds float64 `json:"historicalP95Seconds"`
+}
+
+// HistoricalTestDurations is the content of the file passed with --historical-test-durations-file, for instance:
+//
+//	{"tests": {"[sig-node] pods should run": {"p95Seconds": 42.5}}}
+type HistoricalTestDurations struct {
+	Tests map[string]HistoricalTestDuration `json:"tests"`
+}
+
+type HistoricalTestDuration struct {
+	P95Seconds float64 `json:"p95Seconds"`
+}
+
+// LoadHistoricalTestDurations reads the historical runtimes tests are compared against.
+func LoadHistoricalTestDurations(path string) (*HistoricalTestDurations, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	durations := &HistoricalTestDurations{}
+	if err := json.Unmarshal(data, durations); err != nil {
+		return nil, fmt.Errorf("unable to parse historical test durations %q: %w", path, err)
+	}
+	return durations, nil
+}
+
+func testTimingFor(test *testCase) TestTiming {
+	timing := TestTiming{
+		Name:            test.name,
+		StartTime:       test.start,
+		DurationSeconds: test.duration.Seconds(),
+		CPUSeconds:      test.cpuTime.Seconds(),
+		PeakRSSBytes:    test.peakRSSBytes,
+		Retry:           test.previous != nil,
+	}
+	switch {
+	case test.skipped:
+		timing.Result = "Skipped"
+	case test.failed:
+		timing.Result = "Failed"
+	case test.flake:
+		timing.Result = "Flaked"
+	default:
+		timing.Result = "Passed"
+	}
+	return timing
+}
+
+// generateTestTimingReport records the resource usage of every test that ran, and compares the runtime of the tests
+// that passed with their history.
+func generateTestTimingReport(tests []*testCase, history *HistoricalTestDurations) *TestTimingReport {
+	report := &TestTimingReport{Tests: []TestTiming{}}
+	for _, test := range tests {
+		if test.start.IsZero() {
+			continue
+		}
+		report.Tests = append(report.Tests, testTimingFor(test))
+		if history == nil || !test.success {
+			continue
+		}
+		historical, ok := history.Tests[test.name]
+		if !ok || historical.P95Seconds <= 0 {
+			continue
+		}
+		p95 := time.Duration(historical.P95Seconds * float64(time.Second))
+		if test.duration > time.Duration(float64(p95)*runtimeRegressionFactor) && test.duration-p95 > runtimeRegressionMinimum {
+			report.Regressions = append(report.Regressions, RuntimeRegression{
+				Name:                 test.name,
+				DurationSeconds:      test.duration.Seconds(),
+				HistoricalP95Seconds: historical.P95Seconds,
+			})
+		}
+	}
+	sort.Slice(report.Regressions, func(i, j int) bool {
+		return report.Regressions[i].Name < report.Regressions[j].Name
+	})
+	return report
+}
+
+// testRuntimeRegressionJUnit reports the tests that ran much longer than they used to.  Without history there is
+// nothing to compare with and no result.
+func testRuntimeRegressionJUnit(report *TestTimingReport, history *HistoricalTestDurations) []*junitapi.JUnitTestCase {
+	if history == nil {
+		return nil
+	}
+	if len(report.Regressions) == 0 {
+		return []*junitapi.JUnitTestCase{{Name: testRuntimeRegressionTestName}}
+	}
+	lines := []string{}
+	for _, regression := range report.Regressions {
+		lines = append(lines, fmt.Sprintf("%s took %.0fs, historically %.0fs at the P95", regression.Name, regression.DurationSeconds, regression.HistoricalP95Seconds))
+	}
+	failure := &junitapi.JUnitTestCase{
+		Name: testRuntimeRegressionTestName,
+		FailureOutput: &junitapi.FailureOutput{
+			Output: fmt.Sprintf("%d tests took more than %.1f times and %s longer than their historical P95:\n\n%s",
+				len(lines), runtimeRegressionFactor, runtimeRegressionMinimum, strings.Join(lines, "\n")),
+		},
+	}
+	// runtimes depend on the cluster under test, only flake.
+	return []*junitapi.JUnitTestCase{failure, {Name: testRuntimeRegressionTestName}}
+}
+
+func writeTestTimingReport(report *TestTimingReport, fileSuffix, dir string, errOut io.Writer) error {
+	out, err := json.MarshalIndent(report, "", "    ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("test-timing%s.json", fileSuffix))
+	fmt.Fprintf(errOut, "Writing test timing to %s\n", path)
+	return os.WriteFile(path, out, 0640)
+}
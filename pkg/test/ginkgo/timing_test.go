@@ -0,0 +1,65 @@
+package ginkgo
+
+import (
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateTestTimingReport(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	test := func(name string, duration time.Duration) *testCase {
+		return &testCase{
+			name:         name,
+			start:        start,
+			end:          start.Add(duration),
+			duration:     duration,
+			cpuTime:      duration / 10,
+			peakRSSBytes: 64 << 20,
+			success:      true,
+		}
+	}
+	slow := test("[sig-node] slow", 10*time.Minute)
+	slightlySlow := test("[sig-node] slightly slow", 100*time.Second)
+	fast := test("[sig-node] fast", time.Minute)
+	failed := test("[sig-node] failed", 20*time.Minute)
+	failed.success, failed.failed = false, true
+	notRun := &testCase{name: "[sig-node] not run", skipped: true}
+
+	history := &HistoricalTestDurations{Tests: map[string]HistoricalTestDuration{
+		"[sig-node] slow":          {P95Seconds: 300},
+		"[sig-node] slightly slow": {P95Seconds: 60},
+		"[sig-node] fast":          {P95Seconds: 60},
+		"[sig-node] failed":        {P95Seconds: 60},
+	}}
+	report := generateTestTimingReport([]*testCase{slow, slightlySlow, fast, failed, notRun}, history)
+
+	require.Len(t, report.Tests, 4, "tests that never started have no timing")
+	assert.Equal(t, 60.0, report.Tests[0].CPUSeconds)
+	assert.Equal(t, int64(64<<20), report.Tests[0].PeakRSSBytes)
+	assert.Equal(t, "Failed", report.Tests[3].Result)
+
+	require.Len(t, report.Regressions, 1, "short regressions and failed tests are not runtime regressions")
+	assert.Equal(t, "[sig-node] slow", report.Regressions[0].Name)
+
+	junits := testRuntimeRegressionJUnit(report, history)
+	require.Len(t, junits, 2, "regressions are reported as flakes")
+	assert.Contains(t, junits[0].FailureOutput.Output, "[sig-node] slow took 600s, historically 300s")
+	assert.Nil(t, junits[1].FailureOutput)
+
+	assert.Empty(t, testRuntimeRegressionJUnit(generateTestTimingReport([]*testCase{slow}, nil), nil), "no history, no result")
+}
+
+func TestProcessUsage(t *testing.T) {
+	command := exec.Command("sh", "-c", "true")
+	require.NoError(t, command.Run())
+	_, peakRSSBytes := processUsage(command.ProcessState)
+	assert.Greater(t, peakRSSBytes, int64(0))
+
+	cpuTime, peakRSSBytes := processUsage(nil)
+	assert.Zero(t, cpuTime)
+	assert.Zero(t, peakRSSBytes)
+}
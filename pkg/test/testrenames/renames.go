@@ -0,0 +1,147 @@
+// Package testrenames maps the old names of renamed tests to their current names, so that a renamed test keeps the
+// history recorded under its previous names instead of appearing as a brand-new test.
+package testrenames
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+)
+
+// PreviousNameProperty is the junit property listing a name a test was previously reported under, once per name.
+const PreviousNameProperty = "PreviousName"
+
+// Rename records that the test named From is now named To.
+type Rename struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Reference optionally links the change that renamed the test.
+	Reference string `json:"reference,omitempty"`
+}
+
+// Config is the content of the file passed with --test-renames-file, for instance:
+//
+//	renames:
+//	- from: "[sig-node] pods should run"
+//	  to: "[sig-node][Jira:Node] pods should run"
+type Config struct {
+	Renames []Rename `json:"renames"`
+}
+
+// Renames resolves chains of renames, a test renamed from A to B and later from B to C has the current name C and the
+// previous names B and A.
+type Renames struct {
+	current  map[string]string
+	previous map[string][]string
+}
+
+// LoadRenames reads a yaml or json rename file.
+func LoadRenames(path string) (*Renames, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config := &Config{}
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return nil, fmt.Errorf("unable to parse test renames %q: %w", path, err)
+	}
+	renames, err := NewRenames(config.Renames)
+	if err != nil {
+		return nil, fmt.Errorf("invalid test renames %q: %w", path, err)
+	}
+	return renames, nil
+}
+
+// NewRenames validates the renames, each test may only be renamed once and renames may not loop.
+func NewRenames(renames []Rename) (*Renames, error) {
+	next := map[string]string{}
+	for i, rename := range renames {
+		switch {
+		case len(rename.From) == 0 || len(rename.To) == 0:
+			return nil, fmt.Errorf("rename %d must set from and to", i)
+		case rename.From == rename.To:
+			return nil, fmt.Errorf("rename %d renames %q to itself", i, rename.From)
+		}
+		if to, ok := next[rename.From]; ok {
+			return nil, fmt.Errorf("%q is renamed to both %q and %q", rename.From, to, rename.To)
+		}
+		next[rename.From] = rename.To
+	}
+
+	ret := &Renames{current: map[string]string{}, previous: map[string][]string{}}
+	for from := range next {
+		current := from
+		for steps := 0; ; steps++ {
+			to, ok := next[current]
+			if !ok {
+				break
+			}
+			if steps == len(next) {
+				return nil, fmt.Errorf("the renames of %q loop", from)
+			}
+			current = to
+		}
+		ret.current[from] = current
+	}
+	from := map[string][]string{}
+	for old, to := range next {
+		from[to] = append(from[to], old)
+	}
+	for _, olds := range from {
+		sort.Strings(olds)
+	}
+	for _, current := range ret.current {
+		ret.previous[current] = previousNames(current, from)
+	}
+	return ret, nil
+}
+
+// previousNames walks back from name, each name is followed by its own previous names.  A name can be the target of
+// several renames when tests are merged.
+func previousNames(name string, from map[string][]string) []string {
+	ret := []string{}
+	for _, old := range from[name] {
+		ret = append(ret, old)
+		ret = append(ret, previousNames(old, from)...)
+	}
+	return ret
+}
+
+// CurrentName returns the name a test is reported under now, which is name itself unless it was renamed.
+func (r *Renames) CurrentName(name string) string {
+	if r == nil {
+		return name
+	}
+	if current, ok := r.current[name]; ok {
+		return current
+	}
+	return name
+}
+
+// PreviousNames returns the names the test was reported under before, most recent first.
+func (r *Renames) PreviousNames(name string) []string {
+	if r == nil {
+		return nil
+	}
+	return r.previous[name]
+}
+
+// AddPreviousNameProperties adds a PreviousName property to every test case that was renamed, so whatever reads the
+// junit can join its results with those recorded under the old names.
+func (r *Renames) AddPreviousNameProperties(suite *junitapi.JUnitTestSuite) {
+	if r == nil {
+		return
+	}
+	for _, testCase := range suite.TestCases {
+		for _, previous := range r.PreviousNames(testCase.Name) {
+			testCase.Properties = append(testCase.Properties, &junitapi.TestSuiteProperty{Name: PreviousNameProperty, Value: previous})
+		}
+	}
+	for _, child := range suite.Children {
+		r.AddPreviousNameProperties(child)
+	}
+}
@@ -0,0 +1,79 @@
+package testrenames
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/origin/pkg/test/ginkgo/junitapi"
+)
+
+func TestRenames(t *testing.T) {
+	renames, err := NewRenames([]Rename{
+		{From: "b", To: "c"},
+		{From: "a", To: "b"},
+		{From: "merged", To: "c"},
+		{From: "x", To: "y"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "c", renames.CurrentName("a"))
+	assert.Equal(t, "c", renames.CurrentName("b"))
+	assert.Equal(t, "c", renames.CurrentName("c"))
+	assert.Equal(t, "unrelated", renames.CurrentName("unrelated"))
+	assert.Equal(t, []string{"b", "a", "merged"}, renames.PreviousNames("c"))
+	assert.Equal(t, []string{"x"}, renames.PreviousNames("y"))
+	assert.Empty(t, renames.PreviousNames("a"))
+
+	var none *Renames
+	assert.Equal(t, "a", none.CurrentName("a"))
+	assert.Empty(t, none.PreviousNames("c"))
+}
+
+func TestNewRenamesInvalid(t *testing.T) {
+	tests := map[string][]Rename{
+		"missing to":    {{From: "a"}},
+		"to itself":     {{From: "a", To: "a"}},
+		"renamed twice": {{From: "a", To: "b"}, {From: "a", To: "c"}},
+		"loop":          {{From: "a", To: "b"}, {From: "b", To: "c"}, {From: "c", To: "a"}},
+	}
+	for name, renames := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewRenames(renames)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLoadRenames(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "renames.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`renames:
+- from: "[sig-node] pods should run"
+  to: "[sig-node][Jira:Node] pods should run"
+  reference: https://github.com/openshift/origin/pull/1
+`), 0644))
+	renames, err := LoadRenames(path)
+	require.NoError(t, err)
+	assert.Equal(t, "[sig-node][Jira:Node] pods should run", renames.CurrentName("[sig-node] pods should run"))
+
+	require.NoError(t, os.WriteFile(path, []byte("renames:\n- form: a\n  to: b\n"), 0644))
+	_, err = LoadRenames(path)
+	assert.Error(t, err, "unknown fields are rejected")
+}
+
+func TestAddPreviousNameProperties(t *testing.T) {
+	renames, err := NewRenames([]Rename{{From: "old", To: "new"}})
+	require.NoError(t, err)
+	suite := &junitapi.JUnitTestSuite{
+		TestCases: []*junitapi.JUnitTestCase{{Name: "new"}, {Name: "other"}},
+		Children:  []*junitapi.JUnitTestSuite{{TestCases: []*junitapi.JUnitTestCase{{Name: "new"}}}},
+	}
+	renames.AddPreviousNameProperties(suite)
+
+	assert.Equal(t, []*junitapi.TestSuiteProperty{{Name: PreviousNameProperty, Value: "old"}}, suite.TestCases[0].Properties)
+	assert.Empty(t, suite.TestCases[1].Properties)
+	assert.Len(t, suite.Children[0].TestCases[0].Properties, 1)
+}
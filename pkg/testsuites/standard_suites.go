@@ -397,6 +397,17 @@ var staticSuites = []ginkgo.TestSuite{
 		Parallelism:                1,
 		ClusterStabilityDuringTest: ginkgo.Stable,
 	},
+	{
+		Name: "openshift/monitoring-only",
+		Description: templates.LongDesc(`
+		Runs no tests.  The monitor tests observe the cluster for --duration, one hour by default, and then evaluate
+		the invariants.  Useful to validate existing clusters and to soak test without conformance tests running.
+		`),
+		Matches: func(name string) bool {
+			return false
+		},
+		MonitorOnlyDuration: time.Hour,
+	},
 	{
 		Name: "openshift/nodes/realtime",
 		Description: templates.LongDesc(`
@@ -74,3 +74,19 @@ func TestSkippedUntil(t *testing.T) {
 		})
 	}
 }
+
+func TestMonitoringOnlySuite(t *testing.T) {
+	for _, suite := range StandardTestSuites() {
+		if suite.Name != "openshift/monitoring-only" {
+			continue
+		}
+		if suite.MonitorOnlyDuration != time.Hour {
+			t.Errorf("expected the suite to monitor for an hour by default, got %s", suite.MonitorOnlyDuration)
+		}
+		if suite.Matches("[sig-node] pods should run [Suite:openshift/conformance/parallel]") {
+			t.Errorf("expected the suite to run no tests")
+		}
+		return
+	}
+	t.Fatalf("openshift/monitoring-only is not registered")
+}
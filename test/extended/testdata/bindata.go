@@ -53213,10 +53213,30 @@ var _e2echartE2eChartTemplateHtml = []byte(`<html lang="en">
 
 <div id="search" class="form-control-lg">
     <form>
-        <input class="form-control" type="text" id="filterInput" placeholder="RegExp Filter">
+        <div class="form-row">
+            <div class="col-md-4">
+                <input class="chart-filter form-control" type="text" id="filterInput" placeholder="RegExp Filter">
+            </div>
+            <div class="col-md-3">
+                <input class="chart-filter form-control" type="text" id="locatorSearch" placeholder="Locator Search">
+            </div>
+            <div class="col-md-2">
+                <input class="chart-filter form-control" type="text" id="namespaceFilter" placeholder="Namespace">
+            </div>
+            <div class="col-md-2">
+                <input class="chart-filter form-control" type="text" id="nodeFilter" placeholder="Node">
+            </div>
+            <div class="col-md-1">
+                <select class="chart-filter form-control" id="sourceFilter">
+                    <option value="" selected=true>Source</option>
+                </select>
+            </div>
+        </div>
     </form>
 </div>
 
+<div id="summary" class="container"></div>
+
 <div id="chart"></div>
 
 <div class="modal" id="myModal" tabindex="-1" role="dialog">
@@ -53242,24 +53262,55 @@ var _e2echartE2eChartTemplateHtml = []byte(`<html lang="en">
     var eventIntervals = EVENT_INTERVAL_JSON_GOES_HERE
 </script>
 
+<script type="application/json" id="timeline-summary">EVENT_INTERVAL_SUMMARY_GOES_HERE</script>
+
 <script>
-    // Re-render the chart with input as a regexp. Timeout for event debouncing.
-    $('#filterInput').on('input', (e) => {
+    // the sources are the ones present in this chart, so every option matches something.
+    Array.from(new Set(eventIntervals.items.map((item) => item.source).filter((source) => source))).sort().forEach((source) => {
+        $('#sourceFilter').append($('<option>').val(source).text(source))
+    });
+
+    // Re-render the chart with the filters, the RegExp is matched against the row label. Timeout for event debouncing.
+    $('.chart-filter').on('input change', (e) => {
         var $this = $(this);
         clearTimeout($this.data('timeout'));
         $this.data('timeout', setTimeout(() => {
             document.getElementById("chart").innerHTML = "";
-            renderChart(new RegExp(e.target.value))
+            var regexStr = $('#filterInput').val()
+            renderChart(regexStr.length > 0 ? new RegExp(regexStr) : null)
         }, 250));
     });
 
     // Prevent page refresh from pressing enter in input box
-    $('#filterInput').keypress((e) => {
+    $('.chart-filter').keypress((e) => {
         if (event.which == '13') {
             event.preventDefault();
         }
     });
 
+    // matchesLocatorFilters returns true when the interval matches every locator filter that is set.  Locator search
+    // is a case-insensitive substring match of the whole locator, namespace and node match any part of the key.
+    function matchesLocatorFilters(item) {
+        var locatorSearch = $('#locatorSearch').val().toLowerCase()
+        var namespace = $('#namespaceFilter').val()
+        var node = $('#nodeFilter').val()
+        var source = $('#sourceFilter').val()
+        var keys = item.locator.keys || {}
+        if (locatorSearch.length > 0 && !buildLocatorDisplayString(item.locator).toLowerCase().includes(locatorSearch)) {
+            return false
+        }
+        if (namespace.length > 0 && !(keys.namespace && keys.namespace.includes(namespace))) {
+            return false
+        }
+        if (node.length > 0 && !(keys.node && keys.node.includes(node))) {
+            return false
+        }
+        if (source.length > 0 && item.source !== source) {
+            return false
+        }
+        return true
+    }
+
     function isOperatorAvailable(eventInterval) {
         return eventInterval.locator.type === "ClusterOperator" &&
             eventInterval.message.annotations["condition"] === "Available" &&
@@ -53339,6 +53390,42 @@ var _e2echartE2eChartTemplateHtml = []byte(`<html lang="en">
         return false
     }
 
+    // Networking groups the cluster network intervals that otherwise get lost across the pod and event rows: OVN/SDN
+    // and CNI failures, pod sandbox creation, and the pod network connectivity probes.
+    function isNetworking(eventInterval) {
+        const networkingNamespaces = ["openshift-ovn-kubernetes", "openshift-sdn", "openshift-multus", "openshift-network-operator", "openshift-network-diagnostics", "openshift-network-node-identity"]
+        const networkingReasons = ["FailedCreatePodSandBox", "NetworkNotReady", "ErrorAddingLogicalPort", "ErrorAddingResource", "ErrorUpdatingResource"]
+        const networkingMessageRegex = /\bCNI\b|pod sandbox|network plugin|multus|ovn-kubernetes|openshift-sdn/i
+
+        if (eventInterval.source === "OVSVswitchdLog" || eventInterval.source === "NetworkMangerLog") {
+            return true
+        }
+        if (eventInterval.source === "Disruption") {
+            // the poller deployments prefix their backends with where they connect from and to.
+            const backend = eventInterval.locator.keys["backend-disruption-name"] || ""
+            return (backend.startsWith("pod-to-") || backend.startsWith("host-to-")) &&
+                (eventInterval.message.reason === "DisruptionBegan" || eventInterval.message.reason === "DisruptionSamplerOutageBegan")
+        }
+        if (eventInterval.source !== "KubeEvent" && eventInterval.source !== "PodLog") {
+            return false
+        }
+        if (networkingNamespaces.includes(eventInterval.locator.keys["namespace"])) {
+            return true
+        }
+        if (networkingReasons.includes(eventInterval.message.reason)) {
+            return true
+        }
+        return networkingMessageRegex.test(eventInterval.message.humanMessage || "")
+    }
+
+    function networkingValue(item) {
+        if (item.source === "Disruption") {
+            return disruptionValue(item)
+        }
+        const sub = item.message.reason ? ` + "`" + ` (${item.message.reason})` + "`" + ` : ` + "`" + ` (${item.source})` + "`" + `
+        return [buildLocatorDisplayString(item.locator), sub, "Networking" + item.level]
+    }
+
     function isNodeState(eventInterval) {
         return eventInterval.source === "NodeState"
     }
@@ -53574,7 +53661,7 @@ var _e2echartE2eChartTemplateHtml = []byte(`<html lang="en">
             new Date(now.getTime() - 1),
         );
         rawEventIntervals.items.forEach((item) => {
-            if (!preconditionFunc(item)) {
+            if (!preconditionFunc(item) || !matchesLocatorFilters(item)) {
                 return
             }
             var startDate = new Date(item.from)
@@ -53655,7 +53742,10 @@ var _e2echartE2eChartTemplateHtml = []byte(`<html lang="en">
         })
 
         timelineGroups.push({group: "disruption", data: []})
-        createTimelineData(disruptionValue, timelineGroups[timelineGroups.length - 1].data, eventIntervals, isEndpointConnectivity, regex)
+        createTimelineData(disruptionValue, timelineGroups[timelineGroups.length - 1].data, eventIntervals, (item) => isEndpointConnectivity(item) && !isNetworking(item), regex)
+
+        timelineGroups.push({group: "networking", data: []})
+        createTimelineData(networkingValue, timelineGroups[timelineGroups.length - 1].data, eventIntervals, isNetworking, regex)
 
         timelineGroups.push({group: "apiserver-shutdown", data: []})
         createTimelineData(apiserverShutdownValue, timelineGroups[timelineGroups.length - 1].data, eventIntervals, isGracefulShutdownActivity, regex)
@@ -53667,7 +53757,7 @@ var _e2echartE2eChartTemplateHtml = []byte(`<html lang="en">
         createTimelineData(cloudMetricsValue, timelineGroups[timelineGroups.length - 1].data, eventIntervals, isCloudMetrics, regex)
 
         timelineGroups.push({group: "pod-logs", data: []})
-        createTimelineData(podLogs, timelineGroups[timelineGroups.length - 1].data, eventIntervals, isPodLog, regex)
+        createTimelineData(podLogs, timelineGroups[timelineGroups.length - 1].data, eventIntervals, (item) => isPodLog(item) && !isNetworking(item), regex)
 
         timelineGroups.push({group: "alerts", data: []})
         createTimelineData(alertSeverity, timelineGroups[timelineGroups.length - 1].data, eventIntervals, isAlert, regex)
@@ -53691,7 +53781,7 @@ var _e2echartE2eChartTemplateHtml = []byte(`<html lang="en">
         createTimelineData("Passed", timelineGroups[timelineGroups.length - 1].data, eventIntervals, isE2EPassed, regex)
 
         timelineGroups.push({group: "pathological-events", data: []})
-        createTimelineData(pathologicalEvents, timelineGroups[timelineGroups.length - 1].data, eventIntervals, isInterestingOrPathological, regex)
+        createTimelineData(pathologicalEvents, timelineGroups[timelineGroups.length - 1].data, eventIntervals, (item) => isInterestingOrPathological(item) && !isNetworking(item), regex)
 
         var segmentFunc = function (segment) {
             // Copy label to clipboard
@@ -53731,6 +53821,7 @@ var _e2echartE2eChartTemplateHtml = []byte(`<html lang="en">
                 'CIClusterDisruption', 'Disruption', // disruption
                 'Degraded', 'Upgradeable', 'False', 'Unknown',
                 'PodLogInfo', 'PodLogWarning', 'PodLogError',
+                'NetworkingInfo', 'NetworkingWarning', 'NetworkingError',
                 'EtcdOther', 'EtcdLeaderFound', 'EtcdLeaderLost', 'EtcdLeaderElected', 'EtcdLeaderMissing'])
             .range([
                 '#6E6E6E', '#0000ff', '#d0312d', '#ffa500', // pathological and interesting events
@@ -53742,6 +53833,7 @@ var _e2echartE2eChartTemplateHtml = []byte(`<html lang="en">
                 '#96cbff', '#d0312d', // disruption
                 '#b65049', '#32b8b6', '#ffffff', '#bbbbbb',
                 '#96cbff', '#fada5e', '#d0312d',
+                '#96cbff', '#ffa500', '#d0312d', // networking
                 '#d3d3de', '#03fc62', '#fc0303', '#fada5e', '#8c5efa']); // EtcdLeadership
         myChart.
         data(timelineGroups).
@@ -53763,6 +53855,47 @@ var _e2echartE2eChartTemplateHtml = []byte(`<html lang="en">
         setTimeout(() => { if (myChart.width() < 3100) { myChart.width(3100) }}, 1)
     }
 
+    // Render the summary tables, so the worst repeated events, alerts, and disruption can be read without the chart.
+    function renderSummaryTables() {
+        var summary
+        try {
+            summary = JSON.parse(document.getElementById("timeline-summary").textContent)
+        } catch (e) {
+            return
+        }
+        renderSummaryTable("Repeated events", ["Count", "Status", "Locator", "Reason", "Message"], summary.repeatedEvents, (e) => {
+            var status = e.known ? "known" : ""
+            if (e.pathological) {
+                status = e.known ? "pathological (known)" : "pathological (new)"
+            }
+            return [e.count, status, e.locator, e.reason, e.message]
+        })
+        renderSummaryTable("Firing alerts", ["Duration", "Intervals", "Alert", "Namespace", "Severity"], summary.alerts, (a) => {
+            return [getDurationString(Math.round(a.durationSeconds)), a.intervals, a.alert, a.namespace, a.severity]
+        })
+        renderSummaryTable("Disruption", ["Duration", "Intervals", "Backend"], summary.disruption, (d) => {
+            return [getDurationString(Math.round(d.durationSeconds)), d.intervals, d.backend]
+        })
+    }
+
+    function renderSummaryTable(title, headers, rows, rowFunc) {
+        if (!rows || rows.length == 0) {
+            return
+        }
+        var headerRow = $('<tr>')
+        headers.forEach((header) => headerRow.append($('<th>').text(header)))
+        var body = $('<tbody>')
+        rows.forEach((row) => {
+            var tableRow = $('<tr>')
+            rowFunc(row).forEach((value) => tableRow.append($('<td>').text(value)))
+            body.append(tableRow)
+        })
+        var table = $('<table class="table table-sm table-striped">').append($('<thead>').append(headerRow)).append(body)
+        $('#summary').append($('<details>').append($('<summary>').text(title + " (" + rows.length + ")")).append(table))
+    }
+
+    renderSummaryTables()
+
     renderChart(null)
 </script>
 </body>
@@ -53959,27 +54092,38 @@ var _e2echartNonSpyglassE2eChartTemplateHtml = []byte(`<html lang="en">
     <div id="search" class="form-group">
         <form>
             <div class="form-row" id="positive-selection-header-row">
-                <div class="form-group col-md-2" id="not-column">
+                <div class="form-group col-md-1" id="not-column">
                     <label for="not_1" style="display: flex; justify-content: center; align-items: center; margin-bottom:10px;">Not</label>
                 </div>
                 <div class="form-group col-md-2" id="regex-column">
                     <label for="filterInput">General RegExp</label>
                 </div>
-                <div class="form-group col-md-2" id="lodash-column">
+                <div class="form-group col-md-1" id="lodash-column">
                     <label for="lodash"><a href="https://www.geeksforgeeks.org/lodash-_-matches-method/">Lodash Match String</a></label>
                 </div>
-                <div class="form-group col-md-2" id="namespace-column">
+                <div class="form-group col-md-2" id="locator-column">
+                    <label for="locator">Locator Search</label>
+                </div>
+                <div class="form-group col-md-1" id="namespace-column">
                     <label for="namespace">Namespace</label>
                 </div>
+                <div class="form-group col-md-1" id="node-column">
+                    <label for="node">Node</label>
+                </div>
+                <div class="form-group col-md-1" id="source-column">
+                    <label for="source">Source</label>
+                </div>
                 <div class="form-group col-md-2" id="category-column">
                     <label for="category">Category</label>
                 </div>
             </div>
-            <small class="positive-filter-form form-text text-muted">For each line, if all inputs match an event it will be displayed.  If any line matches, an event will displayed.  Nots are evaluated last.  Without any filter, everything is displayed.</small>
+            <small class="positive-filter-form form-text text-muted">For each line, if all inputs match an event it will be displayed.  If any line matches, an event will displayed.  Nots are evaluated last.  Without any filter, everything is displayed.  Locator search is a case-insensitive substring match of the whole locator, namespace and node match any part of the locator key.</small>
         </form>
     </div>
 </div>
 
+<div id="summary" class="container"></div>
+
 <div id="chart"></div>
 
 <div class="modal" id="myModal" tabindex="-1" role="dialog">
@@ -54005,6 +54149,8 @@ var _e2echartNonSpyglassE2eChartTemplateHtml = []byte(`<html lang="en">
     var eventIntervals = EVENT_INTERVAL_JSON_GOES_HERE
 </script>
 
+<script type="application/json" id="timeline-summary">EVENT_INTERVAL_SUMMARY_GOES_HERE</script>
+
 <script>
     // Re-render the chart with input as a regexp. Timeout for event debouncing.
     var clearAndRenderChart =  _.debounce(function(e) {
@@ -54021,9 +54167,25 @@ var _e2echartNonSpyglassE2eChartTemplateHtml = []byte(`<html lang="en">
 ` + "`" + `
     lodashInputTemplate = ` + "`" + `
                     <input class="positive-selection-fields form-control form-control-sm" type="text" id="lodash_INPUT_NUMBER">
+` + "`" + `
+    locatorInputTemplate = ` + "`" + `
+                    <input class="positive-selection-fields form-control form-control-sm" type="text" id="locator_INPUT_NUMBER">
 ` + "`" + `
     namespaceInputTemplate = ` + "`" + `
                     <input class="positive-selection-fields form-control form-control-sm" type="text" id="namespace_INPUT_NUMBER">
+` + "`" + `
+    nodeInputTemplate = ` + "`" + `
+                    <input class="positive-selection-fields form-control form-control-sm" type="text" id="node_INPUT_NUMBER">
+` + "`" + `
+    // the sources are the ones present in this chart, so every option matches something.
+    sourceOptions = _.map(_.sortBy(_.uniq(_.map(eventIntervals.items, "source"))), function(source) {
+        return ` + "`" + `<option value="${_.escape(source)}">${_.escape(source)}</option>` + "`" + `
+    }).join("")
+    sourceInputTemplate = ` + "`" + `
+                    <select class="positive-selection-fields form-control form-control-sm" type="text" id="source_INPUT_NUMBER">
+                        <option value="" selected=true ></option>
+                        ${sourceOptions}
+                    </select>
 ` + "`" + `
     categoryInputTemplate = ` + "`" + `
                     <select class="positive-selection-fields form-control form-control-sm" type="text" id="category_INPUT_NUMBER">
@@ -54036,6 +54198,7 @@ var _e2echartNonSpyglassE2eChartTemplateHtml = []byte(`<html lang="en">
                         <option value="alerts">Alerts</option>
                         <option value="node_state">Node State</option>
                         <option value="endpoint_availability">Endpoint Availability</option>
+                        <option value="networking">Networking</option>
                         <option value="e2e_test_passed">e2e Test Passed</option>
                         <option value="e2e_test_failed">e2e Test Failed</option>
                         <option value="e2e_test_flaked">e2e Test Flaked</option>
@@ -54049,7 +54212,10 @@ var _e2echartNonSpyglassE2eChartTemplateHtml = []byte(`<html lang="en">
         $( "#not-column" ).append(notInputTemplate.replaceAll("INPUT_NUMBER", i))
         $( "#regex-column" ).append(regexInputTemplate.replaceAll("INPUT_NUMBER", i))
         $( "#lodash-column" ).append(lodashInputTemplate.replaceAll("INPUT_NUMBER", i))
+        $( "#locator-column" ).append(locatorInputTemplate.replaceAll("INPUT_NUMBER", i))
         $( "#namespace-column" ).append(namespaceInputTemplate.replaceAll("INPUT_NUMBER", i))
+        $( "#node-column" ).append(nodeInputTemplate.replaceAll("INPUT_NUMBER", i))
+        $( "#source-column" ).append(sourceInputTemplate.replaceAll("INPUT_NUMBER", i))
         $( "#category-column" ).append(categoryInputTemplate.replaceAll("INPUT_NUMBER", i))
     }
 
@@ -54070,21 +54236,58 @@ var _e2echartNonSpyglassE2eChartTemplateHtml = []byte(`<html lang="en">
 
         // Categorizing the events once on page load will save time on filtering later
         eventInterval.categories = {};
+        eventInterval.categories.networking = isNetworking(eventInterval);
         eventInterval.categories.operator_unavailable = isOperatorAvailable(eventInterval);
         eventInterval.categories.operator_progressing = isOperatorProgressing(eventInterval);
         eventInterval.categories.operator_degraded = isOperatorDegraded(eventInterval);
-        eventInterval.categories.pods = isPod(eventInterval);
-        eventInterval.categories.pod_logs = isPodLog(eventInterval);
-        eventInterval.categories.interesting_events = isInterestingOrPathological(eventInterval);
+        eventInterval.categories.pods = isPod(eventInterval) && !eventInterval.categories.networking;
+        eventInterval.categories.pod_logs = isPodLog(eventInterval) && !eventInterval.categories.networking;
+        eventInterval.categories.interesting_events = isInterestingOrPathological(eventInterval) && !eventInterval.categories.networking;
         eventInterval.categories.alerts = isAlert(eventInterval);
         eventInterval.categories.node_state = isNodeState(eventInterval);
         eventInterval.categories.e2e_test_failed = isE2EFailed(eventInterval);
         eventInterval.categories.e2e_test_flaked = isE2EFlaked(eventInterval);
         eventInterval.categories.e2e_test_passed = isE2EPassed(eventInterval);
-        eventInterval.categories.endpoint_availability = isEndpointConnectivity(eventInterval);
+        eventInterval.categories.endpoint_availability = isEndpointConnectivity(eventInterval) && !eventInterval.categories.networking;
         eventInterval.categories.uncategorized = !_.some(eventInterval.categories); // will save time later during filtering and re-rendering since we don't render any uncategorized events
     });
 
+    // Networking groups the cluster network intervals that otherwise get lost across the pod and event rows: OVN/SDN
+    // and CNI failures, pod sandbox creation, and the pod network connectivity probes.
+    function isNetworking(eventInterval) {
+        const networkingNamespaces = ["openshift-ovn-kubernetes", "openshift-sdn", "openshift-multus", "openshift-network-operator", "openshift-network-diagnostics", "openshift-network-node-identity"]
+        const networkingReasons = ["FailedCreatePodSandBox", "NetworkNotReady", "ErrorAddingLogicalPort", "ErrorAddingResource", "ErrorUpdatingResource"]
+        const networkingMessageRegex = /\bCNI\b|pod sandbox|network plugin|multus|ovn-kubernetes|openshift-sdn/i
+
+        if (eventInterval.source === "OVSVswitchdLog" || eventInterval.source === "NetworkMangerLog") {
+            return true
+        }
+        if (eventInterval.source === "Disruption") {
+            // the poller deployments prefix their backends with where they connect from and to.
+            const backend = eventInterval.locator.keys["backend-disruption-name"] || ""
+            return (backend.startsWith("pod-to-") || backend.startsWith("host-to-")) &&
+                (eventInterval.message.reason === "DisruptionBegan" || eventInterval.message.reason === "DisruptionSamplerOutageBegan")
+        }
+        if (eventInterval.source !== "KubeEvent" && eventInterval.source !== "PodLog") {
+            return false
+        }
+        if (networkingNamespaces.includes(eventInterval.locator.keys["namespace"])) {
+            return true
+        }
+        if (networkingReasons.includes(eventInterval.message.reason)) {
+            return true
+        }
+        return networkingMessageRegex.test(eventInterval.message.humanMessage || "")
+    }
+
+    function networkingValue(item) {
+        if (item.source === "Disruption") {
+            return disruptionValue(item)
+        }
+        const sub = item.message.reason ? ` + "`" + ` (${item.message.reason})` + "`" + ` : ` + "`" + ` (${item.source})` + "`" + `
+        return [buildLocatorDisplayString(item.locator), sub, "Networking" + item.level]
+    }
+
     function isOperatorAvailable(eventInterval) {
         return eventInterval.locator.type === "ClusterOperator" &&
             eventInterval.message.annotations["condition"] === "Available" &&
@@ -54494,6 +54697,36 @@ var _e2echartNonSpyglassE2eChartTemplateHtml = []byte(`<html lang="en">
         }
     }
 
+    // matchesSelectionRow returns true when every input set on the row matches the interval.
+    function matchesSelectionRow(selectionRow, eventInterval) {
+        var locatorKeys = eventInterval.locator.keys || {}
+        if (selectionRow.regexStr.length > 0 && !selectionRow.regex.test(buildLocatorDisplayString(eventInterval.locator))) {
+            return false
+        }
+        if (selectionRow.locatorSearch.length > 0 && !buildLocatorDisplayString(eventInterval.locator).toLowerCase().includes(selectionRow.locatorSearch)) {
+            return false
+        }
+        if (selectionRow.namespace.length > 0 && !(locatorKeys.namespace && locatorKeys.namespace.includes(selectionRow.namespace))) {
+            return false
+        }
+        if (selectionRow.node.length > 0 && !(locatorKeys.node && locatorKeys.node.includes(selectionRow.node))) {
+            return false
+        }
+        if (selectionRow.source.length > 0 && eventInterval.source !== selectionRow.source) {
+            return false
+        }
+        if (selectionRow.category.length > 0 && !eventInterval.categories[selectionRow.category]) {
+            return false
+        }
+        if (selectionRow.lodash.length > 0) {
+            var lodashMatches = _.filter([eventInterval], _.matches(JSON.parse(selectionRow.lodash)));
+            if (lodashMatches.length == 0) {
+                return false
+            }
+        }
+        return true
+    }
+
     function renderChart() {
         var loc = window.location.href;
 
@@ -54505,8 +54738,12 @@ var _e2echartNonSpyglassE2eChartTemplateHtml = []byte(`<html lang="en">
             var category = $("#category_"+i).val()
             var lodash = $("#lodash_"+i).val()
             var ns = $("#namespace_"+i).val()
+            var node = $("#node_"+i).val()
+            var source = $("#source_"+i).val()
+            var locatorSearch = $("#locator_"+i).val().toLowerCase()
             var regexStr = $("#filterInput_"+i).val()
-            var currIsSet = (regexStr.length != 0 || lodash.length != 0 ||  ns.length != 0 || category.length != 0)
+            var currIsSet = (regexStr.length != 0 || lodash.length != 0 ||  ns.length != 0 || category.length != 0 ||
+                node.length != 0 || source.length != 0 || locatorSearch.length != 0)
             console.log("value of not " + not)
             if (not){
                 negativeSelectionRows.set(i,
@@ -54516,6 +54753,9 @@ var _e2echartNonSpyglassE2eChartTemplateHtml = []byte(`<html lang="en">
                         regexStr: regexStr,
                         regex: new RegExp(regexStr),
                         namespace: ns,
+                        node: node,
+                        source: source,
+                        locatorSearch: locatorSearch,
                         category: category
                     })
             } else{
@@ -54526,6 +54766,9 @@ var _e2echartNonSpyglassE2eChartTemplateHtml = []byte(`<html lang="en">
                         regexStr: regexStr,
                         regex: new RegExp(regexStr),
                         namespace: ns,
+                        node: node,
+                        source: source,
+                        locatorSearch: locatorSearch,
                         category: category
                     })
             }
@@ -54552,15 +54795,7 @@ var _e2echartNonSpyglassE2eChartTemplateHtml = []byte(`<html lang="en">
 
                 for (let [key, positiveSelectionRow] of positiveSelectionRows) {
                     if (positiveSelectionRow.isSet){
-                        matchRegex = positiveSelectionRow.regexStr.length == 0 || positiveSelectionRow.regex.test(buildLocatorDisplayString(eventInterval.locator))
-                        matchNS = positiveSelectionRow.namespace.length == 0 || eventInterval.locator.keys.namespace && eventInterval.locator.namespace.includes(positiveSelectionRow.namespace)
-                        matchCategory = positiveSelectionRow.category.length == 0 || eventInterval.categories[positiveSelectionRow.category]
-                        matchLodash = true
-                        if (positiveSelectionRow.lodash.length > 0){
-                            lodashMatches = _.filter([eventInterval], _.matches(JSON.parse(positiveSelectionRow.lodash)));
-                            matchLodash = (lodashMatches.length > 0)
-                        }
-                        matchesPositive = matchRegex && matchLodash && matchNS && matchCategory
+                        matchesPositive = matchesSelectionRow(positiveSelectionRow, eventInterval)
 
                         if (matchesPositive) {
                             console.log("matched positive " + negativeSelectionRows.size)
@@ -54568,15 +54803,7 @@ var _e2echartNonSpyglassE2eChartTemplateHtml = []byte(`<html lang="en">
                             for (let [key, negativeSelectionRow] of negativeSelectionRows) {
                                 if (negativeSelectionRow.isSet){
                                     console.log("checking negative")
-                                    matchRegex = negativeSelectionRow.regexStr.length == 0 || negativeSelectionRow.regex.test(buildLocatorDisplayString(eventInterval.locator))
-                                    matchNS = negativeSelectionRow.namespace.length == 0 || eventInterval.locator.keys.namespace && eventInterval.locator.keys.namespace.includes(negativeSelectionRow.namespace)
-                                    matchCategory = negativeSelectionRow.category.length == 0 || eventInterval.categories[negativeSelectionRow.category]
-                                    matchLodash = true
-                                    if (negativeSelectionRow.lodash.length > 0){
-                                        lodashMatches = _.filter([eventInterval], _.matches(JSON.parse(negativeSelectionRow.lodash)));
-                                        matchLodash = (lodashMatches.length > 0)
-                                    }
-                                    matchesNegative = matchRegex && matchLodash && matchNS && matchCategory
+                                    matchesNegative = matchesSelectionRow(negativeSelectionRow, eventInterval)
 
                                     if (matchesNegative) {
                                         exclude = true
@@ -54618,6 +54845,9 @@ var _e2echartNonSpyglassE2eChartTemplateHtml = []byte(`<html lang="en">
         timelineGroups.push({group: "disruption", data: []});
         createTimelineData(disruptionValue, timelineGroups[timelineGroups.length - 1].data, filteredEvents, "endpoint_availability");
 
+        timelineGroups.push({group: "networking", data: []});
+        createTimelineData(networkingValue, timelineGroups[timelineGroups.length - 1].data, filteredEvents, "networking");
+
         timelineGroups.push({group: "pods", data: []});
         createTimelineData(podStateValue, timelineGroups[timelineGroups.length - 1].data, filteredEvents, "pods");
         timelineGroups[timelineGroups.length - 1].data.sort(function (e1 ,e2){
@@ -54688,7 +54918,8 @@ var _e2echartNonSpyglassE2eChartTemplateHtml = []byte(`<html lang="en">
                 'PodCreated', 'PodScheduled', 'PodTerminating','ContainerWait', 'ContainerStart', 'ContainerNotReady', 'ContainerReady', 'ContainerReadinessFailed', 'ContainerReadinessErrored',  'StartupProbeFailed', // pods
                 'CIClusterDisruption', 'Disruption', // disruption
                 'Degraded', 'Upgradeable', 'False', 'Unknown',
-                'PodLogInfo', 'PodLogWarning', 'PodLogError'])
+                'PodLogInfo', 'PodLogWarning', 'PodLogError',
+                'NetworkingInfo', 'NetworkingWarning', 'NetworkingError'])
             .range([
                 '#6E6E6E', '#0000ff', '#d0312d', // pathological and interesting events
                 '#fada5e','#fada5e','#ffa500', '#d0312d',  // alerts
@@ -54698,7 +54929,8 @@ var _e2echartNonSpyglassE2eChartTemplateHtml = []byte(`<html lang="en">
                 '#96cbff', '#1e7bd9', '#ffa500', '#ca8dfd', '#9300ff', '#fada5e','#3cb043', '#d0312d', '#d0312d', '#c90076', // pods
                 '#96cbff', '#d0312d', // disruption
                 '#b65049', '#32b8b6', '#ffffff', '#bbbbbb',
-                '#96cbff', '#fada5e', '#d0312d']);
+                '#96cbff', '#fada5e', '#d0312d',
+                '#96cbff', '#ffa500', '#d0312d']); // networking
         myChart.
         data(timelineGroups).
         useUtc(true).
@@ -54719,6 +54951,47 @@ var _e2echartNonSpyglassE2eChartTemplateHtml = []byte(`<html lang="en">
         setTimeout(() => { if (myChart.width() < 3100) { myChart.width(3100) }}, 1)
     }
 
+    // Render the summary tables, so the worst repeated events, alerts, and disruption can be read without the chart.
+    function renderSummaryTables() {
+        var summary
+        try {
+            summary = JSON.parse(document.getElementById("timeline-summary").textContent)
+        } catch (e) {
+            return
+        }
+        renderSummaryTable("Repeated events", ["Count", "Status", "Locator", "Reason", "Message"], summary.repeatedEvents, (e) => {
+            var status = e.known ? "known" : ""
+            if (e.pathological) {
+                status = e.known ? "pathological (known)" : "pathological (new)"
+            }
+            return [e.count, status, e.locator, e.reason, e.message]
+        })
+        renderSummaryTable("Firing alerts", ["Duration", "Intervals", "Alert", "Namespace", "Severity"], summary.alerts, (a) => {
+            return [getDurationString(Math.round(a.durationSeconds)), a.intervals, a.alert, a.namespace, a.severity]
+        })
+        renderSummaryTable("Disruption", ["Duration", "Intervals", "Backend"], summary.disruption, (d) => {
+            return [getDurationString(Math.round(d.durationSeconds)), d.intervals, d.backend]
+        })
+    }
+
+    function renderSummaryTable(title, headers, rows, rowFunc) {
+        if (!rows || rows.length == 0) {
+            return
+        }
+        var headerRow = $('<tr>')
+        headers.forEach((header) => headerRow.append($('<th>').text(header)))
+        var body = $('<tbody>')
+        rows.forEach((row) => {
+            var tableRow = $('<tr>')
+            rowFunc(row).forEach((value) => tableRow.append($('<td>').text(value)))
+            body.append(tableRow)
+        })
+        var table = $('<table class="table table-sm table-striped">').append($('<thead>').append(headerRow)).append(body)
+        $('#summary').append($('<details>').append($('<summary>').text(title + " (" + rows.length + ")")).append(table))
+    }
+
+    renderSummaryTables()
+
     renderChart(null)
 </script>
 </body>
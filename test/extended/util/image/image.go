@@ -96,6 +96,9 @@ func InitializeImages(repo string) {
 	initialized = true
 	fromRepository = repo
 	images = GetMappedImages(allowedImages, repo)
+	for original, pullSpec := range images {
+		images[original] = mirrors.Mirror(pullSpec)
+	}
 }
 
 func GetGlobalFromRepository() string {
@@ -276,7 +279,7 @@ func GetMappedImages(originalImages map[string]k8simage.ImageID, repo string) ma
 
 func GetPullSpecFor(image string) (string, bool) {
 	if spec, found := availablePullSpecs[image]; found {
-		return spec, true
+		return mirrors.Mirror(spec), true
 	}
 	return "", false
 }
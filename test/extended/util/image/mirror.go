@@ -0,0 +1,95 @@
+package image
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// MirrorConfigEnvVar names the file of the registry mirrors, read by every openshift-tests process so that the tests
+// run as child processes pull from the same mirrors.
+const MirrorConfigEnvVar = "TEST_IMAGE_MIRROR_CONFIG"
+
+// MirrorConfig redirects the test images to mirror registries, for clusters that cannot reach the public ones.
+//
+//	mirrors:
+//	  quay.io: mirror.example.com:5000/quay.io
+//	  registry.k8s.io/e2e-test-images: mirror.example.com:5000/e2e-test-images
+type MirrorConfig struct {
+	// Mirrors maps registries or repositories to the location they are mirrored to.  The longest match wins.
+	Mirrors map[string]string `json:"mirrors"`
+}
+
+var mirrors = &MirrorConfig{}
+
+// LoadMirrorConfig reads the mirror configuration from the file, an empty path is no mirrors.
+func LoadMirrorConfig(path string) (*MirrorConfig, error) {
+	config := &MirrorConfig{}
+	if len(path) == 0 {
+		return config, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read image mirror config: %w", err)
+	}
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return nil, fmt.Errorf("unable to parse image mirror config %s: %w", path, err)
+	}
+	for source, mirror := range config.Mirrors {
+		if len(source) == 0 || len(mirror) == 0 || hasTagOrDigest(source) || hasTagOrDigest(mirror) {
+			return nil, fmt.Errorf("image mirror %q to %q must map a registry or repository to another, without tag or digest", source, mirror)
+		}
+	}
+	return config, nil
+}
+
+// hasTagOrDigest returns true if the location names an image rather than a registry or repository.  Only the
+// registry, before the first "/", may have a ":" for its port.
+func hasTagOrDigest(location string) bool {
+	if strings.Contains(location, "@") {
+		return true
+	}
+	if i := strings.Index(location, "/"); i >= 0 {
+		return strings.Contains(location[i+1:], ":")
+	}
+	return false
+}
+
+// Mirror returns where the pull spec is mirrored to, or the pull spec itself when no mirror covers it.
+func (c *MirrorConfig) Mirror(pullSpec string) string {
+	sources := make([]string, 0, len(c.Mirrors))
+	for source := range c.Mirrors {
+		sources = append(sources, source)
+	}
+	// longest first, so that a repository mirror wins over the mirror of its registry.
+	sort.Slice(sources, func(i, j int) bool { return len(sources[i]) > len(sources[j]) })
+	for _, source := range sources {
+		if !strings.HasPrefix(pullSpec, source) {
+			continue
+		}
+		rest := pullSpec[len(source):]
+		if len(rest) == 0 || strings.ContainsAny(rest[:1], "/:@") {
+			return c.Mirrors[source] + rest
+		}
+	}
+	return pullSpec
+}
+
+// InitializeMirrors sets the mirrors of the images of this process, it must precede InitializeImages.
+func InitializeMirrors(config *MirrorConfig) {
+	initializationLock.Lock()
+	defer initializationLock.Unlock()
+
+	if initialized {
+		panic("attempt to initialize image mirrors after the images")
+	}
+	mirrors = config
+}
+
+// MirrorFor returns where the pull spec is mirrored to, for images not resolved through LocationFor.
+func MirrorFor(pullSpec string) string {
+	return mirrors.Mirror(pullSpec)
+}
@@ -0,0 +1,60 @@
+package image
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMirror(t *testing.T) {
+	config := &MirrorConfig{Mirrors: map[string]string{
+		"quay.io":                         "mirror.example.com:5000/quay.io",
+		"quay.io/openshifttest":           "mirror.example.com:5000/openshifttest",
+		"registry.k8s.io/e2e-test-images": "mirror.example.com:5000/e2e",
+	}}
+	tests := []struct {
+		pullSpec string
+		expected string
+	}{
+		{pullSpec: "quay.io/redhat-developer/nfs-server:1.1", expected: "mirror.example.com:5000/quay.io/redhat-developer/nfs-server:1.1"},
+		{pullSpec: "quay.io/openshifttest/ldap:1.2", expected: "mirror.example.com:5000/openshifttest/ldap:1.2"},
+		{pullSpec: "registry.k8s.io/e2e-test-images/agnhost:2.47", expected: "mirror.example.com:5000/e2e/agnhost:2.47"},
+		{pullSpec: "registry.k8s.io/pause:3.9", expected: "registry.k8s.io/pause:3.9"},
+		{pullSpec: "quay.io.example.com/image:1", expected: "quay.io.example.com/image:1"},
+		{pullSpec: "image-registry.openshift-image-registry.svc:5000/openshift/cli:latest", expected: "image-registry.openshift-image-registry.svc:5000/openshift/cli:latest"},
+	}
+	for _, test := range tests {
+		if actual := config.Mirror(test.pullSpec); actual != test.expected {
+			t.Errorf("expected %s to be mirrored to %s, got %s", test.pullSpec, test.expected, actual)
+		}
+	}
+}
+
+func TestLoadMirrorConfig(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	config, err := LoadMirrorConfig(write("valid.yaml", "mirrors:\n  quay.io: mirror.example.com:5000/quay.io\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Mirrors["quay.io"] != "mirror.example.com:5000/quay.io" {
+		t.Errorf("unexpected mirrors %v", config.Mirrors)
+	}
+
+	if _, err := LoadMirrorConfig(write("tag.yaml", "mirrors:\n  quay.io/openshifttest/ldap:1.2: mirror.example.com/ldap\n")); err == nil {
+		t.Error("expected a mirror of a tagged image to be rejected")
+	}
+	if _, err := LoadMirrorConfig(write("unknown.yaml", "registries:\n  quay.io: mirror.example.com\n")); err == nil {
+		t.Error("expected unknown fields to be rejected")
+	}
+	if config, err := LoadMirrorConfig(""); err != nil || len(config.Mirrors) != 0 {
+		t.Errorf("expected no mirrors without a config, got %v %v", config, err)
+	}
+}